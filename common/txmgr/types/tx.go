@@ -111,6 +111,10 @@ type TxMeta[ADDR types.Hashable, TX_HASH types.Hashable] struct {
 	MessageIDs []string `json:"MessageIDs,omitempty"`
 	// SeqNumbers is used by CCIP for tx to committed sequence numbers correlation in logs
 	SeqNumbers []uint64 `json:"SeqNumbers,omitempty"`
+
+	// TraceContext is the W3C trace context of the pipeline task which created
+	// the tx, used to trace the tx through to its confirmation
+	TraceContext map[string]string `json:"TraceContext,omitempty"`
 }
 
 type TxAttempt[
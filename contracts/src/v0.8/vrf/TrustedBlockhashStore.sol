@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: MIT
+pragma solidity 0.8.6;
+
+import "../ChainSpecificUtil.sol";
+import "../ConfirmedOwner.sol";
+
+/**
+ * @title TrustedBlockhashStore
+ * @notice The TrustedBlockhashStore is a BlockhashStore that additionally lets whitelisted
+ *   callers write many blockhashes in a single transaction, without the 256 block limit of
+ *   the BLOCKHASH opcode. To guard against stale or forked inputs, each batch must reference
+ *   a recent block whose hash is still verifiable on-chain.
+ */
+contract TrustedBlockhashStore is ConfirmedOwner {
+  error NotInWhitelist();
+  error InvalidTrustedBlockhashes();
+  error InvalidRecentBlockhash();
+
+  mapping(uint256 => bytes32) internal s_blockhashes;
+  mapping(address => bool) public s_whitelistStatus;
+  address[] public s_whitelist;
+
+  constructor(address[] memory whitelist) ConfirmedOwner(msg.sender) {
+    setWhitelist(whitelist);
+  }
+
+  /**
+   * @notice sets the whitelist of addresses that can store trusted blockhashes
+   * @param whitelist the whitelist of addresses that can store trusted blockhashes
+   */
+  function setWhitelist(address[] memory whitelist) public onlyOwner {
+    address[] memory previousWhitelist = s_whitelist;
+    s_whitelist = whitelist;
+
+    // Unset whitelist status for all addresses in the previous whitelist,
+    // and set whitelist status for all addresses in the new whitelist.
+    for (uint256 i = 0; i < previousWhitelist.length; i++) {
+      s_whitelistStatus[previousWhitelist[i]] = false;
+    }
+    for (uint256 i = 0; i < whitelist.length; i++) {
+      s_whitelistStatus[whitelist[i]] = true;
+    }
+  }
+
+  /**
+   * @notice stores a list of trusted blockhashes after validating that a recent blockhash is correct.
+   * @param blockNums the block numbers whose blockhashes should be stored
+   * @param blockhashes the blockhashes of blockNums, in the same order
+   * @param recentBlockNumber a recent block number whose hash is available through BLOCKHASH
+   * @param recentBlockhash the blockhash of recentBlockNumber
+   */
+  function storeTrusted(
+    uint256[] calldata blockNums,
+    bytes32[] calldata blockhashes,
+    uint256 recentBlockNumber,
+    bytes32 recentBlockhash
+  ) external {
+    if (!s_whitelistStatus[msg.sender]) {
+      revert NotInWhitelist();
+    }
+    if (blockNums.length != blockhashes.length) {
+      revert InvalidTrustedBlockhashes();
+    }
+    // Reject the batch if the caller is on a different fork than the chain, since the
+    // provided blockhashes can then not be trusted either.
+    bytes32 onChainHash = ChainSpecificUtil.getBlockhash(uint64(recentBlockNumber));
+    if (onChainHash != recentBlockhash) {
+      revert InvalidRecentBlockhash();
+    }
+
+    for (uint256 i = 0; i < blockNums.length; i++) {
+      s_blockhashes[blockNums[i]] = blockhashes[i];
+    }
+  }
+
+  /**
+   * @notice stores blockhash of a given block, assuming it is available through BLOCKHASH
+   * @param n the number of the block whose blockhash should be stored
+   */
+  function store(uint256 n) public {
+    bytes32 h = ChainSpecificUtil.getBlockhash(uint64(n));
+    require(h != 0x0, "blockhash(n) failed");
+    s_blockhashes[n] = h;
+  }
+
+  /**
+   * @notice stores blockhash of the earliest block still available through BLOCKHASH.
+   */
+  function storeEarliest() external {
+    store(ChainSpecificUtil.getBlockNumber() - 256);
+  }
+
+  /**
+   * @notice stores blockhash after verifying blockheader of child/subsequent block
+   * @param n the number of the block whose blockhash should be stored
+   * @param header the rlp-encoded blockheader of block n+1. We verify its correctness by checking
+   *   that it hashes to a stored blockhash, and then extract parentHash to get the n-th blockhash.
+   */
+  function storeVerifyHeader(uint256 n, bytes memory header) public {
+    require(keccak256(header) == s_blockhashes[n + 1], "header has unknown blockhash");
+
+    // At this point, we know that header is the correct blockheader for block n+1.
+    // The PARENTHASH is always at offset 4 of the rlp-encoded block header, see
+    // BlockhashStore.storeVerifyHeader for details.
+    bytes32 parentHash;
+    assembly {
+      parentHash := mload(add(header, 36)) // 36 = 32 byte offset for length prefix of ABI-encoded array
+      //    +  4 byte offset of PARENTHASH (see above)
+    }
+
+    s_blockhashes[n] = parentHash;
+  }
+
+  /**
+   * @notice gets a blockhash from the store. If no hash is known, this function reverts.
+   * @param n the number of the block whose blockhash should be returned
+   */
+  function getBlockhash(uint256 n) external view returns (bytes32) {
+    bytes32 h = s_blockhashes[n];
+    require(h != 0x0, "blockhash not found in store");
+    return h;
+  }
+}
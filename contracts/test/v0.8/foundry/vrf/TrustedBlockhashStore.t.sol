@@ -0,0 +1,109 @@
+pragma solidity 0.8.6;
+
+import "../BaseTest.t.sol";
+import {TrustedBlockhashStore} from "../../../../src/v0.8/vrf/TrustedBlockhashStore.sol";
+
+contract TrustedBlockhashStoreTest is BaseTest {
+  address internal constant STRANGER = address(0xdead);
+  address internal constant WHITELISTED = address(0xbeef);
+
+  TrustedBlockhashStore internal s_bhs;
+
+  function setUp() public override {
+    BaseTest.setUp();
+    address[] memory whitelist = new address[](1);
+    whitelist[0] = WHITELISTED;
+    s_bhs = new TrustedBlockhashStore(whitelist);
+  }
+
+  function testStoreTrusted() public {
+    vm.roll(1000);
+    uint256 recentBlockNumber = block.number - 1;
+    bytes32 recentBlockhash = blockhash(recentBlockNumber);
+
+    uint256[] memory blockNums = new uint256[](2);
+    blockNums[0] = 10;
+    blockNums[1] = 20;
+    bytes32[] memory blockhashes = new bytes32[](2);
+    blockhashes[0] = keccak256("block 10");
+    blockhashes[1] = keccak256("block 20");
+
+    changePrank(WHITELISTED);
+    s_bhs.storeTrusted(blockNums, blockhashes, recentBlockNumber, recentBlockhash);
+
+    assertEq(s_bhs.getBlockhash(10), blockhashes[0]);
+    assertEq(s_bhs.getBlockhash(20), blockhashes[1]);
+  }
+
+  function testStoreTrustedNotInWhitelist() public {
+    vm.roll(1000);
+    changePrank(STRANGER);
+    vm.expectRevert(TrustedBlockhashStore.NotInWhitelist.selector);
+    s_bhs.storeTrusted(new uint256[](0), new bytes32[](0), block.number - 1, blockhash(block.number - 1));
+  }
+
+  function testStoreTrustedMismatchedLengths() public {
+    vm.roll(1000);
+    changePrank(WHITELISTED);
+    vm.expectRevert(TrustedBlockhashStore.InvalidTrustedBlockhashes.selector);
+    s_bhs.storeTrusted(new uint256[](2), new bytes32[](1), block.number - 1, blockhash(block.number - 1));
+  }
+
+  function testStoreTrustedInvalidRecentBlockhash() public {
+    vm.roll(1000);
+    uint256 recentBlockNumber = block.number - 1;
+    bytes32 wrongBlockhash = bytes32(uint256(blockhash(recentBlockNumber)) ^ 1);
+
+    uint256[] memory blockNums = new uint256[](1);
+    blockNums[0] = 10;
+    bytes32[] memory blockhashes = new bytes32[](1);
+    blockhashes[0] = keccak256("block 10");
+
+    changePrank(WHITELISTED);
+    vm.expectRevert(TrustedBlockhashStore.InvalidRecentBlockhash.selector);
+    s_bhs.storeTrusted(blockNums, blockhashes, recentBlockNumber, wrongBlockhash);
+  }
+
+  function testSetWhitelist() public {
+    address[] memory whitelist = new address[](1);
+    whitelist[0] = STRANGER;
+    s_bhs.setWhitelist(whitelist);
+
+    assertTrue(s_bhs.s_whitelistStatus(STRANGER));
+    assertTrue(!s_bhs.s_whitelistStatus(WHITELISTED));
+    assertEq(s_bhs.s_whitelist(0), STRANGER);
+  }
+
+  function testSetWhitelistOnlyOwner() public {
+    changePrank(STRANGER);
+    vm.expectRevert("Only callable by owner");
+    s_bhs.setWhitelist(new address[](0));
+  }
+
+  function testStoreVerifyHeader() public {
+    vm.roll(1000);
+    bytes32 parentHash = keccak256("block 99");
+    // The parent hash of an rlp-encoded header starts at byte 4.
+    bytes memory header = abi.encodePacked(bytes4(0xf9021aa0), parentHash, keccak256("rest of header"));
+
+    uint256[] memory blockNums = new uint256[](1);
+    blockNums[0] = 100;
+    bytes32[] memory blockhashes = new bytes32[](1);
+    blockhashes[0] = keccak256(header);
+    changePrank(WHITELISTED);
+    s_bhs.storeTrusted(blockNums, blockhashes, block.number - 1, blockhash(block.number - 1));
+
+    s_bhs.storeVerifyHeader(99, header);
+    assertEq(s_bhs.getBlockhash(99), parentHash);
+  }
+
+  function testStoreVerifyHeaderUnknownBlockhash() public {
+    vm.expectRevert("header has unknown blockhash");
+    s_bhs.storeVerifyHeader(99, abi.encodePacked(keccak256("unknown header")));
+  }
+
+  function testGetBlockhashNotFound() public {
+    vm.expectRevert("blockhash not found in store");
+    s_bhs.getBlockhash(1);
+  }
+}
@@ -1566,6 +1566,20 @@ func (_m *ChainScopedConfig) FeatureFeedsManager() bool {
 	return r0
 }
 
+// FeatureGraphQLSubscriptions provides a mock function with given fields:
+func (_m *ChainScopedConfig) FeatureGraphQLSubscriptions() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // FeatureLogPoller provides a mock function with given fields:
 func (_m *ChainScopedConfig) FeatureLogPoller() bool {
 	ret := _m.Called()
@@ -1720,6 +1734,20 @@ func (_m *ChainScopedConfig) JSONConsole() bool {
 	return r0
 }
 
+// JobPipelineErroredReaperThresholdForJobType provides a mock function with given fields: jobType
+func (_m *ChainScopedConfig) JobPipelineErroredReaperThresholdForJobType(jobType string) time.Duration {
+	ret := _m.Called(jobType)
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func(string) time.Duration); ok {
+		r0 = rf(jobType)
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
 // JobPipelineMaxRunDuration provides a mock function with given fields:
 func (_m *ChainScopedConfig) JobPipelineMaxRunDuration() time.Duration {
 	ret := _m.Called()
@@ -1748,6 +1776,34 @@ func (_m *ChainScopedConfig) JobPipelineMaxSuccessfulRuns() uint64 {
 	return r0
 }
 
+// JobPipelineMaxSuccessfulRunsForJobType provides a mock function with given fields: jobType
+func (_m *ChainScopedConfig) JobPipelineMaxSuccessfulRunsForJobType(jobType string) uint64 {
+	ret := _m.Called(jobType)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(string) uint64); ok {
+		r0 = rf(jobType)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	return r0
+}
+
+// JobPipelineReaperArchiveDir provides a mock function with given fields:
+func (_m *ChainScopedConfig) JobPipelineReaperArchiveDir() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // JobPipelineReaperInterval provides a mock function with given fields:
 func (_m *ChainScopedConfig) JobPipelineReaperInterval() time.Duration {
 	ret := _m.Called()
@@ -1776,6 +1832,20 @@ func (_m *ChainScopedConfig) JobPipelineReaperThreshold() time.Duration {
 	return r0
 }
 
+// JobPipelineReaperThresholdForJobType provides a mock function with given fields: jobType
+func (_m *ChainScopedConfig) JobPipelineReaperThresholdForJobType(jobType string) time.Duration {
+	ret := _m.Called(jobType)
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func(string) time.Duration); ok {
+		r0 = rf(jobType)
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
 // JobPipelineResultWriteQueueDepth provides a mock function with given fields:
 func (_m *ChainScopedConfig) JobPipelineResultWriteQueueDepth() uint64 {
 	ret := _m.Called()
@@ -1790,6 +1860,36 @@ func (_m *ChainScopedConfig) JobPipelineResultWriteQueueDepth() uint64 {
 	return r0
 }
 
+// JobPipelineRetentionJobTypes provides a mock function with given fields:
+func (_m *ChainScopedConfig) JobPipelineRetentionJobTypes() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// JobPipelineSuccessfulTaskRunsSampleRateForJobType provides a mock function with given fields: jobType
+func (_m *ChainScopedConfig) JobPipelineSuccessfulTaskRunsSampleRateForJobType(jobType string) uint32 {
+	ret := _m.Called(jobType)
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func(string) uint32); ok {
+		r0 = rf(jobType)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
 // KeeperBaseFeeBufferPercent provides a mock function with given fields:
 func (_m *ChainScopedConfig) KeeperBaseFeeBufferPercent() uint16 {
 	ret := _m.Called()
@@ -2643,6 +2743,184 @@ func (_m *ChainScopedConfig) OCRTransmitterAddress() (ethkey.EIP55Address, error
 	return r0, r1
 }
 
+// OIDCAdminGroups provides a mock function with given fields:
+func (_m *ChainScopedConfig) OIDCAdminGroups() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// OIDCClientID provides a mock function with given fields:
+func (_m *ChainScopedConfig) OIDCClientID() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// OIDCClientSecret provides a mock function with given fields:
+func (_m *ChainScopedConfig) OIDCClientSecret() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// OIDCDisableLocalPasswords provides a mock function with given fields:
+func (_m *ChainScopedConfig) OIDCDisableLocalPasswords() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// OIDCEditGroups provides a mock function with given fields:
+func (_m *ChainScopedConfig) OIDCEditGroups() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// OIDCEnabled provides a mock function with given fields:
+func (_m *ChainScopedConfig) OIDCEnabled() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// OIDCGroupsClaim provides a mock function with given fields:
+func (_m *ChainScopedConfig) OIDCGroupsClaim() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// OIDCIssuerURL provides a mock function with given fields:
+func (_m *ChainScopedConfig) OIDCIssuerURL() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// OIDCRedirectURL provides a mock function with given fields:
+func (_m *ChainScopedConfig) OIDCRedirectURL() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// OIDCRunGroups provides a mock function with given fields:
+func (_m *ChainScopedConfig) OIDCRunGroups() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// OIDCScopes provides a mock function with given fields:
+func (_m *ChainScopedConfig) OIDCScopes() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// OIDCViewGroups provides a mock function with given fields:
+func (_m *ChainScopedConfig) OIDCViewGroups() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
 // ORMMaxIdleConns provides a mock function with given fields:
 func (_m *ChainScopedConfig) ORMMaxIdleConns() int {
 	ret := _m.Called()
@@ -3433,6 +3711,76 @@ func (_m *ChainScopedConfig) TelemetryIngressBufferSize() uint {
 	return r0
 }
 
+// TelemetryIngressLocalDir provides a mock function with given fields:
+func (_m *ChainScopedConfig) TelemetryIngressLocalDir() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// TelemetryIngressLocalMaxAge provides a mock function with given fields:
+func (_m *ChainScopedConfig) TelemetryIngressLocalMaxAge() int64 {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// TelemetryIngressLocalMaxBackups provides a mock function with given fields:
+func (_m *ChainScopedConfig) TelemetryIngressLocalMaxBackups() int64 {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// TelemetryIngressLocalMaxSize provides a mock function with given fields:
+func (_m *ChainScopedConfig) TelemetryIngressLocalMaxSize() utils.FileSize {
+	ret := _m.Called()
+
+	var r0 utils.FileSize
+	if rf, ok := ret.Get(0).(func() utils.FileSize); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(utils.FileSize)
+	}
+
+	return r0
+}
+
+// TelemetryIngressLocalMode provides a mock function with given fields:
+func (_m *ChainScopedConfig) TelemetryIngressLocalMode() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // TelemetryIngressLogging provides a mock function with given fields:
 func (_m *ChainScopedConfig) TelemetryIngressLogging() bool {
 	ret := _m.Called()
@@ -3547,6 +3895,76 @@ func (_m *ChainScopedConfig) TelemetryIngressUseBatchSend() bool {
 	return r0
 }
 
+// TracingCollectorTarget provides a mock function with given fields:
+func (_m *ChainScopedConfig) TracingCollectorTarget() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// TracingEnabled provides a mock function with given fields:
+func (_m *ChainScopedConfig) TracingEnabled() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// TracingMode provides a mock function with given fields:
+func (_m *ChainScopedConfig) TracingMode() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// TracingNodeID provides a mock function with given fields:
+func (_m *ChainScopedConfig) TracingNodeID() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// TracingSamplingRatio provides a mock function with given fields:
+func (_m *ChainScopedConfig) TracingSamplingRatio() float64 {
+	ret := _m.Called()
+
+	var r0 float64
+	if rf, ok := ret.Get(0).(func() float64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	return r0
+}
+
 // TriggerFallbackDBPollInterval provides a mock function with given fields:
 func (_m *ChainScopedConfig) TriggerFallbackDBPollInterval() time.Duration {
 	ret := _m.Called()
@@ -11,6 +11,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/multierr"
 	"gopkg.in/guregu/null.v4"
 
@@ -528,7 +530,17 @@ func (eb *EthBroadcaster[CHAIN_ID, HEAD, ADDR, TX_HASH, BLOCK_HASH, R, SEQ, FEE,
 	cancel()
 
 	lgr.Debugw("Sending transaction", "ethTxAttemptID", attempt.ID, "txHash", attempt.Hash, "err", err, "meta", etx.Meta, "feeLimit", etx.FeeLimit, "attempt", attempt, "etx", etx)
-	errType, err := eb.client.SendTransactionReturnCode(ctx, etx, attempt, lgr)
+	sendCtx, span := tracer.Start(txTraceContext(ctx, etx), "txmgr.broadcast", trace.WithAttributes(
+		attribute.Int64("tx.id", etx.ID),
+		attribute.String("tx.hash", attempt.Hash.String()),
+		attribute.String("chain.id", eb.chainID.String()),
+	))
+	errType, err := eb.client.SendTransactionReturnCode(sendCtx, etx, attempt, lgr)
+	span.SetAttributes(attribute.Int("tx.send_return_code", int(errType)))
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
 
 	if errType != clienttypes.Fatal {
 		etx.InitialBroadcastAt = &initialBroadcastAt
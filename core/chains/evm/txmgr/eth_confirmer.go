@@ -12,6 +12,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/multierr"
 
 	clienttypes "github.com/smartcontractkit/chainlink/v2/common/chains/client"
@@ -1122,6 +1124,18 @@ func observeUntilTxConfirmed[
 				WithLabelValues(chainID.String()).
 				Observe(float64(duration))
 
+			// The span covers the whole lifetime of the eth tx, and joins the
+			// trace of the pipeline run which created it
+			_, span := tracer.Start(txTraceContext(context.Background(), attempt.Tx), "txmgr.confirm",
+				trace.WithTimestamp(attempt.Tx.CreatedAt),
+				trace.WithAttributes(
+					attribute.Int64("tx.id", attempt.Tx.ID),
+					attribute.String("tx.hash", attempt.Hash.String()),
+					attribute.String("chain.id", chainID.String()),
+					attribute.Int64("block.number", r.GetBlockNumber().Int64()),
+				))
+			span.End()
+
 			// Since a eth tx can have many attempts, we take the number of blocks to confirm as the block number
 			// of the receipt minus the block number of the first ever broadcast for this transaction.
 			broadcastBefore := utils.MinKey(attempt.Tx.TxAttempts, func(attempt txmgrtypes.TxAttempt[CHAIN_ID, ADDR, TX_HASH, BLOCK_HASH, R, FEE, ADD]) int64 {
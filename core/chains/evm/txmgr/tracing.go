@@ -0,0 +1,32 @@
+package txmgr
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+
+	txmgrtypes "github.com/smartcontractkit/chainlink/v2/common/txmgr/types"
+	commontypes "github.com/smartcontractkit/chainlink/v2/common/types"
+)
+
+// tracer is a no-op until tracing is enabled in the node config
+var tracer = otel.Tracer("github.com/smartcontractkit/chainlink/v2/core/chains/evm/txmgr")
+
+// txTraceContext returns ctx carrying the trace context recorded in the meta of
+// etx by the pipeline task which created it, if any, so that the spans of the
+// transaction join the trace of its pipeline run.
+func txTraceContext[
+	CHAIN_ID txmgrtypes.ID,
+	ADDR commontypes.Hashable,
+	TX_HASH, BLOCK_HASH commontypes.Hashable,
+	R txmgrtypes.ChainReceipt[TX_HASH, BLOCK_HASH],
+	FEE txmgrtypes.Fee,
+	ADD any,
+](ctx context.Context, etx txmgrtypes.Tx[CHAIN_ID, ADDR, TX_HASH, BLOCK_HASH, R, FEE, ADD]) context.Context {
+	meta, err := etx.GetMeta()
+	if err != nil || meta == nil || len(meta.TraceContext) == 0 {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(meta.TraceContext))
+}
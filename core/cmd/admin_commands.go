@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -128,6 +129,43 @@ func initAdminSubCmds(client *Client) []cli.Command {
 				},
 			},
 		},
+		{
+			Name:  "tokens",
+			Usage: "Create, list, or revoke named API tokens for the logged in user",
+			Subcommands: cli.Commands{
+				{
+					Name:   "list",
+					Usage:  "Lists your named API tokens",
+					Action: client.ListAPITokens,
+				},
+				{
+					Name:   "create",
+					Usage:  "Create a new named API token. The secret is only displayed once.",
+					Action: client.CreateAPIToken,
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:     "name",
+							Usage:    "unique name of the token, included in audit log entries for actions it performs",
+							Required: true,
+						},
+						cli.StringFlag{
+							Name:  "role",
+							Usage: "Permission level of the token, which may not exceed your own. Options: 'admin', 'edit', 'run', 'view'. Defaults to your own role.",
+						},
+						cli.DurationFlag{
+							Name:  "expires-in",
+							Usage: "duration after which the token expires, e.g. 720h. Tokens without an expiry remain valid until revoked.",
+						},
+					},
+				},
+				{
+					Name:      "delete",
+					Usage:     "Revoke a named API token",
+					Action:    client.DeleteAPIToken,
+					ArgsUsage: "<name>",
+				},
+			},
+		},
 	}
 }
 
@@ -294,6 +332,151 @@ func (cli *Client) DeleteUser(c *cli.Context) (err error) {
 	return cli.renderAPIResponse(response, &AdminUsersPresenter{}, "Successfully deleted API user")
 }
 
+type APITokenPresenter struct {
+	JAID
+	presenters.UserAPITokenResource
+}
+
+var apiTokensTableHeaders = []string{"Name", "Role", "Expires at", "Last used", "Created at"}
+
+func (p *APITokenPresenter) ToRow() []string {
+	row := []string{
+		p.Name,
+		string(p.Role),
+		timePtrString(p.ExpiresAt, "never"),
+		timePtrString(p.LastUsed, "never"),
+		p.CreatedAt.String(),
+	}
+	return row
+}
+
+// RenderTable implements TableRenderer
+func (p *APITokenPresenter) RenderTable(rt RendererTable) error {
+	rows := [][]string{p.ToRow()}
+
+	renderList(apiTokensTableHeaders, rows, rt.Writer)
+
+	return utils.JustError(rt.Write([]byte("\n")))
+}
+
+type APITokenPresenters []APITokenPresenter
+
+// RenderTable implements TableRenderer
+func (ps APITokenPresenters) RenderTable(rt RendererTable) error {
+	rows := [][]string{}
+
+	for _, p := range ps {
+		rows = append(rows, p.ToRow())
+	}
+
+	if _, err := rt.Write([]byte("API Tokens\n")); err != nil {
+		return err
+	}
+	renderList(apiTokensTableHeaders, rows, rt.Writer)
+
+	return utils.JustError(rt.Write([]byte("\n")))
+}
+
+type CreatedAPITokenPresenter struct {
+	JAID
+	presenters.CreatedUserAPITokenResource
+}
+
+// RenderTable implements TableRenderer
+func (p *CreatedAPITokenPresenter) RenderTable(rt RendererTable) error {
+	tp := APITokenPresenter{UserAPITokenResource: p.UserAPITokenResource}
+	renderList(apiTokensTableHeaders, [][]string{tp.ToRow()}, rt.Writer)
+	renderList([]string{"Access key", "Secret"}, [][]string{{p.AccessKey, p.Secret}}, rt.Writer)
+
+	return utils.JustError(rt.Write([]byte("Store the secret now, it cannot be displayed again.\n")))
+}
+
+func timePtrString(t *time.Time, zero string) string {
+	if t == nil {
+		return zero
+	}
+	return t.String()
+}
+
+// ListAPITokens renders the named API tokens of the logged in user
+func (cli *Client) ListAPITokens(c *cli.Context) (err error) {
+	resp, err := cli.HTTP.Get("/v2/user/tokens", nil)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return cli.renderAPIResponse(resp, &APITokenPresenters{})
+}
+
+// CreateAPIToken creates a named API token for the logged in user, prompting
+// for their password
+func (cli *Client) CreateAPIToken(c *cli.Context) (err error) {
+	fmt.Println("Password of the logged in user (leave empty for single sign-on users):")
+	pwd := cli.PasswordPrompter.Prompt()
+
+	request := struct {
+		Name      string     `json:"name"`
+		Role      string     `json:"role"`
+		ExpiresAt *time.Time `json:"expiresAt"`
+		Password  string     `json:"password"`
+	}{
+		Name:     c.String("name"),
+		Role:     c.String("role"),
+		Password: pwd,
+	}
+	if d := c.Duration("expires-in"); d > 0 {
+		expiresAt := time.Now().Add(d)
+		request.ExpiresAt = &expiresAt
+	}
+
+	requestData, err := json.Marshal(request)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	buf := bytes.NewBuffer(requestData)
+	response, err := cli.HTTP.Post("/v2/user/tokens", buf)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := response.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return cli.renderAPIResponse(response, &CreatedAPITokenPresenter{}, "Successfully created API token")
+}
+
+// DeleteAPIToken revokes a named API token of the logged in user
+func (cli *Client) DeleteAPIToken(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("must pass the name of the token to delete"))
+	}
+	name := c.Args().First()
+
+	response, err := cli.HTTP.Delete("/v2/user/tokens/" + url.PathEscape(name))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := response.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+	if _, err = cli.parseResponse(response); err != nil {
+		return cli.errorOut(err)
+	}
+
+	fmt.Printf("API token %s deleted\n", name)
+	return nil
+}
+
 // Status will display the health of various services
 func (cli *Client) Status(c *cli.Context) error {
 	resp, err := cli.HTTP.Get("/health?full=1", nil)
@@ -187,3 +187,64 @@ func TestAdminUsersPresenter_RenderTable(t *testing.T) {
 	assert.Contains(t, output, user.CreatedAt.String())
 	assert.Contains(t, output, user.UpdatedAt.String())
 }
+
+func TestClient_APITokens(t *testing.T) {
+	app := startNewApplicationV2(t, nil)
+	client, _ := app.NewClientAndRenderer()
+	client.PasswordPrompter = cltest.MockPasswordPrompter{
+		Password: cltest.Password,
+	}
+	buffer := bytes.NewBufferString("")
+	client.Renderer = cmd.RendererTable{Writer: buffer}
+
+	set := flag.NewFlagSet("test", 0)
+	cltest.FlagSetApplyFromAction(client.CreateAPIToken, set, "")
+	require.NoError(t, set.Set("name", "ci"))
+	require.NoError(t, set.Set("role", "run"))
+	require.NoError(t, set.Set("expires-in", "24h"))
+	require.NoError(t, client.CreateAPIToken(cli.NewContext(nil, set, nil)))
+	assert.Contains(t, buffer.String(), "cannot be displayed again")
+
+	tokens, err := app.SessionORM().ListAPITokens(cltest.APIEmailAdmin)
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	assert.Equal(t, sessions.UserRoleRun, tokens[0].Role)
+	assert.True(t, tokens[0].ExpiresAt.Valid)
+
+	buffer.Reset()
+	set = flag.NewFlagSet("test", 0)
+	cltest.FlagSetApplyFromAction(client.ListAPITokens, set, "")
+	require.NoError(t, client.ListAPITokens(cli.NewContext(nil, set, nil)))
+	assert.Contains(t, buffer.String(), "ci")
+
+	set = flag.NewFlagSet("test", 0)
+	cltest.FlagSetApplyFromAction(client.DeleteAPIToken, set, "")
+	require.NoError(t, set.Parse([]string{"ci"}))
+	require.NoError(t, client.DeleteAPIToken(cli.NewContext(nil, set, nil)))
+	assert.ErrorContains(t, client.DeleteAPIToken(cli.NewContext(nil, set, nil)), "API token ci not found")
+}
+
+func TestAPITokenPresenter_RenderTable(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	presenter := cmd.APITokenPresenter{
+		JAID: cmd.JAID{ID: "ci"},
+		UserAPITokenResource: presenters.UserAPITokenResource{
+			JAID:      presenters.JAID{ID: "ci"},
+			Name:      "ci",
+			Role:      sessions.UserRoleRun,
+			ExpiresAt: &expiresAt,
+			CreatedAt: time.Now(),
+		},
+	}
+
+	buffer := bytes.NewBufferString("")
+	r := cmd.RendererTable{Writer: buffer}
+
+	require.NoError(t, presenter.RenderTable(r))
+
+	output := buffer.String()
+	assert.Contains(t, output, "ci")
+	assert.Contains(t, output, "run")
+	assert.Contains(t, output, expiresAt.String())
+	assert.Contains(t, output, "never")
+}
@@ -239,6 +239,11 @@ func NewApp(client *Client) *cli.App {
 			Usage:       "Commands for managing forwarder addresses.",
 			Subcommands: initFowardersSubCmds(client),
 		},
+		{
+			Name:        "vrf",
+			Usage:       "Commands for inspecting VRF v2 requests",
+			Subcommands: initVRFSubCmds(client),
+		},
 	}...)
 	return app
 }
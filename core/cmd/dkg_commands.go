@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+	clipkg "github.com/urfave/cli"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/dkg"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/dkg/persistence"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+)
+
+func initDKGSubCmd(client *Client) cli.Command {
+	return cli.Command{
+		Name:  "dkg",
+		Usage: "Commands for the DKG keys of OCR2VRF jobs.",
+		Subcommands: []cli.Command{
+			{
+				Name:   "status",
+				Usage:  "List the DKG epochs recorded for each key ID, oldest first. Keys are not reshared: an epoch whose committee changed dealt a new key",
+				Action: client.DKGStatus,
+				Before: client.validateDB,
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "key-id",
+						Usage: "only include the epochs of this hex encoded DKG key ID",
+					},
+				},
+			},
+		},
+	}
+}
+
+// DKGEpochStatusPresenter implements TableRenderer for a DKGEpochStatus
+type DKGEpochStatusPresenter struct {
+	persistence.DKGEpochStatus
+}
+
+// ToRow presents the DKGEpochStatus as a slice of strings.
+func (p *DKGEpochStatusPresenter) ToRow() []string {
+	previous := ""
+	if len(p.PreviousConfigDigest) > 0 {
+		previous = hex.EncodeToString(p.PreviousConfigDigest)
+	}
+	return []string{
+		hex.EncodeToString(p.KeyID),
+		strconv.FormatInt(p.Epoch, 10),
+		hex.EncodeToString(p.ConfigDigest),
+		previous,
+		strconv.Itoa(len(p.Committee)),
+		strconv.Itoa(p.F),
+		strconv.FormatBool(p.CommitteeChanged),
+		fmt.Sprintf("%d/%d", p.NumShares, len(p.Committee)),
+		p.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+type DKGEpochStatusPresenters []DKGEpochStatusPresenter
+
+// RenderTable implements TableRenderer
+func (ps DKGEpochStatusPresenters) RenderTable(rt RendererTable) error {
+	headers := []string{"Key ID", "Epoch", "Config Digest", "Previous Config Digest", "Committee Size", "F", "Committee Changed", "Shares", "Created At"}
+	rows := [][]string{}
+
+	for _, p := range ps {
+		rows = append(rows, p.ToRow())
+	}
+
+	renderList(headers, rows, rt.Writer)
+
+	return nil
+}
+
+// DKGStatus lists the DKG epochs recorded by the OCR2VRF DKG jobs of the node
+func (cli *Client) DKGStatus(c *clipkg.Context) error {
+	var keyID *[32]byte
+	if c.IsSet("key-id") {
+		decoded, err := dkg.DecodeKeyID(c.String("key-id"))
+		if err != nil {
+			return cli.errorOut(errors.Wrap(err, "invalid key-id"))
+		}
+		keyID = &decoded
+	}
+
+	lggr := logger.Sugared(cli.Logger.Named("DKGStatus"))
+	db, err := pg.OpenUnlockedDB(cli.Config)
+	if err != nil {
+		return cli.errorOut(errors.Wrap(err, "opening DB"))
+	}
+	defer lggr.ErrorIfFn(db.Close, "Error closing db")
+
+	// The chain is only used for metrics, which reading epochs does not report.
+	statuses, err := persistence.NewShareDB(db, lggr, cli.Config, nil, "").KeyStatus(keyID)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	presenters := make(DKGEpochStatusPresenters, len(statuses))
+	for i, s := range statuses {
+		presenters[i] = DKGEpochStatusPresenter{s}
+	}
+	return cli.errorOut(cli.Render(&presenters))
+}
@@ -0,0 +1,55 @@
+package cmd_test
+
+import (
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+
+	"github.com/smartcontractkit/chainlink/v2/core/cmd"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	configtest "github.com/smartcontractkit/chainlink/v2/core/internal/testutils/configtest/v2"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/dkg/persistence"
+)
+
+func TestDKGEpochStatusPresenter_ToRow(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	p := cmd.DKGEpochStatusPresenter{DKGEpochStatus: persistence.DKGEpochStatus{
+		DKGEpoch: persistence.DKGEpoch{
+			KeyID:                []byte{0x01},
+			ConfigDigest:         []byte{0x02},
+			Epoch:                2,
+			Committee:            pq.ByteaArray{{1}, {2}, {3}, {4}},
+			F:                    1,
+			PreviousConfigDigest: []byte{0x03},
+			CommitteeChanged:     true,
+			CreatedAt:            createdAt,
+		},
+		NumShares: 3,
+	}}
+
+	assert.Equal(t, []string{"01", "2", "02", "03", "4", "1", "true", "3/4", "2023-05-01T12:00:00Z"}, p.ToRow())
+
+	p.PreviousConfigDigest = nil
+	assert.Equal(t, "", p.ToRow()[3])
+}
+
+func TestClient_DKGStatus_InvalidKeyID(t *testing.T) {
+	t.Parallel()
+
+	client := &cmd.Client{Config: configtest.NewGeneralConfig(t, nil), Logger: logger.TestLogger(t), Renderer: &cltest.RendererMock{}}
+
+	set := flag.NewFlagSet("test", 0)
+	cltest.FlagSetApplyFromAction(client.DKGStatus, set, "")
+	require.NoError(t, set.Set("key-id", "zz"))
+
+	err := client.DKGStatus(cli.NewContext(nil, set, nil))
+	assert.ErrorContains(t, err, "invalid key-id")
+}
@@ -226,6 +226,8 @@ func initLocalSubCmds(client *Client, safe bool) []cli.Command {
 				},
 			},
 		},
+		initTelemetrySubCmd(client),
+		initDKGSubCmd(client),
 	}
 }
 
@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+	clipkg "github.com/urfave/cli"
+
+	v2 "github.com/smartcontractkit/chainlink/v2/core/config/v2"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+	"github.com/smartcontractkit/chainlink/v2/core/services/synchronization"
+	"github.com/smartcontractkit/chainlink/v2/core/store/models"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+// telemetryDialTimeout bounds the time to connect to the ingress server when
+// replaying telemetry
+const telemetryDialTimeout = time.Minute
+
+func initTelemetrySubCmd(client *Client) cli.Command {
+	filterFlags := []cli.Flag{
+		cli.StringFlag{
+			Name:  "type, t",
+			Usage: "only include telemetry of this type, e.g. ocr2-median or enhanced-ea",
+		},
+		cli.StringFlag{
+			Name:  "contract",
+			Usage: "only include telemetry of this contract ID",
+		},
+		cli.StringFlag{
+			Name:  "since",
+			Usage: "only include telemetry recorded at or after this RFC3339 time",
+		},
+		cli.StringFlag{
+			Name:  "until",
+			Usage: "only include telemetry recorded at or before this RFC3339 time",
+		},
+	}
+	return cli.Command{
+		Name:  "telemetry",
+		Usage: "Commands for the telemetry written by the local sink of TelemetryIngress.Local.",
+		Subcommands: []cli.Command{
+			{
+				Name:   "list",
+				Usage:  "List the recorded telemetry matching the flags, oldest first",
+				Action: client.ListTelemetry,
+				Flags: append(filterFlags,
+					cli.IntFlag{
+						Name:  "limit",
+						Usage: "maximum number of records to list",
+						Value: 100,
+					},
+				),
+			},
+			{
+				Name:   "replay",
+				Usage:  "Send the recorded telemetry matching the flags to the telemetry ingress server, oldest first",
+				Action: client.ReplayTelemetry,
+				Flags: append(filterFlags,
+					cli.IntFlag{
+						Name:  "limit",
+						Usage: "maximum number of records to replay, or 0 for all of them",
+					},
+					cli.StringFlag{
+						Name:  "url",
+						Usage: "telemetry ingress server URL, defaults to TelemetryIngress.URL",
+					},
+					cli.StringFlag{
+						Name:  "server-pub-key",
+						Usage: "telemetry ingress server public key, defaults to TelemetryIngress.ServerPubKey",
+					},
+					cli.StringFlag{
+						Name:  "password, p",
+						Usage: "text file holding the password for the node's account",
+					},
+				),
+			},
+		},
+	}
+}
+
+// TelemetryRecordPresenter implements TableRenderer for a TelemetryRecord
+type TelemetryRecordPresenter struct {
+	synchronization.TelemetryRecord
+}
+
+// ToRow presents the TelemetryRecord as a slice of strings.
+func (p *TelemetryRecordPresenter) ToRow() []string {
+	return []string{
+		p.Timestamp.Format(time.RFC3339Nano),
+		string(p.TelemType),
+		p.ContractID,
+		strconv.Itoa(len(p.Telemetry)),
+	}
+}
+
+type TelemetryRecordPresenters []TelemetryRecordPresenter
+
+// RenderTable implements TableRenderer
+func (ps TelemetryRecordPresenters) RenderTable(rt RendererTable) error {
+	headers := []string{"Timestamp", "Type", "Contract ID", "Size"}
+	rows := [][]string{}
+
+	for _, p := range ps {
+		rows = append(rows, p.ToRow())
+	}
+
+	renderList(headers, rows, rt.Writer)
+
+	return nil
+}
+
+// ListTelemetry lists the telemetry recorded by the local sink
+func (cli *Client) ListTelemetry(c *clipkg.Context) error {
+	records, err := cli.readTelemetry(c)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	presenters := make(TelemetryRecordPresenters, len(records))
+	for i, r := range records {
+		presenters[i] = TelemetryRecordPresenter{r}
+	}
+	return cli.errorOut(cli.Render(&presenters))
+}
+
+// ReplayTelemetry sends the telemetry recorded by the local sink to the
+// telemetry ingress server, authenticating with the CSA key of the node
+func (cli *Client) ReplayTelemetry(c *clipkg.Context) error {
+	ingressURL := cli.Config.TelemetryIngressURL()
+	if c.IsSet("url") {
+		u, err := models.ParseURL(c.String("url"))
+		if err != nil {
+			return cli.errorOut(errors.Wrap(err, "invalid url"))
+		}
+		ingressURL = u.URL()
+	}
+	if ingressURL == nil {
+		return cli.errorOut(errors.New("You must set TelemetryIngress.URL or the --url flag"))
+	}
+	serverPubKey := cli.Config.TelemetryIngressServerPubKey()
+	if c.IsSet("server-pub-key") {
+		serverPubKey = c.String("server-pub-key")
+	}
+
+	records, err := cli.readTelemetry(c)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	if len(records) == 0 {
+		fmt.Println("No telemetry to replay")
+		return nil
+	}
+
+	if c.IsSet("password") {
+		pwd, err2 := utils.PasswordFromFile(c.String("password"))
+		if err2 != nil {
+			return cli.errorOut(fmt.Errorf("error reading password: %+v", err2))
+		}
+		cli.Config.SetPasswords(&pwd, nil)
+	}
+
+	lggr := logger.Sugared(cli.Logger.Named("ReplayTelemetry"))
+	db, err := pg.OpenUnlockedDB(cli.Config)
+	if err != nil {
+		return cli.errorOut(errors.Wrap(err, "opening DB"))
+	}
+	defer lggr.ErrorIfFn(db.Close, "Error closing db")
+
+	keyStore := keystore.New(db, utils.GetScryptParams(cli.Config), lggr, cli.Config)
+	if err = keyStore.Unlock(cli.Config.KeystorePassword()); err != nil {
+		return cli.errorOut(errors.Wrap(err, "error authenticating keystore"))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), telemetryDialTimeout)
+	defer cancel()
+	client, closeClient, err := synchronization.DialTelemetryIngress(ctx, ingressURL, serverPubKey, keyStore.CSA(), lggr)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer closeClient()
+
+	sent, err := synchronization.ReplayTelemetry(context.Background(), client, records, cli.Config.TelemetryIngressMaxBatchSize(), cli.Config.TelemetryIngressSendTimeout())
+	fmt.Printf("Replayed %d of %d telemetry records to %s\n", sent, len(records), ingressURL)
+	return cli.errorOut(err)
+}
+
+// readTelemetry reads the telemetry matching the filter flags from the
+// configured local sink
+func (cli *Client) readTelemetry(c *clipkg.Context) ([]synchronization.TelemetryRecord, error) {
+	filter := synchronization.TelemetryFilter{
+		TelemType:  synchronization.TelemetryType(c.String("type")),
+		ContractID: c.String("contract"),
+		Limit:      c.Int("limit"),
+	}
+	for name, t := range map[string]*time.Time{"since": &filter.Since, "until": &filter.Until} {
+		if !c.IsSet(name) {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, c.String(name))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s", name)
+		}
+		*t = parsed
+	}
+
+	var store synchronization.TelemetryStore
+	switch mode := cli.Config.TelemetryIngressLocalMode(); mode {
+	case v2.TelemetryLocalModeDatabase:
+		if err := cli.Config.ValidateDB(); err != nil {
+			return nil, err
+		}
+		db, err := pg.OpenUnlockedDB(cli.Config)
+		if err != nil {
+			return nil, errors.Wrap(err, "opening DB")
+		}
+		defer db.Close()
+		store = synchronization.NewTelemetryStore(cli.Config, db, cli.Logger, cli.Config)
+	case v2.TelemetryLocalModeFile:
+		store = synchronization.NewTelemetryStore(cli.Config, nil, cli.Logger, cli.Config)
+	default:
+		return nil, errors.Errorf("TelemetryIngress.Local.Mode is %s: telemetry is not recorded locally", mode)
+	}
+	defer store.Close()
+
+	return store.Read(context.Background(), filter)
+}
@@ -0,0 +1,80 @@
+package cmd_test
+
+import (
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+
+	"github.com/smartcontractkit/chainlink/v2/core/cmd"
+	v2 "github.com/smartcontractkit/chainlink/v2/core/config/v2"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	configtest "github.com/smartcontractkit/chainlink/v2/core/internal/testutils/configtest/v2"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/services/synchronization"
+)
+
+func TestClient_ListTelemetry(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	now := time.Now().UTC()
+	store := synchronization.NewTelemetryFileStore(dir, 1, 0, 0)
+	require.NoError(t, store.Write(testutils.Context(t), []synchronization.TelemetryRecord{
+		{Timestamp: now.Add(-2 * time.Minute), TelemType: synchronization.OCR2Median, ContractID: "0xa", Telemetry: []byte("one")},
+		{Timestamp: now.Add(-time.Minute), TelemType: synchronization.EnhancedEA, ContractID: "0xa", Telemetry: []byte("two")},
+		{Timestamp: now, TelemType: synchronization.OCR2Median, ContractID: "0xb", Telemetry: []byte("three")},
+	}))
+	require.NoError(t, store.Close())
+
+	newClient := func(t *testing.T, mode string) (*cmd.Client, *cltest.RendererMock) {
+		cfg := configtest.NewGeneralConfig(t, func(c *chainlink.Config, s *chainlink.Secrets) {
+			c.TelemetryIngress.Local.Mode = ptr(mode)
+			c.TelemetryIngress.Local.Dir = ptr(dir)
+		})
+		r := &cltest.RendererMock{}
+		return &cmd.Client{Config: cfg, Logger: logger.TestLogger(t), Renderer: r}, r
+	}
+
+	t.Run("filters", func(t *testing.T) {
+		client, r := newClient(t, v2.TelemetryLocalModeFile)
+
+		set := flag.NewFlagSet("test", 0)
+		cltest.FlagSetApplyFromAction(client.ListTelemetry, set, "")
+		require.NoError(t, set.Set("type", string(synchronization.OCR2Median)))
+		require.NoError(t, set.Set("since", now.Add(-time.Hour).Format(time.RFC3339)))
+
+		require.NoError(t, client.ListTelemetry(cli.NewContext(nil, set, nil)))
+		require.Len(t, r.Renders, 1)
+		presenters := *r.Renders[0].(*cmd.TelemetryRecordPresenters)
+		require.Len(t, presenters, 2)
+		assert.Equal(t, []byte("one"), presenters[0].Telemetry)
+		assert.Equal(t, "0xb", presenters[1].ContractID)
+	})
+
+	t.Run("invalid time", func(t *testing.T) {
+		client, _ := newClient(t, v2.TelemetryLocalModeFile)
+
+		set := flag.NewFlagSet("test", 0)
+		cltest.FlagSetApplyFromAction(client.ListTelemetry, set, "")
+		require.NoError(t, set.Set("until", "yesterday"))
+
+		err := client.ListTelemetry(cli.NewContext(nil, set, nil))
+		assert.ErrorContains(t, err, "invalid until")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		client, _ := newClient(t, v2.TelemetryLocalModeDisabled)
+
+		set := flag.NewFlagSet("test", 0)
+		cltest.FlagSetApplyFromAction(client.ListTelemetry, set, "")
+
+		err := client.ListTelemetry(cli.NewContext(nil, set, nil))
+		assert.EqualError(t, err, "TelemetryIngress.Local.Mode is disabled: telemetry is not recorded locally")
+	})
+}
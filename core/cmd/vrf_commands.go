@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/manyminds/api2go/jsonapi"
+	"github.com/urfave/cli"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/v2/core/web"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func initVRFSubCmds(client *Client) []cli.Command {
+	filterFlags := []cli.Flag{
+		cli.Int64Flag{
+			Name:  "job-id",
+			Usage: "only include requests of the VRF v2 job with this ID",
+		},
+		cli.Uint64Flag{
+			Name:  "sub-id",
+			Usage: "only include requests of the subscription with this ID",
+		},
+	}
+	return []cli.Command{
+		{
+			Name:   "pending",
+			Usage:  "List the VRF v2 requests that have not been fulfilled yet",
+			Action: client.ListVRFPendingRequests,
+			Flags:  filterFlags,
+		},
+		{
+			Name:   "retry",
+			Usage:  "Clear the retry backoff of pending VRF v2 requests so they are processed right away",
+			Action: client.RetryVRFPendingRequests,
+			Flags:  filterFlags,
+		},
+	}
+}
+
+type VRFPendingRequestPresenter struct {
+	JAID // This is needed to render the id for a JSONAPI Resource as normal JSON
+	presenters.VRFPendingRequestResource
+}
+
+var vrfPendingRequestHeaders = []string{"Request ID", "Job ID", "Sub ID", "Age", "Confirmations", "Attempts", "Next Try", "Estimated Fee (Juels)", "Last Skip Reason"}
+
+// ToRow presents the VRFPendingRequestResource as a slice of strings.
+func (p *VRFPendingRequestPresenter) ToRow() []string {
+	nextTry := "next poll"
+	if p.NextTry != nil {
+		nextTry = p.NextTry.Format(time.RFC3339)
+	}
+	fee := "unknown"
+	if p.EstimatedFeeJuels != nil {
+		fee = p.EstimatedFeeJuels.String()
+	}
+	return []string{
+		p.GetID(),
+		strconv.Itoa(int(p.JobID)),
+		strconv.FormatUint(p.SubID, 10),
+		time.Since(p.ReceivedAt).Truncate(time.Second).String(),
+		fmt.Sprintf("%d/%d", p.Confirmations, p.RequiredConfirmations),
+		strconv.Itoa(p.Attempts),
+		nextTry,
+		fee,
+		p.LastSkipReason,
+	}
+}
+
+// RenderTable implements TableRenderer
+func (p *VRFPendingRequestPresenter) RenderTable(rt RendererTable) error {
+	renderList(vrfPendingRequestHeaders, [][]string{p.ToRow()}, rt.Writer)
+	return nil
+}
+
+// VRFPendingRequestPresenters implements TableRenderer for a slice of VRFPendingRequestPresenter.
+type VRFPendingRequestPresenters []VRFPendingRequestPresenter
+
+// RenderTable implements TableRenderer
+func (ps VRFPendingRequestPresenters) RenderTable(rt RendererTable) error {
+	var rows [][]string
+	for _, p := range ps {
+		rows = append(rows, p.ToRow())
+	}
+	renderList(vrfPendingRequestHeaders, rows, rt.Writer)
+	return nil
+}
+
+func vrfBacklogQuery(c *cli.Context) string {
+	v := url.Values{}
+	if c.IsSet("job-id") {
+		v.Add("jobID", strconv.FormatInt(c.Int64("job-id"), 10))
+	}
+	if c.IsSet("sub-id") {
+		v.Add("subID", strconv.FormatUint(c.Uint64("sub-id"), 10))
+	}
+	if len(v) == 0 {
+		return ""
+	}
+	return "?" + v.Encode()
+}
+
+// ListVRFPendingRequests lists the unfulfilled requests of the VRF v2 jobs.
+func (cli *Client) ListVRFPendingRequests(c *cli.Context) (err error) {
+	resp, err := cli.HTTP.Get("/v2/vrf/pending_requests" + vrfBacklogQuery(c))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return cli.renderAPIResponse(resp, &VRFPendingRequestPresenters{})
+}
+
+// RetryVRFPendingRequests asks the VRF v2 jobs to process their pending
+// requests right away.
+func (cli *Client) RetryVRFPendingRequests(c *cli.Context) (err error) {
+	resp, err := cli.HTTP.Post("/v2/vrf/pending_requests/retry"+vrfBacklogQuery(c), bytes.NewBufferString("{}"))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	var response web.VRFRetryResponse
+	if err = cli.deserializeAPIResponse(resp, &response, &jsonapi.Links{}); err != nil {
+		return cli.errorOut(err)
+	}
+	fmt.Println(response.Message)
+	return nil
+}
@@ -0,0 +1,60 @@
+package cmd_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/cmd"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func TestVRFPendingRequestPresenter_RenderTable(t *testing.T) {
+	t.Parallel()
+
+	var (
+		nextTry = time.Now().Add(time.Minute)
+		buffer  = bytes.NewBufferString("")
+		r       = cmd.RendererTable{Writer: buffer}
+	)
+
+	p := cmd.VRFPendingRequestPresenter{
+		JAID: cmd.JAID{ID: "12345"},
+		VRFPendingRequestResource: presenters.VRFPendingRequestResource{
+			JAID:                  presenters.NewJAID("12345"),
+			JobID:                 3,
+			SubID:                 42,
+			ReceivedAt:            time.Now().Add(-time.Hour),
+			RequiredConfirmations: 20,
+			Confirmations:         25,
+			Attempts:              4,
+			NextTry:               &nextTry,
+			EstimatedFeeJuels:     utils.NewBig(big.NewInt(987654321)),
+			LastSkipReason:        "insufficient subscription balance",
+		},
+	}
+
+	require.NoError(t, p.RenderTable(r))
+	output := buffer.String()
+	assert.Contains(t, output, "12345")
+	assert.Contains(t, output, "42")
+	assert.Contains(t, output, "25/20")
+	assert.Contains(t, output, nextTry.Format(time.RFC3339))
+	assert.Contains(t, output, "987654321")
+	assert.Contains(t, output, "insufficient subscription balance")
+
+	// Never simulated and not backing off
+	buffer.Reset()
+	p.NextTry = nil
+	p.EstimatedFeeJuels = nil
+	ps := cmd.VRFPendingRequestPresenters{p}
+	require.NoError(t, ps.RenderTable(r))
+	output = buffer.String()
+	assert.Contains(t, output, "next poll")
+	assert.Contains(t, output, "unknown")
+}
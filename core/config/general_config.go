@@ -28,6 +28,7 @@ var (
 type FeatureFlags interface {
 	FeatureExternalInitiators() bool
 	FeatureFeedsManager() bool
+	FeatureGraphQLSubscriptions() bool
 	FeatureOffchainReporting() bool
 	FeatureOffchainReporting2() bool
 	FeatureUICSAKeys() bool
@@ -105,6 +106,14 @@ type BasicConfig interface {
 	JobPipelineReaperInterval() time.Duration
 	JobPipelineReaperThreshold() time.Duration
 	JobPipelineResultWriteQueueDepth() uint64
+	JobPipelineReaperArchiveDir() string
+	// JobPipelineRetentionJobTypes returns the job types with their own retention settings
+	JobPipelineRetentionJobTypes() []string
+	// The following return the retention settings of jobType, or the defaults of JobPipeline if it has none
+	JobPipelineMaxSuccessfulRunsForJobType(jobType string) uint64
+	JobPipelineReaperThresholdForJobType(jobType string) time.Duration
+	JobPipelineErroredReaperThresholdForJobType(jobType string) time.Duration
+	JobPipelineSuccessfulTaskRunsSampleRateForJobType(jobType string) uint32
 	KeeperDefaultTransactionQueueDepth() uint32
 	KeeperGasPriceBufferPercent() uint16
 	KeeperGasTipCapBufferPercent() uint16
@@ -129,6 +138,18 @@ type BasicConfig interface {
 	LogUnixTimestamps() bool
 	MercuryCredentials(credName string) *ocr2models.MercuryCredentials
 	MigrateDatabase() bool
+	OIDCEnabled() bool
+	OIDCIssuerURL() string
+	OIDCClientID() string
+	OIDCClientSecret() string
+	OIDCRedirectURL() string
+	OIDCScopes() []string
+	OIDCGroupsClaim() string
+	OIDCAdminGroups() []string
+	OIDCEditGroups() []string
+	OIDCRunGroups() []string
+	OIDCViewGroups() []string
+	OIDCDisableLocalPasswords() bool
 	ORMMaxIdleConns() int
 	ORMMaxOpenConns() int
 	Port() uint16
@@ -162,6 +183,16 @@ type BasicConfig interface {
 	TelemetryIngressSendInterval() time.Duration
 	TelemetryIngressSendTimeout() time.Duration
 	TelemetryIngressUseBatchSend() bool
+	TelemetryIngressLocalMode() string
+	TelemetryIngressLocalDir() string
+	TelemetryIngressLocalMaxSize() utils.FileSize
+	TelemetryIngressLocalMaxAge() int64
+	TelemetryIngressLocalMaxBackups() int64
+	TracingCollectorTarget() string
+	TracingEnabled() bool
+	TracingMode() string
+	TracingNodeID() string
+	TracingSamplingRatio() float64
 	TriggerFallbackDBPollInterval() time.Duration
 	UnAuthenticatedRateLimit() int64
 	UnAuthenticatedRateLimitPeriod() models.Duration
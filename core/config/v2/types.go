@@ -12,6 +12,7 @@ import (
 	"github.com/google/uuid"
 	"go.uber.org/multierr"
 	"go.uber.org/zap/zapcore"
+	"golang.org/x/exp/slices"
 
 	ocrcommontypes "github.com/smartcontractkit/libocr/commontypes"
 	ocrnetworking "github.com/smartcontractkit/libocr/networking"
@@ -52,6 +53,7 @@ type Core struct {
 	Keeper           Keeper                  `toml:",omitempty"`
 	AutoPprof        AutoPprof               `toml:",omitempty"`
 	Pyroscope        Pyroscope               `toml:",omitempty"`
+	Tracing          Tracing                 `toml:",omitempty"`
 	Sentry           Sentry                  `toml:",omitempty"`
 	Insecure         Insecure                `toml:",omitempty"`
 }
@@ -66,6 +68,8 @@ func init() {
 	if err := cfgtest.DocDefaultsOnly(strings.NewReader(defaultsTOML), &defaults, DecodeTOML); err != nil {
 		log.Fatalf("Failed to initialize defaults from docs: %v", err)
 	}
+	// JobPipeline.Retention has no default entries, only an example.
+	defaults.JobPipeline.Retention = nil
 }
 
 func CoreDefaults() (c Core) {
@@ -106,6 +110,7 @@ func (c *Core) SetFrom(f *Core) {
 
 	c.AutoPprof.setFrom(&f.AutoPprof)
 	c.Pyroscope.setFrom(&f.Pyroscope)
+	c.Tracing.setFrom(&f.Tracing)
 	c.Sentry.setFrom(&f.Sentry)
 	c.Insecure.setFrom(&f.Insecure)
 }
@@ -117,6 +122,7 @@ type Secrets struct {
 	Pyroscope  PyroscopeSecrets  `toml:",omitempty"`
 	Prometheus PrometheusSecrets `toml:",omitempty"`
 	Mercury    MercurySecrets    `toml:",omitempty"`
+	WebServer  WebServerSecrets  `toml:",omitempty"`
 }
 
 func dbURLPasswordComplexity(err error) string {
@@ -172,16 +178,28 @@ type PyroscopeSecrets struct {
 type PrometheusSecrets struct {
 	AuthToken *models.Secret
 }
+
+type WebServerSecrets struct {
+	OIDC OIDCSecrets `toml:",omitempty"`
+}
+
+type OIDCSecrets struct {
+	ClientSecret *models.Secret
+}
 type Feature struct {
-	FeedsManager *bool
-	LogPoller    *bool
-	UICSAKeys    *bool
+	FeedsManager         *bool
+	GraphQLSubscriptions *bool
+	LogPoller            *bool
+	UICSAKeys            *bool
 }
 
 func (f *Feature) setFrom(f2 *Feature) {
 	if v := f2.FeedsManager; v != nil {
 		f.FeedsManager = v
 	}
+	if v := f2.GraphQLSubscriptions; v != nil {
+		f.GraphQLSubscriptions = v
+	}
 	if v := f2.LogPoller; v != nil {
 		f.LogPoller = v
 	}
@@ -319,6 +337,8 @@ type TelemetryIngress struct {
 	SendInterval *models.Duration
 	SendTimeout  *models.Duration
 	UseBatchSend *bool
+
+	Local TelemetryIngressLocal `toml:",omitempty"`
 }
 
 func (t *TelemetryIngress) setFrom(f *TelemetryIngress) {
@@ -349,6 +369,59 @@ func (t *TelemetryIngress) setFrom(f *TelemetryIngress) {
 	if v := f.UseBatchSend; v != nil {
 		t.UseBatchSend = v
 	}
+	t.Local.setFrom(&f.Local)
+}
+
+const (
+	TelemetryLocalModeDisabled = "disabled"
+	TelemetryLocalModeFile     = "file"
+	TelemetryLocalModeDatabase = "database"
+)
+
+type TelemetryIngressLocal struct {
+	Mode       *string
+	Dir        *string
+	MaxSize    *utils.FileSize
+	MaxAgeDays *int64
+	MaxBackups *int64
+}
+
+func (t *TelemetryIngressLocal) ValidateConfig() (err error) {
+	if t.Mode != nil {
+		switch *t.Mode {
+		case TelemetryLocalModeDisabled, TelemetryLocalModeFile, TelemetryLocalModeDatabase:
+		default:
+			err = multierr.Append(err, ErrInvalid{Name: "Mode", Value: *t.Mode, Msg: "must be one of: disabled, file, database"})
+		}
+	}
+	if t.MaxSize != nil && *t.MaxSize < utils.MB {
+		err = multierr.Append(err, ErrInvalid{Name: "MaxSize", Value: *t.MaxSize, Msg: "must be at least 1mb"})
+	}
+	if t.MaxAgeDays != nil && *t.MaxAgeDays < 0 {
+		err = multierr.Append(err, ErrInvalid{Name: "MaxAgeDays", Value: *t.MaxAgeDays, Msg: "must not be negative"})
+	}
+	if t.MaxBackups != nil && *t.MaxBackups < 0 {
+		err = multierr.Append(err, ErrInvalid{Name: "MaxBackups", Value: *t.MaxBackups, Msg: "must not be negative"})
+	}
+	return
+}
+
+func (t *TelemetryIngressLocal) setFrom(f *TelemetryIngressLocal) {
+	if v := f.Mode; v != nil {
+		t.Mode = v
+	}
+	if v := f.Dir; v != nil {
+		t.Dir = v
+	}
+	if v := f.MaxSize; v != nil {
+		t.MaxSize = v
+	}
+	if v := f.MaxAgeDays; v != nil {
+		t.MaxAgeDays = v
+	}
+	if v := f.MaxBackups; v != nil {
+		t.MaxBackups = v
+	}
 }
 
 // LogLevel replaces dpanic with crit/CRIT
@@ -437,6 +510,7 @@ type WebServer struct {
 	SessionReaperExpiration *models.Duration
 
 	MFA       WebServerMFA       `toml:",omitempty"`
+	OIDC      WebServerOIDC      `toml:",omitempty"`
 	RateLimit WebServerRateLimit `toml:",omitempty"`
 	TLS       WebServerTLS       `toml:",omitempty"`
 }
@@ -468,6 +542,7 @@ func (w *WebServer) setFrom(f *WebServer) {
 	}
 
 	w.MFA.setFrom(&f.MFA)
+	w.OIDC.setFrom(&f.OIDC)
 	w.RateLimit.setFrom(&f.RateLimit)
 	w.TLS.setFrom(&f.TLS)
 }
@@ -486,6 +561,87 @@ func (w *WebServerMFA) setFrom(f *WebServerMFA) {
 	}
 }
 
+// WebServerOIDC
+//
+// Note: the client secret is stored in Secrets.WebServer.OIDC.ClientSecret
+type WebServerOIDC struct {
+	Enabled               *bool
+	IssuerURL             *models.URL
+	ClientID              *string
+	RedirectURL           *models.URL
+	Scopes                *[]string
+	GroupsClaim           *string
+	AdminGroups           *[]string
+	EditGroups            *[]string
+	RunGroups             *[]string
+	ViewGroups            *[]string
+	DisableLocalPasswords *bool
+}
+
+func (w *WebServerOIDC) ValidateConfig() (err error) {
+	if w.Enabled == nil || !*w.Enabled {
+		if w.DisableLocalPasswords != nil && *w.DisableLocalPasswords {
+			err = multierr.Append(err, ErrInvalid{Name: "DisableLocalPasswords", Value: true, Msg: "requires OIDC to be enabled"})
+		}
+		return
+	}
+	if w.IssuerURL == nil || w.IssuerURL.IsZero() {
+		err = multierr.Append(err, ErrMissing{Name: "IssuerURL", Msg: "required when OIDC is enabled"})
+	}
+	if w.ClientID == nil || *w.ClientID == "" {
+		err = multierr.Append(err, ErrMissing{Name: "ClientID", Msg: "required when OIDC is enabled"})
+	}
+	if w.RedirectURL == nil || w.RedirectURL.IsZero() {
+		err = multierr.Append(err, ErrMissing{Name: "RedirectURL", Msg: "required when OIDC is enabled"})
+	}
+	var mapped int
+	for _, groups := range []*[]string{w.AdminGroups, w.EditGroups, w.RunGroups, w.ViewGroups} {
+		if groups != nil {
+			mapped += len(*groups)
+		}
+	}
+	if mapped == 0 {
+		err = multierr.Append(err, ErrMissing{Name: "AdminGroups", Msg: "at least one group must be mapped to a role when OIDC is enabled"})
+	}
+	return
+}
+
+func (w *WebServerOIDC) setFrom(f *WebServerOIDC) {
+	if v := f.Enabled; v != nil {
+		w.Enabled = v
+	}
+	if v := f.IssuerURL; v != nil {
+		w.IssuerURL = v
+	}
+	if v := f.ClientID; v != nil {
+		w.ClientID = v
+	}
+	if v := f.RedirectURL; v != nil {
+		w.RedirectURL = v
+	}
+	if v := f.Scopes; v != nil {
+		w.Scopes = v
+	}
+	if v := f.GroupsClaim; v != nil {
+		w.GroupsClaim = v
+	}
+	if v := f.AdminGroups; v != nil {
+		w.AdminGroups = v
+	}
+	if v := f.EditGroups; v != nil {
+		w.EditGroups = v
+	}
+	if v := f.RunGroups; v != nil {
+		w.RunGroups = v
+	}
+	if v := f.ViewGroups; v != nil {
+		w.ViewGroups = v
+	}
+	if v := f.DisableLocalPasswords; v != nil {
+		w.DisableLocalPasswords = v
+	}
+}
+
 type WebServerRateLimit struct {
 	Authenticated         *int64
 	AuthenticatedPeriod   *models.Duration
@@ -535,14 +691,18 @@ func (w *WebServerTLS) setFrom(f *WebServerTLS) {
 }
 
 type JobPipeline struct {
-	ExternalInitiatorsEnabled *bool
-	MaxRunDuration            *models.Duration
-	MaxSuccessfulRuns         *uint64
-	ReaperInterval            *models.Duration
-	ReaperThreshold           *models.Duration
-	ResultWriteQueueDepth     *uint32
+	ExternalInitiatorsEnabled    *bool
+	MaxRunDuration               *models.Duration
+	MaxSuccessfulRuns            *uint64
+	ReaperInterval               *models.Duration
+	ReaperThreshold              *models.Duration
+	ErroredReaperThreshold       *models.Duration
+	ReaperArchiveDir             *string
+	ResultWriteQueueDepth        *uint32
+	SuccessfulTaskRunsSampleRate *uint32
 
 	HTTPRequest JobPipelineHTTPRequest `toml:",omitempty"`
+	Retention   JobPipelineRetentions  `toml:",omitempty"`
 }
 
 func (j *JobPipeline) setFrom(f *JobPipeline) {
@@ -561,11 +721,78 @@ func (j *JobPipeline) setFrom(f *JobPipeline) {
 	if v := f.ReaperThreshold; v != nil {
 		j.ReaperThreshold = v
 	}
+	if v := f.ErroredReaperThreshold; v != nil {
+		j.ErroredReaperThreshold = v
+	}
+	if v := f.ReaperArchiveDir; v != nil {
+		j.ReaperArchiveDir = v
+	}
 	if v := f.ResultWriteQueueDepth; v != nil {
 		j.ResultWriteQueueDepth = v
 	}
+	if v := f.SuccessfulTaskRunsSampleRate; v != nil {
+		j.SuccessfulTaskRunsSampleRate = v
+	}
 	j.HTTPRequest.setFrom(&f.HTTPRequest)
 
+	for _, v := range f.Retention {
+		v := v
+		if i := slices.IndexFunc(j.Retention, func(r JobPipelineRetention) bool {
+			return r.JobType != nil && v.JobType != nil && *r.JobType == *v.JobType
+		}); i == -1 {
+			j.Retention = append(j.Retention, v)
+		} else {
+			j.Retention[i].setFrom(&v)
+		}
+	}
+}
+
+// JobPipelineRetentions overrides the retention of pipeline runs per job type.
+type JobPipelineRetentions []JobPipelineRetention
+
+func (rs JobPipelineRetentions) ValidateConfig() (err error) {
+	types := map[string]struct{}{}
+	for _, r := range rs {
+		if r.JobType == nil {
+			continue
+		}
+		if _, ok := types[*r.JobType]; ok {
+			err = multierr.Append(err, NewErrDuplicate("JobType", *r.JobType))
+		} else {
+			types[*r.JobType] = struct{}{}
+		}
+	}
+	return
+}
+
+type JobPipelineRetention struct {
+	JobType                      *string
+	MaxSuccessfulRuns            *uint64
+	ReaperThreshold              *models.Duration
+	ErroredReaperThreshold       *models.Duration
+	SuccessfulTaskRunsSampleRate *uint32
+}
+
+func (r *JobPipelineRetention) ValidateConfig() (err error) {
+	if r.JobType == nil || *r.JobType == "" {
+		err = multierr.Append(err, ErrMissing{Name: "JobType", Msg: "must be provided and non-empty"})
+	}
+	return
+}
+
+func (r *JobPipelineRetention) setFrom(f *JobPipelineRetention) {
+	if v := f.MaxSuccessfulRuns; v != nil {
+		r.MaxSuccessfulRuns = v
+	}
+	if v := f.ReaperThreshold; v != nil {
+		r.ReaperThreshold = v
+	}
+	if v := f.ErroredReaperThreshold; v != nil {
+		r.ErroredReaperThreshold = v
+	}
+	if v := f.SuccessfulTaskRunsSampleRate; v != nil {
+		r.SuccessfulTaskRunsSampleRate = v
+	}
 }
 
 type JobPipelineHTTPRequest struct {
@@ -943,6 +1170,57 @@ func (p *Pyroscope) setFrom(f *Pyroscope) {
 	}
 }
 
+const (
+	TracingModeTLS         = "tls"
+	TracingModeUnencrypted = "unencrypted"
+)
+
+type Tracing struct {
+	Enabled         *bool
+	CollectorTarget *string
+	NodeID          *string
+	SamplingRatio   *float64
+	Mode            *string
+}
+
+func (t *Tracing) ValidateConfig() (err error) {
+	if t.SamplingRatio != nil && (*t.SamplingRatio < 0 || *t.SamplingRatio > 1) {
+		err = multierr.Append(err, ErrInvalid{Name: "SamplingRatio", Value: *t.SamplingRatio, Msg: "must be between 0 and 1"})
+	}
+	if t.Mode != nil {
+		switch *t.Mode {
+		case TracingModeTLS, TracingModeUnencrypted:
+		default:
+			err = multierr.Append(err, ErrInvalid{Name: "Mode", Value: *t.Mode, Msg: "must be one of: tls, unencrypted"})
+		}
+	}
+	if t.Enabled == nil || !*t.Enabled {
+		return
+	}
+	if t.CollectorTarget == nil || *t.CollectorTarget == "" {
+		err = multierr.Append(err, ErrMissing{Name: "CollectorTarget", Msg: "required when tracing is enabled"})
+	}
+	return
+}
+
+func (t *Tracing) setFrom(f *Tracing) {
+	if v := f.Enabled; v != nil {
+		t.Enabled = v
+	}
+	if v := f.CollectorTarget; v != nil {
+		t.CollectorTarget = v
+	}
+	if v := f.NodeID; v != nil {
+		t.NodeID = v
+	}
+	if v := f.SamplingRatio; v != nil {
+		t.SamplingRatio = v
+	}
+	if v := f.Mode; v != nil {
+		t.Mode = v
+	}
+}
+
 type Sentry struct {
 	Debug       *bool
 	DSN         *string
@@ -7,6 +7,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink/cfgtest"
 	"github.com/smartcontractkit/chainlink/v2/core/store/models"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
 )
 
 func TestCoreDefaults_notNil(t *testing.T) {
@@ -69,3 +70,45 @@ func TestMercurySecrets_emptyURL(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, "URL: missing: must be provided and non-empty", err.Error())
 }
+
+func TestWebServerOIDC_ValidateConfig(t *testing.T) {
+	enabled, disabled := true, false
+	clientID := "chainlink"
+	issuer, redirect := models.MustParseURL("https://accounts.example.com"), models.MustParseURL("https://localhost:6688/oidc/callback")
+
+	assert.NoError(t, (&WebServerOIDC{Enabled: &disabled}).ValidateConfig())
+	assert.NoError(t, (&WebServerOIDC{Enabled: &enabled, IssuerURL: issuer, ClientID: &clientID, RedirectURL: redirect,
+		ViewGroups: &[]string{"engineering"}}).ValidateConfig())
+
+	err := (&WebServerOIDC{Enabled: &disabled, DisableLocalPasswords: &enabled}).ValidateConfig()
+	assert.EqualError(t, err, "DisableLocalPasswords: invalid value (true): requires OIDC to be enabled")
+
+	err = (&WebServerOIDC{Enabled: &enabled, ClientID: new(string)}).ValidateConfig()
+	assert.EqualError(t, err, `IssuerURL: missing: required when OIDC is enabled; ClientID: missing: required when OIDC is enabled; RedirectURL: missing: required when OIDC is enabled; AdminGroups: missing: at least one group must be mapped to a role when OIDC is enabled`)
+}
+
+func TestTracing_ValidateConfig(t *testing.T) {
+	enabled, disabled := true, false
+	target, tls, ratio := "localhost:4317", TracingModeTLS, 0.1
+
+	assert.NoError(t, (&Tracing{Enabled: &disabled}).ValidateConfig())
+	assert.NoError(t, (&Tracing{Enabled: &enabled, CollectorTarget: &target, SamplingRatio: &ratio, Mode: &tls}).ValidateConfig())
+
+	err := (&Tracing{Enabled: &enabled}).ValidateConfig()
+	assert.EqualError(t, err, "CollectorTarget: missing: required when tracing is enabled")
+
+	invalidRatio, invalidMode := 1.5, "plaintext"
+	err = (&Tracing{Enabled: &disabled, SamplingRatio: &invalidRatio, Mode: &invalidMode}).ValidateConfig()
+	assert.EqualError(t, err, "SamplingRatio: invalid value (1.5): must be between 0 and 1; Mode: invalid value (plaintext): must be one of: tls, unencrypted")
+}
+
+func TestTelemetryIngressLocal_ValidateConfig(t *testing.T) {
+	file, size, age, backups := TelemetryLocalModeFile, utils.FileSize(100*utils.MB), int64(7), int64(10)
+
+	assert.NoError(t, (&TelemetryIngressLocal{}).ValidateConfig())
+	assert.NoError(t, (&TelemetryIngressLocal{Mode: &file, MaxSize: &size, MaxAgeDays: &age, MaxBackups: &backups}).ValidateConfig())
+
+	invalidMode, invalidSize, invalidAge, invalidBackups := "s3", utils.FileSize(utils.KB), int64(-1), int64(-2)
+	err := (&TelemetryIngressLocal{Mode: &invalidMode, MaxSize: &invalidSize, MaxAgeDays: &invalidAge, MaxBackups: &invalidBackups}).ValidateConfig()
+	assert.EqualError(t, err, "Mode: invalid value (s3): must be one of: disabled, file, database; MaxSize: invalid value (1.00kb): must be at least 1mb; MaxAgeDays: invalid value (-1): must not be negative; MaxBackups: invalid value (-2): must not be negative")
+}
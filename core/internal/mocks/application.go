@@ -37,6 +37,8 @@ import (
 
 	sessions "github.com/smartcontractkit/chainlink/v2/core/sessions"
 
+	slo "github.com/smartcontractkit/chainlink/v2/core/services/slo"
+
 	sqlx "github.com/smartcontractkit/sqlx"
 
 	txmgr "github.com/smartcontractkit/chainlink/v2/core/chains/evm/txmgr"
@@ -47,6 +49,8 @@ import (
 
 	uuid "github.com/google/uuid"
 
+	vrf "github.com/smartcontractkit/chainlink/v2/core/services/vrf"
+
 	webhook "github.com/smartcontractkit/chainlink/v2/core/services/webhook"
 
 	zapcore "go.uber.org/zap/zapcore"
@@ -337,6 +341,22 @@ func (_m *Application) JobORM() job.ORM {
 	return r0
 }
 
+// JobSLOMonitor provides a mock function with given fields:
+func (_m *Application) JobSLOMonitor() slo.Monitor {
+	ret := _m.Called()
+
+	var r0 slo.Monitor
+	if rf, ok := ret.Get(0).(func() slo.Monitor); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(slo.Monitor)
+		}
+	}
+
+	return r0
+}
+
 // JobSpawner provides a mock function with given fields:
 func (_m *Application) JobSpawner() job.Spawner {
 	ret := _m.Called()
@@ -421,23 +441,23 @@ func (_m *Application) RunJobV2(ctx context.Context, jobID int32, meta map[strin
 	return r0, r1
 }
 
-// RunWebhookJobV2 provides a mock function with given fields: ctx, jobUUID, requestBody, meta
-func (_m *Application) RunWebhookJobV2(ctx context.Context, jobUUID uuid.UUID, requestBody string, meta pipeline.JSONSerializable) (int64, error) {
-	ret := _m.Called(ctx, jobUUID, requestBody, meta)
+// RunWebhookJobV2 provides a mock function with given fields: ctx, jobUUID, requestBody, meta, opts
+func (_m *Application) RunWebhookJobV2(ctx context.Context, jobUUID uuid.UUID, requestBody string, meta pipeline.JSONSerializable, opts webhook.RunJobOpts) (int64, error) {
+	ret := _m.Called(ctx, jobUUID, requestBody, meta, opts)
 
 	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, pipeline.JSONSerializable) (int64, error)); ok {
-		return rf(ctx, jobUUID, requestBody, meta)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, pipeline.JSONSerializable, webhook.RunJobOpts) (int64, error)); ok {
+		return rf(ctx, jobUUID, requestBody, meta, opts)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, pipeline.JSONSerializable) int64); ok {
-		r0 = rf(ctx, jobUUID, requestBody, meta)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, pipeline.JSONSerializable, webhook.RunJobOpts) int64); ok {
+		r0 = rf(ctx, jobUUID, requestBody, meta, opts)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, pipeline.JSONSerializable) error); ok {
-		r1 = rf(ctx, jobUUID, requestBody, meta)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, pipeline.JSONSerializable, webhook.RunJobOpts) error); ok {
+		r1 = rf(ctx, jobUUID, requestBody, meta, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -535,6 +555,22 @@ func (_m *Application) TxmStorageService() txmgrtypes.TxStore[common.Address, *b
 	return r0
 }
 
+// VRFBacklog provides a mock function with given fields:
+func (_m *Application) VRFBacklog() *vrf.Backlog {
+	ret := _m.Called()
+
+	var r0 *vrf.Backlog
+	if rf, ok := ret.Get(0).(func() *vrf.Backlog); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*vrf.Backlog)
+		}
+	}
+
+	return r0
+}
+
 // WakeSessionReaper provides a mock function with given fields:
 func (_m *Application) WakeSessionReaper() {
 	_m.Called()
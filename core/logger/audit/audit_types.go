@@ -9,6 +9,9 @@ const (
 	AuthLoginFailed2FA      EventID = "AUTH_LOGIN_FAILED_2FA"
 	AuthLoginSuccessWith2FA EventID = "AUTH_LOGIN_SUCCESS_WITH_2FA"
 	AuthLoginSuccessNo2FA   EventID = "AUTH_LOGIN_SUCCESS_NO_2FA"
+	AuthLoginFailedOIDC     EventID = "AUTH_LOGIN_FAILED_OIDC"
+	AuthLoginSuccessOIDC    EventID = "AUTH_LOGIN_SUCCESS_OIDC"
+	AuthLoginLocalDisabled  EventID = "AUTH_LOGIN_LOCAL_DISABLED"
 	Auth2FAEnrolled         EventID = "AUTH_2FA_ENROLLED"
 	AuthSessionDeleted      EventID = "SESSION_DELETED"
 
@@ -19,6 +22,7 @@ const (
 	APITokenCreated                       EventID = "API_TOKEN_CREATED"
 	APITokenDeleteAttemptPasswordMismatch EventID = "API_TOKEN_DELETE_ATTEMPT_PASSWORD_MISMATCH"
 	APITokenDeleted                       EventID = "API_TOKEN_DELETED"
+	APITokenUsed                          EventID = "API_TOKEN_USED"
 
 	FeedsManCreated EventID = "FEEDS_MAN_CREATED"
 	FeedsManUpdated EventID = "FEEDS_MAN_UPDATED"
@@ -0,0 +1,67 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/smartcontractkit/chainlink/v2/core/static"
+)
+
+// TracingConfig represents the expected configuration for OpenTelemetry tracing
+type TracingConfig interface {
+	TracingCollectorTarget() string
+	TracingNodeID() string
+	TracingSamplingRatio() float64
+	TracingMode() string
+}
+
+// StartTracing registers a global OpenTelemetry tracer provider exporting spans
+// over OTLP/gRPC, along with the W3C trace context propagator. Until it is
+// called, the global tracer provider is a no-op.
+//
+// The returned provider must be shut down to flush any buffered spans.
+func StartTracing(cfg TracingConfig, lggr Logger) (*sdktrace.TracerProvider, error) {
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.TracingCollectorTarget())}
+	if cfg.TracingMode() == "unencrypted" {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	} else {
+		opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewClientTLSFromCert(nil, "")))
+	}
+	// the connection is established lazily, so an unreachable collector does
+	// not prevent the node from starting
+	exporter, err := otlptracegrpc.New(context.Background(), opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create OTLP trace exporter")
+	}
+
+	_, ver := static.Short()
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName("chainlink-node"),
+		semconv.ServiceVersion(ver),
+		semconv.ServiceInstanceID(cfg.TracingNodeID()),
+	))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create tracing resource")
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.TracingSamplingRatio()))),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	lggr = lggr.Named("Tracing")
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		lggr.Warnw("OpenTelemetry error", "err", err)
+	}))
+	return tp, nil
+}
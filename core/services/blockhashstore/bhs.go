@@ -16,6 +16,8 @@ import (
 
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/txmgr"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/blockhash_store"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/trusted_blockhash_store"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/ethkey"
 	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
@@ -23,32 +25,75 @@ import (
 
 var _ BHS = &BulletproofBHS{}
 
+const (
+	// trustedStoreBaseGas is a conservative estimate of the gas used by a storeTrusted call
+	// regardless of its batch size, including the intrinsic transaction gas.
+	trustedStoreBaseGas = 50_000
+
+	// trustedStoreGasPerBlock is a conservative estimate of the gas used by a storeTrusted call
+	// for every blockhash in the batch.
+	trustedStoreGasPerBlock = 25_000
+)
+
+// TrustedBatchSize returns the number of blockhashes that can be stored in a single
+// storeTrusted transaction, given the configured batch size and gas limit.
+func TrustedBatchSize(batchSize int32, gasLimit uint32) int {
+	if gasLimit <= trustedStoreBaseGas {
+		return 0
+	}
+	maxForGas := int((gasLimit - trustedStoreBaseGas) / trustedStoreGasPerBlock)
+	if int(batchSize) < maxForGas {
+		return int(batchSize)
+	}
+	return maxForGas
+}
+
 type bpBHSConfig interface {
 	EvmGasLimitDefault() uint32
 	DatabaseDefaultQueryTimeout() time.Duration
 }
 
+// blockhashStore is the part of the BlockhashStore and TrustedBlockhashStore wrappers that
+// BulletproofBHS reads and stores blockhashes with.
+type blockhashStore interface {
+	Address() common.Address
+	GetBlockhash(opts *bind.CallOpts, n *big.Int) ([32]byte, error)
+}
+
 // BulletproofBHS is an implementation of BHS that writes "store" transactions to a bulletproof
 // transaction manager, and reads BlockhashStore state from the contract.
+//
+// If a TrustedBlockhashStore is given, it is the target of all reads and writes instead of the
+// BlockhashStore, which is logged on creation, and blockhashes can be stored in batches with
+// StoreTrusted.
 type BulletproofBHS struct {
-	config        bpBHSConfig
-	jobID         uuid.UUID
-	fromAddresses []ethkey.EIP55Address
-	txm           txmgr.EvmTxManager
-	abi           *abi.ABI
-	bhs           blockhash_store.BlockhashStoreInterface
-	chainID       *big.Int
-	gethks        keystore.Eth
+	config             bpBHSConfig
+	jobID              uuid.UUID
+	fromAddresses      []ethkey.EIP55Address
+	txm                txmgr.EvmTxManager
+	abi                *abi.ABI
+	trustedAbi         *abi.ABI
+	bhs                blockhash_store.BlockhashStoreInterface
+	trustedBHS         trusted_blockhash_store.TrustedBlockhashStoreInterface
+	trustedBHSGasLimit uint32
+	chainID            *big.Int
+	gethks             keystore.Eth
+	lggr               logger.Logger
 }
 
 // NewBulletproofBHS creates a new instance with the given transaction manager and blockhash store.
+// trustedBHS may be nil, and a trustedBHSGasLimit of 0 defaults to the chain's default gas limit.
+// If trustedBHS is not nil, it replaces bhs as the target of all reads and writes.
 func NewBulletproofBHS(
 	config bpBHSConfig,
 	fromAddresses []ethkey.EIP55Address,
 	txm txmgr.EvmTxManager,
 	bhs blockhash_store.BlockhashStoreInterface,
+	trustedBHS trusted_blockhash_store.TrustedBlockhashStoreInterface,
+	trustedBHSGasLimit uint32,
 	chainID *big.Int,
 	gethks keystore.Eth,
+	lggr logger.Logger,
 ) (*BulletproofBHS, error) {
 	bhsABI, err := blockhash_store.BlockhashStoreMetaData.GetAbi()
 	if err != nil {
@@ -56,17 +101,45 @@ func NewBulletproofBHS(
 		return nil, errors.Wrap(err, "building ABI")
 	}
 
+	trustedABI, err := trusted_blockhash_store.TrustedBlockhashStoreMetaData.GetAbi()
+	if err != nil {
+		return nil, errors.Wrap(err, "building trusted BHS ABI")
+	}
+
+	if trustedBHSGasLimit == 0 {
+		trustedBHSGasLimit = config.EvmGasLimitDefault()
+	}
+
+	lggr = lggr.Named("BulletproofBHS")
+	if trustedBHS != nil {
+		lggr.Infow("Using the trusted BHS instead of the BHS to read and store blockhashes",
+			"bhsAddress", bhs.Address(), "trustedBHSAddress", trustedBHS.Address())
+	}
+
 	return &BulletproofBHS{
-		config:        config,
-		fromAddresses: fromAddresses,
-		txm:           txm,
-		abi:           bhsABI,
-		bhs:           bhs,
-		chainID:       chainID,
-		gethks:        gethks,
+		config:             config,
+		fromAddresses:      fromAddresses,
+		txm:                txm,
+		abi:                bhsABI,
+		trustedAbi:         trustedABI,
+		bhs:                bhs,
+		trustedBHS:         trustedBHS,
+		trustedBHSGasLimit: trustedBHSGasLimit,
+		chainID:            chainID,
+		gethks:             gethks,
+		lggr:               lggr,
 	}, nil
 }
 
+// target returns the contract that blockhashes are read from and stored into: the trusted BHS
+// if one is configured, and the BHS otherwise.
+func (c *BulletproofBHS) target() blockhashStore {
+	if c.IsTrusted() {
+		return c.trustedBHS
+	}
+	return c.bhs
+}
+
 // Store satisfies the BHS interface.
 func (c *BulletproofBHS) Store(ctx context.Context, blockNum uint64) error {
 	payload, err := c.abi.Pack("store", new(big.Int).SetUint64(blockNum))
@@ -81,7 +154,7 @@ func (c *BulletproofBHS) Store(ctx context.Context, blockNum uint64) error {
 
 	_, err = c.txm.CreateEthTransaction(txmgr.EvmNewTx{
 		FromAddress:    fromAddress,
-		ToAddress:      c.bhs.Address(),
+		ToAddress:      c.target().Address(),
 		EncodedPayload: payload,
 		FeeLimit:       c.config.EvmGasLimitDefault(),
 
@@ -96,9 +169,63 @@ func (c *BulletproofBHS) Store(ctx context.Context, blockNum uint64) error {
 	return nil
 }
 
+// IsTrusted satisfies the BHS interface.
+func (c *BulletproofBHS) IsTrusted() bool {
+	return c.trustedBHS != nil
+}
+
+// StoreTrusted satisfies the BHS interface.
+func (c *BulletproofBHS) StoreTrusted(
+	ctx context.Context,
+	blockNums []uint64,
+	blockhashes []common.Hash,
+	recentBlock uint64,
+	recentBlockhash common.Hash,
+) error {
+	if !c.IsTrusted() {
+		return errors.New("no trusted BHS configured")
+	}
+	if len(blockNums) != len(blockhashes) {
+		return errors.Errorf("got %d blockhashes for %d blocks", len(blockhashes), len(blockNums))
+	}
+
+	nums := make([]*big.Int, len(blockNums))
+	hashes := make([][32]byte, len(blockhashes))
+	for i := range blockNums {
+		nums[i] = new(big.Int).SetUint64(blockNums[i])
+		hashes[i] = blockhashes[i]
+	}
+	payload, err := c.trustedAbi.Pack("storeTrusted", nums, hashes,
+		new(big.Int).SetUint64(recentBlock), [32]byte(recentBlockhash))
+	if err != nil {
+		return errors.Wrap(err, "packing args")
+	}
+
+	fromAddress, err := c.gethks.GetRoundRobinAddress(c.chainID, SendingKeys(c.fromAddresses)...)
+	if err != nil {
+		return errors.Wrap(err, "getting next from address")
+	}
+
+	_, err = c.txm.CreateEthTransaction(txmgr.EvmNewTx{
+		FromAddress:    fromAddress,
+		ToAddress:      c.trustedBHS.Address(),
+		EncodedPayload: payload,
+		FeeLimit:       c.trustedBHSGasLimit,
+
+		// A batch is sent at most once per feeder run, so the queue only needs to cover the
+		// runs that fit in the lookback window.
+		Strategy: txmgr.NewQueueingTxStrategy(c.jobID, 256, c.config.DatabaseDefaultQueryTimeout()),
+	}, pg.WithParentCtx(ctx))
+	if err != nil {
+		return errors.Wrap(err, "creating transaction")
+	}
+
+	return nil
+}
+
 // IsStored satisfies the BHS interface.
 func (c *BulletproofBHS) IsStored(ctx context.Context, blockNum uint64) (bool, error) {
-	_, err := c.bhs.GetBlockhash(&bind.CallOpts{Context: ctx}, big.NewInt(int64(blockNum)))
+	_, err := c.target().GetBlockhash(&bind.CallOpts{Context: ctx}, big.NewInt(int64(blockNum)))
 	if err != nil && strings.Contains(err.Error(), "reverted") {
 		// Transaction reverted because the blockhash is not stored
 		return false, nil
@@ -129,7 +256,7 @@ func (c *BulletproofBHS) StoreEarliest(ctx context.Context) error {
 
 	_, err = c.txm.CreateEthTransaction(txmgr.EvmNewTx{
 		FromAddress:    fromAddress,
-		ToAddress:      c.bhs.Address(),
+		ToAddress:      c.target().Address(),
 		EncodedPayload: payload,
 		FeeLimit:       c.config.EvmGasLimitDefault(),
 		Strategy:       txmgr.NewSendEveryStrategy(),
@@ -11,6 +11,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/txmgr"
 	txmmocks "github.com/smartcontractkit/chainlink/v2/core/chains/evm/txmgr/mocks"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/blockhash_store"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/trusted_blockhash_store"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
 	configtest "github.com/smartcontractkit/chainlink/v2/core/internal/testutils/configtest/v2"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/evmtest"
@@ -49,8 +50,11 @@ func TestStoreRotatesFromAddresses(t *testing.T) {
 		fromAddresses,
 		txm,
 		store,
+		nil,
+		0,
 		&cltest.FixtureChainID,
 		ks.Eth(),
+		lggr,
 	)
 	require.NoError(t, err)
 
@@ -68,3 +72,48 @@ func TestStoreRotatesFromAddresses(t *testing.T) {
 	err = bhs.Store(context.Background(), 2)
 	require.NoError(t, err)
 }
+
+func TestStoreTargetsTrustedBHS(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	ethClient := evmtest.NewEthClientMockWithDefaultChain(t)
+	cfg := configtest.NewTestGeneralConfig(t)
+	kst := cltest.NewKeyStore(t, db, cfg)
+	require.NoError(t, kst.Unlock(cltest.Password))
+	chainSet := evmtest.NewChainSet(t, evmtest.TestChainOpts{DB: db, KeyStore: kst.Eth(), GeneralConfig: cfg, Client: ethClient})
+	chain, err := chainSet.Get(&cltest.FixtureChainID)
+	require.NoError(t, err)
+	lggr := logger.TestLogger(t)
+	ks := keystore.New(db, utils.FastScryptParams, lggr, cfg)
+	require.NoError(t, ks.Unlock("blah"))
+	k, err := ks.Eth().Create(&cltest.FixtureChainID)
+	require.NoError(t, err)
+	txm := new(txmmocks.MockEvmTxManager)
+	bhsAddress := common.HexToAddress("0x31Ca8bf590360B3198749f852D5c516c642846F6")
+	trustedAddress := common.HexToAddress("0x5A4E2C5d1e7A5E7C5a7F3A5b1D6E2f8C9B0A1D2E")
+
+	store, err := blockhash_store.NewBlockhashStore(bhsAddress, chain.Client())
+	require.NoError(t, err)
+	trustedStore, err := trusted_blockhash_store.NewTrustedBlockhashStore(trustedAddress, chain.Client())
+	require.NoError(t, err)
+	bhs, err := blockhashstore.NewBulletproofBHS(
+		chain.Config(),
+		[]ethkey.EIP55Address{k.EIP55Address},
+		txm,
+		store,
+		trustedStore,
+		0,
+		&cltest.FixtureChainID,
+		ks.Eth(),
+		lggr,
+	)
+	require.NoError(t, err)
+	require.True(t, bhs.IsTrusted())
+
+	txm.On("CreateEthTransaction", mock.MatchedBy(func(tx txmgr.EvmNewTx) bool {
+		return tx.ToAddress == trustedAddress
+	}), mock.Anything).Twice().Return(txmgr.EvmTx{}, nil)
+
+	require.NoError(t, bhs.Store(context.Background(), 1))
+	require.NoError(t, bhs.StoreEarliest(context.Background()))
+	txm.AssertExpectations(t)
+}
@@ -25,10 +25,12 @@ type Coordinator interface {
 // Event contains metadata about a VRF randomness request or fulfillment.
 type Event struct {
 	// ID of the relevant VRF request. For a VRF V1 request, this will an encoded 32 byte array.
-	// For VRF V2, it will be an integer in string form.
+	// For VRF V2, it will be an integer in string form. For OCR2VRF, it will be the beacon output
+	// height and confirmation delay of the request, separated by a dash.
 	ID string
 
-	// Block that the request or fulfillment was included in.
+	// Block that the request or fulfillment was included in. For OCR2VRF, it is the beacon
+	// output height of the request instead.
 	Block uint64
 }
 
@@ -42,6 +44,13 @@ type BHS interface {
 
 	// StoreEarliest stores the earliest possible blockhash (i.e. block.number - 256)
 	StoreEarliest(ctx context.Context) error
+
+	// IsTrusted returns whether the BHS is a trusted BHS, i.e. supports StoreTrusted.
+	IsTrusted() bool
+
+	// StoreTrusted stores the given blockhashes in a single transaction. The contract checks
+	// that recentBlockhash is the hash of recentBlock to reject batches built on another fork.
+	StoreTrusted(ctx context.Context, blockNums []uint64, blockhashes []common.Hash, recentBlock uint64, recentBlockhash common.Hash) error
 }
 
 func GetUnfulfilledBlocksAndRequests(
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
@@ -11,6 +12,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
 	v1 "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/solidity_vrf_coordinator_interface"
 	v2 "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/vrf_coordinator_v2"
+	ocr2vrf "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ocr2vrf/generated/vrf_coordinator"
 	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
 )
 
@@ -18,6 +20,7 @@ var (
 	_ Coordinator = MultiCoordinator{}
 	_ Coordinator = &V1Coordinator{}
 	_ Coordinator = &V2Coordinator{}
+	_ Coordinator = &OCR2VRFCoordinator{}
 )
 
 // MultiCoordinator combines the data from multiple coordinators.
@@ -241,3 +244,129 @@ func (v *V2Coordinator) Fulfillments(ctx context.Context, fromBlock uint64) ([]E
 	}
 	return fuls, nil
 }
+
+// OCR2VRFCoordinator fetches request and fulfillment logs from an OCR2VRF coordinator contract.
+//
+// OCR2VRF requests are fulfilled by the beacon output of the block at their next beacon output
+// height, so that is the block of a request, and the requests for the same height and
+// confirmation delay share an ID. They are fulfilled when the beacon serves that output.
+type OCR2VRFCoordinator struct {
+	c  ocr2vrf.VRFCoordinatorInterface
+	lp logpoller.LogPoller
+
+	// beaconPeriodBlocks is the maximum number of blocks between a request and its beacon output
+	// height.
+	beaconPeriodBlocks uint64
+}
+
+// NewOCR2VRFCoordinator creates a new OCR2VRFCoordinator from the given contract.
+func NewOCR2VRFCoordinator(c ocr2vrf.VRFCoordinatorInterface, lp logpoller.LogPoller) (*OCR2VRFCoordinator, error) {
+	beaconPeriodBlocks, err := c.IBeaconPeriodBlocks(nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching beacon period")
+	}
+
+	err = lp.RegisterFilter(logpoller.Filter{
+		Name: logpoller.FilterName("OCR2VRFCoordinatorFeeder", c.Address()),
+		EventSigs: []common.Hash{
+			ocr2vrf.VRFCoordinatorRandomnessRequested{}.Topic(),
+			ocr2vrf.VRFCoordinatorRandomnessFulfillmentRequested{}.Topic(),
+			ocr2vrf.VRFCoordinatorOutputsServed{}.Topic(),
+		}, Addresses: []common.Address{c.Address()},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &OCR2VRFCoordinator{c: c, lp: lp, beaconPeriodBlocks: beaconPeriodBlocks.Uint64()}, nil
+}
+
+// Requests satisfies the Coordinator interface. It returns the requests whose beacon output
+// height is within the specified blocks, which were made up to a beacon period earlier.
+func (v *OCR2VRFCoordinator) Requests(
+	ctx context.Context,
+	fromBlock uint64,
+	toBlock uint64,
+) ([]Event, error) {
+	logsFromBlock := uint64(0)
+	if fromBlock > v.beaconPeriodBlocks {
+		logsFromBlock = fromBlock - v.beaconPeriodBlocks
+	}
+	logs, err := v.lp.LogsWithSigs(
+		int64(logsFromBlock),
+		int64(toBlock),
+		[]common.Hash{
+			ocr2vrf.VRFCoordinatorRandomnessRequested{}.Topic(),
+			ocr2vrf.VRFCoordinatorRandomnessFulfillmentRequested{}.Topic(),
+		},
+		v.c.Address(),
+		pg.WithParentCtx(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "filter ocr2vrf requests")
+	}
+
+	var reqs []Event
+	for _, l := range logs {
+		requestLog, err := v.c.ParseLog(l.ToGethLog())
+		if err != nil {
+			continue // malformed log should not break flow
+		}
+		var height uint64
+		var confDelay *big.Int
+		switch request := requestLog.(type) {
+		case *ocr2vrf.VRFCoordinatorRandomnessRequested:
+			height, confDelay = request.NextBeaconOutputHeight, request.ConfDelay
+		case *ocr2vrf.VRFCoordinatorRandomnessFulfillmentRequested:
+			height, confDelay = request.NextBeaconOutputHeight, request.ConfDelay
+		default:
+			continue // malformed log should not break flow
+		}
+		if height < fromBlock || height > toBlock {
+			continue
+		}
+		reqs = append(reqs, Event{ID: ocr2vrfEventID(height, confDelay), Block: height})
+	}
+
+	return reqs, nil
+}
+
+// Fulfillments satisfies the Coordinator interface.
+func (v *OCR2VRFCoordinator) Fulfillments(ctx context.Context, fromBlock uint64) ([]Event, error) {
+	toBlock, err := v.lp.LatestBlock()
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching latest block")
+	}
+
+	logs, err := v.lp.LogsWithSigs(
+		int64(fromBlock),
+		int64(toBlock),
+		[]common.Hash{
+			ocr2vrf.VRFCoordinatorOutputsServed{}.Topic(),
+		},
+		v.c.Address(),
+		pg.WithParentCtx(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "filter ocr2vrf fulfillments")
+	}
+
+	var fuls []Event
+	for _, l := range logs {
+		outputsLog, err := v.c.ParseLog(l.ToGethLog())
+		if err != nil {
+			continue // malformed log should not break flow
+		}
+		outputs, ok := outputsLog.(*ocr2vrf.VRFCoordinatorOutputsServed)
+		if !ok {
+			continue // malformed log should not break flow
+		}
+		for _, output := range outputs.OutputsServed {
+			fuls = append(fuls, Event{ID: ocr2vrfEventID(output.Height, output.ConfirmationDelay), Block: output.Height})
+		}
+	}
+	return fuls, nil
+}
+
+// ocr2vrfEventID returns the ID of the beacon output of the given height and confirmation delay.
+func ocr2vrfEventID(height uint64, confDelay *big.Int) string {
+	return fmt.Sprintf("%d-%s", height, confDelay)
+}
@@ -3,14 +3,21 @@ package blockhashstore
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/pkg/errors"
 
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm"
+	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/blockhash_store"
 	v1 "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/solidity_vrf_coordinator_interface"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/trusted_blockhash_store"
 	v2 "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/vrf_coordinator_v2"
+	ocr2vrf "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ocr2vrf/generated/vrf_coordinator"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore"
@@ -87,6 +94,25 @@ func (d *Delegate) ServicesForSpec(jb job.Job) ([]job.ServiceCtx, error) {
 		return nil, errors.Wrap(err, "building BHS")
 	}
 
+	var trustedBHS trusted_blockhash_store.TrustedBlockhashStoreInterface
+	var trustedBatchSize int
+	if jb.BlockhashStoreSpec.TrustedBlockhashStoreAddress != nil {
+		if trustedBHS, err = trusted_blockhash_store.NewTrustedBlockhashStore(
+			jb.BlockhashStoreSpec.TrustedBlockhashStoreAddress.Address(), chain.Client()); err != nil {
+
+			return nil, errors.Wrap(err, "building trusted BHS")
+		}
+
+		gasLimit := jb.BlockhashStoreSpec.TrustedBlockhashStoreGasLimit
+		if gasLimit == 0 {
+			gasLimit = chain.Config().EvmGasLimitDefault()
+		}
+		trustedBatchSize = TrustedBatchSize(jb.BlockhashStoreSpec.TrustedBlockhashStoreBatchSize, gasLimit)
+		if trustedBatchSize == 0 {
+			return nil, errors.Errorf("trusted BHS gas limit %d is too low to store a single blockhash", gasLimit)
+		}
+	}
+
 	lp := chain.LogPoller()
 	var coordinators []Coordinator
 	if jb.BlockhashStoreSpec.CoordinatorV1Address != nil {
@@ -119,17 +145,34 @@ func (d *Delegate) ServicesForSpec(jb job.Job) ([]job.ServiceCtx, error) {
 		}
 		coordinators = append(coordinators, coord)
 	}
+	if jb.BlockhashStoreSpec.CoordinatorOCR2VRFAddress != nil {
+		var c *ocr2vrf.VRFCoordinator
+		if c, err = ocr2vrf.NewVRFCoordinator(
+			jb.BlockhashStoreSpec.CoordinatorOCR2VRFAddress.Address(), chain.Client()); err != nil {
 
-	bpBHS, err := NewBulletproofBHS(chain.Config(), fromAddresses, chain.TxManager(), bhs, chain.ID(), d.ks)
+			return nil, errors.Wrap(err, "building OCR2VRF coordinator")
+		}
+
+		var coord *OCR2VRFCoordinator
+		coord, err = NewOCR2VRFCoordinator(c, lp)
+		if err != nil {
+			return nil, errors.Wrap(err, "building OCR2VRF coordinator")
+		}
+		coordinators = append(coordinators, coord)
+	}
+
+	log := d.logger.Named("BHS Feeder").With("jobID", jb.ID, "externalJobID", jb.ExternalJobID)
+	bpBHS, err := NewBulletproofBHS(chain.Config(), fromAddresses, chain.TxManager(), bhs, trustedBHS,
+		jb.BlockhashStoreSpec.TrustedBlockhashStoreGasLimit, chain.ID(), d.ks, log)
 	if err != nil {
 		return nil, errors.Wrap(err, "building bulletproof bhs")
 	}
 
-	log := d.logger.Named("BHS Feeder").With("jobID", jb.ID, "externalJobID", jb.ExternalJobID)
 	feeder := NewFeeder(
 		log,
 		NewMultiCoordinator(coordinators...),
 		bpBHS,
+		trustedBatchSize,
 		int(jb.BlockhashStoreSpec.WaitBlocks),
 		int(jb.BlockhashStoreSpec.LookbackBlocks),
 		func(ctx context.Context) (uint64, error) {
@@ -138,6 +181,9 @@ func (d *Delegate) ServicesForSpec(jb job.Job) ([]job.ServiceCtx, error) {
 				return 0, errors.Wrap(err, "getting chain head")
 			}
 			return uint64(head.Number), nil
+		},
+		func(ctx context.Context, blockNums []uint64) ([]common.Hash, error) {
+			return getBlockhashes(ctx, chain.Client().BatchCallContext, blockNums)
 		})
 
 	return []job.ServiceCtx{&service{
@@ -217,3 +263,35 @@ func (s *service) runFeeder() {
 			"error", err)
 	}
 }
+
+// getBlockhashes fetches the hashes of the given blocks in a single batch call.
+func getBlockhashes(
+	ctx context.Context,
+	batchCall func(ctx context.Context, b []rpc.BatchElem) error,
+	blockNums []uint64,
+) ([]common.Hash, error) {
+	reqs := make([]rpc.BatchElem, len(blockNums))
+	for i, num := range blockNums {
+		reqs[i] = rpc.BatchElem{
+			Method: "eth_getBlockByNumber",
+			Args:   []interface{}{hexutil.EncodeBig(new(big.Int).SetUint64(num)), false},
+			Result: &evmtypes.Head{},
+		}
+	}
+	if err := batchCall(ctx, reqs); err != nil {
+		return nil, errors.Wrap(err, "batch fetching blocks")
+	}
+
+	hashes := make([]common.Hash, len(blockNums))
+	for i, req := range reqs {
+		if req.Error != nil {
+			return nil, errors.Wrapf(req.Error, "fetching block %d", blockNums[i])
+		}
+		head, ok := req.Result.(*evmtypes.Head)
+		if !ok || head.Hash == (common.Hash{}) {
+			return nil, errors.Errorf("block %d not found", blockNums[i])
+		}
+		hashes[i] = head.Hash
+	}
+	return hashes, nil
+}
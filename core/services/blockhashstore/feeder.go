@@ -2,43 +2,67 @@ package blockhashstore
 
 import (
 	"context"
+	"sort"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"go.uber.org/multierr"
 
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 )
 
+var (
+	promBlocksStored = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "blockhash_store_blocks_stored",
+		Help: "Number of blockhashes the blockhash store feeder sent store transactions for",
+	})
+	promBlocksMissed = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "blockhash_store_blocks_missed",
+		Help: "Number of blockhashes the blockhash store feeder needed to store but failed to",
+	})
+)
+
 // NewFeeder creates a new Feeder instance.
+//
+// If bhs is trusted, the blockhashes of all blocks that need storing in a run are stored in
+// batches of at most trustedBHSBatchSize blocks, using getBlockhashes to look them up.
 func NewFeeder(
 	logger logger.Logger,
 	coordinator Coordinator,
 	bhs BHS,
+	trustedBHSBatchSize int,
 	waitBlocks int,
 	lookbackBlocks int,
 	latestBlock func(ctx context.Context) (uint64, error),
+	getBlockhashes func(ctx context.Context, blockNums []uint64) ([]common.Hash, error),
 ) *Feeder {
 	return &Feeder{
-		lggr:           logger,
-		coordinator:    coordinator,
-		bhs:            bhs,
-		waitBlocks:     waitBlocks,
-		lookbackBlocks: lookbackBlocks,
-		latestBlock:    latestBlock,
-		stored:         make(map[uint64]struct{}),
-		lastRunBlock:   0,
+		lggr:                logger,
+		coordinator:         coordinator,
+		bhs:                 bhs,
+		trustedBHSBatchSize: trustedBHSBatchSize,
+		waitBlocks:          waitBlocks,
+		lookbackBlocks:      lookbackBlocks,
+		latestBlock:         latestBlock,
+		getBlockhashes:      getBlockhashes,
+		stored:              make(map[uint64]struct{}),
+		lastRunBlock:        0,
 	}
 }
 
 // Feeder checks recent VRF coordinator events and stores any blockhashes for blocks within
 // waitBlocks and lookbackBlocks that have unfulfilled requests.
 type Feeder struct {
-	lggr           logger.Logger
-	coordinator    Coordinator
-	bhs            BHS
-	waitBlocks     int
-	lookbackBlocks int
-	latestBlock    func(ctx context.Context) (uint64, error)
+	lggr                logger.Logger
+	coordinator         Coordinator
+	bhs                 BHS
+	trustedBHSBatchSize int
+	waitBlocks          int
+	lookbackBlocks      int
+	latestBlock         func(ctx context.Context) (uint64, error)
+	getBlockhashes      func(ctx context.Context, blockNums []uint64) ([]common.Hash, error)
 
 	stored       map[uint64]struct{}
 	lastRunBlock uint64
@@ -65,6 +89,7 @@ func (f *Feeder) Run(ctx context.Context) error {
 	}
 
 	var errs error
+	var missing []uint64
 	for block, unfulfilledReqs := range blockToRequests {
 		if len(unfulfilledReqs) == 0 {
 			continue
@@ -88,17 +113,28 @@ func (f *Feeder) Run(ctx context.Context) error {
 		}
 
 		// Block needs to be stored
-		err = f.bhs.Store(ctx, block)
-		if err != nil {
-			f.lggr.Errorw("Failed to store block", "error", err, "block", block)
-			errs = multierr.Append(errs, errors.Wrap(err, "storing block"))
-			continue
-		}
+		missing = append(missing, block)
+	}
+	sort.Slice(missing, func(i, j int) bool { return missing[i] < missing[j] })
+
+	if f.bhs.IsTrusted() {
+		errs = multierr.Append(errs, f.storeTrusted(ctx, lggr, latestBlock, missing))
+	} else {
+		for _, block := range missing {
+			err = f.bhs.Store(ctx, block)
+			if err != nil {
+				f.lggr.Errorw("Failed to store block", "error", err, "block", block)
+				errs = multierr.Append(errs, errors.Wrap(err, "storing block"))
+				promBlocksMissed.Inc()
+				continue
+			}
 
-		f.lggr.Infow("Stored blockhash",
-			"block", block, "latestBlock", latestBlock,
-			"unfulfilledReqIDs", LimitReqIDs(unfulfilledReqs, 50))
-		f.stored[block] = struct{}{}
+			f.lggr.Infow("Stored blockhash",
+				"block", block, "latestBlock", latestBlock,
+				"unfulfilledReqIDs", LimitReqIDs(blockToRequests[block], 50))
+			f.stored[block] = struct{}{}
+			promBlocksStored.Inc()
+		}
 	}
 
 	if f.lastRunBlock != 0 {
@@ -114,3 +150,51 @@ func (f *Feeder) Run(ctx context.Context) error {
 	f.lastRunBlock = latestBlock
 	return errs
 }
+
+// storeTrusted stores the blockhashes of the given blocks in batches of at most
+// trustedBHSBatchSize blocks, each anchored to the hash of latestBlock.
+func (f *Feeder) storeTrusted(ctx context.Context, lggr logger.Logger, latestBlock uint64, blocks []uint64) error {
+	if len(blocks) == 0 {
+		return nil
+	}
+	if f.trustedBHSBatchSize <= 0 {
+		promBlocksMissed.Add(float64(len(blocks)))
+		return errors.New("trusted BHS batch size must be positive")
+	}
+
+	var errs error
+	for start := 0; start < len(blocks); start += f.trustedBHSBatchSize {
+		end := start + f.trustedBHSBatchSize
+		if end > len(blocks) {
+			end = len(blocks)
+		}
+		batch := blocks[start:end]
+
+		// Look up the batch's blockhashes together with the recent blockhash.
+		hashes, err := f.getBlockhashes(ctx, append(append([]uint64{}, batch...), latestBlock))
+		if err == nil && len(hashes) != len(batch)+1 {
+			err = errors.Errorf("got %d blockhashes for %d blocks", len(hashes), len(batch)+1)
+		}
+		if err != nil {
+			lggr.Errorw("Failed to fetch blockhashes", "error", err, "blocks", batch)
+			errs = multierr.Append(errs, errors.Wrap(err, "fetching blockhashes"))
+			promBlocksMissed.Add(float64(len(batch)))
+			continue
+		}
+
+		err = f.bhs.StoreTrusted(ctx, batch, hashes[:len(batch)], latestBlock, hashes[len(batch)])
+		if err != nil {
+			lggr.Errorw("Failed to store trusted blockhashes", "error", err, "blocks", batch)
+			errs = multierr.Append(errs, errors.Wrap(err, "storing trusted blockhashes"))
+			promBlocksMissed.Add(float64(len(batch)))
+			continue
+		}
+
+		lggr.Infow("Stored trusted blockhashes", "blocks", batch)
+		for _, block := range batch {
+			f.stored[block] = struct{}{}
+		}
+		promBlocksStored.Add(float64(len(batch)))
+	}
+	return errs
+}
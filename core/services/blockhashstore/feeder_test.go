@@ -8,6 +8,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
@@ -19,6 +20,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/solidity_vrf_coordinator_interface"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/vrf_coordinator_v2"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ocr2vrf/generated/vrf_coordinator"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 )
@@ -28,6 +30,7 @@ const (
 	randomWordsRequestedV2              string = "RandomWordsRequested"
 	randomWordsFulfilledV2              string = "RandomWordsFulfilled"
 	randomWordsRequestedV1              string = "RandomnessRequest"
+	randomnessRequestedEvent            string = "RandomnessRequested"
 	randomWordsFulfilledV1              string = "RandomnessRequestFulfilled"
 	randomnessFulfillmentRequestedEvent string = "RandomnessFulfillmentRequested"
 	randomWordsFulfilledEvent           string = "RandomWordsFulfilled"
@@ -36,8 +39,9 @@ const (
 )
 
 var (
-	vrfCoordinatorV2ABI = evmtypes.MustGetABI(vrf_coordinator_v2.VRFCoordinatorV2MetaData.ABI)
-	vrfCoordinatorV1ABI = evmtypes.MustGetABI(solidity_vrf_coordinator_interface.VRFCoordinatorMetaData.ABI)
+	vrfCoordinatorV2ABI   = evmtypes.MustGetABI(vrf_coordinator_v2.VRFCoordinatorV2MetaData.ABI)
+	vrfCoordinatorV1ABI   = evmtypes.MustGetABI(solidity_vrf_coordinator_interface.VRFCoordinatorMetaData.ABI)
+	ocr2vrfCoordinatorABI = evmtypes.MustGetABI(vrf_coordinator.VRFCoordinatorMetaData.ABI)
 
 	_     Coordinator = &TestCoordinator{}
 	_     BHS         = &TestBHS{}
@@ -229,11 +233,13 @@ func TestFeeder(t *testing.T) {
 				logger.TestLogger(t),
 				coordinator,
 				&test.bhs,
+				0,
 				test.wait,
 				test.lookback,
 				func(ctx context.Context) (uint64, error) {
 					return test.latest, nil
-				})
+				},
+				nil)
 
 			err := feeder.Run(testutils.Context(t))
 			if test.expectedErrMsg == "" {
@@ -318,11 +324,13 @@ func TestFeederWithLogPollerVRFv1(t *testing.T) {
 				logger.TestLogger(t),
 				coordinator,
 				&test.bhs,
+				0,
 				test.wait,
 				test.lookback,
 				func(ctx context.Context) (uint64, error) {
 					return test.latest, nil
-				})
+				},
+				nil)
 
 			// Run feeder and assert correct results.
 			err = feeder.Run(testutils.Context(t))
@@ -411,11 +419,13 @@ func TestFeederWithLogPollerVRFv2(t *testing.T) {
 				logger.TestLogger(t),
 				coordinator,
 				&test.bhs,
+				0,
 				test.wait,
 				test.lookback,
 				func(ctx context.Context) (uint64, error) {
 					return test.latest, nil
-				})
+				},
+				nil)
 
 			// Run feeder and assert correct results.
 			err = feeder.Run(testutils.Context(t))
@@ -429,6 +439,75 @@ func TestFeederWithLogPollerVRFv2(t *testing.T) {
 	}
 }
 
+func TestFeederWithLogPollerOCR2VRF(t *testing.T) {
+	coordinatorAddress := common.HexToAddress("0x514910771AF9Ca656af840dff83E8264EcF986CA")
+	lp := &mocklp.LogPoller{}
+	c, err := vrf_coordinator.NewVRFCoordinator(coordinatorAddress, nil)
+	require.NoError(t, err)
+	coordinator := &OCR2VRFCoordinator{
+		c:                  c,
+		lp:                 lp,
+		beaconPeriodBlocks: 10,
+	}
+
+	// The search window is [100, 175]. Requests are looked up a beacon period earlier, and only
+	// kept if their beacon output height is within the window.
+	requestLogs := []logpoller.Log{
+		// Made before the window, for an unfulfilled output in it.
+		newOCR2VRFRequestLog(t, randomnessRequestedEvent, 95, 100, 3, coordinatorAddress),
+		// Fulfilled by the served output.
+		newOCR2VRFRequestLog(t, randomnessFulfillmentRequestedEvent, 120, 130, 3, coordinatorAddress),
+		// Unfulfilled, the output of a different confirmation delay was served.
+		newOCR2VRFRequestLog(t, randomnessRequestedEvent, 135, 140, 6, coordinatorAddress),
+		// Output height after the window.
+		newOCR2VRFRequestLog(t, randomnessRequestedEvent, 170, 180, 3, coordinatorAddress),
+	}
+	outputsLogs := []logpoller.Log{
+		newOCR2VRFOutputsServedLog(t, 150, coordinatorAddress,
+			vrf_coordinator.VRFBeaconTypesOutputServed{Height: 130, ConfirmationDelay: big.NewInt(3), ProofG1X: big.NewInt(0), ProofG1Y: big.NewInt(0)},
+			vrf_coordinator.VRFBeaconTypesOutputServed{Height: 140, ConfirmationDelay: big.NewInt(3), ProofG1X: big.NewInt(0), ProofG1Y: big.NewInt(0)}),
+	}
+
+	lp.On("LatestBlock", mock.Anything).Return(int64(200), nil)
+	lp.On(
+		"LogsWithSigs",
+		int64(90),
+		int64(175),
+		[]common.Hash{
+			vrf_coordinator.VRFCoordinatorRandomnessRequested{}.Topic(),
+			vrf_coordinator.VRFCoordinatorRandomnessFulfillmentRequested{}.Topic(),
+		},
+		coordinatorAddress,
+		mock.Anything,
+	).Return(requestLogs, nil)
+	lp.On(
+		"LogsWithSigs",
+		int64(100),
+		int64(200),
+		[]common.Hash{
+			vrf_coordinator.VRFCoordinatorOutputsServed{}.Topic(),
+		},
+		coordinatorAddress,
+		mock.Anything,
+	).Return(outputsLogs, nil)
+
+	bhs := &TestBHS{}
+	feeder := NewFeeder(
+		logger.TestLogger(t),
+		coordinator,
+		bhs,
+		0,
+		25,
+		100,
+		func(ctx context.Context) (uint64, error) {
+			return 200, nil
+		},
+		nil)
+
+	require.NoError(t, feeder.Run(testutils.Context(t)))
+	require.ElementsMatch(t, []uint64{100, 140}, bhs.Stored)
+}
+
 func TestFeeder_CachesStoredBlocks(t *testing.T) {
 	coordinator := &TestCoordinator{
 		RequestEvents: []Event{{Block: 100, ID: "1000"}},
@@ -440,11 +519,13 @@ func TestFeeder_CachesStoredBlocks(t *testing.T) {
 		logger.TestLogger(t),
 		coordinator,
 		bhs,
+		0,
 		100,
 		200,
 		func(ctx context.Context) (uint64, error) {
 			return 250, nil
-		})
+		},
+		nil)
 
 	// Should store block 100
 	require.NoError(t, feeder.Run(testutils.Context(t)))
@@ -464,6 +545,92 @@ func TestFeeder_CachesStoredBlocks(t *testing.T) {
 	require.Empty(t, feeder.stored)
 }
 
+func TestFeeder_StoresTrustedBatches(t *testing.T) {
+	coordinator := &TestCoordinator{
+		RequestEvents: []Event{
+			{Block: 150, ID: "1000"},
+			{Block: 153, ID: "1001"},
+			{Block: 151, ID: "1002"},
+			{Block: 155, ID: "1003"},
+			{Block: 152, ID: "1004"},
+		},
+		FulfillmentEvents: []Event{{Block: 160, ID: "1004"}},
+	}
+	bhs := &TestBHS{Trusted: true, Stored: []uint64{153}}
+
+	var fetched [][]uint64
+	feeder := NewFeeder(
+		logger.TestLogger(t),
+		coordinator,
+		bhs,
+		2,
+		25,
+		100,
+		func(ctx context.Context) (uint64, error) {
+			return 200, nil
+		},
+		func(ctx context.Context, blockNums []uint64) ([]common.Hash, error) {
+			fetched = append(fetched, blockNums)
+			hashes := make([]common.Hash, len(blockNums))
+			for i, num := range blockNums {
+				hashes[i] = common.BigToHash(new(big.Int).SetUint64(num))
+			}
+			return hashes, nil
+		})
+
+	require.NoError(t, feeder.Run(testutils.Context(t)))
+	require.Equal(t, [][]uint64{{150, 151}, {155}}, bhs.TrustedBatches)
+	// The recent block is fetched along with every batch.
+	require.Equal(t, [][]uint64{{150, 151, 200}, {155, 200}}, fetched)
+
+	// Stored blocks are cached.
+	require.NoError(t, feeder.Run(testutils.Context(t)))
+	require.Len(t, bhs.TrustedBatches, 2)
+}
+
+func TestFeeder_TrustedBatchErrors(t *testing.T) {
+	coordinator := &TestCoordinator{
+		RequestEvents: []Event{{Block: 150, ID: "1000"}, {Block: 151, ID: "1001"}},
+	}
+
+	t.Run("fetching blockhashes", func(t *testing.T) {
+		bhs := &TestBHS{Trusted: true}
+		feeder := NewFeeder(logger.TestLogger(t), coordinator, bhs, 10, 25, 100,
+			func(ctx context.Context) (uint64, error) {
+				return 200, nil
+			},
+			func(ctx context.Context, blockNums []uint64) ([]common.Hash, error) {
+				return make([]common.Hash, len(blockNums)-1), nil
+			})
+
+		require.EqualError(t, feeder.Run(testutils.Context(t)), "fetching blockhashes: got 2 blockhashes for 3 blocks")
+		require.Empty(t, bhs.Stored)
+		require.Empty(t, feeder.stored)
+	})
+
+	t.Run("storing", func(t *testing.T) {
+		bhs := &TestBHS{Trusted: true, ErrorStoreTrusted: errors.New("reverted")}
+		feeder := NewFeeder(logger.TestLogger(t), coordinator, bhs, 10, 25, 100,
+			func(ctx context.Context) (uint64, error) {
+				return 200, nil
+			},
+			func(ctx context.Context, blockNums []uint64) ([]common.Hash, error) {
+				return make([]common.Hash, len(blockNums)), nil
+			})
+
+		require.EqualError(t, feeder.Run(testutils.Context(t)), "storing trusted blockhashes: reverted")
+		require.Empty(t, feeder.stored)
+	})
+}
+
+func TestTrustedBatchSize(t *testing.T) {
+	require.Equal(t, 100, TrustedBatchSize(100, 30_000_000))
+	require.Equal(t, 18, TrustedBatchSize(100, 500_000))
+	require.Equal(t, 1, TrustedBatchSize(100, 75_000))
+	require.Equal(t, 0, TrustedBatchSize(100, 74_999))
+	require.Equal(t, 0, TrustedBatchSize(100, 10_000))
+}
+
 func newRandomnessRequestedLogV1(
 	t *testing.T,
 	requestBlock uint64,
@@ -697,3 +864,70 @@ func newRandomnessFulfilledLogV2(
 	}
 	return lg
 }
+
+func newOCR2VRFRequestLog(
+	t *testing.T,
+	event string,
+	requestBlock uint64,
+	height uint64,
+	confDelay int64,
+	coordinatorAddress common.Address,
+) logpoller.Log {
+	var unindexed abi.Arguments
+	var indexed abi.Arguments
+	for _, a := range ocr2vrfCoordinatorABI.Events[event].Inputs {
+		if a.Indexed {
+			indexed = append(indexed, a)
+		} else {
+			unindexed = append(unindexed, a)
+		}
+	}
+	values := []interface{}{height, big.NewInt(confDelay), big.NewInt(1), uint16(1)}
+	if event == randomnessFulfillmentRequestedEvent {
+		values = append(values, uint32(100_000), big.NewInt(0), big.NewInt(0), []byte{})
+	}
+	values = append(values, big.NewInt(0))
+	nonIndexedData, err := unindexed.Pack(values...)
+	require.NoError(t, err)
+
+	topic1, err := indexed[:1].Pack(big.NewInt(1))
+	require.NoError(t, err)
+	topic2, err := indexed[1:].Pack(common.HexToAddress("0xeFF41C8725be95e66F6B10489B6bF34b08055853"))
+	require.NoError(t, err)
+
+	topic0 := ocr2vrfCoordinatorABI.Events[event].ID
+	return logpoller.Log{
+		Address: coordinatorAddress,
+		Data:    nonIndexedData,
+		Topics: [][]byte{
+			// first topic is the event signature
+			topic0.Bytes(),
+			// second topic is requestID since it's indexed
+			topic1,
+			// third topic is requester since it's indexed
+			topic2,
+		},
+		BlockNumber: int64(requestBlock),
+		EventSig:    topic0,
+	}
+}
+
+func newOCR2VRFOutputsServedLog(
+	t *testing.T,
+	block uint64,
+	coordinatorAddress common.Address,
+	outputs ...vrf_coordinator.VRFBeaconTypesOutputServed,
+) logpoller.Log {
+	nonIndexedData, err := ocr2vrfCoordinatorABI.Events[outputsServedEvent].Inputs.Pack(
+		block, big.NewInt(0), uint64(0), outputs)
+	require.NoError(t, err)
+
+	topic0 := ocr2vrfCoordinatorABI.Events[outputsServedEvent].ID
+	return logpoller.Log{
+		Address:     coordinatorAddress,
+		Data:        nonIndexedData,
+		Topics:      [][]byte{topic0.Bytes()},
+		BlockNumber: int64(block),
+		EventSig:    topic0,
+	}
+}
@@ -48,6 +48,15 @@ type TestBHS struct {
 
 	// errorsIsStored defines which block numbers should return errors on IsStored.
 	ErrorsIsStored []uint64
+
+	// Trusted defines whether the BHS supports StoreTrusted.
+	Trusted bool
+
+	// TrustedBatches records the block numbers of every StoreTrusted call.
+	TrustedBatches [][]uint64
+
+	// ErrorStoreTrusted defines the error returned by StoreTrusted, if any.
+	ErrorStoreTrusted error
 }
 
 func (t *TestBHS) Store(_ context.Context, blockNum uint64) error {
@@ -81,6 +90,23 @@ func (t *TestBHS) StoreEarliest(ctx context.Context) error {
 	return nil
 }
 
+func (t *TestBHS) IsTrusted() bool {
+	return t.Trusted
+}
+
+func (t *TestBHS) StoreTrusted(_ context.Context, blockNums []uint64, blockhashes []common.Hash, _ uint64, _ common.Hash) error {
+	if t.ErrorStoreTrusted != nil {
+		return t.ErrorStoreTrusted
+	}
+	if len(blockNums) != len(blockhashes) {
+		return errors.Errorf("got %d blockhashes for %d blocks", len(blockhashes), len(blockNums))
+	}
+
+	t.TrustedBatches = append(t.TrustedBatches, blockNums)
+	t.Stored = append(t.Stored, blockNums...)
+	return nil
+}
+
 type TestBatchBHS struct {
 	Stored                       []uint64
 	GetBlockhashesCallCounter    uint16
@@ -38,9 +38,9 @@ func ValidatedSpec(tomlString string) (job.Job, error) {
 	}
 
 	// Required fields
-	if spec.CoordinatorV1Address == nil && spec.CoordinatorV2Address == nil {
+	if spec.CoordinatorV1Address == nil && spec.CoordinatorV2Address == nil && spec.CoordinatorOCR2VRFAddress == nil {
 		return jb, errors.New(
-			`at least one of "coordinatorV1Address" and "coordinatorV2Address" must be set`)
+			`at least one of "coordinatorV1Address", "coordinatorV2Address" and "coordinatorOCR2VRFAddress" must be set`)
 	}
 	if spec.BlockhashStoreAddress == "" {
 		return jb, notSet("blockhashStoreAddress")
@@ -62,6 +62,9 @@ func ValidatedSpec(tomlString string) (job.Job, error) {
 	if spec.RunTimeout == 0 {
 		spec.RunTimeout = 30 * time.Second
 	}
+	if spec.TrustedBlockhashStoreAddress != nil && spec.TrustedBlockhashStoreBatchSize == 0 {
+		spec.TrustedBlockhashStoreBatchSize = 100
+	}
 
 	// Validation
 	if spec.WaitBlocks >= spec.LookbackBlocks {
@@ -73,6 +76,14 @@ func ValidatedSpec(tomlString string) (job.Job, error) {
 	if spec.LookbackBlocks >= 256 {
 		return jb, errors.New(`"lookbackBlocks" must be less than 256`)
 	}
+	if spec.TrustedBlockhashStoreBatchSize < 0 {
+		return jb, errors.New(`"trustedBlockhashStoreBatchSize" must not be negative`)
+	}
+	if spec.TrustedBlockhashStoreGasLimit != 0 &&
+		TrustedBatchSize(spec.TrustedBlockhashStoreBatchSize, spec.TrustedBlockhashStoreGasLimit) == 0 {
+		return jb, errors.Errorf(`"trustedBlockhashStoreGasLimit" must be at least %d to store a single blockhash`,
+			trustedStoreBaseGas+trustedStoreGasPerBlock)
+	}
 
 	jb.BlockhashStoreSpec = &spec
 
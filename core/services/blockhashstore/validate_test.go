@@ -103,6 +103,22 @@ fromAddresses = ["0x469aA2CD13e037DC5236320783dCfd0e641c0559"]`,
 				require.Equal(t, &v2Coordinator, os.BlockhashStoreSpec.CoordinatorV2Address)
 			},
 		},
+		{
+			name: "ocr2vrf only",
+			toml: `
+type = "blockhashstore"
+name = "defaults-test"
+coordinatorOCR2VRFAddress = "0x2be990eE17832b59E0086534c5ea2459Aa75E38F"
+blockhashStoreAddress = "0x3e20Cef636EdA7ba135bCbA4fe6177Bd3cE0aB17"
+evmChainID = "4"
+fromAddresses = ["0x469aA2CD13e037DC5236320783dCfd0e641c0559"]`,
+			assertion: func(t *testing.T, os job.Job, err error) {
+				require.NoError(t, err)
+				require.Nil(t, os.BlockhashStoreSpec.CoordinatorV1Address)
+				require.Nil(t, os.BlockhashStoreSpec.CoordinatorV2Address)
+				require.Equal(t, &v2Coordinator, os.BlockhashStoreSpec.CoordinatorOCR2VRFAddress)
+			},
+		},
 		{
 			name: "invalid no coordinators",
 			toml: `
@@ -112,7 +128,7 @@ blockhashStoreAddress = "0x3e20Cef636EdA7ba135bCbA4fe6177Bd3cE0aB17"
 evmChainID = "4"
 fromAddresses = ["0x469aA2CD13e037DC5236320783dCfd0e641c0559"]`,
 			assertion: func(t *testing.T, os job.Job, err error) {
-				require.EqualError(t, err, `at least one of "coordinatorV1Address" and "coordinatorV2Address" must be set`)
+				require.EqualError(t, err, `at least one of "coordinatorV1Address", "coordinatorV2Address" and "coordinatorOCR2VRFAddress" must be set`)
 			},
 		},
 		{
@@ -183,6 +199,37 @@ evmChainID = "4"`,
 				require.EqualError(t, err, `"waitBlocks" must be less than "lookbackBlocks"`)
 			},
 		},
+		{
+			name: "trusted bhs",
+			toml: `
+type = "blockhashstore"
+name = "trusted-test"
+coordinatorV2Address = "0x2be990eE17832b59E0086534c5ea2459Aa75E38F"
+blockhashStoreAddress = "0x3e20Cef636EdA7ba135bCbA4fe6177Bd3cE0aB17"
+trustedBlockhashStoreAddress = "0x469aA2CD13e037DC5236320783dCfd0e641c0559"
+trustedBlockhashStoreGasLimit = 2500000
+evmChainID = "4"`,
+			assertion: func(t *testing.T, os job.Job, err error) {
+				require.NoError(t, err)
+				require.Equal(t, &fromAddresses[0], os.BlockhashStoreSpec.TrustedBlockhashStoreAddress)
+				require.Equal(t, int32(100), os.BlockhashStoreSpec.TrustedBlockhashStoreBatchSize)
+				require.Equal(t, uint32(2_500_000), os.BlockhashStoreSpec.TrustedBlockhashStoreGasLimit)
+			},
+		},
+		{
+			name: "trusted bhs gas limit too low",
+			toml: `
+type = "blockhashstore"
+name = "trusted-test"
+coordinatorV2Address = "0x2be990eE17832b59E0086534c5ea2459Aa75E38F"
+blockhashStoreAddress = "0x3e20Cef636EdA7ba135bCbA4fe6177Bd3cE0aB17"
+trustedBlockhashStoreAddress = "0x469aA2CD13e037DC5236320783dCfd0e641c0559"
+trustedBlockhashStoreGasLimit = 60000
+evmChainID = "4"`,
+			assertion: func(t *testing.T, os job.Job, err error) {
+				require.EqualError(t, err, `"trustedBlockhashStoreGasLimit" must be at least 75000 to store a single blockhash`)
+			},
+		},
 		{
 			name: "invalid toml",
 			toml: `
@@ -123,7 +123,9 @@ func (d *Delegate) ServicesForSpec(jb job.Job) ([]job.ServiceCtx, error) {
 		coordinators = append(coordinators, coord)
 	}
 
-	bpBHS, err := blockhashstore.NewBulletproofBHS(chain.Config(), fromAddresses, chain.TxManager(), bhs, chain.ID(), d.ks)
+	// The block header feeder stores blockhashes beyond the 256 block limit by verifying headers
+	// with the batch BHS, so it does not use a trusted BHS.
+	bpBHS, err := blockhashstore.NewBulletproofBHS(chain.Config(), fromAddresses, chain.TxManager(), bhs, nil, 0, chain.ID(), d.ks, d.logger)
 	if err != nil {
 		return nil, errors.Wrap(err, "building bulletproof bhs")
 	}
@@ -6,12 +6,14 @@ import (
 	"math/big"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"github.com/pyroscope-io/client/pyroscope"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.uber.org/multierr"
 	"go.uber.org/zap/zapcore"
 
@@ -36,6 +38,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services/blockheaderfeeder"
 	"github.com/smartcontractkit/chainlink/v2/core/services/cron"
 	"github.com/smartcontractkit/chainlink/v2/core/services/directrequest"
+	"github.com/smartcontractkit/chainlink/v2/core/services/evmlog"
 	"github.com/smartcontractkit/chainlink/v2/core/services/feeds"
 	"github.com/smartcontractkit/chainlink/v2/core/services/fluxmonitorv2"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
@@ -51,6 +54,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services/promreporter"
 	"github.com/smartcontractkit/chainlink/v2/core/services/relay"
 	evmrelay "github.com/smartcontractkit/chainlink/v2/core/services/relay/evm"
+	"github.com/smartcontractkit/chainlink/v2/core/services/slo"
 	"github.com/smartcontractkit/chainlink/v2/core/services/synchronization"
 	"github.com/smartcontractkit/chainlink/v2/core/services/telemetry"
 	"github.com/smartcontractkit/chainlink/v2/core/services/vrf"
@@ -60,6 +64,9 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/plugins"
 )
 
+// tracingShutdownTimeout bounds the time spent flushing spans on shutdown.
+const tracingShutdownTimeout = 5 * time.Second
+
 //go:generate mockery --quiet --name Application --output ../../internal/mocks/ --case=underscore
 
 // Application implements the common functions used in the core node.
@@ -90,9 +97,11 @@ type Application interface {
 	BridgeORM() bridges.ORM
 	SessionORM() sessions.ORM
 	TxmStorageService() txmgr.EvmTxStore
+	JobSLOMonitor() slo.Monitor
+	VRFBacklog() *vrf.Backlog
 	AddJobV2(ctx context.Context, job *job.Job) error
 	DeleteJob(ctx context.Context, jobID int32) error
-	RunWebhookJobV2(ctx context.Context, jobUUID uuid.UUID, requestBody string, meta pipeline.JSONSerializable) (int64, error)
+	RunWebhookJobV2(ctx context.Context, jobUUID uuid.UUID, requestBody string, meta pipeline.JSONSerializable, opts webhook.RunJobOpts) (int64, error)
 	ResumeJobV2(ctx context.Context, taskID uuid.UUID, result pipeline.Result) error
 	// Testing only
 	RunJobV2(ctx context.Context, jobID int32, meta map[string]interface{}) (int64, error)
@@ -123,6 +132,8 @@ type ChainlinkApplication struct {
 	bridgeORM                bridges.ORM
 	sessionORM               sessions.ORM
 	txmStorageService        txmgr.EvmTxStore
+	jobSLOMonitor            slo.Monitor
+	vrfBacklog               *vrf.Backlog
 	FeedsService             feeds.Service
 	webhookJobRunner         webhook.JobRunner
 	Config                   GeneralConfig
@@ -140,6 +151,7 @@ type ChainlinkApplication struct {
 	sqlxDB                   *sqlx.DB
 	secretGenerator          SecretGenerator
 	profiler                 *pyroscope.Profiler
+	tracerProvider           *sdktrace.TracerProvider
 	loopRegistry             *plugins.LoopRegistry
 
 	started     bool
@@ -233,6 +245,22 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 		globalLogger.Debug("Pyroscope (automatic pprof profiling) is disabled")
 	}
 
+	var tracerProvider *sdktrace.TracerProvider
+	if cfg.TracingEnabled() {
+		globalLogger.Infow("OpenTelemetry tracing is enabled", "collectorTarget", cfg.TracingCollectorTarget())
+		var err error
+		tracerProvider, err = logger.StartTracing(cfg, globalLogger)
+		if err != nil {
+			return nil, errors.Wrap(err, "starting OpenTelemetry tracing failed")
+		}
+	} else {
+		globalLogger.Debug("OpenTelemetry tracing is disabled")
+	}
+
+	if err := pg.SetNotifyTriggers(pg.NewQ(db, globalLogger, cfg), cfg.FeatureGraphQLSubscriptions()); err != nil {
+		return nil, errors.Wrap(err, "failed to set GraphQL subscription triggers")
+	}
+
 	var nurse *services.Nurse
 	if cfg.AutoPprofEnabled() {
 		globalLogger.Info("Nurse service (automatic pprof profiling) is enabled")
@@ -261,8 +289,18 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 		monitoringEndpointGen = telemetry.NewExplorerAgent(explorerClient)
 	}
 
+	telemetryStore := synchronization.NewTelemetryStore(cfg, db, globalLogger, cfg)
+	if cfg.ExplorerURL() == nil && telemetryStore != nil {
+		if cfg.TelemetryIngressURL() != nil {
+			globalLogger.Warnf("Both TelemetryIngress.Url and TelemetryIngress.Local.Mode are set, defaulting to the local %s sink", cfg.TelemetryIngressLocalMode())
+		}
+		maxAge := time.Duration(cfg.TelemetryIngressLocalMaxAge()) * 24 * time.Hour
+		telemetryIngressBatchClient = synchronization.NewTelemetryLocalClient(telemetryStore, globalLogger, cfg.TelemetryIngressBufferSize(), cfg.TelemetryIngressMaxBatchSize(), cfg.TelemetryIngressSendInterval(), maxAge)
+		monitoringEndpointGen = telemetry.NewIngressAgentBatchWrapper(telemetryIngressBatchClient)
+	}
+
 	// Use Explorer over TelemetryIngress if both URLs are set
-	if cfg.ExplorerURL() == nil && cfg.TelemetryIngressURL() != nil {
+	if cfg.ExplorerURL() == nil && telemetryStore == nil && cfg.TelemetryIngressURL() != nil {
 		if cfg.TelemetryIngressUseBatchSend() {
 			telemetryIngressBatchClient = synchronization.NewTelemetryIngressBatchClient(cfg.TelemetryIngressURL(),
 				cfg.TelemetryIngressServerPubKey(), keyStore.CSA(), cfg.TelemetryIngressLogging(), globalLogger, cfg.TelemetryIngressBufferSize(), cfg.TelemetryIngressMaxBatchSize(), cfg.TelemetryIngressSendInterval(), cfg.TelemetryIngressSendTimeout(), cfg.TelemetryIngressUniConn())
@@ -300,6 +338,7 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 		pipelineRunner = pipeline.NewRunner(pipelineORM, bridgeORM, cfg, chains.EVM, keyStore.Eth(), keyStore.VRF(), globalLogger, restrictedHTTPClient, unrestrictedHTTPClient)
 		jobORM         = job.NewORM(db, chains.EVM, pipelineORM, bridgeORM, keyStore, globalLogger, cfg)
 		txmORM         = txmgr.NewTxStore(db, globalLogger, cfg)
+		vrfBacklog     = vrf.NewBacklog()
 	)
 
 	srvcs = append(srvcs, pipelineORM)
@@ -315,6 +354,7 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 				globalLogger,
 				pipelineRunner,
 				pipelineORM,
+				jobORM,
 				chains.EVM,
 				mailMon),
 			job.Keeper: keeper.NewDelegate(
@@ -332,13 +372,16 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 				chains.EVM,
 				globalLogger,
 				cfg,
-				mailMon),
+				mailMon,
+				vrfBacklog),
 			job.Webhook: webhook.NewDelegate(
 				pipelineRunner,
 				externalInitiatorManager,
+				webhook.NewORM(db, globalLogger, cfg),
 				globalLogger),
 			job.Cron: cron.NewDelegate(
 				pipelineRunner,
+				cron.NewORM(db, globalLogger, cfg),
 				globalLogger),
 			job.BlockhashStore: blockhashstore.NewDelegate(
 				globalLogger,
@@ -348,6 +391,11 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 				globalLogger,
 				chains.EVM,
 				keyStore.Eth()),
+			job.EVMLog: evmlog.NewDelegate(
+				db,
+				pipelineRunner,
+				chains.EVM,
+				globalLogger),
 		}
 		webhookJobRunner = delegates[job.Webhook].(*webhook.Delegate).WebhookJobRunner()
 	)
@@ -363,6 +411,7 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 			pipelineRunner,
 			db,
 			chains.EVM,
+			healthChecker,
 			globalLogger,
 		)
 	}
@@ -455,6 +504,9 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 	jobSpawner := job.NewSpawner(jobORM, cfg, delegates, db, globalLogger, lbs)
 	srvcs = append(srvcs, jobSpawner, pipelineRunner)
 
+	jobSLOMonitor := slo.NewMonitor(slo.NewORM(db, globalLogger, cfg), globalLogger, unrestrictedHTTPClient, slo.EvaluationInterval)
+	srvcs = append(srvcs, jobSLOMonitor)
+
 	// We start the log poller after the job spawner
 	// so jobs have a chance to apply their initial log filters.
 	if cfg.FeatureLogPoller() {
@@ -491,6 +543,8 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 		bridgeORM:                bridgeORM,
 		sessionORM:               sessionORM,
 		txmStorageService:        txmORM,
+		jobSLOMonitor:            jobSLOMonitor,
+		vrfBacklog:               vrfBacklog,
 		FeedsService:             feedsService,
 		Config:                   cfg,
 		webhookJobRunner:         webhookJobRunner,
@@ -505,6 +559,7 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 		closeLogger:              opts.CloseLogger,
 		secretGenerator:          opts.SecretGenerator,
 		profiler:                 profiler,
+		tracerProvider:           tracerProvider,
 		loopRegistry:             loopRegistry,
 
 		sqlxDB: opts.SqlxDB,
@@ -643,6 +698,13 @@ func (app *ChainlinkApplication) stop() (err error) {
 			err = multierr.Append(err, app.profiler.Stop())
 		}
 
+		if app.tracerProvider != nil {
+			app.logger.Debug("Flushing spans...")
+			ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+			err = multierr.Append(err, app.tracerProvider.Shutdown(ctx))
+			cancel()
+		}
+
 		app.logger.Info("Exited all services")
 
 		app.started = false
@@ -682,6 +744,14 @@ func (app *ChainlinkApplication) BridgeORM() bridges.ORM {
 	return app.bridgeORM
 }
 
+func (app *ChainlinkApplication) JobSLOMonitor() slo.Monitor {
+	return app.jobSLOMonitor
+}
+
+func (app *ChainlinkApplication) VRFBacklog() *vrf.Backlog {
+	return app.vrfBacklog
+}
+
 func (app *ChainlinkApplication) SessionORM() sessions.ORM {
 	return app.sessionORM
 }
@@ -729,8 +799,8 @@ func (app *ChainlinkApplication) DeleteJob(ctx context.Context, jobID int32) err
 	return app.jobSpawner.DeleteJob(jobID, pg.WithParentCtx(ctx))
 }
 
-func (app *ChainlinkApplication) RunWebhookJobV2(ctx context.Context, jobUUID uuid.UUID, requestBody string, meta pipeline.JSONSerializable) (int64, error) {
-	return app.webhookJobRunner.RunJob(ctx, jobUUID, requestBody, meta)
+func (app *ChainlinkApplication) RunWebhookJobV2(ctx context.Context, jobUUID uuid.UUID, requestBody string, meta pipeline.JSONSerializable, opts webhook.RunJobOpts) (int64, error) {
+	return app.webhookJobRunner.RunJob(ctx, jobUUID, requestBody, meta, opts)
 }
 
 // Only used for local testing, not supported by the UI.
@@ -271,6 +271,10 @@ func (g *generalConfig) FeatureOffchainReporting2() bool {
 	return *g.c.OCR2.Enabled
 }
 
+func (g *generalConfig) FeatureGraphQLSubscriptions() bool {
+	return *g.c.Feature.GraphQLSubscriptions
+}
+
 func (g *generalConfig) FeatureLogPoller() bool {
 	return *g.c.Feature.LogPoller
 }
@@ -599,6 +603,63 @@ func (g *generalConfig) JobPipelineResultWriteQueueDepth() uint64 {
 	return uint64(*g.c.JobPipeline.ResultWriteQueueDepth)
 }
 
+func (g *generalConfig) JobPipelineReaperArchiveDir() string {
+	if g.c.JobPipeline.ReaperArchiveDir == nil {
+		return ""
+	}
+	return *g.c.JobPipeline.ReaperArchiveDir
+}
+
+func (g *generalConfig) JobPipelineRetentionJobTypes() (jobTypes []string) {
+	for _, r := range g.c.JobPipeline.Retention {
+		jobTypes = append(jobTypes, *r.JobType)
+	}
+	return
+}
+
+func (g *generalConfig) jobPipelineRetention(jobType string) *v2.JobPipelineRetention {
+	for i, r := range g.c.JobPipeline.Retention {
+		if *r.JobType == jobType {
+			return &g.c.JobPipeline.Retention[i]
+		}
+	}
+	return nil
+}
+
+func (g *generalConfig) JobPipelineMaxSuccessfulRunsForJobType(jobType string) uint64 {
+	if r := g.jobPipelineRetention(jobType); r != nil && r.MaxSuccessfulRuns != nil {
+		return *r.MaxSuccessfulRuns
+	}
+	return g.JobPipelineMaxSuccessfulRuns()
+}
+
+func (g *generalConfig) JobPipelineReaperThresholdForJobType(jobType string) time.Duration {
+	if r := g.jobPipelineRetention(jobType); r != nil && r.ReaperThreshold != nil {
+		return r.ReaperThreshold.Duration()
+	}
+	return g.JobPipelineReaperThreshold()
+}
+
+func (g *generalConfig) JobPipelineErroredReaperThresholdForJobType(jobType string) time.Duration {
+	if r := g.jobPipelineRetention(jobType); r != nil && r.ErroredReaperThreshold != nil && r.ErroredReaperThreshold.Duration() > 0 {
+		return r.ErroredReaperThreshold.Duration()
+	}
+	if d := g.c.JobPipeline.ErroredReaperThreshold; d != nil && d.Duration() > 0 {
+		return d.Duration()
+	}
+	return g.JobPipelineReaperThresholdForJobType(jobType)
+}
+
+func (g *generalConfig) JobPipelineSuccessfulTaskRunsSampleRateForJobType(jobType string) uint32 {
+	if r := g.jobPipelineRetention(jobType); r != nil && r.SuccessfulTaskRunsSampleRate != nil {
+		return *r.SuccessfulTaskRunsSampleRate
+	}
+	if g.c.JobPipeline.SuccessfulTaskRunsSampleRate != nil {
+		return *g.c.JobPipeline.SuccessfulTaskRunsSampleRate
+	}
+	return 0
+}
+
 func (g *generalConfig) KeeperDefaultTransactionQueueDepth() uint32 {
 	return *g.c.Keeper.DefaultTransactionQueueDepth
 }
@@ -923,6 +984,26 @@ func (g *generalConfig) PyroscopeServerAddress() string {
 func (g *generalConfig) PyroscopeEnvironment() string {
 	return *g.c.Pyroscope.Environment
 }
+
+func (g *generalConfig) TracingEnabled() bool {
+	return *g.c.Tracing.Enabled
+}
+
+func (g *generalConfig) TracingCollectorTarget() string {
+	return *g.c.Tracing.CollectorTarget
+}
+
+func (g *generalConfig) TracingNodeID() string {
+	return *g.c.Tracing.NodeID
+}
+
+func (g *generalConfig) TracingSamplingRatio() float64 {
+	return *g.c.Tracing.SamplingRatio
+}
+
+func (g *generalConfig) TracingMode() string {
+	return *g.c.Tracing.Mode
+}
 func (g *generalConfig) Port() uint16 {
 	return *g.c.WebServer.HTTPPort
 }
@@ -935,6 +1016,74 @@ func (g *generalConfig) RPOrigin() string {
 	return *g.c.WebServer.MFA.RPOrigin
 }
 
+func (g *generalConfig) OIDCEnabled() bool {
+	return *g.c.WebServer.OIDC.Enabled
+}
+
+func (g *generalConfig) OIDCIssuerURL() string {
+	if v := g.c.WebServer.OIDC.IssuerURL; v != nil {
+		return v.String()
+	}
+	return ""
+}
+
+func (g *generalConfig) OIDCClientID() string {
+	if v := g.c.WebServer.OIDC.ClientID; v != nil {
+		return *v
+	}
+	return ""
+}
+
+func (g *generalConfig) OIDCRedirectURL() string {
+	if v := g.c.WebServer.OIDC.RedirectURL; v != nil {
+		return v.String()
+	}
+	return ""
+}
+
+func (g *generalConfig) OIDCScopes() []string {
+	if v := g.c.WebServer.OIDC.Scopes; v != nil {
+		return *v
+	}
+	return nil
+}
+
+func (g *generalConfig) OIDCGroupsClaim() string {
+	return *g.c.WebServer.OIDC.GroupsClaim
+}
+
+func (g *generalConfig) OIDCAdminGroups() []string {
+	if v := g.c.WebServer.OIDC.AdminGroups; v != nil {
+		return *v
+	}
+	return nil
+}
+
+func (g *generalConfig) OIDCEditGroups() []string {
+	if v := g.c.WebServer.OIDC.EditGroups; v != nil {
+		return *v
+	}
+	return nil
+}
+
+func (g *generalConfig) OIDCRunGroups() []string {
+	if v := g.c.WebServer.OIDC.RunGroups; v != nil {
+		return *v
+	}
+	return nil
+}
+
+func (g *generalConfig) OIDCViewGroups() []string {
+	if v := g.c.WebServer.OIDC.ViewGroups; v != nil {
+		return *v
+	}
+	return nil
+}
+
+func (g *generalConfig) OIDCDisableLocalPasswords() bool {
+	return *g.c.WebServer.OIDC.DisableLocalPasswords
+}
+
 func (g *generalConfig) ReaperExpiration() models.Duration {
 	return *g.c.WebServer.SessionReaperExpiration
 }
@@ -1045,6 +1194,30 @@ func (g *generalConfig) TelemetryIngressUseBatchSend() bool {
 	return *g.c.TelemetryIngress.UseBatchSend
 }
 
+func (g *generalConfig) TelemetryIngressLocalMode() string {
+	return *g.c.TelemetryIngress.Local.Mode
+}
+
+func (g *generalConfig) TelemetryIngressLocalDir() string {
+	s := *g.c.TelemetryIngress.Local.Dir
+	if s == "" {
+		s = filepath.Join(g.RootDir(), "telemetry")
+	}
+	return s
+}
+
+func (g *generalConfig) TelemetryIngressLocalMaxSize() utils.FileSize {
+	return *g.c.TelemetryIngress.Local.MaxSize
+}
+
+func (g *generalConfig) TelemetryIngressLocalMaxAge() int64 {
+	return *g.c.TelemetryIngress.Local.MaxAgeDays
+}
+
+func (g *generalConfig) TelemetryIngressLocalMaxBackups() int64 {
+	return *g.c.TelemetryIngress.Local.MaxBackups
+}
+
 func (g *generalConfig) TriggerFallbackDBPollInterval() time.Duration {
 	return g.c.Database.Listener.FallbackPollInterval.Duration()
 }
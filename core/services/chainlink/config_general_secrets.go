@@ -44,6 +44,13 @@ func (g *generalConfig) PrometheusAuthToken() string {
 	return string(*g.secrets.Prometheus.AuthToken)
 }
 
+func (g *generalConfig) OIDCClientSecret() string {
+	if g.secrets.WebServer.OIDC.ClientSecret == nil {
+		return ""
+	}
+	return string(*g.secrets.WebServer.OIDC.ClientSecret)
+}
+
 func (g *generalConfig) MercuryCredentials(credName string) *models.MercuryCredentials {
 	if mc, ok := g.secrets.Mercury.Credentials[credName]; ok {
 		return &models.MercuryCredentials{
@@ -239,9 +239,10 @@ func TestConfig_Marshal(t *testing.T) {
 	}
 
 	full.Feature = config.Feature{
-		FeedsManager: ptr(true),
-		LogPoller:    ptr(true),
-		UICSAKeys:    ptr(true),
+		FeedsManager:         ptr(true),
+		GraphQLSubscriptions: ptr(true),
+		LogPoller:            ptr(true),
+		UICSAKeys:            ptr(true),
 	}
 	full.Database = config.Database{
 		DefaultIdleInTxSessionTimeout: models.MustNewDuration(time.Minute),
@@ -278,6 +279,13 @@ func TestConfig_Marshal(t *testing.T) {
 		SendInterval: models.MustNewDuration(time.Minute),
 		SendTimeout:  models.MustNewDuration(5 * time.Second),
 		UseBatchSend: ptr(true),
+		Local: config.TelemetryIngressLocal{
+			Mode:       ptr(config.TelemetryLocalModeFile),
+			Dir:        ptr("telemetry/dir"),
+			MaxSize:    ptr[utils.FileSize](50 * utils.MB),
+			MaxAgeDays: ptr[int64](3),
+			MaxBackups: ptr[int64](5),
+		},
 	}
 	full.Log = config.Log{
 		Level:       ptr(config.LogLevel(zapcore.DPanicLevel)),
@@ -303,6 +311,19 @@ func TestConfig_Marshal(t *testing.T) {
 			RPID:     ptr("test-rpid"),
 			RPOrigin: ptr("test-rp-origin"),
 		},
+		OIDC: config.WebServerOIDC{
+			Enabled:               ptr(true),
+			IssuerURL:             mustURL("https://accounts.example.com"),
+			ClientID:              ptr("test-client-id"),
+			RedirectURL:           mustURL("https://localhost:6688/oidc/callback"),
+			Scopes:                &[]string{"openid", "email", "profile", "groups"},
+			GroupsClaim:           ptr("roles"),
+			AdminGroups:           &[]string{"admins"},
+			EditGroups:            &[]string{"editors"},
+			RunGroups:             &[]string{"runners"},
+			ViewGroups:            &[]string{"viewers", "engineering"},
+			DisableLocalPasswords: ptr(true),
+		},
 		RateLimit: config.WebServerRateLimit{
 			Authenticated:         ptr[int64](42),
 			AuthenticatedPeriod:   models.MustNewDuration(time.Second),
@@ -318,16 +339,28 @@ func TestConfig_Marshal(t *testing.T) {
 		},
 	}
 	full.JobPipeline = config.JobPipeline{
-		ExternalInitiatorsEnabled: ptr(true),
-		MaxRunDuration:            models.MustNewDuration(time.Hour),
-		MaxSuccessfulRuns:         ptr[uint64](123456),
-		ReaperInterval:            models.MustNewDuration(4 * time.Hour),
-		ReaperThreshold:           models.MustNewDuration(7 * 24 * time.Hour),
-		ResultWriteQueueDepth:     ptr[uint32](10),
+		ExternalInitiatorsEnabled:    ptr(true),
+		MaxRunDuration:               models.MustNewDuration(time.Hour),
+		MaxSuccessfulRuns:            ptr[uint64](123456),
+		ReaperInterval:               models.MustNewDuration(4 * time.Hour),
+		ReaperThreshold:              models.MustNewDuration(7 * 24 * time.Hour),
+		ErroredReaperThreshold:       models.MustNewDuration(30 * 24 * time.Hour),
+		ReaperArchiveDir:             ptr("test/runs"),
+		ResultWriteQueueDepth:        ptr[uint32](10),
+		SuccessfulTaskRunsSampleRate: ptr[uint32](50),
 		HTTPRequest: config.JobPipelineHTTPRequest{
 			MaxSize:        ptr[utils.FileSize](100 * utils.MB),
 			DefaultTimeout: models.MustNewDuration(time.Minute),
 		},
+		Retention: []config.JobPipelineRetention{
+			{
+				JobType:                      ptr("offchainreporting2"),
+				MaxSuccessfulRuns:            ptr[uint64](1000),
+				ReaperThreshold:              models.MustNewDuration(time.Hour),
+				ErroredReaperThreshold:       models.MustNewDuration(24 * time.Hour),
+				SuccessfulTaskRunsSampleRate: ptr[uint32](1000),
+			},
+		},
 	}
 	full.FluxMonitor = config.FluxMonitor{
 		DefaultTransactionQueueDepth: ptr[uint32](100),
@@ -419,6 +452,13 @@ func TestConfig_Marshal(t *testing.T) {
 		ServerAddress: ptr("http://localhost:4040"),
 		Environment:   ptr("tests"),
 	}
+	full.Tracing = config.Tracing{
+		Enabled:         ptr(true),
+		CollectorTarget: ptr("localhost:4317"),
+		NodeID:          ptr("clc-ocr-sol-devnet-node-1"),
+		SamplingRatio:   ptr(0.5),
+		Mode:            ptr("unencrypted"),
+	}
 	full.Sentry = config.Sentry{
 		Debug:       ptr(true),
 		DSN:         ptr("sentry-dsn"),
@@ -642,6 +682,7 @@ Headers = ['Authorization: token', 'X-SomeOther-Header: value with spaces | and
 `},
 		{"Feature", Config{Core: config.Core{Feature: full.Feature}}, `[Feature]
 FeedsManager = true
+GraphQLSubscriptions = true
 LogPoller = true
 UICSAKeys = true
 `},
@@ -680,6 +721,13 @@ MaxBatchSize = 4321
 SendInterval = '1m0s'
 SendTimeout = '5s'
 UseBatchSend = true
+
+[TelemetryIngress.Local]
+Mode = 'file'
+Dir = 'telemetry/dir'
+MaxSize = '50.00mb'
+MaxAgeDays = 3
+MaxBackups = 5
 `},
 		{"Log", Config{Core: config.Core{Log: full.Log}}, `[Log]
 Level = 'crit'
@@ -706,6 +754,19 @@ SessionReaperExpiration = '168h0m0s'
 RPID = 'test-rpid'
 RPOrigin = 'test-rp-origin'
 
+[WebServer.OIDC]
+Enabled = true
+IssuerURL = 'https://accounts.example.com'
+ClientID = 'test-client-id'
+RedirectURL = 'https://localhost:6688/oidc/callback'
+Scopes = ['openid', 'email', 'profile', 'groups']
+GroupsClaim = 'roles'
+AdminGroups = ['admins']
+EditGroups = ['editors']
+RunGroups = ['runners']
+ViewGroups = ['viewers', 'engineering']
+DisableLocalPasswords = true
+
 [WebServer.RateLimit]
 Authenticated = 42
 AuthenticatedPeriod = '1s'
@@ -729,11 +790,21 @@ MaxRunDuration = '1h0m0s'
 MaxSuccessfulRuns = 123456
 ReaperInterval = '4h0m0s'
 ReaperThreshold = '168h0m0s'
+ErroredReaperThreshold = '720h0m0s'
+ReaperArchiveDir = 'test/runs'
 ResultWriteQueueDepth = 10
+SuccessfulTaskRunsSampleRate = 50
 
 [JobPipeline.HTTPRequest]
 DefaultTimeout = '1m0s'
 MaxSize = '100.00mb'
+
+[[JobPipeline.Retention]]
+JobType = 'offchainreporting2'
+MaxSuccessfulRuns = 1000
+ReaperThreshold = '1h0m0s'
+ErroredReaperThreshold = '24h0m0s'
+SuccessfulTaskRunsSampleRate = 1000
 `},
 		{"OCR", Config{Core: config.Core{OCR: full.OCR}}, `[OCR]
 Enabled = true
@@ -817,6 +888,13 @@ GoroutineThreshold = 999
 		{"Pyroscope", Config{Core: config.Core{Pyroscope: full.Pyroscope}}, `[Pyroscope]
 ServerAddress = 'http://localhost:4040'
 Environment = 'tests'
+`},
+		{"Tracing", Config{Core: config.Core{Tracing: full.Tracing}}, `[Tracing]
+Enabled = true
+CollectorTarget = 'localhost:4317'
+NodeID = 'clc-ocr-sol-devnet-node-1'
+SamplingRatio = 0.5
+Mode = 'unencrypted'
 `},
 		{"Sentry", Config{Core: config.Core{Sentry: full.Sentry}}, `[Sentry]
 Debug = true
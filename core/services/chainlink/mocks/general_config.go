@@ -915,6 +915,20 @@ func (_m *GeneralConfig) FeatureFeedsManager() bool {
 	return r0
 }
 
+// FeatureGraphQLSubscriptions provides a mock function with given fields:
+func (_m *GeneralConfig) FeatureGraphQLSubscriptions() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // FeatureLogPoller provides a mock function with given fields:
 func (_m *GeneralConfig) FeatureLogPoller() bool {
 	ret := _m.Called()
@@ -1041,6 +1055,20 @@ func (_m *GeneralConfig) JSONConsole() bool {
 	return r0
 }
 
+// JobPipelineErroredReaperThresholdForJobType provides a mock function with given fields: jobType
+func (_m *GeneralConfig) JobPipelineErroredReaperThresholdForJobType(jobType string) time.Duration {
+	ret := _m.Called(jobType)
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func(string) time.Duration); ok {
+		r0 = rf(jobType)
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
 // JobPipelineMaxRunDuration provides a mock function with given fields:
 func (_m *GeneralConfig) JobPipelineMaxRunDuration() time.Duration {
 	ret := _m.Called()
@@ -1069,6 +1097,34 @@ func (_m *GeneralConfig) JobPipelineMaxSuccessfulRuns() uint64 {
 	return r0
 }
 
+// JobPipelineMaxSuccessfulRunsForJobType provides a mock function with given fields: jobType
+func (_m *GeneralConfig) JobPipelineMaxSuccessfulRunsForJobType(jobType string) uint64 {
+	ret := _m.Called(jobType)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(string) uint64); ok {
+		r0 = rf(jobType)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	return r0
+}
+
+// JobPipelineReaperArchiveDir provides a mock function with given fields:
+func (_m *GeneralConfig) JobPipelineReaperArchiveDir() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // JobPipelineReaperInterval provides a mock function with given fields:
 func (_m *GeneralConfig) JobPipelineReaperInterval() time.Duration {
 	ret := _m.Called()
@@ -1097,6 +1153,20 @@ func (_m *GeneralConfig) JobPipelineReaperThreshold() time.Duration {
 	return r0
 }
 
+// JobPipelineReaperThresholdForJobType provides a mock function with given fields: jobType
+func (_m *GeneralConfig) JobPipelineReaperThresholdForJobType(jobType string) time.Duration {
+	ret := _m.Called(jobType)
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func(string) time.Duration); ok {
+		r0 = rf(jobType)
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
 // JobPipelineResultWriteQueueDepth provides a mock function with given fields:
 func (_m *GeneralConfig) JobPipelineResultWriteQueueDepth() uint64 {
 	ret := _m.Called()
@@ -1111,6 +1181,36 @@ func (_m *GeneralConfig) JobPipelineResultWriteQueueDepth() uint64 {
 	return r0
 }
 
+// JobPipelineRetentionJobTypes provides a mock function with given fields:
+func (_m *GeneralConfig) JobPipelineRetentionJobTypes() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// JobPipelineSuccessfulTaskRunsSampleRateForJobType provides a mock function with given fields: jobType
+func (_m *GeneralConfig) JobPipelineSuccessfulTaskRunsSampleRateForJobType(jobType string) uint32 {
+	ret := _m.Called(jobType)
+
+	var r0 uint32
+	if rf, ok := ret.Get(0).(func(string) uint32); ok {
+		r0 = rf(jobType)
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	return r0
+}
+
 // KeeperBaseFeeBufferPercent provides a mock function with given fields:
 func (_m *GeneralConfig) KeeperBaseFeeBufferPercent() uint16 {
 	ret := _m.Called()
@@ -1764,6 +1864,184 @@ func (_m *GeneralConfig) OCRTransmitterAddress() (ethkey.EIP55Address, error) {
 	return r0, r1
 }
 
+// OIDCAdminGroups provides a mock function with given fields:
+func (_m *GeneralConfig) OIDCAdminGroups() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// OIDCClientID provides a mock function with given fields:
+func (_m *GeneralConfig) OIDCClientID() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// OIDCClientSecret provides a mock function with given fields:
+func (_m *GeneralConfig) OIDCClientSecret() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// OIDCDisableLocalPasswords provides a mock function with given fields:
+func (_m *GeneralConfig) OIDCDisableLocalPasswords() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// OIDCEditGroups provides a mock function with given fields:
+func (_m *GeneralConfig) OIDCEditGroups() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// OIDCEnabled provides a mock function with given fields:
+func (_m *GeneralConfig) OIDCEnabled() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// OIDCGroupsClaim provides a mock function with given fields:
+func (_m *GeneralConfig) OIDCGroupsClaim() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// OIDCIssuerURL provides a mock function with given fields:
+func (_m *GeneralConfig) OIDCIssuerURL() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// OIDCRedirectURL provides a mock function with given fields:
+func (_m *GeneralConfig) OIDCRedirectURL() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// OIDCRunGroups provides a mock function with given fields:
+func (_m *GeneralConfig) OIDCRunGroups() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// OIDCScopes provides a mock function with given fields:
+func (_m *GeneralConfig) OIDCScopes() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// OIDCViewGroups provides a mock function with given fields:
+func (_m *GeneralConfig) OIDCViewGroups() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
 // ORMMaxIdleConns provides a mock function with given fields:
 func (_m *GeneralConfig) ORMMaxIdleConns() int {
 	ret := _m.Called()
@@ -2572,6 +2850,76 @@ func (_m *GeneralConfig) TelemetryIngressBufferSize() uint {
 	return r0
 }
 
+// TelemetryIngressLocalDir provides a mock function with given fields:
+func (_m *GeneralConfig) TelemetryIngressLocalDir() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// TelemetryIngressLocalMaxAge provides a mock function with given fields:
+func (_m *GeneralConfig) TelemetryIngressLocalMaxAge() int64 {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// TelemetryIngressLocalMaxBackups provides a mock function with given fields:
+func (_m *GeneralConfig) TelemetryIngressLocalMaxBackups() int64 {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// TelemetryIngressLocalMaxSize provides a mock function with given fields:
+func (_m *GeneralConfig) TelemetryIngressLocalMaxSize() utils.FileSize {
+	ret := _m.Called()
+
+	var r0 utils.FileSize
+	if rf, ok := ret.Get(0).(func() utils.FileSize); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(utils.FileSize)
+	}
+
+	return r0
+}
+
+// TelemetryIngressLocalMode provides a mock function with given fields:
+func (_m *GeneralConfig) TelemetryIngressLocalMode() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // TelemetryIngressLogging provides a mock function with given fields:
 func (_m *GeneralConfig) TelemetryIngressLogging() bool {
 	ret := _m.Called()
@@ -2686,6 +3034,76 @@ func (_m *GeneralConfig) TelemetryIngressUseBatchSend() bool {
 	return r0
 }
 
+// TracingCollectorTarget provides a mock function with given fields:
+func (_m *GeneralConfig) TracingCollectorTarget() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// TracingEnabled provides a mock function with given fields:
+func (_m *GeneralConfig) TracingEnabled() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// TracingMode provides a mock function with given fields:
+func (_m *GeneralConfig) TracingMode() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// TracingNodeID provides a mock function with given fields:
+func (_m *GeneralConfig) TracingNodeID() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// TracingSamplingRatio provides a mock function with given fields:
+func (_m *GeneralConfig) TracingSamplingRatio() float64 {
+	ret := _m.Called()
+
+	var r0 float64
+	if rf, ok := ret.Get(0).(func() float64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	return r0
+}
+
 // TriggerFallbackDBPollInterval provides a mock function with given fields:
 func (_m *GeneralConfig) TriggerFallbackDBPollInterval() time.Duration {
 	ret := _m.Called()
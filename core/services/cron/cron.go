@@ -3,33 +3,52 @@ package cron
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/robfig/cron/v3"
 
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
 	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/v2/core/utils"
 )
 
+// maxCatchUpRuns caps the number of missed ticks that are run with the
+// catchUp misfire policy, so that a frequent schedule cannot flood the node
+// after a long downtime.
+const maxCatchUpRuns = 100
+
 // Cron runs a cron jobSpec from a CronSpec
 type Cron struct {
+	utils.StartStopOnce
 	cronRunner     *cron.Cron
 	logger         logger.Logger
 	jobSpec        job.Job
 	pipelineRunner pipeline.Runner
+	orm            ORM
 	chStop         utils.StopChan
+	wg             sync.WaitGroup
+
+	// runMu serializes runs with the skip and queue overlap policies.
+	runMu sync.Mutex
+	// queued holds the one tick that may wait for the run in progress with
+	// the queue overlap policy.
+	queued chan struct{}
 }
 
 // NewCronFromJobSpec instantiates a job that executes on a predefined schedule.
 func NewCronFromJobSpec(
 	jobSpec job.Job,
 	pipelineRunner pipeline.Runner,
+	orm ORM,
 	logger logger.Logger,
 ) (*Cron, error) {
 	cronLogger := logger.Named("Cron").With(
 		"jobID", jobSpec.ID,
 		"schedule", jobSpec.CronSpec.CronSchedule,
+		"timezone", jobSpec.CronSpec.Timezone,
 	)
 
 	return &Cron{
@@ -37,35 +56,153 @@ func NewCronFromJobSpec(
 		logger:         cronLogger,
 		jobSpec:        jobSpec,
 		pipelineRunner: pipelineRunner,
+		orm:            orm,
 		chStop:         make(chan struct{}),
+		queued:         make(chan struct{}, 1),
 	}, nil
 }
 
 // Start implements the job.Service interface.
 func (cr *Cron) Start(context.Context) error {
+	return cr.StartOnce("Cron", cr.start)
+}
+
+func (cr *Cron) start() error {
 	cr.logger.Debug("Starting")
 
-	_, err := cr.cronRunner.AddFunc(cr.jobSpec.CronSpec.CronSchedule, cr.runPipeline)
+	sched, err := cronParser().Parse(schedule(*cr.jobSpec.CronSpec))
 	if err != nil {
 		cr.logger.Errorw(fmt.Sprintf("Error running cron job %d", cr.jobSpec.ID), "error", err, "schedule", cr.jobSpec.CronSpec.CronSchedule, "jobID", cr.jobSpec.ID)
 		return err
 	}
+
+	// Missed ticks are determined before the scheduler starts, so that they
+	// are not hidden by a run of the first tick.
+	missed, err := cr.missedTicks(sched, time.Now())
+	if err != nil {
+		cr.logger.Errorw("Failed to determine missed cron ticks, not running them", "error", err)
+		missed = nil
+	}
+
+	cr.cronRunner.Schedule(sched, cron.FuncJob(cr.runPipeline))
 	cr.cronRunner.Start()
+
+	if len(missed) > 0 {
+		cr.wg.Add(1)
+		go func() {
+			defer cr.wg.Done()
+			for _, tick := range missed {
+				select {
+				case <-cr.chStop:
+					return
+				default:
+				}
+				cr.run(tick)
+			}
+		}()
+	}
 	return nil
 }
 
 // Close implements the job.Service interface. It stops this job from
 // running and cleans up resources.
 func (cr *Cron) Close() error {
-	cr.logger.Debug("Closing")
-	cr.cronRunner.Stop()
-	return nil
+	return cr.StopOnce("Cron", func() error {
+		cr.logger.Debug("Closing")
+		// Cancel the running pipelines before waiting for them to return.
+		close(cr.chStop)
+		<-cr.cronRunner.Stop().Done()
+		cr.wg.Wait()
+		return nil
+	})
+}
+
+// missedTicks returns the ticks to run for the misfire policy of the job,
+// given the ticks between the last tick of the job and now.
+func (cr *Cron) missedTicks(sched cron.Schedule, now time.Time) ([]time.Time, error) {
+	policy := cr.jobSpec.CronSpec.MisfirePolicy
+	if policy == "" || policy == job.CronMisfireSkip {
+		return nil, nil
+	}
+
+	since, err := cr.orm.LastTick(cr.jobSpec.ID)
+	if err != nil {
+		return nil, err
+	}
+	if since.Before(cr.jobSpec.CreatedAt) {
+		since = cr.jobSpec.CreatedAt
+	}
+
+	var ticks []time.Time
+	for tick := sched.Next(since); !tick.After(now); tick = sched.Next(tick) {
+		if policy == job.CronMisfireRunOnce {
+			// Only the most recent missed tick is run.
+			ticks = []time.Time{tick}
+			continue
+		}
+		if len(ticks) == maxCatchUpRuns {
+			cr.logger.Warnw(fmt.Sprintf("More than %d cron ticks were missed, only catching up on the first %d", maxCatchUpRuns, maxCatchUpRuns),
+				"since", since)
+			break
+		}
+		ticks = append(ticks, tick)
+	}
+	if len(ticks) > 0 {
+		cr.logger.Infow("Running missed cron ticks", "misfirePolicy", policy, "since", since, "ticks", len(ticks))
+	}
+	return ticks, nil
 }
 
 func (cr *Cron) runPipeline() {
+	cr.run(time.Time{})
+}
+
+// run runs the pipeline according to the overlap policy of the job. A
+// non-zero scheduledAt marks a run of a missed tick.
+func (cr *Cron) run(scheduledAt time.Time) {
+	switch cr.jobSpec.CronSpec.OverlapPolicy {
+	case job.CronOverlapSkip:
+		if !cr.runMu.TryLock() {
+			cr.logger.Warn("Skipping cron tick, previous run is still in progress")
+			return
+		}
+		defer cr.runMu.Unlock()
+	case job.CronOverlapQueue:
+		if !cr.runMu.TryLock() {
+			// Only one tick waits for the run in progress, later ones are
+			// skipped.
+			select {
+			case cr.queued <- struct{}{}:
+			default:
+				cr.logger.Warn("Skipping cron tick, previous run is still in progress and a tick is already queued")
+				return
+			}
+			cr.runMu.Lock()
+			<-cr.queued
+		}
+		defer cr.runMu.Unlock()
+	}
+
+	select {
+	case <-cr.chStop:
+		// Queued runs are dropped when the job stops.
+		return
+	default:
+	}
 	ctx, cancel := cr.chStop.NewCtx()
 	defer cancel()
 
+	meta := map[string]interface{}{}
+	tick := time.Now()
+	if !scheduledAt.IsZero() {
+		meta["scheduledAt"] = scheduledAt.UTC().Format(time.RFC3339)
+		tick = scheduledAt
+	}
+	// The tick is recorded before the run, so that a tick whose run was
+	// interrupted by a restart is not run again as a missed tick.
+	if err := cr.orm.SetLastTick(cr.jobSpec.ID, tick, pg.WithParentCtx(ctx)); err != nil {
+		cr.logger.Errorw("Failed to record cron tick", "error", err)
+	}
 	vars := pipeline.NewVarsFrom(map[string]interface{}{
 		"jobSpec": map[string]interface{}{
 			"databaseID":    cr.jobSpec.ID,
@@ -73,7 +210,7 @@ func (cr *Cron) runPipeline() {
 			"name":          cr.jobSpec.Name.ValueOrZero(),
 		},
 		"jobRun": map[string]interface{}{
-			"meta": map[string]interface{}{},
+			"meta": meta,
 		},
 	})
 
@@ -88,3 +225,8 @@ func (cr *Cron) runPipeline() {
 func cronRunner() *cron.Cron {
 	return cron.New(cron.WithSeconds())
 }
+
+// cronParser returns the parser used by cronRunner.
+func cronParser() cron.Parser {
+	return cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+}
@@ -1,7 +1,10 @@
 package cron_test
 
 import (
+	"context"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -16,6 +19,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/cron"
+	cronmocks "github.com/smartcontractkit/chainlink/v2/core/services/cron/mocks"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
 	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 	pipelinemocks "github.com/smartcontractkit/chainlink/v2/core/services/pipeline/mocks"
@@ -40,7 +44,7 @@ func TestCronV2Pipeline(t *testing.T) {
 		PipelineSpec:  &pipeline.Spec{},
 		ExternalJobID: uuid.New(),
 	}
-	delegate := cron.NewDelegate(runner, lggr)
+	delegate := cron.NewDelegate(runner, cron.NewORM(db, lggr, cfg), lggr)
 
 	err := jobORM.CreateJob(jb)
 	require.NoError(t, err)
@@ -70,7 +74,7 @@ func TestCronV2Schedule(t *testing.T) {
 		Return(false, nil).
 		Once()
 
-	service, err := cron.NewCronFromJobSpec(spec, runner, logger.TestLogger(t))
+	service, err := cron.NewCronFromJobSpec(spec, runner, newORM(t), logger.TestLogger(t))
 	require.NoError(t, err)
 	err = service.Start(testutils.Context(t))
 	require.NoError(t, err)
@@ -78,3 +82,211 @@ func TestCronV2Schedule(t *testing.T) {
 
 	awaiter.AwaitOrFail(t)
 }
+
+func TestCronV2MisfirePolicy(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	lastMidnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	daily := "0 0 0 * * *"
+
+	tests := []struct {
+		name      string
+		policy    job.CronMisfirePolicy
+		createdAt time.Time
+		lastTick  *time.Time
+		expected  []time.Time
+	}{
+		{
+			name:      "skip",
+			policy:    job.CronMisfireSkip,
+			createdAt: lastMidnight.Add(-72*time.Hour - time.Second),
+		},
+		{
+			name:      "run once",
+			policy:    job.CronMisfireRunOnce,
+			createdAt: lastMidnight.Add(-72*time.Hour - time.Second),
+			expected:  []time.Time{lastMidnight},
+		},
+		{
+			name:      "catch up since creation",
+			policy:    job.CronMisfireCatchUp,
+			createdAt: lastMidnight.Add(-48*time.Hour - time.Second),
+			expected:  []time.Time{lastMidnight.Add(-48 * time.Hour), lastMidnight.Add(-24 * time.Hour), lastMidnight},
+		},
+		{
+			name:      "catch up since last tick",
+			policy:    job.CronMisfireCatchUp,
+			createdAt: lastMidnight.Add(-72*time.Hour - time.Second),
+			lastTick:  ptr(lastMidnight.Add(-24 * time.Hour)),
+			expected:  []time.Time{lastMidnight},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			spec := job.Job{
+				ID:            1,
+				Type:          job.Cron,
+				SchemaVersion: 1,
+				CronSpec: &job.CronSpec{
+					CronSchedule:  daily,
+					Timezone:      "UTC",
+					MisfirePolicy: tc.policy,
+				},
+				PipelineSpec: &pipeline.Spec{},
+				CreatedAt:    tc.createdAt,
+			}
+
+			orm := cronmocks.NewORM(t)
+			if tc.policy != job.CronMisfireSkip {
+				if tc.lastTick == nil {
+					orm.On("LastTick", int32(1)).Return(time.Time{}, nil)
+				} else {
+					orm.On("LastTick", int32(1)).Return(*tc.lastTick, nil)
+				}
+			}
+			for _, tick := range tc.expected {
+				tick := tick
+				orm.On("SetLastTick", int32(1), mock.MatchedBy(tick.Equal), mock.Anything).Return(nil).Once()
+			}
+
+			var mu sync.Mutex
+			var scheduled []string
+			runner := pipelinemocks.NewRunner(t)
+			if len(tc.expected) > 0 {
+				runner.On("Run", mock.Anything, mock.AnythingOfType("*pipeline.Run"), mock.Anything, mock.Anything, mock.Anything).
+					Run(func(args mock.Arguments) {
+						run := args.Get(1).(*pipeline.Run)
+						meta := run.Inputs.Val.(map[string]interface{})["jobRun"].(map[string]interface{})["meta"].(map[string]interface{})
+						mu.Lock()
+						defer mu.Unlock()
+						scheduled = append(scheduled, meta["scheduledAt"].(string))
+					}).
+					Return(false, nil).
+					Times(len(tc.expected))
+			}
+
+			service, err := cron.NewCronFromJobSpec(spec, runner, orm, logger.TestLogger(t))
+			require.NoError(t, err)
+			require.NoError(t, service.Start(testutils.Context(t)))
+
+			var expected []string
+			for _, tick := range tc.expected {
+				expected = append(expected, tick.Format(time.RFC3339))
+			}
+			require.Eventually(t, func() bool {
+				mu.Lock()
+				defer mu.Unlock()
+				return len(scheduled) == len(expected)
+			}, testutils.WaitTimeout(t), 10*time.Millisecond)
+			require.NoError(t, service.Close())
+			assert.Equal(t, expected, scheduled)
+		})
+	}
+}
+
+func TestCronV2OverlapPolicySkip(t *testing.T) {
+	t.Parallel()
+
+	spec := job.Job{
+		Type:          job.Cron,
+		SchemaVersion: 1,
+		CronSpec:      &job.CronSpec{CronSchedule: "@every 1s", OverlapPolicy: job.CronOverlapSkip},
+		PipelineSpec:  &pipeline.Spec{},
+	}
+	started := cltest.NewAwaiter()
+	runner := pipelinemocks.NewRunner(t)
+	runner.On("Run", mock.Anything, mock.AnythingOfType("*pipeline.Run"), mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			started.ItHappened()
+			// Block until the service is closed.
+			<-args.Get(0).(context.Context).Done()
+		}).
+		Return(false, nil).
+		Once()
+
+	service, err := cron.NewCronFromJobSpec(spec, runner, newORM(t), logger.TestLogger(t))
+	require.NoError(t, err)
+	require.NoError(t, service.Start(testutils.Context(t)))
+
+	// Further ticks are skipped while the first run is in progress.
+	started.AwaitOrFail(t)
+	time.Sleep(2500 * time.Millisecond)
+	require.NoError(t, service.Close())
+}
+
+func TestCronV2OverlapPolicyQueue(t *testing.T) {
+	t.Parallel()
+
+	spec := job.Job{
+		Type:          job.Cron,
+		SchemaVersion: 1,
+		CronSpec:      &job.CronSpec{CronSchedule: "@every 1h", OverlapPolicy: job.CronOverlapQueue},
+		PipelineSpec:  &pipeline.Spec{},
+	}
+	started, release := cltest.NewAwaiter(), make(chan struct{})
+	runner := pipelinemocks.NewRunner(t)
+	runner.On("Run", mock.Anything, mock.AnythingOfType("*pipeline.Run"), mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			started.ItHappened()
+			<-release
+		}).
+		Return(false, nil).
+		Once()
+	runner.On("Run", mock.Anything, mock.AnythingOfType("*pipeline.Run"), mock.Anything, mock.Anything, mock.Anything).
+		Return(false, nil).
+		Once()
+
+	service, err := cron.NewCronFromJobSpec(spec, runner, newORM(t), logger.TestLogger(t))
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		service.RunTick(time.Time{})
+	}()
+	started.AwaitOrFail(t)
+	go func() {
+		defer wg.Done()
+		service.RunTick(time.Time{})
+	}()
+	require.Eventually(t, service.HasQueuedTick, testutils.WaitTimeout(t), 10*time.Millisecond)
+
+	// A tick is queued already, so the next one is skipped instead of
+	// waiting for the run in progress.
+	service.RunTick(time.Time{})
+
+	// The queued tick runs once the run in progress finishes.
+	close(release)
+	wg.Wait()
+}
+
+func TestCronV2CloseTwice(t *testing.T) {
+	t.Parallel()
+
+	spec := job.Job{
+		Type:          job.Cron,
+		SchemaVersion: 1,
+		CronSpec:      &job.CronSpec{CronSchedule: "@every 1h"},
+		PipelineSpec:  &pipeline.Spec{},
+	}
+	service, err := cron.NewCronFromJobSpec(spec, pipelinemocks.NewRunner(t), newORM(t), logger.TestLogger(t))
+	require.NoError(t, err)
+	require.NoError(t, service.Start(testutils.Context(t)))
+	require.NoError(t, service.Close())
+	assert.Error(t, service.Close())
+}
+
+// newORM returns an ORM mock that records any tick.
+func newORM(t *testing.T) *cronmocks.ORM {
+	orm := cronmocks.NewORM(t)
+	orm.On("SetLastTick", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	return orm
+}
+
+func ptr[T any](v T) *T { return &v }
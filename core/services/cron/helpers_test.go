@@ -0,0 +1,14 @@
+package cron
+
+import "time"
+
+// RunTick runs the pipeline for a tick according to the overlap policy of
+// the job.
+func (cr *Cron) RunTick(scheduledAt time.Time) {
+	cr.run(scheduledAt)
+}
+
+// HasQueuedTick returns whether a tick waits for the run in progress.
+func (cr *Cron) HasQueuedTick() bool {
+	return len(cr.queued) > 0
+}
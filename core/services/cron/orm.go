@@ -0,0 +1,54 @@
+package cron
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/sqlx"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+)
+
+//go:generate mockery --quiet --name ORM --output ./mocks/ --case=underscore
+
+// ORM stores the last tick of each cron job.
+type ORM interface {
+	// LastTick returns the time of the last tick the job ran, or the zero
+	// time if it never ran.
+	LastTick(jobID int32, qopts ...pg.QOpt) (time.Time, error)
+	// SetLastTick records that the job ran the tick. Older ticks do not
+	// replace a newer one.
+	SetLastTick(jobID int32, tick time.Time, qopts ...pg.QOpt) error
+}
+
+type orm struct {
+	q pg.Q
+}
+
+var _ ORM = &orm{}
+
+// NewORM returns an ORM backed by db.
+func NewORM(db *sqlx.DB, lggr logger.Logger, cfg pg.QConfig) ORM {
+	return &orm{q: pg.NewQ(db, lggr.Named("CronORM"), cfg)}
+}
+
+func (o *orm) LastTick(jobID int32, qopts ...pg.QOpt) (time.Time, error) {
+	q := o.q.WithOpts(qopts...)
+	var tick time.Time
+	err := q.Get(&tick, `SELECT tick_at FROM cron_last_ticks WHERE job_id = $1`, jobID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	return tick, errors.Wrap(err, "failed to get last tick")
+}
+
+func (o *orm) SetLastTick(jobID int32, tick time.Time, qopts ...pg.QOpt) error {
+	q := o.q.WithOpts(qopts...)
+	_, err := q.Exec(`INSERT INTO cron_last_ticks (job_id, tick_at, updated_at) VALUES ($1, $2, NOW())
+		ON CONFLICT (job_id) DO UPDATE SET
+			tick_at = GREATEST(cron_last_ticks.tick_at, EXCLUDED.tick_at),
+			updated_at = EXCLUDED.updated_at`, jobID, tick)
+	return errors.Wrap(err, "failed to set last tick")
+}
@@ -0,0 +1,60 @@
+package cron_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/bridges"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	configtest "github.com/smartcontractkit/chainlink/v2/core/internal/testutils/configtest/v2"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/evmtest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/cron"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+)
+
+func TestORM_LastTick(t *testing.T) {
+	cfg := configtest.NewTestGeneralConfig(t)
+	db := pgtest.NewSqlxDB(t)
+	keyStore := cltest.NewKeyStore(t, db, cfg)
+	cc := evmtest.NewChainSet(t, evmtest.TestChainOpts{DB: db, GeneralConfig: cfg, Client: evmtest.NewEthClientMockWithDefaultChain(t), KeyStore: keyStore.Eth()})
+	lggr := logger.TestLogger(t)
+	jobORM := job.NewORM(db, cc, pipeline.NewORM(db, lggr, cfg), bridges.NewORM(db, lggr, cfg), keyStore, lggr, cfg)
+
+	jb := &job.Job{
+		Type:          job.Cron,
+		SchemaVersion: 1,
+		CronSpec:      &job.CronSpec{CronSchedule: "@every 1s"},
+		PipelineSpec:  &pipeline.Spec{},
+		ExternalJobID: uuid.New(),
+	}
+	require.NoError(t, jobORM.CreateJob(jb))
+	orm := cron.NewORM(db, lggr, cfg)
+
+	tick, err := orm.LastTick(jb.ID)
+	require.NoError(t, err)
+	assert.True(t, tick.IsZero())
+
+	now := time.Now().UTC().Truncate(time.Second)
+	require.NoError(t, orm.SetLastTick(jb.ID, now))
+	tick, err = orm.LastTick(jb.ID)
+	require.NoError(t, err)
+	assert.True(t, now.Equal(tick))
+
+	// An older tick, e.g. of a missed tick run late, does not replace a newer one.
+	require.NoError(t, orm.SetLastTick(jb.ID, now.Add(-time.Hour)))
+	tick, err = orm.LastTick(jb.ID)
+	require.NoError(t, err)
+	assert.True(t, now.Equal(tick))
+
+	require.NoError(t, orm.SetLastTick(jb.ID, now.Add(time.Hour)))
+	tick, err = orm.LastTick(jb.ID)
+	require.NoError(t, err)
+	assert.True(t, now.Add(time.Hour).Equal(tick))
+}
@@ -1,6 +1,10 @@
 package cron
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/pelletier/go-toml"
 	"github.com/pkg/errors"
@@ -34,9 +38,43 @@ func ValidatedCronSpec(tomlString string) (job.Job, error) {
 	if jb.Type != job.Cron {
 		return jb, errors.Errorf("unsupported type %s", jb.Type)
 	}
-	if err := utils.ValidateCronSchedule(spec.CronSchedule); err != nil {
+	if spec.Timezone != "" {
+		if strings.HasPrefix(spec.CronSchedule, "CRON_TZ=") {
+			return jb, errors.New("cron schedule must not specify a time zone using CRON_TZ when timezone is set")
+		}
+		if _, err := time.LoadLocation(spec.Timezone); err != nil {
+			return jb, errors.Wrapf(err, "invalid timezone '%v'", spec.Timezone)
+		}
+	}
+	if err := utils.ValidateCronSchedule(schedule(spec)); err != nil {
 		return jb, errors.Wrapf(err, "while validating cron schedule '%v'", spec.CronSchedule)
 	}
 
+	switch spec.MisfirePolicy {
+	case "":
+		spec.MisfirePolicy = job.CronMisfireSkip
+	case job.CronMisfireSkip, job.CronMisfireRunOnce, job.CronMisfireCatchUp:
+	default:
+		return jb, errors.Errorf("invalid misfirePolicy '%v', must be one of %s, %s or %s",
+			spec.MisfirePolicy, job.CronMisfireSkip, job.CronMisfireRunOnce, job.CronMisfireCatchUp)
+	}
+	switch spec.OverlapPolicy {
+	case "":
+		spec.OverlapPolicy = job.CronOverlapAllow
+	case job.CronOverlapAllow, job.CronOverlapSkip, job.CronOverlapQueue:
+	default:
+		return jb, errors.Errorf("invalid overlapPolicy '%v', must be one of %s, %s or %s",
+			spec.OverlapPolicy, job.CronOverlapAllow, job.CronOverlapSkip, job.CronOverlapQueue)
+	}
+
 	return jb, nil
 }
+
+// schedule returns the cron schedule of spec, with its timezone as a CRON_TZ
+// prefix if set.
+func schedule(spec job.CronSpec) string {
+	if spec.Timezone == "" {
+		return spec.CronSchedule
+	}
+	return fmt.Sprintf("CRON_TZ=%s %s", spec.Timezone, spec.CronSchedule)
+}
@@ -77,6 +77,103 @@ ds -> ds_parse -> ds_multiply;
 				assert.True(t, strings.Contains(err.Error(), "invalid cron schedule"))
 			},
 		},
+		{
+			name: "timezone and policies",
+			toml: `
+type            = "cron"
+schemaVersion   = 1
+schedule        = "0 0 18 * * *"
+timezone        = "America/New_York"
+misfirePolicy   = "runOnce"
+overlapPolicy   = "queue"
+observationSource   = """
+ds          [type=http method=GET url="https://chain.link/ETH-USD"];
+"""
+`,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "America/New_York", s.CronSpec.Timezone)
+				assert.Equal(t, job.CronMisfireRunOnce, s.CronSpec.MisfirePolicy)
+				assert.Equal(t, job.CronOverlapQueue, s.CronSpec.OverlapPolicy)
+			},
+		},
+		{
+			name: "default policies",
+			toml: `
+type            = "cron"
+schemaVersion   = 1
+schedule        = "CRON_TZ=UTC 0 0 1 1 * *"
+observationSource   = """
+ds          [type=http method=GET url="https://chain.link/ETH-USD"];
+"""
+`,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, job.CronMisfireSkip, s.CronSpec.MisfirePolicy)
+				assert.Equal(t, job.CronOverlapAllow, s.CronSpec.OverlapPolicy)
+			},
+		},
+		{
+			name: "timezone and CRON_TZ",
+			toml: `
+type            = "cron"
+schemaVersion   = 1
+schedule        = "CRON_TZ=UTC 0 0 1 1 * *"
+timezone        = "UTC"
+observationSource   = """
+ds          [type=http method=GET url="https://chain.link/ETH-USD"];
+"""
+`,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				require.EqualError(t, err, "cron schedule must not specify a time zone using CRON_TZ when timezone is set")
+			},
+		},
+		{
+			name: "invalid timezone",
+			toml: `
+type            = "cron"
+schemaVersion   = 1
+schedule        = "0 0 1 1 * *"
+timezone        = "Mars/Olympus_Mons"
+observationSource   = """
+ds          [type=http method=GET url="https://chain.link/ETH-USD"];
+"""
+`,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "invalid timezone 'Mars/Olympus_Mons'")
+			},
+		},
+		{
+			name: "invalid misfire policy",
+			toml: `
+type            = "cron"
+schemaVersion   = 1
+schedule        = "CRON_TZ=UTC 0 0 1 1 * *"
+misfirePolicy   = "always"
+observationSource   = """
+ds          [type=http method=GET url="https://chain.link/ETH-USD"];
+"""
+`,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				require.EqualError(t, err, "invalid misfirePolicy 'always', must be one of skip, runOnce or catchUp")
+			},
+		},
+		{
+			name: "invalid overlap policy",
+			toml: `
+type            = "cron"
+schemaVersion   = 1
+schedule        = "CRON_TZ=UTC 0 0 1 1 * *"
+overlapPolicy   = "cancel"
+observationSource   = """
+ds          [type=http method=GET url="https://chain.link/ETH-USD"];
+"""
+`,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				require.EqualError(t, err, "invalid overlapPolicy 'cancel', must be one of allow, skip or queue")
+			},
+		},
 	}
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
@@ -3,14 +3,18 @@ package directrequest
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"reflect"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/pkg/errors"
 
 	"github.com/smartcontractkit/chainlink/v2/core/assets"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm"
+	evmclient "github.com/smartcontractkit/chainlink/v2/core/chains/evm/client"
+	httypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/headtracker/types"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/log"
 	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/operator_wrapper"
@@ -28,6 +32,7 @@ type (
 		logger         logger.Logger
 		pipelineRunner pipeline.Runner
 		pipelineORM    pipeline.ORM
+		jobORM         job.ORM
 		chHeads        chan *evmtypes.Head
 		chainSet       evm.ChainSet
 		mailMon        *utils.MailboxMonitor
@@ -45,6 +50,7 @@ func NewDelegate(
 	logger logger.Logger,
 	pipelineRunner pipeline.Runner,
 	pipelineORM pipeline.ORM,
+	jobORM job.ORM,
 	chainSet evm.ChainSet,
 	mailMon *utils.MailboxMonitor,
 ) *Delegate {
@@ -52,6 +58,7 @@ func NewDelegate(
 		logger.Named("DirectRequest"),
 		pipelineRunner,
 		pipelineORM,
+		jobORM,
 		make(chan *evmtypes.Head, 1),
 		chainSet,
 		mailMon,
@@ -94,10 +101,13 @@ func (d *Delegate) ServicesForSpec(jb job.Job) ([]job.ServiceCtx, error) {
 	logListener := &listener{
 		logger:                   svcLogger.Named("DirectRequest"),
 		config:                   chain.Config(),
+		ethClient:                chain.Client(),
 		logBroadcaster:           chain.LogBroadcaster(),
+		headBroadcaster:          chain.HeadBroadcaster(),
 		oracle:                   oracle,
 		pipelineRunner:           d.pipelineRunner,
 		pipelineORM:              d.pipelineORM,
+		jobORM:                   d.jobORM,
 		mailMon:                  d.mailMon,
 		job:                      jb,
 		mbOracleRequests:         utils.NewHighCapacityMailbox[log.Broadcast](),
@@ -106,6 +116,7 @@ func (d *Delegate) ServicesForSpec(jb job.Job) ([]job.ServiceCtx, error) {
 		requesters:               concreteSpec.Requesters,
 		minContractPayment:       concreteSpec.MinContractPayment,
 		chStop:                   make(chan struct{}),
+		chNewHead:                make(chan struct{}, 1),
 	}
 	var services []job.ServiceCtx
 	services = append(services, logListener)
@@ -114,17 +125,30 @@ func (d *Delegate) ServicesForSpec(jb job.Job) ([]job.ServiceCtx, error) {
 }
 
 var (
-	_ log.Listener   = &listener{}
-	_ job.ServiceCtx = &listener{}
+	_ log.Listener          = &listener{}
+	_ job.ServiceCtx        = &listener{}
+	_ httypes.HeadTrackable = &listener{}
 )
 
+// pendingRequest is a request waiting for the confirmations it asked for in
+// its options.
+type pendingRequest struct {
+	confirmedAtBlock uint64
+	request          *operator_wrapper.OperatorOracleRequest
+	lb               log.Broadcast
+	opts             RequestOptions
+}
+
 type listener struct {
 	logger                   logger.Logger
 	config                   Config
+	ethClient                evmclient.Client
 	logBroadcaster           log.Broadcaster
+	headBroadcaster          httypes.HeadBroadcasterRegistry
 	oracle                   operator_wrapper.OperatorInterface
 	pipelineRunner           pipeline.Runner
 	pipelineORM              pipeline.ORM
+	jobORM                   job.ORM
 	mailMon                  *utils.MailboxMonitor
 	job                      job.Job
 	runs                     sync.Map // map[string]utils.StopChan
@@ -136,6 +160,14 @@ type listener struct {
 	minContractPayment       *assets.Link
 	chStop                   chan struct{}
 	utils.StartStopOnce
+
+	// The requests waiting for more confirmations are kept in memory, as
+	// their logs are only marked consumed once their run starts.
+	pendingMu    sync.Mutex
+	pending      []pendingRequest
+	latestHead   *evmtypes.Head
+	latestHeadMu sync.RWMutex
+	chNewHead    chan struct{}
 }
 
 // Start complies with job.Service
@@ -160,6 +192,17 @@ func (l *listener) Start(context.Context) error {
 			l.shutdownWaitGroup.Done()
 		}()
 
+		// Requests can only wait for more confirmations than the job if the
+		// job allows it, so the listener only follows the heads then.
+		if maxConfs := l.job.DirectRequestSpec.MaxRequestMinConfirmations; maxConfs.Valid && maxConfs.Uint32 > l.minIncomingConfirmations {
+			latestHead, unsubscribeHeads := l.headBroadcaster.Subscribe(l)
+			if latestHead != nil {
+				l.setLatestHead(latestHead)
+			}
+			l.shutdownWaitGroup.Add(1)
+			go l.processPendingRequests(unsubscribeHeads)
+		}
+
 		l.mailMon.Monitor(l.mbOracleRequests, "DirectRequest", "Requests", fmt.Sprint(l.job.PipelineSpec.JobID))
 		l.mailMon.Monitor(l.mbOracleCancelRequests, "DirectRequest", "Cancel", fmt.Sprint(l.job.PipelineSpec.JobID))
 
@@ -219,6 +262,128 @@ func (l *listener) processOracleRequests() {
 	}
 }
 
+// OnNewLongestChain implements httypes.HeadTrackable. It wakes up the
+// requests waiting for more confirmations.
+func (l *listener) OnNewLongestChain(_ context.Context, head *evmtypes.Head) {
+	l.setLatestHead(head)
+	select {
+	case l.chNewHead <- struct{}{}:
+	default:
+	}
+}
+
+func (l *listener) setLatestHead(h *evmtypes.Head) {
+	l.latestHeadMu.Lock()
+	defer l.latestHeadMu.Unlock()
+	// A head of the same height replaces the latest head, as it is on a new
+	// longest chain.
+	if l.latestHead == nil || h.Number >= l.latestHead.Number {
+		l.latestHead = h
+	}
+}
+
+func (l *listener) getLatestHead() *evmtypes.Head {
+	l.latestHeadMu.RLock()
+	defer l.latestHeadMu.RUnlock()
+	return l.latestHead
+}
+
+func (l *listener) getLatestHeadNumber() uint64 {
+	if h := l.getLatestHead(); h != nil {
+		return uint64(h.Number)
+	}
+	return 0
+}
+
+func (l *listener) processPendingRequests(unsubscribe func()) {
+	defer l.shutdownWaitGroup.Done()
+	defer unsubscribe()
+	ctx, cancel := utils.StopChan(l.chStop).NewCtx()
+	defer cancel()
+	for {
+		select {
+		case <-l.chStop:
+			return
+		case <-l.chNewHead:
+			for _, req := range l.extractConfirmedRequests() {
+				l.runPendingRequest(ctx, req)
+			}
+		}
+	}
+}
+
+// extractConfirmedRequests removes and returns the pending requests that have
+// the confirmations they asked for.
+func (l *listener) extractConfirmedRequests() []pendingRequest {
+	l.pendingMu.Lock()
+	defer l.pendingMu.Unlock()
+	latestHead := l.getLatestHeadNumber()
+	var confirmed, pending []pendingRequest
+	for _, req := range l.pending {
+		if req.confirmedAtBlock <= latestHead {
+			confirmed = append(confirmed, req)
+		} else {
+			pending = append(pending, req)
+		}
+	}
+	l.pending = pending
+	return confirmed
+}
+
+// runPendingRequest runs a request that has the confirmations it asked for,
+// unless it was cancelled or its log was removed by a reorg while it waited.
+func (l *listener) runPendingRequest(ctx context.Context, req pendingRequest) {
+	requestID := formatRequestId(req.request.RequestId)
+	runCloserChannelIf, exists := l.runs.Load(requestID)
+	if !exists {
+		// The request was cancelled while it was waiting.
+		l.markLogConsumed(req.lb)
+		return
+	}
+	select {
+	case <-runCloserChannelIf.(utils.StopChan):
+		l.markLogConsumed(req.lb)
+		return
+	default:
+	}
+	// The log broadcaster only delivered the log with the confirmations of
+	// the job, so the log might have been removed by a reorg since.
+	canonical, err := l.isCanonical(ctx, req.request.Raw)
+	if err != nil {
+		if ctx.Err() == nil {
+			l.logger.Errorw("Failed to check the block of oracle request, retrying on the next head", "err", err, "requestId", requestID)
+			l.addPendingRequest(req)
+		}
+		return
+	}
+	if !canonical {
+		l.logger.Warnw("Oracle request was removed by a reorg, dropping it",
+			"requestId", requestID,
+			"blockNumber", req.request.Raw.BlockNumber,
+			"blockHash", req.request.Raw.BlockHash,
+		)
+		l.markLogConsumed(req.lb)
+		return
+	}
+	l.runOracleRequest(req.request, req.lb, req.opts)
+}
+
+// isCanonical returns true if the block of a log is on the canonical chain.
+// The chain of the latest head is checked first, and the block is fetched
+// from the node if it is older than that chain.
+func (l *listener) isCanonical(ctx context.Context, lg types.Log) (bool, error) {
+	if head := l.getLatestHead(); head != nil {
+		if hash := head.HashAtHeight(int64(lg.BlockNumber)); hash != (common.Hash{}) {
+			return hash == lg.BlockHash, nil
+		}
+	}
+	head, err := l.ethClient.HeadByNumber(ctx, new(big.Int).SetUint64(lg.BlockNumber))
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get block %d", lg.BlockNumber)
+	}
+	return head.Hash == lg.BlockHash, nil
+}
+
 func (l *listener) processCancelOracleRequests() {
 	for {
 		select {
@@ -328,6 +493,63 @@ func (l *listener) handleOracleRequest(request *operator_wrapper.OperatorOracleR
 		}
 	}
 
+	opts, err := ParseRequestOptions(request.Data)
+	if err == nil {
+		err = opts.Validate(*l.job.DirectRequestSpec, l.minIncomingConfirmations)
+	}
+	if err != nil {
+		l.rejectOracleRequest(request, lb, err)
+		return
+	}
+
+	minConfs := opts.minConfirmations(l.minIncomingConfirmations)
+	if minConfs > l.minIncomingConfirmations {
+		// The log broadcaster delivers logs with the confirmations of the
+		// job, so requests asking for more wait for later heads.
+		confirmedAtBlock := request.Raw.BlockNumber + uint64(minConfs) - 1
+		if confirmedAtBlock > l.getLatestHeadNumber() {
+			l.logger.Infow("Oracle request waits for more confirmations",
+				"requestId", formatRequestId(request.RequestId),
+				"minConfirmations", minConfs,
+				"confirmedAtBlock", confirmedAtBlock,
+			)
+			l.addPendingRequest(pendingRequest{confirmedAtBlock, request, lb, opts})
+			return
+		}
+	}
+
+	l.runOracleRequest(request, lb, opts)
+}
+
+func (l *listener) addPendingRequest(req pendingRequest) {
+	l.pendingMu.Lock()
+	defer l.pendingMu.Unlock()
+	for _, p := range l.pending {
+		if p.request.RequestId == req.request.RequestId && p.request.Raw.BlockHash == req.request.Raw.BlockHash {
+			// The log was delivered again while the request was waiting. A
+			// log of the same request in another block, emitted again after
+			// a reorg, waits next to it.
+			return
+		}
+	}
+	l.runs.LoadOrStore(formatRequestId(req.request.RequestId), make(utils.StopChan))
+	l.pending = append(l.pending, req)
+}
+
+// rejectOracleRequest consumes a request whose options the job does not
+// allow, and records the reason as a job error.
+func (l *listener) rejectOracleRequest(request *operator_wrapper.OperatorOracleRequest, lb log.Broadcast, reason error) {
+	l.logger.Warnw("Rejected run for invalid request options",
+		"requestId", formatRequestId(request.RequestId),
+		"reason", reason,
+	)
+	l.jobORM.TryRecordError(l.job.ID, fmt.Sprintf("Rejected oracle request: %v", reason))
+	l.markLogConsumed(lb)
+}
+
+// runOracleRequest runs the pipeline for a request, with the options of the
+// request applied.
+func (l *listener) runOracleRequest(request *operator_wrapper.OperatorOracleRequest, lb log.Broadcast, opts RequestOptions) {
 	meta := make(map[string]interface{})
 	meta["oracleRequest"] = oracleRequestToMap(request)
 
@@ -359,9 +581,16 @@ func (l *listener) handleOracleRequest(request *operator_wrapper.OperatorOracleR
 			"blockReceiptsRoot":     lb.ReceiptsRoot(),
 			"blockTransactionsRoot": lb.TransactionsRoot(),
 			"blockStateRoot":        lb.StateRoot(),
+			"requestOptions":        opts.vars(*l.job.DirectRequestSpec, l.minIncomingConfirmations),
 		},
 	})
-	run := pipeline.NewRun(*l.job.PipelineSpec, vars)
+	// The gas limit of the request replaces the one of the job for the eth
+	// tx tasks that do not set one.
+	pipelineSpec := *l.job.PipelineSpec
+	if opts.GasLimit != nil {
+		pipelineSpec.GasLimit = opts.GasLimit
+	}
+	run := pipeline.NewRun(pipelineSpec, vars)
 	_, err := l.pipelineRunner.Run(ctx, &run, l.logger, true, func(tx pg.Queryer) error {
 		l.markLogConsumed(lb, pg.WithQueryer(tx))
 		return nil
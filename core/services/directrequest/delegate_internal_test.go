@@ -0,0 +1,101 @@
+package directrequest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	evmclimocks "github.com/smartcontractkit/chainlink/v2/core/chains/evm/client/mocks"
+	logmocks "github.com/smartcontractkit/chainlink/v2/core/chains/evm/log/mocks"
+	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/operator_wrapper"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+	pipelinemocks "github.com/smartcontractkit/chainlink/v2/core/services/pipeline/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+func TestListener_RunPendingRequest(t *testing.T) {
+	t.Parallel()
+
+	canonicalHash, reorgedHash := utils.NewHash(), utils.NewHash()
+	// The chain of the latest head goes back to block 10.
+	head := &evmtypes.Head{Number: 12, Hash: utils.NewHash(), Parent: &evmtypes.Head{
+		Number: 11, Hash: utils.NewHash(), Parent: &evmtypes.Head{Number: 10, Hash: canonicalHash},
+	}}
+
+	newListener := func(t *testing.T) (*listener, *logmocks.Broadcaster, *evmclimocks.Client, *pipelinemocks.Runner) {
+		broadcaster := logmocks.NewBroadcaster(t)
+		client := evmclimocks.NewClient(t)
+		runner := pipelinemocks.NewRunner(t)
+		l := &listener{
+			logger:         logger.TestLogger(t),
+			ethClient:      client,
+			logBroadcaster: broadcaster,
+			pipelineRunner: runner,
+			job: job.Job{
+				DirectRequestSpec: &job.DirectRequestSpec{},
+				PipelineSpec:      &pipeline.Spec{},
+			},
+		}
+		l.setLatestHead(head)
+		return l, broadcaster, client, runner
+	}
+	newRequest := func(l *listener, blockNumber uint64, blockHash common.Hash) pendingRequest {
+		request := &operator_wrapper.OperatorOracleRequest{
+			RequestId: utils.NewHash(),
+			Raw:       types.Log{BlockNumber: blockNumber, BlockHash: blockHash},
+		}
+		l.runs.Store(formatRequestId(request.RequestId), make(utils.StopChan))
+		lb := logmocks.NewBroadcast(t)
+		lb.On("String").Return("log").Maybe()
+		return pendingRequest{confirmedAtBlock: blockNumber, request: request, lb: lb}
+	}
+
+	t.Run("log removed by a reorg", func(t *testing.T) {
+		l, broadcaster, _, _ := newListener(t)
+		req := newRequest(l, 10, reorgedHash)
+		broadcaster.On("MarkConsumed", req.lb).Return(nil).Once()
+
+		l.runPendingRequest(testutils.Context(t), req)
+	})
+
+	t.Run("log in the chain of the latest head", func(t *testing.T) {
+		l, _, _, runner := newListener(t)
+		req := newRequest(l, 10, canonicalHash)
+		lb := req.lb.(*logmocks.Broadcast)
+		lb.On("ReceiptsRoot").Return(common.Hash{})
+		lb.On("TransactionsRoot").Return(common.Hash{})
+		lb.On("StateRoot").Return(common.Hash{})
+		runner.On("Run", mock.Anything, mock.Anything, mock.Anything, true, mock.Anything).Return(false, nil).Once()
+
+		l.runPendingRequest(testutils.Context(t), req)
+	})
+
+	t.Run("log older than the chain of the latest head", func(t *testing.T) {
+		l, broadcaster, client, _ := newListener(t)
+		req := newRequest(l, 5, reorgedHash)
+		client.On("HeadByNumber", mock.Anything, big.NewInt(5)).Return(&evmtypes.Head{Number: 5, Hash: utils.NewHash()}, nil).Once()
+		broadcaster.On("MarkConsumed", req.lb).Return(nil).Once()
+
+		l.runPendingRequest(testutils.Context(t), req)
+	})
+
+	t.Run("block of the log cannot be fetched", func(t *testing.T) {
+		l, _, client, _ := newListener(t)
+		req := newRequest(l, 5, reorgedHash)
+		client.On("HeadByNumber", mock.Anything, big.NewInt(5)).Return(nil, assert.AnError).Once()
+
+		// The request waits for the next head.
+		l.runPendingRequest(testutils.Context(t), req)
+		require.Len(t, l.pending, 1)
+		assert.Equal(t, req.request.RequestId, l.pending[0].request.RequestId)
+	})
+}
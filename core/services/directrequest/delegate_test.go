@@ -8,6 +8,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/fxamacker/cbor/v2"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -24,6 +25,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/evmtest"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/null"
 	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/v2/core/services/directrequest"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
@@ -46,7 +48,7 @@ func TestDelegate_ServicesForSpec(t *testing.T) {
 	cc := evmtest.NewChainSet(t, evmtest.TestChainOpts{DB: db, GeneralConfig: cfg, Client: ethClient, MailMon: mailMon, KeyStore: keyStore.Eth()})
 
 	lggr := logger.TestLogger(t)
-	delegate := directrequest.NewDelegate(lggr, runner, nil, cc, mailMon)
+	delegate := directrequest.NewDelegate(lggr, runner, nil, nil, cc, mailMon)
 
 	t.Run("Spec without DirectRequestSpec", func(t *testing.T) {
 		spec := job.Job{}
@@ -88,7 +90,7 @@ func NewDirectRequestUniverseWithConfig(t *testing.T, cfg chainlink.GeneralConfi
 	btORM := bridges.NewORM(db, lggr, cfg)
 
 	jobORM := job.NewORM(db, cc, orm, btORM, keyStore, lggr, cfg)
-	delegate := directrequest.NewDelegate(lggr, runner, orm, cc, mailMon)
+	delegate := directrequest.NewDelegate(lggr, runner, orm, jobORM, cc, mailMon)
 
 	jb := cltest.MakeDirectRequestJobSpec(t)
 	jb.ExternalJobID = uuid.New()
@@ -539,6 +541,95 @@ func TestDelegate_ServicesListenerHandleLog(t *testing.T) {
 
 		uni.service.Close()
 	})
+
+	t.Run("request options exceed the caps of the job", func(t *testing.T) {
+		uni := NewDirectRequestUniverse(t)
+		defer uni.Cleanup()
+
+		log := log_mocks.NewBroadcast(t)
+
+		uni.logBroadcaster.On("WasAlreadyConsumed", mock.Anything, mock.Anything).Return(false, nil)
+		data, err := cbor.Marshal(map[string]interface{}{directrequest.OptionGasLimit: 500000})
+		require.NoError(t, err)
+		logOracleRequest := operator_wrapper.OperatorOracleRequest{
+			CancelExpiration: big.NewInt(0),
+			Data:             data,
+		}
+		log.On("RawLog").Return(types.Log{
+			Topics: []common.Hash{
+				{},
+				uni.spec.ExternalIDEncodeStringToTopic(),
+			},
+		})
+		log.On("DecodedLog").Return(&logOracleRequest)
+		markConsumedLogAwaiter := cltest.NewAwaiter()
+		uni.logBroadcaster.On("MarkConsumed", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			markConsumedLogAwaiter.ItHappened()
+		}).Return(nil)
+
+		err = uni.service.Start(testutils.Context(t))
+		require.NoError(t, err)
+
+		uni.listener.HandleLog(log)
+
+		markConsumedLogAwaiter.AwaitOrFail(t, 5*time.Second)
+
+		drJob, err := uni.jobORM.FindJob(testutils.Context(t), uni.listener.JobID())
+		require.NoError(t, err)
+		require.Len(t, drJob.JobSpecErrors, 1)
+		assert.Equal(t, "Rejected oracle request: _gasLimit is set, but the job does not allow requests to set a gas limit", drJob.JobSpecErrors[0].Description)
+
+		uni.service.Close()
+	})
+
+	t.Run("request gas limit replaces the gas limit of the job", func(t *testing.T) {
+		uni := NewDirectRequestUniverseWithConfig(t, configtest.NewGeneralConfig(t, func(c *chainlink.Config, s *chainlink.Secrets) {
+			c.EVM[0].MinIncomingConfirmations = ptr[uint32](1)
+		}), func(jb *job.Job) {
+			jb.DirectRequestSpec.MaxRequestGasLimit = null.Uint32From(1000000)
+		})
+		defer uni.Cleanup()
+
+		log := log_mocks.NewBroadcast(t)
+		log.On("ReceiptsRoot").Return(common.Hash{})
+		log.On("TransactionsRoot").Return(common.Hash{})
+		log.On("StateRoot").Return(common.Hash{})
+
+		uni.logBroadcaster.On("WasAlreadyConsumed", mock.Anything, mock.Anything).Return(false, nil)
+		data, err := cbor.Marshal(map[string]interface{}{directrequest.OptionGasLimit: 500000})
+		require.NoError(t, err)
+		logOracleRequest := operator_wrapper.OperatorOracleRequest{
+			CancelExpiration: big.NewInt(0),
+			Data:             data,
+		}
+		log.On("RawLog").Return(types.Log{
+			Topics: []common.Hash{
+				{},
+				uni.spec.ExternalIDEncodeStringToTopic(),
+			},
+		})
+		log.On("DecodedLog").Return(&logOracleRequest)
+		uni.logBroadcaster.On("MarkConsumed", mock.Anything, mock.Anything).Return(nil)
+
+		runBeganAwaiter := cltest.NewAwaiter()
+		uni.runner.On("Run", mock.Anything, mock.AnythingOfType("*pipeline.Run"), mock.Anything, mock.Anything, mock.Anything).
+			Return(false, nil).
+			Run(func(args mock.Arguments) {
+				run := args.Get(1).(*pipeline.Run)
+				require.NotNil(t, run.PipelineSpec.GasLimit)
+				assert.Equal(t, uint32(500000), *run.PipelineSpec.GasLimit)
+				runBeganAwaiter.ItHappened()
+			}).Once()
+
+		err = uni.service.Start(testutils.Context(t))
+		require.NoError(t, err)
+
+		uni.listener.HandleLog(log)
+
+		runBeganAwaiter.AwaitOrFail(t, 5*time.Second)
+
+		uni.service.Close()
+	})
 }
 
 func ptr[T any](t T) *T { return &t }
@@ -0,0 +1,167 @@
+package directrequest
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/cbor"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+)
+
+// Keys of the request options in the CBOR payload of an oracle request. They
+// are prefixed with an underscore so that they do not clash with the
+// parameters that existing consumers pass to the pipeline.
+const (
+	OptionGasLimit         = "_gasLimit"
+	OptionMinConfirmations = "_minConfirmations"
+	OptionFromAddress      = "_fromAddress"
+)
+
+// RequestOptions are the options an oracle request sets in its CBOR payload.
+type RequestOptions struct {
+	// GasLimit is the gas limit of the fulfillment transaction.
+	GasLimit *uint32
+	// MinConfirmations is the number of confirmations the request waits for
+	// before it is processed.
+	MinConfirmations *uint32
+	// FromAddress is the key that fulfills the request.
+	FromAddress *common.Address
+}
+
+// ParseRequestOptions returns the options set in the CBOR payload of an
+// oracle request. Payloads that are not CBOR maps have no options, as some
+// jobs do not read the payload at all.
+func ParseRequestOptions(data []byte) (opts RequestOptions, err error) {
+	payload, parseErr := cbor.ParseDietCBOR(data)
+	if parseErr != nil {
+		return opts, nil
+	}
+	if v, ok := payload[OptionGasLimit]; ok {
+		gasLimit, err2 := optionUint32(v)
+		if err2 != nil {
+			return opts, errors.Wrapf(err2, "invalid %s", OptionGasLimit)
+		}
+		opts.GasLimit = &gasLimit
+	}
+	if v, ok := payload[OptionMinConfirmations]; ok {
+		minConfs, err2 := optionUint32(v)
+		if err2 != nil {
+			return opts, errors.Wrapf(err2, "invalid %s", OptionMinConfirmations)
+		}
+		opts.MinConfirmations = &minConfs
+	}
+	if v, ok := payload[OptionFromAddress]; ok {
+		from, err2 := optionAddress(v)
+		if err2 != nil {
+			return opts, errors.Wrapf(err2, "invalid %s", OptionFromAddress)
+		}
+		opts.FromAddress = &from
+	}
+	return opts, nil
+}
+
+// Validate checks the options against the caps of the job. The returned
+// error is the reason the request is rejected.
+func (o RequestOptions) Validate(spec job.DirectRequestSpec, minIncomingConfirmations uint32) error {
+	if o.GasLimit != nil {
+		if !spec.MaxRequestGasLimit.Valid {
+			return errors.Errorf("%s is set, but the job does not allow requests to set a gas limit", OptionGasLimit)
+		}
+		if *o.GasLimit == 0 {
+			return errors.Errorf("%s must be positive", OptionGasLimit)
+		}
+		if *o.GasLimit > spec.MaxRequestGasLimit.Uint32 {
+			return errors.Errorf("%s %d exceeds maxRequestGasLimit %d", OptionGasLimit, *o.GasLimit, spec.MaxRequestGasLimit.Uint32)
+		}
+	}
+	// Requests asking for fewer confirmations than the job are processed once
+	// they have the confirmations of the job.
+	if o.MinConfirmations != nil && *o.MinConfirmations > minIncomingConfirmations {
+		if !spec.MaxRequestMinConfirmations.Valid {
+			return errors.Errorf("%s is set, but the job does not allow requests to wait for more than %d confirmations", OptionMinConfirmations, minIncomingConfirmations)
+		}
+		if *o.MinConfirmations > spec.MaxRequestMinConfirmations.Uint32 {
+			return errors.Errorf("%s %d exceeds maxRequestMinConfirmations %d", OptionMinConfirmations, *o.MinConfirmations, spec.MaxRequestMinConfirmations.Uint32)
+		}
+	}
+	if o.FromAddress != nil && !containsAddress(spec.RequestFromAddresses, *o.FromAddress) {
+		return errors.Errorf("%s %s is not one of the requestFromAddresses of the job", OptionFromAddress, o.FromAddress.Hex())
+	}
+	return nil
+}
+
+// minConfirmations returns the number of confirmations the request waits for.
+func (o RequestOptions) minConfirmations(minIncomingConfirmations uint32) uint32 {
+	if o.MinConfirmations != nil && *o.MinConfirmations > minIncomingConfirmations {
+		return *o.MinConfirmations
+	}
+	return minIncomingConfirmations
+}
+
+// vars returns the options as pipeline variables, with the defaults of the
+// job for the options the request does not set.
+func (o RequestOptions) vars(spec job.DirectRequestSpec, minIncomingConfirmations uint32) map[string]interface{} {
+	var gasLimit interface{}
+	if o.GasLimit != nil {
+		gasLimit = *o.GasLimit
+	}
+	fromAddresses := []common.Address(spec.RequestFromAddresses)
+	if o.FromAddress != nil {
+		fromAddresses = []common.Address{*o.FromAddress}
+	}
+	if fromAddresses == nil {
+		fromAddresses = []common.Address{}
+	}
+	return map[string]interface{}{
+		"gasLimit":         gasLimit,
+		"minConfirmations": o.minConfirmations(minIncomingConfirmations),
+		"fromAddresses":    fromAddresses,
+	}
+}
+
+func optionUint32(v interface{}) (uint32, error) {
+	var n *big.Int
+	switch v := v.(type) {
+	case uint64:
+		n = new(big.Int).SetUint64(v)
+	case int64:
+		n = big.NewInt(v)
+	case *big.Int:
+		n = v
+	default:
+		return 0, errors.Errorf("expected an integer, got %T", v)
+	}
+	if n.Sign() < 0 || n.Cmp(big.NewInt(math.MaxUint32)) > 0 {
+		return 0, errors.Errorf("%s is out of range", n)
+	}
+	return uint32(n.Uint64()), nil
+}
+
+func optionAddress(v interface{}) (common.Address, error) {
+	switch v := v.(type) {
+	case string:
+		if !common.IsHexAddress(v) {
+			return common.Address{}, errors.Errorf("%q is not an address", v)
+		}
+		return common.HexToAddress(v), nil
+	case []byte:
+		if len(v) != common.AddressLength {
+			return common.Address{}, errors.Errorf("expected %d bytes, got %d", common.AddressLength, len(v))
+		}
+		return common.BytesToAddress(v), nil
+	default:
+		return common.Address{}, errors.Errorf("expected an address, got %T", v)
+	}
+}
+
+func containsAddress(addrs []common.Address, addr common.Address) bool {
+	for _, a := range addrs {
+		if a == addr {
+			return true
+		}
+	}
+	return false
+}
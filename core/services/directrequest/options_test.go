@@ -0,0 +1,134 @@
+package directrequest
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/null"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/store/models"
+)
+
+func mustCBOR(t *testing.T, v map[string]interface{}) []byte {
+	b, err := cbor.Marshal(v)
+	require.NoError(t, err)
+	return b
+}
+
+func TestParseRequestOptions(t *testing.T) {
+	t.Parallel()
+
+	from := common.HexToAddress("0x469aA2CD13e037DC5236320783dCfd0e641c0559")
+
+	t.Run("no options", func(t *testing.T) {
+		opts, err := ParseRequestOptions(mustCBOR(t, map[string]interface{}{"path": "USD"}))
+		require.NoError(t, err)
+		assert.Equal(t, RequestOptions{}, opts)
+	})
+
+	t.Run("not a CBOR map", func(t *testing.T) {
+		opts, err := ParseRequestOptions([]byte{0x01, 0x02})
+		require.NoError(t, err)
+		assert.Equal(t, RequestOptions{}, opts)
+	})
+
+	t.Run("all options", func(t *testing.T) {
+		opts, err := ParseRequestOptions(mustCBOR(t, map[string]interface{}{
+			"path":                 "USD",
+			OptionGasLimit:         500000,
+			OptionMinConfirmations: 10,
+			OptionFromAddress:      from.Hex(),
+		}))
+		require.NoError(t, err)
+		require.NotNil(t, opts.GasLimit)
+		assert.Equal(t, uint32(500000), *opts.GasLimit)
+		require.NotNil(t, opts.MinConfirmations)
+		assert.Equal(t, uint32(10), *opts.MinConfirmations)
+		require.NotNil(t, opts.FromAddress)
+		assert.Equal(t, from, *opts.FromAddress)
+	})
+
+	t.Run("address as bytes", func(t *testing.T) {
+		opts, err := ParseRequestOptions(mustCBOR(t, map[string]interface{}{OptionFromAddress: from.Bytes()}))
+		require.NoError(t, err)
+		assert.Equal(t, from, *opts.FromAddress)
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		_, err := ParseRequestOptions(mustCBOR(t, map[string]interface{}{OptionGasLimit: "lots"}))
+		assert.EqualError(t, err, "invalid _gasLimit: expected an integer, got string")
+		_, err = ParseRequestOptions(mustCBOR(t, map[string]interface{}{OptionMinConfirmations: -1}))
+		assert.EqualError(t, err, "invalid _minConfirmations: -1 is out of range")
+		_, err = ParseRequestOptions(mustCBOR(t, map[string]interface{}{OptionGasLimit: uint64(1) << 32}))
+		assert.EqualError(t, err, "invalid _gasLimit: 4294967296 is out of range")
+		_, err = ParseRequestOptions(mustCBOR(t, map[string]interface{}{OptionFromAddress: "0x1234"}))
+		assert.EqualError(t, err, `invalid _fromAddress: "0x1234" is not an address`)
+	})
+}
+
+func TestRequestOptions_Validate(t *testing.T) {
+	t.Parallel()
+
+	from := common.HexToAddress("0x469aA2CD13e037DC5236320783dCfd0e641c0559")
+	other := common.HexToAddress("0x2be990eE17832b59E0086534c5ea2459Aa75E38F")
+	u32 := func(v uint32) *uint32 { return &v }
+	caps := job.DirectRequestSpec{
+		MaxRequestGasLimit:         null.Uint32From(1000000),
+		MaxRequestMinConfirmations: null.Uint32From(20),
+		RequestFromAddresses:       models.AddressCollection{from},
+	}
+
+	tests := []struct {
+		name string
+		spec job.DirectRequestSpec
+		opts RequestOptions
+		err  string
+	}{
+		{"no options", job.DirectRequestSpec{}, RequestOptions{}, ""},
+		{"within caps", caps, RequestOptions{GasLimit: u32(1000000), MinConfirmations: u32(20), FromAddress: &from}, ""},
+		{"fewer confirmations than the job", job.DirectRequestSpec{}, RequestOptions{MinConfirmations: u32(3)}, ""},
+		{"gas limit not allowed", job.DirectRequestSpec{}, RequestOptions{GasLimit: u32(1)},
+			"_gasLimit is set, but the job does not allow requests to set a gas limit"},
+		{"zero gas limit", caps, RequestOptions{GasLimit: u32(0)}, "_gasLimit must be positive"},
+		{"gas limit too high", caps, RequestOptions{GasLimit: u32(1000001)}, "_gasLimit 1000001 exceeds maxRequestGasLimit 1000000"},
+		{"confirmations not allowed", job.DirectRequestSpec{}, RequestOptions{MinConfirmations: u32(4)},
+			"_minConfirmations is set, but the job does not allow requests to wait for more than 3 confirmations"},
+		{"too many confirmations", caps, RequestOptions{MinConfirmations: u32(21)}, "_minConfirmations 21 exceeds maxRequestMinConfirmations 20"},
+		{"from address not allowed", caps, RequestOptions{FromAddress: &other},
+			"_fromAddress 0x2be990eE17832b59E0086534c5ea2459Aa75E38F is not one of the requestFromAddresses of the job"},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.opts.Validate(tc.spec, 3)
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestRequestOptions_Vars(t *testing.T) {
+	t.Parallel()
+
+	from := common.HexToAddress("0x469aA2CD13e037DC5236320783dCfd0e641c0559")
+	gasLimit, minConfs := uint32(500000), uint32(10)
+
+	assert.Equal(t, map[string]interface{}{
+		"gasLimit":         nil,
+		"minConfirmations": uint32(3),
+		"fromAddresses":    []common.Address{},
+	}, RequestOptions{}.vars(job.DirectRequestSpec{}, 3))
+
+	assert.Equal(t, map[string]interface{}{
+		"gasLimit":         gasLimit,
+		"minConfirmations": minConfs,
+		"fromAddresses":    []common.Address{from},
+	}, RequestOptions{GasLimit: &gasLimit, MinConfirmations: &minConfs, FromAddress: &from}.vars(job.DirectRequestSpec{}, 3))
+}
@@ -1,26 +1,42 @@
 package directrequest
 
 import (
+	"math/big"
+	"strings"
+
 	"github.com/pelletier/go-toml"
 	"github.com/pkg/errors"
 
 	"github.com/smartcontractkit/chainlink/v2/core/assets"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm"
 	"github.com/smartcontractkit/chainlink/v2/core/null"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/ethkey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/v2/core/store/models"
 	"github.com/smartcontractkit/chainlink/v2/core/utils"
 )
 
 type DirectRequestToml struct {
-	ContractAddress          ethkey.EIP55Address      `toml:"contractAddress"`
-	Requesters               models.AddressCollection `toml:"requesters"`
-	MinContractPayment       *assets.Link             `toml:"minContractPaymentLinkJuels"`
-	EVMChainID               *utils.Big               `toml:"evmChainID"`
-	MinIncomingConfirmations null.Uint32              `toml:"minIncomingConfirmations"`
+	ContractAddress            ethkey.EIP55Address      `toml:"contractAddress"`
+	Requesters                 models.AddressCollection `toml:"requesters"`
+	MinContractPayment         *assets.Link             `toml:"minContractPaymentLinkJuels"`
+	EVMChainID                 *utils.Big               `toml:"evmChainID"`
+	MinIncomingConfirmations   null.Uint32              `toml:"minIncomingConfirmations"`
+	MaxRequestGasLimit         null.Uint32              `toml:"maxRequestGasLimit"`
+	MaxRequestMinConfirmations null.Uint32              `toml:"maxRequestMinConfirmations"`
+	RequestFromAddresses       models.AddressCollection `toml:"requestFromAddresses"`
 }
 
-func ValidatedDirectRequestSpec(tomlString string) (job.Job, error) {
+// requestFromAddressesVar is the pipeline variable the ethtx tasks of a job
+// read the requestFromAddresses from.
+const requestFromAddressesVar = "jobRun.requestOptions.fromAddresses"
+
+// ValidatedDirectRequestSpec parses and validates a directrequest job spec.
+// The requestFromAddresses of the spec must be enabled keys on the chain of
+// the job, which defaults to the default chain of chainSet.
+func ValidatedDirectRequestSpec(chainSet evm.ChainSet, ethKs keystore.Eth, tomlString string) (job.Job, error) {
 	var jb = job.Job{}
 	tree, err := toml.Load(tomlString)
 	if err != nil {
@@ -36,15 +52,66 @@ func ValidatedDirectRequestSpec(tomlString string) (job.Job, error) {
 		return jb, err
 	}
 	jb.DirectRequestSpec = &job.DirectRequestSpec{
-		ContractAddress:          spec.ContractAddress,
-		Requesters:               spec.Requesters,
-		MinContractPayment:       spec.MinContractPayment,
-		EVMChainID:               spec.EVMChainID,
-		MinIncomingConfirmations: spec.MinIncomingConfirmations,
+		ContractAddress:            spec.ContractAddress,
+		Requesters:                 spec.Requesters,
+		MinContractPayment:         spec.MinContractPayment,
+		EVMChainID:                 spec.EVMChainID,
+		MinIncomingConfirmations:   spec.MinIncomingConfirmations,
+		MaxRequestGasLimit:         spec.MaxRequestGasLimit,
+		MaxRequestMinConfirmations: spec.MaxRequestMinConfirmations,
+		RequestFromAddresses:       spec.RequestFromAddresses,
 	}
 
 	if jb.Type != job.DirectRequest {
 		return jb, errors.Errorf("unsupported type %s", jb.Type)
 	}
+	if spec.MaxRequestGasLimit.Valid && spec.MaxRequestGasLimit.Uint32 == 0 {
+		return jb, errors.New(`"maxRequestGasLimit" must be positive`)
+	}
+	if spec.MaxRequestMinConfirmations.Valid && spec.MinIncomingConfirmations.Valid &&
+		spec.MaxRequestMinConfirmations.Uint32 < spec.MinIncomingConfirmations.Uint32 {
+		return jb, errors.New(`"maxRequestMinConfirmations" must not be lower than "minIncomingConfirmations"`)
+	}
+	if len(spec.RequestFromAddresses) > 0 {
+		if err = validateRequestFromAddresses(chainSet, ethKs, jb); err != nil {
+			return jb, err
+		}
+	}
 	return jb, nil
 }
+
+// validateRequestFromAddresses checks that the requestFromAddresses of a job
+// are enabled keys on its chain, and that its pipeline sends transactions from
+// them.
+func validateRequestFromAddresses(chainSet evm.ChainSet, ethKs keystore.Eth, jb job.Job) error {
+	if !usesRequestFromAddresses(jb.Pipeline) {
+		return errors.Errorf(`"requestFromAddresses" is set, but no ethtx task sends from $(%s)`, requestFromAddressesVar)
+	}
+	var chainID *big.Int
+	if jb.DirectRequestSpec.EVMChainID != nil {
+		chainID = jb.DirectRequestSpec.EVMChainID.ToInt()
+	} else {
+		chain, err := chainSet.Default()
+		if err != nil {
+			return errors.Wrap(err, "failed to get default chain")
+		}
+		chainID = chain.ID()
+	}
+	for _, addr := range jb.DirectRequestSpec.RequestFromAddresses {
+		if err := ethKs.CheckEnabled(addr, chainID); err != nil {
+			return errors.Wrapf(err, `"requestFromAddresses" key %s is not enabled on chain %s`, addr.Hex(), chainID)
+		}
+	}
+	return nil
+}
+
+// usesRequestFromAddresses returns true if an ethtx task of the pipeline sends
+// from the requestFromAddresses.
+func usesRequestFromAddresses(p pipeline.Pipeline) bool {
+	for _, task := range p.Tasks {
+		if tx, ok := task.(*pipeline.ETHTxTask); ok && strings.Contains(tx.From, requestFromAddressesVar) {
+			return true
+		}
+	}
+	return false
+}
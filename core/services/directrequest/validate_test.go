@@ -1,11 +1,17 @@
 package directrequest
 
 import (
+	"math/big"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	evmmocks "github.com/smartcontractkit/chainlink/v2/core/chains/evm/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/null"
+	ksmocks "github.com/smartcontractkit/chainlink/v2/core/services/keystore/mocks"
 )
 
 func TestValidatedDirectRequestSpec(t *testing.T) {
@@ -25,7 +31,7 @@ observationSource   = """
 """
 `
 
-	s, err := ValidatedDirectRequestSpec(toml)
+	s, err := ValidatedDirectRequestSpec(nil, nil, toml)
 	require.NoError(t, err)
 
 	assert.Equal(t, int32(0), s.ID)
@@ -49,7 +55,7 @@ func TestValidatedDirectRequestSpec_MinIncomingConfirmations(t *testing.T) {
 		name                = "example eth request event spec"
 		`
 
-		s, err := ValidatedDirectRequestSpec(toml)
+		s, err := ValidatedDirectRequestSpec(nil, nil, toml)
 		require.NoError(t, err)
 
 		assert.False(t, s.DirectRequestSpec.MinIncomingConfirmations.Valid)
@@ -65,10 +71,108 @@ func TestValidatedDirectRequestSpec_MinIncomingConfirmations(t *testing.T) {
 		minIncomingConfirmations = 100
 		`
 
-		s, err := ValidatedDirectRequestSpec(toml)
+		s, err := ValidatedDirectRequestSpec(nil, nil, toml)
 		require.NoError(t, err)
 
 		assert.True(t, s.DirectRequestSpec.MinIncomingConfirmations.Valid)
 		assert.Equal(t, uint32(100), s.DirectRequestSpec.MinIncomingConfirmations.Uint32)
 	})
 }
+
+func TestValidatedDirectRequestSpec_RequestOptions(t *testing.T) {
+	t.Parallel()
+
+	from := common.HexToAddress("0x469aA2CD13e037DC5236320783dCfd0e641c0559")
+
+	t.Run("caps set", func(t *testing.T) {
+		t.Parallel()
+
+		toml := `
+		type                = "directrequest"
+		schemaVersion       = 1
+		evmChainID          = 4
+		minIncomingConfirmations = 3
+		maxRequestGasLimit = 1000000
+		maxRequestMinConfirmations = 20
+		requestFromAddresses = ["0x469aA2CD13e037DC5236320783dCfd0e641c0559"]
+		observationSource   = """
+		    submit [type=ethtx to="0x613a38AC1659769640aaE063C651F48E0250454C" data="0x" from="$(jobRun.requestOptions.fromAddresses)"];
+		"""
+		`
+
+		ethKs := ksmocks.NewEth(t)
+		ethKs.On("CheckEnabled", from, big.NewInt(4)).Return(nil).Once()
+		s, err := ValidatedDirectRequestSpec(nil, ethKs, toml)
+		require.NoError(t, err)
+
+		assert.Equal(t, null.Uint32From(1000000), s.DirectRequestSpec.MaxRequestGasLimit)
+		assert.Equal(t, null.Uint32From(20), s.DirectRequestSpec.MaxRequestMinConfirmations)
+		assert.Equal(t, []string{"0x469aA2CD13e037DC5236320783dCfd0e641c0559"}, s.DirectRequestSpec.RequestFromAddresses.ToStrings())
+	})
+
+	t.Run("zero maxRequestGasLimit", func(t *testing.T) {
+		t.Parallel()
+
+		toml := `
+		type                = "directrequest"
+		schemaVersion       = 1
+		maxRequestGasLimit = 0
+		`
+
+		_, err := ValidatedDirectRequestSpec(nil, nil, toml)
+		require.EqualError(t, err, `"maxRequestGasLimit" must be positive`)
+	})
+
+	t.Run("maxRequestMinConfirmations lower than minIncomingConfirmations", func(t *testing.T) {
+		t.Parallel()
+
+		toml := `
+		type                = "directrequest"
+		schemaVersion       = 1
+		minIncomingConfirmations = 10
+		maxRequestMinConfirmations = 5
+		`
+
+		_, err := ValidatedDirectRequestSpec(nil, nil, toml)
+		require.EqualError(t, err, `"maxRequestMinConfirmations" must not be lower than "minIncomingConfirmations"`)
+	})
+	t.Run("requestFromAddresses on the default chain", func(t *testing.T) {
+		t.Parallel()
+
+		toml := `
+		type                = "directrequest"
+		schemaVersion       = 1
+		requestFromAddresses = ["0x469aA2CD13e037DC5236320783dCfd0e641c0559"]
+		observationSource   = """
+		    submit [type=ethtx to="0x613a38AC1659769640aaE063C651F48E0250454C" data="0x" from="$(jobRun.requestOptions.fromAddresses)"];
+		"""
+		`
+
+		chain := evmmocks.NewChain(t)
+		chain.On("ID").Return(big.NewInt(5))
+		chainSet := evmmocks.NewChainSet(t)
+		chainSet.On("Default").Return(chain, nil).Once()
+		ethKs := ksmocks.NewEth(t)
+		ethKs.On("CheckEnabled", from, big.NewInt(5)).Return(assert.AnError).Once()
+		_, err := ValidatedDirectRequestSpec(chainSet, ethKs, toml)
+		require.ErrorIs(t, err, assert.AnError)
+		require.ErrorContains(t, err, `"requestFromAddresses" key 0x469aA2CD13e037DC5236320783dCfd0e641c0559 is not enabled on chain 5`)
+	})
+
+	t.Run("requestFromAddresses not used by the pipeline", func(t *testing.T) {
+		t.Parallel()
+
+		toml := `
+		type                = "directrequest"
+		schemaVersion       = 1
+		evmChainID          = 4
+		requestFromAddresses = ["0x469aA2CD13e037DC5236320783dCfd0e641c0559"]
+		observationSource   = """
+		    submit [type=ethtx to="0x613a38AC1659769640aaE063C651F48E0250454C" data="0x"];
+		"""
+		`
+
+		_, err := ValidatedDirectRequestSpec(nil, nil, toml)
+		require.EqualError(t, err, `"requestFromAddresses" is set, but no ethtx task sends from $(jobRun.requestOptions.fromAddresses)`)
+	})
+}
@@ -0,0 +1,108 @@
+package evmlog
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/sqlx"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+)
+
+var _ job.Delegate = (*Delegate)(nil)
+
+type Delegate struct {
+	db             *sqlx.DB
+	pipelineRunner pipeline.Runner
+	chains         evm.ChainSet
+	logger         logger.Logger
+}
+
+func NewDelegate(
+	db *sqlx.DB,
+	pipelineRunner pipeline.Runner,
+	chains evm.ChainSet,
+	logger logger.Logger,
+) *Delegate {
+	return &Delegate{
+		db:             db,
+		pipelineRunner: pipelineRunner,
+		chains:         chains,
+		logger:         logger.Named("EVMLog"),
+	}
+}
+
+// JobType satisfies the job.Delegate interface.
+func (d *Delegate) JobType() job.Type {
+	return job.EVMLog
+}
+
+func (d *Delegate) BeforeJobCreated(spec job.Job) {}
+func (d *Delegate) AfterJobCreated(spec job.Job)  {}
+func (d *Delegate) BeforeJobDeleted(spec job.Job) {}
+
+// OnDeleteJob satisfies the job.Delegate interface. It unregisters the
+// LogPoller filter of the job.
+func (d *Delegate) OnDeleteJob(jb job.Job, q pg.Queryer) error {
+	if jb.EVMLogSpec == nil {
+		return nil
+	}
+	chain, err := d.chains.Get(jb.EVMLogSpec.EVMChainID.ToInt())
+	if err != nil {
+		d.logger.Errorw("Failed to get chain of evmlog job", "err", err, "jobID", jb.ID)
+		return nil
+	}
+	return errors.Wrapf(chain.LogPoller().UnregisterFilter(FilterName(jb.ID), q), "failed to unregister filter %s", FilterName(jb.ID))
+}
+
+// ServicesForSpec satisfies the job.Delegate interface.
+func (d *Delegate) ServicesForSpec(jb job.Job) ([]job.ServiceCtx, error) {
+	if jb.EVMLogSpec == nil {
+		return nil, errors.Errorf("Delegate expects an EVMLogSpec to be present, got %+v", jb)
+	}
+
+	chain, err := d.chains.Get(jb.EVMLogSpec.EVMChainID.ToInt())
+	if err != nil {
+		return nil, fmt.Errorf(
+			"getting chain ID %d: %w", jb.EVMLogSpec.EVMChainID.ToInt(), err)
+	}
+	if !chain.Config().FeatureLogPoller() {
+		return nil, errors.New("log poller must be enabled to run evmlog jobs")
+	}
+
+	event, err := ParseEvent(jb.EVMLogSpec.EventABI)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := pipeline.Parse(jb.PipelineSpec.DotDagSource)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse pipeline")
+	}
+
+	lp := chain.LogPoller()
+	if err = lp.RegisterFilter(LogPollerFilter(jb.ID, *jb.EVMLogSpec, event)); err != nil {
+		return nil, errors.Wrap(err, "failed to register filter")
+	}
+
+	lggr := d.logger.With(
+		"jobID", jb.ID,
+		"externalJobID", jb.ExternalJobID,
+		"contractAddress", jb.EVMLogSpec.ContractAddress,
+		"event", event.Sig,
+	)
+	return []job.ServiceCtx{newListener(
+		jb,
+		event,
+		lp,
+		NewORM(d.db, lggr, chain.Config()),
+		d.pipelineRunner,
+		chain.Config().EvmLogPollInterval(),
+		p.RequiresPreInsert(),
+		lggr,
+	)}, nil
+}
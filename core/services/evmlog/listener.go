@@ -0,0 +1,298 @@
+package evmlog
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+var (
+	_ job.ServiceCtx = &listener{}
+
+	errLogProcessed = errors.New("log already processed")
+)
+
+// FilterName returns the name of the LogPoller filter of the evmlog job with
+// the given ID.
+func FilterName(jobID int32) string {
+	return logpoller.FilterName("EVMLog", jobID)
+}
+
+// LogPollerFilter returns the LogPoller filter of an evmlog job.
+func LogPollerFilter(jobID int32, spec job.EVMLogSpec, event abi.Event) logpoller.Filter {
+	return logpoller.Filter{
+		Name:      FilterName(jobID),
+		EventSigs: []common.Hash{event.ID},
+		Addresses: []common.Address{spec.ContractAddress.Address()},
+	}
+}
+
+// listener runs the pipeline of an evmlog job once for every confirmed log
+// matching the spec of the job.
+type listener struct {
+	utils.StartStopOnce
+	job            job.Job
+	event          abi.Event
+	lp             logpoller.LogPoller
+	orm            ORM
+	pipelineRunner pipeline.Runner
+	pollPeriod     time.Duration
+	logger         logger.Logger
+	// preInsert is true if the pipeline has async tasks, so that its runs
+	// are inserted before they execute.
+	preInsert bool
+
+	// fromBlock is the first block that is not fully processed yet, or -1
+	// before the first poll.
+	fromBlock int64
+
+	chStop utils.StopChan
+	wg     sync.WaitGroup
+}
+
+func newListener(
+	jb job.Job,
+	event abi.Event,
+	lp logpoller.LogPoller,
+	orm ORM,
+	pipelineRunner pipeline.Runner,
+	pollPeriod time.Duration,
+	preInsert bool,
+	lggr logger.Logger,
+) *listener {
+	return &listener{
+		job:            jb,
+		event:          event,
+		lp:             lp,
+		orm:            orm,
+		pipelineRunner: pipelineRunner,
+		pollPeriod:     pollPeriod,
+		preInsert:      preInsert,
+		logger:         lggr,
+		fromBlock:      -1,
+		chStop:         make(chan struct{}),
+	}
+}
+
+// Start implements the job.Service interface.
+func (l *listener) Start(context.Context) error {
+	return l.StartOnce("EVMLogListener", func() error {
+		l.wg.Add(1)
+		go l.run()
+		return nil
+	})
+}
+
+// Close implements the job.Service interface.
+func (l *listener) Close() error {
+	return l.StopOnce("EVMLogListener", func() error {
+		close(l.chStop)
+		l.wg.Wait()
+		return nil
+	})
+}
+
+func (l *listener) run() {
+	defer l.wg.Done()
+	ctx, cancel := l.chStop.NewCtx()
+	defer cancel()
+
+	ticker := time.NewTicker(utils.WithJitter(l.pollPeriod))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := l.poll(ctx); err != nil && ctx.Err() == nil {
+				l.logger.Errorw("Failed to process logs", "err", err, "fromBlock", l.fromBlock)
+			}
+		case <-l.chStop:
+			return
+		}
+	}
+}
+
+// poll runs the pipeline for the confirmed logs emitted since the last poll.
+func (l *listener) poll(ctx context.Context) error {
+	latest, err := l.lp.LatestBlock(pg.WithParentCtx(ctx))
+	if err != nil {
+		return errors.Wrap(err, "failed to get latest block")
+	}
+	toBlock := latest - int64(l.job.EVMLogSpec.MinConfirmations)
+
+	if l.fromBlock < 0 {
+		// Resume from the block the job stopped at. A new job starts from
+		// the current confirmed block, which is persisted right away so that
+		// the logs emitted while the node is down are processed after a
+		// restart.
+		fromBlock, err2 := l.orm.FromBlock(l.job.ID, pg.WithParentCtx(ctx))
+		if err2 != nil {
+			return err2
+		}
+		if fromBlock < 0 {
+			fromBlock = toBlock + 1
+			if err2 = l.orm.SetFromBlock(l.job.ID, fromBlock, pg.WithParentCtx(ctx)); err2 != nil {
+				return err2
+			}
+		}
+		l.fromBlock = fromBlock
+	}
+	if toBlock < l.fromBlock {
+		return nil
+	}
+
+	logs, err := l.lp.LogsWithSigs(l.fromBlock, toBlock, []common.Hash{l.event.ID}, l.job.EVMLogSpec.ContractAddress.Address(), pg.WithParentCtx(ctx))
+	if err != nil {
+		return errors.Wrapf(err, "failed to get logs of blocks %d to %d", l.fromBlock, toBlock)
+	}
+	for _, lg := range logs {
+		if !l.matches(lg.GetTopics()) {
+			continue
+		}
+		if err = l.processLog(ctx, lg); err != nil {
+			return err
+		}
+	}
+	if err = l.orm.SetFromBlock(l.job.ID, toBlock+1, pg.WithParentCtx(ctx)); err != nil {
+		return err
+	}
+	l.fromBlock = toBlock + 1
+	return nil
+}
+
+// matches returns true if the topics of a log match the topic filters of the
+// job.
+func (l *listener) matches(topics []common.Hash) bool {
+	for i, filter := range topicFilters(*l.job.EVMLogSpec) {
+		if len(filter) == 0 {
+			continue
+		}
+		if len(topics) <= i+1 || !containsHash(filter, topics[i+1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// processLog runs the pipeline for a log, unless it was processed before. The
+// log is marked processed in the transaction that inserts its run: before the
+// run executes if the run is pre-inserted, after it finished otherwise, in
+// which case a log whose run did not finish before a crash is run again after
+// a restart.
+func (l *listener) processLog(ctx context.Context, lg logpoller.Log) error {
+	lggr := l.logger.With("blockNumber", lg.BlockNumber, "txHash", lg.TxHash, "logIndex", lg.LogIndex)
+
+	event, err := decodeLog(l.event, lg)
+	if err != nil {
+		// A log that cannot be decoded will not be decoded on a later poll
+		// either, so it is skipped.
+		lggr.Errorw("Failed to decode log, skipping it", "err", err)
+		return nil
+	}
+
+	vars := pipeline.NewVarsFrom(map[string]interface{}{
+		"jobSpec": map[string]interface{}{
+			"databaseID":    l.job.ID,
+			"externalJobID": l.job.ExternalJobID,
+			"name":          l.job.Name.ValueOrZero(),
+			"evmChainID":    l.job.EVMLogSpec.EVMChainID.String(),
+		},
+		"jobRun": map[string]interface{}{
+			"logBlockHash":   lg.BlockHash,
+			"logBlockNumber": lg.BlockNumber,
+			"logTxHash":      lg.TxHash,
+			"logIndex":       lg.LogIndex,
+			"logAddress":     lg.Address,
+			"logTopics":      lg.GetTopics(),
+			"logData":        lg.Data,
+			"logEvent":       event,
+		},
+	})
+	if !l.preInsert {
+		return l.executeRun(ctx, lg, vars, lggr)
+	}
+
+	run := pipeline.NewRun(*l.job.PipelineSpec, vars)
+	_, err = l.pipelineRunner.Run(ctx, &run, lggr, true, func(tx pg.Queryer) error {
+		inserted, err2 := l.orm.MarkLogProcessed(l.job.ID, lg, pg.WithQueryer(tx))
+		if err2 != nil {
+			return err2
+		}
+		if !inserted {
+			return errLogProcessed
+		}
+		return nil
+	})
+	if errors.Is(err, errLogProcessed) {
+		return nil
+	} else if err != nil {
+		return errors.Wrapf(err, "failed to run pipeline for log %d of tx %s", lg.LogIndex, lg.TxHash)
+	}
+	lggr.Debugw("Processed log", "runID", run.ID)
+	return nil
+}
+
+// executeRun runs the pipeline for a log in memory, then inserts the finished
+// run in the transaction that marks the log processed.
+func (l *listener) executeRun(ctx context.Context, lg logpoller.Log, vars pipeline.Vars, lggr logger.Logger) error {
+	processed, err := l.orm.IsLogProcessed(l.job.ID, lg, pg.WithParentCtx(ctx))
+	if err != nil {
+		return err
+	}
+	if processed {
+		return nil
+	}
+
+	run, _, err := l.pipelineRunner.ExecuteRun(ctx, *l.job.PipelineSpec, vars, lggr)
+	if err != nil {
+		return errors.Wrapf(err, "failed to run pipeline for log %d of tx %s", lg.LogIndex, lg.TxHash)
+	}
+	inserted, err := l.orm.MarkLogProcessedWithRun(l.job.ID, lg, func(tx pg.Queryer) error {
+		return l.pipelineRunner.InsertFinishedRun(&run, true, pg.WithQueryer(tx))
+	}, pg.WithParentCtx(ctx))
+	if err != nil {
+		return errors.Wrapf(err, "failed to insert run for log %d of tx %s", lg.LogIndex, lg.TxHash)
+	}
+	if inserted {
+		lggr.Debugw("Processed log", "runID", run.ID)
+	}
+	return nil
+}
+
+// decodeLog returns the arguments of an event, keyed by name.
+func decodeLog(event abi.Event, lg logpoller.Log) (map[string]interface{}, error) {
+	out := make(map[string]interface{})
+	if len(lg.Data) > 0 {
+		if err := event.Inputs.NonIndexed().UnpackIntoMap(out, lg.Data); err != nil {
+			return nil, errors.Wrap(err, "failed to unpack data")
+		}
+	}
+	indexed := indexedInputs(event)
+	topics := lg.GetTopics()
+	if len(topics) != len(indexed)+1 {
+		return nil, errors.Errorf("expected %d topics, got %d", len(indexed)+1, len(topics))
+	}
+	if err := abi.ParseTopicsIntoMap(out, indexed, topics[1:]); err != nil {
+		return nil, errors.Wrap(err, "failed to parse topics")
+	}
+	return out, nil
+}
+
+func containsHash(hashes []common.Hash, h common.Hash) bool {
+	for _, hash := range hashes {
+		if hash == h {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,222 @@
+package evmlog
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
+	lpmocks "github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/evmlog/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/ethkey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+	pipelinemocks "github.com/smartcontractkit/chainlink/v2/core/services/pipeline/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+const transferABI = "Transfer(address indexed from, address indexed to, uint256 value)"
+
+var (
+	contractAddress = common.HexToAddress("0x1F72B4A5DCf7CC6d2E38423bF2f4BFA7db97d139")
+	fromAddress     = common.HexToAddress("0x469aA2CD13e037DC5236320783dCfd0e641c0559")
+	toAddress       = common.HexToAddress("0x2be990eE17832b59E0086534c5ea2459Aa75E38F")
+)
+
+func newTestListener(t *testing.T, spec job.EVMLogSpec, preInsert bool) (*listener, *lpmocks.LogPoller, *mocks.ORM, *pipelinemocks.Runner) {
+	event, err := ParseEvent(spec.EventABI)
+	require.NoError(t, err)
+	lp := lpmocks.NewLogPoller(t)
+	orm := mocks.NewORM(t)
+	runner := pipelinemocks.NewRunner(t)
+	jb := job.Job{
+		ID:            1,
+		ExternalJobID: uuid.New(),
+		Type:          job.EVMLog,
+		EVMLogSpec:    &spec,
+		PipelineSpec:  &pipeline.Spec{},
+	}
+	return newListener(jb, event, lp, orm, runner, 0, preInsert, logger.TestLogger(t)), lp, orm, runner
+}
+
+func transferLog(t *testing.T, blockNumber int64, logIndex int64, from, to common.Address, value int64) logpoller.Log {
+	event, err := ParseEvent(transferABI)
+	require.NoError(t, err)
+	data, err := event.Inputs.NonIndexed().Pack(big.NewInt(value))
+	require.NoError(t, err)
+	return logpoller.Log{
+		BlockNumber: blockNumber,
+		BlockHash:   common.BigToHash(big.NewInt(blockNumber)),
+		LogIndex:    logIndex,
+		TxHash:      utils.NewHash(),
+		Address:     contractAddress,
+		EventSig:    event.ID,
+		Topics: pq.ByteaArray{
+			event.ID.Bytes(),
+			common.BytesToHash(from.Bytes()).Bytes(),
+			common.BytesToHash(to.Bytes()).Bytes(),
+		},
+		Data: data,
+	}
+}
+
+func TestListener_Poll(t *testing.T) {
+	spec := job.EVMLogSpec{
+		ContractAddress:  ethkey.EIP55AddressFromAddress(contractAddress),
+		EventABI:         transferABI,
+		Topic1:           []common.Hash{common.BytesToHash(fromAddress.Bytes())},
+		MinConfirmations: 3,
+		EVMChainID:       utils.NewBigI(4),
+	}
+	l, lp, orm, runner := newTestListener(t, spec, true)
+	ctx := testutils.Context(t)
+	event, err := ParseEvent(transferABI)
+	require.NoError(t, err)
+
+	// The first poll of a new job starts at the current confirmed block, and
+	// persists it.
+	lp.On("LatestBlock", mock.Anything).Return(int64(100), nil).Once()
+	orm.On("FromBlock", int32(1), mock.Anything).Return(int64(-1), nil).Once()
+	orm.On("SetFromBlock", int32(1), int64(98), mock.Anything).Return(nil).Once()
+	require.NoError(t, l.poll(ctx))
+	assert.Equal(t, int64(98), l.fromBlock)
+
+	matching := transferLog(t, 99, 0, fromAddress, toAddress, 42)
+	otherSender := transferLog(t, 99, 1, toAddress, fromAddress, 43)
+	processed := transferLog(t, 100, 0, fromAddress, toAddress, 44)
+
+	lp.On("LatestBlock", mock.Anything).Return(int64(103), nil).Once()
+	lp.On("LogsWithSigs", int64(98), int64(100), []common.Hash{event.ID}, contractAddress, mock.Anything).
+		Return([]logpoller.Log{matching, otherSender, processed}, nil).Once()
+	orm.On("MarkLogProcessed", int32(1), matching, mock.Anything).Return(true, nil).Once()
+	orm.On("MarkLogProcessed", int32(1), processed, mock.Anything).Return(false, nil).Once()
+	orm.On("SetFromBlock", int32(1), int64(101), mock.Anything).Return(nil).Once()
+
+	// The runner calls fn in the transaction that starts the run, so the run
+	// is only recorded if the log was not processed before.
+	var runs []pipeline.Run
+	runner.On("Run", mock.Anything, mock.Anything, mock.Anything, true, mock.Anything).
+		Return(func(_ context.Context, run *pipeline.Run, _ logger.Logger, _ bool, fn func(pg.Queryer) error) (bool, error) {
+			if err := fn(nil); err != nil {
+				return false, err
+			}
+			runs = append(runs, *run)
+			return false, nil
+		}).Twice()
+
+	require.NoError(t, l.poll(ctx))
+	assert.Equal(t, int64(101), l.fromBlock)
+
+	require.Len(t, runs, 1)
+	vars := runs[0].Inputs.Val.(map[string]interface{})
+	jobRun := vars["jobRun"].(map[string]interface{})
+	assert.Equal(t, matching.TxHash, jobRun["logTxHash"])
+	assert.Equal(t, matching.Data, jobRun["logData"])
+	assert.Equal(t, map[string]interface{}{
+		"from":  fromAddress,
+		"to":    toAddress,
+		"value": big.NewInt(42),
+	}, jobRun["logEvent"])
+}
+
+func TestListener_PollExecutesRun(t *testing.T) {
+	spec := job.EVMLogSpec{
+		ContractAddress: ethkey.EIP55AddressFromAddress(contractAddress),
+		EventABI:        transferABI,
+		EVMChainID:      utils.NewBigI(4),
+	}
+	l, lp, orm, runner := newTestListener(t, spec, false)
+	l.fromBlock = 90
+
+	lg := transferLog(t, 95, 0, fromAddress, toAddress, 1)
+	processed := transferLog(t, 96, 0, fromAddress, toAddress, 2)
+	concurrent := transferLog(t, 97, 0, fromAddress, toAddress, 3)
+	lp.On("LatestBlock", mock.Anything).Return(int64(100), nil).Once()
+	lp.On("LogsWithSigs", int64(90), int64(100), mock.Anything, contractAddress, mock.Anything).
+		Return([]logpoller.Log{lg, processed, concurrent}, nil).Once()
+	orm.On("IsLogProcessed", int32(1), lg, mock.Anything).Return(false, nil).Once()
+	orm.On("IsLogProcessed", int32(1), processed, mock.Anything).Return(true, nil).Once()
+	orm.On("IsLogProcessed", int32(1), concurrent, mock.Anything).Return(false, nil).Once()
+
+	// Logs processed before are not run again. The finished run is inserted
+	// in the transaction that marks the log processed, unless the log was
+	// marked processed meanwhile.
+	runner.On("ExecuteRun", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(pipeline.Run{ID: 1}, nil, nil).Twice()
+	runner.On("InsertFinishedRun", &pipeline.Run{ID: 1}, true, mock.Anything).Return(nil).Once()
+	orm.On("MarkLogProcessedWithRun", int32(1), lg, mock.Anything, mock.Anything).
+		Return(func(_ int32, _ logpoller.Log, insertRun func(pg.Queryer) error, _ ...pg.QOpt) (bool, error) {
+			return true, insertRun(nil)
+		}).Once()
+	orm.On("MarkLogProcessedWithRun", int32(1), concurrent, mock.Anything, mock.Anything).Return(false, nil).Once()
+	orm.On("SetFromBlock", int32(1), int64(101), mock.Anything).Return(nil).Once()
+
+	require.NoError(t, l.poll(testutils.Context(t)))
+	assert.Equal(t, int64(101), l.fromBlock)
+}
+
+func TestListener_PollResumesFromPersistedBlock(t *testing.T) {
+	spec := job.EVMLogSpec{
+		ContractAddress: ethkey.EIP55AddressFromAddress(contractAddress),
+		EventABI:        transferABI,
+		EVMChainID:      utils.NewBigI(4),
+	}
+	l, lp, orm, _ := newTestListener(t, spec, false)
+	event, err := ParseEvent(transferABI)
+	require.NoError(t, err)
+
+	lp.On("LatestBlock", mock.Anything).Return(int64(100), nil).Once()
+	orm.On("FromBlock", int32(1), mock.Anything).Return(int64(90), nil).Once()
+	lp.On("LogsWithSigs", int64(90), int64(100), []common.Hash{event.ID}, contractAddress, mock.Anything).
+		Return(nil, nil).Once()
+	orm.On("SetFromBlock", int32(1), int64(101), mock.Anything).Return(nil).Once()
+	require.NoError(t, l.poll(testutils.Context(t)))
+	assert.Equal(t, int64(101), l.fromBlock)
+}
+
+func TestListener_PollDoesNotAdvanceOnError(t *testing.T) {
+	spec := job.EVMLogSpec{
+		ContractAddress: ethkey.EIP55AddressFromAddress(contractAddress),
+		EventABI:        transferABI,
+		EVMChainID:      utils.NewBigI(4),
+	}
+	l, lp, _, runner := newTestListener(t, spec, true)
+	l.fromBlock = 90
+
+	lp.On("LatestBlock", mock.Anything).Return(int64(100), nil).Once()
+	lp.On("LogsWithSigs", int64(90), int64(100), mock.Anything, contractAddress, mock.Anything).
+		Return([]logpoller.Log{transferLog(t, 95, 0, fromAddress, toAddress, 1)}, nil).Once()
+	runner.On("Run", mock.Anything, mock.Anything, mock.Anything, true, mock.Anything).
+		Return(false, assert.AnError).Once()
+
+	require.ErrorIs(t, l.poll(testutils.Context(t)), assert.AnError)
+	assert.Equal(t, int64(90), l.fromBlock)
+}
+
+func TestDecodeLog(t *testing.T) {
+	event, err := ParseEvent(transferABI)
+	require.NoError(t, err)
+
+	lg := transferLog(t, 1, 0, fromAddress, toAddress, 7)
+	decoded, err := decodeLog(event, lg)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"from":  fromAddress,
+		"to":    toAddress,
+		"value": big.NewInt(7),
+	}, decoded)
+
+	lg.Topics = lg.Topics[:2]
+	_, err = decodeLog(event, lg)
+	require.EqualError(t, err, "expected 3 topics, got 2")
+}
@@ -0,0 +1,96 @@
+package evmlog
+
+import (
+	"database/sql"
+
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/sqlx"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+)
+
+//go:generate mockery --quiet --name ORM --output ./mocks/ --case=underscore
+
+// ORM tracks the logs that evmlog jobs ran their pipeline for, and the block
+// each job reads logs from.
+type ORM interface {
+	// MarkLogProcessed records that the job processed the log. It returns
+	// false if the log was already processed by the job.
+	MarkLogProcessed(jobID int32, lg logpoller.Log, qopts ...pg.QOpt) (bool, error)
+	// MarkLogProcessedWithRun records that the job processed the log and
+	// calls insertRun in the same transaction. It returns false, without
+	// calling insertRun, if the log was already processed by the job.
+	MarkLogProcessedWithRun(jobID int32, lg logpoller.Log, insertRun func(tx pg.Queryer) error, qopts ...pg.QOpt) (bool, error)
+	// IsLogProcessed returns whether the job processed the log.
+	IsLogProcessed(jobID int32, lg logpoller.Log, qopts ...pg.QOpt) (bool, error)
+	// FromBlock returns the first block the job has not read all logs of,
+	// or -1 if it was never set.
+	FromBlock(jobID int32, qopts ...pg.QOpt) (int64, error)
+	// SetFromBlock sets the first block the job has not read all logs of.
+	SetFromBlock(jobID int32, block int64, qopts ...pg.QOpt) error
+}
+
+type orm struct {
+	q pg.Q
+}
+
+var _ ORM = &orm{}
+
+// NewORM returns an ORM backed by db.
+func NewORM(db *sqlx.DB, lggr logger.Logger, cfg pg.QConfig) ORM {
+	return &orm{q: pg.NewQ(db, lggr.Named("EVMLogORM"), cfg)}
+}
+
+func (o *orm) MarkLogProcessed(jobID int32, lg logpoller.Log, qopts ...pg.QOpt) (bool, error) {
+	q := o.q.WithOpts(qopts...)
+	res, err := q.Exec(`INSERT INTO evm_log_processed_logs (job_id, block_hash, log_index, block_number, tx_hash, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		ON CONFLICT DO NOTHING`, jobID, lg.BlockHash, lg.LogIndex, lg.BlockNumber, lg.TxHash)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to mark log processed")
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get RowsAffected")
+	}
+	return rowsAffected > 0, nil
+}
+
+func (o *orm) MarkLogProcessedWithRun(jobID int32, lg logpoller.Log, insertRun func(tx pg.Queryer) error, qopts ...pg.QOpt) (inserted bool, err error) {
+	q := o.q.WithOpts(qopts...)
+	err = q.Transaction(func(tx pg.Queryer) error {
+		inserted, err = o.MarkLogProcessed(jobID, lg, pg.WithQueryer(tx))
+		if err != nil || !inserted {
+			return err
+		}
+		return insertRun(tx)
+	})
+	return inserted && err == nil, err
+}
+
+func (o *orm) IsLogProcessed(jobID int32, lg logpoller.Log, qopts ...pg.QOpt) (bool, error) {
+	q := o.q.WithOpts(qopts...)
+	var processed bool
+	err := q.Get(&processed, `SELECT EXISTS (SELECT 1 FROM evm_log_processed_logs WHERE job_id = $1 AND block_hash = $2 AND log_index = $3)`,
+		jobID, lg.BlockHash, lg.LogIndex)
+	return processed, errors.Wrap(err, "failed to check if log is processed")
+}
+
+func (o *orm) FromBlock(jobID int32, qopts ...pg.QOpt) (int64, error) {
+	q := o.q.WithOpts(qopts...)
+	var block int64
+	err := q.Get(&block, `SELECT from_block FROM evm_log_from_blocks WHERE job_id = $1`, jobID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return -1, nil
+	}
+	return block, errors.Wrap(err, "failed to get from block")
+}
+
+func (o *orm) SetFromBlock(jobID int32, block int64, qopts ...pg.QOpt) error {
+	q := o.q.WithOpts(qopts...)
+	_, err := q.Exec(`INSERT INTO evm_log_from_blocks (job_id, from_block, updated_at) VALUES ($1, $2, NOW())
+		ON CONFLICT (job_id) DO UPDATE SET from_block = EXCLUDED.from_block, updated_at = EXCLUDED.updated_at`, jobID, block)
+	return errors.Wrap(err, "failed to set from block")
+}
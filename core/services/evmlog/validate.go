@@ -0,0 +1,98 @@
+package evmlog
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"github.com/pelletier/go-toml"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+)
+
+// ValidatedSpec validates and converts the given toml string to a job.Job.
+func ValidatedSpec(tomlString string) (job.Job, error) {
+	jb := job.Job{
+		// Default to generating a UUID, can be overwritten by the specified one in tomlString.
+		ExternalJobID: uuid.New(),
+	}
+
+	tree, err := toml.Load(tomlString)
+	if err != nil {
+		return jb, errors.Wrap(err, "loading toml")
+	}
+
+	err = tree.Unmarshal(&jb)
+	if err != nil {
+		return jb, errors.Wrap(err, "unmarshalling toml spec")
+	}
+
+	if jb.Type != job.EVMLog {
+		return jb, errors.Errorf("unsupported type %s", jb.Type)
+	}
+
+	var spec job.EVMLogSpec
+	err = tree.Unmarshal(&spec)
+	if err != nil {
+		return jb, errors.Wrap(err, "unmarshalling toml job")
+	}
+
+	// Required fields
+	if spec.ContractAddress == "" {
+		return jb, notSet("contractAddress")
+	}
+	if spec.EventABI == "" {
+		return jb, notSet("eventABI")
+	}
+	if spec.EVMChainID == nil {
+		return jb, notSet("evmChainID")
+	}
+
+	event, err := ParseEvent(spec.EventABI)
+	if err != nil {
+		return jb, err
+	}
+
+	indexed := len(indexedInputs(event))
+	for i, filter := range topicFilters(spec) {
+		if len(filter) > 0 && i >= indexed {
+			return jb, errors.Errorf(`"topic%d" is set, but event %s has only %d indexed arguments`, i+1, event.Sig, indexed)
+		}
+	}
+
+	jb.EVMLogSpec = &spec
+
+	return jb, nil
+}
+
+// ParseEvent parses the eventABI of an EVMLog spec.
+func ParseEvent(eventABI string) (abi.Event, error) {
+	event, err := pipeline.ParseETHABIEvent([]byte(eventABI))
+	if err != nil {
+		return event, errors.Wrap(err, `invalid "eventABI"`)
+	}
+	if len(indexedInputs(event)) > 3 {
+		return event, errors.Errorf(`invalid "eventABI": event %s has more than 3 indexed arguments`, event.Sig)
+	}
+	return event, nil
+}
+
+func indexedInputs(event abi.Event) abi.Arguments {
+	var indexed abi.Arguments
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	return indexed
+}
+
+// topicFilters returns the filters of the indexed topics 1 to 3 of a spec.
+func topicFilters(spec job.EVMLogSpec) [][]common.Hash {
+	return [][]common.Hash{spec.Topic1, spec.Topic2, spec.Topic3}
+}
+
+func notSet(field string) error {
+	return errors.Errorf("%q must be set", field)
+}
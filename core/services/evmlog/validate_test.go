@@ -0,0 +1,150 @@
+package evmlog
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/ethkey"
+	"github.com/smartcontractkit/chainlink/v2/core/testdata/testspecs"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+func TestValidate(t *testing.T) {
+	var tests = []struct {
+		name      string
+		toml      string
+		assertion func(t *testing.T, os job.Job, err error)
+	}{
+		{
+			name: "valid",
+			toml: `
+type = "evmlog"
+name = "valid-test"
+contractAddress = "0x1F72B4A5DCf7CC6d2E38423bF2f4BFA7db97d139"
+eventABI = "Transfer(address indexed from, address indexed to, uint256 value)"
+topic2 = ["0x000000000000000000000000469aa2cd13e037dc5236320783dcfd0e641c0559"]
+minConfirmations = 10
+evmChainID = "4"
+observationSource = """
+    decode [type=ethabidecodelog abi="Transfer(address indexed from, address indexed to, uint256 value)" data="$(jobRun.logData)" topics="$(jobRun.logTopics)"]
+"""
+`,
+			assertion: func(t *testing.T, os job.Job, err error) {
+				require.NoError(t, err)
+				require.Equal(t, job.EVMLog, os.Type)
+				require.Equal(t, "valid-test", os.Name.String)
+				require.Equal(t, ethkey.EIP55Address("0x1F72B4A5DCf7CC6d2E38423bF2f4BFA7db97d139"),
+					os.EVMLogSpec.ContractAddress)
+				require.Equal(t, "Transfer(address indexed from, address indexed to, uint256 value)",
+					os.EVMLogSpec.EventABI)
+				require.Empty(t, os.EVMLogSpec.Topic1)
+				require.Equal(t, []common.Hash{common.HexToHash("0x469aA2CD13e037DC5236320783dCfd0e641c0559")},
+					os.EVMLogSpec.Topic2)
+				require.Empty(t, os.EVMLogSpec.Topic3)
+				require.Equal(t, uint32(10), os.EVMLogSpec.MinConfirmations)
+				require.Equal(t, utils.NewBigI(4), os.EVMLogSpec.EVMChainID)
+				require.Len(t, os.Pipeline.Tasks, 1)
+			},
+		},
+		{
+			name: "generated",
+			toml: testspecs.GenerateEVMLogSpec(testspecs.EVMLogSpecParams{EVMChainID: 4}).Toml(),
+			assertion: func(t *testing.T, os job.Job, err error) {
+				require.NoError(t, err)
+				require.Equal(t, uint32(0), os.EVMLogSpec.MinConfirmations)
+			},
+		},
+		{
+			name: "invalid-job-type",
+			toml: `
+type = "blockhashstore"
+contractAddress = "0x1F72B4A5DCf7CC6d2E38423bF2f4BFA7db97d139"
+eventABI = "Transfer(address indexed from, address indexed to, uint256 value)"
+evmChainID = "4"
+`,
+			assertion: func(t *testing.T, os job.Job, err error) {
+				require.EqualError(t, err, "unsupported type blockhashstore")
+			},
+		},
+		{
+			name: "missing contract address",
+			toml: `
+type = "evmlog"
+eventABI = "Transfer(address indexed from, address indexed to, uint256 value)"
+evmChainID = "4"
+`,
+			assertion: func(t *testing.T, os job.Job, err error) {
+				require.EqualError(t, err, `"contractAddress" must be set`)
+			},
+		},
+		{
+			name: "missing event ABI",
+			toml: `
+type = "evmlog"
+contractAddress = "0x1F72B4A5DCf7CC6d2E38423bF2f4BFA7db97d139"
+evmChainID = "4"
+`,
+			assertion: func(t *testing.T, os job.Job, err error) {
+				require.EqualError(t, err, `"eventABI" must be set`)
+			},
+		},
+		{
+			name: "missing evmChainID",
+			toml: `
+type = "evmlog"
+contractAddress = "0x1F72B4A5DCf7CC6d2E38423bF2f4BFA7db97d139"
+eventABI = "Transfer(address indexed from, address indexed to, uint256 value)"
+`,
+			assertion: func(t *testing.T, os job.Job, err error) {
+				require.EqualError(t, err, `"evmChainID" must be set`)
+			},
+		},
+		{
+			name: "invalid event ABI",
+			toml: `
+type = "evmlog"
+contractAddress = "0x1F72B4A5DCf7CC6d2E38423bF2f4BFA7db97d139"
+eventABI = "Transfer(address indexed from, address indexed to, uint256 value"
+evmChainID = "4"
+`,
+			assertion: func(t *testing.T, os job.Job, err error) {
+				require.ErrorContains(t, err, `invalid "eventABI"`)
+			},
+		},
+		{
+			name: "too many indexed arguments",
+			toml: `
+type = "evmlog"
+contractAddress = "0x1F72B4A5DCf7CC6d2E38423bF2f4BFA7db97d139"
+eventABI = "Event(uint256 indexed a, uint256 indexed b, uint256 indexed c, uint256 indexed d)"
+evmChainID = "4"
+`,
+			assertion: func(t *testing.T, os job.Job, err error) {
+				require.EqualError(t, err, `invalid "eventABI": event Event(uint256,uint256,uint256,uint256) has more than 3 indexed arguments`)
+			},
+		},
+		{
+			name: "topic filter of non-indexed argument",
+			toml: `
+type = "evmlog"
+contractAddress = "0x1F72B4A5DCf7CC6d2E38423bF2f4BFA7db97d139"
+eventABI = "Transfer(address indexed from, address indexed to, uint256 value)"
+topic3 = ["0x000000000000000000000000000000000000000000000000000000000000002a"]
+evmChainID = "4"
+`,
+			assertion: func(t *testing.T, os job.Job, err error) {
+				require.EqualError(t, err, `"topic3" is set, but event Transfer(address,address,uint256) has only 2 indexed arguments`)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := ValidatedSpec(test.toml)
+			test.assertion(t, s, err)
+		})
+	}
+}
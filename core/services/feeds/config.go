@@ -15,6 +15,6 @@ type Config interface {
 	FeatureOffchainReporting2() bool
 	DefaultHTTPTimeout() models.Duration
 	JobPipelineResultWriteQueueDepth() uint64
-	JobPipelineMaxSuccessfulRuns() uint64
+	JobPipelineMaxSuccessfulRunsForJobType(jobType string) uint64
 	MercuryCredentials(credName string) *ocr2models.MercuryCredentials
 }
@@ -1,12 +1,14 @@
 package fluxmonitorv2
 
 import (
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"github.com/smartcontractkit/sqlx"
 
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/txmgr"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore"
 	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
@@ -21,6 +23,7 @@ type Delegate struct {
 	pipelineORM    pipeline.ORM
 	pipelineRunner pipeline.Runner
 	chainSet       evm.ChainSet
+	checker        services.Checker
 	lggr           logger.Logger
 }
 
@@ -34,6 +37,7 @@ func NewDelegate(
 	pipelineRunner pipeline.Runner,
 	db *sqlx.DB,
 	chainSet evm.ChainSet,
+	checker services.Checker,
 	lggr logger.Logger,
 ) *Delegate {
 	return &Delegate{
@@ -43,6 +47,7 @@ func NewDelegate(
 		pipelineORM,
 		pipelineRunner,
 		chainSet,
+		checker,
 		lggr.Named("FluxMonitor"),
 	}
 }
@@ -57,7 +62,8 @@ func (d *Delegate) AfterJobCreated(spec job.Job)                 {}
 func (d *Delegate) BeforeJobDeleted(spec job.Job)                {}
 func (d *Delegate) OnDeleteJob(spec job.Job, q pg.Queryer) error { return nil }
 
-// ServicesForSpec returns the flux monitor service for the job spec
+// ServicesForSpec returns a flux monitor service for each contract of the job
+// spec
 func (d *Delegate) ServicesForSpec(jb job.Job) (services []job.ServiceCtx, err error) {
 	if jb.FluxMonitorSpec == nil {
 		return nil, errors.Errorf("Delegate expects a *job.FluxMonitorSpec to be present, got %v", jb)
@@ -67,28 +73,53 @@ func (d *Delegate) ServicesForSpec(jb job.Job) (services []job.ServiceCtx, err e
 		return nil, err
 	}
 	cfg := chain.Config()
-	strategy := txmgr.NewQueueingTxStrategy(jb.ExternalJobID, cfg.FMDefaultTransactionQueueDepth(), cfg.DatabaseDefaultQueryTimeout())
 	var checker txmgr.EvmTransmitCheckerSpec
 	if chain.Config().FMSimulateTransactions() {
 		checker.CheckerType = txmgr.TransmitCheckerTypeSimulate
 	}
 
-	fm, err := NewFromJobSpec(
-		jb,
-		d.db,
-		NewORM(d.db, d.lggr, chain.Config(), chain.TxManager(), strategy, checker),
-		d.jobORM,
-		d.pipelineORM,
-		NewKeyStore(d.ethKeyStore),
-		chain.Client(),
-		chain.LogBroadcaster(),
-		d.pipelineRunner,
-		chain.Config(),
-		d.lggr,
-	)
-	if err != nil {
-		return nil, err
+	feeds := jb.FluxMonitorSpec.ContractFeeds()
+	monitors := make([]*FluxMonitor, 0, len(feeds))
+	for _, feed := range feeds {
+		// Each contract of a job watching several contracts queues its
+		// submissions separately, so that the submissions to one contract do
+		// not push those to another out of the queue.
+		subject := jb.ExternalJobID
+		if len(jb.FluxMonitorSpec.Feeds) > 0 {
+			subject = uuid.NewSHA1(jb.ExternalJobID, feed.ContractAddress.Bytes())
+		}
+		strategy := txmgr.NewQueueingTxStrategy(subject, cfg.FMDefaultTransactionQueueDepth(), cfg.DatabaseDefaultQueryTimeout())
+
+		fm, err := NewFromJobSpec(
+			jb,
+			feed,
+			d.db,
+			NewORM(d.db, d.lggr, chain.Config(), chain.TxManager(), strategy, checker),
+			d.jobORM,
+			d.pipelineORM,
+			NewKeyStore(d.ethKeyStore),
+			chain.Client(),
+			chain.LogBroadcaster(),
+			d.pipelineRunner,
+			chain.Config(),
+			d.lggr,
+		)
+		if err != nil {
+			return nil, err
+		}
+		fm.checker = d.checker
+		monitors = append(monitors, fm)
+		services = append(services, fm)
+	}
+
+	if len(jb.FluxMonitorSpec.Feeds) > 0 {
+		flags, err := NewFlags(cfg.FlagsContractAddress(), chain.Client())
+		if err != nil {
+			d.lggr.Errorw("Error creating Flags contract instance, check address", "address", cfg.FlagsContractAddress(), "err", err)
+		} else if flags.ContractExists() {
+			services = append(services, NewFlagsFanout(jb.ID, flags, chain.LogBroadcaster(), monitors, d.lggr))
+		}
 	}
 
-	return []job.ServiceCtx{fm}, nil
+	return services, nil
 }
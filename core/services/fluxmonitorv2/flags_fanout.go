@@ -0,0 +1,98 @@
+package fluxmonitorv2
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/log"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/flags_wrapper"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+// FlagsFanout subscribes to the Flags contract on behalf of the contracts of a
+// job watching several contracts, as the log broadcaster allows a single
+// subscription per job and contract. It notifies the contracts whose flag, or
+// the global flag, changed.
+type FlagsFanout struct {
+	jobID          int32
+	flags          Flags
+	logBroadcaster log.Broadcaster
+	monitors       map[common.Address]*FluxMonitor
+	logger         logger.Logger
+
+	unsubscribe func()
+	utils.StartStopOnce
+}
+
+// NewFlagsFanout constructs a FlagsFanout notifying the given flux monitors.
+func NewFlagsFanout(jobID int32, flags Flags, logBroadcaster log.Broadcaster, monitors []*FluxMonitor, lggr logger.Logger) *FlagsFanout {
+	byContract := make(map[common.Address]*FluxMonitor, len(monitors))
+	for _, fm := range monitors {
+		byContract[fm.contractAddress] = fm
+	}
+	return &FlagsFanout{
+		jobID:          jobID,
+		flags:          flags,
+		logBroadcaster: logBroadcaster,
+		monitors:       byContract,
+		logger:         lggr.Named("FlagsFanout"),
+	}
+}
+
+// Start implements the job.Service interface.
+func (f *FlagsFanout) Start(context.Context) error {
+	return f.StartOnce("FlagsFanout", func() error {
+		f.unsubscribe = f.logBroadcaster.Register(f, log.ListenerOpts{
+			Contract: f.flags.Address(),
+			ParseLog: f.flags.ParseLog,
+			LogsWithTopics: map[common.Hash][][]log.Topic{
+				flags_wrapper.FlagsFlagLowered{}.Topic(): nil,
+				flags_wrapper.FlagsFlagRaised{}.Topic():  nil,
+			},
+			MinIncomingConfirmations: 0,
+		})
+		return nil
+	})
+}
+
+// Close implements the job.Service interface.
+func (f *FlagsFanout) Close() error {
+	return f.StopOnce("FlagsFanout", func() error {
+		f.unsubscribe()
+		return nil
+	})
+}
+
+// JobID implements the listener.Listener interface.
+func (f *FlagsFanout) JobID() int32 { return f.jobID }
+
+// HandleLog notifies the contracts the flag change applies to. Flux monitors
+// read the flag of their contract when notified, so a log handled twice does
+// no harm.
+func (f *FlagsFanout) HandleLog(broadcast log.Broadcast) {
+	var subject common.Address
+	switch l := broadcast.DecodedLog().(type) {
+	case *flags_wrapper.FlagsFlagRaised:
+		subject = l.Subject
+	case *flags_wrapper.FlagsFlagLowered:
+		subject = l.Subject
+	default:
+		f.logger.Warnf("unexpected log type %T", l)
+		return
+	}
+
+	if subject == utils.ZeroAddress {
+		for _, fm := range f.monitors {
+			fm.FlagsChanged()
+		}
+	} else if fm, ok := f.monitors[subject]; ok {
+		fm.FlagsChanged()
+	}
+
+	if err := f.logBroadcaster.MarkConsumed(broadcast); err != nil {
+		f.logger.Errorw("Failed to mark log as consumed", "err", err, "logType", fmt.Sprintf("%T", broadcast.DecodedLog()), "log", broadcast.String())
+	}
+}
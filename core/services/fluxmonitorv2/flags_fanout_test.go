@@ -0,0 +1,78 @@
+package fluxmonitorv2
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+
+	logmocks "github.com/smartcontractkit/chainlink/v2/core/chains/evm/log/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/flags_wrapper"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+func TestFlagsFanout_HandleLog(t *testing.T) {
+	t.Parallel()
+
+	newMonitor := func() *FluxMonitor {
+		return &FluxMonitor{contractAddress: testutils.NewAddress(), chFlagsChanged: make(chan struct{}, 1)}
+	}
+	notified := func(fm *FluxMonitor) bool {
+		select {
+		case <-fm.chFlagsChanged:
+			return true
+		default:
+			return false
+		}
+	}
+
+	testCases := []struct {
+		name    string
+		log     func(a, b common.Address) interface{}
+		notifyA bool
+		notifyB bool
+	}{
+		{
+			name: "global flag raised",
+			log: func(a, b common.Address) interface{} {
+				return &flags_wrapper.FlagsFlagRaised{Subject: utils.ZeroAddress}
+			},
+			notifyA: true, notifyB: true,
+		},
+		{
+			name:    "flag of a contract lowered",
+			log:     func(a, b common.Address) interface{} { return &flags_wrapper.FlagsFlagLowered{Subject: b} },
+			notifyA: false, notifyB: true,
+		},
+		{
+			name: "flag of another contract raised",
+			log: func(a, b common.Address) interface{} {
+				return &flags_wrapper.FlagsFlagRaised{Subject: testutils.NewAddress()}
+			},
+			notifyA: false, notifyB: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			fmA, fmB := newMonitor(), newMonitor()
+			lb := logmocks.NewBroadcaster(t)
+			fanout := NewFlagsFanout(1, nil, lb, []*FluxMonitor{fmA, fmB}, logger.TestLogger(t))
+
+			broadcast := logmocks.NewBroadcast(t)
+			broadcast.On("DecodedLog").Return(tc.log(fmA.contractAddress, fmB.contractAddress))
+			broadcast.On("String").Maybe().Return("")
+			lb.On("MarkConsumed", broadcast).Return(nil).Once()
+
+			fanout.HandleLog(broadcast)
+
+			assert.Equal(t, tc.notifyA, notified(fmA))
+			assert.Equal(t, tc.notifyB, notified(fmB))
+		})
+	}
+}
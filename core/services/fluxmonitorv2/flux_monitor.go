@@ -7,6 +7,7 @@ import (
 	"math/big"
 	mrand "math/rand"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -22,6 +23,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/flux_aggregator_wrapper"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/recovery"
+	"github.com/smartcontractkit/chainlink/v2/core/services"
 	"github.com/smartcontractkit/chainlink/v2/core/services/fluxmonitorv2/promfm"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
 	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
@@ -75,10 +77,24 @@ type FluxMonitor struct {
 	logBroadcaster    log.Broadcaster
 	chainID           *big.Int
 
+	// feed is the feed of a job watching several contracts, whose params the
+	// pipeline observes the answer of this contract with.
+	feed job.FluxMonitorFeed
+	// multiContract is set for the contracts of a job watching several
+	// contracts. Only one listener per job may subscribe to the Flags
+	// contract, so they are notified of flag changes with FlagsChanged.
+	multiContract bool
+	// checker reports the health of the contract, if set.
+	checker services.Checker
+
 	logger logger.SugaredLogger
 
-	backlog       *utils.BoundedPriorityQueue[log.Broadcast]
-	chProcessLogs chan struct{}
+	backlog        *utils.BoundedPriorityQueue[log.Broadcast]
+	chProcessLogs  chan struct{}
+	chFlagsChanged chan struct{}
+
+	pollErrMu sync.RWMutex
+	pollErr   error
 
 	utils.StartStopOnce
 	chStop     chan struct{}
@@ -134,10 +150,11 @@ func NewFluxMonitor(
 			PriorityAnswerUpdatedLog: 1,
 			PriorityFlagChangedLog:   2,
 		}),
-		StartStopOnce: utils.StartStopOnce{},
-		chProcessLogs: make(chan struct{}, 1),
-		chStop:        make(chan struct{}),
-		waitOnStop:    make(chan struct{}),
+		StartStopOnce:  utils.StartStopOnce{},
+		chProcessLogs:  make(chan struct{}, 1),
+		chFlagsChanged: make(chan struct{}, 1),
+		chStop:         make(chan struct{}),
+		waitOnStop:     make(chan struct{}),
 	}
 
 	return fm, nil
@@ -145,8 +162,12 @@ func NewFluxMonitor(
 
 // NewFromJobSpec constructs an instance of FluxMonitor with sane defaults and
 // validation.
+//
+// Each contract of a job watching several contracts has its own FluxMonitor,
+// constructed with the feed of the contract.
 func NewFromJobSpec(
 	jobSpec job.Job,
+	feed job.FluxMonitorFeed,
 	db *sqlx.DB,
 	orm ORM,
 	jobORM job.ORM,
@@ -171,7 +192,7 @@ func NewFromJobSpec(
 
 	// Set up the flux aggregator
 	fluxAggregator, err := flux_aggregator_wrapper.NewFluxAggregator(
-		fmSpec.ContractAddress.Address(),
+		feed.ContractAddress.Address(),
 		ethClient,
 	)
 	if err != nil {
@@ -219,7 +240,7 @@ func NewFromJobSpec(
 
 	fmLogger := lggr.With(
 		"jobID", jobSpec.ID,
-		"contract", fmSpec.ContractAddress.Hex(),
+		"contract", feed.ContractAddress.Hex(),
 	)
 
 	pollManager, err := NewPollManager(
@@ -241,7 +262,7 @@ func NewFromJobSpec(
 		return nil, err
 	}
 
-	return NewFluxMonitor(
+	fm, err := NewFluxMonitor(
 		pipelineRunner,
 		jobSpec,
 		*jobSpec.PipelineSpec,
@@ -252,7 +273,7 @@ func NewFromJobSpec(
 		keyStore,
 		pollManager,
 		paymentChecker,
-		fmSpec.ContractAddress.Address(),
+		feed.ContractAddress.Address(),
 		contractSubmitter,
 		NewDeviationChecker(
 			float64(fmSpec.Threshold),
@@ -266,6 +287,12 @@ func NewFromJobSpec(
 		fmLogger,
 		chainId,
 	)
+	if err != nil {
+		return nil, err
+	}
+	fm.feed = feed
+	fm.multiContract = len(fmSpec.Feeds) > 0
+	return fm, nil
 }
 
 const (
@@ -280,12 +307,59 @@ func (fm *FluxMonitor) Start(context.Context) error {
 	return fm.StartOnce("FluxMonitor", func() error {
 		fm.logger.Debug("Starting Flux Monitor for job")
 
+		if fm.checker != nil {
+			if err := fm.checker.Register(fm.Name(), fm); err != nil {
+				return err
+			}
+		}
+
 		go fm.consume()
 
 		return nil
 	})
 }
 
+// Name returns the name the health of the contract is reported under.
+func (fm *FluxMonitor) Name() string {
+	return fmt.Sprintf("FluxMonitor.%d.%s", fm.spec.JobID, fm.contractAddress.Hex())
+}
+
+// HealthReport reports the contract as unhealthy while its last poll failed.
+func (fm *FluxMonitor) HealthReport() map[string]error {
+	err := fm.StartStopOnce.Healthy()
+	if err == nil {
+		fm.pollErrMu.RLock()
+		err = fm.pollErr
+		fm.pollErrMu.RUnlock()
+	}
+	return map[string]error{fm.Name(): err}
+}
+
+// recordError records an error of the job. The errors of a job watching several
+// contracts name the contract they occurred on.
+func (fm *FluxMonitor) recordError(description string) {
+	if fm.multiContract {
+		description = fmt.Sprintf("%s on contract %s", description, fm.contractAddress.Hex())
+	}
+	fm.jobORM.TryRecordError(fm.spec.JobID, description)
+}
+
+// setPollErr records the outcome of the last poll of the contract.
+func (fm *FluxMonitor) setPollErr(err error) {
+	fm.pollErrMu.Lock()
+	defer fm.pollErrMu.Unlock()
+	fm.pollErr = err
+}
+
+// FlagsChanged notifies the contract of a job watching several contracts that
+// a flag changed, so that it hibernates or awakens according to its flag.
+func (fm *FluxMonitor) FlagsChanged() {
+	select {
+	case fm.chFlagsChanged <- struct{}{}:
+	default:
+	}
+}
+
 func (fm *FluxMonitor) IsHibernating() bool {
 	if !fm.flags.ContractExists() {
 		return false
@@ -309,6 +383,9 @@ func (fm *FluxMonitor) Close() error {
 		close(fm.chStop)
 		<-fm.waitOnStop
 
+		if fm.checker != nil {
+			return fm.checker.Unregister(fm.Name())
+		}
 		return nil
 	})
 }
@@ -373,7 +450,7 @@ func (fm *FluxMonitor) consume() {
 	})
 	defer unsubscribe()
 
-	if fm.flags.ContractExists() {
+	if fm.flags.ContractExists() && !fm.multiContract {
 		unsubscribe := fm.logBroadcaster.Register(fm, log.ListenerOpts{
 			Contract: fm.flags.Address(),
 			ParseLog: fm.flags.ParseLog,
@@ -401,6 +478,9 @@ func (fm *FluxMonitor) consume() {
 		case <-fm.chProcessLogs:
 			recovery.WrapRecover(fm.logger, fm.processLogs)
 
+		case <-fm.chFlagsChanged:
+			recovery.WrapRecover(fm.logger, fm.respondToFlagsChanged)
+
 		case at := <-fm.pollManager.PollTickerTicks():
 			tickLogger.Debugf("Poll ticker fired on %v", formatTime(at))
 			recovery.WrapRecover(fm.logger, func() {
@@ -555,6 +635,23 @@ func (fm *FluxMonitor) respondToFlagsRaisedLog() {
 	}
 }
 
+// respondToFlagsChanged hibernates or awakens the contract of a job watching
+// several contracts according to its flag.
+func (fm *FluxMonitor) respondToFlagsChanged() {
+	hibernating := fm.IsHibernating()
+	if hibernating == fm.pollManager.isHibernating.Load() {
+		return
+	}
+	if hibernating {
+		fm.logger.Debug("Flag raised, hibernating")
+		fm.pollManager.Hibernate()
+		return
+	}
+	fm.logger.Debug("Flag lowered, awakening")
+	fm.pollManager.Awaken(fm.initialRoundState())
+	fm.pollIfEligible(PollRequestTypeAwaken, NewZeroDeviationChecker(fm.logger), nil)
+}
+
 // The AnswerUpdated log tells us that round has successfully closed with a new
 // answer.  We update our view of the oracleRoundState in case this log was
 // generated by a chain reorg.
@@ -598,7 +695,7 @@ func (fm *FluxMonitor) respondToNewRoundLog(log flux_aggregator_wrapper.FluxAggr
 	}()
 
 	newRoundLogger.Debug("NewRound log")
-	promfm.SetBigInt(promfm.SeenRound.WithLabelValues(fmt.Sprintf("%d", fm.spec.JobID)), log.RoundId)
+	promfm.SetBigInt(promfm.SeenRound.WithLabelValues(fmt.Sprintf("%d", fm.spec.JobID), fm.contractAddress.Hex()), log.RoundId)
 
 	//
 	// NewRound answer submission logic:
@@ -724,27 +821,20 @@ func (fm *FluxMonitor) respondToNewRoundLog(log flux_aggregator_wrapper.FluxAggr
 		}
 	}
 
-	vars := pipeline.NewVarsFrom(map[string]interface{}{
-		"jobSpec": map[string]interface{}{
-			"databaseID":    fm.jobSpec.ID,
-			"externalJobID": fm.jobSpec.ExternalJobID,
-			"name":          fm.jobSpec.Name.ValueOrZero(),
-		},
-		"jobRun": map[string]interface{}{
-			"meta": metaDataForBridge,
-		},
-	})
+	vars := fm.runVars(metaDataForBridge)
 
 	// Call the v2 pipeline to execute a new job run
 	run, results, err := fm.runner.ExecuteRun(context.Background(), fm.spec, vars, fm.logger)
 	if err != nil {
 		newRoundLogger.Errorw(fmt.Sprintf("error executing new run for job ID %v name %v", fm.spec.JobID, fm.spec.JobName), "err", err)
+		fm.setPollErr(errors.Wrap(err, "can't fetch answer"))
 		return
 	}
 	result, err := results.FinalResult(newRoundLogger).SingularResult()
 	if err != nil || result.Error != nil {
 		newRoundLogger.Errorw("can't fetch answer", "err", err, "result", result)
-		fm.jobORM.TryRecordError(fm.spec.JobID, "Error polling")
+		fm.recordError("Error polling")
+		fm.setPollErr(errors.New("can't fetch answer"))
 		return
 	}
 	answer, err := utils.ToDecimal(result.Value)
@@ -774,8 +864,10 @@ func (fm *FluxMonitor) respondToNewRoundLog(log flux_aggregator_wrapper.FluxAggr
 	markConsumed = false
 	if err != nil {
 		newRoundLogger.Errorf("unable to create job run: %v", err)
+		fm.setPollErr(errors.Wrap(err, "can't submit answer"))
 		return
 	}
+	fm.setPollErr(nil)
 }
 
 var (
@@ -843,10 +935,8 @@ func (fm *FluxMonitor) pollIfEligible(pollReq PollRequestType, deviationChecker
 	roundState, err := fm.roundState(0)
 	if err != nil {
 		l.Errorw("unable to determine eligibility to submit from FluxAggregator contract", "err", err)
-		fm.jobORM.TryRecordError(
-			fm.spec.JobID,
-			"Unable to call roundState method on provided contract. Check contract address.",
-		)
+		fm.recordError("Unable to call roundState method on provided contract. Check contract address.")
+		fm.setPollErr(errors.Wrap(err, "unable to call roundState"))
 
 		return
 	}
@@ -864,10 +954,8 @@ func (fm *FluxMonitor) pollIfEligible(pollReq PollRequestType, deviationChecker
 		roundStateNew, err2 := fm.roundState(roundState.RoundId)
 		if err2 != nil {
 			l.Errorw("unable to determine eligibility to submit from FluxAggregator contract", "err", err2)
-			fm.jobORM.TryRecordError(
-				fm.spec.JobID,
-				"Unable to call roundState method on provided contract. Check contract address.",
-			)
+			fm.recordError("Unable to call roundState method on provided contract. Check contract address.")
+			fm.setPollErr(errors.Wrap(err2, "unable to call roundState"))
 
 			return
 		}
@@ -927,27 +1015,20 @@ func (fm *FluxMonitor) pollIfEligible(pollReq PollRequestType, deviationChecker
 	// Note: we expect the FM pipeline to scale the fetched answer by the same
 	// amount as "decimals" in the FM contract.
 
-	vars := pipeline.NewVarsFrom(map[string]interface{}{
-		"jobSpec": map[string]interface{}{
-			"databaseID":    fm.jobSpec.ID,
-			"externalJobID": fm.jobSpec.ExternalJobID,
-			"name":          fm.jobSpec.Name.ValueOrZero(),
-		},
-		"jobRun": map[string]interface{}{
-			"meta": metaDataForBridge,
-		},
-	})
+	vars := fm.runVars(metaDataForBridge)
 
 	run, results, err := fm.runner.ExecuteRun(context.Background(), fm.spec, vars, fm.logger)
 	if err != nil {
 		l.Errorw("can't fetch answer", "err", err)
-		fm.jobORM.TryRecordError(fm.spec.JobID, "Error polling")
+		fm.recordError("Error polling")
+		fm.setPollErr(errors.Wrap(err, "can't fetch answer"))
 		return
 	}
 	result, err := results.FinalResult(l).SingularResult()
 	if err != nil || result.Error != nil {
 		l.Errorw("can't fetch answer", "err", err, "result", result)
-		fm.jobORM.TryRecordError(fm.spec.JobID, "Error polling")
+		fm.recordError("Error polling")
+		fm.setPollErr(errors.New("can't fetch answer"))
 		return
 	}
 	answer, err := utils.ToDecimal(result.Value)
@@ -961,8 +1042,9 @@ func (fm *FluxMonitor) pollIfEligible(pollReq PollRequestType, deviationChecker
 	}
 
 	jobID := fmt.Sprintf("%d", fm.spec.JobID)
+	contract := fm.contractAddress.Hex()
 	latestAnswer := decimal.NewFromBigInt(roundState.LatestSubmission, 0)
-	promfm.SetDecimal(promfm.SeenValue.WithLabelValues(jobID), answer)
+	promfm.SetDecimal(promfm.SeenValue.WithLabelValues(jobID, contract), answer)
 
 	l = l.With(
 		"latestAnswer", latestAnswer,
@@ -971,6 +1053,7 @@ func (fm *FluxMonitor) pollIfEligible(pollReq PollRequestType, deviationChecker
 
 	if roundState.RoundId > 1 && !deviationChecker.OutsideDeviation(latestAnswer, answer) {
 		l.Debugw("deviation < threshold, not submitting")
+		fm.setPollErr(nil)
 		return
 	}
 
@@ -1001,11 +1084,13 @@ func (fm *FluxMonitor) pollIfEligible(pollReq PollRequestType, deviationChecker
 	markConsumed = false
 	if err != nil {
 		l.Errorw("can't create job run", "err", err)
+		fm.setPollErr(errors.Wrap(err, "can't submit answer"))
 		return
 	}
+	fm.setPollErr(nil)
 
-	promfm.SetDecimal(promfm.ReportedValue.WithLabelValues(jobID), answer)
-	promfm.SetUint32(promfm.ReportedRound.WithLabelValues(jobID), roundState.RoundId)
+	promfm.SetDecimal(promfm.ReportedValue.WithLabelValues(jobID, contract), answer)
+	promfm.SetUint32(promfm.ReportedRound.WithLabelValues(jobID, contract), roundState.RoundId)
 }
 
 // If the answer is outside the allowable range, log an error and don't submit.
@@ -1020,7 +1105,8 @@ func (fm *FluxMonitor) isValidSubmission(l logger.Logger, answer decimal.Decimal
 		"max", fm.submissionChecker.Max,
 		"answer", answer,
 	)
-	fm.jobORM.TryRecordError(fm.spec.JobID, "Answer is outside acceptable range")
+	fm.recordError("Answer is outside acceptable range")
+	fm.setPollErr(errors.New("answer is outside acceptable range"))
 
 	jobId := fm.spec.JobID
 	jobName := fm.spec.JobName
@@ -1032,6 +1118,25 @@ func (fm *FluxMonitor) isValidSubmission(l logger.Logger, answer decimal.Decimal
 	return false
 }
 
+// runVars returns the variables of a pipeline run observing the answer of the
+// contract.
+func (fm *FluxMonitor) runVars(metaDataForBridge map[string]interface{}) pipeline.Vars {
+	return pipeline.NewVarsFrom(map[string]interface{}{
+		"jobSpec": map[string]interface{}{
+			"databaseID":    fm.jobSpec.ID,
+			"externalJobID": fm.jobSpec.ExternalJobID,
+			"name":          fm.jobSpec.Name.ValueOrZero(),
+		},
+		"jobRun": map[string]interface{}{
+			"meta": metaDataForBridge,
+		},
+		"feed": map[string]interface{}{
+			"contractAddress": fm.contractAddress.Hex(),
+			"params":          fm.feed.Params,
+		},
+	})
+}
+
 func (fm *FluxMonitor) roundState(roundID uint32) (flux_aggregator_wrapper.OracleRoundState, error) {
 	return fm.fluxAggregator.OracleRoundState(nil, fm.oracleAddress, roundID)
 }
@@ -442,6 +442,10 @@ func TestFluxMonitor_PollIfEligible(t *testing.T) {
 								"externalJobID": uuid.UUID{},
 								"name":          "",
 							},
+							"feed": map[string]interface{}{
+								"contractAddress": contractAddress.Hex(),
+								"params":          map[string]interface{}(nil),
+							},
 						},
 					), mock.Anything).
 					Return(pipeline.Run{}, pipeline.TaskRunResults{
@@ -518,6 +522,54 @@ func TestFluxMonitor_PollIfEligible_Creates_JobErr(t *testing.T) {
 	fm.ExportedPollIfEligible(1, 1)
 }
 
+// A contract of a job watching several contracts runs the pipeline with the
+// params of its feed, and names the contract in the errors it records.
+func TestFluxMonitor_PollIfEligible_Feed(t *testing.T) {
+	db, nodeAddr := setupStoreWithKey(t)
+	oracles := []common.Address{nodeAddr, testutils.NewAddress()}
+
+	fm, tm := setup(t, db)
+	fm.ExportedSetFeed(job.FluxMonitorFeed{
+		ContractAddress: ethkey.EIP55AddressFromAddress(contractAddress),
+		Params:          map[string]interface{}{"from": "ETH", "to": "USD"},
+	})
+
+	tm.keyStore.On("EnabledKeysForChain", testutils.FixtureChainID).Return([]ethkey.KeyV2{{Address: nodeAddr}}, nil).Once()
+	tm.logBroadcaster.On("IsConnected").Return(true).Once()
+	tm.fluxAggregator.On("GetOracles", nilOpts).Return(oracles, nil)
+	tm.fluxAggregator.On("OracleRoundState", nilOpts, nodeAddr, uint32(0)).Return(flux_aggregator_wrapper.OracleRoundState{
+		RoundId:          2,
+		EligibleToSubmit: true,
+		LatestSubmission: big.NewInt(100),
+		AvailableFunds:   big.NewInt(1).Mul(big.NewInt(10000), config.DefaultMinimumContractPayment.ToInt()),
+		PaymentAmount:    config.DefaultMinimumContractPayment.ToInt(),
+		OracleCount:      oracleCount,
+	}, nil).Once()
+	tm.orm.On("FindOrCreateFluxMonitorRoundStats", contractAddress, uint32(2), mock.Anything).
+		Return(fluxmonitorv2.FluxMonitorRoundStatsV2{Aggregator: contractAddress, RoundID: 2}, nil).Once()
+	tm.fluxAggregator.On("LatestRoundData", nilOpts).Return(flux_aggregator_wrapper.LatestRoundData{}, errors.New("no data")).Once()
+	tm.pipelineRunner.
+		On("ExecuteRun", mock.Anything, pipelineSpec, mock.MatchedBy(func(vars pipeline.Vars) bool {
+			from, err := vars.Get("feed.params.from")
+			require.NoError(t, err)
+			address, err := vars.Get("feed.contractAddress")
+			require.NoError(t, err)
+			return from == "ETH" && address == contractAddress.Hex()
+		}), mock.Anything).
+		Return(pipeline.Run{}, pipeline.TaskRunResults{
+			{
+				Result: pipeline.Result{Error: errors.New("bridge unreachable")},
+				Task:   &pipeline.HTTPTask{},
+			},
+		}, nil).Once()
+	tm.jobORM.On("TryRecordError", pipelineSpec.JobID, "Error polling on contract "+contractAddress.Hex()).Once()
+
+	require.NoError(t, fm.SetOracleAddress())
+	fm.ExportedPollIfEligible(1, 1)
+
+	assert.EqualError(t, fm.ExportedPollErr(), "can't fetch answer")
+}
+
 func TestPollingDeviationChecker_BuffersLogs(t *testing.T) {
 	db, nodeAddr := setupStoreWithKey(t)
 	oracles := []common.Address{nodeAddr, testutils.NewAddress()}
@@ -1879,6 +1931,10 @@ func TestFluxMonitor_DrumbeatTicker(t *testing.T) {
 						"externalJobID": uuid.UUID{},
 						"name":          "",
 					},
+					"feed": map[string]interface{}{
+						"contractAddress": contractAddress.Hex(),
+						"params":          map[string]interface{}(nil),
+					},
 				},
 			), mock.Anything).
 			Return(pipeline.Run{}, pipeline.TaskRunResults{
@@ -8,6 +8,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/log"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/flux_aggregator_wrapper"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
 	"github.com/smartcontractkit/chainlink/v2/core/utils"
 )
 
@@ -44,6 +45,19 @@ func (fm *FluxMonitor) ExportedRespondToFlagsRaisedLog() {
 	fm.rotateSelectLoop()
 }
 
+// ExportedSetFeed makes the flux monitor watch a contract of a job watching
+// several contracts.
+func (fm *FluxMonitor) ExportedSetFeed(feed job.FluxMonitorFeed) {
+	fm.feed = feed
+	fm.multiContract = true
+}
+
+func (fm *FluxMonitor) ExportedPollErr() error {
+	fm.pollErrMu.RLock()
+	defer fm.pollErrMu.RUnlock()
+	return fm.pollErr
+}
+
 func (fm *FluxMonitor) rotateSelectLoop() {
 	// the PollRequest is sent to 'rotate' the main select loop, so that new timers will be evaluated
 	fm.pollManager.chPoll <- PollRequest{Type: PollRequestTypeUnknown}
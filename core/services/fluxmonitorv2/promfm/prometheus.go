@@ -14,7 +14,7 @@ var (
 			Name: "flux_monitor_reported_value",
 			Help: "Flux monitor's last reported price",
 		},
-		[]string{"job_spec_id"},
+		[]string{"job_spec_id", "contract_address"},
 	)
 
 	SeenValue = promauto.NewGaugeVec(
@@ -22,7 +22,7 @@ var (
 			Name: "flux_monitor_seen_value",
 			Help: "Flux monitor's last observed value from target",
 		},
-		[]string{"job_spec_id"},
+		[]string{"job_spec_id", "contract_address"},
 	)
 
 	ReportedRound = promauto.NewGaugeVec(
@@ -30,7 +30,7 @@ var (
 			Name: "flux_monitor_reported_round",
 			Help: "Flux monitor's last reported round",
 		},
-		[]string{"job_spec_id"},
+		[]string{"job_spec_id", "contract_address"},
 	)
 
 	SeenRound = promauto.NewGaugeVec(
@@ -38,7 +38,7 @@ var (
 			Name: "flux_monitor_seen_round",
 			Help: "Last seen round by other node operators",
 		},
-		[]string{"job_spec_id"},
+		[]string{"job_spec_id", "contract_address"},
 	)
 )
 
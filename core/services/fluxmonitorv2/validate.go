@@ -3,6 +3,7 @@ package fluxmonitorv2
 import (
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/google/uuid"
 
 	"github.com/pelletier/go-toml"
@@ -40,6 +41,10 @@ func ValidatedFluxMonitorSpec(config ValidationConfig, ts string) (job.Job, erro
 		return jb, errors.Errorf("unsupported type %s", jb.Type)
 	}
 
+	if err = validateFeeds(&spec); err != nil {
+		return jb, err
+	}
+
 	// Find the smallest of all the timeouts
 	// and ensure the polling period is greater than that.
 	minTaskTimeout, aTimeoutSet, err := jb.Pipeline.MinTimeout()
@@ -88,3 +93,26 @@ func validatePollTimer(disabled bool, minTimeout time.Duration, period time.Dura
 
 	return period >= minTimeout
 }
+
+// validateFeeds checks the feeds of a job watching several contracts, and sets
+// the contract address of the job to the address of its first feed.
+func validateFeeds(spec *job.FluxMonitorSpec) error {
+	if len(spec.Feeds) == 0 {
+		return nil
+	}
+	if spec.ContractAddress != "" {
+		return errors.New("contractAddress must not be set when feeds are set, the address of each contract goes in its feed")
+	}
+	seen := make(map[common.Address]struct{}, len(spec.Feeds))
+	for i, feed := range spec.Feeds {
+		if feed.ContractAddress == "" {
+			return errors.Errorf("feed %d: contractAddress is required", i)
+		}
+		if _, ok := seen[feed.ContractAddress.Address()]; ok {
+			return errors.Errorf("feed %d: contract %s is already watched by another feed", i, feed.ContractAddress)
+		}
+		seen[feed.ContractAddress.Address()] = struct{}{}
+	}
+	spec.ContractAddress = spec.Feeds[0].ContractAddress
+	return nil
+}
@@ -208,6 +208,82 @@ externalJobID = "cfa3fa6b-2850-446b-b973-8f4c3b29d519"
 				require.NoError(t, err)
 			},
 		},
+		{
+			name: "feeds",
+			toml: `
+type = "fluxmonitor"
+schemaVersion = 1
+name = "multi contract flux monitor spec"
+threshold = 0.5
+idleTimerDisabled = true
+pollTimerPeriod = "1m"
+observationSource = """
+ds1 [type=bridge name="bridge-coinmarketcap" requestData="{\\"data\\":{\\"from\\":$(feed.params.from),\\"to\\":$(feed.params.to)}}"];
+ds1_parse [type=jsonparse path="data,result"];
+ds1 -> ds1_parse;
+"""
+
+[[feeds]]
+contractAddress = "0x3cCad4715152693fE3BC4460591e3D3Fbd071b42"
+params = { from = "ETH", to = "USD" }
+
+[[feeds]]
+contractAddress = "0x3e4a23dB81D1F1268983f0CE78F1a9dC329A5b36"
+params = { from = "ADA", to = "USD" }
+`,
+			assertion: func(t *testing.T, j job.Job, err error) {
+				require.NoError(t, err)
+				spec := j.FluxMonitorSpec
+				require.Len(t, spec.Feeds, 2)
+				assert.Equal(t, "0x3cCad4715152693fE3BC4460591e3D3Fbd071b42", spec.ContractAddress.String())
+				assert.Equal(t, spec.Feeds, spec.ContractFeeds())
+				assert.Equal(t, "0x3e4a23dB81D1F1268983f0CE78F1a9dC329A5b36", spec.Feeds[1].ContractAddress.String())
+				assert.Equal(t, map[string]interface{}{"from": "ADA", "to": "USD"}, spec.Feeds[1].Params)
+				assert.NotZero(t, j.Pipeline)
+			},
+		},
+		{
+			name: "feeds and contractAddress both set",
+			toml: `
+type = "fluxmonitor"
+schemaVersion = 1
+contractAddress = "0x3cCad4715152693fE3BC4460591e3D3Fbd071b42"
+threshold = 0.5
+idleTimerDisabled = true
+pollTimerPeriod = "1m"
+observationSource = """
+ds1 [type=http method=GET url="https://pricesource1.com"];
+"""
+
+[[feeds]]
+contractAddress = "0x3e4a23dB81D1F1268983f0CE78F1a9dC329A5b36"
+`,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				assert.EqualError(t, err, "contractAddress must not be set when feeds are set, the address of each contract goes in its feed")
+			},
+		},
+		{
+			name: "feeds watching the same contract",
+			toml: `
+type = "fluxmonitor"
+schemaVersion = 1
+threshold = 0.5
+idleTimerDisabled = true
+pollTimerPeriod = "1m"
+observationSource = """
+ds1 [type=http method=GET url="https://pricesource1.com"];
+"""
+
+[[feeds]]
+contractAddress = "0x3e4a23dB81D1F1268983f0CE78F1a9dC329A5b36"
+
+[[feeds]]
+contractAddress = "0x3e4a23dB81D1F1268983f0CE78F1a9dC329A5b36"
+`,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				assert.EqualError(t, err, "feed 1: contract 0x3e4a23dB81D1F1268983f0CE78F1a9dC329A5b36 is already watched by another feed")
+			},
+		},
 	}
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
@@ -3,7 +3,6 @@ package functions
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"reflect"
 	"sync"
@@ -20,8 +19,8 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
 	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/functions/config"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/threshold"
 	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
-	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/v2/core/services/synchronization/telem"
 	"github.com/smartcontractkit/chainlink/v2/core/utils"
 
@@ -102,27 +101,16 @@ var (
 	}, []string{"oracle"})
 )
 
-const (
-	ParseResultTaskName  string = "parse_result"
-	ParseErrorTaskName   string = "parse_error"
-	ParseDomainsTaskName string = "parse_domains"
-	// TODO: Remove/reduce dependency on pipeline tasks (https://smartcontract-it.atlassian.net/browse/FUN-135)
-	PipelineObservationSource string = `
-		run_computation [type="bridge" name="ea_bridge" requestData="{\"requestId\": $(jobRun.meta.requestId), \"jobName\": $(jobSpec.name), \"subscriptionOwner\": $(jobRun.meta.subscriptionOwner), \"subscriptionId\": $(jobRun.meta.subscriptionId), \"data\": $(jobRun.meta.requestData)}"]
-		parse_result    [type=jsonparse data="$(run_computation)" path="data,result"]
-		parse_error     [type=jsonparse data="$(run_computation)" path="data,error"]
-		parse_domains   [type=jsonparse data="$(run_computation)" path="data,domains" lax=true]
-		run_computation -> parse_result -> parse_error -> parse_domains
-	`
-)
+const secretsPruneInterval = time.Minute
 
 type FunctionsListener struct {
 	utils.StartStopOnce
 	oracle            *ocr2dr_oracle.OCR2DROracle
 	oracleHexAddr     string
 	job               job.Job
-	pipelineRunner    pipeline.Runner
-	jobORM            job.ORM
+	sandbox           Sandbox
+	secretsORM        SecretsORM
+	decryptor         threshold.Decryptor
 	logBroadcaster    log.Broadcaster
 	shutdownWaitGroup sync.WaitGroup
 	mbOracleEvents    *utils.Mailbox[log.Broadcast]
@@ -140,13 +128,14 @@ func formatRequestId(requestId [32]byte) string {
 	return fmt.Sprintf("0x%x", requestId)
 }
 
-func NewFunctionsListener(oracle *ocr2dr_oracle.OCR2DROracle, job job.Job, runner pipeline.Runner, jobORM job.ORM, pluginORM ORM, pluginConfig config.PluginConfig, logBroadcaster log.Broadcaster, lggr logger.Logger, mailMon *utils.MailboxMonitor, urlsMonEndpoint commontypes.MonitoringEndpoint) *FunctionsListener {
+func NewFunctionsListener(oracle *ocr2dr_oracle.OCR2DROracle, job job.Job, sandbox Sandbox, pluginORM ORM, secretsORM SecretsORM, decryptor threshold.Decryptor, pluginConfig config.PluginConfig, logBroadcaster log.Broadcaster, lggr logger.Logger, mailMon *utils.MailboxMonitor, urlsMonEndpoint commontypes.MonitoringEndpoint) *FunctionsListener {
 	return &FunctionsListener{
 		oracle:          oracle,
 		oracleHexAddr:   oracle.Address().Hex(),
 		job:             job,
-		pipelineRunner:  runner,
-		jobORM:          jobORM,
+		sandbox:         sandbox,
+		secretsORM:      secretsORM,
+		decryptor:       decryptor,
 		logBroadcaster:  logBroadcaster,
 		mbOracleEvents:  utils.NewHighCapacityMailbox[log.Broadcast](),
 		chStop:          make(chan struct{}),
@@ -177,9 +166,10 @@ func (l *FunctionsListener) Start(context.Context) error {
 		if l.pluginConfig.ListenerEventHandlerTimeoutSec == 0 {
 			l.logger.Warn("listenerEventHandlerTimeoutSec set to zero! ORM calls will never time out.")
 		}
-		l.shutdownWaitGroup.Add(3)
+		l.shutdownWaitGroup.Add(4)
 		go l.processOracleEvents()
 		go l.timeoutRequests()
+		go l.pruneExpiredSecrets()
 		go func() {
 			<-l.chStop
 			unsubscribeLogs()
@@ -320,6 +310,23 @@ func (l *FunctionsListener) getNewHandlerContext() (context.Context, context.Can
 	return context.WithTimeout(l.serviceContext, time.Duration(timeoutSec)*time.Second)
 }
 
+// getExecutionContext bounds the execution of a request by ExecutionTimeoutSec.
+func (l *FunctionsListener) getExecutionContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeoutSec := l.pluginConfig.ExecutionTimeoutSec
+	if timeoutSec == 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, time.Duration(timeoutSec)*time.Second)
+}
+
+func (l *FunctionsListener) executionLimits() ExecutionLimits {
+	return ExecutionLimits{
+		Timeout:        time.Duration(l.pluginConfig.ExecutionTimeoutSec) * time.Second,
+		MaxMemoryBytes: l.pluginConfig.MaxExecutionMemoryBytes,
+		AllowedDomains: l.pluginConfig.AllowedDomains,
+	}
+}
+
 func (l *FunctionsListener) setError(ctx context.Context, requestId RequestID, runId int64, errType ErrType, errBytes []byte) {
 	if errType == INTERNAL_ERROR {
 		promRequestInternalError.WithLabelValues(l.oracleHexAddr).Inc()
@@ -367,93 +374,49 @@ func (l *FunctionsListener) handleOracleRequest(request *ocr2dr_oracle.OCR2DROra
 		return
 	}
 
-	vars := pipeline.NewVarsFrom(map[string]interface{}{
-		"jobSpec": map[string]interface{}{
-			"databaseID":    l.job.ID,
-			"externalJobID": l.job.ExternalJobID,
-			"name":          l.job.Name.ValueOrZero(),
-		},
-		"jobRun": map[string]interface{}{
-			"meta": map[string]interface{}{
-				"requestId":         formatRequestId(request.RequestId),
-				"subscriptionOwner": request.SubscriptionOwner,
-				"subscriptionId":    request.SubscriptionId,
-				"requestData":       requestData,
-			},
-		},
-	})
-
-	// TODO: Remove/reduce dependency on pipeline tasks (https://smartcontract-it.atlassian.net/browse/FUN-135)
-	spec := pipeline.Spec{
-		DotDagSource:      PipelineObservationSource,
-		ID:                l.job.PipelineSpec.ID,
-		JobID:             l.job.PipelineSpec.JobID,
-		JobName:           l.job.PipelineSpec.JobName,
-		JobType:           l.job.PipelineSpec.JobType,
-		CreatedAt:         l.job.CreatedAt,
-		MaxTaskDuration:   l.job.MaxTaskDuration,
-		ForwardingAllowed: l.job.ForwardingAllowed,
-	}
-
-	run := pipeline.NewRun(spec, vars)
-	_, err = l.pipelineRunner.Run(ctx, &run, l.logger, true, nil)
+	secrets, err := resolveSecrets(ctx, l.secretsORM, l.decryptor, request.RequestId, request.SubscriptionOwner, requestData)
 	if err != nil {
-		l.logger.Errorw("pipeline run failed", "requestID", formatRequestId(request.RequestId), "runID", run.ID, "err", err)
+		errType := ErrorType(err)
+		l.logger.Errorw("failed to resolve secrets", "requestID", formatRequestId(request.RequestId), "errType", errType, "err", err)
+		l.setError(ctx, request.RequestId, 0, errType, []byte(err.Error()))
 		return
 	}
-	l.logger.Infow("pipeline run finished", "requestID", formatRequestId(request.RequestId), "runID", run.ID)
 
-	computationResult, errResult := l.jobORM.FindTaskResultByRunIDAndTaskName(run.ID, ParseResultTaskName, pg.WithParentCtx(ctx))
-	if errResult != nil {
-		// Internal problem: Can't find computation results
-		l.logger.Errorw("internal error: can't retrieve computation results field", "requestID", formatRequestId(request.RequestId))
-		l.setError(ctx, request.RequestId, run.ID, INTERNAL_ERROR, []byte(errResult.Error()))
-		return
-	}
-	computationResult, errResult = ExtractRawBytes(computationResult)
-	if errResult != nil {
-		l.logger.Errorw("failed to extract result", "requestID", formatRequestId(request.RequestId), "err", errResult)
-		return
-	}
-
-	computationError, errErr := l.jobORM.FindTaskResultByRunIDAndTaskName(run.ID, ParseErrorTaskName, pg.WithParentCtx(ctx))
-	if errErr != nil {
-		// Internal problem: Can't find computation errors
-		l.logger.Errorw("internal error: can't retrieve computation error field", "requestID", formatRequestId(request.RequestId))
-		l.setError(ctx, request.RequestId, run.ID, INTERNAL_ERROR, []byte(errErr.Error()))
-		return
-	}
-	computationError, errErr = ExtractRawBytes(computationError)
-	if errErr != nil {
-		l.logger.Errorw("failed to extract error", "requestID", formatRequestId(request.RequestId), "err", errErr)
-		return
+	execCtx, cancelExec := l.getExecutionContext(ctx)
+	defer cancelExec()
+	res, err := l.sandbox.Execute(execCtx, ExecutionRequest{
+		RequestID:         request.RequestId,
+		SubscriptionOwner: request.SubscriptionOwner,
+		SubscriptionID:    request.SubscriptionId,
+		Data:              requestData,
+		Secrets:           secrets,
+		Limits:            l.executionLimits(),
+	})
+	if len(res.Domains) > 0 {
+		l.reportSourceCodeDomains(request.RequestId, res.Domains)
 	}
-
-	reportedDomainsJson, errDomains := l.jobORM.FindTaskResultByRunIDAndTaskName(run.ID, ParseDomainsTaskName, pg.WithParentCtx(ctx))
-	if errDomains != nil {
-		l.logger.Errorw("failed to extract domains", "requestID", formatRequestId(request.RequestId), "err", errDomains)
-	} else if len(reportedDomainsJson) > 0 {
-		var reportedDomains []string
-		errJson := json.Unmarshal(reportedDomainsJson, &reportedDomains)
-		if errJson != nil {
-			l.logger.Warnw("failed to parse reported domains", "requestID", formatRequestId(request.RequestId), "err", errJson)
-		} else if len(reportedDomains) > 0 {
-			l.reportSourceCodeDomains(request.RequestId, reportedDomains)
+	if err != nil {
+		if ctx.Err() == nil && execCtx.Err() == context.DeadlineExceeded {
+			err = ErrExecutionTimeout
 		}
+		errType := ErrorType(err)
+		l.logger.Errorw("request execution failed", "requestID", formatRequestId(request.RequestId), "runID", res.RunID, "errType", errType, "err", err)
+		l.setError(ctx, request.RequestId, res.RunID, errType, []byte(err.Error()))
+		return
 	}
 
-	if len(computationError) != 0 {
-		if len(computationResult) != 0 {
+	if len(res.Error) != 0 {
+		if len(res.Result) != 0 {
 			l.logger.Warnw("both result and error are non-empty - using error", "requestID", formatRequestId(request.RequestId))
 		}
 		l.logger.Debugw("saving computation error", "requestID", formatRequestId(request.RequestId))
-		l.setError(ctx, request.RequestId, run.ID, USER_ERROR, computationError)
-		promComputationErrorSize.WithLabelValues(l.oracleHexAddr).Set(float64(len(computationError)))
+		l.setError(ctx, request.RequestId, res.RunID, USER_ERROR, res.Error)
+		promComputationErrorSize.WithLabelValues(l.oracleHexAddr).Set(float64(len(res.Error)))
 	} else {
 		promRequestComputationSuccess.WithLabelValues(l.oracleHexAddr).Inc()
-		promComputationResultSize.WithLabelValues(l.oracleHexAddr).Set(float64(len(computationResult)))
+		promComputationResultSize.WithLabelValues(l.oracleHexAddr).Set(float64(len(res.Result)))
 		l.logger.Debugw("saving computation result", "requestID", formatRequestId(request.RequestId))
-		if err2 := l.pluginORM.SetResult(request.RequestId, run.ID, computationResult, time.Now(), pg.WithParentCtx(ctx)); err2 != nil {
+		if err2 := l.pluginORM.SetResult(request.RequestId, res.RunID, res.Result, time.Now(), pg.WithParentCtx(ctx)); err2 != nil {
 			l.logger.Errorw("call to SetResult failed", "requestID", formatRequestId(request.RequestId), "err", err2)
 		}
 	}
@@ -514,6 +477,30 @@ func (l *FunctionsListener) timeoutRequests() {
 	}
 }
 
+func (l *FunctionsListener) pruneExpiredSecrets() {
+	defer l.shutdownWaitGroup.Done()
+	if l.secretsORM == nil {
+		return
+	}
+	ticker := time.NewTicker(secretsPruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-l.chStop:
+			return
+		case <-ticker.C:
+			ctx, cancel := l.getNewHandlerContext()
+			deleted, err := l.secretsORM.DeleteExpiredSecrets(time.Now(), pg.WithParentCtx(ctx))
+			cancel()
+			if err != nil {
+				l.logger.Errorw("failed to delete expired secrets", "err", err)
+			} else if deleted > 0 {
+				l.logger.Debugw("deleted expired secrets", "count", deleted)
+			}
+		}
+	}
+}
+
 func (l *FunctionsListener) reportSourceCodeDomains(requestId RequestID, domains []string) {
 	r := &telem.FunctionsRequest{
 		RequestId:   formatRequestId(requestId),
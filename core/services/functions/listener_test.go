@@ -1,6 +1,7 @@
 package functions_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"sync"
@@ -8,6 +9,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/fxamacker/cbor/v2"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -28,6 +30,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
 	job_mocks "github.com/smartcontractkit/chainlink/v2/core/services/job/mocks"
 	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/functions/config"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/threshold"
 	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
 	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 	pipeline_mocks "github.com/smartcontractkit/chainlink/v2/core/services/pipeline/mocks"
@@ -46,11 +49,22 @@ type FunctionsListenerUniverse struct {
 	pluginORM      *functions_mocks.ORM
 	logBroadcaster *log_mocks.Broadcaster
 	ingressClient  *sync_mocks.TelemetryIngressClient
+	secretsORM     *functions_mocks.SecretsORM
+	// decryptionQueue is served by the tests in place of the threshold
+	// decryption plugin of the DON.
+	decryptionQueue threshold.DecryptionQueuingService
 }
 
 func ptr[T any](t T) *T { return &t }
 
 func NewFunctionsListenerUniverse(t *testing.T, timeoutSec int) *FunctionsListenerUniverse {
+	return NewFunctionsListenerUniverseWithSandbox(t, timeoutSec, nil, job.JSONConfig{}, false)
+}
+
+// NewFunctionsListenerUniverseWithSandbox executes requests with sandbox, or
+// with a PipelineSandbox if it is nil. DON-hosted secrets are supported if
+// withSecrets is set.
+func NewFunctionsListenerUniverseWithSandbox(t *testing.T, timeoutSec int, sandbox functions_service.Sandbox, extraConfig job.JSONConfig, withSecrets bool) *FunctionsListenerUniverse {
 	cfg := configtest.NewGeneralConfig(t, func(c *chainlink.Config, s *chainlink.Secrets) {
 		c.EVM[0].MinIncomingConfirmations = ptr[uint32](1)
 	})
@@ -74,6 +88,9 @@ func NewFunctionsListenerUniverse(t *testing.T, timeoutSec int) *FunctionsListen
 		"requestTimeoutBatchLookupSize":   1,
 		"listenerEventHandlerTimeoutSec":  1,
 	}
+	for k, v := range extraConfig {
+		jsonConfig[k] = v
+	}
 	jb := job.Job{
 		Type:          job.OffchainReporting2,
 		SchemaVersion: 1,
@@ -95,20 +112,45 @@ func NewFunctionsListenerUniverse(t *testing.T, timeoutSec int) *FunctionsListen
 	ingressAgent := telemetry.NewIngressAgentWrapper(ingressClient)
 	monEndpoint := ingressAgent.GenMonitoringEndpoint("0xa", synchronization.FunctionsRequests)
 
-	functionsListener := functions_service.NewFunctionsListener(oracleContract, jb, runner, jobORM, pluginORM, pluginConfig, broadcaster, lggr, mailMon, monEndpoint)
+	if sandbox == nil {
+		sandbox = functions_service.NewPipelineSandbox(jb, runner, jobORM, lggr)
+	}
+	var secretsORM *functions_mocks.SecretsORM
+	var decryptor threshold.Decryptor
+	var decryptionQueue threshold.DecryptionQueuingService
+	if withSecrets {
+		secretsORM = functions_mocks.NewSecretsORM(t)
+		dq := threshold.NewDecryptionQueue(10, 1024, time.Minute, lggr)
+		decryptor, decryptionQueue = dq, dq
+	}
+	functionsListener := functions_service.NewFunctionsListener(oracleContract, jb, sandbox, pluginORM, secretsORMOrNil(secretsORM), decryptor, pluginConfig, broadcaster, lggr, mailMon, monEndpoint)
 
 	return &FunctionsListenerUniverse{
-		runner:         runner,
-		service:        functionsListener,
-		jobORM:         jobORM,
-		pluginORM:      pluginORM,
-		logBroadcaster: broadcaster,
-		ingressClient:  ingressClient,
+		runner:          runner,
+		service:         functionsListener,
+		jobORM:          jobORM,
+		pluginORM:       pluginORM,
+		logBroadcaster:  broadcaster,
+		ingressClient:   ingressClient,
+		secretsORM:      secretsORM,
+		decryptionQueue: decryptionQueue,
+	}
+}
+
+// secretsORMOrNil avoids passing a typed nil as an interface.
+func secretsORMOrNil(orm *functions_mocks.SecretsORM) functions_service.SecretsORM {
+	if orm == nil {
+		return nil
 	}
+	return orm
 }
 
 func PrepareAndStartFunctionsListener(t *testing.T, cbor []byte, expectPipelineRun bool) (*FunctionsListenerUniverse, *log_mocks.Broadcast, cltest.Awaiter) {
-	uni := NewFunctionsListenerUniverse(t, 0)
+	return PrepareAndStartFunctionsListenerWithSandbox(t, cbor, expectPipelineRun, nil, job.JSONConfig{}, false)
+}
+
+func PrepareAndStartFunctionsListenerWithSandbox(t *testing.T, cbor []byte, expectPipelineRun bool, sandbox functions_service.Sandbox, extraConfig job.JSONConfig, withSecrets bool) (*FunctionsListenerUniverse, *log_mocks.Broadcast, cltest.Awaiter) {
+	uni := NewFunctionsListenerUniverseWithSandbox(t, 0, sandbox, extraConfig, withSecrets)
 	uni.logBroadcaster.On("Register", mock.Anything, mock.Anything).Return(func() {})
 
 	err := uni.service.Start(testutils.Context(t))
@@ -219,6 +261,28 @@ func TestFunctionsListener_HandleOracleRequestComputationError(t *testing.T) {
 	uni.service.Close()
 }
 
+func TestFunctionsListener_HandleOracleRequestPipelineRunError(t *testing.T) {
+	testutils.SkipShortDB(t)
+	t.Parallel()
+
+	uni, log, _ := PrepareAndStartFunctionsListener(t, []byte{}, false)
+
+	uni.pluginORM.On("CreateRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	uni.logBroadcaster.On("MarkConsumed", mock.Anything, mock.Anything).Return(nil)
+	uni.runner.On("Run", mock.Anything, mock.AnythingOfType("*pipeline.Run"), mock.Anything, mock.Anything, mock.Anything).
+		Return(false, errors.New("bridge unavailable")).Once()
+	// the request is failed instead of being left to time out
+	errorSet := cltest.NewAwaiter()
+	uni.pluginORM.On("SetError", RequestID, mock.Anything, functions_service.INTERNAL_ERROR, []byte("pipeline run failed: bridge unavailable"), mock.Anything, false, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		errorSet.ItHappened()
+	})
+
+	uni.service.HandleLog(log)
+
+	errorSet.AwaitOrFail(t, 5*time.Second)
+	uni.service.Close()
+}
+
 func TestFunctionsListener_HandleOracleRequestCBORParsingError(t *testing.T) {
 	testutils.SkipShortDB(t)
 	t.Parallel()
@@ -276,6 +340,141 @@ func TestFunctionsListener_ORMDoesNotFreezeHandlersForever(t *testing.T) {
 	uni.service.Close()
 }
 
+func TestFunctionsListener_LocalSandbox(t *testing.T) {
+	testutils.SkipShortDB(t)
+	t.Parallel()
+
+	requestData, err := cbor.Marshal(map[string]interface{}{"source": "0x1234"})
+	require.NoError(t, err)
+
+	t.Run("saves the result", func(t *testing.T) {
+		uni, log, _ := PrepareAndStartFunctionsListenerWithSandbox(t, requestData, false, functions_service.NewLocalSandbox(nil, nil), job.JSONConfig{}, false)
+
+		done := make(chan struct{})
+		uni.pluginORM.On("CreateRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		uni.logBroadcaster.On("MarkConsumed", mock.Anything, mock.Anything).Return(nil)
+		uni.pluginORM.On("SetResult", RequestID, int64(0), []byte{0x12, 0x34}, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			close(done)
+		})
+
+		uni.service.HandleLog(log)
+		<-done
+		uni.service.Close()
+	})
+
+	t.Run("times out", func(t *testing.T) {
+		blocking := func(ctx context.Context, _ *functions_service.LocalEnv, _ functions_service.ExecutionRequest) ([]byte, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		uni, log, _ := PrepareAndStartFunctionsListenerWithSandbox(t, requestData, false, functions_service.NewLocalSandbox(blocking, nil), job.JSONConfig{
+			"executionTimeoutSec":            1,
+			"listenerEventHandlerTimeoutSec": 10,
+		}, false)
+
+		done := make(chan struct{})
+		uni.pluginORM.On("CreateRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		uni.logBroadcaster.On("MarkConsumed", mock.Anything, mock.Anything).Return(nil)
+		uni.pluginORM.On("SetError", RequestID, int64(0), functions_service.USER_ERROR, []byte("execution timed out"), mock.Anything, true, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			close(done)
+		})
+
+		uni.service.HandleLog(log)
+		<-done
+		uni.service.Close()
+	})
+
+	t.Run("rejects domains which are not allowed", func(t *testing.T) {
+		fetching := func(_ context.Context, env *functions_service.LocalEnv, _ functions_service.ExecutionRequest) ([]byte, error) {
+			return env.Fetch("https://example.com/price")
+		}
+		uni, log, _ := PrepareAndStartFunctionsListenerWithSandbox(t, requestData, false, functions_service.NewLocalSandbox(fetching, nil), job.JSONConfig{
+			"allowedDomains": []string{"chain.link"},
+		}, false)
+
+		done := make(chan struct{})
+		uni.pluginORM.On("CreateRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		uni.logBroadcaster.On("MarkConsumed", mock.Anything, mock.Anything).Return(nil)
+		uni.pluginORM.On("SetError", RequestID, int64(0), functions_service.USER_ERROR, []byte("domain not allowed: example.com"), mock.Anything, true, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			close(done)
+		})
+
+		uni.service.HandleLog(log)
+		<-done
+		uni.service.Close()
+	})
+}
+
+func TestFunctionsListener_DONHostedSecrets(t *testing.T) {
+	testutils.SkipShortDB(t)
+	t.Parallel()
+
+	requestData, err := cbor.Marshal(donHostedRequestData(t, 1, 2))
+	require.NoError(t, err)
+	returnSecrets := func(_ context.Context, env *functions_service.LocalEnv, _ functions_service.ExecutionRequest) ([]byte, error) {
+		return env.Secrets(), nil
+	}
+
+	t.Run("decrypts the secrets", func(t *testing.T) {
+		uni, log, _ := PrepareAndStartFunctionsListenerWithSandbox(t, requestData, false, functions_service.NewLocalSandbox(returnSecrets, nil), job.JSONConfig{}, true)
+
+		done := make(chan struct{})
+		uni.pluginORM.On("CreateRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		uni.logBroadcaster.On("MarkConsumed", mock.Anything, mock.Anything).Return(nil)
+		uni.secretsORM.On("GetSecrets", common.Address{}, uint64(1), mock.Anything).Return(&functions_service.HostedSecrets{Version: 2, EncryptedSecrets: []byte{1}, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+		uni.pluginORM.On("SetResult", RequestID, int64(0), []byte("plaintext"), mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			close(done)
+		})
+
+		uni.service.HandleLog(log)
+
+		// Decrypt the ciphertext queued by the request, as the threshold
+		// decryption plugin would.
+		var ciphertext []byte
+		require.Eventually(t, func() bool {
+			var err error
+			ciphertext, err = uni.decryptionQueue.GetCiphertext(RequestID[:])
+			return err == nil
+		}, testutils.WaitTimeout(t), 10*time.Millisecond)
+		assert.Equal(t, []byte{1}, ciphertext)
+		uni.decryptionQueue.ReturnResult(RequestID[:], []byte("plaintext"))
+
+		<-done
+		uni.service.Close()
+	})
+
+	t.Run("rejects expired secrets", func(t *testing.T) {
+		uni, log, _ := PrepareAndStartFunctionsListenerWithSandbox(t, requestData, false, functions_service.NewLocalSandbox(returnSecrets, nil), job.JSONConfig{}, true)
+
+		done := make(chan struct{})
+		uni.pluginORM.On("CreateRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		uni.logBroadcaster.On("MarkConsumed", mock.Anything, mock.Anything).Return(nil)
+		uni.secretsORM.On("GetSecrets", common.Address{}, uint64(1), mock.Anything).Return(&functions_service.HostedSecrets{Version: 2, EncryptedSecrets: []byte{1}, ExpiresAt: time.Now().Add(-time.Hour)}, nil)
+		uni.pluginORM.On("SetError", RequestID, int64(0), functions_service.USER_ERROR, []byte("secrets in slot 1 expired"), mock.Anything, true, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			close(done)
+		})
+
+		uni.service.HandleLog(log)
+		<-done
+		uni.service.Close()
+	})
+
+	t.Run("rejects secrets without a secrets store", func(t *testing.T) {
+		uni, log, _ := PrepareAndStartFunctionsListenerWithSandbox(t, requestData, false, functions_service.NewLocalSandbox(returnSecrets, nil), job.JSONConfig{}, false)
+
+		done := make(chan struct{})
+		uni.pluginORM.On("CreateRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		uni.logBroadcaster.On("MarkConsumed", mock.Anything, mock.Anything).Return(nil)
+		uni.pluginORM.On("SetError", RequestID, int64(0), functions_service.USER_ERROR, []byte("DON-hosted secrets are not supported by this DON"), mock.Anything, true, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			close(done)
+		})
+
+		uni.service.HandleLog(log)
+		<-done
+		uni.service.Close()
+	})
+}
+
 func TestFunctionsListener_ExtractRawBytes(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,140 @@
+package functions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+var _ Sandbox = &LocalSandbox{}
+
+// LocalHandler computes the result of a request in process. A returned error
+// is the error of the user's code, unless it is an ExecutionError.
+type LocalHandler func(ctx context.Context, env *LocalEnv, req ExecutionRequest) ([]byte, error)
+
+// LocalSandbox executes requests in process, without any external service.
+// Given the same handler and responses, it computes the same result on every
+// node, so that the whole flow from the listener to an OCR report can be tested
+// locally.
+//
+// Handlers share the memory of the node, so the memory limit only applies to
+// the memory they account for with LocalEnv.Allocate, and to their results.
+// Handlers which ignore their context keep running after timing out.
+type LocalSandbox struct {
+	handler LocalHandler
+	// responses are served by LocalEnv.Fetch, by URL.
+	responses map[string][]byte
+}
+
+// NewLocalSandbox returns a LocalSandbox executing requests with handler, or
+// with EchoHandler if it is nil.
+func NewLocalSandbox(handler LocalHandler, responses map[string][]byte) *LocalSandbox {
+	if handler == nil {
+		handler = EchoHandler
+	}
+	return &LocalSandbox{handler: handler, responses: responses}
+}
+
+// LocalEnv is the environment of a LocalHandler, enforcing the limits of a
+// request.
+type LocalEnv struct {
+	limits    ExecutionLimits
+	secrets   []byte
+	responses map[string][]byte
+
+	mu      sync.Mutex
+	used    uint64
+	domains []string
+}
+
+// Fetch returns the response for rawURL, if it is on an allowed domain.
+func (e *LocalEnv) Fetch(rawURL string) ([]byte, error) {
+	if !e.limits.DomainAllowed(rawURL) {
+		return nil, NewUserError(fmt.Errorf("domain not allowed: %s", hostname(rawURL)))
+	}
+	e.mu.Lock()
+	e.domains = append(e.domains, hostname(rawURL))
+	e.mu.Unlock()
+	resp, ok := e.responses[rawURL]
+	if !ok {
+		return nil, fmt.Errorf("no response for %s", rawURL)
+	}
+	return resp, e.Allocate(uint64(len(resp)))
+}
+
+// Secrets returns the decrypted DON-hosted secrets of the request, if any.
+func (e *LocalEnv) Secrets() []byte {
+	return e.secrets
+}
+
+// Allocate accounts for n bytes of memory used by the handler.
+func (e *LocalEnv) Allocate(n uint64) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.used += n
+	if e.limits.MaxMemoryBytes > 0 && e.used > e.limits.MaxMemoryBytes {
+		return NewUserError(fmt.Errorf("memory limit of %d bytes exceeded", e.limits.MaxMemoryBytes))
+	}
+	return nil
+}
+
+func (s *LocalSandbox) Execute(ctx context.Context, req ExecutionRequest) (ExecutionResult, error) {
+	env := &LocalEnv{limits: req.Limits, secrets: req.Secrets, responses: s.responses}
+	type handled struct {
+		result []byte
+		err    error
+	}
+	chDone := make(chan handled, 1)
+	go func() {
+		result, err := s.handler(ctx, env, req)
+		chDone <- handled{result, err}
+	}()
+
+	var h handled
+	select {
+	case <-ctx.Done():
+		if ctx.Err() == context.DeadlineExceeded {
+			return ExecutionResult{}, ErrExecutionTimeout
+		}
+		return ExecutionResult{}, ctx.Err()
+	case h = <-chDone:
+	}
+
+	env.mu.Lock()
+	res := ExecutionResult{Domains: env.domains}
+	env.mu.Unlock()
+	if h.err != nil {
+		var execErr *ExecutionError
+		if errors.As(h.err, &execErr) {
+			return res, h.err
+		}
+		res.Error = []byte(h.err.Error())
+		return res, nil
+	}
+	if err := env.Allocate(uint64(len(h.result))); err != nil {
+		return res, err
+	}
+	res.Result = h.result
+	return res, nil
+}
+
+// EchoHandler returns the hex-decoded source of a request. Sources which are
+// not hex are an error of the user's code.
+func EchoHandler(_ context.Context, env *LocalEnv, req ExecutionRequest) ([]byte, error) {
+	source, ok := req.Data["source"].(string)
+	if !ok {
+		return nil, fmt.Errorf("source is not a string")
+	}
+	if !strings.HasPrefix(source, "0x") {
+		source = "0x" + source
+	}
+	result, err := utils.TryParseHex(source)
+	if err != nil {
+		return nil, fmt.Errorf("source is not hex: %w", err)
+	}
+	return result, env.Allocate(uint64(len(source)))
+}
@@ -0,0 +1,128 @@
+package functions
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+)
+
+const (
+	ParseResultTaskName  string = "parse_result"
+	ParseErrorTaskName   string = "parse_error"
+	ParseDomainsTaskName string = "parse_domains"
+	// TODO: Remove/reduce dependency on pipeline tasks (https://smartcontract-it.atlassian.net/browse/FUN-135)
+	PipelineObservationSource string = `
+		run_computation [type="bridge" name="ea_bridge" requestData="{\"requestId\": $(jobRun.meta.requestId), \"jobName\": $(jobSpec.name), \"subscriptionOwner\": $(jobRun.meta.subscriptionOwner), \"subscriptionId\": $(jobRun.meta.subscriptionId), \"data\": $(jobRun.meta.requestData), \"limits\": $(jobRun.meta.limits), \"secrets\": $(secrets)}"]
+		parse_result    [type=jsonparse data="$(run_computation)" path="data,result"]
+		parse_error     [type=jsonparse data="$(run_computation)" path="data,error"]
+		parse_domains   [type=jsonparse data="$(run_computation)" path="data,domains" lax=true]
+		run_computation -> parse_result -> parse_error -> parse_domains
+	`
+)
+
+var _ Sandbox = &PipelineSandbox{}
+
+// PipelineSandbox executes requests with a pipeline run calling the "ea_bridge"
+// external adapter. The limits are passed to the adapter to enforce, and the
+// domains it reports are checked against the allowed domains.
+type PipelineSandbox struct {
+	job            job.Job
+	pipelineRunner pipeline.Runner
+	jobORM         job.ORM
+	logger         logger.Logger
+}
+
+func NewPipelineSandbox(job job.Job, runner pipeline.Runner, jobORM job.ORM, lggr logger.Logger) *PipelineSandbox {
+	return &PipelineSandbox{
+		job:            job,
+		pipelineRunner: runner,
+		jobORM:         jobORM,
+		logger:         lggr,
+	}
+}
+
+func (s *PipelineSandbox) Execute(ctx context.Context, req ExecutionRequest) (ExecutionResult, error) {
+	vars := pipeline.NewVarsFrom(map[string]interface{}{
+		"jobSpec": map[string]interface{}{
+			"databaseID":    s.job.ID,
+			"externalJobID": s.job.ExternalJobID,
+			"name":          s.job.Name.ValueOrZero(),
+		},
+		"jobRun": map[string]interface{}{
+			"meta": map[string]interface{}{
+				"requestId":         formatRequestId(req.RequestID),
+				"subscriptionOwner": req.SubscriptionOwner,
+				"subscriptionId":    req.SubscriptionID,
+				"requestData":       req.Data,
+				"limits": map[string]interface{}{
+					"timeoutMs":      req.Limits.Timeout.Milliseconds(),
+					"maxMemoryBytes": req.Limits.MaxMemoryBytes,
+					"allowedDomains": req.Limits.AllowedDomains,
+				},
+			},
+		},
+	})
+
+	// TODO: Remove/reduce dependency on pipeline tasks (https://smartcontract-it.atlassian.net/browse/FUN-135)
+	spec := pipeline.Spec{
+		DotDagSource:      PipelineObservationSource,
+		ID:                s.job.PipelineSpec.ID,
+		JobID:             s.job.PipelineSpec.JobID,
+		JobName:           s.job.PipelineSpec.JobName,
+		JobType:           s.job.PipelineSpec.JobType,
+		CreatedAt:         s.job.CreatedAt,
+		MaxTaskDuration:   s.job.MaxTaskDuration,
+		ForwardingAllowed: s.job.ForwardingAllowed,
+	}
+
+	run := pipeline.NewRun(spec, vars)
+	// decrypted secrets are sent to the adapter in the request body, but they are
+	// not stored with the run and are redacted from the logged request data
+	var secrets interface{}
+	if req.Secrets != nil {
+		secrets = hexutil.Encode(req.Secrets)
+	}
+	run.SecretInputs = map[string]interface{}{"secrets": secrets}
+	_, err := s.pipelineRunner.Run(ctx, &run, s.logger, true, nil)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return ExecutionResult{}, ErrExecutionTimeout
+		}
+		return ExecutionResult{}, errors.Wrap(err, "pipeline run failed")
+	}
+	s.logger.Infow("pipeline run finished", "requestID", formatRequestId(req.RequestID), "runID", run.ID)
+	res := ExecutionResult{RunID: run.ID}
+
+	computationResult, err := s.jobORM.FindTaskResultByRunIDAndTaskName(run.ID, ParseResultTaskName, pg.WithParentCtx(ctx))
+	if err != nil {
+		return res, NewInternalError(errors.Wrap(err, "can't retrieve computation results field"))
+	}
+	if res.Result, err = ExtractRawBytes(computationResult); err != nil {
+		return res, errors.Wrap(err, "failed to extract result")
+	}
+
+	computationError, err := s.jobORM.FindTaskResultByRunIDAndTaskName(run.ID, ParseErrorTaskName, pg.WithParentCtx(ctx))
+	if err != nil {
+		return res, NewInternalError(errors.Wrap(err, "can't retrieve computation error field"))
+	}
+	if res.Error, err = ExtractRawBytes(computationError); err != nil {
+		return res, errors.Wrap(err, "failed to extract error")
+	}
+
+	reportedDomainsJson, err := s.jobORM.FindTaskResultByRunIDAndTaskName(run.ID, ParseDomainsTaskName, pg.WithParentCtx(ctx))
+	if err != nil {
+		s.logger.Errorw("failed to extract domains", "requestID", formatRequestId(req.RequestID), "err", err)
+	} else if len(reportedDomainsJson) > 0 {
+		if err = json.Unmarshal(reportedDomainsJson, &res.Domains); err != nil {
+			s.logger.Warnw("failed to parse reported domains", "requestID", formatRequestId(req.RequestID), "err", err)
+		}
+	}
+	return res, req.Limits.checkDomains(res.Domains)
+}
@@ -0,0 +1,142 @@
+package functions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// SandboxPipeline executes requests with the job pipeline, which calls an
+	// external adapter. It is the default.
+	SandboxPipeline = "pipeline"
+	// SandboxLocal executes requests in process with LocalSandbox. It is
+	// deterministic and only meant for tests and development.
+	SandboxLocal = "local"
+)
+
+var ErrExecutionTimeout = errors.New("execution timed out")
+
+// ExecutionLimits bound the resources available to the execution of a request.
+// Zero values mean no limit.
+type ExecutionLimits struct {
+	Timeout        time.Duration
+	MaxMemoryBytes uint64
+	// AllowedDomains are the domains a request may fetch from, including their
+	// subdomains.
+	AllowedDomains []string
+}
+
+// ExecutionRequest is an OracleRequest passed to a Sandbox.
+type ExecutionRequest struct {
+	RequestID         RequestID
+	SubscriptionOwner common.Address
+	SubscriptionID    uint64
+	// Data is the CBOR-decoded data of the request.
+	Data map[string]interface{}
+	// Secrets are the decrypted DON-hosted secrets of the request, if any.
+	Secrets []byte
+	Limits  ExecutionLimits
+}
+
+// ExecutionResult is the outcome of a request computed by a Sandbox. Either
+// Result or Error is set.
+type ExecutionResult struct {
+	// RunID is the ID of the pipeline run which computed the result, or zero
+	// if there was none.
+	RunID  int64
+	Result []byte
+	// Error is the error of the user's code.
+	Error []byte
+	// Domains are the domains the request fetched from.
+	Domains []string
+}
+
+// Sandbox executes the user code of Functions requests.
+//
+// Execute returns an error when a request could not be computed, which is an
+// ExecutionError for errors attributable to the request. Other errors are
+// internal errors.
+type Sandbox interface {
+	Execute(ctx context.Context, req ExecutionRequest) (ExecutionResult, error)
+}
+
+// ExecutionError is an error of a Sandbox with its ErrType.
+type ExecutionError struct {
+	Type ErrType
+	Err  error
+}
+
+func NewUserError(err error) *ExecutionError {
+	return &ExecutionError{Type: USER_ERROR, Err: err}
+}
+
+func NewInternalError(err error) *ExecutionError {
+	return &ExecutionError{Type: INTERNAL_ERROR, Err: err}
+}
+
+func (e *ExecutionError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExecutionError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorType returns the ErrType of an error returned by Sandbox.Execute.
+func ErrorType(err error) ErrType {
+	if err == nil {
+		return NONE
+	}
+	var execErr *ExecutionError
+	if errors.As(err, &execErr) {
+		return execErr.Type
+	}
+	if errors.Is(err, ErrExecutionTimeout) {
+		return USER_ERROR
+	}
+	return INTERNAL_ERROR
+}
+
+// DomainAllowed returns true if rawURL is on one of the allowed domains, or if
+// no domains are allowed explicitly.
+func (l ExecutionLimits) DomainAllowed(rawURL string) bool {
+	if len(l.AllowedDomains) == 0 {
+		return true
+	}
+	host := hostname(rawURL)
+	for _, domain := range l.AllowedDomains {
+		domain = strings.ToLower(domain)
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkDomains returns a user error for the first of domains not allowed.
+func (l ExecutionLimits) checkDomains(domains []string) error {
+	for _, domain := range domains {
+		if !l.DomainAllowed(domain) {
+			return NewUserError(fmt.Errorf("domain not allowed: %s", domain))
+		}
+	}
+	return nil
+}
+
+// hostname returns the lowercase host of a URL, or of a bare domain.
+func hostname(rawURL string) string {
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "//" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return strings.ToLower(rawURL)
+	}
+	return strings.ToLower(u.Hostname())
+}
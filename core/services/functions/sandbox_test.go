@@ -0,0 +1,97 @@
+package functions_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	functions_service "github.com/smartcontractkit/chainlink/v2/core/services/functions"
+)
+
+func TestExecutionLimits_DomainAllowed(t *testing.T) {
+	t.Parallel()
+
+	limits := functions_service.ExecutionLimits{AllowedDomains: []string{"chain.link", "API.example.com"}}
+	assert.True(t, limits.DomainAllowed("https://chain.link/price"))
+	assert.True(t, limits.DomainAllowed("https://docs.chain.link"))
+	assert.True(t, limits.DomainAllowed("api.example.com"))
+	assert.True(t, limits.DomainAllowed("https://API.EXAMPLE.COM:8443/x"))
+	assert.False(t, limits.DomainAllowed("https://example.com"))
+	assert.False(t, limits.DomainAllowed("https://notchain.link"))
+	assert.False(t, limits.DomainAllowed("https://chain.link.evil.com"))
+
+	assert.True(t, functions_service.ExecutionLimits{}.DomainAllowed("https://anything.com"))
+}
+
+func TestErrorType(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, functions_service.NONE, functions_service.ErrorType(nil))
+	assert.Equal(t, functions_service.INTERNAL_ERROR, functions_service.ErrorType(errors.New("boom")))
+	assert.Equal(t, functions_service.USER_ERROR, functions_service.ErrorType(functions_service.ErrExecutionTimeout))
+	assert.Equal(t, functions_service.USER_ERROR, functions_service.ErrorType(functions_service.NewUserError(errors.New("bad"))))
+	assert.Equal(t, functions_service.INTERNAL_ERROR, functions_service.ErrorType(functions_service.NewInternalError(errors.New("bad"))))
+}
+
+func TestLocalSandbox_Execute(t *testing.T) {
+	t.Parallel()
+
+	request := func(source string, limits functions_service.ExecutionLimits) functions_service.ExecutionRequest {
+		return functions_service.ExecutionRequest{
+			RequestID: RequestID,
+			Data:      map[string]interface{}{"source": source},
+			Limits:    limits,
+		}
+	}
+
+	t.Run("echo", func(t *testing.T) {
+		sandbox := functions_service.NewLocalSandbox(nil, nil)
+		res, err := sandbox.Execute(testutils.Context(t), request("abcd", functions_service.ExecutionLimits{}))
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0xab, 0xcd}, res.Result)
+		assert.Empty(t, res.Error)
+
+		res, err = sandbox.Execute(testutils.Context(t), request("0xzz", functions_service.ExecutionLimits{}))
+		require.NoError(t, err)
+		assert.Empty(t, res.Result)
+		assert.Contains(t, string(res.Error), "source is not hex")
+	})
+
+	t.Run("memory limit", func(t *testing.T) {
+		sandbox := functions_service.NewLocalSandbox(nil, nil)
+		_, err := sandbox.Execute(testutils.Context(t), request("abcdef", functions_service.ExecutionLimits{MaxMemoryBytes: 4}))
+		require.EqualError(t, err, "memory limit of 4 bytes exceeded")
+		assert.Equal(t, functions_service.USER_ERROR, functions_service.ErrorType(err))
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		sandbox := functions_service.NewLocalSandbox(func(ctx context.Context, _ *functions_service.LocalEnv, _ functions_service.ExecutionRequest) ([]byte, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}, nil)
+		ctx, cancel := context.WithTimeout(testutils.Context(t), 10*time.Millisecond)
+		defer cancel()
+		_, err := sandbox.Execute(ctx, request("", functions_service.ExecutionLimits{}))
+		require.ErrorIs(t, err, functions_service.ErrExecutionTimeout)
+	})
+
+	t.Run("fetch", func(t *testing.T) {
+		sandbox := functions_service.NewLocalSandbox(func(_ context.Context, env *functions_service.LocalEnv, _ functions_service.ExecutionRequest) ([]byte, error) {
+			return env.Fetch("https://api.chain.link/price")
+		}, map[string][]byte{"https://api.chain.link/price": {0x01}})
+
+		res, err := sandbox.Execute(testutils.Context(t), request("", functions_service.ExecutionLimits{AllowedDomains: []string{"chain.link"}}))
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0x01}, res.Result)
+		assert.Equal(t, []string{"api.chain.link"}, res.Domains)
+
+		_, err = sandbox.Execute(testutils.Context(t), request("", functions_service.ExecutionLimits{AllowedDomains: []string{"example.com"}}))
+		require.EqualError(t, err, "domain not allowed: api.chain.link")
+		assert.Equal(t, functions_service.USER_ERROR, functions_service.ErrorType(err))
+	})
+}
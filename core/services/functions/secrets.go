@@ -0,0 +1,204 @@
+package functions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/cbor"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/functions/config"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/threshold"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+)
+
+// SecretsLocation is where the secrets of a request are, set in its
+// secretsLocation field.
+type SecretsLocation uint64
+
+const (
+	// SecretsInline secrets are included in the request.
+	SecretsInline SecretsLocation = iota
+	// SecretsRemote secrets are hosted by the user at an encrypted URL.
+	SecretsRemote
+	// SecretsDONHosted secrets are uploaded to the DON through the gateway,
+	// and referenced by the request with the CBOR encoding of a
+	// DONHostedSecretsReference.
+	SecretsDONHosted
+)
+
+// DONHostedSecretsReference identifies the secrets of a request in the slots
+// of the subscription owner.
+type DONHostedSecretsReference struct {
+	SlotID  uint64
+	Version uint64
+}
+
+// ParseDONHostedSecretsReference returns the reference to DON-hosted secrets
+// of the request data, or nil if its secrets are elsewhere.
+func ParseDONHostedSecretsReference(requestData map[string]interface{}) (*DONHostedSecretsReference, error) {
+	location, err := uintField(requestData, "secretsLocation")
+	if err != nil || SecretsLocation(location) != SecretsDONHosted {
+		return nil, nil
+	}
+	encoded, ok := requestData["secrets"].([]byte)
+	if !ok {
+		return nil, errors.New("DON-hosted secrets reference is not bytes")
+	}
+	decoded, err := cbor.ParseDietCBOR(encoded)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid DON-hosted secrets reference")
+	}
+	var ref DONHostedSecretsReference
+	if ref.SlotID, err = uintField(decoded, "slotID"); err != nil {
+		return nil, err
+	}
+	if ref.Version, err = uintField(decoded, "version"); err != nil {
+		return nil, err
+	}
+	return &ref, nil
+}
+
+func uintField(m map[string]interface{}, name string) (uint64, error) {
+	switch v := m[name].(type) {
+	case uint64:
+		return v, nil
+	case int64:
+		if v >= 0 {
+			return uint64(v), nil
+		}
+	}
+	return 0, errors.Errorf("%s is not an unsigned integer", name)
+}
+
+// resolveSecrets returns the decrypted DON-hosted secrets of a request, or nil
+// if it has none. The secrets are decrypted with the request ID as ciphertext
+// ID, so that each request decrypts them anew.
+func resolveSecrets(ctx context.Context, secretsORM SecretsORM, decryptor threshold.Decryptor, requestID RequestID, owner common.Address, requestData map[string]interface{}) ([]byte, error) {
+	ref, err := ParseDONHostedSecretsReference(requestData)
+	if err != nil {
+		return nil, NewUserError(err)
+	}
+	if ref == nil {
+		return nil, nil
+	}
+	if secretsORM == nil || decryptor == nil {
+		return nil, NewUserError(errors.New("DON-hosted secrets are not supported by this DON"))
+	}
+	secrets, err := secretsORM.GetSecrets(owner, ref.SlotID, pg.WithParentCtx(ctx))
+	if errors.Is(err, ErrSecretsNotFound) {
+		return nil, NewUserError(fmt.Errorf("no secrets in slot %d", ref.SlotID))
+	} else if err != nil {
+		return nil, NewInternalError(err)
+	}
+	if secrets.Version != ref.Version {
+		return nil, NewUserError(fmt.Errorf("secrets in slot %d have version %d, not %d", ref.SlotID, secrets.Version, ref.Version))
+	}
+	if !secrets.ExpiresAt.After(time.Now()) {
+		return nil, NewUserError(fmt.Errorf("secrets in slot %d expired", ref.SlotID))
+	}
+	plaintext, err := decryptor.Decrypt(ctx, requestID[:], secrets.EncryptedSecrets)
+	if err != nil {
+		return nil, NewInternalError(errors.Wrap(err, "failed to decrypt secrets"))
+	}
+	return plaintext, nil
+}
+
+// SecretsHandler handles the secrets requests of users, forwarded to the node
+// by the gateway.
+type SecretsHandler struct {
+	orm          SecretsORM
+	pluginConfig config.PluginConfig
+	donID        string
+	lggr         logger.Logger
+}
+
+func NewSecretsHandler(orm SecretsORM, pluginConfig config.PluginConfig, donID string, lggr logger.Logger) *SecretsHandler {
+	return &SecretsHandler{
+		orm:          orm,
+		pluginConfig: pluginConfig,
+		donID:        donID,
+		lggr:         lggr.Named("SecretsHandler"),
+	}
+}
+
+// HandleGatewayMessage handles a user request, which it authenticates itself,
+// and returns the response to send back to the gateway.
+func (h *SecretsHandler) HandleGatewayMessage(ctx context.Context, msg *gateway.Message) (*gateway.Message, error) {
+	var response gateway.SecretsResponse
+	if err := h.handle(ctx, msg, &response); err != nil {
+		h.lggr.Debugw("secrets request failed", "messageID", msg.Body.MessageId, "sender", msg.Body.Sender, "err", err)
+		response = gateway.SecretsResponse{ErrorMessage: err.Error()}
+	} else {
+		response.Success = true
+	}
+	payload, err := json.Marshal(response)
+	if err != nil {
+		return nil, err
+	}
+	body := msg.Body
+	body.Payload = payload
+	return &gateway.Message{Body: body}, nil
+}
+
+func (h *SecretsHandler) handle(ctx context.Context, msg *gateway.Message, response *gateway.SecretsResponse) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+	if msg.Body.DonId != h.donID {
+		return fmt.Errorf("unexpected DON ID: %s", msg.Body.DonId)
+	}
+	owner := common.HexToAddress(msg.Body.Sender)
+
+	switch msg.Body.Method {
+	case gateway.MethodSecretsSet:
+		var req gateway.SecretsSetRequest
+		if err := json.Unmarshal(msg.Body.Payload, &req); err != nil {
+			return errors.Wrap(err, "invalid payload")
+		}
+		expiresAt := time.UnixMilli(req.Expiration)
+		if err := h.validateSecrets(req, expiresAt); err != nil {
+			return err
+		}
+		return h.orm.UpsertSecrets(HostedSecrets{
+			Owner:            owner,
+			SlotID:           req.SlotID,
+			Version:          req.Version,
+			EncryptedSecrets: req.EncryptedSecrets,
+			ExpiresAt:        expiresAt,
+		}, h.pluginConfig.MaxSecretsSlotsPerOwner, pg.WithParentCtx(ctx))
+	case gateway.MethodSecretsList:
+		secrets, err := h.orm.ListSecrets(owner, pg.WithParentCtx(ctx))
+		if err != nil {
+			return err
+		}
+		for _, s := range secrets {
+			response.Rows = append(response.Rows, gateway.SecretsSlotEntry{SlotID: s.SlotID, Version: s.Version, Expiration: s.ExpiresAt.UnixMilli()})
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported method: %s", msg.Body.Method)
+	}
+}
+
+func (h *SecretsHandler) validateSecrets(req gateway.SecretsSetRequest, expiresAt time.Time) error {
+	if len(req.EncryptedSecrets) == 0 {
+		return errors.New("no encrypted secrets")
+	}
+	if maxSize := h.pluginConfig.MaxSecretsSizeBytes; maxSize > 0 && len(req.EncryptedSecrets) > int(maxSize) {
+		return fmt.Errorf("secrets too big (max %d bytes)", maxSize)
+	}
+	now := time.Now()
+	if !expiresAt.After(now) {
+		return errors.New("expiration must be in the future")
+	}
+	if maxTTL := h.pluginConfig.MaxSecretsTTLSec; maxTTL > 0 && expiresAt.Sub(now) > time.Duration(maxTTL)*time.Second {
+		return fmt.Errorf("expiration must be at most %d seconds from now", maxTTL)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package functions
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/sqlx"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+)
+
+//go:generate mockery --quiet --name SecretsORM --output ./mocks/ --case=underscore
+
+var (
+	ErrSecretsNotFound     = errors.New("secrets not found")
+	ErrStaleSecretsVersion = errors.New("secrets version must be greater than the stored version")
+	ErrTooManySecretsSlots = errors.New("too many secrets slots")
+)
+
+// HostedSecrets are threshold-encrypted secrets uploaded by their owner to a
+// slot, to be used by the owner's requests until they expire.
+type HostedSecrets struct {
+	Owner            common.Address
+	SlotID           uint64
+	Version          uint64
+	EncryptedSecrets []byte
+	ExpiresAt        time.Time
+	CreatedAt        time.Time
+}
+
+// SecretsORM stores the secrets hosted by the DON of a contract.
+type SecretsORM interface {
+	// UpsertSecrets stores secrets in their slot, unless the slot holds a
+	// version at or above theirs, or the owner would have more than maxSlots
+	// slots. A maxSlots of zero means no limit.
+	UpsertSecrets(secrets HostedSecrets, maxSlots uint32, qopts ...pg.QOpt) error
+	GetSecrets(owner common.Address, slotID uint64, qopts ...pg.QOpt) (*HostedSecrets, error)
+	// ListSecrets returns the secrets of owner without their contents.
+	ListSecrets(owner common.Address, qopts ...pg.QOpt) ([]HostedSecrets, error)
+	DeleteExpiredSecrets(before time.Time, qopts ...pg.QOpt) (int64, error)
+}
+
+type secretsORM struct {
+	q               pg.Q
+	contractAddress common.Address
+}
+
+var _ SecretsORM = (*secretsORM)(nil)
+
+func NewSecretsORM(db *sqlx.DB, lggr logger.Logger, cfg pg.QConfig, contractAddress common.Address) SecretsORM {
+	return &secretsORM{
+		q:               pg.NewQ(db, lggr, cfg),
+		contractAddress: contractAddress,
+	}
+}
+
+type hostedSecretsRow struct {
+	Owner            common.Address `db:"owner"`
+	SlotID           int64          `db:"slot_id"`
+	Version          int64          `db:"version"`
+	EncryptedSecrets []byte         `db:"encrypted_secrets"`
+	ExpiresAt        time.Time      `db:"expires_at"`
+	CreatedAt        time.Time      `db:"created_at"`
+}
+
+func (r hostedSecretsRow) toHostedSecrets() HostedSecrets {
+	return HostedSecrets{
+		Owner:            r.Owner,
+		SlotID:           uint64(r.SlotID),
+		Version:          uint64(r.Version),
+		EncryptedSecrets: r.EncryptedSecrets,
+		ExpiresAt:        r.ExpiresAt,
+		CreatedAt:        r.CreatedAt,
+	}
+}
+
+func (o *secretsORM) UpsertSecrets(secrets HostedSecrets, maxSlots uint32, qopts ...pg.QOpt) error {
+	return o.q.WithOpts(qopts...).Transaction(func(tx pg.Queryer) error {
+		var version int64
+		err := tx.Get(&version, `SELECT version FROM functions_secrets WHERE contract_address = $1 AND owner = $2 AND slot_id = $3 FOR UPDATE`,
+			o.contractAddress, secrets.Owner, secrets.SlotID)
+		if errors.Is(err, sql.ErrNoRows) {
+			if maxSlots > 0 {
+				var slots uint32
+				if err = tx.Get(&slots, `SELECT count(*) FROM functions_secrets WHERE contract_address = $1 AND owner = $2`, o.contractAddress, secrets.Owner); err != nil {
+					return errors.Wrap(err, "failed to count secrets slots")
+				}
+				if slots >= maxSlots {
+					return ErrTooManySecretsSlots
+				}
+			}
+		} else if err != nil {
+			return errors.Wrap(err, "failed to get secrets version")
+		} else if uint64(version) >= secrets.Version {
+			return ErrStaleSecretsVersion
+		}
+
+		_, err = tx.Exec(`
+			INSERT INTO functions_secrets (contract_address, owner, slot_id, version, encrypted_secrets, expires_at, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, NOW())
+			ON CONFLICT (contract_address, owner, slot_id) DO UPDATE SET
+				version = EXCLUDED.version,
+				encrypted_secrets = EXCLUDED.encrypted_secrets,
+				expires_at = EXCLUDED.expires_at,
+				created_at = EXCLUDED.created_at
+		`, o.contractAddress, secrets.Owner, secrets.SlotID, secrets.Version, secrets.EncryptedSecrets, secrets.ExpiresAt)
+		return errors.Wrap(err, "failed to upsert secrets")
+	})
+}
+
+func (o *secretsORM) GetSecrets(owner common.Address, slotID uint64, qopts ...pg.QOpt) (*HostedSecrets, error) {
+	var row hostedSecretsRow
+	err := o.q.WithOpts(qopts...).Get(&row, `
+		SELECT owner, slot_id, version, encrypted_secrets, expires_at, created_at FROM functions_secrets
+		WHERE contract_address = $1 AND owner = $2 AND slot_id = $3
+	`, o.contractAddress, owner, slotID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSecretsNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to get secrets")
+	}
+	secrets := row.toHostedSecrets()
+	return &secrets, nil
+}
+
+func (o *secretsORM) ListSecrets(owner common.Address, qopts ...pg.QOpt) ([]HostedSecrets, error) {
+	var rows []hostedSecretsRow
+	err := o.q.WithOpts(qopts...).Select(&rows, `
+		SELECT owner, slot_id, version, expires_at, created_at FROM functions_secrets
+		WHERE contract_address = $1 AND owner = $2
+		ORDER BY slot_id
+	`, o.contractAddress, owner)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list secrets")
+	}
+	secrets := make([]HostedSecrets, len(rows))
+	for i, row := range rows {
+		secrets[i] = row.toHostedSecrets()
+	}
+	return secrets, nil
+}
+
+func (o *secretsORM) DeleteExpiredSecrets(before time.Time, qopts ...pg.QOpt) (int64, error) {
+	res, cancel, err := o.q.WithOpts(qopts...).ExecQIter(`DELETE FROM functions_secrets WHERE contract_address = $1 AND expires_at <= $2`, o.contractAddress, before)
+	defer cancel()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete expired secrets")
+	}
+	return res.RowsAffected()
+}
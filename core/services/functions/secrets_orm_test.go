@@ -0,0 +1,95 @@
+package functions_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/functions"
+)
+
+func setupSecretsORM(t *testing.T) functions.SecretsORM {
+	t.Helper()
+
+	db := pgtest.NewSqlxDB(t)
+	return functions.NewSecretsORM(db, logger.TestLogger(t), pgtest.NewQConfig(true), testutils.NewAddress())
+}
+
+func TestSecretsORM_UpsertAndGet(t *testing.T) {
+	t.Parallel()
+
+	orm := setupSecretsORM(t)
+	owner := testutils.NewAddress()
+	expiresAt := time.Now().Add(time.Hour).Round(time.Second)
+
+	_, err := orm.GetSecrets(owner, 0)
+	require.ErrorIs(t, err, functions.ErrSecretsNotFound)
+
+	require.NoError(t, orm.UpsertSecrets(functions.HostedSecrets{Owner: owner, SlotID: 0, Version: 1, EncryptedSecrets: []byte{1}, ExpiresAt: expiresAt}, 0))
+	secrets, err := orm.GetSecrets(owner, 0)
+	require.NoError(t, err)
+	require.Equal(t, owner, secrets.Owner)
+	require.Equal(t, uint64(1), secrets.Version)
+	require.Equal(t, []byte{1}, secrets.EncryptedSecrets)
+	require.Equal(t, expiresAt.UTC(), secrets.ExpiresAt.UTC())
+
+	// versions only go up
+	err = orm.UpsertSecrets(functions.HostedSecrets{Owner: owner, SlotID: 0, Version: 1, EncryptedSecrets: []byte{2}, ExpiresAt: expiresAt}, 0)
+	require.ErrorIs(t, err, functions.ErrStaleSecretsVersion)
+	require.NoError(t, orm.UpsertSecrets(functions.HostedSecrets{Owner: owner, SlotID: 0, Version: 2, EncryptedSecrets: []byte{2}, ExpiresAt: expiresAt}, 0))
+	secrets, err = orm.GetSecrets(owner, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), secrets.Version)
+	require.Equal(t, []byte{2}, secrets.EncryptedSecrets)
+
+	// slots are per owner
+	_, err = orm.GetSecrets(testutils.NewAddress(), 0)
+	require.ErrorIs(t, err, functions.ErrSecretsNotFound)
+}
+
+func TestSecretsORM_MaxSlots(t *testing.T) {
+	t.Parallel()
+
+	orm := setupSecretsORM(t)
+	owner := testutils.NewAddress()
+	expiresAt := time.Now().Add(time.Hour)
+
+	require.NoError(t, orm.UpsertSecrets(functions.HostedSecrets{Owner: owner, SlotID: 0, Version: 1, EncryptedSecrets: []byte{1}, ExpiresAt: expiresAt}, 2))
+	require.NoError(t, orm.UpsertSecrets(functions.HostedSecrets{Owner: owner, SlotID: 1, Version: 1, EncryptedSecrets: []byte{1}, ExpiresAt: expiresAt}, 2))
+	err := orm.UpsertSecrets(functions.HostedSecrets{Owner: owner, SlotID: 2, Version: 1, EncryptedSecrets: []byte{1}, ExpiresAt: expiresAt}, 2)
+	require.ErrorIs(t, err, functions.ErrTooManySecretsSlots)
+	// existing slots can still be updated
+	require.NoError(t, orm.UpsertSecrets(functions.HostedSecrets{Owner: owner, SlotID: 1, Version: 2, EncryptedSecrets: []byte{1}, ExpiresAt: expiresAt}, 2))
+
+	list, err := orm.ListSecrets(owner)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, uint64(0), list[0].SlotID)
+	require.Equal(t, uint64(1), list[1].SlotID)
+	require.Equal(t, uint64(2), list[1].Version)
+	require.Empty(t, list[1].EncryptedSecrets)
+}
+
+func TestSecretsORM_DeleteExpiredSecrets(t *testing.T) {
+	t.Parallel()
+
+	orm := setupSecretsORM(t)
+	owner := testutils.NewAddress()
+	now := time.Now()
+
+	require.NoError(t, orm.UpsertSecrets(functions.HostedSecrets{Owner: owner, SlotID: 0, Version: 1, EncryptedSecrets: []byte{1}, ExpiresAt: now.Add(-time.Minute)}, 0))
+	require.NoError(t, orm.UpsertSecrets(functions.HostedSecrets{Owner: owner, SlotID: 1, Version: 1, EncryptedSecrets: []byte{1}, ExpiresAt: now.Add(time.Hour)}, 0))
+
+	deleted, err := orm.DeleteExpiredSecrets(now)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+
+	_, err = orm.GetSecrets(owner, 0)
+	require.ErrorIs(t, err, functions.ErrSecretsNotFound)
+	_, err = orm.GetSecrets(owner, 1)
+	require.NoError(t, err)
+}
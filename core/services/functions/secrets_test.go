@@ -0,0 +1,127 @@
+package functions_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	functions_service "github.com/smartcontractkit/chainlink/v2/core/services/functions"
+	functions_mocks "github.com/smartcontractkit/chainlink/v2/core/services/functions/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/functions/config"
+)
+
+func donHostedRequestData(t *testing.T, slotID, version uint64) map[string]interface{} {
+	ref, err := cbor.Marshal(map[string]interface{}{"slotID": slotID, "version": version})
+	require.NoError(t, err)
+	return map[string]interface{}{"secretsLocation": uint64(functions_service.SecretsDONHosted), "secrets": ref}
+}
+
+func TestParseDONHostedSecretsReference(t *testing.T) {
+	t.Parallel()
+
+	ref, err := functions_service.ParseDONHostedSecretsReference(donHostedRequestData(t, 3, 7))
+	require.NoError(t, err)
+	assert.Equal(t, &functions_service.DONHostedSecretsReference{SlotID: 3, Version: 7}, ref)
+
+	ref, err = functions_service.ParseDONHostedSecretsReference(map[string]interface{}{"secretsLocation": uint64(functions_service.SecretsRemote), "secrets": []byte{1}})
+	require.NoError(t, err)
+	assert.Nil(t, ref)
+
+	ref, err = functions_service.ParseDONHostedSecretsReference(map[string]interface{}{"source": "x"})
+	require.NoError(t, err)
+	assert.Nil(t, ref)
+
+	_, err = functions_service.ParseDONHostedSecretsReference(map[string]interface{}{"secretsLocation": uint64(functions_service.SecretsDONHosted), "secrets": "x"})
+	require.EqualError(t, err, "DON-hosted secrets reference is not bytes")
+}
+
+func signedSecretsMessage(t *testing.T, method string, payload interface{}) *gateway.Message {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	rawPayload, err := json.Marshal(payload)
+	require.NoError(t, err)
+	msg := &gateway.Message{Body: gateway.MessageBody{MessageId: "1", Method: method, DonId: "don", Payload: rawPayload}}
+	require.NoError(t, msg.Sign(key))
+	return msg
+}
+
+func secretsResponse(t *testing.T, msg *gateway.Message) gateway.SecretsResponse {
+	var response gateway.SecretsResponse
+	require.NoError(t, json.Unmarshal(msg.Body.Payload, &response))
+	return response
+}
+
+func TestSecretsHandler_HandleGatewayMessage(t *testing.T) {
+	t.Parallel()
+
+	pluginConfig := config.PluginConfig{MaxSecretsSizeBytes: 4, MaxSecretsSlotsPerOwner: 3, MaxSecretsTTLSec: 3600}
+	ctx := testutils.Context(t)
+
+	t.Run("set", func(t *testing.T) {
+		orm := functions_mocks.NewSecretsORM(t)
+		handler := functions_service.NewSecretsHandler(orm, pluginConfig, "don", logger.TestLogger(t))
+		expiration := time.Now().Add(time.Minute).UnixMilli()
+		msg := signedSecretsMessage(t, gateway.MethodSecretsSet, gateway.SecretsSetRequest{SlotID: 1, Version: 2, Expiration: expiration, EncryptedSecrets: []byte{1, 2}})
+
+		orm.On("UpsertSecrets", functions_service.HostedSecrets{
+			Owner:            common.HexToAddress(msg.Body.Sender),
+			SlotID:           1,
+			Version:          2,
+			EncryptedSecrets: []byte{1, 2},
+			ExpiresAt:        time.UnixMilli(expiration),
+		}, uint32(3), mock.Anything).Return(nil).Once()
+
+		resp, err := handler.HandleGatewayMessage(ctx, msg)
+		require.NoError(t, err)
+		assert.Equal(t, "1", resp.Body.MessageId)
+		assert.Equal(t, gateway.SecretsResponse{Success: true}, secretsResponse(t, resp))
+	})
+
+	t.Run("list", func(t *testing.T) {
+		orm := functions_mocks.NewSecretsORM(t)
+		handler := functions_service.NewSecretsHandler(orm, pluginConfig, "don", logger.TestLogger(t))
+		msg := signedSecretsMessage(t, gateway.MethodSecretsList, struct{}{})
+		expiresAt := time.UnixMilli(1686000000000)
+		orm.On("ListSecrets", mock.Anything, mock.Anything).Return([]functions_service.HostedSecrets{{SlotID: 1, Version: 2, ExpiresAt: expiresAt}}, nil).Once()
+
+		resp, err := handler.HandleGatewayMessage(ctx, msg)
+		require.NoError(t, err)
+		assert.Equal(t, gateway.SecretsResponse{Success: true, Rows: []gateway.SecretsSlotEntry{{SlotID: 1, Version: 2, Expiration: 1686000000000}}}, secretsResponse(t, resp))
+	})
+
+	t.Run("rejects invalid requests", func(t *testing.T) {
+		orm := functions_mocks.NewSecretsORM(t)
+		handler := functions_service.NewSecretsHandler(orm, pluginConfig, "don", logger.TestLogger(t))
+		future := time.Now().Add(time.Minute).UnixMilli()
+
+		for _, test := range []struct {
+			name string
+			msg  *gateway.Message
+			err  string
+		}{
+			{"too big", signedSecretsMessage(t, gateway.MethodSecretsSet, gateway.SecretsSetRequest{Expiration: future, EncryptedSecrets: []byte{1, 2, 3, 4, 5}}), "secrets too big (max 4 bytes)"},
+			{"expired", signedSecretsMessage(t, gateway.MethodSecretsSet, gateway.SecretsSetRequest{Expiration: time.Now().Add(-time.Minute).UnixMilli(), EncryptedSecrets: []byte{1}}), "expiration must be in the future"},
+			{"ttl too long", signedSecretsMessage(t, gateway.MethodSecretsSet, gateway.SecretsSetRequest{Expiration: time.Now().Add(2 * time.Hour).UnixMilli(), EncryptedSecrets: []byte{1}}), "expiration must be at most 3600 seconds from now"},
+			{"unknown method", signedSecretsMessage(t, "secrets_delete", struct{}{}), "unsupported method: secrets_delete"},
+			{"forged sender", func() *gateway.Message {
+				msg := signedSecretsMessage(t, gateway.MethodSecretsList, struct{}{})
+				msg.Body.Sender = testutils.NewAddress().Hex()
+				return msg
+			}(), "message signer does not match sender"},
+		} {
+			resp, err := handler.HandleGatewayMessage(ctx, test.msg)
+			require.NoError(t, err, test.name)
+			assert.Equal(t, gateway.SecretsResponse{ErrorMessage: test.err}, secretsResponse(t, resp), test.name)
+		}
+	})
+}
@@ -0,0 +1,196 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.uber.org/multierr"
+)
+
+const (
+	// MethodSecretsSet uploads threshold-encrypted secrets to a slot of the
+	// sender, with a SecretsSetRequest payload.
+	MethodSecretsSet = "secrets_set"
+	// MethodSecretsList lists the slots of the sender.
+	MethodSecretsList = "secrets_list"
+)
+
+// SecretsSetRequest is the payload of MethodSecretsSet.
+type SecretsSetRequest struct {
+	SlotID  uint64 `json:"slot_id"`
+	Version uint64 `json:"version"`
+	// Expiration is the time the secrets expire at, in milliseconds since the
+	// Unix epoch.
+	Expiration       int64         `json:"expiration"`
+	EncryptedSecrets hexutil.Bytes `json:"encrypted_secrets"`
+}
+
+// SecretsResponse is the payload of the response of each node.
+type SecretsResponse struct {
+	Success      bool               `json:"success"`
+	ErrorMessage string             `json:"error_message,omitempty"`
+	Rows         []SecretsSlotEntry `json:"rows,omitempty"`
+}
+
+type SecretsSlotEntry struct {
+	SlotID     uint64 `json:"slot_id"`
+	Version    uint64 `json:"version"`
+	Expiration int64  `json:"expiration"`
+}
+
+// CombinedSecretsResponse is the payload of the response to the user,
+// combining the responses of the nodes.
+type CombinedSecretsResponse struct {
+	Success       bool                       `json:"success"`
+	NodeResponses map[string]SecretsResponse `json:"node_responses"`
+}
+
+type FunctionsHandlerConfig struct {
+	// MinimumSuccessfulResponses is the number of nodes which need to
+	// succeed for a request to succeed. Defaults to all members of the DON.
+	MinimumSuccessfulResponses int `json:"minimumSuccessfulResponses"`
+}
+
+// functionsHandler forwards the secrets requests of users to all nodes of the
+// DON, and responds once enough of them succeeded, or can no longer succeed.
+type functionsHandler struct {
+	donConfig *DONConfig
+	connMgr   DONConnectionManager
+	threshold int
+	members   map[string]struct{}
+	pending   map[string]*pendingSecretsRequest
+	mu        sync.Mutex
+}
+
+type pendingSecretsRequest struct {
+	request      *Message
+	callbackChan chan UserCallbackPayload
+	responses    map[string]SecretsResponse
+	successes    int
+}
+
+var _ Handler = (*functionsHandler)(nil)
+
+func NewFunctionsHandler(donConfig *DONConfig, connMgr DONConnectionManager) (Handler, error) {
+	var cfg FunctionsHandlerConfig
+	if len(donConfig.HandlerConfig) > 0 {
+		if err := json.Unmarshal(donConfig.HandlerConfig, &cfg); err != nil {
+			return nil, fmt.Errorf("invalid functions handler config: %w", err)
+		}
+	}
+	threshold := cfg.MinimumSuccessfulResponses
+	if threshold == 0 {
+		threshold = len(donConfig.Members)
+	}
+	if threshold < 1 || threshold > len(donConfig.Members) {
+		return nil, fmt.Errorf("minimumSuccessfulResponses must be between 1 and %d, got: %d", len(donConfig.Members), threshold)
+	}
+	members := make(map[string]struct{}, len(donConfig.Members))
+	for _, member := range donConfig.Members {
+		members[strings.ToLower(member.Address)] = struct{}{}
+	}
+	return &functionsHandler{
+		donConfig: donConfig,
+		connMgr:   connMgr,
+		threshold: threshold,
+		members:   members,
+		pending:   make(map[string]*pendingSecretsRequest),
+	}, nil
+}
+
+func (h *functionsHandler) HandleUserMessage(ctx context.Context, msg *Message, callbackChan chan UserCallbackPayload) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+	switch msg.Body.Method {
+	case MethodSecretsSet, MethodSecretsList:
+	default:
+		return fmt.Errorf("unsupported method: %s", msg.Body.Method)
+	}
+
+	h.mu.Lock()
+	if _, ok := h.pending[msg.Body.MessageId]; ok {
+		h.mu.Unlock()
+		return errors.New("duplicate message ID")
+	}
+	h.pending[msg.Body.MessageId] = &pendingSecretsRequest{
+		request:      msg,
+		callbackChan: callbackChan,
+		responses:    make(map[string]SecretsResponse),
+	}
+	h.mu.Unlock()
+
+	var sent int
+	var err error
+	for _, member := range h.donConfig.Members {
+		if err2 := h.connMgr.SendToNode(ctx, member.Address, msg); err2 != nil {
+			err = multierr.Combine(err, err2)
+			continue
+		}
+		sent++
+	}
+	if sent < h.threshold {
+		h.mu.Lock()
+		delete(h.pending, msg.Body.MessageId)
+		h.mu.Unlock()
+		return fmt.Errorf("failed to send the request to enough nodes: %w", err)
+	}
+	return nil
+}
+
+func (h *functionsHandler) HandleNodeMessage(ctx context.Context, msg *Message, nodeAddr string) error {
+	nodeAddr = strings.ToLower(nodeAddr)
+	if _, ok := h.members[nodeAddr]; !ok {
+		return fmt.Errorf("node %s is not a member of DON %s", nodeAddr, h.donConfig.DonId)
+	}
+	var response SecretsResponse
+	if err := json.Unmarshal(msg.Body.Payload, &response); err != nil {
+		return fmt.Errorf("invalid secrets response: %w", err)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	req, ok := h.pending[msg.Body.MessageId]
+	if !ok {
+		// the request already got its response
+		return nil
+	}
+	if _, ok := req.responses[nodeAddr]; ok {
+		return fmt.Errorf("duplicate response from node %s", nodeAddr)
+	}
+	req.responses[nodeAddr] = response
+	if response.Success {
+		req.successes++
+	}
+
+	failures := len(req.responses) - req.successes
+	success := req.successes >= h.threshold
+	if !success && len(h.members)-failures >= h.threshold {
+		// wait for more responses
+		return nil
+	}
+	delete(h.pending, msg.Body.MessageId)
+
+	payload, err := json.Marshal(CombinedSecretsResponse{Success: success, NodeResponses: req.responses})
+	if err != nil {
+		return err
+	}
+	body := req.request.Body
+	body.Payload = payload
+	req.callbackChan <- UserCallbackPayload{Msg: &Message{Body: body}, ErrCode: NoError}
+	close(req.callbackChan)
+	return nil
+}
+
+func (h *functionsHandler) Start(context.Context) error {
+	return nil
+}
+
+func (h *functionsHandler) Close() error {
+	return nil
+}
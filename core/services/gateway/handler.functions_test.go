@@ -0,0 +1,113 @@
+package gateway_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway"
+)
+
+func newFunctionsHandler(t *testing.T, handlerConfig string) (gateway.Handler, *testConnManager) {
+	config := gateway.DONConfig{
+		DonId:         "don",
+		HandlerConfig: json.RawMessage(handlerConfig),
+		Members: []gateway.NodeConfig{
+			{Name: "node one", Address: "addr_1"},
+			{Name: "node two", Address: "addr_2"},
+			{Name: "node three", Address: "addr_3"},
+		},
+	}
+	connMgr := testConnManager{}
+	handler, err := gateway.NewFunctionsHandler(&config, &connMgr)
+	require.NoError(t, err)
+	connMgr.SetHandler(handler)
+	return handler, &connMgr
+}
+
+func secretsListMessage(t *testing.T) *gateway.Message {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	msg := &gateway.Message{Body: gateway.MessageBody{MessageId: "1234", Method: gateway.MethodSecretsList, DonId: "don"}}
+	require.NoError(t, msg.Sign(key))
+	return msg
+}
+
+func nodeResponse(t *testing.T, msg *gateway.Message, success bool) *gateway.Message {
+	payload, err := json.Marshal(gateway.SecretsResponse{Success: success})
+	require.NoError(t, err)
+	body := msg.Body
+	body.Payload = payload
+	return &gateway.Message{Body: body}
+}
+
+func TestFunctionsHandler_Config(t *testing.T) {
+	t.Parallel()
+
+	config := gateway.DONConfig{Members: []gateway.NodeConfig{{Address: "addr_1"}}}
+	config.HandlerConfig = json.RawMessage(`{"minimumSuccessfulResponses": 2}`)
+	_, err := gateway.NewFunctionsHandler(&config, &testConnManager{})
+	require.Error(t, err)
+	config.HandlerConfig = json.RawMessage(`{"minimumSuccessfulResponses": 1}`)
+	_, err = gateway.NewFunctionsHandler(&config, &testConnManager{})
+	require.NoError(t, err)
+}
+
+func TestFunctionsHandler_Success(t *testing.T) {
+	t.Parallel()
+
+	handler, connMgr := newFunctionsHandler(t, `{"minimumSuccessfulResponses": 2}`)
+	msg := secretsListMessage(t)
+	callbackChan := make(chan gateway.UserCallbackPayload, 1)
+	require.NoError(t, handler.HandleUserMessage(context.Background(), msg, callbackChan))
+	require.Equal(t, 3, connMgr.sendCounter)
+	require.Error(t, handler.HandleUserMessage(context.Background(), msg, make(chan gateway.UserCallbackPayload, 1)), "duplicate message ID")
+
+	require.NoError(t, handler.HandleNodeMessage(context.Background(), nodeResponse(t, msg, true), "addr_1"))
+	require.Error(t, handler.HandleNodeMessage(context.Background(), nodeResponse(t, msg, true), "addr_1"), "duplicate response")
+	require.Error(t, handler.HandleNodeMessage(context.Background(), nodeResponse(t, msg, true), "addr_4"), "not a member")
+	require.Empty(t, callbackChan)
+	require.NoError(t, handler.HandleNodeMessage(context.Background(), nodeResponse(t, msg, true), "ADDR_2"))
+
+	response := <-callbackChan
+	require.Equal(t, gateway.NoError, response.ErrCode)
+	require.Equal(t, "1234", response.Msg.Body.MessageId)
+	var combined gateway.CombinedSecretsResponse
+	require.NoError(t, json.Unmarshal(response.Msg.Body.Payload, &combined))
+	require.True(t, combined.Success)
+	require.Len(t, combined.NodeResponses, 2)
+
+	// late responses are ignored
+	require.NoError(t, handler.HandleNodeMessage(context.Background(), nodeResponse(t, msg, true), "addr_3"))
+}
+
+func TestFunctionsHandler_Failure(t *testing.T) {
+	t.Parallel()
+
+	handler, _ := newFunctionsHandler(t, `{"minimumSuccessfulResponses": 2}`)
+	msg := secretsListMessage(t)
+	callbackChan := make(chan gateway.UserCallbackPayload, 1)
+	require.NoError(t, handler.HandleUserMessage(context.Background(), msg, callbackChan))
+
+	require.NoError(t, handler.HandleNodeMessage(context.Background(), nodeResponse(t, msg, false), "addr_1"))
+	require.Empty(t, callbackChan)
+	require.NoError(t, handler.HandleNodeMessage(context.Background(), nodeResponse(t, msg, false), "addr_2"))
+
+	response := <-callbackChan
+	var combined gateway.CombinedSecretsResponse
+	require.NoError(t, json.Unmarshal(response.Msg.Body.Payload, &combined))
+	require.False(t, combined.Success)
+}
+
+func TestFunctionsHandler_InvalidUserMessage(t *testing.T) {
+	t.Parallel()
+
+	handler, connMgr := newFunctionsHandler(t, "")
+	msg := secretsListMessage(t)
+	msg.Body.DonId = "other"
+	require.Error(t, handler.HandleUserMessage(context.Background(), msg, make(chan gateway.UserCallbackPayload, 1)))
+	require.Equal(t, 0, connMgr.sendCounter)
+}
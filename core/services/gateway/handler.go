@@ -38,13 +38,16 @@ type Handler interface {
 type HandlerType = string
 
 const (
-	Dummy HandlerType = "dummy"
+	Dummy     HandlerType = "dummy"
+	Functions HandlerType = "functions"
 )
 
 func NewHandler(handlerType HandlerType, donConfig *DONConfig, connMgr DONConnectionManager) (Handler, error) {
 	switch handlerType {
 	case Dummy:
 		return NewDummyHandler(donConfig, connMgr)
+	case Functions:
+		return NewFunctionsHandler(donConfig, connMgr)
 	default:
 		return nil, fmt.Errorf("unsupported handler type %s", handlerType)
 	}
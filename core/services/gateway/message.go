@@ -1,11 +1,33 @@
 package gateway
 
-import "encoding/json"
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	MessageSignatureLen  = 65
+	MessageIdMaxLen      = 128
+	MessageMethodMaxLen  = 64
+	MessageDonIdMaxLen   = 64
+	MessageSenderLen     = 42
+	messageSignedDataLen = MessageIdMaxLen + MessageMethodMaxLen + MessageDonIdMaxLen + MessageSenderLen
+)
 
 /*
  * Top-level Message structure containing:
  *   - universal fields identifying the request, the sender and the target DON/service
  *   - product-specific payload
+ *
+ * Signature is the hex-encoded, EIP-191 signature of the body by the sender.
  */
 type Message struct {
 	Signature string      `json:"signature"`
@@ -21,3 +43,80 @@ type MessageBody struct {
 	// Service-specific payload, decoded inside the Handler.
 	Payload json.RawMessage `json:"payload"`
 }
+
+// Validate checks the lengths of the fields of the message, and that it is
+// signed by its sender.
+func (m *Message) Validate() error {
+	if m == nil {
+		return errors.New("nil message")
+	}
+	if len(m.Body.MessageId) == 0 || len(m.Body.MessageId) > MessageIdMaxLen {
+		return errors.New("invalid message ID length")
+	}
+	if len(m.Body.Method) == 0 || len(m.Body.Method) > MessageMethodMaxLen {
+		return errors.New("invalid method name length")
+	}
+	if len(m.Body.DonId) == 0 || len(m.Body.DonId) > MessageDonIdMaxLen {
+		return errors.New("invalid DON ID length")
+	}
+	if len(m.Body.Sender) != MessageSenderLen || !common.IsHexAddress(m.Body.Sender) {
+		return errors.New("invalid sender address")
+	}
+	signer, err := m.ExtractSigner()
+	if err != nil {
+		return err
+	}
+	if signer != common.HexToAddress(m.Body.Sender) {
+		return errors.New("message signer does not match sender")
+	}
+	return nil
+}
+
+// Sign sets the sender of the message to the address of privateKey, and signs
+// the body with it.
+func (m *Message) Sign(privateKey *ecdsa.PrivateKey) error {
+	m.Body.Sender = strings.ToLower(crypto.PubkeyToAddress(privateKey.PublicKey).Hex())
+	signature, err := crypto.Sign(accounts.TextHash(m.Body.signedData()), privateKey)
+	if err != nil {
+		return err
+	}
+	m.Signature = hexutil.Encode(signature)
+	return nil
+}
+
+// ExtractSigner returns the address which signed the body of the message.
+func (m *Message) ExtractSigner() (common.Address, error) {
+	signature, err := hexutil.Decode(m.Signature)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid signature: %w", err)
+	}
+	if len(signature) != MessageSignatureLen {
+		return common.Address{}, errors.New("invalid signature length")
+	}
+	// accept signatures with the legacy recovery IDs of 27 and 28
+	if signature[crypto.RecoveryIDOffset] >= 27 {
+		signature[crypto.RecoveryIDOffset] -= 27
+	}
+	publicKey, err := crypto.SigToPub(accounts.TextHash(m.Body.signedData()), signature)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid signature: %w", err)
+	}
+	return crypto.PubkeyToAddress(*publicKey), nil
+}
+
+// signedData pads each field of the body to its maximum length, so that field
+// boundaries cannot be shifted, and appends the payload.
+func (b *MessageBody) signedData() []byte {
+	data := make([]byte, 0, messageSignedDataLen+len(b.Payload))
+	data = append(data, padded(b.MessageId, MessageIdMaxLen)...)
+	data = append(data, padded(b.Method, MessageMethodMaxLen)...)
+	data = append(data, padded(b.DonId, MessageDonIdMaxLen)...)
+	data = append(data, padded(b.Sender, MessageSenderLen)...)
+	return append(data, b.Payload...)
+}
+
+func padded(s string, n int) []byte {
+	b := make([]byte, n)
+	copy(b, s)
+	return b
+}
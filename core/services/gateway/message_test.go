@@ -0,0 +1,59 @@
+package gateway_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/gateway"
+)
+
+func signedMessage(t *testing.T) (*gateway.Message, common.Address) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	msg := &gateway.Message{Body: gateway.MessageBody{
+		MessageId: "1234",
+		Method:    gateway.MethodSecretsList,
+		DonId:     "don",
+		Payload:   []byte(`{"slot_id":1}`),
+	}}
+	require.NoError(t, msg.Sign(key))
+	return msg, crypto.PubkeyToAddress(key.PublicKey)
+}
+
+func TestMessage_SignAndValidate(t *testing.T) {
+	t.Parallel()
+
+	msg, address := signedMessage(t)
+	require.NoError(t, msg.Validate())
+	signer, err := msg.ExtractSigner()
+	require.NoError(t, err)
+	require.Equal(t, address, signer)
+}
+
+func TestMessage_ValidateTampered(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name   string
+		tamper func(*gateway.Message)
+	}{
+		{"message ID", func(m *gateway.Message) { m.Body.MessageId = "1235" }},
+		{"method", func(m *gateway.Message) { m.Body.Method = gateway.MethodSecretsSet }},
+		{"DON ID", func(m *gateway.Message) { m.Body.DonId = "other" }},
+		{"sender", func(m *gateway.Message) { m.Body.Sender = "0x0000000000000000000000000000000000000001" }},
+		{"payload", func(m *gateway.Message) { m.Body.Payload = []byte(`{"slot_id":2}`) }},
+		{"shifted fields", func(m *gateway.Message) { m.Body.MessageId, m.Body.Method = "1234secrets", "_list" }},
+		{"signature", func(m *gateway.Message) { m.Signature = "0x1234" }},
+		{"empty message ID", func(m *gateway.Message) { m.Body.MessageId = "" }},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			msg, _ := signedMessage(t)
+			tt.tamper(msg)
+			require.Error(t, msg.Validate())
+		})
+	}
+}
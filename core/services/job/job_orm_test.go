@@ -19,6 +19,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/bridges"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm"
 	evmcfg "github.com/smartcontractkit/chainlink/v2/core/chains/evm/config/v2"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
 	configtest "github.com/smartcontractkit/chainlink/v2/core/internal/testutils/configtest/v2"
@@ -29,12 +30,14 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services/blockheaderfeeder"
 	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/v2/core/services/directrequest"
+	"github.com/smartcontractkit/chainlink/v2/core/services/evmlog"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keeper"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/ethkey"
 	"github.com/smartcontractkit/chainlink/v2/core/services/ocr"
 	ocr2validate "github.com/smartcontractkit/chainlink/v2/core/services/ocr2/validate"
 	"github.com/smartcontractkit/chainlink/v2/core/services/ocrbootstrap"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
 	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/v2/core/services/vrf"
 	"github.com/smartcontractkit/chainlink/v2/core/services/webhook"
@@ -189,7 +192,7 @@ func TestORM(t *testing.T) {
 	t.Run("creates a job with a direct request spec", func(t *testing.T) {
 		tree, err := toml.LoadFile("../../testdata/tomlspecs/direct-request-spec.toml")
 		require.NoError(t, err)
-		jb, err := directrequest.ValidatedDirectRequestSpec(tree.String())
+		jb, err := directrequest.ValidatedDirectRequestSpec(cc, keyStore.Eth(), tree.String())
 		require.NoError(t, err)
 		err = orm.CreateJob(&jb)
 		require.NoError(t, err)
@@ -215,7 +218,9 @@ func TestORM(t *testing.T) {
 
 	t.Run("it creates and deletes records for blockhash store jobs", func(t *testing.T) {
 		jb, err := blockhashstore.ValidatedSpec(
-			testspecs.GenerateBlockhashStoreSpec(testspecs.BlockhashStoreSpecParams{}).Toml())
+			testspecs.GenerateBlockhashStoreSpec(testspecs.BlockhashStoreSpecParams{
+				CoordinatorOCR2VRFAddress: "0x2be990eE17832b59E0086534c5ea2459Aa75E38F",
+			}).Toml())
 		require.NoError(t, err)
 
 		err = orm.CreateJob(&jb)
@@ -227,6 +232,7 @@ func TestORM(t *testing.T) {
 		require.Equal(t, jb.BlockhashStoreSpec.ID, savedJob.BlockhashStoreSpec.ID)
 		require.Equal(t, jb.BlockhashStoreSpec.CoordinatorV1Address, savedJob.BlockhashStoreSpec.CoordinatorV1Address)
 		require.Equal(t, jb.BlockhashStoreSpec.CoordinatorV2Address, savedJob.BlockhashStoreSpec.CoordinatorV2Address)
+		require.Equal(t, jb.BlockhashStoreSpec.CoordinatorOCR2VRFAddress, savedJob.BlockhashStoreSpec.CoordinatorOCR2VRFAddress)
 		require.Equal(t, jb.BlockhashStoreSpec.WaitBlocks, savedJob.BlockhashStoreSpec.WaitBlocks)
 		require.Equal(t, jb.BlockhashStoreSpec.LookbackBlocks, savedJob.BlockhashStoreSpec.LookbackBlocks)
 		require.Equal(t, jb.BlockhashStoreSpec.BlockhashStoreAddress, savedJob.BlockhashStoreSpec.BlockhashStoreAddress)
@@ -269,6 +275,87 @@ func TestORM(t *testing.T) {
 		_, err = orm.FindJob(testutils.Context(t), jb.ID)
 		require.Error(t, err)
 	})
+
+	t.Run("it creates and deletes records for evmlog jobs", func(t *testing.T) {
+		jb, err := evmlog.ValidatedSpec(
+			testspecs.GenerateEVMLogSpec(testspecs.EVMLogSpecParams{
+				Topic1: []string{"0x000000000000000000000000000000000000000000000000000000000000002a"},
+			}).Toml())
+		require.NoError(t, err)
+
+		err = orm.CreateJob(&jb)
+		require.NoError(t, err)
+		savedJob, err := orm.FindJob(testutils.Context(t), jb.ID)
+		require.NoError(t, err)
+		require.Equal(t, jb.ID, savedJob.ID)
+		require.Equal(t, jb.Type, savedJob.Type)
+		require.Equal(t, jb.EVMLogSpec.ID, savedJob.EVMLogSpec.ID)
+		require.Equal(t, jb.EVMLogSpec.ContractAddress, savedJob.EVMLogSpec.ContractAddress)
+		require.Equal(t, jb.EVMLogSpec.EventABI, savedJob.EVMLogSpec.EventABI)
+		require.Equal(t, jb.EVMLogSpec.Topic1, savedJob.EVMLogSpec.Topic1)
+		require.Empty(t, savedJob.EVMLogSpec.Topic2)
+		require.Empty(t, savedJob.EVMLogSpec.Topic3)
+		require.Equal(t, jb.EVMLogSpec.MinConfirmations, savedJob.EVMLogSpec.MinConfirmations)
+		require.Equal(t, jb.EVMLogSpec.EVMChainID, savedJob.EVMLogSpec.EVMChainID)
+
+		logORM := evmlog.NewORM(db, logger.TestLogger(t), config)
+		block, err := logORM.FromBlock(jb.ID)
+		require.NoError(t, err)
+		require.Equal(t, int64(-1), block)
+		require.NoError(t, logORM.SetFromBlock(jb.ID, 10))
+		require.NoError(t, logORM.SetFromBlock(jb.ID, 11))
+		block, err = logORM.FromBlock(jb.ID)
+		require.NoError(t, err)
+		require.Equal(t, int64(11), block)
+
+		lg := logpoller.Log{BlockHash: utils.NewHash(), BlockNumber: 10, LogIndex: 1, TxHash: utils.NewHash()}
+		processed, err := logORM.IsLogProcessed(jb.ID, lg)
+		require.NoError(t, err)
+		require.False(t, processed)
+		inserted, err := logORM.MarkLogProcessed(jb.ID, lg)
+		require.NoError(t, err)
+		require.True(t, inserted)
+		inserted, err = logORM.MarkLogProcessed(jb.ID, lg)
+		require.NoError(t, err)
+		require.False(t, inserted)
+		processed, err = logORM.IsLogProcessed(jb.ID, lg)
+		require.NoError(t, err)
+		require.True(t, processed)
+
+		// The run is only inserted for logs that were not processed before.
+		var runInserted bool
+		lg2 := logpoller.Log{BlockHash: utils.NewHash(), BlockNumber: 11, LogIndex: 1, TxHash: utils.NewHash()}
+		inserted, err = logORM.MarkLogProcessedWithRun(jb.ID, lg2, func(pg.Queryer) error {
+			runInserted = true
+			return nil
+		})
+		require.NoError(t, err)
+		require.True(t, inserted)
+		require.True(t, runInserted)
+		runInserted = false
+		inserted, err = logORM.MarkLogProcessedWithRun(jb.ID, lg2, func(pg.Queryer) error {
+			runInserted = true
+			return nil
+		})
+		require.NoError(t, err)
+		require.False(t, inserted)
+		require.False(t, runInserted)
+
+		// A failed run insert rolls back the log.
+		lg3 := logpoller.Log{BlockHash: utils.NewHash(), BlockNumber: 12, LogIndex: 1, TxHash: utils.NewHash()}
+		_, err = logORM.MarkLogProcessedWithRun(jb.ID, lg3, func(pg.Queryer) error { return assert.AnError })
+		require.ErrorIs(t, err, assert.AnError)
+		processed, err = logORM.IsLogProcessed(jb.ID, lg3)
+		require.NoError(t, err)
+		require.False(t, processed)
+
+		err = orm.DeleteJob(jb.ID)
+		require.NoError(t, err)
+		_, err = orm.FindJob(testutils.Context(t), jb.ID)
+		require.Error(t, err)
+		cltest.AssertCount(t, db, "evm_log_processed_logs", 0)
+		cltest.AssertCount(t, db, "evm_log_from_blocks", 0)
+	})
 }
 
 func TestORM_DeleteJob_DeletesAssociatedRecords(t *testing.T) {
@@ -708,7 +795,7 @@ func Test_FindJobs(t *testing.T) {
 	err = orm.CreateJob(&jb1)
 	require.NoError(t, err)
 
-	jb2, err := directrequest.ValidatedDirectRequestSpec(
+	jb2, err := directrequest.ValidatedDirectRequestSpec(cc, keyStore.Eth(),
 		testspecs.DirectRequestSpec,
 	)
 	require.NoError(t, err)
@@ -914,7 +1001,7 @@ func Test_FindJobsByPipelineSpecIDs(t *testing.T) {
 	cc := evmtest.NewChainSet(t, evmtest.TestChainOpts{DB: db, GeneralConfig: config, KeyStore: keyStore.Eth()})
 	orm := NewTestORM(t, db, cc, pipelineORM, bridgesORM, keyStore, config)
 
-	jb, err := directrequest.ValidatedDirectRequestSpec(testspecs.DirectRequestSpec)
+	jb, err := directrequest.ValidatedDirectRequestSpec(cc, keyStore.Eth(), testspecs.DirectRequestSpec)
 	require.NoError(t, err)
 
 	err = orm.CreateJob(&jb)
@@ -1238,7 +1325,7 @@ func Test_FindPipelineRunByID(t *testing.T) {
 	cc := evmtest.NewChainSet(t, evmtest.TestChainOpts{DB: db, GeneralConfig: config, KeyStore: keyStore.Eth()})
 	orm := NewTestORM(t, db, cc, pipelineORM, bridgesORM, keyStore, config)
 
-	jb, err := directrequest.ValidatedDirectRequestSpec(testspecs.DirectRequestSpec)
+	jb, err := directrequest.ValidatedDirectRequestSpec(cc, keyStore.Eth(), testspecs.DirectRequestSpec)
 	require.NoError(t, err)
 
 	err = orm.CreateJob(&jb)
@@ -1282,7 +1369,7 @@ func Test_FindJobWithoutSpecErrors(t *testing.T) {
 	cc := evmtest.NewChainSet(t, evmtest.TestChainOpts{DB: db, GeneralConfig: config, KeyStore: keyStore.Eth()})
 	orm := NewTestORM(t, db, cc, pipelineORM, bridgesORM, keyStore, config)
 
-	jb, err := directrequest.ValidatedDirectRequestSpec(testspecs.DirectRequestSpec)
+	jb, err := directrequest.ValidatedDirectRequestSpec(cc, keyStore.Eth(), testspecs.DirectRequestSpec)
 	require.NoError(t, err)
 
 	err = orm.CreateJob(&jb)
@@ -1320,7 +1407,7 @@ func Test_FindSpecErrorsByJobIDs(t *testing.T) {
 	cc := evmtest.NewChainSet(t, evmtest.TestChainOpts{DB: db, GeneralConfig: config, KeyStore: keyStore.Eth()})
 	orm := NewTestORM(t, db, cc, pipelineORM, bridgesORM, keyStore, config)
 
-	jb, err := directrequest.ValidatedDirectRequestSpec(testspecs.DirectRequestSpec)
+	jb, err := directrequest.ValidatedDirectRequestSpec(cc, keyStore.Eth(), testspecs.DirectRequestSpec)
 	require.NoError(t, err)
 
 	err = orm.CreateJob(&jb)
@@ -39,6 +39,7 @@ const (
 	BlockHeaderFeeder  Type = (Type)(pipeline.BlockHeaderFeederJobType)
 	Webhook            Type = (Type)(pipeline.WebhookJobType)
 	Bootstrap          Type = (Type)(pipeline.BootstrapJobType)
+	EVMLog             Type = (Type)(pipeline.EVMLogJobType)
 )
 
 //revive:disable:redefines-builtin-id
@@ -73,6 +74,7 @@ var (
 		BlockhashStore:     false,
 		BlockHeaderFeeder:  false,
 		Bootstrap:          false,
+		EVMLog:             true,
 	}
 	supportsAsync = map[Type]bool{
 		Cron:               true,
@@ -86,6 +88,7 @@ var (
 		BlockhashStore:     false,
 		BlockHeaderFeeder:  false,
 		Bootstrap:          false,
+		EVMLog:             true,
 	}
 	schemaVersions = map[Type]uint32{
 		Cron:               1,
@@ -99,6 +102,7 @@ var (
 		BlockhashStore:     1,
 		BlockHeaderFeeder:  1,
 		Bootstrap:          1,
+		EVMLog:             1,
 	}
 )
 
@@ -127,6 +131,8 @@ type Job struct {
 	BlockHeaderFeederSpec   *BlockHeaderFeederSpec
 	BootstrapSpec           *BootstrapSpec
 	BootstrapSpecID         *int32
+	EVMLogSpecID            *int32
+	EVMLogSpec              *EVMLogSpec
 	PipelineSpecID          int32
 	PipelineSpec            *pipeline.Spec
 	JobSpecErrors           []SpecError
@@ -136,6 +142,7 @@ type Job struct {
 	ForwardingAllowed       bool          `toml:"forwardingAllowed"`
 	Name                    null.String
 	MaxTaskDuration         models.Interval
+	SLO                     *SLO              `toml:"slo"`
 	Pipeline                pipeline.Pipeline `toml:"observationSource"`
 	CreatedAt               time.Time
 }
@@ -209,6 +216,78 @@ func (pr *PipelineRun) SetID(value string) error {
 	return nil
 }
 
+// SLO defines the service level objectives of a job, which are evaluated
+// against its pipeline runs. Unset objectives are not evaluated.
+type SLO struct {
+	// MaxStaleness is the maximum time since the last successful run.
+	MaxStaleness *models.Interval `toml:"maxStaleness" json:"maxStaleness,omitempty"`
+	// MaxTransmissionStaleness is the maximum time since the last on-chain
+	// transmission of the job was confirmed.
+	MaxTransmissionStaleness *models.Interval `toml:"maxTransmissionStaleness" json:"maxTransmissionStaleness,omitempty"`
+	// MaxErrorRate is the maximum ratio of errored to finished runs in Window.
+	MaxErrorRate *float64 `toml:"maxErrorRate" json:"maxErrorRate,omitempty"`
+	// MaxP95Duration is the maximum 95th percentile of the duration of the
+	// runs finished in Window.
+	MaxP95Duration *models.Interval `toml:"maxP95Duration" json:"maxP95Duration,omitempty"`
+	// Window is the period over which the error rate and run durations are
+	// measured, DefaultSLOWindow if unset.
+	Window *models.Interval `toml:"window" json:"window,omitempty"`
+	// MinRuns is the minimum number of runs finished in Window for the error
+	// rate and run durations to be evaluated.
+	MinRuns uint32 `toml:"minRuns" json:"minRuns,omitempty"`
+	// WebhookURL, if set, is sent a POST request whenever the job becomes
+	// unhealthy or recovers.
+	WebhookURL *models.URL `toml:"webhookURL" json:"webhookURL,omitempty"`
+}
+
+// DefaultSLOWindow is the default Window of an SLO.
+const DefaultSLOWindow = time.Hour
+
+// WindowDuration returns the Window of the SLO, or DefaultSLOWindow if unset.
+func (s SLO) WindowDuration() time.Duration {
+	if s.Window == nil || s.Window.Duration() == 0 {
+		return DefaultSLOWindow
+	}
+	return s.Window.Duration()
+}
+
+// Validate returns an error if the SLO has no objective or an invalid one.
+func (s SLO) Validate() error {
+	if s.MaxStaleness == nil && s.MaxTransmissionStaleness == nil && s.MaxErrorRate == nil && s.MaxP95Duration == nil {
+		return errors.New("slo must set at least one of maxStaleness, maxTransmissionStaleness, maxErrorRate or maxP95Duration")
+	}
+	if s.MaxStaleness != nil && s.MaxStaleness.Duration() <= 0 {
+		return errors.New("slo maxStaleness must be positive")
+	}
+	if s.MaxTransmissionStaleness != nil && s.MaxTransmissionStaleness.Duration() <= 0 {
+		return errors.New("slo maxTransmissionStaleness must be positive")
+	}
+	if s.MaxErrorRate != nil && (*s.MaxErrorRate < 0 || *s.MaxErrorRate > 1) {
+		return errors.New("slo maxErrorRate must be between 0 and 1")
+	}
+	if s.MaxP95Duration != nil && s.MaxP95Duration.Duration() <= 0 {
+		return errors.New("slo maxP95Duration must be positive")
+	}
+	if s.WebhookURL != nil && s.WebhookURL.Scheme != "http" && s.WebhookURL.Scheme != "https" {
+		return errors.New("slo webhookURL must be an http or https URL")
+	}
+	return nil
+}
+
+// Value returns the SLO serialized for database storage.
+func (s SLO) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+// Scan reads the SLO from its database representation.
+func (s *SLO) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.Errorf("expected bytes got %T", value)
+	}
+	return json.Unmarshal(b, s)
+}
+
 // OCROracleSpec defines the job spec for OCR jobs.
 type OCROracleSpec struct {
 	ID                                        int32               `toml:"-"`
@@ -323,6 +402,9 @@ const (
 	OCR2Functions OCR2PluginType = "functions"
 
 	Mercury OCR2PluginType = "mercury"
+
+	// Attestation attests events of a source chain to a destination chain
+	Attestation OCR2PluginType = "attestation"
 )
 
 // OCR2OracleSpec defines the job spec for OCR2 jobs.
@@ -373,8 +455,14 @@ type ExternalInitiatorWebhookSpec struct {
 type WebhookSpec struct {
 	ID                            int32 `toml:"-"`
 	ExternalInitiatorWebhookSpecs []ExternalInitiatorWebhookSpec
-	CreatedAt                     time.Time `json:"createdAt" toml:"-"`
-	UpdatedAt                     time.Time `json:"updatedAt" toml:"-"`
+	// HMACSecret, if set, is the secret that the bodies of the requests
+	// triggering the job must be signed with.
+	HMACSecret null.String `json:"-" toml:"hmacSecret"`
+	// HMACTimestampTolerance is the maximum difference between the timestamp
+	// of a signed request and the current time.
+	HMACTimestampTolerance models.Interval `json:"hmacTimestampTolerance" toml:"hmacTimestampTolerance"`
+	CreatedAt              time.Time       `json:"createdAt" toml:"-"`
+	UpdatedAt              time.Time       `json:"updatedAt" toml:"-"`
 }
 
 func (w WebhookSpec) GetID() string {
@@ -398,15 +486,56 @@ type DirectRequestSpec struct {
 	Requesters                  models.AddressCollection `toml:"requesters"`
 	MinContractPayment          *assets.Link             `toml:"minContractPaymentLinkJuels"`
 	EVMChainID                  *utils.Big               `toml:"evmChainID"`
-	CreatedAt                   time.Time                `toml:"-"`
-	UpdatedAt                   time.Time                `toml:"-"`
-}
+	// MaxRequestGasLimit is the highest gas limit a request may set in its
+	// options. Requests setting a gas limit are rejected if it is not set.
+	MaxRequestGasLimit clnull.Uint32 `toml:"maxRequestGasLimit"`
+	// MaxRequestMinConfirmations is the highest number of confirmations a
+	// request may wait for. Requests asking for more confirmations than
+	// MinIncomingConfirmations are rejected if it is not set.
+	MaxRequestMinConfirmations clnull.Uint32 `toml:"maxRequestMinConfirmations"`
+	// RequestFromAddresses are the keys a request may choose to be fulfilled
+	// from.
+	RequestFromAddresses models.AddressCollection `toml:"requestFromAddresses"`
+	CreatedAt            time.Time                `toml:"-"`
+	UpdatedAt            time.Time                `toml:"-"`
+}
+
+// CronMisfirePolicy defines what a cron job does about the schedule ticks it
+// missed while the node was down.
+type CronMisfirePolicy string
+
+const (
+	// CronMisfireSkip ignores missed ticks.
+	CronMisfireSkip CronMisfirePolicy = "skip"
+	// CronMisfireRunOnce runs the job once if any tick was missed.
+	CronMisfireRunOnce CronMisfirePolicy = "runOnce"
+	// CronMisfireCatchUp runs the job once for every missed tick.
+	CronMisfireCatchUp CronMisfirePolicy = "catchUp"
+)
+
+// CronOverlapPolicy defines what a cron job does when a tick occurs while a
+// previous run is still in progress.
+type CronOverlapPolicy string
+
+const (
+	// CronOverlapAllow runs concurrently with the previous run.
+	CronOverlapAllow CronOverlapPolicy = "allow"
+	// CronOverlapSkip skips the tick.
+	CronOverlapSkip CronOverlapPolicy = "skip"
+	// CronOverlapQueue runs once the previous run has finished.
+	CronOverlapQueue CronOverlapPolicy = "queue"
+)
 
 type CronSpec struct {
-	ID           int32     `toml:"-"`
-	CronSchedule string    `toml:"schedule"`
-	CreatedAt    time.Time `toml:"-"`
-	UpdatedAt    time.Time `toml:"-"`
+	ID           int32  `toml:"-"`
+	CronSchedule string `toml:"schedule"`
+	// Timezone is the IANA time zone the schedule is interpreted in. It is
+	// an alternative to a CRON_TZ prefix in the schedule.
+	Timezone      string            `toml:"timezone"`
+	MisfirePolicy CronMisfirePolicy `toml:"misfirePolicy"`
+	OverlapPolicy CronOverlapPolicy `toml:"overlapPolicy"`
+	CreatedAt     time.Time         `toml:"-"`
+	UpdatedAt     time.Time         `toml:"-"`
 }
 
 func (s CronSpec) GetID() string {
@@ -439,8 +568,48 @@ type FluxMonitorSpec struct {
 	DrumbeatEnabled     bool
 	MinPayment          *assets.Link
 	EVMChainID          *utils.Big `toml:"evmChainID"`
-	CreatedAt           time.Time  `toml:"-"`
-	UpdatedAt           time.Time  `toml:"-"`
+	// Feeds are the aggregator contracts of a job watching several contracts
+	// with one pipeline. ContractAddress is the address of the first feed.
+	Feeds     FluxMonitorFeeds `toml:"feeds"`
+	CreatedAt time.Time        `toml:"-"`
+	UpdatedAt time.Time        `toml:"-"`
+}
+
+// ContractFeeds returns the feeds the job watches. A job without feeds
+// watches ContractAddress alone.
+func (s FluxMonitorSpec) ContractFeeds() FluxMonitorFeeds {
+	if len(s.Feeds) > 0 {
+		return s.Feeds
+	}
+	return FluxMonitorFeeds{{ContractAddress: s.ContractAddress}}
+}
+
+// FluxMonitorFeed is an aggregator contract of a flux monitor job, with the
+// params the pipeline of the job observes its answer with.
+type FluxMonitorFeed struct {
+	ContractAddress ethkey.EIP55Address    `toml:"contractAddress" json:"contractAddress"`
+	Params          map[string]interface{} `toml:"params" json:"params,omitempty"`
+}
+
+// FluxMonitorFeeds is a Go mapping for the JSON based feeds of a flux monitor
+// job.
+type FluxMonitorFeeds []FluxMonitorFeed
+
+// Value returns this instance serialized for database storage.
+func (f FluxMonitorFeeds) Value() (driver.Value, error) {
+	if f == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(f)
+}
+
+// Scan reads the database value and returns an instance.
+func (f *FluxMonitorFeeds) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.Errorf("expected bytes got %T", value)
+	}
+	return json.Unmarshal(b, f)
 }
 
 type KeeperSpec struct {
@@ -513,6 +682,10 @@ type BlockhashStoreSpec struct {
 	// no V2 coordinator will be watched.
 	CoordinatorV2Address *ethkey.EIP55Address `toml:"coordinatorV2Address"`
 
+	// CoordinatorOCR2VRFAddress is the OCR2VRF coordinator to watch for unfulfilled requests. If
+	// empty, no OCR2VRF coordinator will be watched.
+	CoordinatorOCR2VRFAddress *ethkey.EIP55Address `toml:"coordinatorOCR2VRFAddress"`
+
 	// LookbackBlocks defines the maximum age of blocks whose hashes should be stored.
 	LookbackBlocks int32 `toml:"lookbackBlocks"`
 
@@ -523,6 +696,18 @@ type BlockhashStoreSpec struct {
 	// into.
 	BlockhashStoreAddress ethkey.EIP55Address `toml:"blockhashStoreAddress"`
 
+	// TrustedBlockhashStoreAddress is the address of the TrustedBlockhashStore contract to store
+	// blockhashes into in batches. If set, it is used instead of BlockhashStoreAddress.
+	TrustedBlockhashStoreAddress *ethkey.EIP55Address `toml:"trustedBlockhashStoreAddress"`
+
+	// TrustedBlockhashStoreBatchSize is the maximum number of blockhashes to store in a single
+	// TrustedBlockhashStore transaction.
+	TrustedBlockhashStoreBatchSize int32 `toml:"trustedBlockhashStoreBatchSize"`
+
+	// TrustedBlockhashStoreGasLimit is the gas limit of a single TrustedBlockhashStore
+	// transaction. Batches are shrunk to fit within it.
+	TrustedBlockhashStoreGasLimit uint32 `toml:"trustedBlockhashStoreGasLimit"`
+
 	// PollPeriod defines how often recent blocks should be scanned for blockhash storage.
 	PollPeriod time.Duration `toml:"pollPeriod"`
 
@@ -624,3 +809,37 @@ func (s BootstrapSpec) AsOCR2Spec() OCR2OracleSpec {
 		P2PV2Bootstrappers:                pq.StringArray{},
 	}
 }
+
+// EVMLogSpec defines the spec of a job that runs its pipeline once for every
+// log emitted by a contract that matches an event and optional topic filters.
+type EVMLogSpec struct {
+	ID int32
+
+	// ContractAddress is the address of the contract emitting the logs.
+	ContractAddress ethkey.EIP55Address `toml:"contractAddress"`
+
+	// EventABI is the signature of the event, in the format used by the
+	// ethabidecodelog task, e.g.
+	// "Transfer(address indexed from, address indexed to, uint256 value)".
+	EventABI string `toml:"eventABI"`
+
+	// Topic1, Topic2 and Topic3 optionally restrict the corresponding indexed
+	// topic of the logs to one of the given values. An empty filter matches
+	// any value.
+	Topic1 []common.Hash `toml:"topic1"`
+	Topic2 []common.Hash `toml:"topic2"`
+	Topic3 []common.Hash `toml:"topic3"`
+
+	// MinConfirmations is the number of blocks a log must be buried under
+	// before the pipeline is run for it.
+	MinConfirmations uint32 `toml:"minConfirmations"`
+
+	// EVMChainID is the chain the logs are read from.
+	EVMChainID *utils.Big `toml:"evmChainID"`
+
+	// CreatedAt is the time this job was created.
+	CreatedAt time.Time `toml:"-"`
+
+	// UpdatedAt is the time this job was last updated.
+	UpdatedAt time.Time `toml:"-"`
+}
@@ -161,8 +161,10 @@ func (o *orm) CreateJob(jb *Job, qopts ...pg.QOpt) error {
 		switch jb.Type {
 		case DirectRequest:
 			var specID int32
-			sql := `INSERT INTO direct_request_specs (contract_address, min_incoming_confirmations, requesters, min_contract_payment, evm_chain_id, created_at, updated_at)
-			VALUES (:contract_address, :min_incoming_confirmations, :requesters, :min_contract_payment, :evm_chain_id, now(), now())
+			sql := `INSERT INTO direct_request_specs (contract_address, min_incoming_confirmations, requesters, min_contract_payment, evm_chain_id,
+					max_request_gas_limit, max_request_min_confirmations, request_from_addresses, created_at, updated_at)
+			VALUES (:contract_address, :min_incoming_confirmations, :requesters, :min_contract_payment, :evm_chain_id,
+					:max_request_gas_limit, :max_request_min_confirmations, :request_from_addresses, now(), now())
 			RETURNING id;`
 			if err := pg.PrepareQueryRowx(tx, sql, &specID, jb.DirectRequestSpec); err != nil {
 				return errors.Wrap(err, "failed to create DirectRequestSpec")
@@ -171,9 +173,9 @@ func (o *orm) CreateJob(jb *Job, qopts ...pg.QOpt) error {
 		case FluxMonitor:
 			var specID int32
 			sql := `INSERT INTO flux_monitor_specs (contract_address, threshold, absolute_threshold, poll_timer_period, poll_timer_disabled, idle_timer_period, idle_timer_disabled,
-					drumbeat_schedule, drumbeat_random_delay, drumbeat_enabled, min_payment, evm_chain_id, created_at, updated_at)
+					drumbeat_schedule, drumbeat_random_delay, drumbeat_enabled, min_payment, evm_chain_id, feeds, created_at, updated_at)
 			VALUES (:contract_address, :threshold, :absolute_threshold, :poll_timer_period, :poll_timer_disabled, :idle_timer_period, :idle_timer_disabled,
-					:drumbeat_schedule, :drumbeat_random_delay, :drumbeat_enabled, :min_payment, :evm_chain_id, NOW(), NOW())
+					:drumbeat_schedule, :drumbeat_random_delay, :drumbeat_enabled, :min_payment, :evm_chain_id, :feeds, NOW(), NOW())
 			RETURNING id;`
 			if err := pg.PrepareQueryRowx(tx, sql, &specID, jb.FluxMonitorSpec); err != nil {
 				return errors.Wrap(err, "failed to create FluxMonitorSpec")
@@ -320,8 +322,8 @@ func (o *orm) CreateJob(jb *Job, qopts ...pg.QOpt) error {
 			jb.KeeperSpecID = &specID
 		case Cron:
 			var specID int32
-			sql := `INSERT INTO cron_specs (cron_schedule, created_at, updated_at)
-			VALUES (:cron_schedule, NOW(), NOW())
+			sql := `INSERT INTO cron_specs (cron_schedule, timezone, misfire_policy, overlap_policy, created_at, updated_at)
+			VALUES (:cron_schedule, :timezone, :misfire_policy, :overlap_policy, NOW(), NOW())
 			RETURNING id;`
 			if err := pg.PrepareQueryRowx(tx, sql, &specID, jb.CronSpec); err != nil {
 				return errors.Wrap(err, "failed to create CronSpec")
@@ -378,8 +380,8 @@ func (o *orm) CreateJob(jb *Job, qopts ...pg.QOpt) error {
 			}
 		case BlockhashStore:
 			var specID int32
-			sql := `INSERT INTO blockhash_store_specs (coordinator_v1_address, coordinator_v2_address, wait_blocks, lookback_blocks, blockhash_store_address, poll_period, run_timeout, evm_chain_id, from_addresses, created_at, updated_at)
-			VALUES (:coordinator_v1_address, :coordinator_v2_address, :wait_blocks, :lookback_blocks, :blockhash_store_address, :poll_period, :run_timeout, :evm_chain_id, :from_addresses, NOW(), NOW())
+			sql := `INSERT INTO blockhash_store_specs (coordinator_v1_address, coordinator_v2_address, coordinator_ocr2vrf_address, wait_blocks, lookback_blocks, blockhash_store_address, trusted_blockhash_store_address, trusted_blockhash_store_batch_size, trusted_blockhash_store_gas_limit, poll_period, run_timeout, evm_chain_id, from_addresses, created_at, updated_at)
+			VALUES (:coordinator_v1_address, :coordinator_v2_address, :coordinator_ocr2vrf_address, :wait_blocks, :lookback_blocks, :blockhash_store_address, :trusted_blockhash_store_address, :trusted_blockhash_store_batch_size, :trusted_blockhash_store_gas_limit, :poll_period, :run_timeout, :evm_chain_id, :from_addresses, NOW(), NOW())
 			RETURNING id;`
 			if err := pg.PrepareQueryRowx(tx, sql, &specID, toBlockhashStoreSpecRow(jb.BlockhashStoreSpec)); err != nil {
 				return errors.Wrap(err, "failed to create BlockhashStore spec")
@@ -407,6 +409,15 @@ func (o *orm) CreateJob(jb *Job, qopts ...pg.QOpt) error {
 				return errors.Wrap(err, "failed to create BootstrapSpec for jobSpec")
 			}
 			jb.BootstrapSpecID = &specID
+		case EVMLog:
+			var specID int32
+			sql := `INSERT INTO evm_log_specs (contract_address, event_abi, topic1, topic2, topic3, min_confirmations, evm_chain_id, created_at, updated_at)
+			VALUES (:contract_address, :event_abi, :topic1, :topic2, :topic3, :min_confirmations, :evm_chain_id, NOW(), NOW())
+			RETURNING id;`
+			if err := pg.PrepareQueryRowx(tx, sql, &specID, toEVMLogSpecRow(jb.EVMLogSpec)); err != nil {
+				return errors.Wrap(err, "failed to create EVMLog spec")
+			}
+			jb.EVMLogSpecID = &specID
 		default:
 			o.lggr.Panicf("Unsupported jb.Type: %v", jb.Type)
 		}
@@ -431,8 +442,8 @@ func (o *orm) CreateJob(jb *Job, qopts ...pg.QOpt) error {
 
 func (o *orm) InsertWebhookSpec(webhookSpec *WebhookSpec, qopts ...pg.QOpt) error {
 	q := o.q.WithOpts(qopts...)
-	query := `INSERT INTO webhook_specs (created_at, updated_at)
-			VALUES (NOW(), NOW())
+	query := `INSERT INTO webhook_specs (hmac_secret, hmac_timestamp_tolerance, created_at, updated_at)
+			VALUES (:hmac_secret, :hmac_timestamp_tolerance, NOW(), NOW())
 			RETURNING *;`
 	return q.GetNamed(query, webhookSpec, webhookSpec)
 }
@@ -444,15 +455,15 @@ func (o *orm) InsertJob(job *Job, qopts ...pg.QOpt) error {
 	// if job has id, emplace otherwise insert with a new id.
 	if job.ID == 0 {
 		query = `INSERT INTO jobs (pipeline_spec_id, name, schema_version, type, max_task_duration, ocr_oracle_spec_id, ocr2_oracle_spec_id, direct_request_spec_id, flux_monitor_spec_id,
-				keeper_spec_id, cron_spec_id, vrf_spec_id, webhook_spec_id, blockhash_store_spec_id, bootstrap_spec_id, block_header_feeder_spec_id, external_job_id, gas_limit, forwarding_allowed, created_at)
+				keeper_spec_id, cron_spec_id, vrf_spec_id, webhook_spec_id, blockhash_store_spec_id, bootstrap_spec_id, block_header_feeder_spec_id, evm_log_spec_id, external_job_id, gas_limit, forwarding_allowed, slo, created_at)
 		VALUES (:pipeline_spec_id, :name, :schema_version, :type, :max_task_duration, :ocr_oracle_spec_id, :ocr2_oracle_spec_id, :direct_request_spec_id, :flux_monitor_spec_id,
-				:keeper_spec_id, :cron_spec_id, :vrf_spec_id, :webhook_spec_id, :blockhash_store_spec_id, :bootstrap_spec_id, :block_header_feeder_spec_id, :external_job_id, :gas_limit, :forwarding_allowed, NOW())
+				:keeper_spec_id, :cron_spec_id, :vrf_spec_id, :webhook_spec_id, :blockhash_store_spec_id, :bootstrap_spec_id, :block_header_feeder_spec_id, :evm_log_spec_id, :external_job_id, :gas_limit, :forwarding_allowed, :slo, NOW())
 		RETURNING *;`
 	} else {
 		query = `INSERT INTO jobs (id, pipeline_spec_id, name, schema_version, type, max_task_duration, ocr_oracle_spec_id, ocr2_oracle_spec_id, direct_request_spec_id, flux_monitor_spec_id,
-			keeper_spec_id, cron_spec_id, vrf_spec_id, webhook_spec_id, blockhash_store_spec_id, bootstrap_spec_id, block_header_feeder_spec_id, external_job_id, gas_limit, forwarding_allowed, created_at)
+			keeper_spec_id, cron_spec_id, vrf_spec_id, webhook_spec_id, blockhash_store_spec_id, bootstrap_spec_id, block_header_feeder_spec_id, evm_log_spec_id, external_job_id, gas_limit, forwarding_allowed, slo, created_at)
 	VALUES (:id, :pipeline_spec_id, :name, :schema_version, :type, :max_task_duration, :ocr_oracle_spec_id, :ocr2_oracle_spec_id, :direct_request_spec_id, :flux_monitor_spec_id,
-			:keeper_spec_id, :cron_spec_id, :vrf_spec_id, :webhook_spec_id, :blockhash_store_spec_id, :bootstrap_spec_id, :block_header_feeder_spec_id, :external_job_id, :gas_limit, :forwarding_allowed, NOW())
+			:keeper_spec_id, :cron_spec_id, :vrf_spec_id, :webhook_spec_id, :blockhash_store_spec_id, :bootstrap_spec_id, :block_header_feeder_spec_id, :evm_log_spec_id, :external_job_id, :gas_limit, :forwarding_allowed, :slo, NOW())
 	RETURNING *;`
 	}
 	return q.GetNamed(query, job, job)
@@ -480,7 +491,8 @@ func (o *orm) DeleteJob(id int32, qopts ...pg.QOpt) error {
 				direct_request_spec_id,
 				blockhash_store_spec_id,
 				bootstrap_spec_id,
-				block_header_feeder_spec_id
+				block_header_feeder_spec_id,
+				evm_log_spec_id
 		),
 		deleted_oracle_specs AS (
 			DELETE FROM ocr_oracle_specs WHERE id IN (SELECT ocr_oracle_spec_id FROM deleted_jobs)
@@ -514,6 +526,9 @@ func (o *orm) DeleteJob(id int32, qopts ...pg.QOpt) error {
 		),
 		deleted_block_header_feeder_specs AS (
 			DELETE FROM block_header_feeder_specs WHERE id IN (SELECT block_header_feeder_spec_id FROM deleted_jobs)
+		),
+		deleted_evm_log_specs AS (
+			DELETE FROM evm_log_specs WHERE id IN (SELECT evm_log_spec_id FROM deleted_jobs)
 		)
 		DELETE FROM pipeline_specs WHERE id IN (SELECT pipeline_spec_id FROM deleted_jobs)`
 	res, cancel, err := q.ExecQIter(query, id)
@@ -801,10 +816,10 @@ func (o *orm) FindJobIDByAddress(address ethkey.EIP55Address, evmChainID *utils.
 SELECT jobs.id
 FROM jobs
 LEFT JOIN ocr_oracle_specs ocrspec on ocrspec.contract_address = $1 AND (ocrspec.evm_chain_id = $2 OR ocrspec.evm_chain_id IS NULL) AND ocrspec.id = jobs.ocr_oracle_spec_id
-LEFT JOIN flux_monitor_specs fmspec on fmspec.contract_address = $1 AND (fmspec.evm_chain_id = $2 OR fmspec.evm_chain_id IS NULL) AND fmspec.id = jobs.flux_monitor_spec_id
+LEFT JOIN flux_monitor_specs fmspec on (fmspec.contract_address = $1 OR fmspec.feeds @> jsonb_build_array(jsonb_build_object('contractAddress', $3::text))) AND (fmspec.evm_chain_id = $2 OR fmspec.evm_chain_id IS NULL) AND fmspec.id = jobs.flux_monitor_spec_id
 WHERE ocrspec.id IS NOT NULL OR fmspec.id IS NOT NULL
 `
-		err = tx.Get(&jobID, stmt, address, evmChainID)
+		err = tx.Get(&jobID, stmt, address, evmChainID, address.String())
 
 		if !errors.Is(err, sql.ErrNoRows) {
 			if err != nil {
@@ -1188,6 +1203,7 @@ func LoadAllJobTypes(tx pg.Queryer, job *Job) error {
 		loadBlockhashStoreJob(tx, job, job.BlockhashStoreSpecID),
 		loadBlockHeaderFeederJob(tx, job, job.BlockHeaderFeederSpecID),
 		loadJobType(tx, job, "BootstrapSpec", "bootstrap_specs", job.BootstrapSpecID),
+		loadEVMLogJob(tx, job, job.EVMLogSpecID),
 	)
 }
 
@@ -1330,6 +1346,63 @@ func (r blockHeaderFeederSpecRow) toBlockHeaderFeederSpec() *BlockHeaderFeederSp
 	return r.BlockHeaderFeederSpec
 }
 
+func loadEVMLogJob(tx pg.Queryer, job *Job, id *int32) error {
+	if id == nil {
+		return nil
+	}
+
+	var row evmLogSpecRow
+	err := tx.Get(&row, `SELECT * FROM evm_log_specs WHERE id = $1`, *id)
+	if err != nil {
+		return errors.Wrapf(err, `failed to load job type EVMLogSpec with id %d`, *id)
+	}
+
+	job.EVMLogSpec = row.toEVMLogSpec()
+	return nil
+}
+
+// evmLogSpecRow is a helper type for reading and writing EVMLog specs to the database. This is
+// necessary because the bytea[] in the DB is not automatically convertible to or from the spec's
+// topic filters. pq.ByteaArray must be used instead.
+type evmLogSpecRow struct {
+	*EVMLogSpec
+	Topic1 pq.ByteaArray
+	Topic2 pq.ByteaArray
+	Topic3 pq.ByteaArray
+}
+
+func toEVMLogSpecRow(spec *EVMLogSpec) evmLogSpecRow {
+	return evmLogSpecRow{
+		EVMLogSpec: spec,
+		Topic1:     hashesToByteaArray(spec.Topic1),
+		Topic2:     hashesToByteaArray(spec.Topic2),
+		Topic3:     hashesToByteaArray(spec.Topic3),
+	}
+}
+
+func (r evmLogSpecRow) toEVMLogSpec() *EVMLogSpec {
+	r.EVMLogSpec.Topic1 = byteaArrayToHashes(r.Topic1)
+	r.EVMLogSpec.Topic2 = byteaArrayToHashes(r.Topic2)
+	r.EVMLogSpec.Topic3 = byteaArrayToHashes(r.Topic3)
+	return r.EVMLogSpec
+}
+
+func hashesToByteaArray(hashes []common.Hash) pq.ByteaArray {
+	arr := make(pq.ByteaArray, len(hashes))
+	for i, h := range hashes {
+		arr[i] = h.Bytes()
+	}
+	return arr
+}
+
+func byteaArrayToHashes(arr pq.ByteaArray) []common.Hash {
+	var hashes []common.Hash
+	for _, b := range arr {
+		hashes = append(hashes, common.BytesToHash(b))
+	}
+	return hashes
+}
+
 func loadJobSpecErrors(tx pg.Queryer, jb *Job) error {
 	return errors.Wrapf(tx.Select(&jb.JobSpecErrors, `SELECT * FROM job_spec_errors WHERE job_id = $1`, jb.ID), "failed to load job spec errors for job %d", jb.ID)
 }
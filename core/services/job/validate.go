@@ -23,6 +23,7 @@ var (
 		BlockhashStore:     {},
 		Bootstrap:          {},
 		BlockHeaderFeeder:  {},
+		EVMLog:             {},
 	}
 )
 
@@ -57,6 +58,11 @@ func ValidateSpec(ts string) (Type, error) {
 	if jb.Type.RequiresPipelineSpec() && (jb.Pipeline.Source == "") {
 		return "", ErrNoPipelineSpec
 	}
+	if jb.SLO != nil {
+		if err = jb.SLO.Validate(); err != nil {
+			return "", err
+		}
+	}
 	if jb.Pipeline.RequiresPreInsert() && !jb.Type.SupportsAsync() {
 		return "", errors.Errorf("async=true tasks are not supported for %v", jb.Type)
 	}
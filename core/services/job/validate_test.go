@@ -90,6 +90,72 @@ ds [type=bridge async=true]
 				require.Error(t, err)
 			},
 		},
+		{
+			name: "slo without objectives",
+			spec: `
+type="vrf"
+schemaVersion=1
+observationSource="""
+ds [type=http]
+"""
+[slo]
+window="10m"
+`,
+			assertion: func(t *testing.T, err error) {
+				require.EqualError(t, err, "slo must set at least one of maxStaleness, maxTransmissionStaleness, maxErrorRate or maxP95Duration")
+			},
+		},
+		{
+			name: "slo with invalid error rate",
+			spec: `
+type="vrf"
+schemaVersion=1
+observationSource="""
+ds [type=http]
+"""
+[slo]
+maxErrorRate=1.5
+`,
+			assertion: func(t *testing.T, err error) {
+				require.EqualError(t, err, "slo maxErrorRate must be between 0 and 1")
+			},
+		},
+		{
+			name: "slo with invalid webhook",
+			spec: `
+type="vrf"
+schemaVersion=1
+observationSource="""
+ds [type=http]
+"""
+[slo]
+maxStaleness="1h"
+webhookURL="ftp://example.com"
+`,
+			assertion: func(t *testing.T, err error) {
+				require.EqualError(t, err, "slo webhookURL must be an http or https URL")
+			},
+		},
+		{
+			name: "slo",
+			spec: `
+type="vrf"
+schemaVersion=1
+observationSource="""
+ds [type=http]
+"""
+[slo]
+maxStaleness="1h"
+maxErrorRate=0.1
+maxP95Duration="30s"
+window="6h"
+minRuns=10
+webhookURL="https://example.com/alerts"
+`,
+			assertion: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
 		{
 			name: "happy path",
 			spec: `
@@ -331,7 +331,7 @@ func (d *Delegate) ServicesForSpec(jb job.Job) (services []job.ServiceCtx, err e
 			d.pipelineRunner,
 			make(chan struct{}),
 			lggr,
-			cfg.JobPipelineMaxSuccessfulRuns(),
+			cfg.JobPipelineMaxSuccessfulRunsForJobType(string(jb.Type)),
 		)}, services...)
 	}
 
@@ -24,6 +24,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/attestation"
 	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/dkg"
 	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/dkg/persistence"
 	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/functions"
@@ -173,6 +174,22 @@ func (d *Delegate) OnDeleteJob(jb job.Job, q pg.Queryer) error {
 		if err != nil {
 			d.lggr.Errorw("failed to derive ocr2keeper filter names from spec", "err", err, "spec", spec)
 		}
+	case job.Attestation:
+		// The events are observed on the source chain, which may not be the
+		// chain of the relay.
+		pluginConfig, err2 := attestation.ParsePluginConfig(spec)
+		if err2 != nil {
+			d.lggr.Errorw("failed to parse attestation plugin config from spec", "err", err2, "spec", spec)
+			break
+		}
+		sourceChain, err2 := d.chainSet.Get(pluginConfig.SourceChainID.ToInt())
+		if err2 != nil {
+			d.lggr.Errorw("failed to get attestation source chain", "err", err2, "spec", spec)
+			break
+		}
+		if err2 = sourceChain.LogPoller().UnregisterFilter(attestation.FilterName(spec.ID), q); err2 != nil {
+			return errors.Wrapf(err2, "Failed to unregister filter %s", attestation.FilterName(spec.ID))
+		}
 	default:
 		return nil
 	}
@@ -395,7 +412,7 @@ func (d *Delegate) ServicesForSpec(jb job.Job) ([]job.ServiceCtx, error) {
 		}
 		errorLog := &errorLog{jobID: jb.ID, recordError: d.jobORM.RecordError}
 		enhancedTelemChan := make(chan ocrcommon.EnhancedTelemetryData, 100)
-		mConfig := median.NewMedianConfig(d.cfg.JobPipelineMaxSuccessfulRuns(), d.cfg)
+		mConfig := median.NewMedianConfig(d.cfg.JobPipelineMaxSuccessfulRunsForJobType(string(jb.Type)), d.cfg)
 
 		medianServices, err2 := median.NewMedianServices(ctx, jb, d.isNewlyCreatedJob, relayer, d.pipelineRunner, runResults, lggr, oracleArgsNoPlugin, mConfig, enhancedTelemChan, errorLog)
 
@@ -622,7 +639,7 @@ func (d *Delegate) ServicesForSpec(jb job.Job) ([]job.ServiceCtx, error) {
 			d.pipelineRunner,
 			make(chan struct{}),
 			lggr,
-			d.cfg.JobPipelineMaxSuccessfulRuns(),
+			d.cfg.JobPipelineMaxSuccessfulRunsForJobType(string(jb.Type)),
 		)
 
 		// NOTE: we return from here with the services because the OCR2VRF oracles are defined
@@ -636,10 +653,6 @@ func (d *Delegate) ServicesForSpec(jb job.Job) ([]job.ServiceCtx, error) {
 			return nil, errors.Wrap(err2, "failed to get mercury credential name")
 		}
 		mc := d.cfg.MercuryCredentials(credName)
-		keeperProvider, rgstry, encoder, logProvider, err2 := ocr2keeper.EVMDependencies(jb, d.db, lggr, d.chainSet, d.pipelineRunner, mc)
-		if err2 != nil {
-			return nil, errors.Wrap(err2, "could not build dependencies for ocr2 keepers")
-		}
 
 		var cfg ocr2keeper.PluginConfig
 		err2 = json.Unmarshal(spec.PluginConfig.Bytes(), &cfg)
@@ -652,6 +665,11 @@ func (d *Delegate) ServicesForSpec(jb job.Job) ([]job.ServiceCtx, error) {
 			return nil, errors.Wrap(err2, "ocr2keepers plugin config validation failure")
 		}
 
+		keeperProvider, rgstry, encoder, logProvider, err2 := ocr2keeper.EVMDependencies(jb, d.db, lggr, d.chainSet, d.pipelineRunner, mc, cfg)
+		if err2 != nil {
+			return nil, errors.Wrap(err2, "could not build dependencies for ocr2 keepers")
+		}
+
 		conf := ocr2keepers.DelegateConfig{
 			BinaryNetworkEndpointFactory: peerWrapper.Peer2,
 			V2Bootstrappers:              bootstrapPeers,
@@ -686,7 +704,7 @@ func (d *Delegate) ServicesForSpec(jb job.Job) ([]job.ServiceCtx, error) {
 			d.pipelineRunner,
 			make(chan struct{}),
 			lggr,
-			d.cfg.JobPipelineMaxSuccessfulRuns(),
+			d.cfg.JobPipelineMaxSuccessfulRunsForJobType(string(jb.Type)),
 		)
 
 		return []job.ServiceCtx{
@@ -771,10 +789,52 @@ func (d *Delegate) ServicesForSpec(jb job.Job) ([]job.ServiceCtx, error) {
 			d.pipelineRunner,
 			make(chan struct{}),
 			lggr,
-			d.cfg.JobPipelineMaxSuccessfulRuns(),
+			d.cfg.JobPipelineMaxSuccessfulRunsForJobType(string(jb.Type)),
 		)
 
 		return append([]job.ServiceCtx{runResultSaver, functionsProvider}, functionsServices...), nil
+	case job.Attestation:
+		if spec.Relay != relay.EVM {
+			return nil, fmt.Errorf("unsupported relay: %s", spec.Relay)
+		}
+		attestationProvider, err2 := evmrelay.NewAttestationProvider(
+			d.chainSet,
+			types.RelayArgs{
+				ExternalJobID: jb.ExternalJobID,
+				JobID:         spec.ID,
+				ContractID:    spec.ContractID,
+				RelayConfig:   spec.RelayConfig.Bytes(),
+				New:           d.isNewlyCreatedJob,
+			},
+			types.PluginArgs{
+				TransmitterID: transmitterID,
+				PluginConfig:  spec.PluginConfig.Bytes(),
+			},
+			lggr.Named("AttestationRelayer"),
+			d.ethKs,
+		)
+		if err2 != nil {
+			return nil, err2
+		}
+
+		oracleArgsNoPlugin := libocr2.OracleArgs{
+			BinaryNetworkEndpointFactory: peerWrapper.Peer2,
+			V2Bootstrappers:              bootstrapPeers,
+			ContractTransmitter:          attestationProvider.ContractTransmitter(),
+			ContractConfigTracker:        attestationProvider.ContractConfigTracker(),
+			Database:                     ocrDB,
+			LocalConfig:                  lc,
+			Logger:                       ocrLogger,
+			MonitoringEndpoint:           d.monitoringEndpointGen.GenMonitoringEndpoint(spec.ContractID, synchronization.OCR2Attestation),
+			OffchainConfigDigester:       attestationProvider.OffchainConfigDigester(),
+			OffchainKeyring:              kb,
+			OnchainKeyring:               kb,
+		}
+		attestationServices, err2 := attestation.NewAttestationServices(jb, d.chainSet, lggr.Named("Attestation"), oracleArgsNoPlugin)
+		if err2 != nil {
+			return nil, err2
+		}
+		return append([]job.ServiceCtx{attestationProvider}, attestationServices...), nil
 	default:
 		return nil, errors.Errorf("plugin type %s not supported", spec.PluginType)
 	}
@@ -28,13 +28,13 @@ func (_m *Config) DatabaseDefaultQueryTimeout() time.Duration {
 	return r0
 }
 
-// JobPipelineMaxSuccessfulRuns provides a mock function with given fields:
-func (_m *Config) JobPipelineMaxSuccessfulRuns() uint64 {
-	ret := _m.Called()
+// JobPipelineMaxSuccessfulRunsForJobType provides a mock function with given fields: jobType
+func (_m *Config) JobPipelineMaxSuccessfulRunsForJobType(jobType string) uint64 {
+	ret := _m.Called(jobType)
 
 	var r0 uint64
-	if rf, ok := ret.Get(0).(func() uint64); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(string) uint64); ok {
+		r0 = rf(jobType)
 	} else {
 		r0 = ret.Get(0).(uint64)
 	}
@@ -0,0 +1,116 @@
+package attestation
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+// Event is an event observed on the source chain.
+type Event struct {
+	Address     common.Address
+	Topics      []common.Hash
+	Data        []byte
+	BlockNumber uint64
+	BlockHash   common.Hash
+	TxHash      common.Hash
+	LogIndex    uint64
+}
+
+// eventID identifies an event independently of its content, so that
+// reorged events can be told apart from new ones.
+type eventID struct {
+	txHash   common.Hash
+	logIndex uint64
+}
+
+func (e Event) id() eventID {
+	return eventID{e.TxHash, e.LogIndex}
+}
+
+// before orders events by their position in the source chain.
+func (e Event) before(o Event) bool {
+	if e.BlockNumber != o.BlockNumber {
+		return e.BlockNumber < o.BlockNumber
+	}
+	return e.LogIndex < o.LogIndex
+}
+
+// eventType is the ABI type of the events in observations and reports:
+// (address emitter, bytes32[] topics, bytes data, uint64 blockNumber,
+// bytes32 blockHash, bytes32 txHash, uint64 logIndex)[]
+var eventType = mustNewType("tuple[]", []abi.ArgumentMarshaling{
+	{Name: "address", Type: "address"},
+	{Name: "topics", Type: "bytes32[]"},
+	{Name: "data", Type: "bytes"},
+	{Name: "blockNumber", Type: "uint64"},
+	{Name: "blockHash", Type: "bytes32"},
+	{Name: "txHash", Type: "bytes32"},
+	{Name: "logIndex", Type: "uint64"},
+})
+
+// reportArgs are the arguments of a report: abi.encode(uint256
+// sourceChainID, Event[] events). Observations use the same encoding.
+var reportArgs = abi.Arguments{
+	{Name: "sourceChainID", Type: mustNewType("uint256", nil)},
+	{Name: "events", Type: eventType},
+}
+
+func mustNewType(t string, components []abi.ArgumentMarshaling) abi.Type {
+	result, err := abi.NewType(t, "", components)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// EncodeEvents encodes the events of the source chain for observations and
+// reports.
+func EncodeEvents(sourceChainID *big.Int, events []Event) ([]byte, error) {
+	if events == nil {
+		events = []Event{}
+	}
+	return reportArgs.Pack(sourceChainID, events)
+}
+
+// DecodeEvents decodes observations and reports encoded with EncodeEvents.
+func DecodeEvents(raw []byte) (*big.Int, []Event, error) {
+	values, err := reportArgs.Unpack(raw)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to unpack events")
+	}
+	sourceChainID := *abi.ConvertType(values[0], new(*big.Int)).(**big.Int)
+	events := *abi.ConvertType(values[1], new([]Event)).(*[]Event)
+	return sourceChainID, events, nil
+}
+
+// eventHash is the hash of the content of an event, used to count the
+// oracles that observed identical events.
+func eventHash(e Event) common.Hash {
+	var b []byte
+	b = append(b, e.Address[:]...)
+	b = append(b, common.BigToHash(new(big.Int).SetUint64(e.BlockNumber)).Bytes()...)
+	b = append(b, e.BlockHash[:]...)
+	b = append(b, e.TxHash[:]...)
+	b = append(b, common.BigToHash(new(big.Int).SetUint64(e.LogIndex)).Bytes()...)
+	b = append(b, common.BigToHash(big.NewInt(int64(len(e.Topics)))).Bytes()...)
+	for _, t := range e.Topics {
+		b = append(b, t[:]...)
+	}
+	b = append(b, e.Data...)
+	return crypto.Keccak256Hash(b)
+}
+
+// maxEncodedLength is the maximum length of observations and reports of
+// maxEvents events with at most maxDataBytes of data each.
+func maxEncodedLength(maxEvents, maxDataBytes int) int {
+	// source chain ID, events offset and length
+	const header = 3 * 32
+	// offset, static fields and offsets, topics length and four topics, data length
+	const perEvent = 32 + 7*32 + 32 + 4*32 + 32
+	paddedData := (maxDataBytes + 31) / 32 * 32
+	return header + maxEvents*(perEvent+paddedData)
+}
@@ -0,0 +1,70 @@
+package attestation
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEvent(blockNumber, logIndex uint64, dataLen int) Event {
+	return Event{
+		Address:     common.HexToAddress("0x1"),
+		Topics:      []common.Hash{common.HexToHash("0xa"), common.HexToHash("0xb"), common.HexToHash("0xc"), common.HexToHash("0xd")},
+		Data:        make([]byte, dataLen),
+		BlockNumber: blockNumber,
+		BlockHash:   common.BigToHash(new(big.Int).SetUint64(blockNumber)),
+		TxHash:      common.BigToHash(new(big.Int).SetUint64(blockNumber*1000 + logIndex)),
+		LogIndex:    logIndex,
+	}
+}
+
+func TestEncodeEvents(t *testing.T) {
+	t.Parallel()
+
+	events := []Event{newEvent(10, 1, 3), newEvent(11, 0, 0)}
+	events[0].Data = []byte{1, 2, 3}
+	events[1].Topics = events[1].Topics[:1]
+
+	raw, err := EncodeEvents(big.NewInt(1337), events)
+	require.NoError(t, err)
+
+	chainID, decoded, err := DecodeEvents(raw)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1337), chainID)
+	require.Len(t, decoded, 2)
+	assert.Equal(t, events[0], decoded[0])
+	assert.Equal(t, events[1].Topics, decoded[1].Topics)
+	assert.Equal(t, events[1].TxHash, decoded[1].TxHash)
+
+	raw, err = EncodeEvents(big.NewInt(1), nil)
+	require.NoError(t, err)
+	_, decoded, err = DecodeEvents(raw)
+	require.NoError(t, err)
+	assert.Empty(t, decoded)
+
+	_, _, err = DecodeEvents([]byte{1, 2, 3})
+	assert.Error(t, err)
+}
+
+func TestMaxEncodedLength(t *testing.T) {
+	t.Parallel()
+
+	events := []Event{newEvent(1, 0, 100), newEvent(2, 0, 100), newEvent(3, 0, 100)}
+	raw, err := EncodeEvents(big.NewInt(1), events)
+	require.NoError(t, err)
+	assert.Equal(t, maxEncodedLength(3, 100), len(raw))
+}
+
+func TestEventHash(t *testing.T) {
+	t.Parallel()
+
+	e := newEvent(1, 0, 1)
+	other := e
+	other.BlockHash = common.HexToHash("0xff")
+	assert.Equal(t, eventHash(e), eventHash(newEvent(1, 0, 1)))
+	assert.NotEqual(t, eventHash(e), eventHash(other))
+	assert.Equal(t, e.id(), other.id())
+}
@@ -0,0 +1,157 @@
+// config is a separate package so that we can validate
+// the config in other packages, for example in job at job create time.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+const (
+	// DefaultLookbackBlocks is the number of finalized blocks events are
+	// observed in, if LookbackBlocks is not set.
+	DefaultLookbackBlocks = 1000
+	// DefaultTransmissionLookbackBlocks is the number of destination chain
+	// blocks the transmitted reports are read from, if
+	// TransmissionLookbackBlocks is not set.
+	DefaultTransmissionLookbackBlocks = 10000
+	// DefaultMaxEventsPerReport is the maximum number of events attested in
+	// a single report, if MaxEventsPerReport is not set.
+	DefaultMaxEventsPerReport = 50
+	// DefaultMaxEventDataBytes is the maximum length of the data of an
+	// attested event, if MaxEventDataBytes is not set.
+	DefaultMaxEventDataBytes = 1024
+
+	// MaxEventsPerReport bounds MaxEventsPerReport.
+	MaxEventsPerReport = 500
+	// MaxEventDataBytes bounds MaxEventDataBytes.
+	MaxEventDataBytes = 64 * 1024
+)
+
+// PluginConfig is the plugin config of attestation jobs. Events matching
+// any of the EventFilters are observed on the source chain once they are
+// final, and attested in reports transmitted to the contract of the job on
+// the destination chain.
+type PluginConfig struct {
+	// SourceChainID is the ID of the EVM chain the events are observed on.
+	SourceChainID *utils.Big `json:"sourceChainID"`
+	// EventFilters selects the events to attest.
+	EventFilters []EventFilter `json:"eventFilters"`
+	// Finality is the number of confirmations an event needs before it is
+	// attested. It defaults to the finality depth of the source chain.
+	Finality uint32 `json:"finality"`
+	// LookbackBlocks is the number of finalized blocks events are observed
+	// in, counting back from the latest finalized block.
+	LookbackBlocks uint32 `json:"lookbackBlocks"`
+	// TransmissionLookbackBlocks is the number of destination chain blocks,
+	// counting back from the latest one, in which the transmitted reports
+	// are read to skip the events already delivered. It should cover at
+	// least the time span of LookbackBlocks on the source chain.
+	TransmissionLookbackBlocks uint32 `json:"transmissionLookbackBlocks"`
+	// MaxEventsPerReport is the maximum number of events in a report.
+	MaxEventsPerReport uint32 `json:"maxEventsPerReport"`
+	// MaxEventDataBytes is the maximum length of the data of an event.
+	// Longer events are not attested.
+	MaxEventDataBytes uint32 `json:"maxEventDataBytes"`
+}
+
+// EventFilter matches the events with the given signature emitted by
+// Address. Each of Topic1, Topic2 and Topic3 optionally restricts the
+// corresponding indexed topic to one of the given values.
+type EventFilter struct {
+	Address  common.Address `json:"address"`
+	EventSig common.Hash    `json:"eventSig"`
+	Topic1   []common.Hash  `json:"topic1"`
+	Topic2   []common.Hash  `json:"topic2"`
+	Topic3   []common.Hash  `json:"topic3"`
+}
+
+// Matches returns true if the event emitted by address with the given topics
+// matches the filter.
+func (f EventFilter) Matches(address common.Address, topics []common.Hash) bool {
+	if address != f.Address || len(topics) == 0 || topics[0] != f.EventSig {
+		return false
+	}
+	for i, allowed := range [][]common.Hash{f.Topic1, f.Topic2, f.Topic3} {
+		if len(allowed) == 0 {
+			continue
+		}
+		if len(topics) <= i+1 || !containsHash(allowed, topics[i+1]) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsHash(hashes []common.Hash, h common.Hash) bool {
+	for _, v := range hashes {
+		if v == h {
+			return true
+		}
+	}
+	return false
+}
+
+// GetLookbackBlocks returns LookbackBlocks, or its default.
+func (c PluginConfig) GetLookbackBlocks() uint32 {
+	if c.LookbackBlocks == 0 {
+		return DefaultLookbackBlocks
+	}
+	return c.LookbackBlocks
+}
+
+// GetTransmissionLookbackBlocks returns TransmissionLookbackBlocks, or its
+// default.
+func (c PluginConfig) GetTransmissionLookbackBlocks() uint32 {
+	if c.TransmissionLookbackBlocks == 0 {
+		return DefaultTransmissionLookbackBlocks
+	}
+	return c.TransmissionLookbackBlocks
+}
+
+// GetMaxEventsPerReport returns MaxEventsPerReport, or its default.
+func (c PluginConfig) GetMaxEventsPerReport() uint32 {
+	if c.MaxEventsPerReport == 0 {
+		return DefaultMaxEventsPerReport
+	}
+	return c.MaxEventsPerReport
+}
+
+// GetMaxEventDataBytes returns MaxEventDataBytes, or its default.
+func (c PluginConfig) GetMaxEventDataBytes() uint32 {
+	if c.MaxEventDataBytes == 0 {
+		return DefaultMaxEventDataBytes
+	}
+	return c.MaxEventDataBytes
+}
+
+// ValidatePluginConfig validates the attestation plugin config.
+func ValidatePluginConfig(config PluginConfig) (merr error) {
+	if config.SourceChainID == nil {
+		merr = multierr.Append(merr, errors.New("Attestation: SourceChainID must be set"))
+	}
+	if len(config.EventFilters) == 0 {
+		merr = multierr.Append(merr, errors.New("Attestation: at least one EventFilter must be set"))
+	}
+	for i, f := range config.EventFilters {
+		if f.Address == (common.Address{}) {
+			merr = multierr.Append(merr, fmt.Errorf("Attestation: EventFilters[%d]: Address must be set", i))
+		}
+		if f.EventSig == (common.Hash{}) {
+			merr = multierr.Append(merr, fmt.Errorf("Attestation: EventFilters[%d]: EventSig must be set", i))
+		}
+	}
+	if config.MaxEventsPerReport > MaxEventsPerReport {
+		merr = multierr.Append(merr, fmt.Errorf("Attestation: MaxEventsPerReport must be at most %d, got %d", MaxEventsPerReport, config.MaxEventsPerReport))
+	}
+	if config.MaxEventDataBytes > MaxEventDataBytes {
+		merr = multierr.Append(merr, fmt.Errorf("Attestation: MaxEventDataBytes must be at most %d, got %d", MaxEventDataBytes, config.MaxEventDataBytes))
+	}
+	return merr
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PluginConfig(t *testing.T) {
+	t.Run("with valid values", func(t *testing.T) {
+		rawJSON := `{
+	"sourceChainID": "1",
+	"finality": 64,
+	"eventFilters": [{
+		"address": "0x2F4B5D44F9B6c8c0b9F6b5e6f0d6f0C0f0b0F0a0",
+		"eventSig": "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+		"topic2": ["0x0000000000000000000000000000000000000000000000000000000000000001"]
+	}]
+}`
+		var c PluginConfig
+		require.NoError(t, json.Unmarshal([]byte(rawJSON), &c))
+		require.NoError(t, ValidatePluginConfig(c))
+
+		assert.Equal(t, int64(1), c.SourceChainID.Int64())
+		assert.Equal(t, uint32(64), c.Finality)
+		require.Len(t, c.EventFilters, 1)
+		assert.Equal(t, common.HexToAddress("0x2F4B5D44F9B6c8c0b9F6b5e6f0d6f0C0f0b0F0a0"), c.EventFilters[0].Address)
+		assert.Equal(t, uint32(DefaultLookbackBlocks), c.GetLookbackBlocks())
+		assert.Equal(t, uint32(DefaultTransmissionLookbackBlocks), c.GetTransmissionLookbackBlocks())
+		assert.Equal(t, uint32(DefaultMaxEventsPerReport), c.GetMaxEventsPerReport())
+		assert.Equal(t, uint32(DefaultMaxEventDataBytes), c.GetMaxEventDataBytes())
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		c := PluginConfig{
+			EventFilters:       []EventFilter{{}},
+			MaxEventsPerReport: MaxEventsPerReport + 1,
+			MaxEventDataBytes:  MaxEventDataBytes + 1,
+		}
+		err := ValidatePluginConfig(c)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "SourceChainID must be set")
+		assert.Contains(t, err.Error(), "EventFilters[0]: Address must be set")
+		assert.Contains(t, err.Error(), "EventFilters[0]: EventSig must be set")
+		assert.Contains(t, err.Error(), "MaxEventsPerReport must be at most 500")
+		assert.Contains(t, err.Error(), "MaxEventDataBytes must be at most 65536")
+
+		err = ValidatePluginConfig(PluginConfig{})
+		assert.Contains(t, err.Error(), "at least one EventFilter must be set")
+	})
+}
+
+func TestEventFilter_Matches(t *testing.T) {
+	address := common.HexToAddress("0x1")
+	sig := common.HexToHash("0xa")
+	f := EventFilter{
+		Address:  address,
+		EventSig: sig,
+		Topic2:   []common.Hash{common.HexToHash("0x2"), common.HexToHash("0x3")},
+	}
+
+	assert.True(t, f.Matches(address, []common.Hash{sig, {}, common.HexToHash("0x2")}))
+	assert.True(t, f.Matches(address, []common.Hash{sig, common.HexToHash("0x9"), common.HexToHash("0x3"), {}}))
+	assert.False(t, f.Matches(address, []common.Hash{sig, {}, common.HexToHash("0x4")}))
+	assert.False(t, f.Matches(address, []common.Hash{sig, {}}))
+	assert.False(t, f.Matches(common.HexToAddress("0x2"), []common.Hash{sig, {}, common.HexToHash("0x2")}))
+	assert.False(t, f.Matches(address, []common.Hash{common.HexToHash("0xb"), {}, common.HexToHash("0x2")}))
+	assert.False(t, f.Matches(address, nil))
+
+	assert.True(t, EventFilter{Address: address, EventSig: sig}.Matches(address, []common.Hash{sig}))
+}
@@ -0,0 +1,76 @@
+package attestation
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	libocr2 "github.com/smartcontractkit/libocr/offchainreporting2"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/attestation/config"
+	evmrelaytypes "github.com/smartcontractkit/chainlink/v2/core/services/relay/evm/types"
+)
+
+// ParsePluginConfig parses and validates the plugin config of an attestation
+// job.
+func ParsePluginConfig(spec *job.OCR2OracleSpec) (config.PluginConfig, error) {
+	var pluginConfig config.PluginConfig
+	if err := json.Unmarshal(spec.PluginConfig.Bytes(), &pluginConfig); err != nil {
+		return pluginConfig, errors.Wrap(err, "failed to unmarshal attestation plugin config")
+	}
+	return pluginConfig, config.ValidatePluginConfig(pluginConfig)
+}
+
+// NewAttestationServices returns the oracle of an attestation job. The
+// events are observed with the LogPoller of the source chain, and the
+// reports are transmitted by the ContractTransmitter of oracleArgsNoPlugin
+// to the destination chain, whose transmissions are read back to skip the
+// events already delivered.
+func NewAttestationServices(jb job.Job, chainSet evm.ChainSet, lggr logger.Logger, oracleArgsNoPlugin libocr2.OracleArgs) ([]job.ServiceCtx, error) {
+	spec := jb.OCR2OracleSpec
+	pluginConfig, err := ParsePluginConfig(spec)
+	if err != nil {
+		return nil, err
+	}
+	sourceChain, err := chainSet.Get(pluginConfig.SourceChainID.ToInt())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get source chain")
+	}
+	finality := pluginConfig.Finality
+	if finality == 0 {
+		finality = sourceChain.Config().EvmFinalityDepth()
+	}
+
+	lp := sourceChain.LogPoller()
+	if err = lp.RegisterFilter(LogPollerFilter(spec.ID, pluginConfig.EventFilters)); err != nil {
+		return nil, errors.Wrap(err, "failed to register attestation log filter")
+	}
+
+	var relayConfig evmrelaytypes.RelayConfig
+	if err = json.Unmarshal(spec.RelayConfig.Bytes(), &relayConfig); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal relay config")
+	}
+	destChain, err := chainSet.Get(relayConfig.ChainID.ToInt())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get destination chain")
+	}
+	if !common.IsHexAddress(spec.ContractID) {
+		return nil, errors.New("invalid contractID, expected hex address")
+	}
+
+	source := NewLogPollerEventSource(lp, pluginConfig.EventFilters, finality, pluginConfig.GetLookbackBlocks())
+	lggr = lggr.With("sourceChainID", pluginConfig.SourceChainID.String(), "finality", finality)
+	transmissions, err := NewLogPollerTransmissions(destChain.LogPoller(), destChain.Client(), common.HexToAddress(spec.ContractID), pluginConfig.GetTransmissionLookbackBlocks(), lggr)
+	if err != nil {
+		return nil, err
+	}
+	oracleArgsNoPlugin.ReportingPluginFactory = NewReportingPluginFactory(source, transmissions, pluginConfig, jb.ExternalJobID.String(), lggr)
+	oracle, err := libocr2.NewOracle(oracleArgsNoPlugin)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create attestation oracle")
+	}
+	return []job.ServiceCtx{job.NewServiceAdapter(oracle)}, nil
+}
@@ -0,0 +1,317 @@
+package attestation
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/attestation/config"
+)
+
+var (
+	promObservedEvents = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "attestation_reporting_plugin_observed_events",
+		Help: "Number of final source chain events not yet reported in the latest observation",
+	}, []string{"jobID"})
+	promReportedEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "attestation_reporting_plugin_reported_events",
+		Help: "Number of source chain events in accepted reports",
+	}, []string{"jobID"})
+)
+
+// EventSource returns the final events to attest.
+type EventSource interface {
+	// Events returns the final events of the lookback window, oldest first,
+	// and the first block of the window.
+	Events(ctx context.Context) (events []Event, fromBlock uint64, err error)
+}
+
+// PendingEventsTimeout is how long the events of an accepted report are not
+// observed again while waiting for the report to be transmitted.
+const PendingEventsTimeout = 10 * time.Minute
+
+// reportedEvents are the events delivered to the destination chain, and the
+// pending events of the reports accepted by the oracle but not transmitted
+// yet. They are shared by the reporting plugins of a job, across config
+// changes. Delivered events are forgotten once they leave the lookback
+// window, and pending events once delivered or timed out, so that the events
+// of reports that are never transmitted are reported again.
+type reportedEvents struct {
+	mu        sync.Mutex
+	timeout   time.Duration
+	delivered map[eventID]uint64
+	pending   map[eventID]time.Time
+}
+
+func newReportedEvents(timeout time.Duration) *reportedEvents {
+	return &reportedEvents{
+		timeout:   timeout,
+		delivered: make(map[eventID]uint64),
+		pending:   make(map[eventID]time.Time),
+	}
+}
+
+func (r *reportedEvents) contains(id eventID) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.delivered[id]; ok {
+		return true
+	}
+	acceptedAt, ok := r.pending[id]
+	return ok && time.Since(acceptedAt) < r.timeout
+}
+
+func (r *reportedEvents) addPending(events []Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	for _, e := range events {
+		if _, ok := r.delivered[e.id()]; !ok {
+			r.pending[e.id()] = now
+		}
+	}
+}
+
+func (r *reportedEvents) addDelivered(events []Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, e := range events {
+		r.delivered[e.id()] = e.BlockNumber
+		delete(r.pending, e.id())
+	}
+}
+
+func (r *reportedEvents) prune(fromBlock uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, blockNumber := range r.delivered {
+		if blockNumber < fromBlock {
+			delete(r.delivered, id)
+		}
+	}
+	for id, acceptedAt := range r.pending {
+		if time.Since(acceptedAt) >= r.timeout {
+			delete(r.pending, id)
+		}
+	}
+}
+
+var _ types.ReportingPluginFactory = &reportingPluginFactory{}
+
+type reportingPluginFactory struct {
+	source        EventSource
+	transmissions Transmissions
+	sourceChainID *big.Int
+	config        config.PluginConfig
+	jobID         string
+	lggr          logger.Logger
+	reported      *reportedEvents
+}
+
+// NewReportingPluginFactory returns the factory of the attestation reporting
+// plugin, which attests the events of source that are not in transmissions.
+func NewReportingPluginFactory(source EventSource, transmissions Transmissions, cfg config.PluginConfig, jobID string, lggr logger.Logger) types.ReportingPluginFactory {
+	return &reportingPluginFactory{
+		source:        source,
+		transmissions: transmissions,
+		sourceChainID: cfg.SourceChainID.ToInt(),
+		config:        cfg,
+		jobID:         jobID,
+		lggr:          lggr.Named("AttestationReporting"),
+		reported:      newReportedEvents(PendingEventsTimeout),
+	}
+}
+
+func (f *reportingPluginFactory) NewReportingPlugin(c types.ReportingPluginConfig) (types.ReportingPlugin, types.ReportingPluginInfo, error) {
+	maxEvents := int(f.config.GetMaxEventsPerReport())
+	maxDataBytes := int(f.config.GetMaxEventDataBytes())
+	maxLength := maxEncodedLength(maxEvents, maxDataBytes)
+	return &reportingPlugin{
+		source:        f.source,
+		transmissions: f.transmissions,
+		sourceChainID: f.sourceChainID,
+		maxEvents:     maxEvents,
+		maxDataBytes:  maxDataBytes,
+		f:             c.F,
+		jobID:         f.jobID,
+		lggr:          f.lggr.With("configDigest", c.ConfigDigest.Hex()),
+		reported:      f.reported,
+	}, types.ReportingPluginInfo{
+		Name: "attestation",
+		Limits: types.ReportingPluginLimits{
+			MaxQueryLength:       0,
+			MaxObservationLength: maxLength,
+			MaxReportLength:      maxLength,
+		},
+	}, nil
+}
+
+var _ types.ReportingPlugin = &reportingPlugin{}
+
+// reportingPlugin attests the final events of the source chain. Each oracle
+// observes the oldest events it has not reported yet, and reports contain
+// the events observed identically by at least F+1 oracles, oldest first.
+// Events are not reported again once delivered to the destination chain, nor
+// while a report containing them waits to be transmitted.
+type reportingPlugin struct {
+	source        EventSource
+	transmissions Transmissions
+	sourceChainID *big.Int
+	maxEvents     int
+	maxDataBytes  int
+	f             int
+	jobID         string
+	lggr          logger.Logger
+	reported      *reportedEvents
+}
+
+func (p *reportingPlugin) Query(context.Context, types.ReportTimestamp) (types.Query, error) {
+	return nil, nil
+}
+
+func (p *reportingPlugin) Observation(ctx context.Context, _ types.ReportTimestamp, _ types.Query) (types.Observation, error) {
+	events, fromBlock, err := p.source.Events(ctx)
+	if err != nil {
+		return nil, err
+	}
+	delivered, err := p.transmissions.Events(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.reported.addDelivered(delivered)
+	p.reported.prune(fromBlock)
+
+	var pending []Event
+	for _, e := range events {
+		if p.reported.contains(e.id()) {
+			continue
+		}
+		if len(e.Data) > p.maxDataBytes {
+			p.lggr.Errorw("Event data is too long to attest, skipping event",
+				"txHash", e.TxHash, "logIndex", e.LogIndex, "dataLength", len(e.Data), "maxEventDataBytes", p.maxDataBytes)
+			continue
+		}
+		pending = append(pending, e)
+	}
+	promObservedEvents.WithLabelValues(p.jobID).Set(float64(len(pending)))
+	if len(pending) > p.maxEvents {
+		pending = pending[:p.maxEvents]
+	}
+	return EncodeEvents(p.sourceChainID, pending)
+}
+
+type observedEvent struct {
+	event Event
+	hash  common.Hash
+	count int
+}
+
+func (p *reportingPlugin) Report(_ context.Context, _ types.ReportTimestamp, _ types.Query, aos []types.AttributedObservation) (bool, types.Report, error) {
+	observed := make(map[common.Hash]*observedEvent)
+	for _, ao := range aos {
+		sourceChainID, events, err := DecodeEvents(ao.Observation)
+		if err != nil {
+			p.lggr.Warnw("Ignoring invalid observation", "observer", ao.Observer, "err", err)
+			continue
+		}
+		if sourceChainID.Cmp(p.sourceChainID) != 0 {
+			p.lggr.Warnw("Ignoring observation of another chain", "observer", ao.Observer, "sourceChainID", sourceChainID)
+			continue
+		}
+		if len(events) > p.maxEvents {
+			p.lggr.Warnw("Ignoring observation with too many events", "observer", ao.Observer, "events", len(events))
+			continue
+		}
+		// An oracle observing the same event several times counts once.
+		seen := make(map[common.Hash]bool)
+		for _, e := range events {
+			h := eventHash(e)
+			if seen[h] {
+				continue
+			}
+			seen[h] = true
+			if o, ok := observed[h]; ok {
+				o.count++
+			} else {
+				observed[h] = &observedEvent{event: e, hash: h, count: 1}
+			}
+		}
+	}
+
+	var quorum []*observedEvent
+	for _, o := range observed {
+		if o.count > p.f && len(o.event.Data) <= p.maxDataBytes && !p.reported.contains(o.event.id()) {
+			quorum = append(quorum, o)
+		}
+	}
+	// Should two versions of an event reach a quorum, keep the one observed
+	// by the most oracles.
+	sort.Slice(quorum, func(i, j int) bool {
+		if quorum[i].count != quorum[j].count {
+			return quorum[i].count > quorum[j].count
+		}
+		return quorum[i].hash.Big().Cmp(quorum[j].hash.Big()) < 0
+	})
+	var events []Event
+	ids := make(map[eventID]bool)
+	for _, o := range quorum {
+		if ids[o.event.id()] {
+			continue
+		}
+		ids[o.event.id()] = true
+		events = append(events, o.event)
+	}
+	if len(events) == 0 {
+		return false, nil, nil
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].before(events[j])
+	})
+	if len(events) > p.maxEvents {
+		events = events[:p.maxEvents]
+	}
+
+	report, err := EncodeEvents(p.sourceChainID, events)
+	if err != nil {
+		return false, nil, err
+	}
+	return true, report, nil
+}
+
+// ShouldAcceptFinalizedReport accepts reports with at least one event that
+// has not been reported yet, and records their events as pending.
+func (p *reportingPlugin) ShouldAcceptFinalizedReport(_ context.Context, ts types.ReportTimestamp, report types.Report) (bool, error) {
+	_, events, err := DecodeEvents(report)
+	if err != nil {
+		return false, err
+	}
+	var unreported int
+	for _, e := range events {
+		if !p.reported.contains(e.id()) {
+			unreported++
+		}
+	}
+	if unreported == 0 {
+		p.lggr.Debugw("Not accepting report of already reported events", "epoch", ts.Epoch, "round", ts.Round)
+		return false, nil
+	}
+	p.reported.addPending(events)
+	promReportedEvents.WithLabelValues(p.jobID).Add(float64(unreported))
+	return true, nil
+}
+
+func (p *reportingPlugin) ShouldTransmitAcceptedReport(context.Context, types.ReportTimestamp, types.Report) (bool, error) {
+	return true, nil
+}
+
+func (p *reportingPlugin) Close() error {
+	return nil
+}
@@ -0,0 +1,221 @@
+package attestation
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/attestation/config"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+type fakeEventSource struct {
+	events    []Event
+	fromBlock uint64
+}
+
+func (s *fakeEventSource) Events(context.Context) ([]Event, uint64, error) {
+	return s.events, s.fromBlock, nil
+}
+
+type fakeTransmissions struct {
+	events []Event
+}
+
+func (t *fakeTransmissions) Events(context.Context) ([]Event, error) {
+	return t.events, nil
+}
+
+func newTestPlugin(t *testing.T, source EventSource, maxEvents uint32) (types.ReportingPlugin, types.ReportingPluginInfo) {
+	return newTestPluginWithTransmissions(t, source, &fakeTransmissions{}, maxEvents)
+}
+
+func newTestPluginWithTransmissions(t *testing.T, source EventSource, transmissions Transmissions, maxEvents uint32) (types.ReportingPlugin, types.ReportingPluginInfo) {
+	factory := NewReportingPluginFactory(source, transmissions, config.PluginConfig{
+		SourceChainID:      utils.NewBigI(1),
+		MaxEventsPerReport: maxEvents,
+		MaxEventDataBytes:  32,
+	}, "job", logger.TestLogger(t))
+	plugin, info, err := factory.NewReportingPlugin(types.ReportingPluginConfig{N: 4, F: 1})
+	require.NoError(t, err)
+	return plugin, info
+}
+
+func observation(t *testing.T, chainID int64, events ...Event) types.Observation {
+	raw, err := EncodeEvents(big.NewInt(chainID), events)
+	require.NoError(t, err)
+	return raw
+}
+
+func TestReportingPlugin_Observation(t *testing.T) {
+	t.Parallel()
+
+	source := &fakeEventSource{events: []Event{newEvent(1, 0, 1), newEvent(1, 1, 64), newEvent(2, 0, 1), newEvent(3, 0, 1)}}
+	plugin, info := newTestPlugin(t, source, 2)
+	assert.Equal(t, maxEncodedLength(2, 32), info.Limits.MaxReportLength)
+
+	raw, err := plugin.Observation(testutils.Context(t), types.ReportTimestamp{}, nil)
+	require.NoError(t, err)
+	chainID, events, err := DecodeEvents(raw)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1), chainID)
+	// The event with too much data is skipped, and the observation capped.
+	assert.Equal(t, []Event{source.events[0], source.events[2]}, events)
+
+	// Reported events are not observed again.
+	accepted, err := plugin.ShouldAcceptFinalizedReport(testutils.Context(t), types.ReportTimestamp{}, types.Report(observation(t, 1, source.events[0])))
+	require.NoError(t, err)
+	assert.True(t, accepted)
+
+	raw, err = plugin.Observation(testutils.Context(t), types.ReportTimestamp{}, nil)
+	require.NoError(t, err)
+	_, events, err = DecodeEvents(raw)
+	require.NoError(t, err)
+	assert.Equal(t, []Event{source.events[2], source.events[3]}, events)
+}
+
+func TestReportingPlugin_ObservationDelivered(t *testing.T) {
+	t.Parallel()
+
+	source := &fakeEventSource{events: []Event{newEvent(1, 0, 1), newEvent(2, 0, 1), newEvent(3, 0, 1)}}
+	transmissions := &fakeTransmissions{events: []Event{source.events[1]}}
+	plugin, _ := newTestPluginWithTransmissions(t, source, transmissions, 10)
+
+	// Events delivered to the destination chain, e.g. before a restart, are
+	// not observed.
+	raw, err := plugin.Observation(testutils.Context(t), types.ReportTimestamp{}, nil)
+	require.NoError(t, err)
+	_, events, err := DecodeEvents(raw)
+	require.NoError(t, err)
+	assert.Equal(t, []Event{source.events[0], source.events[2]}, events)
+
+	// Delivered events stay reported once out of the destination lookback
+	// window.
+	transmissions.events = nil
+	raw, err = plugin.Observation(testutils.Context(t), types.ReportTimestamp{}, nil)
+	require.NoError(t, err)
+	_, events, err = DecodeEvents(raw)
+	require.NoError(t, err)
+	assert.Equal(t, []Event{source.events[0], source.events[2]}, events)
+}
+
+func TestReportingPlugin_Report(t *testing.T) {
+	t.Parallel()
+
+	e1, e2, e3 := newEvent(1, 0, 1), newEvent(2, 0, 1), newEvent(2, 1, 1)
+	reorged := e3
+	reorged.BlockHash = common.HexToHash("0xff")
+
+	t.Run("reports events observed by f+1 oracles, oldest first", func(t *testing.T) {
+		plugin, _ := newTestPlugin(t, &fakeEventSource{}, 10)
+		ok, report, err := plugin.Report(testutils.Context(t), types.ReportTimestamp{}, nil, []types.AttributedObservation{
+			{Observer: 0, Observation: observation(t, 1, e2, e1, e2)},
+			{Observer: 1, Observation: observation(t, 1, e1, e3)},
+			{Observer: 2, Observation: observation(t, 1, e2, reorged)},
+			{Observer: 3, Observation: observation(t, 2, e3)},
+			{Observer: 3, Observation: []byte{1}},
+		})
+		require.NoError(t, err)
+		require.True(t, ok)
+		chainID, events, err := DecodeEvents(report)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(1), chainID)
+		assert.Equal(t, []Event{e1, e2}, events)
+	})
+
+	t.Run("keeps the version of an event observed by most oracles", func(t *testing.T) {
+		plugin, _ := newTestPlugin(t, &fakeEventSource{}, 10)
+		ok, report, err := plugin.Report(testutils.Context(t), types.ReportTimestamp{}, nil, []types.AttributedObservation{
+			{Observer: 0, Observation: observation(t, 1, e3)},
+			{Observer: 1, Observation: observation(t, 1, reorged)},
+			{Observer: 2, Observation: observation(t, 1, reorged)},
+			{Observer: 3, Observation: observation(t, 1, e3, reorged)},
+		})
+		require.NoError(t, err)
+		require.True(t, ok)
+		_, events, err := DecodeEvents(report)
+		require.NoError(t, err)
+		assert.Equal(t, []Event{reorged}, events)
+	})
+
+	t.Run("caps and dedups reports", func(t *testing.T) {
+		plugin, _ := newTestPlugin(t, &fakeEventSource{}, 1)
+		aos := []types.AttributedObservation{
+			{Observer: 0, Observation: observation(t, 1, e1)},
+			{Observer: 1, Observation: observation(t, 1, e1)},
+			{Observer: 2, Observation: observation(t, 1, e2)},
+			{Observer: 3, Observation: observation(t, 1, e2)},
+		}
+		ok, report, err := plugin.Report(testutils.Context(t), types.ReportTimestamp{}, nil, aos)
+		require.NoError(t, err)
+		require.True(t, ok)
+		_, events, err := DecodeEvents(report)
+		require.NoError(t, err)
+		assert.Equal(t, []Event{e1}, events)
+
+		accepted, err := plugin.ShouldAcceptFinalizedReport(testutils.Context(t), types.ReportTimestamp{}, report)
+		require.NoError(t, err)
+		assert.True(t, accepted)
+		accepted, err = plugin.ShouldAcceptFinalizedReport(testutils.Context(t), types.ReportTimestamp{}, report)
+		require.NoError(t, err)
+		assert.False(t, accepted)
+
+		ok, report, err = plugin.Report(testutils.Context(t), types.ReportTimestamp{}, nil, aos)
+		require.NoError(t, err)
+		require.True(t, ok)
+		_, events, err = DecodeEvents(report)
+		require.NoError(t, err)
+		assert.Equal(t, []Event{e2}, events)
+	})
+
+	t.Run("no report without a quorum", func(t *testing.T) {
+		plugin, _ := newTestPlugin(t, &fakeEventSource{}, 10)
+		ok, _, err := plugin.Report(testutils.Context(t), types.ReportTimestamp{}, nil, []types.AttributedObservation{
+			{Observer: commontypes.OracleID(0), Observation: observation(t, 1, e1)},
+			{Observer: commontypes.OracleID(1), Observation: observation(t, 1, e2)},
+		})
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
+
+func TestReportedEvents(t *testing.T) {
+	t.Parallel()
+
+	t.Run("prunes delivered events out of the lookback window", func(t *testing.T) {
+		r := newReportedEvents(time.Hour)
+		r.addDelivered([]Event{newEvent(1, 0, 0), newEvent(5, 0, 0)})
+		r.prune(2)
+		assert.False(t, r.contains(newEvent(1, 0, 0).id()))
+		assert.True(t, r.contains(newEvent(5, 0, 0).id()))
+	})
+
+	t.Run("pending events are reported again once timed out", func(t *testing.T) {
+		r := newReportedEvents(time.Hour)
+		r.addPending([]Event{newEvent(1, 0, 0)})
+		assert.True(t, r.contains(newEvent(1, 0, 0).id()))
+
+		r = newReportedEvents(0)
+		r.addPending([]Event{newEvent(1, 0, 0)})
+		assert.False(t, r.contains(newEvent(1, 0, 0).id()))
+		r.prune(0)
+		assert.Empty(t, r.pending)
+	})
+
+	t.Run("delivered events are no longer pending", func(t *testing.T) {
+		r := newReportedEvents(0)
+		r.addPending([]Event{newEvent(1, 0, 0)})
+		r.addDelivered([]Event{newEvent(1, 0, 0)})
+		assert.Empty(t, r.pending)
+		assert.True(t, r.contains(newEvent(1, 0, 0).id()))
+	})
+}
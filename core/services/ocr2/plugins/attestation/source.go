@@ -0,0 +1,119 @@
+package attestation
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/attestation/config"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+)
+
+var _ EventSource = &logPollerEventSource{}
+
+// logPollerEventSource reads the final events matching the event filters of
+// a job from the LogPoller of the source chain.
+type logPollerEventSource struct {
+	lp       logpoller.LogPoller
+	filters  []config.EventFilter
+	finality int64
+	lookback int64
+}
+
+// NewLogPollerEventSource returns an EventSource of the events matching
+// filters with at least finality confirmations, in the lookback blocks
+// preceding the latest final block.
+func NewLogPollerEventSource(lp logpoller.LogPoller, filters []config.EventFilter, finality, lookback uint32) EventSource {
+	return &logPollerEventSource{
+		lp:       lp,
+		filters:  filters,
+		finality: int64(finality),
+		lookback: int64(lookback),
+	}
+}
+
+// FilterName returns the name of the LogPoller filter of the attestation job
+// with the given ID.
+func FilterName(jobID int32) string {
+	return logpoller.FilterName("Attestation", jobID)
+}
+
+// LogPollerFilter returns the LogPoller filter of the events of a job.
+func LogPollerFilter(jobID int32, filters []config.EventFilter) logpoller.Filter {
+	var sigs []common.Hash
+	var addresses []common.Address
+	seenSigs, seenAddresses := make(map[common.Hash]bool), make(map[common.Address]bool)
+	for _, f := range filters {
+		if !seenSigs[f.EventSig] {
+			seenSigs[f.EventSig] = true
+			sigs = append(sigs, f.EventSig)
+		}
+		if !seenAddresses[f.Address] {
+			seenAddresses[f.Address] = true
+			addresses = append(addresses, f.Address)
+		}
+	}
+	return logpoller.Filter{
+		Name:      FilterName(jobID),
+		EventSigs: sigs,
+		Addresses: addresses,
+	}
+}
+
+func (s *logPollerEventSource) Events(ctx context.Context) ([]Event, uint64, error) {
+	latest, err := s.lp.LatestBlock(pg.WithParentCtx(ctx))
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to get latest block")
+	}
+	end := latest - s.finality
+	if end < 0 {
+		return nil, 0, nil
+	}
+	start := end - s.lookback + 1
+	if start < 0 {
+		start = 0
+	}
+
+	sigsByAddress := make(map[common.Address][]common.Hash)
+	for _, f := range s.filters {
+		sigsByAddress[f.Address] = append(sigsByAddress[f.Address], f.EventSig)
+	}
+	var events []Event
+	for address, sigs := range sigsByAddress {
+		logs, err := s.lp.LogsWithSigs(start, end, sigs, address, pg.WithParentCtx(ctx))
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "failed to get logs of %s", address)
+		}
+		for _, l := range logs {
+			topics := l.GetTopics()
+			if !s.matches(l.Address, topics) {
+				continue
+			}
+			events = append(events, Event{
+				Address:     l.Address,
+				Topics:      topics,
+				Data:        l.Data,
+				BlockNumber: uint64(l.BlockNumber),
+				BlockHash:   l.BlockHash,
+				TxHash:      l.TxHash,
+				LogIndex:    uint64(l.LogIndex),
+			})
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].before(events[j])
+	})
+	return events, uint64(start), nil
+}
+
+func (s *logPollerEventSource) matches(address common.Address, topics []common.Hash) bool {
+	for _, f := range s.filters {
+		if f.Matches(address, topics) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package attestation
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/attestation/config"
+)
+
+func TestLogPollerEventSource(t *testing.T) {
+	t.Parallel()
+
+	a, b := common.HexToAddress("0xa"), common.HexToAddress("0xb")
+	sig1, sig2 := common.HexToHash("0x1"), common.HexToHash("0x2")
+	filters := []config.EventFilter{
+		{Address: a, EventSig: sig1},
+		{Address: a, EventSig: sig2, Topic1: []common.Hash{common.HexToHash("0x10")}},
+		{Address: b, EventSig: sig1},
+	}
+	newLog := func(address common.Address, blockNumber, logIndex int64, topics ...common.Hash) logpoller.Log {
+		var raw pq.ByteaArray
+		for _, topic := range topics {
+			raw = append(raw, topic.Bytes())
+		}
+		return logpoller.Log{Address: address, BlockNumber: blockNumber, LogIndex: logIndex, Topics: raw, EventSig: topics[0]}
+	}
+
+	lp := mocks.NewLogPoller(t)
+	lp.On("LatestBlock", mock.Anything).Return(int64(110), nil)
+	lp.On("LogsWithSigs", int64(1), int64(100), []common.Hash{sig1, sig2}, a, mock.Anything).Return([]logpoller.Log{
+		newLog(a, 90, 0, sig1),
+		newLog(a, 95, 1, sig2, common.HexToHash("0x10")),
+		newLog(a, 95, 2, sig2, common.HexToHash("0x11")),
+	}, nil)
+	lp.On("LogsWithSigs", int64(1), int64(100), []common.Hash{sig1}, b, mock.Anything).Return([]logpoller.Log{
+		newLog(b, 92, 0, sig1),
+	}, nil)
+
+	source := NewLogPollerEventSource(lp, filters, 10, 100)
+	events, fromBlock, err := source.Events(testutils.Context(t))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), fromBlock)
+	require.Len(t, events, 3)
+	assert.Equal(t, a, events[0].Address)
+	assert.Equal(t, b, events[1].Address)
+	assert.Equal(t, uint64(95), events[2].BlockNumber)
+	assert.Equal(t, uint64(1), events[2].LogIndex)
+	assert.Equal(t, []common.Hash{sig2, common.HexToHash("0x10")}, events[2].Topics)
+}
+
+func TestLogPollerEventSource_NoFinalBlocks(t *testing.T) {
+	t.Parallel()
+
+	lp := mocks.NewLogPoller(t)
+	lp.On("LatestBlock", mock.Anything).Return(int64(5), nil)
+
+	events, _, err := NewLogPollerEventSource(lp, []config.EventFilter{{}}, 10, 100).Events(testutils.Context(t))
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestLogPollerFilter(t *testing.T) {
+	t.Parallel()
+
+	a, b := common.HexToAddress("0xa"), common.HexToAddress("0xb")
+	sig1, sig2 := common.HexToHash("0x1"), common.HexToHash("0x2")
+	filter := LogPollerFilter(7, []config.EventFilter{
+		{Address: a, EventSig: sig1},
+		{Address: a, EventSig: sig2},
+		{Address: b, EventSig: sig1},
+	})
+	assert.Equal(t, FilterName(7), filter.Name)
+	assert.Equal(t, []common.Hash{sig1, sig2}, []common.Hash(filter.EventSigs))
+	assert.Equal(t, []common.Address{a, b}, []common.Address(filter.Addresses))
+}
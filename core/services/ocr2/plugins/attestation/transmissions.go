@@ -0,0 +1,181 @@
+package attestation
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/libocr/gethwrappers2/ocr2aggregator"
+
+	evmclient "github.com/smartcontractkit/chainlink/v2/core/chains/evm/client"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/authorized_forwarder"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+)
+
+// Transmissions returns the events delivered to the destination chain.
+type Transmissions interface {
+	// Events returns the events of the reports transmitted in the lookback
+	// window of the destination chain.
+	Events(ctx context.Context) ([]Event, error)
+}
+
+var _ Transmissions = &logPollerTransmissions{}
+
+type transmission struct {
+	block  int64
+	events []Event
+}
+
+// logPollerTransmissions reads the reports transmitted to the destination
+// contract from the calldata of the transactions that emitted its
+// Transmitted logs. Transmissions are decoded once, and cached while in the
+// lookback window.
+type logPollerTransmissions struct {
+	lp             logpoller.LogPoller
+	client         evmclient.Client
+	contract       common.Address
+	lookback       int64
+	transmittedSig common.Hash
+	aggregatorABI  abi.ABI
+	forwarderABI   abi.ABI
+	lggr           logger.Logger
+
+	mu  sync.Mutex
+	txs map[common.Hash]transmission
+}
+
+// NewLogPollerTransmissions returns the Transmissions of the destination
+// contract, in the lookback blocks preceding the latest block of lp. The
+// Transmitted logs are those of the LogPoller filter of the contract
+// transmitter.
+func NewLogPollerTransmissions(lp logpoller.LogPoller, client evmclient.Client, contract common.Address, lookback uint32, lggr logger.Logger) (Transmissions, error) {
+	aggregatorABI, err := abi.JSON(strings.NewReader(ocr2aggregator.OCR2AggregatorMetaData.ABI))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse OCR2Aggregator ABI")
+	}
+	forwarderABI, err := abi.JSON(strings.NewReader(authorized_forwarder.AuthorizedForwarderMetaData.ABI))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse AuthorizedForwarder ABI")
+	}
+	return &logPollerTransmissions{
+		lp:             lp,
+		client:         client,
+		contract:       contract,
+		lookback:       int64(lookback),
+		transmittedSig: aggregatorABI.Events["Transmitted"].ID,
+		aggregatorABI:  aggregatorABI,
+		forwarderABI:   forwarderABI,
+		lggr:           lggr,
+		txs:            make(map[common.Hash]transmission),
+	}, nil
+}
+
+func (t *logPollerTransmissions) Events(ctx context.Context) ([]Event, error) {
+	latest, err := t.lp.LatestBlock(pg.WithParentCtx(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get latest block")
+	}
+	start := latest - t.lookback + 1
+	if start < 0 {
+		start = 0
+	}
+	logs, err := t.lp.Logs(start, latest, t.transmittedSig, t.contract, pg.WithParentCtx(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get Transmitted logs")
+	}
+
+	blocks := make(map[common.Hash]int64, len(logs))
+	for _, l := range logs {
+		blocks[l.TxHash] = l.BlockNumber
+	}
+	var reqs []rpc.BatchElem
+	var hashes []common.Hash
+	t.mu.Lock()
+	for hash, tx := range t.txs {
+		if tx.block < start {
+			delete(t.txs, hash)
+		}
+	}
+	for hash := range blocks {
+		if _, ok := t.txs[hash]; ok {
+			continue
+		}
+		hashes = append(hashes, hash)
+		reqs = append(reqs, rpc.BatchElem{
+			Method: "eth_getTransactionByHash",
+			Args:   []interface{}{hash},
+			Result: new(gethtypes.Transaction),
+		})
+	}
+	t.mu.Unlock()
+
+	if len(reqs) > 0 {
+		if err = t.client.BatchCallContext(ctx, reqs); err != nil {
+			return nil, errors.Wrap(err, "failed to get transmit transactions")
+		}
+	}
+	fetched := make(map[common.Hash]transmission, len(reqs))
+	for i, req := range reqs {
+		if req.Error != nil {
+			return nil, errors.Wrapf(req.Error, "failed to get transmit transaction %s", hashes[i])
+		}
+		// A transaction that cannot be decoded delivered no events, and is
+		// not fetched again.
+		events, err := t.decodeTransmit(req.Result.(*gethtypes.Transaction).Data())
+		if err != nil {
+			t.lggr.Warnw("Failed to decode transmit transaction", "txHash", hashes[i], "err", err)
+		}
+		fetched[hashes[i]] = transmission{block: blocks[hashes[i]], events: events}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for hash, tx := range fetched {
+		t.txs[hash] = tx
+	}
+	var events []Event
+	for hash := range blocks {
+		events = append(events, t.txs[hash].events...)
+	}
+	return events, nil
+}
+
+// decodeTransmit returns the events of the report in the calldata of a
+// transmit call, made directly or through an AuthorizedForwarder.
+func (t *logPollerTransmissions) decodeTransmit(data []byte) ([]Event, error) {
+	if len(data) < 4 {
+		return nil, errors.New("calldata is too short")
+	}
+	forward := t.forwarderABI.Methods["forward"]
+	if bytes.Equal(data[:4], forward.ID) {
+		values, err := forward.Inputs.Unpack(data[4:])
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unpack forward call")
+		}
+		if to := values[0].(common.Address); to != t.contract {
+			return nil, errors.Errorf("transaction forwarded to %s", to)
+		}
+		data = values[1].([]byte)
+		if len(data) < 4 {
+			return nil, errors.New("forwarded calldata is too short")
+		}
+	}
+	transmit := t.aggregatorABI.Methods["transmit"]
+	if !bytes.Equal(data[:4], transmit.ID) {
+		return nil, errors.Errorf("unexpected method %x", data[:4])
+	}
+	values, err := transmit.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unpack transmit call")
+	}
+	_, events, err := DecodeEvents(values[1].([]byte))
+	return events, err
+}
@@ -0,0 +1,77 @@
+package attestation
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	evmClientMocks "github.com/smartcontractkit/chainlink/v2/core/chains/evm/client/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
+	lpmocks "github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestLogPollerTransmissions(t *testing.T) {
+	t.Parallel()
+
+	contract := common.HexToAddress("0xc")
+	lp := lpmocks.NewLogPoller(t)
+	client := evmClientMocks.NewClient(t)
+	tr, err := NewLogPollerTransmissions(lp, client, contract, 100, logger.TestLogger(t))
+	require.NoError(t, err)
+	transmissions := tr.(*logPollerTransmissions)
+
+	transmitData := func(events ...Event) []byte {
+		report, err2 := EncodeEvents(big.NewInt(1), events)
+		require.NoError(t, err2)
+		data, err2 := transmissions.aggregatorABI.Pack("transmit", [3][32]byte{}, report, [][32]byte{}, [][32]byte{}, [32]byte{})
+		require.NoError(t, err2)
+		return data
+	}
+	forwardData := func(to common.Address, data []byte) []byte {
+		forwarded, err2 := transmissions.forwarderABI.Pack("forward", to, data)
+		require.NoError(t, err2)
+		return forwarded
+	}
+	e1, e2, e3, e4 := newEvent(1, 0, 1), newEvent(2, 0, 1), newEvent(3, 0, 1), newEvent(4, 0, 1)
+	txs := map[common.Hash][]byte{
+		common.HexToHash("0x1"): transmitData(e1, e2),
+		common.HexToHash("0x2"): forwardData(contract, transmitData(e3)),
+		common.HexToHash("0x3"): forwardData(common.HexToAddress("0xd"), transmitData(e4)),
+		common.HexToHash("0x4"): {1, 2, 3},
+	}
+	var logs []logpoller.Log
+	for hash := range txs {
+		logs = append(logs, logpoller.Log{BlockNumber: 150, TxHash: hash})
+	}
+
+	lp.On("LatestBlock", mock.Anything).Return(int64(200), nil)
+	lp.On("Logs", int64(101), int64(200), transmissions.transmittedSig, contract, mock.Anything).Return(logs, nil)
+	client.On("BatchCallContext", mock.Anything, mock.MatchedBy(func(b []rpc.BatchElem) bool {
+		return len(b) == len(txs)
+	})).Return(nil).Run(func(args mock.Arguments) {
+		for _, elem := range args.Get(1).([]rpc.BatchElem) {
+			hash := elem.Args[0].(common.Hash)
+			assert.Equal(t, "eth_getTransactionByHash", elem.Method)
+			*elem.Result.(*gethtypes.Transaction) = *gethtypes.NewTx(&gethtypes.LegacyTx{Data: txs[hash]})
+		}
+	}).Once()
+
+	// Transmissions forwarded to other contracts and undecodable ones deliver
+	// no events.
+	events, err := transmissions.Events(testutils.Context(t))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []Event{e1, e2, e3}, events)
+
+	// Transactions are fetched once.
+	events, err = transmissions.Events(testutils.Context(t))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []Event{e1, e2, e3}, events)
+}
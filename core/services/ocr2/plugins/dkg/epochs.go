@@ -0,0 +1,96 @@
+package dkg
+
+import (
+	"encoding/hex"
+
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/dkg/persistence"
+)
+
+// signaturePKsField is the protobuf field number of the signing public keys in
+// the DKG offchain config.
+const signaturePKsField protowire.Number = 3
+
+var _ types.ReportingPluginFactory = &epochRecordingFactory{}
+
+// epochRecordingFactory records a DKG epoch in the share DB every time OCR2
+// instantiates the DKG reporting plugin, i.e. on every config change.
+//
+// The epochs are status only: the DKG reporting plugin still deals a new key
+// for every config digest, and share records are bound to the digest they
+// were dealt under. Keys are not reshared to a new committee.
+type epochRecordingFactory struct {
+	types.ReportingPluginFactory
+	shareDB persistence.ShareDB
+	keyID   [32]byte
+	lggr    logger.Logger
+}
+
+func newEpochRecordingFactory(
+	factory types.ReportingPluginFactory,
+	shareDB persistence.ShareDB,
+	keyID [32]byte,
+	lggr logger.Logger,
+) *epochRecordingFactory {
+	return &epochRecordingFactory{
+		ReportingPluginFactory: factory,
+		shareDB:                shareDB,
+		keyID:                  keyID,
+		lggr:                   lggr.Named("DKGEpochs"),
+	}
+}
+
+// NewReportingPlugin records the epoch of the given config and delegates to
+// the wrapped factory. Failing to record the epoch does not prevent the DKG
+// from running.
+func (f *epochRecordingFactory) NewReportingPlugin(c types.ReportingPluginConfig) (types.ReportingPlugin, types.ReportingPluginInfo, error) {
+	lggr := f.lggr.With("keyID", hex.EncodeToString(f.keyID[:]), "configDigest", c.ConfigDigest.Hex())
+	if committee, err := DecodeCommittee(c.OffchainConfig); err != nil {
+		lggr.Errorw("Failed to decode DKG committee from offchain config", "err", err)
+	} else if epoch, err := f.shareDB.RecordEpoch(c.ConfigDigest, f.keyID, committee, c.F); err != nil {
+		lggr.Errorw("Failed to record DKG epoch", "err", err)
+	} else if epoch.CommitteeChanged {
+		lggr.Warnw("DKG committee changed: the new committee will deal a new key",
+			"epoch", epoch.Epoch,
+			"previousConfigDigest", hex.EncodeToString(epoch.PreviousConfigDigest),
+			"committeeSize", len(committee))
+	} else {
+		lggr.Infow("Recorded DKG epoch", "epoch", epoch.Epoch, "committeeSize", len(committee))
+	}
+	return f.ReportingPluginFactory.NewReportingPlugin(c)
+}
+
+// DecodeCommittee returns the signing public keys of the DKG committee, in
+// player order, from the binary DKG offchain config.
+func DecodeCommittee(offchainConfig []byte) ([][]byte, error) {
+	var committee [][]byte
+	for b := offchainConfig; len(b) > 0; {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil, errors.Wrap(protowire.ParseError(n), "invalid tag")
+		}
+		b = b[n:]
+		if num == signaturePKsField && typ == protowire.BytesType {
+			v, m := protowire.ConsumeBytes(b)
+			if m < 0 {
+				return nil, errors.Wrap(protowire.ParseError(m), "invalid signing public key")
+			}
+			committee = append(committee, append([]byte{}, v...))
+			b = b[m:]
+			continue
+		}
+		m := protowire.ConsumeFieldValue(num, typ, b)
+		if m < 0 {
+			return nil, errors.Wrapf(protowire.ParseError(m), "invalid field %d", num)
+		}
+		b = b[m:]
+	}
+	if len(committee) == 0 {
+		return nil, errors.New("no signing public keys in DKG offchain config")
+	}
+	return committee, nil
+}
@@ -0,0 +1,126 @@
+package dkg
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/ocr2vrf/altbn_128"
+	"github.com/smartcontractkit/ocr2vrf/dkg"
+	ocr2vrftypes "github.com/smartcontractkit/ocr2vrf/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/dkgencryptkey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/dkgsignkey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/dkg/persistence"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+)
+
+func offchainConfig(t *testing.T, n int) ([]byte, [][]byte) {
+	var (
+		signingPubKeys []kyber.Point
+		encryptPubKeys []kyber.Point
+		committee      [][]byte
+	)
+	for i := 1; i <= n; i++ {
+		signKey := dkgsignkey.MustNewXXXTestingOnly(big.NewInt(int64(i)))
+		encryptKey := dkgencryptkey.MustNewXXXTestingOnly(big.NewInt(int64(i)))
+		signingPubKeys = append(signingPubKeys, signKey.PublicKey)
+		encryptPubKeys = append(encryptPubKeys, encryptKey.PublicKey)
+		b, err := signKey.PublicKey.MarshalBinary()
+		require.NoError(t, err)
+		committee = append(committee, b)
+	}
+	config, err := dkg.OffchainConfig(encryptPubKeys, signingPubKeys, &altbn_128.G1{}, &ocr2vrftypes.PairingTranslation{
+		Suite: &altbn_128.PairingSuite{},
+	})
+	require.NoError(t, err)
+	return config, committee
+}
+
+func TestDecodeCommittee(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid config", func(t *testing.T) {
+		config, expected := offchainConfig(t, 4)
+		committee, err := DecodeCommittee(config)
+		require.NoError(t, err)
+		assert.Equal(t, expected, committee)
+	})
+
+	t.Run("empty config", func(t *testing.T) {
+		_, err := DecodeCommittee(nil)
+		assert.EqualError(t, err, "no signing public keys in DKG offchain config")
+	})
+
+	t.Run("truncated config", func(t *testing.T) {
+		config, _ := offchainConfig(t, 4)
+		_, err := DecodeCommittee(config[:len(config)/2])
+		assert.Error(t, err)
+	})
+}
+
+type fakeShareDB struct {
+	persistence.ShareDB
+	recorded [][][]byte
+	err      error
+	changed  bool
+}
+
+func (s *fakeShareDB) RecordEpoch(cfgDgst types.ConfigDigest, keyID [32]byte, committee [][]byte, f int, qopts ...pg.QOpt) (persistence.DKGEpoch, error) {
+	if s.err != nil {
+		return persistence.DKGEpoch{}, s.err
+	}
+	s.recorded = append(s.recorded, committee)
+	return persistence.DKGEpoch{Epoch: int64(len(s.recorded)), CommitteeChanged: s.changed}, nil
+}
+
+type fakeFactory struct {
+	configs []types.ReportingPluginConfig
+}
+
+func (f *fakeFactory) NewReportingPlugin(c types.ReportingPluginConfig) (types.ReportingPlugin, types.ReportingPluginInfo, error) {
+	f.configs = append(f.configs, c)
+	return nil, types.ReportingPluginInfo{Name: "dkg"}, nil
+}
+
+func TestEpochRecordingFactory(t *testing.T) {
+	t.Parallel()
+
+	config, committee := offchainConfig(t, 4)
+	pluginConfig := types.ReportingPluginConfig{
+		ConfigDigest:   testutils.Random32Byte(),
+		N:              4,
+		F:              1,
+		OffchainConfig: config,
+	}
+
+	t.Run("records the epoch", func(t *testing.T) {
+		shareDB, factory := &fakeShareDB{changed: true}, &fakeFactory{}
+		f := newEpochRecordingFactory(factory, shareDB, testutils.Random32Byte(), logger.TestLogger(t))
+		_, info, err := f.NewReportingPlugin(pluginConfig)
+		require.NoError(t, err)
+		assert.Equal(t, "dkg", info.Name)
+		assert.Equal(t, [][][]byte{committee}, shareDB.recorded)
+		assert.Equal(t, []types.ReportingPluginConfig{pluginConfig}, factory.configs)
+	})
+
+	t.Run("delegates when the epoch cannot be recorded", func(t *testing.T) {
+		shareDB, factory := &fakeShareDB{err: errors.New("boom")}, &fakeFactory{}
+		f := newEpochRecordingFactory(factory, shareDB, testutils.Random32Byte(), logger.TestLogger(t))
+		_, _, err := f.NewReportingPlugin(pluginConfig)
+		require.NoError(t, err)
+		assert.Len(t, factory.configs, 1)
+
+		invalid := pluginConfig
+		invalid.OffchainConfig = nil
+		_, _, err = f.NewReportingPlugin(invalid)
+		require.NoError(t, err)
+		assert.Len(t, factory.configs, 2)
+	})
+}
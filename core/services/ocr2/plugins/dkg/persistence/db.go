@@ -23,7 +23,7 @@ import (
 )
 
 var (
-	_        ocr2vrftypes.DKGSharePersistence = &shareDB{}
+	_        ShareDB = &shareDB{}
 	zeroHash hash.Hash
 	buckets  = []float64{
 		float64(100 * time.Millisecond),
@@ -50,6 +50,19 @@ var (
 	}, labels)
 )
 
+// ShareDB persists the DKG share records and keeps track of the DKG epochs
+// of each key.
+type ShareDB interface {
+	ocr2vrftypes.DKGSharePersistence
+
+	// RecordEpoch records the epoch for the given config digest of a key, if
+	// it has not been recorded yet, and returns it.
+	RecordEpoch(cfgDgst ocrtypes.ConfigDigest, keyID [32]byte, committee [][]byte, f int, qopts ...pg.QOpt) (DKGEpoch, error)
+	// KeyStatus returns the recorded epochs of the given key, or of all keys
+	// if keyID is nil, ordered by key ID and epoch.
+	KeyStatus(keyID *[32]byte, qopts ...pg.QOpt) ([]DKGEpochStatus, error)
+}
+
 type shareDB struct {
 	q         pg.Q
 	lggr      logger.Logger
@@ -58,7 +71,7 @@ type shareDB struct {
 }
 
 // NewShareDB creates a new DKG share database.
-func NewShareDB(db *sqlx.DB, lggr logger.Logger, cfg pg.QConfig, chainID *big.Int, chainType relay.Network) ocr2vrftypes.DKGSharePersistence {
+func NewShareDB(db *sqlx.DB, lggr logger.Logger, cfg pg.QConfig, chainID *big.Int, chainType relay.Network) ShareDB {
 	return &shareDB{
 		q:         pg.NewQ(db, lggr, cfg),
 		lggr:      lggr,
@@ -211,6 +224,80 @@ WHERE config_digest = :config_digest
 	return retrievedShares, nil
 }
 
+// RecordEpoch records a new epoch for the key the first time it is run under
+// cfgDgst. The committee is compared with the one of the latest epoch of the
+// key, so that operators can tell which config changes replaced DKG members.
+func (s *shareDB) RecordEpoch(
+	cfgDgst ocrtypes.ConfigDigest,
+	keyID [32]byte,
+	committee [][]byte,
+	f int,
+	qopts ...pg.QOpt,
+) (epoch DKGEpoch, err error) {
+	err = s.q.WithOpts(qopts...).Transaction(func(tx pg.Queryer) error {
+		err2 := tx.Get(&epoch, `SELECT * FROM dkg_epochs WHERE key_id = $1 AND config_digest = $2`, keyID[:], cfgDgst[:])
+		if err2 == nil {
+			return nil
+		} else if !errors.Is(err2, sql.ErrNoRows) {
+			return errors.Wrap(err2, "failed to load DKG epoch")
+		}
+
+		var previous DKGEpoch
+		err2 = tx.Get(&previous, `SELECT * FROM dkg_epochs WHERE key_id = $1 ORDER BY epoch DESC LIMIT 1`, keyID[:])
+		if err2 != nil && !errors.Is(err2, sql.ErrNoRows) {
+			return errors.Wrap(err2, "failed to load previous DKG epoch")
+		}
+
+		epoch = DKGEpoch{
+			KeyID:        keyID[:],
+			ConfigDigest: cfgDgst[:],
+			Epoch:        previous.Epoch + 1,
+			Committee:    committee,
+			F:            f,
+		}
+		if previous.Epoch > 0 {
+			epoch.PreviousConfigDigest = previous.ConfigDigest
+			epoch.CommitteeChanged = !sameCommittee(previous.Committee, committee)
+		}
+		return errors.Wrap(tx.Get(&epoch, `
+INSERT INTO dkg_epochs (key_id, config_digest, epoch, committee, f, previous_config_digest, committee_changed, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+RETURNING *`, epoch.KeyID, epoch.ConfigDigest, epoch.Epoch, epoch.Committee, epoch.F, epoch.PreviousConfigDigest, epoch.CommitteeChanged,
+		), "failed to insert DKG epoch")
+	})
+	return
+}
+
+// KeyStatus returns the recorded DKG epochs along with the number of share
+// records persisted for each of them.
+func (s *shareDB) KeyStatus(keyID *[32]byte, qopts ...pg.QOpt) (statuses []DKGEpochStatus, err error) {
+	query := `
+SELECT e.*, (
+	SELECT COUNT(*) FROM dkg_shares s WHERE s.key_id = e.key_id AND s.config_digest = e.config_digest
+) AS num_shares
+FROM dkg_epochs e`
+	var args []interface{}
+	if keyID != nil {
+		query += ` WHERE e.key_id = $1`
+		args = append(args, keyID[:])
+	}
+	query += ` ORDER BY e.key_id, e.epoch`
+	err = s.q.WithOpts(qopts...).Select(&statuses, query, args...)
+	return statuses, errors.Wrap(err, "failed to load DKG epochs")
+}
+
+func sameCommittee(a, b [][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !bytes.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
 func shareHashes(shareRecords []ocr2vrftypes.PersistentShareSetRecord) []string {
 	r := make([]string, len(shareRecords))
 	for i, record := range shareRecords {
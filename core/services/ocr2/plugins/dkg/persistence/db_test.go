@@ -19,7 +19,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services/relay"
 )
 
-func setup(t testing.TB) (ocr2vrftypes.DKGSharePersistence, *sqlx.DB) {
+func setup(t testing.TB) (ShareDB, *sqlx.DB) {
 	db := pgtest.NewSqlxDB(t)
 	lggr := logger.TestLogger(t)
 	return NewShareDB(db, lggr, pgtest.NewQConfig(true), big.NewInt(1337), relay.EVM), db
@@ -175,3 +175,66 @@ func TestShareDBE2E(t *testing.T) {
 
 	require.Equal(t, len(expectedRecords), numAssertions)
 }
+
+func TestShareDB_Epochs(t *testing.T) {
+	shareDB, _ := setup(t)
+	keyID := testutils.Random32Byte()
+	otherKeyID := testutils.Random32Byte()
+	committee := [][]byte{{1}, {2}, {3}, {4}}
+
+	first := testutils.Random32Byte()
+	epoch, err := shareDB.RecordEpoch(first, keyID, committee, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), epoch.Epoch)
+	assert.Nil(t, epoch.PreviousConfigDigest)
+	assert.False(t, epoch.CommitteeChanged)
+
+	// Recording the same config digest again is a no-op.
+	again, err := shareDB.RecordEpoch(first, keyID, committee, 1)
+	require.NoError(t, err)
+	assert.Equal(t, epoch.Epoch, again.Epoch)
+	assert.Equal(t, epoch.CreatedAt, again.CreatedAt)
+
+	second := testutils.Random32Byte()
+	epoch, err = shareDB.RecordEpoch(second, keyID, committee, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), epoch.Epoch)
+	assert.Equal(t, first[:], epoch.PreviousConfigDigest)
+	assert.False(t, epoch.CommitteeChanged)
+
+	third := testutils.Random32Byte()
+	epoch, err = shareDB.RecordEpoch(third, keyID, [][]byte{{1}, {2}, {3}, {5}}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), epoch.Epoch)
+	assert.Equal(t, second[:], epoch.PreviousConfigDigest)
+	assert.True(t, epoch.CommitteeChanged)
+
+	epoch, err = shareDB.RecordEpoch(testutils.Random32Byte(), otherKeyID, committee, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), epoch.Epoch)
+
+	b := ocr2vrftypes.RawMarshalPlayerIdxInt(ocr2vrftypes.PlayerIdxInt(1))
+	dealer, _, err := ocr2vrftypes.UnmarshalPlayerIdx(b)
+	require.NoError(t, err)
+	shareRecord := crypto.Keccak256Hash([]byte("1"))
+	require.NoError(t, shareDB.WriteShareRecords(context.TODO(), third, keyID, []ocr2vrftypes.PersistentShareSetRecord{{
+		Dealer:               *dealer,
+		MarshaledShareRecord: shareRecord[:],
+		Hash:                 hash.GetHash(shareRecord[:]),
+	}}))
+
+	statuses, err := shareDB.KeyStatus(&keyID)
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+	for i, s := range statuses {
+		assert.Equal(t, int64(i+1), s.Epoch)
+		assert.Equal(t, keyID[:], s.KeyID)
+	}
+	assert.Equal(t, 0, statuses[1].NumShares)
+	assert.Equal(t, 1, statuses[2].NumShares)
+	assert.Equal(t, [][]byte{{1}, {2}, {3}, {5}}, [][]byte(statuses[2].Committee))
+
+	statuses, err = shareDB.KeyStatus(nil)
+	require.NoError(t, err)
+	assert.Len(t, statuses, 4)
+}
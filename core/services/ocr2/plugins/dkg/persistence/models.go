@@ -1,5 +1,11 @@
 package persistence
 
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
 type dkgShare struct {
 	ConfigDigest         []byte `db:"config_digest"`
 	KeyID                []byte `db:"key_id"`
@@ -7,3 +13,27 @@ type dkgShare struct {
 	MarshaledShareRecord []byte `db:"marshaled_share_record"`
 	RecordHash           []byte `db:"record_hash"`
 }
+
+// DKGEpoch is a single run of the DKG for a key ID under one OCR2 config.
+// A new epoch is recorded each time the config digest of a key changes.
+type DKGEpoch struct {
+	KeyID        []byte `db:"key_id"`
+	ConfigDigest []byte `db:"config_digest"`
+	Epoch        int64  `db:"epoch"`
+	// Committee holds the DKG signing public keys of the committee, in
+	// player order.
+	Committee            pq.ByteaArray `db:"committee"`
+	F                    int           `db:"f"`
+	PreviousConfigDigest []byte        `db:"previous_config_digest"`
+	// CommitteeChanged is true if the committee differs from the one of the
+	// previous epoch.
+	CommitteeChanged bool      `db:"committee_changed"`
+	CreatedAt        time.Time `db:"created_at"`
+}
+
+// DKGEpochStatus is a DKGEpoch with the number of share records persisted
+// for it.
+type DKGEpochStatus struct {
+	DKGEpoch
+	NumShares int `db:"num_shares"`
+}
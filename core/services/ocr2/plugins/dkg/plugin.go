@@ -68,14 +68,19 @@ func NewDKGServices(
 		return nil, errors.Wrap(err, "decode key ID")
 	}
 	shareDB := persistence.NewShareDB(db, lggr.Named("DKGShareDB"), qConfig, chainID, network)
-	oracleArgsNoPlugin.ReportingPluginFactory = dkg.NewReportingPluginFactory(
-		encryptKey.KyberScalar(),
-		signKey.KyberScalar(),
-		keyID,
-		onchainContract,
-		ocrLogger,
-		keyConsumer,
+	oracleArgsNoPlugin.ReportingPluginFactory = newEpochRecordingFactory(
+		dkg.NewReportingPluginFactory(
+			encryptKey.KyberScalar(),
+			signKey.KyberScalar(),
+			keyID,
+			onchainContract,
+			ocrLogger,
+			keyConsumer,
+			shareDB,
+		),
 		shareDB,
+		keyID,
+		lggr,
 	)
 	oracle, err := libocr2.NewOracle(oracleArgsNoPlugin)
 	if err != nil {
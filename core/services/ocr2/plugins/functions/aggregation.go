@@ -3,21 +3,179 @@ package functions
 import (
 	"bytes"
 	"fmt"
+	"math/big"
 	"sort"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
+
 	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/functions/config"
 )
 
-func CanAggregate(N int, F int, observations []*ProcessedRequest) bool {
-	return N > 0 && F >= 0 && len(observations) > 0 && len(observations) <= N && len(observations) >= 2*F+1
+// ErrUnparsableResult is the error of results which the typed aggregation
+// methods cannot decode. Such results are aggregated as errors, so that all
+// oracles come to the same outcome.
+const ErrUnparsableResult = "unparsable result"
+
+// maxTrimmedMeanFractionBps bounds the fraction trimmed from each end, so that
+// trimmed means keep at least one result.
+const maxTrimmedMeanFractionBps = 5000
+
+type numericAggregation int
+
+const (
+	numericMedian numericAggregation = iota
+	numericMean
+	numericTrimmedMean
+)
+
+// numericMethods maps the typed aggregation methods to their aggregation and
+// the ABI type of the values they aggregate.
+var numericMethods = map[config.AggregationMethod]struct {
+	aggregation numericAggregation
+	abiType     string
+}{
+	config.AggregationMethod_AGGREGATION_MEDIAN_INT256:        {numericMedian, "int256"},
+	config.AggregationMethod_AGGREGATION_MEDIAN_UINT256:       {numericMedian, "uint256"},
+	config.AggregationMethod_AGGREGATION_MEAN_INT256:          {numericMean, "int256"},
+	config.AggregationMethod_AGGREGATION_MEAN_UINT256:         {numericMean, "uint256"},
+	config.AggregationMethod_AGGREGATION_TRIMMED_MEAN_INT256:  {numericTrimmedMean, "int256"},
+	config.AggregationMethod_AGGREGATION_TRIMMED_MEAN_UINT256: {numericTrimmedMean, "uint256"},
+}
+
+// AggregationConfig selects how the results of a request observed by the
+// oracles are aggregated.
+type AggregationConfig struct {
+	Method config.AggregationMethod
+	// TrimmedMeanFractionBps is the fraction of the results dropped from each
+	// end by the trimmed mean methods, in basis points.
+	TrimmedMeanFractionBps uint32
+	// TupleFields are the fields of the results of AGGREGATION_TUPLE.
+	TupleFields []*config.TupleField
+}
+
+func NewAggregationConfig(cfg *config.ReportingPluginConfig) AggregationConfig {
+	return AggregationConfig{
+		Method:                 cfg.GetDefaultAggregationMethod(),
+		TrimmedMeanFractionBps: cfg.GetTrimmedMeanFractionBps(),
+		TupleFields:            cfg.GetTupleFields(),
+	}
+}
+
+// ValidateAggregationConfig checks that the methods are supported, and that
+// tuple fields are aggregated with methods matching their types.
+func ValidateAggregationConfig(aggCfg AggregationConfig) error {
+	switch aggCfg.Method {
+	case config.AggregationMethod_AGGREGATION_MODE, config.AggregationMethod_AGGREGATION_MEDIAN:
+	case config.AggregationMethod_AGGREGATION_TUPLE:
+		if len(aggCfg.TupleFields) == 0 {
+			return fmt.Errorf("aggregation method %s requires tuple fields", aggCfg.Method)
+		}
+		for i, field := range aggCfg.TupleFields {
+			if _, err := abi.NewType(field.GetType(), "", nil); err != nil {
+				return fmt.Errorf("invalid type of tuple field %d: %w", i, err)
+			}
+			if field.GetAggregationMethod() == config.AggregationMethod_AGGREGATION_MODE {
+				continue
+			}
+			numeric, ok := numericMethods[field.GetAggregationMethod()]
+			if !ok {
+				return fmt.Errorf("unsupported aggregation method for tuple field %d: %s", i, field.GetAggregationMethod())
+			}
+			if numeric.abiType != field.GetType() {
+				return fmt.Errorf("aggregation method %s of tuple field %d requires type %s, got: %s", field.GetAggregationMethod(), i, numeric.abiType, field.GetType())
+			}
+		}
+	default:
+		if _, ok := numericMethods[aggCfg.Method]; !ok {
+			return fmt.Errorf("unsupported aggregation method: %s", aggCfg.Method)
+		}
+	}
+	if aggCfg.usesTrimmedMean() && aggCfg.TrimmedMeanFractionBps >= maxTrimmedMeanFractionBps {
+		return fmt.Errorf("trimmed mean fraction must be below %d basis points, got: %d", maxTrimmedMeanFractionBps, aggCfg.TrimmedMeanFractionBps)
+	}
+	return nil
+}
+
+func (c AggregationConfig) usesTrimmedMean() bool {
+	methods := []config.AggregationMethod{c.Method}
+	if c.Method == config.AggregationMethod_AGGREGATION_TUPLE {
+		for _, field := range c.TupleFields {
+			methods = append(methods, field.GetAggregationMethod())
+		}
+	}
+	for _, m := range methods {
+		if numeric, ok := numericMethods[m]; ok && numeric.aggregation == numericTrimmedMean {
+			return true
+		}
+	}
+	return false
+}
+
+// resultArguments returns the ABI types of the results decoded by typed
+// aggregation methods, or nil for methods aggregating raw bytes.
+func (c AggregationConfig) resultArguments() (abi.Arguments, error) {
+	var types []string
+	if c.Method == config.AggregationMethod_AGGREGATION_TUPLE {
+		for _, field := range c.TupleFields {
+			types = append(types, field.GetType())
+		}
+	} else if numeric, ok := numericMethods[c.Method]; ok {
+		types = append(types, numeric.abiType)
+	} else {
+		return nil, nil
+	}
+	args := make(abi.Arguments, len(types))
+	for i, t := range types {
+		abiType, err := abi.NewType(t, "", nil)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = abi.Argument{Type: abiType}
+	}
+	return args, nil
+}
+
+// CanAggregate returns true if there are enough observations of a request to
+// aggregate them. Trimmed means additionally need at least F+1 successful
+// results left after trimming, unless errors are the majority and are
+// aggregated instead.
+func CanAggregate(N int, F int, aggCfg AggregationConfig, observations []*ProcessedRequest) bool {
+	n := len(observations)
+	if !(N > 0 && F >= 0 && n > 0 && n <= N && n >= 2*F+1) {
+		return false
+	}
+	if aggCfg.usesTrimmedMean() {
+		args, err := aggCfg.resultArguments()
+		if err != nil {
+			return false
+		}
+		successful := 0
+		for _, obs := range observations {
+			if len(obs.GetError()) > 0 {
+				continue
+			}
+			if _, err := decodeResult(args, obs.GetResult()); err == nil {
+				successful++
+			}
+		}
+		if successful >= n-successful && successful-2*trimCount(successful, aggCfg.TrimmedMeanFractionBps) < F+1 {
+			return false
+		}
+	}
+	return true
 }
 
-func Aggregate(aggMethod config.AggregationMethod, observations []*ProcessedRequest) (*ProcessedRequest, error) {
+func Aggregate(aggCfg AggregationConfig, observations []*ProcessedRequest) (*ProcessedRequest, error) {
 	if len(observations) == 0 {
 		return nil, fmt.Errorf("empty observation list passed for aggregation")
 	}
+	args, err := aggCfg.resultArguments()
+	if err != nil {
+		return nil, err
+	}
 	var errored []*ProcessedRequest
 	var successful []*ProcessedRequest
+	var decoded [][]interface{}
 	reqId := observations[0].RequestID
 	finalResult := ProcessedRequest{
 		RequestID: reqId,
@@ -30,8 +188,13 @@ func Aggregate(aggMethod config.AggregationMethod, observations []*ProcessedRequ
 		}
 		if obs.GetError() != nil && len(obs.GetError()) > 0 {
 			errored = append(errored, obs)
+		} else if args == nil {
+			successful = append(successful, obs)
+		} else if values, err := decodeResult(args, obs.Result); err != nil {
+			errored = append(errored, &ProcessedRequest{RequestID: obs.RequestID, Error: []byte(ErrUnparsableResult)})
 		} else {
 			successful = append(successful, obs)
+			decoded = append(decoded, values)
 		}
 	}
 	var rawData [][]byte
@@ -46,32 +209,115 @@ func Aggregate(aggMethod config.AggregationMethod, observations []*ProcessedRequ
 	for _, item := range successful {
 		rawData = append(rawData, item.Result)
 	}
-	switch aggMethod {
+	switch aggCfg.Method {
 	case config.AggregationMethod_AGGREGATION_MODE:
 		finalResult.Result = aggregateMode(rawData)
 		return &finalResult, nil
 	case config.AggregationMethod_AGGREGATION_MEDIAN:
 		finalResult.Result = aggregateMedian(rawData)
 		return &finalResult, nil
-	default:
-		return nil, fmt.Errorf("unsupported aggregation method: %s", aggMethod)
+	case config.AggregationMethod_AGGREGATION_TUPLE:
+		finalResult.Result, err = aggregateTuple(args, aggCfg, decoded)
+		return &finalResult, err
+	}
+	if numeric, ok := numericMethods[aggCfg.Method]; ok {
+		aggregated := aggregateNumeric(numeric.aggregation, column(decoded, 0), aggCfg.TrimmedMeanFractionBps)
+		finalResult.Result, err = args.Pack(aggregated)
+		return &finalResult, err
+	}
+	return nil, fmt.Errorf("unsupported aggregation method: %s", aggCfg.Method)
+}
+
+// decodeResult decodes a result ABI-encoded with args. Only the canonical
+// encoding is accepted, so that all oracles parse results alike.
+func decodeResult(args abi.Arguments, result []byte) ([]interface{}, error) {
+	values, err := args.Unpack(result)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := args.Pack(values...)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(encoded, result) {
+		return nil, fmt.Errorf("non-canonical encoding")
+	}
+	return values, nil
+}
+
+func column(decoded [][]interface{}, i int) []*big.Int {
+	values := make([]*big.Int, len(decoded))
+	for j, d := range decoded {
+		values[j] = d[i].(*big.Int)
+	}
+	return values
+}
+
+func aggregateTuple(args abi.Arguments, aggCfg AggregationConfig, decoded [][]interface{}) ([]byte, error) {
+	aggregated := make([]interface{}, len(args))
+	for i, field := range aggCfg.TupleFields {
+		if numeric, ok := numericMethods[field.GetAggregationMethod()]; ok {
+			aggregated[i] = aggregateNumeric(numeric.aggregation, column(decoded, i), aggCfg.TrimmedMeanFractionBps)
+			continue
+		}
+		// MODE compares the encodings of the field
+		fieldArgs := abi.Arguments{args[i]}
+		encoded := make([][]byte, len(decoded))
+		for j, d := range decoded {
+			var err error
+			if encoded[j], err = fieldArgs.Pack(d[i]); err != nil {
+				return nil, err
+			}
+		}
+		aggregated[i] = decoded[aggregateModeIndex(encoded)][i]
+	}
+	return args.Pack(aggregated...)
+}
+
+func trimCount(n int, fractionBps uint32) int {
+	return n * int(fractionBps) / 10000
+}
+
+// aggregateNumeric returns the median, the mean or the trimmed mean of values.
+// Means are rounded towards zero.
+func aggregateNumeric(aggregation numericAggregation, values []*big.Int, trimmedMeanFractionBps uint32) *big.Int {
+	sorted := append([]*big.Int{}, values...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Cmp(sorted[j]) < 0
+	})
+	switch aggregation {
+	case numericMedian:
+		return sorted[(len(sorted)-1)/2]
+	case numericTrimmedMean:
+		k := trimCount(len(sorted), trimmedMeanFractionBps)
+		sorted = sorted[k : len(sorted)-k]
+	}
+	sum := new(big.Int)
+	for _, v := range sorted {
+		sum.Add(sum, v)
 	}
+	return sum.Quo(sum, big.NewInt(int64(len(sorted))))
 }
 
 func aggregateMode(items [][]byte) []byte {
+	return items[aggregateModeIndex(items)]
+}
+
+// aggregateModeIndex returns the index of the first of the most frequent items.
+func aggregateModeIndex(items [][]byte) int {
 	counts := make(map[string]int)
-	var mostFrequent []byte
+	mostFrequent := 0
 	highestFreq := 0
-	for _, item := range items {
+	for i, item := range items {
 		str := string(item)
 		currCount := counts[str] + 1
 		counts[str] = currCount
 		if currCount > highestFreq {
 			highestFreq = currCount
-			mostFrequent = item
+			mostFrequent = i
 		}
 	}
-	return []byte(mostFrequent)
+	return mostFrequent
 }
 
 func aggregateMedian(items [][]byte) []byte {
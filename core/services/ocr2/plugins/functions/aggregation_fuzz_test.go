@@ -0,0 +1,47 @@
+//go:build go1.18
+
+package functions_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/functions"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/functions/config"
+)
+
+// FuzzAggregate_Deterministic checks that the typed aggregation methods do not
+// panic on arbitrary results, and come to the same result regardless of the
+// order of the observations.
+func FuzzAggregate_Deterministic(f *testing.F) {
+	f.Add(int256(f, -5), int256(f, 3), []byte("abc"), uint256(f, 7))
+	f.Add(uint256(f, 0), uint256(f, 1), uint256(f, 2), uint256(f, 3))
+	f.Add([]byte{}, []byte{1}, int256(f, -1), append(int256(f, 1), 0))
+	f.Fuzz(func(t *testing.T, a, b, c, d []byte) {
+		methods := []config.AggregationMethod{
+			config.AggregationMethod_AGGREGATION_MEDIAN_INT256,
+			config.AggregationMethod_AGGREGATION_MEDIAN_UINT256,
+			config.AggregationMethod_AGGREGATION_MEAN_INT256,
+			config.AggregationMethod_AGGREGATION_MEAN_UINT256,
+			config.AggregationMethod_AGGREGATION_TRIMMED_MEAN_INT256,
+			config.AggregationMethod_AGGREGATION_TRIMMED_MEAN_UINT256,
+		}
+		for _, method := range methods {
+			aggCfg := functions.AggregationConfig{Method: method, TrimmedMeanFractionBps: 2500}
+			forward, err := functions.Aggregate(aggCfg, []*functions.ProcessedRequest{req(1, a, nil), req(1, b, nil), req(1, c, nil), req(1, d, nil)})
+			require.NoError(t, err)
+			again, err := functions.Aggregate(aggCfg, []*functions.ProcessedRequest{req(1, a, nil), req(1, b, nil), req(1, c, nil), req(1, d, nil)})
+			require.NoError(t, err)
+			require.Equal(t, forward, again)
+
+			// numeric aggregates do not depend on the order of observations,
+			// unless errors win and their mode is tied
+			backward, err := functions.Aggregate(aggCfg, []*functions.ProcessedRequest{req(1, d, nil), req(1, c, nil), req(1, b, nil), req(1, a, nil)})
+			require.NoError(t, err)
+			if len(forward.Error) == 0 {
+				require.Equal(t, forward, backward)
+			}
+		}
+	})
+}
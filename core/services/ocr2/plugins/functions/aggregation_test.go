@@ -1,9 +1,11 @@
 package functions_test
 
 import (
+	"math/big"
 	"strconv"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/stretchr/testify/require"
 
 	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/functions"
@@ -26,14 +28,44 @@ func TestCanAggregate(t *testing.T) {
 	t.Parallel()
 	obs := make([]*functions.ProcessedRequest, 10)
 
-	require.True(t, functions.CanAggregate(4, 1, obs[:4]))
-	require.True(t, functions.CanAggregate(4, 1, obs[:3]))
-	require.True(t, functions.CanAggregate(6, 1, obs[:3]))
+	mode := functions.AggregationConfig{}
 
-	require.False(t, functions.CanAggregate(4, 1, obs[:5]))
-	require.False(t, functions.CanAggregate(4, 1, obs[:2]))
-	require.False(t, functions.CanAggregate(4, 1, obs[:0]))
-	require.False(t, functions.CanAggregate(0, 0, obs[:0]))
+	require.True(t, functions.CanAggregate(4, 1, mode, obs[:4]))
+	require.True(t, functions.CanAggregate(4, 1, mode, obs[:3]))
+	require.True(t, functions.CanAggregate(6, 1, mode, obs[:3]))
+
+	require.False(t, functions.CanAggregate(4, 1, mode, obs[:5]))
+	require.False(t, functions.CanAggregate(4, 1, mode, obs[:2]))
+	require.False(t, functions.CanAggregate(4, 1, mode, obs[:0]))
+	require.False(t, functions.CanAggregate(0, 0, mode, obs[:0]))
+
+	results := []*functions.ProcessedRequest{
+		req(1, int256(t, 1), nil),
+		req(1, int256(t, 2), nil),
+		req(1, int256(t, 3), nil),
+		req(1, int256(t, 4), nil),
+	}
+	errored := reqS(1, "", "bug")
+	unparsable := reqS(1, "not an int256", "")
+
+	// trimming 40% from each end of 3 results leaves 1 < F+1
+	trimmed := functions.AggregationConfig{Method: config.AggregationMethod_AGGREGATION_TRIMMED_MEAN_INT256, TrimmedMeanFractionBps: 4000}
+	require.False(t, functions.CanAggregate(4, 1, trimmed, results[:3]))
+	require.True(t, functions.CanAggregate(4, 1, trimmed, results[:4]))
+	require.True(t, functions.CanAggregate(8, 1, trimmed, append(results[:4:4], errored)))
+
+	// only successful results are trimmed: 3 results and an error leave 1 < F+1
+	require.False(t, functions.CanAggregate(4, 1, trimmed, append(results[:3:3], errored)))
+	require.False(t, functions.CanAggregate(4, 1, trimmed, append(results[:3:3], unparsable)))
+
+	// errors are the majority and aggregated without trimming
+	require.True(t, functions.CanAggregate(4, 1, trimmed, []*functions.ProcessedRequest{results[0], errored, errored}))
+
+	tuple := functions.AggregationConfig{Method: config.AggregationMethod_AGGREGATION_TUPLE, TrimmedMeanFractionBps: 4000, TupleFields: []*config.TupleField{
+		{Type: "int256", AggregationMethod: config.AggregationMethod_AGGREGATION_TRIMMED_MEAN_INT256},
+	}}
+	require.False(t, functions.CanAggregate(4, 1, tuple, results[:3]))
+	require.True(t, functions.CanAggregate(4, 1, tuple, results[:4]))
 }
 
 func TestAggregate_Successful(t *testing.T) {
@@ -106,9 +138,165 @@ func TestAggregate_Successful(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			result, err := functions.Aggregate(test.mode, test.input)
+			result, err := functions.Aggregate(functions.AggregationConfig{Method: test.mode}, test.input)
 			require.NoError(t, err)
 			require.Equal(t, test.expected, result)
 		})
 	}
 }
+
+func abiEncode(t testing.TB, types []string, values ...interface{}) []byte {
+	var args abi.Arguments
+	for _, typ := range types {
+		abiType, err := abi.NewType(typ, "", nil)
+		require.NoError(t, err)
+		args = append(args, abi.Argument{Type: abiType})
+	}
+	b, err := args.Pack(values...)
+	require.NoError(t, err)
+	return b
+}
+
+func int256(t testing.TB, v int64) []byte {
+	return abiEncode(t, []string{"int256"}, big.NewInt(v))
+}
+
+func uint256(t testing.TB, v int64) []byte {
+	return abiEncode(t, []string{"uint256"}, big.NewInt(v))
+}
+
+func TestAggregate_Typed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		aggCfg   functions.AggregationConfig
+		input    []*functions.ProcessedRequest
+		expected *functions.ProcessedRequest
+	}{
+		{
+			"Median Int256",
+			functions.AggregationConfig{Method: config.AggregationMethod_AGGREGATION_MEDIAN_INT256},
+			[]*functions.ProcessedRequest{
+				req(21, int256(t, -5), nil),
+				req(21, int256(t, 3), nil),
+				req(21, int256(t, -100), nil),
+				req(21, int256(t, 7), nil),
+			},
+			req(21, int256(t, -5), []byte{}),
+		},
+		{
+			"Median Uint256",
+			functions.AggregationConfig{Method: config.AggregationMethod_AGGREGATION_MEDIAN_UINT256},
+			[]*functions.ProcessedRequest{
+				req(21, uint256(t, 256), nil),
+				req(21, uint256(t, 7), nil),
+				req(21, uint256(t, 19), nil),
+			},
+			req(21, uint256(t, 19), []byte{}),
+		},
+		{
+			"Mean Int256 rounds towards zero",
+			functions.AggregationConfig{Method: config.AggregationMethod_AGGREGATION_MEAN_INT256},
+			[]*functions.ProcessedRequest{
+				req(21, int256(t, -10), nil),
+				req(21, int256(t, -1), nil),
+				req(21, int256(t, 0), nil),
+			},
+			req(21, int256(t, -3), []byte{}),
+		},
+		{
+			"Mean Uint256",
+			functions.AggregationConfig{Method: config.AggregationMethod_AGGREGATION_MEAN_UINT256},
+			[]*functions.ProcessedRequest{
+				req(21, uint256(t, 10), nil),
+				req(21, uint256(t, 20), nil),
+				req(21, uint256(t, 31), nil),
+			},
+			req(21, uint256(t, 20), []byte{}),
+		},
+		{
+			"Trimmed Mean",
+			functions.AggregationConfig{Method: config.AggregationMethod_AGGREGATION_TRIMMED_MEAN_UINT256, TrimmedMeanFractionBps: 2500},
+			[]*functions.ProcessedRequest{
+				req(21, uint256(t, 1000000), nil),
+				req(21, uint256(t, 10), nil),
+				req(21, uint256(t, 20), nil),
+				req(21, uint256(t, 0), nil),
+			},
+			req(21, uint256(t, 15), []byte{}),
+		},
+		{
+			"Unparsable results are dropped",
+			functions.AggregationConfig{Method: config.AggregationMethod_AGGREGATION_MEDIAN_INT256},
+			[]*functions.ProcessedRequest{
+				req(21, int256(t, 1), nil),
+				reqS(21, "abc", ""),
+				req(21, int256(t, 2), nil),
+			},
+			req(21, int256(t, 1), []byte{}),
+		},
+		{
+			"Mostly unparsable results are an error",
+			functions.AggregationConfig{Method: config.AggregationMethod_AGGREGATION_MEAN_INT256},
+			[]*functions.ProcessedRequest{
+				req(21, int256(t, 1), nil),
+				reqS(21, "abc", ""),
+				req(21, append(int256(t, 2), 0), nil),
+			},
+			reqS(21, "", functions.ErrUnparsableResult),
+		},
+		{
+			"Tuple",
+			functions.AggregationConfig{Method: config.AggregationMethod_AGGREGATION_TUPLE, TupleFields: []*config.TupleField{
+				{Type: "int256", AggregationMethod: config.AggregationMethod_AGGREGATION_MEDIAN_INT256},
+				{Type: "string", AggregationMethod: config.AggregationMethod_AGGREGATION_MODE},
+				{Type: "uint256", AggregationMethod: config.AggregationMethod_AGGREGATION_MEAN_UINT256},
+			}},
+			[]*functions.ProcessedRequest{
+				req(21, abiEncode(t, []string{"int256", "string", "uint256"}, big.NewInt(-1), "sunny", big.NewInt(10)), nil),
+				req(21, abiEncode(t, []string{"int256", "string", "uint256"}, big.NewInt(5), "rainy", big.NewInt(20)), nil),
+				req(21, abiEncode(t, []string{"int256", "string", "uint256"}, big.NewInt(3), "sunny", big.NewInt(30)), nil),
+			},
+			req(21, abiEncode(t, []string{"int256", "string", "uint256"}, big.NewInt(3), "sunny", big.NewInt(20)), []byte{}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.NoError(t, functions.ValidateAggregationConfig(test.aggCfg))
+			result, err := functions.Aggregate(test.aggCfg, test.input)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestValidateAggregationConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		aggCfg functions.AggregationConfig
+		err    string
+	}{
+		{"unknown method", functions.AggregationConfig{Method: 100}, "unsupported aggregation method: 100"},
+		{"trim too large", functions.AggregationConfig{Method: config.AggregationMethod_AGGREGATION_TRIMMED_MEAN_INT256, TrimmedMeanFractionBps: 5000}, "trimmed mean fraction must be below 5000 basis points, got: 5000"},
+		{"tuple without fields", functions.AggregationConfig{Method: config.AggregationMethod_AGGREGATION_TUPLE}, "aggregation method AGGREGATION_TUPLE requires tuple fields"},
+		{"tuple with invalid type", functions.AggregationConfig{Method: config.AggregationMethod_AGGREGATION_TUPLE, TupleFields: []*config.TupleField{
+			{Type: "foo"},
+		}}, "invalid type of tuple field 0: unsupported arg type: foo"},
+		{"tuple with mismatched type", functions.AggregationConfig{Method: config.AggregationMethod_AGGREGATION_TUPLE, TupleFields: []*config.TupleField{
+			{Type: "uint256", AggregationMethod: config.AggregationMethod_AGGREGATION_MEDIAN_INT256},
+		}}, "aggregation method AGGREGATION_MEDIAN_INT256 of tuple field 0 requires type int256, got: uint256"},
+		{"tuple with raw median", functions.AggregationConfig{Method: config.AggregationMethod_AGGREGATION_TUPLE, TupleFields: []*config.TupleField{
+			{Type: "bytes", AggregationMethod: config.AggregationMethod_AGGREGATION_MEDIAN},
+		}}, "unsupported aggregation method for tuple field 0: AGGREGATION_MEDIAN"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.EqualError(t, functions.ValidateAggregationConfig(test.aggCfg), test.err)
+		})
+	}
+}
@@ -1,6 +1,12 @@
 package config
 
-import "google.golang.org/protobuf/proto"
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+)
 
 // This config is part of the job spec and is loaded only once on node boot/job creation.
 type PluginConfig struct {
@@ -10,9 +16,42 @@ type PluginConfig struct {
 	RequestTimeoutBatchLookupSize   uint32 `json:"requestTimeoutBatchLookupSize"`
 	ListenerEventHandlerTimeoutSec  uint32 `json:"listenerEventHandlerTimeoutSec"`
 	MaxRequestSizeBytes             uint32 `json:"maxRequestSizeBytes"`
+	// Sandbox selects how requests are executed: "pipeline" (default) or
+	// "local", which is only available in OCR development mode.
+	Sandbox                 string   `json:"sandbox"`
+	ExecutionTimeoutSec     uint32   `json:"executionTimeoutSec"`
+	MaxExecutionMemoryBytes uint64   `json:"maxExecutionMemoryBytes"`
+	AllowedDomains          []string `json:"allowedDomains"`
+	// Limits of the secrets users upload to the DON through the gateway.
+	MaxSecretsSizeBytes     uint32 `json:"maxSecretsSizeBytes"`
+	MaxSecretsSlotsPerOwner uint32 `json:"maxSecretsSlotsPerOwner"`
+	MaxSecretsTTLSec        uint32 `json:"maxSecretsTTLSec"`
+	// DecryptionQueueConfig is reserved for the threshold decryption of
+	// DON-hosted secrets, and rejected until a threshold decryption plugin
+	// serves the queue.
+	DecryptionQueueConfig *DecryptionQueueConfig `json:"decryptionQueueConfig"`
+}
+
+type DecryptionQueueConfig struct {
+	MaxQueueLength           uint32 `json:"maxQueueLength"`
+	MaxCiphertextBytes       uint32 `json:"maxCiphertextBytes"`
+	CompletedCacheTimeoutSec uint32 `json:"completedCacheTimeoutSec"`
 }
 
 func ValidatePluginConfig(config PluginConfig) error {
+	switch config.Sandbox {
+	case "", "pipeline", "local":
+	default:
+		return fmt.Errorf("invalid sandbox: %q, expected one of: pipeline, local", config.Sandbox)
+	}
+	for _, domain := range config.AllowedDomains {
+		if domain == "" || strings.Contains(domain, "/") {
+			return fmt.Errorf("invalid allowed domain: %q", domain)
+		}
+	}
+	if config.DecryptionQueueConfig != nil {
+		return errors.New("decryptionQueueConfig is not supported: no threshold decryption plugin serves the queue")
+	}
 	return nil
 }
 
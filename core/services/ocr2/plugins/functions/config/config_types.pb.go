@@ -25,6 +25,15 @@ type AggregationMethod int32
 const (
 	AggregationMethod_AGGREGATION_MODE   AggregationMethod = 0
 	AggregationMethod_AGGREGATION_MEDIAN AggregationMethod = 1
+	// Typed methods decode results as ABI-encoded 256-bit integers.
+	AggregationMethod_AGGREGATION_MEDIAN_INT256        AggregationMethod = 2
+	AggregationMethod_AGGREGATION_MEDIAN_UINT256       AggregationMethod = 3
+	AggregationMethod_AGGREGATION_MEAN_INT256          AggregationMethod = 4
+	AggregationMethod_AGGREGATION_MEAN_UINT256         AggregationMethod = 5
+	AggregationMethod_AGGREGATION_TRIMMED_MEAN_INT256  AggregationMethod = 6
+	AggregationMethod_AGGREGATION_TRIMMED_MEAN_UINT256 AggregationMethod = 7
+	// Decodes results as ABI-encoded tuples and aggregates each field with its own method.
+	AggregationMethod_AGGREGATION_TUPLE AggregationMethod = 8
 )
 
 // Enum value maps for AggregationMethod.
@@ -32,10 +41,24 @@ var (
 	AggregationMethod_name = map[int32]string{
 		0: "AGGREGATION_MODE",
 		1: "AGGREGATION_MEDIAN",
+		2: "AGGREGATION_MEDIAN_INT256",
+		3: "AGGREGATION_MEDIAN_UINT256",
+		4: "AGGREGATION_MEAN_INT256",
+		5: "AGGREGATION_MEAN_UINT256",
+		6: "AGGREGATION_TRIMMED_MEAN_INT256",
+		7: "AGGREGATION_TRIMMED_MEAN_UINT256",
+		8: "AGGREGATION_TUPLE",
 	}
 	AggregationMethod_value = map[string]int32{
-		"AGGREGATION_MODE":   0,
-		"AGGREGATION_MEDIAN": 1,
+		"AGGREGATION_MODE":                 0,
+		"AGGREGATION_MEDIAN":               1,
+		"AGGREGATION_MEDIAN_INT256":        2,
+		"AGGREGATION_MEDIAN_UINT256":       3,
+		"AGGREGATION_MEAN_INT256":          4,
+		"AGGREGATION_MEAN_UINT256":         5,
+		"AGGREGATION_TRIMMED_MEAN_INT256":  6,
+		"AGGREGATION_TRIMMED_MEAN_UINT256": 7,
+		"AGGREGATION_TUPLE":                8,
 	}
 )
 
@@ -66,6 +89,63 @@ func (AggregationMethod) EnumDescriptor() ([]byte, []int) {
 	return file_core_services_ocr2_plugins_functions_config_config_types_proto_rawDescGZIP(), []int{0}
 }
 
+type TupleField struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ABI type of the field, e.g. int256, uint256, bytes32, address, bool, string or bytes.
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// MODE, or a typed method matching the type of the field.
+	AggregationMethod AggregationMethod `protobuf:"varint,2,opt,name=aggregationMethod,proto3,enum=config_types.AggregationMethod" json:"aggregationMethod,omitempty"`
+}
+
+func (x *TupleField) Reset() {
+	*x = TupleField{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_core_services_ocr2_plugins_functions_config_config_types_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TupleField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TupleField) ProtoMessage() {}
+
+func (x *TupleField) ProtoReflect() protoreflect.Message {
+	mi := &file_core_services_ocr2_plugins_functions_config_config_types_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TupleField.ProtoReflect.Descriptor instead.
+func (*TupleField) Descriptor() ([]byte, []int) {
+	return file_core_services_ocr2_plugins_functions_config_config_types_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TupleField) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TupleField) GetAggregationMethod() AggregationMethod {
+	if x != nil {
+		return x.AggregationMethod
+	}
+	return AggregationMethod_AGGREGATION_MODE
+}
+
 type ReportingPluginConfig struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -77,12 +157,16 @@ type ReportingPluginConfig struct {
 	MaxRequestBatchSize       uint32            `protobuf:"varint,4,opt,name=maxRequestBatchSize,proto3" json:"maxRequestBatchSize,omitempty"`
 	DefaultAggregationMethod  AggregationMethod `protobuf:"varint,5,opt,name=defaultAggregationMethod,proto3,enum=config_types.AggregationMethod" json:"defaultAggregationMethod,omitempty"`
 	UniqueReports             bool              `protobuf:"varint,6,opt,name=uniqueReports,proto3" json:"uniqueReports,omitempty"`
+	// Fraction of the results dropped from each end by the trimmed mean methods, in basis points; below 5000.
+	TrimmedMeanFractionBps uint32 `protobuf:"varint,7,opt,name=trimmedMeanFractionBps,proto3" json:"trimmedMeanFractionBps,omitempty"`
+	// Fields of the results of AGGREGATION_TUPLE, in order.
+	TupleFields []*TupleField `protobuf:"bytes,8,rep,name=tupleFields,proto3" json:"tupleFields,omitempty"`
 }
 
 func (x *ReportingPluginConfig) Reset() {
 	*x = ReportingPluginConfig{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_core_services_ocr2_plugins_functions_config_config_types_proto_msgTypes[0]
+		mi := &file_core_services_ocr2_plugins_functions_config_config_types_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -95,7 +179,7 @@ func (x *ReportingPluginConfig) String() string {
 func (*ReportingPluginConfig) ProtoMessage() {}
 
 func (x *ReportingPluginConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_core_services_ocr2_plugins_functions_config_config_types_proto_msgTypes[0]
+	mi := &file_core_services_ocr2_plugins_functions_config_config_types_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -108,7 +192,7 @@ func (x *ReportingPluginConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReportingPluginConfig.ProtoReflect.Descriptor instead.
 func (*ReportingPluginConfig) Descriptor() ([]byte, []int) {
-	return file_core_services_ocr2_plugins_functions_config_config_types_proto_rawDescGZIP(), []int{0}
+	return file_core_services_ocr2_plugins_functions_config_config_types_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *ReportingPluginConfig) GetMaxQueryLengthBytes() uint32 {
@@ -153,6 +237,20 @@ func (x *ReportingPluginConfig) GetUniqueReports() bool {
 	return false
 }
 
+func (x *ReportingPluginConfig) GetTrimmedMeanFractionBps() uint32 {
+	if x != nil {
+		return x.TrimmedMeanFractionBps
+	}
+	return 0
+}
+
+func (x *ReportingPluginConfig) GetTupleFields() []*TupleField {
+	if x != nil {
+		return x.TupleFields
+	}
+	return nil
+}
+
 var File_core_services_ocr2_plugins_functions_config_config_types_proto protoreflect.FileDescriptor
 
 var file_core_services_ocr2_plugins_functions_config_config_types_proto_rawDesc = []byte{
@@ -160,38 +258,66 @@ var file_core_services_ocr2_plugins_functions_config_config_types_proto_rawDesc
 	0x6f, 0x63, 0x72, 0x32, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2f, 0x66, 0x75, 0x6e,
 	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x63, 0x6f,
 	0x6e, 0x66, 0x69, 0x67, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x12, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x22, 0xf0,
-	0x02, 0x0a, 0x15, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x50, 0x6c, 0x75, 0x67,
-	0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x30, 0x0a, 0x13, 0x6d, 0x61, 0x78, 0x51,
-	0x75, 0x65, 0x72, 0x79, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13, 0x6d, 0x61, 0x78, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4c,
-	0x65, 0x6e, 0x67, 0x74, 0x68, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x3c, 0x0a, 0x19, 0x6d, 0x61,
-	0x78, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x65, 0x6e, 0x67,
-	0x74, 0x68, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x19, 0x6d,
+	0x12, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x22, 0x6f,
+	0x0a, 0x0a, 0x54, 0x75, 0x70, 0x6c, 0x65, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x12, 0x4d, 0x0a, 0x11, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d,
+	0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65,
+	0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x52, 0x11, 0x61, 0x67,
+	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x22,
+	0xe4, 0x03, 0x0a, 0x15, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x50, 0x6c, 0x75,
+	0x67, 0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x30, 0x0a, 0x13, 0x6d, 0x61, 0x78,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x42, 0x79, 0x74, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13, 0x6d, 0x61, 0x78, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x3c, 0x0a, 0x19, 0x6d,
 	0x61, 0x78, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x65, 0x6e,
-	0x67, 0x74, 0x68, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x14, 0x6d, 0x61, 0x78, 0x52,
-	0x65, 0x70, 0x6f, 0x72, 0x74, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x42, 0x79, 0x74, 0x65, 0x73,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x70, 0x6f, 0x72,
-	0x74, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x13,
-	0x6d, 0x61, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53,
-	0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13, 0x6d, 0x61, 0x78, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x5b,
-	0x0a, 0x18, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e,
-	0x32, 0x1f, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e,
-	0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x68, 0x6f,
-	0x64, 0x52, 0x18, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x75,
-	0x6e, 0x69, 0x71, 0x75, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x0d, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
-	0x73, 0x2a, 0x41, 0x0a, 0x11, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x14, 0x0a, 0x10, 0x41, 0x47, 0x47, 0x52, 0x45, 0x47,
-	0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12,
-	0x41, 0x47, 0x47, 0x52, 0x45, 0x47, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x45, 0x44, 0x49,
-	0x41, 0x4e, 0x10, 0x01, 0x42, 0x2d, 0x5a, 0x2b, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x73, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x73, 0x2f, 0x6f, 0x63, 0x72, 0x32, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69,
-	0x6e, 0x73, 0x2f, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x63, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x67, 0x74, 0x68, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x19,
+	0x6d, 0x61, 0x78, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x65,
+	0x6e, 0x67, 0x74, 0x68, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x14, 0x6d, 0x61, 0x78,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x42, 0x79, 0x74, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x30, 0x0a,
+	0x13, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x53, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13, 0x6d, 0x61, 0x78, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x7a, 0x65, 0x12,
+	0x5b, 0x0a, 0x18, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1f, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73,
+	0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x68,
+	0x6f, 0x64, 0x52, 0x18, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x41, 0x67, 0x67, 0x72, 0x65,
+	0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x24, 0x0a, 0x0d,
+	0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0d, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x73, 0x12, 0x36, 0x0a, 0x16, 0x74, 0x72, 0x69, 0x6d, 0x6d, 0x65, 0x64, 0x4d, 0x65, 0x61,
+	0x6e, 0x46, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x70, 0x73, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x16, 0x74, 0x72, 0x69, 0x6d, 0x6d, 0x65, 0x64, 0x4d, 0x65, 0x61, 0x6e, 0x46,
+	0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x70, 0x73, 0x12, 0x3a, 0x0a, 0x0b, 0x74, 0x75,
+	0x70, 0x6c, 0x65, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x18, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x54,
+	0x75, 0x70, 0x6c, 0x65, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x0b, 0x74, 0x75, 0x70, 0x6c, 0x65,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x2a, 0x9d, 0x02, 0x0a, 0x11, 0x41, 0x67, 0x67, 0x72, 0x65,
+	0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x14, 0x0a, 0x10,
+	0x41, 0x47, 0x47, 0x52, 0x45, 0x47, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x4f, 0x44, 0x45,
+	0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x41, 0x47, 0x47, 0x52, 0x45, 0x47, 0x41, 0x54, 0x49, 0x4f,
+	0x4e, 0x5f, 0x4d, 0x45, 0x44, 0x49, 0x41, 0x4e, 0x10, 0x01, 0x12, 0x1d, 0x0a, 0x19, 0x41, 0x47,
+	0x47, 0x52, 0x45, 0x47, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x45, 0x44, 0x49, 0x41, 0x4e,
+	0x5f, 0x49, 0x4e, 0x54, 0x32, 0x35, 0x36, 0x10, 0x02, 0x12, 0x1e, 0x0a, 0x1a, 0x41, 0x47, 0x47,
+	0x52, 0x45, 0x47, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x45, 0x44, 0x49, 0x41, 0x4e, 0x5f,
+	0x55, 0x49, 0x4e, 0x54, 0x32, 0x35, 0x36, 0x10, 0x03, 0x12, 0x1b, 0x0a, 0x17, 0x41, 0x47, 0x47,
+	0x52, 0x45, 0x47, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x45, 0x41, 0x4e, 0x5f, 0x49, 0x4e,
+	0x54, 0x32, 0x35, 0x36, 0x10, 0x04, 0x12, 0x1c, 0x0a, 0x18, 0x41, 0x47, 0x47, 0x52, 0x45, 0x47,
+	0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x45, 0x41, 0x4e, 0x5f, 0x55, 0x49, 0x4e, 0x54, 0x32,
+	0x35, 0x36, 0x10, 0x05, 0x12, 0x23, 0x0a, 0x1f, 0x41, 0x47, 0x47, 0x52, 0x45, 0x47, 0x41, 0x54,
+	0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x52, 0x49, 0x4d, 0x4d, 0x45, 0x44, 0x5f, 0x4d, 0x45, 0x41, 0x4e,
+	0x5f, 0x49, 0x4e, 0x54, 0x32, 0x35, 0x36, 0x10, 0x06, 0x12, 0x24, 0x0a, 0x20, 0x41, 0x47, 0x47,
+	0x52, 0x45, 0x47, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x52, 0x49, 0x4d, 0x4d, 0x45, 0x44,
+	0x5f, 0x4d, 0x45, 0x41, 0x4e, 0x5f, 0x55, 0x49, 0x4e, 0x54, 0x32, 0x35, 0x36, 0x10, 0x07, 0x12,
+	0x15, 0x0a, 0x11, 0x41, 0x47, 0x47, 0x52, 0x45, 0x47, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54,
+	0x55, 0x50, 0x4c, 0x45, 0x10, 0x08, 0x42, 0x2d, 0x5a, 0x2b, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2f, 0x6f, 0x63, 0x72, 0x32, 0x2f, 0x70, 0x6c, 0x75,
+	0x67, 0x69, 0x6e, 0x73, 0x2f, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -207,18 +333,21 @@ func file_core_services_ocr2_plugins_functions_config_config_types_proto_rawDesc
 }
 
 var file_core_services_ocr2_plugins_functions_config_config_types_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_core_services_ocr2_plugins_functions_config_config_types_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_core_services_ocr2_plugins_functions_config_config_types_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_core_services_ocr2_plugins_functions_config_config_types_proto_goTypes = []interface{}{
 	(AggregationMethod)(0),        // 0: config_types.AggregationMethod
-	(*ReportingPluginConfig)(nil), // 1: config_types.ReportingPluginConfig
+	(*TupleField)(nil),            // 1: config_types.TupleField
+	(*ReportingPluginConfig)(nil), // 2: config_types.ReportingPluginConfig
 }
 var file_core_services_ocr2_plugins_functions_config_config_types_proto_depIdxs = []int32{
-	0, // 0: config_types.ReportingPluginConfig.defaultAggregationMethod:type_name -> config_types.AggregationMethod
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	0, // 0: config_types.TupleField.aggregationMethod:type_name -> config_types.AggregationMethod
+	0, // 1: config_types.ReportingPluginConfig.defaultAggregationMethod:type_name -> config_types.AggregationMethod
+	1, // 2: config_types.ReportingPluginConfig.tupleFields:type_name -> config_types.TupleField
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_core_services_ocr2_plugins_functions_config_config_types_proto_init() }
@@ -228,6 +357,18 @@ func file_core_services_ocr2_plugins_functions_config_config_types_proto_init()
 	}
 	if !protoimpl.UnsafeEnabled {
 		file_core_services_ocr2_plugins_functions_config_config_types_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TupleField); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_core_services_ocr2_plugins_functions_config_config_types_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ReportingPluginConfig); i {
 			case 0:
 				return &v.state
@@ -246,7 +387,7 @@ func file_core_services_ocr2_plugins_functions_config_config_types_proto_init()
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_core_services_ocr2_plugins_functions_config_config_types_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
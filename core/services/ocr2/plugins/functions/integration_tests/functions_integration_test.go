@@ -14,10 +14,21 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
 	utils "github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/functions/integration_tests/internal"
 )
 
 func TestIntegration_Functions_MultipleRequests_Success(t *testing.T) {
+	runFunctionsMultipleRequests(t, false)
+}
+
+func TestIntegration_Functions_LocalSandbox_MultipleRequests_Success(t *testing.T) {
+	runFunctionsMultipleRequests(t, true)
+}
+
+// runFunctionsMultipleRequests executes requests with mock EAs, or with the
+// local sandbox of each node.
+func runFunctionsMultipleRequests(t *testing.T, localSandbox bool) {
 	// a batch of 8 max-length results uses around 1M gas (assuming 70k gas per client callback - see FunctionsClientExample.sol)
 	nOracleNodes := 4
 	nClients := 50
@@ -31,6 +42,9 @@ func TestIntegration_Functions_MultipleRequests_Success(t *testing.T) {
 
 	// bootstrap node and job
 	bootstrapNodePort := uint16(39999)
+	if localSandbox {
+		bootstrapNodePort = 39989
+	}
 	bootstrapNode := utils.StartNewNode(t, owner, bootstrapNodePort, "bootstrap", b, uint32(maxGas), nil)
 	utils.AddBootstrapJob(t, bootstrapNode.App, oracleContractAddress)
 
@@ -45,10 +59,14 @@ func TestIntegration_Functions_MultipleRequests_Success(t *testing.T) {
 		apps = append(apps, oracleNode.App)
 		oracles = append(oracles, oracleNode.OracleIdentity)
 
-		ea := utils.StartNewMockEA(t)
-		defer ea.Close()
-
-		ocrJob := utils.AddOCR2Job(t, apps[i], oracleContractAddress, oracleNode.Keybundle.ID(), oracleNode.Transmitter, ea.URL)
+		var ocrJob job.Job
+		if localSandbox {
+			ocrJob = utils.AddOCR2JobWithLocalSandbox(t, apps[i], oracleContractAddress, oracleNode.Keybundle.ID(), oracleNode.Transmitter)
+		} else {
+			ea := utils.StartNewMockEA(t)
+			defer ea.Close()
+			ocrJob = utils.AddOCR2Job(t, apps[i], oracleContractAddress, oracleNode.Keybundle.ID(), oracleNode.Transmitter, ea.URL)
+		}
 		jobIds = append(jobIds, ocrJob.ID)
 	}
 
@@ -78,7 +96,7 @@ func TestIntegration_Functions_MultipleRequests_Success(t *testing.T) {
 	// validate that all pipeline jobs completed as many runs as sent requests
 	const tasksPerRun = 4
 	var wg sync.WaitGroup
-	for i := 0; i < nOracleNodes; i++ {
+	for i := 0; i < nOracleNodes && !localSandbox; i++ {
 		ic := i
 		wg.Add(1)
 		go func() {
@@ -88,6 +106,11 @@ func TestIntegration_Functions_MultipleRequests_Success(t *testing.T) {
 	}
 	wg.Wait()
 
+	expectedResponse := utils.GetExpectedResponse
+	if localSandbox {
+		expectedResponse = utils.GetExpectedLocalResponse
+	}
+
 	// validate that all client contracts got correct responses to their requests
 	for i := 0; i < nClients; i++ {
 		ic := i
@@ -98,7 +121,7 @@ func TestIntegration_Functions_MultipleRequests_Success(t *testing.T) {
 				answer, err := clientContracts[ic].Contract.LastResponse(nil)
 				require.NoError(t, err)
 				return answer
-			}, 1*time.Minute, 1*time.Second).Should(gomega.Equal(utils.GetExpectedResponse(requestSources[ic])))
+			}, 1*time.Minute, 1*time.Second).Should(gomega.Equal(expectedResponse(requestSources[ic])))
 		}()
 	}
 	wg.Wait()
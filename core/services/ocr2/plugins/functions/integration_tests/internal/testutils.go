@@ -334,6 +334,16 @@ func AddOCR2Job(t *testing.T, app *cltest.TestApplication, contractAddress commo
 		Name: "ea_bridge",
 		URL:  models.WebURL(*u),
 	}))
+	return addOCR2Job(t, app, contractAddress, keyBundleID, transmitter, `sandbox = "pipeline"`)
+}
+
+// AddOCR2JobWithLocalSandbox adds a job executing requests in process, so
+// that no external adapter is needed.
+func AddOCR2JobWithLocalSandbox(t *testing.T, app *cltest.TestApplication, contractAddress common.Address, keyBundleID string, transmitter common.Address) job.Job {
+	return addOCR2Job(t, app, contractAddress, keyBundleID, transmitter, `sandbox = "local"`)
+}
+
+func addOCR2Job(t *testing.T, app *cltest.TestApplication, contractAddress common.Address, keyBundleID string, transmitter common.Address, extraPluginConfig string) job.Job {
 	job, err := validate.ValidatedOracleSpecToml(app.Config, fmt.Sprintf(`
 		type               = "offchainreporting2"
 		name               = "dr-ocr-node"
@@ -367,7 +377,9 @@ func AddOCR2Job(t *testing.T, app *cltest.TestApplication, contractAddress commo
 		requestTimeoutBatchLookupSize = 20
 		listenerEventHandlerTimeoutSec = 120
 		maxRequestSizeBytes = 30720
-	`, contractAddress, keyBundleID, transmitter))
+		executionTimeoutSec = 30
+		%s
+	`, contractAddress, keyBundleID, transmitter, extraPluginConfig))
 	require.NoError(t, err)
 	err = app.AddJobV2(testutils.Context(t), &job)
 	require.NoError(t, err)
@@ -387,6 +399,13 @@ func StartNewMockEA(t *testing.T) *httptest.Server {
 	}))
 }
 
+// The local sandbox echoes the source and user contract crops the answer to first 32 bytes
+func GetExpectedLocalResponse(source []byte) [32]byte {
+	var resp [32]byte
+	copy(resp[:], source)
+	return resp
+}
+
 // Mock EA prepends 0xab to source and user contract crops the answer to first 32 bytes
 func GetExpectedResponse(source []byte) [32]byte {
 	var resp [32]byte
@@ -16,6 +16,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services/functions"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
 	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/functions/config"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/threshold"
 	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/validate"
 	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/v2/core/utils"
@@ -60,7 +61,21 @@ func NewFunctionsServices(sharedOracleArgs *libocr2.OracleArgs, conf *FunctionsS
 			"jobID", conf.Job.PipelineSpec.JobID,
 			"externalJobID", conf.Job.ExternalJobID,
 		)
-	functionsListener := functions.NewFunctionsListener(oracleContract, conf.Job, conf.PipelineRunner, conf.JobORM, pluginORM, pluginConfig, conf.Chain.LogBroadcaster(), svcLogger, conf.MailMon, conf.URLsMonEndpoint)
+	var sandbox functions.Sandbox
+	switch pluginConfig.Sandbox {
+	case functions.SandboxLocal:
+		if !conf.OCR2JobConfig.OCRDevelopmentMode() {
+			return nil, errors.New("Functions: local sandbox is only available in OCR development mode")
+		}
+		sandbox = functions.NewLocalSandbox(nil, nil)
+	default:
+		sandbox = functions.NewPipelineSandbox(conf.Job, conf.PipelineRunner, conf.JobORM, svcLogger)
+	}
+	secretsORM := functions.NewSecretsORM(conf.DB, conf.Lggr, conf.OCR2JobConfig, contractAddress)
+	// No threshold decryption plugin serves a decryption queue yet, so
+	// requests with DON-hosted secrets fail with a user error.
+	var decryptor threshold.Decryptor
+	functionsListener := functions.NewFunctionsListener(oracleContract, conf.Job, sandbox, pluginORM, secretsORM, decryptor, pluginConfig, conf.Chain.LogBroadcaster(), svcLogger, conf.MailMon, conf.URLsMonEndpoint)
 
 	sharedOracleArgs.ReportingPluginFactory = FunctionsReportingPluginFactory{
 		Logger:    sharedOracleArgs.Logger,
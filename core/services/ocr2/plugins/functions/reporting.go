@@ -87,6 +87,13 @@ func (f FunctionsReportingPluginFactory) NewReportingPlugin(rpConfig types.Repor
 		})
 		return nil, types.ReportingPluginInfo{}, err
 	}
+	if err = ValidateAggregationConfig(NewAggregationConfig(pluginConfig.Config)); err != nil {
+		f.Logger.Error("invalid aggregation config", commontypes.LogFields{
+			"digest": rpConfig.ConfigDigest.String(),
+			"err":    err,
+		})
+		return nil, types.ReportingPluginInfo{}, err
+	}
 	codec, err := NewReportCodec()
 	if err != nil {
 		f.Logger.Error("unable to create a report codec object", commontypes.LogFields{})
@@ -265,12 +272,12 @@ func (r *functionsReporting) Report(ctx context.Context, ts types.ReportTimestam
 		}
 	}
 
-	defaultAggMethod := r.specificConfig.Config.GetDefaultAggregationMethod()
+	aggCfg := NewAggregationConfig(r.specificConfig.Config)
 	var allAggregated []*ProcessedRequest
 	var allIdStrs []string
 	for _, reqId := range uniqueQueryIds {
 		observations := reqIdToObservationList[reqId]
-		if !CanAggregate(r.genericConfig.N, r.genericConfig.F, observations) {
+		if !CanAggregate(r.genericConfig.N, r.genericConfig.F, aggCfg, observations) {
 			r.logger.Debug("FunctionsReporting Report: unable to aggregate request in current round", commontypes.LogFields{
 				"epoch":         ts.Epoch,
 				"round":         ts.Round,
@@ -282,7 +289,7 @@ func (r *functionsReporting) Report(ctx context.Context, ts types.ReportTimestam
 
 		// TODO: support per-request aggregation method
 		// https://app.shortcut.com/chainlinklabs/story/57701/per-request-plugin-config
-		aggregated, errAgg := Aggregate(defaultAggMethod, observations)
+		aggregated, errAgg := Aggregate(aggCfg, observations)
 		if errAgg != nil {
 			r.logger.Error("FunctionsReporting Report: error when aggregating reqId", commontypes.LogFields{
 				"epoch":     ts.Epoch,
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"sort"
 
 	pkgerrors "github.com/pkg/errors"
 
@@ -17,24 +18,79 @@ import (
 type PluginConfig struct {
 	RawServerURL string              `json:"serverURL" toml:"serverURL"`
 	ServerPubKey utils.PlainHexBytes `json:"serverPubKey" toml:"serverPubKey"`
+	// Servers maps the URLs of several Mercury servers to their public keys,
+	// to transmit each report to all of them. It replaces serverURL and
+	// serverPubKey.
+	Servers map[string]utils.PlainHexBytes `json:"servers" toml:"servers"`
+	// SchemaVersion selects the layout of the reports of the feed. Version 1,
+	// the default, reports the current block of the chain; versions 2 and 3
+	// are timestamped instead, and version 3 adds fees and an expiry.
+	SchemaVersion uint32 `json:"schemaVersion" toml:"schemaVersion"`
+	// ExpirationWindow is the number of seconds version 3 reports are valid
+	// for after their observations timestamp.
+	ExpirationWindow uint32 `json:"expirationWindow" toml:"expirationWindow"`
 }
 
-func ValidatePluginConfig(config PluginConfig) (merr error) {
+// The supported report schema versions.
+const (
+	SchemaV1 uint32 = 1
+	SchemaV2 uint32 = 2
+	SchemaV3 uint32 = 3
+)
+
+// Server is a Mercury server reports are transmitted to.
+type Server struct {
+	URL    string
+	PubKey utils.PlainHexBytes
+}
+
+func ValidatePluginConfig(config PluginConfig) error {
+	var validateSchema func(PluginConfig) error
+	switch config.GetSchemaVersion() {
+	case SchemaV1, SchemaV2:
+		validateSchema = validateSchemaWithoutExpiry
+	case SchemaV3:
+		validateSchema = validateSchemaV3
+	default:
+		return pkgerrors.Errorf("Mercury: unsupported SchemaVersion %d, expected one of: %d, %d, %d", config.SchemaVersion, SchemaV1, SchemaV2, SchemaV3)
+	}
+	return errors.Join(validateServers(config), validateSchema(config))
+}
+
+func validateSchemaWithoutExpiry(config PluginConfig) error {
+	if config.ExpirationWindow != 0 {
+		return errors.New("Mercury: ExpirationWindow is only supported by SchemaVersion 3")
+	}
+	return nil
+}
+
+func validateSchemaV3(config PluginConfig) error {
+	if config.ExpirationWindow == 0 {
+		return errors.New("Mercury: ExpirationWindow is required by SchemaVersion 3 and must be greater than 0")
+	}
+	return nil
+}
+
+func validateServers(config PluginConfig) (merr error) {
+	if len(config.Servers) > 0 {
+		if config.RawServerURL != "" || len(config.ServerPubKey) != 0 {
+			return errors.New("Mercury: Servers and ServerURL/ServerPubKey may not be specified together")
+		}
+		for _, server := range config.GetServers() {
+			if err := validateServerURL(server.URL); err != nil {
+				merr = errors.Join(merr, err)
+			}
+			if len(server.PubKey) != 32 {
+				merr = errors.Join(merr, pkgerrors.Errorf("Mercury: ServerPubKey of server %q must be a 32-byte hex string", server.URL))
+			}
+		}
+		return merr
+	}
+
 	if config.RawServerURL == "" {
 		merr = errors.New("Mercury: ServerURL must be specified")
 	} else {
-		var normalizedURI string
-		if schemeRegexp.MatchString(config.RawServerURL) {
-			normalizedURI = config.RawServerURL
-		} else {
-			normalizedURI = fmt.Sprintf("wss://%s", config.RawServerURL)
-		}
-		uri, err := url.ParseRequestURI(normalizedURI)
-		if err != nil {
-			merr = pkgerrors.Wrap(err, "Mercury: invalid value for ServerURL")
-		} else if !(uri.Scheme == "" || uri.Scheme == "wss") {
-			merr = pkgerrors.Errorf(`Mercury: invalid scheme specified for MercuryServer, got: %q (scheme: %q) but expected a websocket url e.g. "192.0.2.2:4242" or "wss://192.0.2.2:4242"`, config.RawServerURL, uri.Scheme)
-		}
+		merr = validateServerURL(config.RawServerURL)
 	}
 	if len(config.ServerPubKey) != 32 {
 		merr = errors.Join(merr, errors.New("Mercury: ServerPubKey is required and must be a 32-byte hex string"))
@@ -42,9 +98,46 @@ func ValidatePluginConfig(config PluginConfig) (merr error) {
 	return merr
 }
 
+func validateServerURL(rawServerURL string) error {
+	var normalizedURI string
+	if schemeRegexp.MatchString(rawServerURL) {
+		normalizedURI = rawServerURL
+	} else {
+		normalizedURI = fmt.Sprintf("wss://%s", rawServerURL)
+	}
+	uri, err := url.ParseRequestURI(normalizedURI)
+	if err != nil {
+		return pkgerrors.Wrap(err, "Mercury: invalid value for ServerURL")
+	} else if !(uri.Scheme == "" || uri.Scheme == "wss") {
+		return pkgerrors.Errorf(`Mercury: invalid scheme specified for MercuryServer, got: %q (scheme: %q) but expected a websocket url e.g. "192.0.2.2:4242" or "wss://192.0.2.2:4242"`, rawServerURL, uri.Scheme)
+	}
+	return nil
+}
+
 var schemeRegexp = regexp.MustCompile(`^(.*)://`)
 var wssRegexp = regexp.MustCompile(`^wss://`)
 
 func (p PluginConfig) ServerURL() string {
 	return wssRegexp.ReplaceAllString(p.RawServerURL, "")
 }
+
+// GetSchemaVersion returns the report schema version, defaulting to 1.
+func (p PluginConfig) GetSchemaVersion() uint32 {
+	if p.SchemaVersion == 0 {
+		return SchemaV1
+	}
+	return p.SchemaVersion
+}
+
+// GetServers returns the servers to transmit to, sorted by URL.
+func (p PluginConfig) GetServers() []Server {
+	if len(p.Servers) == 0 {
+		return []Server{{URL: p.ServerURL(), PubKey: p.ServerPubKey}}
+	}
+	servers := make([]Server, 0, len(p.Servers))
+	for u, pubKey := range p.Servers {
+		servers = append(servers, Server{URL: wssRegexp.ReplaceAllString(u, ""), PubKey: pubKey})
+	}
+	sort.Slice(servers, func(i, j int) bool { return servers[i].URL < servers[j].URL })
+	return servers
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/pelletier/go-toml/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
 )
 
 func Test_PluginConfig(t *testing.T) {
@@ -53,3 +55,91 @@ func Test_PluginConfig_ServerURL(t *testing.T) {
 	pc = PluginConfig{RawServerURL: "wss://example.com:1234/foo"}
 	assert.Equal(t, "example.com:1234/foo", pc.ServerURL())
 }
+
+func Test_PluginConfig_Servers(t *testing.T) {
+	t.Run("with valid values", func(t *testing.T) {
+		rawToml := `
+[Servers]
+"wss://example.com:80" = "724ff6eae9e900270edfff233e16322a70ec06e1a6e62a81ef13921f398f6c93"
+"example.net:80" = "824ff6eae9e900270edfff233e16322a70ec06e1a6e62a81ef13921f398f6c93"
+`
+
+		var mc PluginConfig
+		err := toml.Unmarshal([]byte(rawToml), &mc)
+		require.NoError(t, err)
+		require.NoError(t, ValidatePluginConfig(mc))
+
+		servers := mc.GetServers()
+		require.Len(t, servers, 2)
+		assert.Equal(t, "example.com:80", servers[0].URL)
+		assert.Equal(t, "724ff6eae9e900270edfff233e16322a70ec06e1a6e62a81ef13921f398f6c93", servers[0].PubKey.String())
+		assert.Equal(t, "example.net:80", servers[1].URL)
+		assert.Equal(t, "824ff6eae9e900270edfff233e16322a70ec06e1a6e62a81ef13921f398f6c93", servers[1].PubKey.String())
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		rawToml := `
+[Servers]
+"http://example.com" = "724ff6eae9e900270edfff233e16322a70ec06e1a6e62a81ef13921f398f6c93"
+"example.net:80" = "4242"
+`
+
+		var mc PluginConfig
+		err := toml.Unmarshal([]byte(rawToml), &mc)
+		require.NoError(t, err)
+
+		err = ValidatePluginConfig(mc)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `Mercury: invalid scheme specified for MercuryServer, got: "http://example.com" (scheme: "http")`)
+		assert.Contains(t, err.Error(), `Mercury: ServerPubKey of server "example.net:80" must be a 32-byte hex string`)
+	})
+
+	t.Run("with ServerURL", func(t *testing.T) {
+		mc := PluginConfig{
+			RawServerURL: "example.com:80",
+			Servers:      map[string]utils.PlainHexBytes{"example.net:80": make([]byte, 32)},
+		}
+		err := ValidatePluginConfig(mc)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Mercury: Servers and ServerURL/ServerPubKey may not be specified together")
+	})
+
+	t.Run("single server", func(t *testing.T) {
+		mc := PluginConfig{RawServerURL: "wss://example.com:80", ServerPubKey: make([]byte, 32)}
+		assert.Equal(t, []Server{{URL: "example.com:80", PubKey: make([]byte, 32)}}, mc.GetServers())
+	})
+}
+
+func Test_PluginConfig_SchemaVersion(t *testing.T) {
+	servers := `
+ServerURL = "example.com:80"
+ServerPubKey = "724ff6eae9e900270edfff233e16322a70ec06e1a6e62a81ef13921f398f6c93"
+`
+	for _, tt := range []struct {
+		name    string
+		toml    string
+		version uint32
+		err     string
+	}{
+		{"default", "", SchemaV1, ""},
+		{"v1", "SchemaVersion = 1", SchemaV1, ""},
+		{"v2", "SchemaVersion = 2", SchemaV2, ""},
+		{"v3", "SchemaVersion = 3\nExpirationWindow = 86400", SchemaV3, ""},
+		{"v2 with expiry", "SchemaVersion = 2\nExpirationWindow = 86400", SchemaV2, "Mercury: ExpirationWindow is only supported by SchemaVersion 3"},
+		{"v3 without expiry", "SchemaVersion = 3", SchemaV3, "Mercury: ExpirationWindow is required by SchemaVersion 3 and must be greater than 0"},
+		{"unsupported", "SchemaVersion = 4", 4, "Mercury: unsupported SchemaVersion 4, expected one of: 1, 2, 3"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var mc PluginConfig
+			require.NoError(t, toml.Unmarshal([]byte(servers+tt.toml), &mc))
+			assert.Equal(t, tt.version, mc.GetSchemaVersion())
+
+			err := ValidatePluginConfig(mc)
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
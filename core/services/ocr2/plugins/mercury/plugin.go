@@ -6,20 +6,28 @@ import (
 
 	"github.com/pkg/errors"
 	libocr2 "github.com/smartcontractkit/libocr/offchainreporting2"
+	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting2/types"
 
 	relaymercury "github.com/smartcontractkit/chainlink-relay/pkg/reportingplugins/mercury"
 	relaytypes "github.com/smartcontractkit/chainlink-relay/pkg/types"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
 	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/mercury/config"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/mercury/schema"
 	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/promwrapper"
 	"github.com/smartcontractkit/chainlink/v2/core/services/ocrcommon"
 	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/v2/core/services/relay/evm/mercury"
 )
 
+// SchemaProvider is implemented by the Mercury providers supporting the
+// timestamped report schemas.
+type SchemaProvider interface {
+	SchemaReportCodec() schema.ReportCodec
+}
+
 type Config interface {
-	JobPipelineMaxSuccessfulRuns() uint64
+	JobPipelineMaxSuccessfulRunsForJobType(jobType string) uint64
 }
 
 func NewServices(
@@ -46,22 +54,54 @@ func NewServices(
 		return nil, err
 	}
 	lggr = lggr.Named("MercuryPlugin").With("jobID", jb.ID, "jobName", jb.Name.ValueOrZero())
-	ds := mercury.NewDataSource(
-		pipelineRunner,
-		jb,
-		*jb.PipelineSpec,
-		lggr,
-		runResults,
-		chEnhancedTelem,
-		chainHeadTracker,
-	)
-	wrappedPluginFactory := relaymercury.NewFactory(
-		ds,
-		lggr,
-		ocr2Provider.OnchainConfigCodec(),
-		ocr2Provider.ReportCodec(),
-		ocr2Provider.ContractTransmitter(),
-	)
+	var wrappedPluginFactory ocrtypes.ReportingPluginFactory
+	if version := pluginConfig.GetSchemaVersion(); version == config.SchemaV1 {
+		ds := mercury.NewDataSource(
+			pipelineRunner,
+			jb,
+			*jb.PipelineSpec,
+			lggr,
+			runResults,
+			chEnhancedTelem,
+			chainHeadTracker,
+		)
+		wrappedPluginFactory = relaymercury.NewFactory(
+			ds,
+			lggr,
+			ocr2Provider.OnchainConfigCodec(),
+			ocr2Provider.ReportCodec(),
+			ocr2Provider.ContractTransmitter(),
+		)
+	} else {
+		s, err := schema.Get(version)
+		if err != nil {
+			return nil, err
+		}
+		schemaProvider, ok := ocr2Provider.(SchemaProvider)
+		if !ok {
+			return nil, errors.Errorf("Mercury provider does not support SchemaVersion %d", version)
+		}
+		fetcher, ok := ocr2Provider.ContractTransmitter().(schema.Fetcher)
+		if !ok {
+			return nil, errors.Errorf("Mercury transmitter does not support SchemaVersion %d", version)
+		}
+		ds := mercury.NewSchemaDataSource(
+			pipelineRunner,
+			jb,
+			*jb.PipelineSpec,
+			lggr,
+			runResults,
+			s,
+		)
+		wrappedPluginFactory = schema.NewFactory(
+			s,
+			ds,
+			lggr,
+			ocr2Provider.OnchainConfigCodec(),
+			schemaProvider.SchemaReportCodec(),
+			fetcher,
+		)
+	}
 	chain, err := jb.OCR2OracleSpec.RelayConfig.EVMChainID()
 	if err != nil {
 		return nil, errors.Wrap(err, "get chainset")
@@ -76,7 +116,7 @@ func NewServices(
 		pipelineRunner,
 		make(chan struct{}),
 		lggr,
-		cfg.JobPipelineMaxSuccessfulRuns(),
+		cfg.JobPipelineMaxSuccessfulRunsForJobType(string(jb.Type)),
 	),
 		job.NewServiceAdapter(oracle)}, nil
 }
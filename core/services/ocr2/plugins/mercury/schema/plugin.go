@@ -0,0 +1,417 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jpillora/backoff"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/smartcontractkit/libocr/commontypes"
+	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	relaymercury "github.com/smartcontractkit/chainlink-relay/pkg/reportingplugins/mercury"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+// Observation holds the observed values of the fields of a schema, in order.
+type Observation struct {
+	Values []relaymercury.ObsResult[*big.Int]
+}
+
+// DataSource implementations must be thread-safe. Observe may be called by many
+// different threads concurrently.
+type DataSource interface {
+	// Observe queries the data source for the fields of the schema. Once the
+	// context expires, Observe should return as quickly as possible.
+	Observe(context.Context, ocrtypes.ReportTimestamp) (Observation, error)
+}
+
+// Report is the consensus of the observations of a round.
+type Report struct {
+	// ValidFromTimestamp is one above the observations timestamp of the
+	// previous report of the feed, so that consecutive reports cover
+	// consecutive time ranges.
+	ValidFromTimestamp    uint32
+	ObservationsTimestamp uint32
+	// Values are the median values of the fields of the schema, in order.
+	Values []*big.Int
+}
+
+// All functions on ReportCodec should be pure and thread-safe.
+type ReportCodec interface {
+	BuildReport(Report) (ocrtypes.Report, error)
+
+	// MaxReportLength returns the maximum length of a report based on n, the
+	// number of oracles.
+	MaxReportLength(n int) int
+
+	// ObservationsTimestampFromReport returns the observations timestamp of
+	// a report.
+	ObservationsTimestampFromReport(ocrtypes.Report) (uint32, error)
+}
+
+type Fetcher interface {
+	// FetchInitialMaxFinalizedTimestamp fetches the observations timestamp
+	// of the latest report of the feed from the mercury server, which is 0
+	// for a new feed.
+	FetchInitialMaxFinalizedTimestamp(context.Context) (int64, error)
+}
+
+const unfetchedInitialMaxFinalizedTimestamp int64 = -1
+
+// maxValueLength is an overapproximation of the length of an int192 encoded
+// as a JSON number, with its separator.
+const maxValueLength = 60
+
+var _ ocrtypes.ReportingPluginFactory = Factory{}
+
+type Factory struct {
+	schema             Schema
+	dataSource         DataSource
+	logger             logger.Logger
+	onchainConfigCodec relaymercury.OnchainConfigCodec
+	reportCodec        ReportCodec
+	fetcher            Fetcher
+}
+
+func NewFactory(s Schema, ds DataSource, lggr logger.Logger, occ relaymercury.OnchainConfigCodec, rc ReportCodec, f Fetcher) Factory {
+	return Factory{s, ds, lggr, occ, rc, f}
+}
+
+func (fac Factory) NewReportingPlugin(configuration ocrtypes.ReportingPluginConfig) (ocrtypes.ReportingPlugin, ocrtypes.ReportingPluginInfo, error) {
+	onchainConfig, err := fac.onchainConfigCodec.Decode(configuration.OnchainConfig)
+	if err != nil {
+		return nil, ocrtypes.ReportingPluginInfo{}, err
+	}
+
+	maxReportLength := fac.reportCodec.MaxReportLength(configuration.N)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	r := &reportingPlugin{
+		schema:          fac.schema,
+		onchainConfig:   onchainConfig,
+		dataSource:      fac.dataSource,
+		logger:          fac.logger,
+		reportCodec:     fac.reportCodec,
+		f:               configuration.F,
+		maxReportLength: maxReportLength,
+		cancel:          cancel,
+	}
+	r.maxFinalizedTimestamp.Store(unfetchedInitialMaxFinalizedTimestamp)
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+
+		b := backoff.Backoff{
+			Min: 1 * time.Second,
+			Max: 10 * time.Second,
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(b.Duration()):
+				initial, err := fac.fetcher.FetchInitialMaxFinalizedTimestamp(ctx)
+				if err != nil {
+					fac.logger.Warnw("FetchInitialMaxFinalizedTimestamp failed", "err", err)
+					continue
+				}
+				r.maxFinalizedTimestamp.CompareAndSwap(unfetchedInitialMaxFinalizedTimestamp, initial)
+				return
+			}
+		}
+	}()
+
+	return r, ocrtypes.ReportingPluginInfo{
+		Name:          fmt.Sprintf("MercuryV%d", fac.schema.Version),
+		UniqueReports: false,
+		Limits: ocrtypes.ReportingPluginLimits{
+			MaxQueryLength:       0,
+			MaxObservationLength: maxObservationLength(fac.schema),
+			MaxReportLength:      maxReportLength,
+		},
+	}, nil
+}
+
+func maxObservationLength(s Schema) int {
+	return 128 + /* timestamps and JSON overhead */
+		len(s.Fields)*maxValueLength
+}
+
+// observation is the encoding of an Observation sent to the other oracles.
+// Values which failed to be observed are null.
+type observation struct {
+	Timestamp             uint32     `json:"timestamp"`
+	MaxFinalizedTimestamp int64      `json:"maxFinalizedTimestamp"`
+	Values                []*big.Int `json:"values"`
+}
+
+type parsedAttributedObservation struct {
+	Timestamp             uint32
+	MaxFinalizedTimestamp int64
+	Values                []*big.Int
+	Observer              commontypes.OracleID
+}
+
+var _ ocrtypes.ReportingPlugin = (*reportingPlugin)(nil)
+
+type reportingPlugin struct {
+	schema        Schema
+	onchainConfig relaymercury.OnchainConfig
+	dataSource    DataSource
+	logger        logger.Logger
+	reportCodec   ReportCodec
+
+	f                        int
+	latestAcceptedEpochRound epochRound
+	maxReportLength          int
+	maxFinalizedTimestamp    atomic.Int64
+
+	// fetch initial max finalized timestamp state management
+	wg         sync.WaitGroup
+	cancel     context.CancelFunc
+	cancelOnce sync.Once
+}
+
+func (rp *reportingPlugin) Query(ctx context.Context, repts ocrtypes.ReportTimestamp) (ocrtypes.Query, error) {
+	return nil, nil
+}
+
+func (rp *reportingPlugin) Observation(ctx context.Context, repts ocrtypes.ReportTimestamp, query ocrtypes.Query) (ocrtypes.Observation, error) {
+	if len(query) != 0 {
+		return nil, errors.New("expected empty query")
+	}
+
+	maxFinalizedTimestamp := rp.maxFinalizedTimestamp.Load()
+	if maxFinalizedTimestamp == unfetchedInitialMaxFinalizedTimestamp {
+		return nil, errors.New("initial maxFinalizedTimestamp has not yet been fetched from the mercury server")
+	}
+
+	obs, err := rp.dataSource.Observe(ctx, repts)
+	if err != nil {
+		return nil, pkgerrors.Errorf("DataSource.Observe returned an error: %s", err)
+	}
+	if len(obs.Values) != len(rp.schema.Fields) {
+		return nil, pkgerrors.Errorf("DataSource.Observe returned %d values, expected %d", len(obs.Values), len(rp.schema.Fields))
+	}
+
+	o := observation{
+		Timestamp:             uint32(time.Now().Unix()),
+		MaxFinalizedTimestamp: maxFinalizedTimestamp,
+		Values:                make([]*big.Int, len(obs.Values)),
+	}
+	var obsErrors []error
+	for i, v := range obs.Values {
+		name := rp.schema.Fields[i].Name
+		if v.Err != nil {
+			obsErrors = append(obsErrors, pkgerrors.Wrapf(v.Err, "failed to observe %s", name))
+		} else if _, err := relaymercury.EncodeValueInt192(v.Val); err != nil {
+			obsErrors = append(obsErrors, pkgerrors.Wrapf(err, "failed to observe %s; encoding failed", name))
+		} else {
+			o.Values[i] = v.Val
+		}
+	}
+	if len(obsErrors) > 0 {
+		rp.logger.Warnw(fmt.Sprintf("Observe failed %d/%d observations", len(obsErrors), len(obs.Values)), "err", errors.Join(obsErrors...))
+	}
+
+	return json.Marshal(o)
+}
+
+func (rp *reportingPlugin) parseAttributedObservation(ao ocrtypes.AttributedObservation) (parsedAttributedObservation, error) {
+	var o observation
+	if err := json.Unmarshal(ao.Observation, &o); err != nil {
+		return parsedAttributedObservation{}, pkgerrors.Errorf("attributed observation cannot be unmarshaled: %s", err)
+	}
+	if len(o.Values) != len(rp.schema.Fields) {
+		return parsedAttributedObservation{}, pkgerrors.Errorf("expected %d values, got: %d", len(rp.schema.Fields), len(o.Values))
+	}
+	for i, v := range o.Values {
+		if v == nil {
+			return parsedAttributedObservation{}, pkgerrors.Errorf("missing %s", rp.schema.Fields[i].Name)
+		}
+	}
+	return parsedAttributedObservation{o.Timestamp, o.MaxFinalizedTimestamp, o.Values, ao.Observer}, nil
+}
+
+func (rp *reportingPlugin) parseAttributedObservations(aos []ocrtypes.AttributedObservation) []parsedAttributedObservation {
+	paos := make([]parsedAttributedObservation, 0, len(aos))
+	for i, ao := range aos {
+		pao, err := rp.parseAttributedObservation(ao)
+		if err != nil {
+			rp.logger.Warnw("parseAttributedObservations: dropping invalid observation",
+				"observer", ao.Observer,
+				"error", err,
+				"i", i,
+			)
+			continue
+		}
+		paos = append(paos, pao)
+	}
+	return paos
+}
+
+func (rp *reportingPlugin) Report(ctx context.Context, repts ocrtypes.ReportTimestamp, query ocrtypes.Query, aos []ocrtypes.AttributedObservation) (bool, ocrtypes.Report, error) {
+	if len(query) != 0 {
+		return false, nil, errors.New("expected empty query")
+	}
+
+	paos := rp.parseAttributedObservations(aos)
+
+	// By assumption, we have at most f malicious oracles, so there should be at least f+1 valid paos
+	if !(rp.f+1 <= len(paos)) {
+		return false, nil, pkgerrors.Errorf("only received %v valid attributed observations, but need at least f+1 (%v)", len(paos), rp.f+1)
+	}
+
+	maxFinalizedTimestamp, err := getConsensusMaxFinalizedTimestamp(paos, rp.f)
+	if err != nil {
+		return false, nil, err
+	}
+	report := Report{
+		ValidFromTimestamp:    uint32(maxFinalizedTimestamp + 1),
+		ObservationsTimestamp: getConsensusTimestamp(paos),
+		Values:                make([]*big.Int, len(rp.schema.Fields)),
+	}
+	for i := range rp.schema.Fields {
+		report.Values[i] = getConsensusValue(paos, i)
+	}
+
+	if err := rp.checkReport(report, maxFinalizedTimestamp); err != nil {
+		rp.logger.Debugw("shouldReport: no", "err", err)
+		return false, nil, nil
+	}
+	rp.logger.Debugw("shouldReport: yes", "timestamp", repts)
+
+	encoded, err := rp.reportCodec.BuildReport(report)
+	if err != nil {
+		return false, nil, err
+	}
+	if !(len(encoded) <= rp.maxReportLength) {
+		return false, nil, pkgerrors.Errorf("report violates MaxReportLength limit set by ReportCodec (%v vs %v)", len(encoded), rp.maxReportLength)
+	}
+
+	return true, encoded, nil
+}
+
+func (rp *reportingPlugin) checkReport(report Report, maxFinalizedTimestamp int64) error {
+	var merr error
+	if int64(report.ObservationsTimestamp) <= maxFinalizedTimestamp {
+		merr = pkgerrors.Errorf("observationsTimestamp (%d) must be greater than maxFinalizedTimestamp (%d); this is most likely a duplicate report", report.ObservationsTimestamp, maxFinalizedTimestamp)
+	}
+	for i, field := range rp.schema.Fields {
+		if !field.Price {
+			continue
+		}
+		v := report.Values[i]
+		if !(rp.onchainConfig.Min.Cmp(v) <= 0 && v.Cmp(rp.onchainConfig.Max) <= 0) {
+			merr = errors.Join(merr, pkgerrors.Errorf("median %s %s is outside of allowable range (Min: %s, Max: %s)", field.Name, v, rp.onchainConfig.Min, rp.onchainConfig.Max))
+		}
+	}
+	return merr
+}
+
+func (rp *reportingPlugin) ShouldAcceptFinalizedReport(ctx context.Context, repts ocrtypes.ReportTimestamp, report ocrtypes.Report) (bool, error) {
+	reportEpochRound := epochRound{repts.Epoch, repts.Round}
+	if !rp.latestAcceptedEpochRound.Less(reportEpochRound) {
+		rp.logger.Debugw("ShouldAcceptFinalizedReport() = false, report is stale",
+			"latestAcceptedEpochRound", rp.latestAcceptedEpochRound,
+			"reportEpochRound", reportEpochRound,
+		)
+		return false, nil
+	}
+
+	if !(len(report) <= rp.maxReportLength) {
+		rp.logger.Warnw("report violates MaxReportLength limit set by ReportCodec",
+			"reportEpochRound", reportEpochRound,
+			"reportLength", len(report),
+			"maxReportLength", rp.maxReportLength,
+		)
+		return false, nil
+	}
+
+	observationsTimestamp, err := rp.reportCodec.ObservationsTimestampFromReport(report)
+	if err != nil {
+		return false, pkgerrors.Wrap(err, "error during ObservationsTimestampFromReport")
+	}
+
+	rp.logger.Debugw("ShouldAcceptFinalizedReport() = true",
+		"reportEpochRound", reportEpochRound,
+		"latestAcceptedEpochRound", rp.latestAcceptedEpochRound,
+	)
+
+	if int64(observationsTimestamp) > rp.maxFinalizedTimestamp.Load() {
+		rp.cancelOnce.Do(rp.cancel) // abort fetch because we will store the value from the protocol instead
+		rp.maxFinalizedTimestamp.Store(int64(observationsTimestamp))
+	}
+	rp.latestAcceptedEpochRound = reportEpochRound
+
+	return true, nil
+}
+
+func (rp *reportingPlugin) ShouldTransmitAcceptedReport(ctx context.Context, repts ocrtypes.ReportTimestamp, report ocrtypes.Report) (bool, error) {
+	return true, nil
+}
+
+func (rp *reportingPlugin) Close() error {
+	rp.cancelOnce.Do(rp.cancel)
+	rp.wg.Wait()
+	return nil
+}
+
+type epochRound struct {
+	Epoch uint32
+	Round uint8
+}
+
+func (x epochRound) Less(y epochRound) bool {
+	return x.Epoch < y.Epoch || (x.Epoch == y.Epoch && x.Round < y.Round)
+}
+
+// NOTE: All aggregate functions assume at least one element in the passed slice
+// The passed slice might be mutated (sorted)
+
+// getConsensusTimestamp gets the median timestamp
+func getConsensusTimestamp(paos []parsedAttributedObservation) uint32 {
+	sort.Slice(paos, func(i, j int) bool {
+		return paos[i].Timestamp < paos[j].Timestamp
+	})
+	return paos[len(paos)/2].Timestamp
+}
+
+// getConsensusValue gets the median of the i-th value
+func getConsensusValue(paos []parsedAttributedObservation, i int) *big.Int {
+	sort.Slice(paos, func(a, b int) bool {
+		return paos[a].Values[i].Cmp(paos[b].Values[i]) < 0
+	})
+	return paos[len(paos)/2].Values[i]
+}
+
+// getConsensusMaxFinalizedTimestamp gets the most common max finalized
+// timestamp, which must be observed by at least f+1 oracles. Ties are broken
+// by the highest timestamp.
+func getConsensusMaxFinalizedTimestamp(paos []parsedAttributedObservation, f int) (int64, error) {
+	counts := make(map[int64]int)
+	for _, pao := range paos {
+		counts[pao.MaxFinalizedTimestamp]++
+	}
+	var consensus int64
+	var maxCount int
+	for ts, count := range counts {
+		if count > maxCount || (count == maxCount && ts > consensus) {
+			consensus, maxCount = ts, count
+		}
+	}
+	if maxCount < f+1 {
+		return 0, pkgerrors.Errorf("no valid maxFinalizedTimestamp with at least f+1 votes (got counts: %v)", counts)
+	}
+	return consensus, nil
+}
@@ -0,0 +1,169 @@
+package schema_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	relaymercury "github.com/smartcontractkit/chainlink-relay/pkg/reportingplugins/mercury"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/mercury/schema"
+	"github.com/smartcontractkit/chainlink/v2/core/services/relay/evm/mercury/v2/reportcodec"
+)
+
+type fakeDataSource struct {
+	obs schema.Observation
+}
+
+func (ds fakeDataSource) Observe(context.Context, ocrtypes.ReportTimestamp) (schema.Observation, error) {
+	return ds.obs, nil
+}
+
+type fakeFetcher struct {
+	ts int64
+}
+
+func (f fakeFetcher) FetchInitialMaxFinalizedTimestamp(context.Context) (int64, error) {
+	return f.ts, nil
+}
+
+func newPlugin(t *testing.T, ds schema.DataSource, maxFinalizedTimestamp int64) ocrtypes.ReportingPlugin {
+	onchainConfig, err := relaymercury.StandardOnchainConfigCodec{}.Encode(relaymercury.OnchainConfig{Min: big.NewInt(1), Max: big.NewInt(1000)})
+	require.NoError(t, err)
+
+	codec := reportcodec.NewReportCodec([32]byte{'f', 'o', 'o'}, logger.TestLogger(t))
+	factory := schema.NewFactory(schema.V2, ds, logger.TestLogger(t), relaymercury.StandardOnchainConfigCodec{}, codec, fakeFetcher{maxFinalizedTimestamp})
+	rp, info, err := factory.NewReportingPlugin(ocrtypes.ReportingPluginConfig{OnchainConfig: onchainConfig, N: 4, F: 1})
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, rp.Close()) })
+	assert.Equal(t, "MercuryV2", info.Name)
+	return rp
+}
+
+func observation(t *testing.T, ts uint32, maxFinalizedTimestamp int64, values ...int64) []byte {
+	o := map[string]interface{}{"timestamp": ts, "maxFinalizedTimestamp": maxFinalizedTimestamp}
+	var vs []*big.Int
+	for _, v := range values {
+		vs = append(vs, big.NewInt(v))
+	}
+	o["values"] = vs
+	b, err := json.Marshal(o)
+	require.NoError(t, err)
+	return b
+}
+
+func Test_ReportingPlugin_Observation(t *testing.T) {
+	ds := fakeDataSource{schema.Observation{Values: []relaymercury.ObsResult[*big.Int]{
+		{Val: big.NewInt(100)},
+		{Err: assert.AnError},
+		{Val: big.NewInt(101)},
+	}}}
+	rp := newPlugin(t, ds, 1686000000)
+
+	var b ocrtypes.Observation
+	require.Eventually(t, func() bool {
+		var err error
+		b, err = rp.Observation(testutils.Context(t), ocrtypes.ReportTimestamp{}, nil)
+		return err == nil
+	}, testutils.WaitTimeout(t), 100*time.Millisecond)
+
+	var o struct {
+		Timestamp             uint32
+		MaxFinalizedTimestamp int64
+		Values                []*big.Int
+	}
+	require.NoError(t, json.Unmarshal(b, &o))
+	assert.NotZero(t, o.Timestamp)
+	assert.Equal(t, int64(1686000000), o.MaxFinalizedTimestamp)
+	assert.Equal(t, []*big.Int{big.NewInt(100), nil, big.NewInt(101)}, o.Values)
+}
+
+func Test_ReportingPlugin_Report(t *testing.T) {
+	rp := newPlugin(t, fakeDataSource{}, 0)
+	ctx := testutils.Context(t)
+	aos := func(obs ...[]byte) (aos []ocrtypes.AttributedObservation) {
+		for i, o := range obs {
+			aos = append(aos, ocrtypes.AttributedObservation{Observation: o, Observer: commontypes.OracleID(i)})
+		}
+		return
+	}
+
+	t.Run("reports the median of each value", func(t *testing.T) {
+		should, report, err := rp.Report(ctx, ocrtypes.ReportTimestamp{}, nil, aos(
+			observation(t, 1686000010, 1686000000, 10, 9, 11),
+			observation(t, 1686000011, 1686000000, 20, 19, 21),
+			observation(t, 1686000012, 1686000000, 30, 29, 31),
+			observation(t, 1686000013, 1686000000, 40, 39, 41),
+		))
+		require.NoError(t, err)
+		require.True(t, should)
+
+		reportElems := make(map[string]interface{})
+		require.NoError(t, reportcodec.ReportTypes.UnpackIntoMap(reportElems, report))
+		assert.Equal(t, uint32(1686000001), reportElems["validFromTimestamp"])
+		assert.Equal(t, uint32(1686000012), reportElems["observationsTimestamp"])
+		assert.Equal(t, big.NewInt(30), reportElems["benchmarkPrice"])
+		assert.Equal(t, big.NewInt(29), reportElems["bid"])
+		assert.Equal(t, big.NewInt(31), reportElems["ask"])
+	})
+
+	t.Run("does not report observations older than the latest report", func(t *testing.T) {
+		should, _, err := rp.Report(ctx, ocrtypes.ReportTimestamp{}, nil, aos(
+			observation(t, 1686000000, 1686000000, 10, 9, 11),
+			observation(t, 1686000000, 1686000000, 10, 9, 11),
+		))
+		require.NoError(t, err)
+		assert.False(t, should)
+	})
+
+	t.Run("does not report prices out of range", func(t *testing.T) {
+		should, _, err := rp.Report(ctx, ocrtypes.ReportTimestamp{}, nil, aos(
+			observation(t, 1686000010, 1686000000, 10, 9, 1001),
+			observation(t, 1686000010, 1686000000, 10, 9, 1001),
+		))
+		require.NoError(t, err)
+		assert.False(t, should)
+	})
+
+	t.Run("drops observations with missing values", func(t *testing.T) {
+		_, _, err := rp.Report(ctx, ocrtypes.ReportTimestamp{}, nil, aos(
+			observation(t, 1686000010, 1686000000, 10, 9, 11),
+			observation(t, 1686000010, 1686000000, 10, 9),
+		))
+		require.EqualError(t, err, "only received 1 valid attributed observations, but need at least f+1 (2)")
+	})
+
+	t.Run("requires f+1 oracles to agree on the max finalized timestamp", func(t *testing.T) {
+		_, _, err := rp.Report(ctx, ocrtypes.ReportTimestamp{}, nil, aos(
+			observation(t, 1686000010, 1686000000, 10, 9, 11),
+			observation(t, 1686000010, 1686000001, 10, 9, 11),
+		))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no valid maxFinalizedTimestamp with at least f+1 votes")
+	})
+}
+
+func Test_ReportingPlugin_ShouldAcceptFinalizedReport(t *testing.T) {
+	rp := newPlugin(t, fakeDataSource{}, 0)
+	ctx := testutils.Context(t)
+	codec := reportcodec.NewReportCodec([32]byte{'f', 'o', 'o'}, logger.TestLogger(t))
+	report, err := codec.BuildReport(schema.Report{ValidFromTimestamp: 1, ObservationsTimestamp: 1686000000, Values: []*big.Int{big.NewInt(1), big.NewInt(1), big.NewInt(1)}})
+	require.NoError(t, err)
+
+	should, err := rp.ShouldAcceptFinalizedReport(ctx, ocrtypes.ReportTimestamp{Epoch: 1, Round: 1}, report)
+	require.NoError(t, err)
+	assert.True(t, should)
+
+	should, err = rp.ShouldAcceptFinalizedReport(ctx, ocrtypes.ReportTimestamp{Epoch: 1, Round: 1}, report)
+	require.NoError(t, err)
+	assert.False(t, should, "stale epoch and round")
+}
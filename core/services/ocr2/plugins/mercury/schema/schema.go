@@ -0,0 +1,56 @@
+// Package schema implements the Mercury reporting plugin for the timestamped
+// report schemas, versions 2 and up. Unlike version 1 reports, which carry the
+// current block of the chain, these only need the time of the observations,
+// so that feeds can be reported for chains without block numbers.
+package schema
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/mercury/config"
+)
+
+// Field is a value observed for each report of a schema.
+type Field struct {
+	Name string
+	// Price fields must be within the Min and Max of the onchain config.
+	Price bool
+}
+
+// Schema is a versioned layout of Mercury reports.
+type Schema struct {
+	Version uint32
+	// Fields are the values observed for each report, in the order the
+	// pipeline of a job returns them.
+	Fields []Field
+}
+
+var (
+	// V2 reports the benchmark price, bid and ask.
+	V2 = Schema{config.SchemaV2, []Field{
+		{"benchmarkPrice", true},
+		{"bid", true},
+		{"ask", true},
+	}}
+	// V3 reports the benchmark price, bid and ask, with the fees to pay in
+	// the native token and in LINK to verify the report.
+	V3 = Schema{config.SchemaV3, []Field{
+		{"benchmarkPrice", true},
+		{"bid", true},
+		{"ask", true},
+		{"nativeFee", false},
+		{"linkFee", false},
+	}}
+)
+
+// Get returns the timestamped schema of version.
+func Get(version uint32) (Schema, error) {
+	switch version {
+	case config.SchemaV2:
+		return V2, nil
+	case config.SchemaV3:
+		return V3, nil
+	default:
+		return Schema{}, errors.Errorf("no timestamped report schema with version %d", version)
+	}
+}
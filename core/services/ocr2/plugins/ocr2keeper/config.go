@@ -52,6 +52,12 @@ type PluginConfig struct {
 	// workers or slower RPC responses will cause this queue to build up.
 	// Adding new items to the queue will block if the queue becomes full.
 	ServiceQueueLength int `json:"serviceQueueLength"`
+	// MercuryLookupRateLimit is the number of MercuryLookups an upkeep can make
+	// in MercuryLookupRateLimitWindow. It defaults to 30.
+	MercuryLookupRateLimit int `json:"mercuryLookupRateLimit"`
+	// MercuryLookupRateLimitWindow is the window of MercuryLookupRateLimit. It
+	// defaults to a minute.
+	MercuryLookupRateLimitWindow Duration `json:"mercuryLookupRateLimitWindow"`
 }
 
 func ValidatePluginConfig(cfg PluginConfig) error {
@@ -75,5 +81,13 @@ func ValidatePluginConfig(cfg PluginConfig) error {
 		return fmt.Errorf("service queue length cannot be less than zero")
 	}
 
+	if cfg.MercuryLookupRateLimit < 0 {
+		return fmt.Errorf("mercury lookup rate limit cannot be less than zero")
+	}
+
+	if cfg.MercuryLookupRateLimitWindow < 0 {
+		return fmt.Errorf("mercury lookup rate limit window cannot be less than zero")
+	}
+
 	return nil
 }
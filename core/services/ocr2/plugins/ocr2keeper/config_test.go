@@ -28,3 +28,18 @@ func TestUnmarshalConfig(t *testing.T) {
 	assert.Equal(t, 2*time.Second, config.CacheExpiration.Value())
 	assert.Equal(t, 42, config.MaxServiceWorkers)
 }
+
+func TestUnmarshalConfig_MercuryLookupRateLimit(t *testing.T) {
+	raw := `{"mercuryLookupRateLimit":10,"mercuryLookupRateLimitWindow":"30s"}`
+
+	var config PluginConfig
+	err := json.Unmarshal([]byte(raw), &config)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 10, config.MercuryLookupRateLimit)
+	assert.Equal(t, 30*time.Second, config.MercuryLookupRateLimitWindow.Value())
+	assert.NoError(t, ValidatePluginConfig(config))
+
+	config.MercuryLookupRateLimit = -1
+	assert.Error(t, ValidatePluginConfig(config))
+}
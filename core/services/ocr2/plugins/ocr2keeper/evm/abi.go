@@ -120,6 +120,41 @@ func (rp *evmRegistryPackerV2_0) UnpackPerformResult(raw string) (bool, error) {
 	return *abi.ConvertType(out[0], new(bool)).(*bool), nil
 }
 
+// UnpackCheckFees returns the failure reason and the fee data of a
+// checkUpkeep result, which are set even when the target check reverted.
+func (rp *evmRegistryPackerV2_0) UnpackCheckFees(raw string) (uint8, *big.Int, *big.Int, error) {
+	b, err := hexutil.Decode(raw)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	out, err := rp.abi.Methods["checkUpkeep"].Outputs.UnpackValues(b)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("%w: unpack checkUpkeep return: %s", err, raw)
+	}
+
+	failureReason := *abi.ConvertType(out[2], new(uint8)).(*uint8)
+	fastGasWei := *abi.ConvertType(out[4], new(*big.Int)).(**big.Int)
+	linkNative := *abi.ConvertType(out[5], new(*big.Int)).(**big.Int)
+	return failureReason, fastGasWei, linkNative, nil
+}
+
+func (rp *evmRegistryPackerV2_0) UnpackCheckLogResult(raw string) (bool, []byte, error) {
+	b, err := hexutil.Decode(raw)
+	if err != nil {
+		return false, nil, err
+	}
+
+	out, err := logTriggerABI.Methods["checkLog"].Outputs.UnpackValues(b)
+	if err != nil {
+		return false, nil, fmt.Errorf("%w: unpack checkLog return: %s", err, raw)
+	}
+
+	upkeepNeeded := *abi.ConvertType(out[0], new(bool)).(*bool)
+	performData := *abi.ConvertType(out[1], new([]byte)).(*[]byte)
+	return upkeepNeeded, performData, nil
+}
+
 func (rp *evmRegistryPackerV2_0) UnpackUpkeepResult(id *big.Int, raw string) (activeUpkeep, error) {
 	b, err := hexutil.Decode(raw)
 	if err != nil {
@@ -147,9 +182,15 @@ func (rp *evmRegistryPackerV2_0) UnpackUpkeepResult(id *big.Int, raw string) (ac
 
 	au := activeUpkeep{
 		ID:              id,
+		Target:          temp.Target,
 		PerformGasLimit: temp.ExecuteGas,
 		CheckData:       temp.CheckData,
 	}
+	// an upkeep with an invalid log trigger config is treated as a
+	// conditional upkeep
+	if logTrigger, err := UnpackLogTriggerConfig(temp.OffchainConfig); err == nil {
+		au.LogTrigger = logTrigger
+	}
 
 	return au, nil
 }
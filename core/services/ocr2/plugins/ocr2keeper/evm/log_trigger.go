@@ -0,0 +1,700 @@
+package evm
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/smartcontractkit/ocr2keepers/pkg/types"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/keeper_registry_wrapper2_0"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+)
+
+var (
+	ErrLogTriggerConfigNotParsable = fmt.Errorf("log trigger config not parsable")
+	// MaxLogTriggerChecks is the maximum number of logs a log triggered upkeep
+	// is checked with for an upkeep key. Further logs are checked once the
+	// first ones are performed or leave the lookback window.
+	MaxLogTriggerChecks = 10
+)
+
+var (
+	// logTriggerABI holds dummy functions to encode the log trigger config and
+	// the perform data of log triggered upkeeps, and their checkLog function:
+	// checkLog(Log log, bytes checkData) returns (bool upkeepNeeded, bytes performData)
+	logTriggerABI, _ = abi.JSON(strings.NewReader(`[{
+		"name":"logTriggerConfig",
+		"type":"function",
+		"inputs":[
+			{"type":"address","name":"contractAddress"},
+			{"type":"uint8","name":"filterSelector"},
+			{"type":"bytes32","name":"topic0"},
+			{"type":"bytes32","name":"topic1"},
+			{"type":"bytes32","name":"topic2"},
+			{"type":"bytes32","name":"topic3"}
+		]
+	},{
+		"name":"logTriggerPerformData",
+		"type":"function",
+		"inputs":[
+			{"type":"bytes32","name":"txHash"},
+			{"type":"uint256","name":"logIndex"},
+			{"type":"bytes","name":"performData"}
+		]
+	},{
+		"name":"checkLog",
+		"type":"function",
+		"inputs":[{
+			"name":"log",
+			"type":"tuple",
+			"components":[
+				{"type":"uint256","name":"index"},
+				{"type":"uint256","name":"timestamp"},
+				{"type":"bytes32","name":"txHash"},
+				{"type":"uint256","name":"blockNumber"},
+				{"type":"bytes32","name":"blockHash"},
+				{"type":"address","name":"source"},
+				{"type":"bytes32[]","name":"topics"},
+				{"type":"bytes","name":"data"}
+			]
+		},{"type":"bytes","name":"checkData"}],
+		"outputs":[
+			{"type":"bool","name":"upkeepNeeded"},
+			{"type":"bytes","name":"performData"}
+		]
+	}]`,
+	))
+)
+
+// LogTriggerConfig makes an upkeep log triggered: instead of being polled with
+// checkUpkeep, it is checked with checkLog for each log matching the config.
+// It is set as the offchain config of the upkeep, encoded with
+// PackLogTriggerConfig.
+type LogTriggerConfig struct {
+	ContractAddress common.Address
+	// FilterSelector is a bit mask of the topics to match besides Topic0, the
+	// event signature: bit 0 for Topic1, bit 1 for Topic2 and bit 2 for Topic3.
+	FilterSelector uint8
+	Topic0         [32]byte
+	Topic1         [32]byte
+	Topic2         [32]byte
+	Topic3         [32]byte
+}
+
+func PackLogTriggerConfig(cfg LogTriggerConfig) ([]byte, error) {
+	return logTriggerABI.Pack("logTriggerConfig", cfg.ContractAddress, cfg.FilterSelector, cfg.Topic0, cfg.Topic1, cfg.Topic2, cfg.Topic3)
+}
+
+// UnpackLogTriggerConfig returns the log trigger config of an upkeep from its
+// offchain config, or nil if the upkeep is not log triggered.
+func UnpackLogTriggerConfig(offchainConfig []byte) (*LogTriggerConfig, error) {
+	method := logTriggerABI.Methods["logTriggerConfig"]
+	if !bytes.HasPrefix(offchainConfig, method.ID) {
+		return nil, nil
+	}
+	values, err := method.Inputs.Unpack(offchainConfig[len(method.ID):])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrLogTriggerConfigNotParsable, err)
+	}
+	var cfg LogTriggerConfig
+	if err = method.Inputs.Copy(&cfg, values); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrLogTriggerConfigNotParsable, err)
+	}
+	if cfg.ContractAddress == (common.Address{}) {
+		return nil, fmt.Errorf("%w: empty contract address", ErrLogTriggerConfigNotParsable)
+	}
+	if cfg.Topic0 == (common.Hash{}) {
+		return nil, fmt.Errorf("%w: empty event signature", ErrLogTriggerConfigNotParsable)
+	}
+	if cfg.FilterSelector > 7 {
+		return nil, fmt.Errorf("%w: invalid filter selector %d", ErrLogTriggerConfigNotParsable, cfg.FilterSelector)
+	}
+	return &cfg, nil
+}
+
+// matches returns true if the log is emitted by the contract with the topics
+// selected by the config.
+func (c LogTriggerConfig) matches(l logpoller.Log) bool {
+	topics := l.GetTopics()
+	if l.Address != c.ContractAddress || len(topics) == 0 || topics[0] != c.Topic0 {
+		return false
+	}
+	for i, topic := range [][32]byte{c.Topic1, c.Topic2, c.Topic3} {
+		if c.FilterSelector&(1<<i) == 0 {
+			continue
+		}
+		if len(topics) <= i+1 || topics[i+1] != topic {
+			return false
+		}
+	}
+	return true
+}
+
+func LogTriggerFilterName(addr common.Address, id *big.Int) string {
+	return logpoller.FilterName("EvmRegistry - log trigger for upkeep", addr.String(), id.String())
+}
+
+// logID identifies a log by its transaction hash and log index.
+type logID struct {
+	txHash   common.Hash
+	logIndex int64
+}
+
+func idOfLog(l logpoller.Log) logID {
+	return logID{txHash: l.TxHash, logIndex: l.LogIndex}
+}
+
+// logPosition orders logs on chain.
+type logPosition struct {
+	block    int64
+	logIndex int64
+}
+
+func positionOfLog(l logpoller.Log) logPosition {
+	return logPosition{block: l.BlockNumber, logIndex: l.LogIndex}
+}
+
+func (p logPosition) before(o logPosition) bool {
+	return p.block < o.block || (p.block == o.block && p.logIndex < o.logIndex)
+}
+
+// packLogTriggerPerformData returns the perform data of a log triggered upkeep,
+// abi encoded as (bytes32 txHash, uint256 logIndex, bytes performData): the ID
+// of the log it was checked with, followed by the perform data returned by
+// checkLog. The log ID is read back from the transmitted reports, so that every
+// node knows which logs were performed.
+func packLogTriggerPerformData(id logID, performData []byte) ([]byte, error) {
+	return logTriggerABI.Methods["logTriggerPerformData"].Inputs.Pack(id.txHash, big.NewInt(id.logIndex), performData)
+}
+
+func unpackLogTriggerPerformData(b []byte) (logID, []byte, error) {
+	values, err := logTriggerABI.Methods["logTriggerPerformData"].Inputs.Unpack(b)
+	if err != nil {
+		return logID{}, nil, err
+	}
+	txHash := *abi.ConvertType(values[0], new([32]byte)).(*[32]byte)
+	logIndex := *abi.ConvertType(values[1], new(*big.Int)).(**big.Int)
+	performData := *abi.ConvertType(values[2], new([]byte)).(*[]byte)
+	if !logIndex.IsInt64() {
+		return logID{}, nil, fmt.Errorf("invalid log index %s", logIndex)
+	}
+	return logID{txHash: txHash, logIndex: logIndex.Int64()}, performData, nil
+}
+
+// logWindow returns the blocks of the logs checked at block.
+func logWindow(block int64) (start, end int64) {
+	start = block - logEventLookback
+	if start < 0 {
+		start = 0
+	}
+	return start, block
+}
+
+// logTriggerUpkeep is a log triggered upkeep. The logs it is checked with are
+// derived from the chain, see pendingLogs.
+type logTriggerUpkeep struct {
+	id     *big.Int
+	config LogTriggerConfig
+	// hasLogs is set if the upkeep had logs to be checked at the last poll,
+	// and only decides whether the upkeep is proposed for a check.
+	hasLogs bool
+}
+
+// performTx holds the logs which log triggered upkeeps were performed for by a
+// perform transaction, by upkeep ID.
+type performTx struct {
+	block int64
+	logs  map[string]logID
+}
+
+// syncLogTriggers registers the log filters of the log triggered upkeeps among
+// actives, and unregisters those of upkeeps which are no longer log
+// triggered. If all is set, actives are all the active upkeeps, and the log
+// triggers of other upkeeps are removed too.
+func (r *EvmRegistry) syncLogTriggers(actives []activeUpkeep, all bool) {
+	latest, err := r.poller.LatestBlock(pg.WithParentCtx(r.ctx))
+	if err != nil {
+		r.lggr.Errorw("failed to get latest block for log triggers", "err", err)
+		return
+	}
+
+	r.logMu.Lock()
+	defer r.logMu.Unlock()
+	if r.logTriggers == nil {
+		r.logTriggers = make(map[string]*logTriggerUpkeep)
+	}
+
+	var registered bool
+	activeIDs := make(map[string]bool, len(actives))
+	for _, active := range actives {
+		id := active.ID.String()
+		activeIDs[id] = active.LogTrigger != nil
+		if active.LogTrigger == nil {
+			continue
+		}
+		if existing, ok := r.logTriggers[id]; ok && existing.config == *active.LogTrigger {
+			continue
+		}
+		if err := r.poller.RegisterFilter(logpoller.Filter{
+			Name:      LogTriggerFilterName(r.addr, active.ID),
+			EventSigs: []common.Hash{active.LogTrigger.Topic0},
+			Addresses: []common.Address{active.LogTrigger.ContractAddress},
+		}); err != nil {
+			r.lggr.Errorw("failed to register log trigger filter", "upkeepID", id, "err", err)
+			continue
+		}
+		r.lggr.Debugw("registered log trigger", "upkeepID", id, "contractAddress", active.LogTrigger.ContractAddress)
+		r.logTriggers[id] = &logTriggerUpkeep{id: active.ID, config: *active.LogTrigger}
+		registered = true
+	}
+	if registered {
+		// the logs of the lookback window are replayed, so that they are checked
+		// whenever the node registered the filter
+		start, _ := logWindow(latest)
+		r.poller.ReplayAsync(start)
+	}
+
+	for id, upkeep := range r.logTriggers {
+		logTriggered, ok := activeIDs[id]
+		if logTriggered || (!ok && !all) {
+			continue
+		}
+		r.removeLogTrigger(upkeep)
+	}
+}
+
+func (r *EvmRegistry) removeLogTrigger(upkeep *logTriggerUpkeep) {
+	if err := r.poller.UnregisterFilter(LogTriggerFilterName(r.addr, upkeep.id), nil); err != nil {
+		r.lggr.Errorw("failed to unregister log trigger filter", "upkeepID", upkeep.id.String(), "err", err)
+	}
+	delete(r.logTriggers, upkeep.id.String())
+}
+
+func (r *EvmRegistry) closeLogTriggers() {
+	r.logMu.Lock()
+	defer r.logMu.Unlock()
+	for _, upkeep := range r.logTriggers {
+		r.removeLogTrigger(upkeep)
+	}
+}
+
+// hasPendingLogs returns true if the upkeep is log triggered and had logs to be
+// checked at the last poll.
+func (r *EvmRegistry) hasPendingLogs(id *big.Int) bool {
+	r.logMu.Lock()
+	defer r.logMu.Unlock()
+	upkeep, ok := r.logTriggers[id.String()]
+	return ok && upkeep.hasLogs
+}
+
+func (r *EvmRegistry) logTrigger(id *big.Int) (LogTriggerConfig, bool) {
+	r.logMu.Lock()
+	defer r.logMu.Unlock()
+	upkeep, ok := r.logTriggers[id.String()]
+	if !ok {
+		return LogTriggerConfig{}, false
+	}
+	return upkeep.config, true
+}
+
+func (r *EvmRegistry) isLogTriggered(id *big.Int) bool {
+	_, ok := r.logTrigger(id)
+	return ok
+}
+
+// pollLogTriggers records which log triggered upkeeps have logs to be checked
+// at the latest block.
+func (r *EvmRegistry) pollLogTriggers() error {
+	latest, err := r.poller.LatestBlock(pg.WithParentCtx(r.ctx))
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrHeadNotAvailable, err)
+	}
+	start, _ := logWindow(latest)
+
+	r.logMu.Lock()
+	upkeeps := make([]logTriggerUpkeep, 0, len(r.logTriggers))
+	for _, upkeep := range r.logTriggers {
+		upkeeps = append(upkeeps, *upkeep)
+	}
+	// perform transactions are only needed while in the lookback window of
+	// the blocks being checked
+	for hash, tx := range r.performTxs {
+		if tx.block < start-logEventLookback {
+			delete(r.performTxs, hash)
+		}
+	}
+	r.logMu.Unlock()
+	if len(upkeeps) == 0 {
+		return nil
+	}
+
+	performed, err := r.performedLogs(r.ctx, latest)
+	if err != nil {
+		return err
+	}
+
+	var multiErr error
+	for _, upkeep := range upkeeps {
+		logs, err := r.pendingLogs(r.ctx, upkeep.config, latest, performed[upkeep.id.String()])
+		if err != nil {
+			multierr.AppendInto(&multiErr, fmt.Errorf("upkeep %s: %w", upkeep.id, err))
+			continue
+		}
+		r.logMu.Lock()
+		if current, ok := r.logTriggers[upkeep.id.String()]; ok && current.config == upkeep.config {
+			current.hasLogs = len(logs) > 0
+		}
+		r.logMu.Unlock()
+	}
+	return multiErr
+}
+
+// pendingLogs returns the logs to check a log triggered upkeep with at block, in
+// chain order: the logs matching its config in the lookback window of the block
+// which it was not performed for. It only depends on the chain up to block, so
+// that every node checks an upkeep key with the same logs.
+func (r *EvmRegistry) pendingLogs(ctx context.Context, config LogTriggerConfig, block int64, performed map[logID]bool) ([]logpoller.Log, error) {
+	start, end := logWindow(block)
+	logs, err := r.poller.Logs(start, end, config.Topic0, config.ContractAddress, pg.WithParentCtx(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrLogReadFailure, err)
+	}
+	pending := make([]logpoller.Log, 0, len(logs))
+	for _, l := range logs {
+		if config.matches(l) && !performed[idOfLog(l)] {
+			pending = append(pending, l)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return positionOfLog(pending[i]).before(positionOfLog(pending[j]))
+	})
+	return pending, nil
+}
+
+// performedLogs returns the logs which log triggered upkeeps were performed for
+// in the lookback window of block, by upkeep ID. The logs are read from the
+// perform data of the reports transmitted by the UpkeepPerformed transactions.
+func (r *EvmRegistry) performedLogs(ctx context.Context, block int64) (map[string]map[logID]bool, error) {
+	start, end := logWindow(block)
+	logs, err := r.poller.LogsWithSigs(start, end, []common.Hash{keeper_registry_wrapper2_0.KeeperRegistryUpkeepPerformed{}.Topic()}, r.addr, pg.WithParentCtx(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrLogReadFailure, err)
+	}
+
+	blocks := make(map[common.Hash]int64)
+	for _, l := range logs {
+		abilog, err := r.registry.ParseLog(l.ToGethLog())
+		if err != nil {
+			r.lggr.Errorw("failed to parse UpkeepPerformed log", "txHash", l.TxHash, "err", err)
+			continue
+		}
+		performed, ok := abilog.(*keeper_registry_wrapper2_0.KeeperRegistryUpkeepPerformed)
+		if !ok || !r.isLogTriggered(performed.Id) {
+			continue
+		}
+		blocks[l.TxHash] = l.BlockNumber
+	}
+
+	txs, err := r.getPerformTxs(ctx, blocks)
+	if err != nil {
+		return nil, err
+	}
+	performed := make(map[string]map[logID]bool)
+	for _, tx := range txs {
+		for id, l := range tx.logs {
+			if performed[id] == nil {
+				performed[id] = make(map[logID]bool)
+			}
+			performed[id][l] = true
+		}
+	}
+	return performed, nil
+}
+
+// getPerformTxs returns the perform transactions of the given hashes, with the
+// blocks of their UpkeepPerformed logs. Transactions are fetched once, and
+// cached while in the lookback window.
+func (r *EvmRegistry) getPerformTxs(ctx context.Context, blocks map[common.Hash]int64) ([]performTx, error) {
+	txs := make([]performTx, 0, len(blocks))
+	var reqs []rpc.BatchElem
+	var hashes []common.Hash
+	r.logMu.Lock()
+	if r.performTxs == nil {
+		r.performTxs = make(map[common.Hash]performTx)
+	}
+	for hash := range blocks {
+		if tx, ok := r.performTxs[hash]; ok {
+			txs = append(txs, tx)
+			continue
+		}
+		hashes = append(hashes, hash)
+		reqs = append(reqs, rpc.BatchElem{
+			Method: "eth_getTransactionByHash",
+			Args:   []interface{}{hash},
+			Result: new(gethtypes.Transaction),
+		})
+	}
+	r.logMu.Unlock()
+	if len(reqs) == 0 {
+		return txs, nil
+	}
+
+	if err := r.client.BatchCallContext(ctx, reqs); err != nil {
+		return nil, err
+	}
+	fetched := make(map[common.Hash]performTx, len(reqs))
+	for i, req := range reqs {
+		if req.Error != nil {
+			return nil, fmt.Errorf("failed to get perform transaction %s: %w", hashes[i], req.Error)
+		}
+		tx := performTx{block: blocks[hashes[i]], logs: make(map[string]logID)}
+		data := req.Result.(*gethtypes.Transaction).Data()
+		if len(data) < 4 {
+			return nil, fmt.Errorf("invalid data of perform transaction %s", hashes[i])
+		}
+		report, err := r.packer.UnpackTransmitTxInput(data[4:])
+		if err != nil {
+			return nil, fmt.Errorf("failed to unpack perform transaction %s: %w", hashes[i], err)
+		}
+		for _, result := range report {
+			_, upkeepID, err := result.Key.BlockKeyAndUpkeepID()
+			if err != nil {
+				return nil, err
+			}
+			// the perform data of conditional upkeeps does not hold a log ID
+			if id, _, err := unpackLogTriggerPerformData(result.PerformData); err == nil {
+				tx.logs[string(upkeepID)] = id
+			}
+		}
+		fetched[hashes[i]] = tx
+		txs = append(txs, tx)
+	}
+
+	r.logMu.Lock()
+	for hash, tx := range fetched {
+		r.performTxs[hash] = tx
+	}
+	r.logMu.Unlock()
+	return txs, nil
+}
+
+// checkLogTriggerUpkeeps checks each log triggered upkeep with its pending logs
+// at the block of its key, up to MaxLogTriggerChecks of them, returning the
+// results in the order of keys. The upkeep is eligible for the first log found
+// needed, whose ID is encoded in the perform data. The registry is checked too,
+// for the fees and the state of the upkeep.
+func (r *EvmRegistry) checkLogTriggerUpkeeps(ctx context.Context, keys []types.UpkeepKey) ([]types.UpkeepResult, error) {
+	var (
+		results   = make([]types.UpkeepResult, len(keys))
+		checked   = make([][]logpoller.Log, len(keys))
+		blocks    = make([]uint64, 0, len(keys))
+		reqs      = make([]rpc.BatchElem, 0, 2*len(keys))
+		reqIdx    = make([]int, 0, len(keys))
+		reqOffset = make([]int, 0, len(keys))
+		performed = make(map[int64]map[string]map[logID]bool)
+	)
+
+	latest, err := r.poller.LatestBlock(pg.WithParentCtx(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrHeadNotAvailable, err)
+	}
+
+	for i, key := range keys {
+		block, upkeepId, err := blockAndIdFromKey(key)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = types.UpkeepResult{
+			Key:              key,
+			State:            types.NotEligible,
+			FailureReason:    UPKEEP_FAILURE_REASON_UPKEEP_NOT_NEEDED,
+			GasUsed:          big.NewInt(0),
+			FastGasWei:       big.NewInt(0),
+			LinkNative:       big.NewInt(0),
+			CheckBlockNumber: uint32(block.Uint64()),
+			ExecuteGas:       5_000_000,
+		}
+
+		config, ok := r.logTrigger(upkeepId)
+		r.mu.RLock()
+		active, isActive := r.active[upkeepId.String()]
+		r.mu.RUnlock()
+		if !ok || !isActive {
+			continue
+		}
+		if block.Int64() > latest {
+			// the logs of the block may not be polled yet
+			return nil, fmt.Errorf("%w: block %s of key %s is after the latest polled block %d", ErrHeadNotAvailable, block, key, latest)
+		}
+
+		if _, ok := performed[block.Int64()]; !ok {
+			performed[block.Int64()], err = r.performedLogs(ctx, block.Int64())
+			if err != nil {
+				return nil, err
+			}
+		}
+		logs, err := r.pendingLogs(ctx, config, block.Int64(), performed[block.Int64()][upkeepId.String()])
+		if err != nil {
+			return nil, err
+		}
+		if len(logs) == 0 {
+			continue
+		}
+		if len(logs) > MaxLogTriggerChecks {
+			logs = logs[:MaxLogTriggerChecks]
+		}
+		checked[i] = logs
+		blocks = append(blocks, block.Uint64())
+
+		opts, err := r.buildCallOpts(ctx, block)
+		if err != nil {
+			return nil, err
+		}
+		registryPayload, err := r.abi.Pack("checkUpkeep", upkeepId)
+		if err != nil {
+			return nil, err
+		}
+		reqIdx = append(reqIdx, i)
+		reqOffset = append(reqOffset, len(reqs))
+		reqs = append(reqs, rpc.BatchElem{
+			Method: "eth_call",
+			Args: []interface{}{
+				map[string]interface{}{
+					"to":   r.addr.Hex(),
+					"data": hexutil.Bytes(registryPayload),
+				},
+				hexutil.EncodeBig(opts.BlockNumber),
+			},
+			Result: new(string),
+		})
+		for _, l := range logs {
+			logPayload, err := logTriggerABI.Pack("checkLog", logTriggerLogFrom(l), active.CheckData)
+			if err != nil {
+				return nil, err
+			}
+			reqs = append(reqs, rpc.BatchElem{
+				Method: "eth_call",
+				Args: []interface{}{
+					map[string]interface{}{
+						"to":   active.Target.Hex(),
+						"data": hexutil.Bytes(logPayload),
+					},
+					hexutil.EncodeBig(opts.BlockNumber),
+				},
+				Result: new(string),
+			})
+		}
+	}
+
+	if len(reqs) == 0 {
+		return results, nil
+	}
+	if err := r.client.BatchCallContext(ctx, reqs); err != nil {
+		return nil, err
+	}
+	blockHashes, err := r.blockHashes(ctx, blocks)
+	if err != nil {
+		return nil, err
+	}
+
+	var multiErr error
+	for j, i := range reqIdx {
+		registryReq := reqs[reqOffset[j]]
+		if registryReq.Error != nil {
+			r.lggr.Debugf("error encountered for key %s with message '%s' in check", keys[i], registryReq.Error)
+			multierr.AppendInto(&multiErr, registryReq.Error)
+			continue
+		}
+		reason, fastGasWei, linkNative, err := r.packer.UnpackCheckFees(*registryReq.Result.(*string))
+		if err != nil {
+			return nil, err
+		}
+		results[i].FailureReason = reason
+		results[i].FastGasWei = fastGasWei
+		results[i].LinkNative = linkNative
+		switch reason {
+		case UPKEEP_FAILURE_REASON_UPKEEP_CANCELLED, UPKEEP_FAILURE_REASON_UPKEEP_PAUSED, UPKEEP_FAILURE_REASON_INSUFFICIENT_BALANCE:
+			// the upkeep cannot be performed whatever the log
+			continue
+		}
+
+		results[i].FailureReason = UPKEEP_FAILURE_REASON_TARGET_CHECK_REVERTED
+		for k, l := range checked[i] {
+			logReq := reqs[reqOffset[j]+1+k]
+			if logReq.Error != nil {
+				r.lggr.Debugf("checkLog reverted for key %s and log %s:%d with message '%s'", keys[i], l.TxHash, l.LogIndex, logReq.Error)
+				continue
+			}
+			needed, performData, err := r.packer.UnpackCheckLogResult(*logReq.Result.(*string))
+			if err != nil {
+				return nil, err
+			}
+			if !needed {
+				results[i].FailureReason = UPKEEP_FAILURE_REASON_UPKEEP_NOT_NEEDED
+				continue
+			}
+			performData, err = packLogTriggerPerformData(idOfLog(l), performData)
+			if err != nil {
+				return nil, err
+			}
+			results[i].State = types.Eligible
+			results[i].FailureReason = UPKEEP_FAILURE_REASON_NONE
+			results[i].PerformData = performData
+			results[i].CheckBlockHash = blockHashes[uint64(results[i].CheckBlockNumber)]
+			break
+		}
+	}
+
+	return results, multiErr
+}
+
+func (r *EvmRegistry) blockHashes(ctx context.Context, numbers []uint64) (map[uint64]common.Hash, error) {
+	blocks, err := r.poller.GetBlocksRange(ctx, numbers, pg.WithParentCtx(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrHeadNotAvailable, err)
+	}
+	hashes := make(map[uint64]common.Hash, len(blocks))
+	for _, block := range blocks {
+		hashes[uint64(block.BlockNumber)] = block.BlockHash
+	}
+	return hashes, nil
+}
+
+type logTriggerLog struct {
+	Index       *big.Int
+	Timestamp   *big.Int
+	TxHash      [32]byte
+	BlockNumber *big.Int
+	BlockHash   [32]byte
+	Source      common.Address
+	Topics      [][32]byte
+	Data        []byte
+}
+
+func logTriggerLogFrom(l logpoller.Log) logTriggerLog {
+	topics := make([][32]byte, len(l.Topics))
+	for i, topic := range l.GetTopics() {
+		topics[i] = topic
+	}
+	return logTriggerLog{
+		Index:       big.NewInt(l.LogIndex),
+		Timestamp:   big.NewInt(l.BlockTimestamp.Unix()),
+		TxHash:      l.TxHash,
+		BlockNumber: big.NewInt(l.BlockNumber),
+		BlockHash:   l.BlockHash,
+		Source:      l.Address,
+		Topics:      topics,
+		Data:        l.Data,
+	}
+}
@@ -0,0 +1,284 @@
+package evm
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lib/pq"
+	"github.com/smartcontractkit/ocr2keepers/pkg/chain"
+	"github.com/smartcontractkit/ocr2keepers/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	evmClientMocks "github.com/smartcontractkit/chainlink/v2/core/chains/evm/client/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller"
+	lpmocks "github.com/smartcontractkit/chainlink/v2/core/chains/evm/logpoller/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/keeper_registry_wrapper2_0"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/ocr2keeper/evm/mocks"
+)
+
+var (
+	testTriggerAddress = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	testTriggerTopic0  = common.HexToHash("0xaa")
+	testTriggerTopic1  = common.HexToHash("0xbb")
+)
+
+func testLogTriggerConfig() LogTriggerConfig {
+	return LogTriggerConfig{
+		ContractAddress: testTriggerAddress,
+		FilterSelector:  1,
+		Topic0:          testTriggerTopic0,
+		Topic1:          testTriggerTopic1,
+	}
+}
+
+func testTriggerLog(block, index int64, topics ...common.Hash) logpoller.Log {
+	if len(topics) == 0 {
+		topics = []common.Hash{testTriggerTopic0, testTriggerTopic1}
+	}
+	raw := make(pq.ByteaArray, len(topics))
+	for i, topic := range topics {
+		raw[i] = topic.Bytes()
+	}
+	return logpoller.Log{
+		Address:        testTriggerAddress,
+		BlockNumber:    block,
+		LogIndex:       index,
+		TxHash:         common.BigToHash(big.NewInt(block*1000 + index)),
+		BlockTimestamp: time.Unix(block, 0),
+		EventSig:       topics[0],
+		Topics:         raw,
+	}
+}
+
+func TestLogTriggerConfig_PackUnpack(t *testing.T) {
+	cfg := testLogTriggerConfig()
+	packed, err := PackLogTriggerConfig(cfg)
+	require.NoError(t, err)
+
+	unpacked, err := UnpackLogTriggerConfig(packed)
+	require.NoError(t, err)
+	require.NotNil(t, unpacked)
+	assert.Equal(t, cfg, *unpacked)
+
+	t.Run("not log triggered", func(t *testing.T) {
+		unpacked, err := UnpackLogTriggerConfig(nil)
+		assert.NoError(t, err)
+		assert.Nil(t, unpacked)
+		unpacked, err = UnpackLogTriggerConfig([]byte(`{"foo":"bar"}`))
+		assert.NoError(t, err)
+		assert.Nil(t, unpacked)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := UnpackLogTriggerConfig(packed[:40])
+		assert.ErrorIs(t, err, ErrLogTriggerConfigNotParsable)
+
+		invalid := cfg
+		invalid.Topic0 = common.Hash{}
+		packed, err := PackLogTriggerConfig(invalid)
+		require.NoError(t, err)
+		_, err = UnpackLogTriggerConfig(packed)
+		assert.ErrorIs(t, err, ErrLogTriggerConfigNotParsable)
+
+		invalid = cfg
+		invalid.FilterSelector = 8
+		packed, err = PackLogTriggerConfig(invalid)
+		require.NoError(t, err)
+		_, err = UnpackLogTriggerConfig(packed)
+		assert.ErrorIs(t, err, ErrLogTriggerConfigNotParsable)
+	})
+}
+
+func TestLogTriggerConfig_Matches(t *testing.T) {
+	cfg := testLogTriggerConfig()
+
+	assert.True(t, cfg.matches(testTriggerLog(1, 0)))
+	assert.True(t, cfg.matches(testTriggerLog(1, 0, testTriggerTopic0, testTriggerTopic1, common.HexToHash("0xcc"))))
+	assert.False(t, cfg.matches(testTriggerLog(1, 0, testTriggerTopic0, common.HexToHash("0xcc"))))
+	assert.False(t, cfg.matches(testTriggerLog(1, 0, testTriggerTopic0)))
+	assert.False(t, cfg.matches(testTriggerLog(1, 0, common.HexToHash("0xcc"), testTriggerTopic1)))
+
+	otherAddress := testTriggerLog(1, 0)
+	otherAddress.Address = common.HexToAddress("0x2")
+	assert.False(t, cfg.matches(otherAddress))
+
+	cfg.FilterSelector = 0
+	assert.True(t, cfg.matches(testTriggerLog(1, 0, testTriggerTopic0, common.HexToHash("0xcc"))))
+}
+
+func TestLogTriggerPerformData(t *testing.T) {
+	id := idOfLog(testTriggerLog(10, 3))
+	packed, err := packLogTriggerPerformData(id, []byte("perform"))
+	require.NoError(t, err)
+
+	unpackedID, performData, err := unpackLogTriggerPerformData(packed)
+	require.NoError(t, err)
+	assert.Equal(t, id, unpackedID)
+	assert.Equal(t, []byte("perform"), performData)
+
+	_, _, err = unpackLogTriggerPerformData([]byte("perform"))
+	assert.Error(t, err)
+}
+
+// transmitTx returns a transmit transaction of a report performing each upkeep
+// with the given perform data.
+func transmitTx(t *testing.T, r *EvmRegistry, performData map[int64][]byte) *gethtypes.Transaction {
+	results := make([]types.UpkeepResult, 0, len(performData))
+	for id, data := range performData {
+		results = append(results, types.UpkeepResult{
+			Key:         chain.NewUpkeepKey(big.NewInt(450), big.NewInt(id)),
+			FastGasWei:  big.NewInt(1),
+			LinkNative:  big.NewInt(1),
+			PerformData: data,
+		})
+	}
+	report, err := chain.NewEVMReportEncoder().EncodeReport(results)
+	require.NoError(t, err)
+	data, err := r.abi.Pack("transmit", [3][32]byte{}, report, [][32]byte{}, [][32]byte{}, [32]byte{})
+	require.NoError(t, err)
+	return gethtypes.NewTx(&gethtypes.LegacyTx{Data: data})
+}
+
+func TestPollLogTriggers(t *testing.T) {
+	r := setupEVMRegistry(t)
+	mp := lpmocks.NewLogPoller(t)
+	r.poller = mp
+	r.addr = common.HexToAddress("0x3")
+	client := r.client.(*evmClientMocks.Client)
+	id := big.NewInt(42)
+	r.logTriggers = map[string]*logTriggerUpkeep{
+		id.String(): {id: id, config: testLogTriggerConfig()},
+	}
+
+	// the upkeep was performed for its first log
+	performData, err := packLogTriggerPerformData(idOfLog(testTriggerLog(400, 1)), []byte("perform"))
+	require.NoError(t, err)
+	performedLog := logpoller.Log{BlockNumber: 452, LogIndex: 1, TxHash: common.HexToHash("0x4")}
+	r.registry.(*mocks.Registry).On("ParseLog", performedLog.ToGethLog()).Return(&keeper_registry_wrapper2_0.KeeperRegistryUpkeepPerformed{Id: id, CheckBlockNumber: 450}, nil)
+	tx := transmitTx(t, r, map[int64][]byte{42: performData, 7: []byte("conditional")})
+	client.On("BatchCallContext", mock.Anything, mock.MatchedBy(func(b []rpc.BatchElem) bool {
+		return len(b) == 1 && b[0].Method == "eth_getTransactionByHash" && b[0].Args[0] == performedLog.TxHash
+	})).Return(nil).Run(func(args mock.Arguments) {
+		*args.Get(1).([]rpc.BatchElem)[0].Result.(*gethtypes.Transaction) = *tx
+	}).Once()
+
+	mp.On("LatestBlock", mock.Anything).Return(int64(500), nil)
+	mp.On("LogsWithSigs", int64(250), int64(500), []common.Hash{keeper_registry_wrapper2_0.KeeperRegistryUpkeepPerformed{}.Topic()}, r.addr, mock.Anything).Return([]logpoller.Log{performedLog}, nil)
+	mp.On("Logs", int64(250), int64(500), common.Hash(testTriggerTopic0), testTriggerAddress, mock.Anything).Return([]logpoller.Log{
+		testTriggerLog(400, 1),
+		testTriggerLog(401, 0, testTriggerTopic0, common.HexToHash("0xcc")),
+	}, nil).Once()
+
+	require.NoError(t, r.pollLogTriggers())
+	assert.False(t, r.logTriggers[id.String()].hasLogs, "the only matching log was performed")
+	require.Contains(t, r.performTxs, performedLog.TxHash)
+	assert.Equal(t, map[string]logID{"42": idOfLog(testTriggerLog(400, 1))}, r.performTxs[performedLog.TxHash].logs)
+
+	// the perform transaction is only fetched once
+	mp.On("Logs", int64(250), int64(500), common.Hash(testTriggerTopic0), testTriggerAddress, mock.Anything).Return([]logpoller.Log{
+		testTriggerLog(400, 1),
+		testTriggerLog(402, 0),
+	}, nil).Once()
+	require.NoError(t, r.pollLogTriggers())
+	assert.True(t, r.logTriggers[id.String()].hasLogs)
+
+	ids, err := r.GetActiveUpkeepIDs(testutils.Context(t))
+	require.NoError(t, err)
+	assert.Empty(t, ids, "the upkeep is not active")
+	r.active[id.String()] = activeUpkeep{ID: id, LogTrigger: &LogTriggerConfig{}}
+	ids, err = r.GetActiveUpkeepIDs(testutils.Context(t))
+	require.NoError(t, err)
+	assert.Equal(t, []types.UpkeepIdentifier{types.UpkeepIdentifier("42")}, ids)
+}
+
+func TestCheckLogTriggerUpkeeps(t *testing.T) {
+	r := setupEVMRegistry(t)
+	mp := lpmocks.NewLogPoller(t)
+	r.poller = mp
+	client := r.client.(*evmClientMocks.Client)
+
+	target := common.HexToAddress("0x5")
+	eligibleID, notNeededID, pausedID, emptyID := big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4)
+	r.logTriggers = make(map[string]*logTriggerUpkeep)
+	for _, id := range []*big.Int{eligibleID, notNeededID, pausedID, emptyID} {
+		config := testLogTriggerConfig()
+		if id == emptyID {
+			config.Topic1 = common.HexToHash("0xcc")
+		}
+		r.active[id.String()] = activeUpkeep{ID: id, Target: target, CheckData: []byte{1}, LogTrigger: &config}
+		r.logTriggers[id.String()] = &logTriggerUpkeep{id: id, config: config}
+	}
+
+	mp.On("LatestBlock", mock.Anything).Return(int64(100), nil)
+	mp.On("LogsWithSigs", int64(0), int64(100), []common.Hash{keeper_registry_wrapper2_0.KeeperRegistryUpkeepPerformed{}.Topic()}, r.addr, mock.Anything).Return(nil, nil)
+	mp.On("Logs", int64(0), int64(100), common.Hash(testTriggerTopic0), testTriggerAddress, mock.Anything).Return([]logpoller.Log{
+		testTriggerLog(10, 1),
+		testTriggerLog(10, 0),
+	}, nil)
+
+	registryResult := func(reason uint8) string {
+		b, err := r.abi.Methods["checkUpkeep"].Outputs.Pack(false, []byte{}, reason, big.NewInt(1), big.NewInt(2), big.NewInt(3))
+		require.NoError(t, err)
+		return hexutil.Encode(b)
+	}
+	checkLogResult := func(needed bool, performData []byte) string {
+		b, err := logTriggerABI.Methods["checkLog"].Outputs.Pack(needed, performData)
+		require.NoError(t, err)
+		return hexutil.Encode(b)
+	}
+
+	client.On("BatchCallContext", mock.Anything, mock.MatchedBy(func(b []rpc.BatchElem) bool { return len(b) == 9 })).Return(nil).Run(func(args mock.Arguments) {
+		b := args.Get(1).([]rpc.BatchElem)
+		// the first log reverts, the second one is needed
+		*b[0].Result.(*string) = registryResult(UPKEEP_FAILURE_REASON_TARGET_CHECK_REVERTED)
+		b[1].Error = errors.New("execution reverted")
+		*b[2].Result.(*string) = checkLogResult(true, []byte("perform"))
+		*b[3].Result.(*string) = registryResult(UPKEEP_FAILURE_REASON_UPKEEP_NOT_NEEDED)
+		*b[4].Result.(*string) = checkLogResult(false, nil)
+		*b[5].Result.(*string) = checkLogResult(false, nil)
+		*b[6].Result.(*string) = registryResult(UPKEEP_FAILURE_REASON_UPKEEP_PAUSED)
+		*b[7].Result.(*string) = checkLogResult(true, []byte("perform"))
+		*b[8].Result.(*string) = checkLogResult(true, []byte("perform"))
+	})
+	blockHash := common.HexToHash("0x1234")
+	mp.On("GetBlocksRange", mock.Anything, []uint64{100, 100, 100}, mock.Anything).Return([]logpoller.LogPollerBlock{{BlockNumber: 100, BlockHash: blockHash}}, nil)
+
+	keys := []types.UpkeepKey{
+		chain.NewUpkeepKey(big.NewInt(100), eligibleID),
+		chain.NewUpkeepKey(big.NewInt(100), notNeededID),
+		chain.NewUpkeepKey(big.NewInt(100), pausedID),
+		chain.NewUpkeepKey(big.NewInt(100), emptyID),
+	}
+	results, err := r.checkAllUpkeeps(testutils.Context(t), keys)
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+
+	assert.Equal(t, types.Eligible, results[0].State)
+	logID, performData, err := unpackLogTriggerPerformData(results[0].PerformData)
+	require.NoError(t, err)
+	assert.Equal(t, idOfLog(testTriggerLog(10, 1)), logID, "logs are checked in chain order")
+	assert.Equal(t, []byte("perform"), performData)
+	assert.Equal(t, [32]byte(blockHash), results[0].CheckBlockHash)
+	assert.Equal(t, uint32(100), results[0].CheckBlockNumber)
+	assert.Equal(t, big.NewInt(2), results[0].FastGasWei)
+	assert.Equal(t, big.NewInt(3), results[0].LinkNative)
+	assert.Equal(t, types.NotEligible, results[1].State)
+	assert.Equal(t, uint8(UPKEEP_FAILURE_REASON_UPKEEP_NOT_NEEDED), results[1].FailureReason)
+	assert.Equal(t, types.NotEligible, results[2].State)
+	assert.Equal(t, uint8(UPKEEP_FAILURE_REASON_UPKEEP_PAUSED), results[2].FailureReason)
+	assert.Equal(t, types.NotEligible, results[3].State)
+
+	t.Run("block after the latest polled block", func(t *testing.T) {
+		_, err := r.checkAllUpkeeps(testutils.Context(t), []types.UpkeepKey{chain.NewUpkeepKey(big.NewInt(101), eligibleID)})
+		assert.ErrorIs(t, err, ErrHeadNotAvailable)
+	})
+}
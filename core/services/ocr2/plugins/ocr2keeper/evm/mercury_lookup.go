@@ -13,6 +13,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/avast/retry-go/v4"
@@ -50,11 +51,35 @@ const (
 	TotalAttempt = 3
 )
 
+// mercuryFeedRequest is the request of the report of one feed, shared by all
+// upkeeps which need it.
+type mercuryFeedRequest struct {
+	feedLabel  string
+	feed       string
+	queryLabel string
+	query      string
+}
+
+func (f mercuryFeedRequest) key() string {
+	return strings.Join([]string{f.feedLabel, f.feed, f.queryLabel, f.query}, "|")
+}
+
+// pendingMercuryLookup is a MercuryLookup of an upkeep waiting for its feeds.
+type pendingMercuryLookup struct {
+	index      int
+	upkeepId   *big.Int
+	block      *big.Int
+	lookup     *MercuryLookup
+	upkeepInfo keeper_registry_wrapper2_0.UpkeepInfo
+	opts       *bind.CallOpts
+	requests   []mercuryFeedRequest
+}
+
 // mercuryLookup looks through check upkeep results looking for any that need off chain lookup
 func (r *EvmRegistry) mercuryLookup(ctx context.Context, upkeepResults []types.UpkeepResult) ([]types.UpkeepResult, error) {
 	// return error only if there are errors which stops the process
 	// don't surface Mercury API errors to plugin bc MercuryLookup process should be self-contained
-	// TODO (AUTO-2862): parallelize the mercury lookup work for all upkeeps
+	var lookups []pendingMercuryLookup
 	for i := range upkeepResults {
 		// if its another reason continue/skip
 		if upkeepResults[i].FailureReason != UPKEEP_FAILURE_REASON_TARGET_CHECK_REVERTED {
@@ -81,6 +106,12 @@ func (r *EvmRegistry) mercuryLookup(ctx context.Context, upkeepResults []types.U
 			continue
 		}
 
+		// checking if this upkeep made too many lookups recently
+		if !r.allowMercuryLookup(upkeepId) {
+			r.lggr.Infof("[MercuryLookup] upkeep %s block %s skipped bc of rate limit", upkeepId.String(), block.String())
+			continue
+		}
+
 		opts, err := r.buildCallOpts(ctx, block)
 		if err != nil {
 			r.lggr.Errorf("[MercuryLookup] upkeep %s block %s buildCallOpts: %v", upkeepId.String(), block.String(), err)
@@ -93,14 +124,56 @@ func (r *EvmRegistry) mercuryLookup(ctx context.Context, upkeepResults []types.U
 			return nil, err
 		}
 
-		// do the mercury lookup request
-		values, err := r.doMercuryRequest(ctx, mercuryLookup, upkeepId)
-		if err != nil {
-			r.lggr.Errorf("[MercuryLookup] upkeep %s block %s doMercuryRequest: %v", upkeepId.String(), block.String(), err)
+		requests := make([]mercuryFeedRequest, len(mercuryLookup.feeds))
+		for j, feed := range mercuryLookup.feeds {
+			requests[j] = mercuryFeedRequest{
+				feedLabel:  mercuryLookup.feedLabel,
+				feed:       feed,
+				queryLabel: mercuryLookup.queryLabel,
+				query:      mercuryLookup.query.String(),
+			}
+		}
+		lookups = append(lookups, pendingMercuryLookup{
+			index:      i,
+			upkeepId:   upkeepId,
+			block:      block,
+			lookup:     mercuryLookup,
+			upkeepInfo: upkeepInfo,
+			opts:       opts,
+			requests:   requests,
+		})
+	}
+	if len(lookups) == 0 {
+		return upkeepResults, nil
+	}
+
+	// request each feed once for all the upkeeps which need it
+	var requests []mercuryFeedRequest
+	for _, l := range lookups {
+		requests = append(requests, l.requests...)
+	}
+	responses := r.doMercuryRequests(ctx, requests)
+
+	for _, l := range lookups {
+		i, upkeepId, block := l.index, l.upkeepId, l.block
+
+		values := make([][]byte, len(l.requests))
+		var reqErr error
+		for j, req := range l.requests {
+			resp := responses[req.key()]
+			if resp.Error != nil {
+				reqErr = errors.Join(reqErr, fmt.Errorf("feed[%s]: %w", req.feed, resp.Error))
+			}
+			values[j] = resp.Bytes
+		}
+		if reqErr != nil {
+			r.lggr.Errorf("[MercuryLookup] upkeep %s block %s doMercuryRequest: %v", upkeepId.String(), block.String(), reqErr)
+			// it's very likely the feed IDs are incorrect or block number is too old or too new, put into cooldown
+			r.setCachesOnAPIErr(upkeepId)
 			continue
 		}
 
-		needed, performData, err := r.mercuryLookupCallback(ctx, mercuryLookup, values, upkeepInfo, opts)
+		needed, performData, err := r.mercuryLookupCallback(ctx, l.lookup, values, l.upkeepInfo, l.opts)
 		if err != nil {
 			r.lggr.Errorf("[MercuryLookup] upkeep %s block %s mercuryLookupCallback err: %v", upkeepId.String(), block.String(), err)
 			continue
@@ -183,79 +256,120 @@ func (r *EvmRegistry) mercuryLookupCallback(ctx context.Context, mercuryLookup *
 	return r.packer.UnpackMercuryLookupResult(callbackResp)
 }
 
-func (r *EvmRegistry) doMercuryRequest(ctx context.Context, ml *MercuryLookup, upkeepId *big.Int) ([][]byte, error) {
-	ch := make(chan MercuryBytes, len(ml.feeds))
-	for i := range ml.feeds {
-		go r.singleFeedRequest(ctx, ch, upkeepId, i, ml)
+// doMercuryRequests requests the distinct feeds of requests concurrently, and
+// returns the responses by request key.
+func (r *EvmRegistry) doMercuryRequests(ctx context.Context, requests []mercuryFeedRequest) map[string]MercuryBytes {
+	distinct := make(map[string]mercuryFeedRequest)
+	for _, req := range requests {
+		distinct[req.key()] = req
+	}
+
+	type response struct {
+		key string
+		MercuryBytes
 	}
-	var reqErr error
-	results := make([][]byte, len(ml.feeds))
-	for i := 0; i < len(results); i++ {
-		m := <-ch
-		if m.Error != nil {
-			reqErr = errors.Join(reqErr, fmt.Errorf("feed[%s]: %w", ml.feeds[i], m.Error))
+	ch := make(chan response, len(distinct))
+	for key, req := range distinct {
+		go func(key string, req mercuryFeedRequest) {
+			blob, err := r.fetchFeed(ctx, req)
+			ch <- response{key: key, MercuryBytes: MercuryBytes{Bytes: blob, Error: err}}
+		}(key, req)
+	}
+
+	responses := make(map[string]MercuryBytes, len(distinct))
+	for range distinct {
+		resp := <-ch
+		responses[resp.key] = resp.MercuryBytes
+	}
+	return responses
+}
+
+// fetchFeed returns the report of a feed from the feed cache, or requests it.
+// Concurrent requests of the same report share a single HTTP request, which is
+// not bound to the context of any caller: a caller whose context is done stops
+// waiting, without failing the others.
+func (r *EvmRegistry) fetchFeed(ctx context.Context, req mercuryFeedRequest) ([]byte, error) {
+	key := req.key()
+	if blob, ok := r.mercury.feedCache.Get(key); ok {
+		r.lggr.Debugf("[MercuryLookup] block %s cache hit for feed %s", req.query, req.feed)
+		return blob.([]byte), nil
+	}
+	ch := r.mercury.inflight.DoChan(key, func() (interface{}, error) {
+		reqCtx, cancel := context.WithTimeout(context.Background(), MercuryFeedRequestTimeout)
+		defer cancel()
+		blob, err := r.singleFeedRequest(reqCtx, req)
+		if err != nil {
+			return nil, err
+		}
+		r.mercury.feedCache.Set(key, blob, cache.DefaultExpiration)
+		return blob, nil
+	})
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
 		}
-		results[m.Index] = m.Bytes
+		return res.Val.([]byte), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	}
-	return results, reqErr
 }
 
-func (r *EvmRegistry) singleFeedRequest(ctx context.Context, ch chan<- MercuryBytes, upkeepId *big.Int, index int, ml *MercuryLookup) {
+func (r *EvmRegistry) singleFeedRequest(ctx context.Context, feedReq mercuryFeedRequest) ([]byte, error) {
 	q := url.Values{
-		ml.feedLabel:  {ml.feeds[index]},
-		ml.queryLabel: {ml.query.String()},
+		feedReq.feedLabel:  {feedReq.feed},
+		feedReq.queryLabel: {feedReq.query},
 	}
 	reqUrl := fmt.Sprintf("%s/client?%s", r.mercury.cred.URL, q.Encode())
 	r.lggr.Debugf("MercuryLookup request URL: %s", reqUrl)
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqUrl, nil)
-	if err != nil {
-		ch <- MercuryBytes{Index: index, Error: err}
-		return
-	}
-
-	ts := time.Now().UTC().UnixMilli()
-	signature := r.generateHMAC(http.MethodGet, "/client?"+q.Encode(), []byte{}, r.mercury.cred.Username, r.mercury.cred.Password, ts)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", r.mercury.cred.Username)
-	req.Header.Set("X-Authorization-Timestamp", strconv.FormatInt(ts, 10))
-	req.Header.Set("X-Authorization-Signature-SHA256", signature)
-
+	var blobBytes []byte
 	retryErr := retry.Do(
 		func() error {
+			// sign each attempt, so that the timestamp of the signature is fresh
+			req, err1 := http.NewRequestWithContext(ctx, http.MethodGet, reqUrl, nil)
+			if err1 != nil {
+				return retry.Unrecoverable(err1)
+			}
+			ts := time.Now().UTC().UnixMilli()
+			signature := r.generateHMAC(http.MethodGet, "/client?"+q.Encode(), []byte{}, r.mercury.cred.Username, r.mercury.cred.Password, ts)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", r.mercury.cred.Username)
+			req.Header.Set("X-Authorization-Timestamp", strconv.FormatInt(ts, 10))
+			req.Header.Set("X-Authorization-Signature-SHA256", signature)
+
 			resp, err1 := r.hc.Do(req)
 			if err1 != nil {
-				r.lggr.Errorf("[MercuryLookup] upkeep %s block %s GET request fails for feed %s: %v", upkeepId.String(), ml.query.String(), ml.feeds[index], err1)
+				r.lggr.Errorf("[MercuryLookup] block %s GET request fails for feed %s: %v", feedReq.query, feedReq.feed, err1)
 				return err1
 			}
 			defer resp.Body.Close()
 			body, err1 := io.ReadAll(resp.Body)
 			if err1 != nil {
-				r.lggr.Errorf("[MercuryLookup] upkeep %s block %s fails to read response body for feed %s: %v", upkeepId.String(), ml.query.String(), ml.feeds[index], err1)
+				r.lggr.Errorf("[MercuryLookup] block %s fails to read response body for feed %s: %v", feedReq.query, feedReq.feed, err1)
 				return err1
 			}
 
 			if resp.StatusCode == http.StatusNotFound {
 				// there are 2 possible causes for 404: incorrect URL and querying a block where report has not been generated
-				r.lggr.Errorf("[MercuryLookup] upkeep %s block %s received status code %d for feed %s", upkeepId.String(), ml.query.String(), resp.StatusCode, ml.feeds[index])
+				r.lggr.Errorf("[MercuryLookup] block %s received status code %d for feed %s", feedReq.query, resp.StatusCode, feedReq.feed)
 				// return 404 for retry
 				return fmt.Errorf("%d", http.StatusNotFound)
 			} else if resp.StatusCode != http.StatusOK {
-				return fmt.Errorf("[MercuryLookup] upkeep %s block %s received status code %d for feed %s", upkeepId.String(), ml.query.String(), resp.StatusCode, ml.feeds[index])
+				return fmt.Errorf("[MercuryLookup] block %s received status code %d for feed %s", feedReq.query, resp.StatusCode, feedReq.feed)
 			}
 
 			var m MercuryResponse
 			err1 = json.Unmarshal(body, &m)
 			if err1 != nil {
-				r.lggr.Errorf("[MercuryLookup] upkeep %s block %s failed to unmarshal body to MercuryResponse for feed %s: %v", upkeepId.String(), ml.query.String(), ml.feeds[index], err1)
+				r.lggr.Errorf("[MercuryLookup] block %s failed to unmarshal body to MercuryResponse for feed %s: %v", feedReq.query, feedReq.feed, err1)
 				return err1
 			}
-			blobBytes, err1 := hexutil.Decode(m.ChainlinkBlob)
+			blobBytes, err1 = hexutil.Decode(m.ChainlinkBlob)
 			if err1 != nil {
-				r.lggr.Debugf("[MercuryLookup] upkeep %s block %s failed to decode chainlinkBlob %s for feed %s: %v", upkeepId.String(), ml.query.String(), m.ChainlinkBlob, ml.feeds[index], err1)
+				r.lggr.Debugf("[MercuryLookup] block %s failed to decode chainlinkBlob %s for feed %s: %v", feedReq.query, m.ChainlinkBlob, feedReq.feed, err1)
 				return err1
 			}
-			ch <- MercuryBytes{Index: index, Bytes: blobBytes}
 			return nil
 		},
 		// only retry when the error is 404 Not Found
@@ -264,13 +378,12 @@ func (r *EvmRegistry) singleFeedRequest(ctx context.Context, ch chan<- MercuryBy
 		}),
 		retry.Context(ctx),
 		retry.Delay(RetryDelay),
-		retry.Attempts(TotalAttempt))
-
-	// if all retries fail, it's very likely the feed IDs are incorrect or block number is too old or too new, put into cooldownf
+		retry.Attempts(TotalAttempt),
+		retry.LastErrorOnly(true))
 	if retryErr != nil {
-		ch <- MercuryBytes{Index: index, Error: retryErr}
-		r.setCachesOnAPIErr(upkeepId)
+		return nil, retryErr
 	}
+	return blobBytes, nil
 }
 
 func (r *EvmRegistry) generateHMAC(method string, path string, body []byte, clientId string, secret string, ts int64) string {
@@ -288,6 +401,22 @@ func (r *EvmRegistry) generateHMAC(method string, path string, body []byte, clie
 	return userHmac
 }
 
+// allowMercuryLookup counts a lookup of the upkeep, and returns false if the
+// upkeep already made its rate limit of lookups in the current window.
+func (r *EvmRegistry) allowMercuryLookup(upkeepId *big.Int) bool {
+	cacheKey := upkeepId.String()
+	if err := r.mercury.rateLimitCache.Add(cacheKey, 1, cache.DefaultExpiration); err == nil {
+		return true
+	}
+	count, err := r.mercury.rateLimitCache.IncrementInt(cacheKey, 1)
+	if err != nil {
+		// the window expired in between
+		r.mercury.rateLimitCache.Set(cacheKey, 1, cache.DefaultExpiration)
+		return true
+	}
+	return count <= r.mercury.rateLimit
+}
+
 // setCachesOnAPIErr when an off chain look up request fails or gets a 4xx/5xx response code we increment error count and put the upkeep in cooldown state
 func (r *EvmRegistry) setCachesOnAPIErr(upkeepId *big.Int) {
 	r.lggr.Infof("[MercuryLookup] adding %s to API error cache", upkeepId.String())
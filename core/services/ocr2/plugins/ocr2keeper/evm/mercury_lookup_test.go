@@ -43,6 +43,7 @@ func setupEVMRegistry(t *testing.T) *EvmRegistry {
 	mercuryCompatibleABI, err := abi.JSON(strings.NewReader(mercury_lookup_compatible_interface.MercuryLookupCompatibleInterfaceABI))
 	require.Nil(t, err, "need mercury abi")
 	upkeepInfoCache, cooldownCache, apiErrCache := setupCaches(DefaultUpkeepExpiration, DefaultCooldownExpiration, DefaultApiErrExpiration, CleanupInterval)
+	feedCache, rateLimitCache := setupRequestCaches(DefaultFeedCacheExpiration, DefaultRateLimitWindow)
 	var headTracker httypes.HeadTracker
 	var headBroadcaster httypes.HeadBroadcaster
 	var logPoller logpoller.LogPoller
@@ -73,10 +74,13 @@ func setupEVMRegistry(t *testing.T) *EvmRegistry {
 				Username: "FakeClientID",
 				Password: "FakeClientKey",
 			},
-			abi:           mercuryCompatibleABI,
-			upkeepCache:   upkeepInfoCache,
-			cooldownCache: cooldownCache,
-			apiErrCache:   apiErrCache,
+			abi:            mercuryCompatibleABI,
+			upkeepCache:    upkeepInfoCache,
+			cooldownCache:  cooldownCache,
+			apiErrCache:    apiErrCache,
+			feedCache:      feedCache,
+			rateLimitCache: rateLimitCache,
+			rateLimit:      DefaultMercuryUpkeepRateLimit,
 		},
 		hc: mockHttpClient,
 	}
@@ -557,3 +561,163 @@ func TestEvmRegistry_setCachesOnAPIErr(t *testing.T) {
 		})
 	}
 }
+
+func TestEvmRegistry_mercuryLookup_SharedFeeds(t *testing.T) {
+	r := setupEVMRegistry(t)
+	ethBlob, err := os.ReadFile("./testdata/eth-usd.json")
+	require.NoError(t, err)
+
+	revertPerformData := r.buildRevertBytesHelper()
+	target := common.HexToAddress("0x79D8aDb571212b922089A48956c54A453D889dBe")
+	var input []types.UpkeepResult
+	for _, id := range []string{"1", "2", "3"} {
+		key := chain.UpkeepKey("8586948|" + id)
+		input = append(input, types.UpkeepResult{
+			Key:           key,
+			State:         types.NotEligible,
+			FailureReason: UPKEEP_FAILURE_REASON_TARGET_CHECK_REVERTED,
+			PerformData:   revertPerformData,
+		})
+		r.mercury.upkeepCache.Set(id, keeper_registry_wrapper2_0.UpkeepInfo{Target: target}, cache.DefaultExpiration)
+	}
+
+	callbackResp, err := r.mercury.abi.Methods["mercuryCallback"].Outputs.Pack(true, []byte{1})
+	require.NoError(t, err)
+	client := evmClientMocks.NewClient(t)
+	client.On("CallContract", mock.Anything, mock.Anything, mock.Anything).Return(callbackResp, nil)
+	r.client = client
+
+	// the 2 feeds are requested once for all 3 upkeeps
+	mockHttpClient := mocks.NewHttpClient(t)
+	mockHttpClient.On("Do", mock.Anything).Return(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(ethBlob))}, nil
+	}).Twice()
+	r.hc = mockHttpClient
+
+	got, err := r.mercuryLookup(context.Background(), input)
+	require.NoError(t, err)
+	for _, res := range got {
+		assert.Equal(t, types.Eligible, res.State)
+		assert.Equal(t, []byte{1}, res.PerformData)
+	}
+
+	// the feeds are cached for the next checks
+	got, err = r.mercuryLookup(context.Background(), []types.UpkeepResult{{
+		Key:           chain.UpkeepKey("8586948|1"),
+		FailureReason: UPKEEP_FAILURE_REASON_TARGET_CHECK_REVERTED,
+		PerformData:   revertPerformData,
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, types.Eligible, got[0].State)
+	mockHttpClient.AssertNumberOfCalls(t, "Do", 2)
+}
+
+func TestEvmRegistry_mercuryLookup_FeedError(t *testing.T) {
+	r := setupEVMRegistry(t)
+	r.mercury.upkeepCache.Set("1", keeper_registry_wrapper2_0.UpkeepInfo{Target: common.HexToAddress("0x1")}, cache.DefaultExpiration)
+	mockHttpClient := mocks.NewHttpClient(t)
+	mockHttpClient.On("Do", mock.Anything).Return(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusInternalServerError, Body: io.NopCloser(bytes.NewReader(nil))}, nil
+	})
+	r.hc = mockHttpClient
+
+	input := []types.UpkeepResult{{
+		Key:           chain.UpkeepKey("8586948|1"),
+		FailureReason: UPKEEP_FAILURE_REASON_TARGET_CHECK_REVERTED,
+		PerformData:   r.buildRevertBytesHelper(),
+	}}
+	got, err := r.mercuryLookup(context.Background(), input)
+	require.NoError(t, err)
+	assert.Equal(t, types.NotEligible, got[0].State)
+
+	// failures are not cached, and put the upkeep in cooldown
+	assert.Equal(t, 0, r.mercury.feedCache.ItemCount())
+	_, onIce := r.mercury.cooldownCache.Get("1")
+	assert.True(t, onIce)
+}
+
+func TestEvmRegistry_fetchFeed_Coalesced(t *testing.T) {
+	r := setupEVMRegistry(t)
+	ethBlob, err := os.ReadFile("./testdata/eth-usd.json")
+	require.NoError(t, err)
+
+	release := make(chan struct{})
+	mockHttpClient := mocks.NewHttpClient(t)
+	mockHttpClient.On("Do", mock.Anything).Return(func(*http.Request) (*http.Response, error) {
+		<-release
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(ethBlob))}, nil
+	}).Once()
+	r.hc = mockHttpClient
+
+	req := mercuryFeedRequest{feedLabel: "feedIDStr", feed: "ETH-USD", queryLabel: "blockNumber", query: "100"}
+	results := make(chan []byte, 3)
+	for i := 0; i < 3; i++ {
+		go func() {
+			blob, err := r.fetchFeed(context.Background(), req)
+			assert.NoError(t, err)
+			results <- blob
+		}()
+	}
+	// let the requests pile up behind the first one
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	for i := 0; i < 3; i++ {
+		assert.NotEmpty(t, <-results)
+	}
+}
+
+func TestEvmRegistry_allowMercuryLookup(t *testing.T) {
+	r := setupEVMRegistry(t)
+	for i := 0; i < DefaultMercuryUpkeepRateLimit; i++ {
+		require.True(t, r.allowMercuryLookup(big.NewInt(1)))
+	}
+	assert.False(t, r.allowMercuryLookup(big.NewInt(1)))
+	assert.True(t, r.allowMercuryLookup(big.NewInt(2)), "limits are per upkeep")
+}
+
+func TestEvmRegistry_fetchFeed_CancelledCaller(t *testing.T) {
+	r := setupEVMRegistry(t)
+	ethBlob, err := os.ReadFile("./testdata/eth-usd.json")
+	require.NoError(t, err)
+
+	started, release := make(chan struct{}), make(chan struct{})
+	mockHttpClient := mocks.NewHttpClient(t)
+	mockHttpClient.On("Do", mock.Anything).Return(func(req *http.Request) (*http.Response, error) {
+		close(started)
+		<-release
+		if err := req.Context().Err(); err != nil {
+			return nil, err
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(ethBlob))}, nil
+	}).Once()
+	r.hc = mockHttpClient
+
+	req := mercuryFeedRequest{feedLabel: "feedIDStr", feed: "ETH-USD", queryLabel: "blockNumber", query: "100"}
+	// the first caller gives up while the request is in flight
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := r.fetchFeed(ctx, req)
+		first <- err
+	}()
+	<-started
+	second := make(chan []byte, 1)
+	go func() {
+		blob, err := r.fetchFeed(context.Background(), req)
+		assert.NoError(t, err)
+		second <- blob
+	}()
+	cancel()
+	assert.ErrorIs(t, <-first, context.Canceled)
+
+	close(release)
+	assert.NotEmpty(t, <-second, "the other callers still get the report")
+}
+
+func TestEvmRegistry_allowMercuryLookup_Configured(t *testing.T) {
+	r := setupEVMRegistry(t)
+	r.mercury.rateLimit = 2
+	require.True(t, r.allowMercuryLookup(big.NewInt(1)))
+	require.True(t, r.allowMercuryLookup(big.NewInt(1)))
+	assert.False(t, r.allowMercuryLookup(big.NewInt(1)))
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/patrickmn/go-cache"
 	"github.com/smartcontractkit/ocr2keepers/pkg/types"
 	"go.uber.org/multierr"
+	"golang.org/x/sync/singleflight"
 
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/client"
@@ -45,6 +46,17 @@ const (
 	DefaultApiErrExpiration = 10 * time.Minute
 	// CleanupInterval decides when the expired items in cache will be deleted.
 	CleanupInterval = 15 * time.Minute
+	// DefaultFeedCacheExpiration decides how long a Mercury report is cached for, and shared by all the upkeeps
+	// looking up the same feed at the same block or timestamp.
+	DefaultFeedCacheExpiration = 30 * time.Second
+	// FeedCacheCleanupInterval decides when the expired Mercury reports in cache will be deleted.
+	FeedCacheCleanupInterval = time.Minute
+	// DefaultRateLimitWindow is the default window in which the Mercury lookups of an upkeep are rate limited.
+	DefaultRateLimitWindow = time.Minute
+	// DefaultMercuryUpkeepRateLimit is the default number of Mercury lookups an upkeep can make in the rate limit window.
+	DefaultMercuryUpkeepRateLimit = 30
+	// MercuryFeedRequestTimeout bounds a Mercury report request, which is shared by all the checks waiting for the report.
+	MercuryFeedRequestTimeout = 10 * time.Second
 )
 
 var (
@@ -80,7 +92,14 @@ type LatestBlockGetter interface {
 	LatestBlock() int64
 }
 
-func NewEVMRegistryServiceV2_0(addr common.Address, client evm.Chain, mc *models.MercuryCredentials, lggr logger.Logger) (*EvmRegistry, error) {
+// MercuryRateLimit limits the Mercury lookups of each upkeep to Limit in every
+// Window. Zero values are replaced by the defaults.
+type MercuryRateLimit struct {
+	Limit  int
+	Window time.Duration
+}
+
+func NewEVMRegistryServiceV2_0(addr common.Address, client evm.Chain, mc *models.MercuryCredentials, rateLimit MercuryRateLimit, lggr logger.Logger) (*EvmRegistry, error) {
 	mercuryLookupCompatibleABI, err := abi.JSON(strings.NewReader(mercury_lookup_compatible_interface.MercuryLookupCompatibleInterfaceABI))
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrABINotParsable, err)
@@ -96,6 +115,13 @@ func NewEVMRegistryServiceV2_0(addr common.Address, client evm.Chain, mc *models
 	}
 
 	upkeepInfoCache, cooldownCache, apiErrCache := setupCaches(DefaultUpkeepExpiration, DefaultCooldownExpiration, DefaultApiErrExpiration, CleanupInterval)
+	if rateLimit.Limit == 0 {
+		rateLimit.Limit = DefaultMercuryUpkeepRateLimit
+	}
+	if rateLimit.Window == 0 {
+		rateLimit.Window = DefaultRateLimitWindow
+	}
+	feedCache, rateLimitCache := setupRequestCaches(DefaultFeedCacheExpiration, rateLimit.Window)
 
 	r := &EvmRegistry{
 		HeadProvider: HeadProvider{
@@ -103,23 +129,28 @@ func NewEVMRegistryServiceV2_0(addr common.Address, client evm.Chain, mc *models
 			hb:     client.HeadBroadcaster(),
 			chHead: make(chan types.BlockKey, 1),
 		},
-		lggr:     lggr,
-		poller:   client.LogPoller(),
-		addr:     addr,
-		client:   client.Client(),
-		txHashes: make(map[string]bool),
-		registry: registry,
-		abi:      keeperRegistryABI,
-		active:   make(map[string]activeUpkeep),
-		packer:   &evmRegistryPackerV2_0{abi: keeperRegistryABI},
-		headFunc: func(types.BlockKey) {},
-		chLog:    make(chan logpoller.Log, 1000),
+		lggr:        lggr,
+		poller:      client.LogPoller(),
+		addr:        addr,
+		client:      client.Client(),
+		txHashes:    make(map[string]bool),
+		registry:    registry,
+		abi:         keeperRegistryABI,
+		active:      make(map[string]activeUpkeep),
+		packer:      &evmRegistryPackerV2_0{abi: keeperRegistryABI},
+		headFunc:    func(types.BlockKey) {},
+		chLog:       make(chan logpoller.Log, 1000),
+		logTriggers: make(map[string]*logTriggerUpkeep),
+		performTxs:  make(map[common.Hash]performTx),
 		mercury: MercuryConfig{
-			cred:          mc,
-			abi:           mercuryLookupCompatibleABI,
-			upkeepCache:   upkeepInfoCache,
-			cooldownCache: cooldownCache,
-			apiErrCache:   apiErrCache,
+			cred:           mc,
+			abi:            mercuryLookupCompatibleABI,
+			upkeepCache:    upkeepInfoCache,
+			cooldownCache:  cooldownCache,
+			apiErrCache:    apiErrCache,
+			feedCache:      feedCache,
+			rateLimitCache: rateLimitCache,
+			rateLimit:      rateLimit.Limit,
 		},
 		hc: http.DefaultClient,
 	}
@@ -145,11 +176,21 @@ func setupCaches(defaultUpkeepExpiration, defaultCooldownExpiration, defaultApiE
 	return upkeepInfoCache, cooldownCache, apiErrCache
 }
 
+func setupRequestCaches(defaultFeedCacheExpiration, defaultRateLimitWindow time.Duration) (*cache.Cache, *cache.Cache) {
+	// cache for Mercury reports shared by all upkeeps, keyed by feed and block or timestamp
+	feedCache := cache.New(defaultFeedCacheExpiration, FeedCacheCleanupInterval)
+
+	// cache for counting the MercuryLookups of an upkeep in the current rate limit window
+	rateLimitCache := cache.New(defaultRateLimitWindow, CleanupInterval)
+	return feedCache, rateLimitCache
+}
+
 var upkeepStateEvents = []common.Hash{
-	keeper_registry_wrapper2_0.KeeperRegistryUpkeepRegistered{}.Topic(),  // adds new upkeep id to registry
-	keeper_registry_wrapper2_0.KeeperRegistryUpkeepReceived{}.Topic(),    // adds new upkeep id to registry via migration
-	keeper_registry_wrapper2_0.KeeperRegistryUpkeepGasLimitSet{}.Topic(), // unpauses an upkeep
-	keeper_registry_wrapper2_0.KeeperRegistryUpkeepUnpaused{}.Topic(),    // updates the gas limit for an upkeep
+	keeper_registry_wrapper2_0.KeeperRegistryUpkeepRegistered{}.Topic(),        // adds new upkeep id to registry
+	keeper_registry_wrapper2_0.KeeperRegistryUpkeepReceived{}.Topic(),          // adds new upkeep id to registry via migration
+	keeper_registry_wrapper2_0.KeeperRegistryUpkeepGasLimitSet{}.Topic(),       // unpauses an upkeep
+	keeper_registry_wrapper2_0.KeeperRegistryUpkeepUnpaused{}.Topic(),          // updates the gas limit for an upkeep
+	keeper_registry_wrapper2_0.KeeperRegistryUpkeepOffchainConfigSet{}.Topic(), // updates the log trigger config of an upkeep
 }
 
 var upkeepActiveEvents = []common.Hash{
@@ -166,16 +207,26 @@ type checkResult struct {
 
 type activeUpkeep struct {
 	ID              *big.Int
+	Target          common.Address
 	PerformGasLimit uint32
 	CheckData       []byte
+	// LogTrigger is set for log triggered upkeeps.
+	LogTrigger *LogTriggerConfig
 }
 
 type MercuryConfig struct {
-	cred          *models.MercuryCredentials
-	abi           abi.ABI
-	upkeepCache   *cache.Cache
-	cooldownCache *cache.Cache
-	apiErrCache   *cache.Cache
+	cred           *models.MercuryCredentials
+	abi            abi.ABI
+	upkeepCache    *cache.Cache
+	cooldownCache  *cache.Cache
+	apiErrCache    *cache.Cache
+	feedCache      *cache.Cache
+	rateLimitCache *cache.Cache
+	// rateLimit is the number of lookups an upkeep can make in the window of
+	// rateLimitCache
+	rateLimit int
+	// inflight coalesces concurrent requests of the same Mercury report
+	inflight singleflight.Group
 }
 
 type EvmRegistry struct {
@@ -201,19 +252,24 @@ type EvmRegistry struct {
 	runError      error
 	mercury       MercuryConfig
 	hc            HttpClient
+	logMu         sync.Mutex
+	logTriggers   map[string]*logTriggerUpkeep
+	performTxs    map[common.Hash]performTx
 }
 
 // GetActiveUpkeepKeys uses the latest head and map of all active upkeeps to build a
-// slice of upkeep keys.
+// slice of upkeep keys. Log triggered upkeeps are only included while they have
+// logs to be checked.
 func (r *EvmRegistry) GetActiveUpkeepIDs(context.Context) ([]types.UpkeepIdentifier, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	keys := make([]types.UpkeepIdentifier, len(r.active))
-	var i int
+	keys := make([]types.UpkeepIdentifier, 0, len(r.active))
 	for _, value := range r.active {
-		keys[i] = types.UpkeepIdentifier(value.ID.String())
-		i++
+		if value.LogTrigger != nil && !r.hasPendingLogs(value.ID) {
+			continue
+		}
+		keys = append(keys, types.UpkeepIdentifier(value.ID.String()))
 	}
 
 	return keys, nil
@@ -299,6 +355,26 @@ func (r *EvmRegistry) Start(ctx context.Context) error {
 			}(r.ctx, r.lggr, r.pollLogs)
 		}
 
+		// queue the logs of log triggered upkeeps on an interval
+		{
+			go func(cx context.Context, lggr logger.Logger, f func() error) {
+				ticker := time.NewTicker(time.Second)
+
+				for {
+					select {
+					case <-ticker.C:
+						err := f()
+						if err != nil {
+							lggr.Errorf("failed to poll logs for log triggered upkeeps", err)
+						}
+					case <-cx.Done():
+						ticker.Stop()
+						return
+					}
+				}
+			}(r.ctx, r.lggr, r.pollLogTriggers)
+		}
+
 		// run process to process logs from log channel
 		{
 			go func(cx context.Context, ch chan logpoller.Log, lggr logger.Logger, f func(logpoller.Log) error) {
@@ -328,6 +404,7 @@ func (r *EvmRegistry) Close() error {
 		r.cancel()
 		r.runState = 0
 		r.runError = nil
+		r.closeLogTriggers()
 		return nil
 	})
 }
@@ -388,6 +465,12 @@ func (r *EvmRegistry) initialize() error {
 	r.active = idMap
 	r.mu.Unlock()
 
+	actives := make([]activeUpkeep, 0, len(idMap))
+	for _, active := range idMap {
+		actives = append(actives, active)
+	}
+	r.syncLogTriggers(actives, true)
+
 	return nil
 }
 
@@ -477,6 +560,9 @@ func (r *EvmRegistry) processUpkeepStateLog(l logpoller.Log) error {
 	case *keeper_registry_wrapper2_0.KeeperRegistryUpkeepGasLimitSet:
 		r.lggr.Debugf("KeeperRegistryUpkeepGasLimitSet log detected for upkeep ID %s in transaction %s", l.Id.String(), hash)
 		r.addToActive(l.Id, true)
+	case *keeper_registry_wrapper2_0.KeeperRegistryUpkeepOffchainConfigSet:
+		r.lggr.Debugf("KeeperRegistryUpkeepOffchainConfigSet log detected for upkeep ID %s in transaction %s", l.Id.String(), hash)
+		r.addToActive(l.Id, true)
 	}
 
 	return nil
@@ -502,6 +588,7 @@ func (r *EvmRegistry) addToActive(id *big.Int, force bool) {
 		}
 
 		r.active[id.String()] = actives[0]
+		r.syncLogTriggers(actives, false)
 	}
 }
 
@@ -563,7 +650,7 @@ func (r *EvmRegistry) getLatestIDsFromContract(ctx context.Context) ([]*big.Int,
 }
 
 func (r *EvmRegistry) doCheck(ctx context.Context, mercuryEnabled bool, keys []types.UpkeepKey, chResult chan checkResult) {
-	upkeepResults, err := r.checkUpkeeps(ctx, keys)
+	upkeepResults, err := r.checkAllUpkeeps(ctx, keys)
 	if err != nil {
 		chResult <- checkResult{
 			err: err,
@@ -614,6 +701,49 @@ func (r *EvmRegistry) doCheck(ctx context.Context, mercuryEnabled bool, keys []t
 	}
 }
 
+// checkAllUpkeeps checks conditional upkeeps with checkUpkeep and log triggered
+// upkeeps with checkLog, returning the results in the order of keys.
+func (r *EvmRegistry) checkAllUpkeeps(ctx context.Context, keys []types.UpkeepKey) ([]types.UpkeepResult, error) {
+	var (
+		conditionalKeys, logKeys []types.UpkeepKey
+		conditionalIdx, logIdx   []int
+	)
+	for i, key := range keys {
+		_, id, err := blockAndIdFromKey(key)
+		if err != nil {
+			return nil, err
+		}
+		if r.isLogTriggered(id) {
+			logKeys = append(logKeys, key)
+			logIdx = append(logIdx, i)
+		} else {
+			conditionalKeys = append(conditionalKeys, key)
+			conditionalIdx = append(conditionalIdx, i)
+		}
+	}
+
+	results := make([]types.UpkeepResult, len(keys))
+	if len(conditionalKeys) > 0 {
+		conditionalResults, err := r.checkUpkeeps(ctx, conditionalKeys)
+		if err != nil {
+			return nil, err
+		}
+		for j, i := range conditionalIdx {
+			results[i] = conditionalResults[j]
+		}
+	}
+	if len(logKeys) > 0 {
+		logResults, err := r.checkLogTriggerUpkeeps(ctx, logKeys)
+		if err != nil {
+			return nil, err
+		}
+		for j, i := range logIdx {
+			results[i] = logResults[j]
+		}
+	}
+	return results, nil
+}
+
 // TODO (AUTO-2013): Have better error handling to not return nil results in case of partial errors
 func (r *EvmRegistry) checkUpkeeps(ctx context.Context, keys []types.UpkeepKey) ([]types.UpkeepResult, error) {
 	var (
@@ -17,7 +17,7 @@ import (
 type Config interface {
 	config.OCR2Config
 	pg.QConfig
-	JobPipelineMaxSuccessfulRuns() uint64
+	JobPipelineMaxSuccessfulRunsForJobType(jobType string) uint64
 	JobPipelineResultWriteQueueDepth() uint64
 	OCRDevelopmentMode() bool
 	MercuryCredentials(credName string) *models.MercuryCredentials
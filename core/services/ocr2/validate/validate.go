@@ -12,7 +12,9 @@ import (
 	libocr2 "github.com/smartcontractkit/libocr/offchainreporting2"
 
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	attestationconfig "github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/attestation/config"
 	dkgconfig "github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/dkg/config"
+	functionsconfig "github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/functions/config"
 	mercuryconfig "github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/mercury/config"
 	ocr2vrfconfig "github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/ocr2vrf/config"
 	"github.com/smartcontractkit/chainlink/v2/core/services/ocrcommon"
@@ -105,10 +107,11 @@ func validateSpec(tree *toml.Tree, spec job.Job) error {
 	case job.OCR2Keeper:
 		return validateOCR2KeeperSpec(spec.OCR2OracleSpec.PluginConfig)
 	case job.OCR2Functions:
-		// TODO validator for DR-OCR spec: https://app.shortcut.com/chainlinklabs/story/54054/ocr-plugin-for-directrequest-ocr
-		return nil
+		return validateOCR2FunctionsSpec(spec.OCR2OracleSpec.PluginConfig)
 	case job.Mercury:
 		return validateOCR2MercurySpec(spec.OCR2OracleSpec.PluginConfig)
+	case job.Attestation:
+		return validateOCR2AttestationSpec(spec.OCR2OracleSpec.PluginConfig)
 	case "":
 		return errors.New("no plugin specified")
 	default:
@@ -192,3 +195,21 @@ func validateOCR2MercurySpec(jsonConfig job.JSONConfig) error {
 	}
 	return pkgerrors.Wrap(mercuryconfig.ValidatePluginConfig(pluginConfig), "Mercury PluginConfig is invalid")
 }
+
+func validateOCR2FunctionsSpec(jsonConfig job.JSONConfig) error {
+	var pluginConfig functionsconfig.PluginConfig
+	err := json.Unmarshal(jsonConfig.Bytes(), &pluginConfig)
+	if err != nil {
+		return pkgerrors.Wrap(err, "error while unmarshaling plugin config")
+	}
+	return pkgerrors.Wrap(functionsconfig.ValidatePluginConfig(pluginConfig), "Functions PluginConfig is invalid")
+}
+
+func validateOCR2AttestationSpec(jsonConfig job.JSONConfig) error {
+	var pluginConfig attestationconfig.PluginConfig
+	err := json.Unmarshal(jsonConfig.Bytes(), &pluginConfig)
+	if err != nil {
+		return pkgerrors.Wrap(err, "error while unmarshaling plugin config")
+	}
+	return pkgerrors.Wrap(attestationconfig.ValidatePluginConfig(pluginConfig), "Attestation PluginConfig is invalid")
+}
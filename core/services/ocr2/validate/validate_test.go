@@ -580,6 +580,104 @@ KeyID               = "6f3b82406688b8ddb944c6f2e6d808f014c8fa8d568d639c25019568c
 				require.Contains(t, err.Error(), "validation error for keyID")
 			},
 		},
+		{
+			name: "valid attestation pluginConfig",
+			toml: `
+type = "offchainreporting2"
+schemaVersion = 1
+name = "attestation"
+contractID = "0x3e54dCc49F16411A3aaa4cDbC41A25bCa9763Cee"
+relay = "evm"
+pluginType = "attestation"
+transmitterID = "0x74103Cf8b436465870b26aa9Fa2F62AD62b22E35"
+
+[relayConfig]
+chainID = 4
+
+[pluginConfig]
+sourceChainID = "1"
+finality = 64
+
+[[pluginConfig.eventFilters]]
+address = "0x613a38AC1659769640aaE063C651F48E0250454C"
+eventSig = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+`,
+			assertion: func(t *testing.T, os job.Job, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, job.Attestation, os.OCR2OracleSpec.PluginType)
+			},
+		},
+		{
+			name: "invalid attestation pluginConfig",
+			toml: `
+type = "offchainreporting2"
+schemaVersion = 1
+name = "attestation"
+contractID = "0x3e54dCc49F16411A3aaa4cDbC41A25bCa9763Cee"
+relay = "evm"
+pluginType = "attestation"
+transmitterID = "0x74103Cf8b436465870b26aa9Fa2F62AD62b22E35"
+
+[relayConfig]
+chainID = 4
+
+[pluginConfig]
+finality = 64
+`,
+			assertion: func(t *testing.T, os job.Job, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "Attestation PluginConfig is invalid")
+				require.Contains(t, err.Error(), "SourceChainID must be set")
+			},
+		},
+		{
+			name: "valid functions pluginConfig",
+			toml: `
+type = "offchainreporting2"
+schemaVersion = 1
+name = "functions"
+contractID = "0x3e54dCc49F16411A3aaa4cDbC41A25bCa9763Cee"
+relay = "evm"
+pluginType = "functions"
+transmitterID = "0x74103Cf8b436465870b26aa9Fa2F62AD62b22E35"
+
+[relayConfig]
+chainID = 4
+
+[pluginConfig]
+requestTimeoutSec = 300
+maxSecretsSizeBytes = 1024
+`,
+			assertion: func(t *testing.T, os job.Job, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, job.OCR2Functions, os.OCR2OracleSpec.PluginType)
+			},
+		},
+		{
+			name: "functions pluginConfig with a decryption queue",
+			toml: `
+type = "offchainreporting2"
+schemaVersion = 1
+name = "functions"
+contractID = "0x3e54dCc49F16411A3aaa4cDbC41A25bCa9763Cee"
+relay = "evm"
+pluginType = "functions"
+transmitterID = "0x74103Cf8b436465870b26aa9Fa2F62AD62b22E35"
+
+[relayConfig]
+chainID = 4
+
+[pluginConfig.decryptionQueueConfig]
+maxQueueLength = 100
+maxCiphertextBytes = 1024
+completedCacheTimeoutSec = 300
+`,
+			assertion: func(t *testing.T, os job.Job, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "Functions PluginConfig is invalid")
+				require.Contains(t, err.Error(), "decryptionQueueConfig is not supported")
+			},
+		},
 	}
 
 	for _, tc := range tt {
@@ -5,3 +5,13 @@ const (
 	ChannelInsertOnEthTx     = "insert_on_eth_txes"
 	ChannelInsertOnCosmosMsg = "insert_on_cosmos_msg"
 )
+
+// Postgres channels notified of changes streamed to GraphQL subscribers. The
+// payloads are JSON objects identifying the changed row, except for
+// ChannelJobProposalUpdated whose payload is the job proposal ID.
+const (
+	ChannelPipelineRunCreated     = "pipeline_run_created"
+	ChannelPipelineTaskRunUpdated = "pipeline_task_run_updated"
+	ChannelEthTxStateChanged      = "eth_tx_state_changed"
+	ChannelJobProposalUpdated     = "job_proposal_updated"
+)
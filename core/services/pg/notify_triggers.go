@@ -0,0 +1,56 @@
+package pg
+
+import (
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// notifyTriggers notify the channels streamed to GraphQL subscribers. Each
+// trigger is named after its channel and calls the function of the same name
+// created by migration 0171.
+var notifyTriggers = []struct {
+	channel string
+	table   string
+	events  string
+}{
+	{ChannelPipelineRunCreated, "pipeline_runs", "INSERT"},
+	{ChannelPipelineTaskRunUpdated, "pipeline_task_runs", "INSERT OR UPDATE"},
+	{ChannelEthTxStateChanged, "eth_txes", "INSERT OR UPDATE"},
+	{ChannelJobProposalUpdated, "job_proposals", "INSERT OR UPDATE"},
+}
+
+// SetNotifyTriggers installs the triggers notifying the GraphQL subscription
+// channels if enabled, and removes them otherwise, so that nodes which do not
+// serve subscriptions don't pay for a notification on every write to these
+// tables. Tables are only locked when the triggers have to change.
+func SetNotifyTriggers(q Q, enabled bool) error {
+	var names []string
+	for _, t := range notifyTriggers {
+		names = append(names, "notify_"+t.channel)
+	}
+	var installed int
+	if err := q.Get(&installed, `SELECT count(*) FROM pg_trigger WHERE NOT tgisinternal AND tgname = ANY($1)`, pq.Array(names)); err != nil {
+		return errors.Wrap(err, "failed to find notify triggers")
+	}
+	if (enabled && installed == len(notifyTriggers)) || (!enabled && installed == 0) {
+		return nil
+	}
+
+	return q.Transaction(func(tx Queryer) error {
+		for _, t := range notifyTriggers {
+			name := "notify_" + t.channel
+			if _, err := tx.Exec(fmt.Sprintf(`DROP TRIGGER IF EXISTS %s ON %s`, name, t.table)); err != nil {
+				return errors.Wrapf(err, "failed to drop trigger %s", name)
+			}
+			if !enabled {
+				continue
+			}
+			if _, err := tx.Exec(fmt.Sprintf(`CREATE TRIGGER %[1]s AFTER %[2]s ON %[3]s FOR EACH ROW EXECUTE PROCEDURE %[1]s()`, name, t.events, t.table)); err != nil {
+				return errors.Wrapf(err, "failed to create trigger %s", name)
+			}
+		}
+		return nil
+	})
+}
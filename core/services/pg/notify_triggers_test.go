@@ -0,0 +1,34 @@
+package pg_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+)
+
+func TestSetNotifyTriggers(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	q := pg.NewQ(db, logger.TestLogger(t), pgtest.NewQConfig(true))
+
+	countTriggers := func() (n int) {
+		require.NoError(t, db.Get(&n, `SELECT count(*) FROM pg_trigger WHERE tgname IN
+('notify_pipeline_run_created', 'notify_pipeline_task_run_updated', 'notify_eth_tx_state_changed', 'notify_job_proposal_updated')`))
+		return
+	}
+
+	require.NoError(t, pg.SetNotifyTriggers(q, false))
+	assert.Equal(t, 0, countTriggers())
+
+	require.NoError(t, pg.SetNotifyTriggers(q, true))
+	assert.Equal(t, 4, countTriggers())
+	require.NoError(t, pg.SetNotifyTriggers(q, true))
+	assert.Equal(t, 4, countTriggers())
+
+	require.NoError(t, pg.SetNotifyTriggers(q, false))
+	assert.Equal(t, 0, countTriggers())
+}
@@ -40,6 +40,7 @@ const (
 	BlockHeaderFeederJobType  string = "blockheaderfeeder"
 	WebhookJobType            string = "webhook"
 	BootstrapJobType          string = "bootstrap"
+	EVMLogJobType             string = "evmlog"
 )
 
 //go:generate mockery --quiet --name Config --output ./mocks/ --case=underscore
@@ -70,6 +71,10 @@ type (
 		JobPipelineMaxRunDuration() time.Duration
 		JobPipelineReaperInterval() time.Duration
 		JobPipelineReaperThreshold() time.Duration
+		JobPipelineReaperArchiveDir() string
+		JobPipelineRetentionJobTypes() []string
+		JobPipelineReaperThresholdForJobType(jobType string) time.Duration
+		JobPipelineErroredReaperThresholdForJobType(jobType string) time.Duration
 	}
 )
 
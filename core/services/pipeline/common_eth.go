@@ -103,6 +103,19 @@ func parseETHABIString(theABI []byte, isLog bool) (name string, args abi.Argumen
 	return name, args, indexedArgs, err
 }
 
+// ParseETHABIEvent parses an event specification in the format of the
+// ethabidecodelog task, e.g. "Transfer(address indexed from, uint256 value)".
+func ParseETHABIEvent(theABI []byte) (abi.Event, error) {
+	name, args, _, err := parseETHABIString(theABI, true)
+	if err != nil {
+		return abi.Event{}, err
+	}
+	if name == "" {
+		return abi.Event{}, errors.Errorf("bad ABI specification, missing event name: %s", theABI)
+	}
+	return abi.NewEvent(name, name, false, args), nil
+}
+
 func convertToETHABIType(val interface{}, abiType abi.Type) (interface{}, error) {
 	srcVal := reflect.ValueOf(val)
 
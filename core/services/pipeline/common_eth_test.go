@@ -152,3 +152,23 @@ func Test_convertToETHABIBytes_Errors(t *testing.T) {
 		})
 	}
 }
+
+func TestParseETHABIEvent(t *testing.T) {
+	t.Parallel()
+
+	event, err := ParseETHABIEvent([]byte("Transfer(address indexed from, address indexed to, uint256 value)"))
+	require.NoError(t, err)
+	assert.Equal(t, "Transfer", event.Name)
+	assert.Equal(t, "Transfer(address,address,uint256)", event.Sig)
+	assert.Equal(t, common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"), event.ID)
+	require.Len(t, event.Inputs, 3)
+	assert.True(t, event.Inputs[0].Indexed)
+	assert.True(t, event.Inputs[1].Indexed)
+	assert.False(t, event.Inputs[2].Indexed)
+
+	_, err = ParseETHABIEvent([]byte("(address indexed from)"))
+	assert.EqualError(t, err, "bad ABI specification, missing event name: (address indexed from)")
+
+	_, err = ParseETHABIEvent([]byte("Transfer(address indexed)"))
+	assert.Error(t, err)
+}
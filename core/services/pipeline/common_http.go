@@ -9,6 +9,11 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	clhttp "github.com/smartcontractkit/chainlink/v2/core/utils/http"
@@ -23,7 +28,19 @@ func makeHTTPRequest(
 	requestData MapParam,
 	client *http.Client,
 	httpLimit int64,
-) ([]byte, int, http.Header, time.Duration, error) {
+) (_ []byte, _ int, _ http.Header, _ time.Duration, err error) {
+	// the URL is not recorded since it may embed credentials
+	ctx, span := tracer.Start(ctx, "http.request", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("http.method", string(method)),
+		attribute.String("net.peer.name", url.Host),
+	))
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
 
 	var bodyReader io.Reader
 	if requestData != nil {
@@ -45,6 +62,8 @@ func makeHTTPRequest(
 	for i := 0; i+1 < len(reqHeaders); i += 2 {
 		request.Header.Set(reqHeaders[i], reqHeaders[i+1])
 	}
+	// propagate the W3C trace context to external adapters
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(request.Header))
 
 	httpRequest := clhttp.HTTPRequest{
 		Client:  client,
@@ -62,6 +81,7 @@ func makeHTTPRequest(
 		return nil, 0, nil, 0, errors.Wrapf(err, "error making http request")
 	}
 	elapsed := time.Since(start) // TODO: return elapsed from utils/http
+	span.SetAttributes(attribute.Int("http.status_code", statusCode))
 
 	if statusCode >= 400 {
 		maybeErr := bestEffortExtractError(responseBytes)
@@ -61,3 +61,8 @@ func (t *ETHTxTask) HelperSetDependencies(cc evm.ChainSet, keyStore ETHKeyStore,
 	t.specGasLimit = specGasLimit
 	t.jobType = jobType
 }
+
+// NewVarsWithSecrets returns the vars of a run with the given inputs and secret inputs.
+func NewVarsWithSecrets(inputs, secretInputs map[string]interface{}) Vars {
+	return runVars(&Run{Inputs: JSONSerializable{Val: inputs, Valid: true}, SecretInputs: secretInputs})
+}
@@ -88,6 +88,20 @@ func (_m *ORM) CreateSpec(_a0 pipeline.Pipeline, maxTaskTimeout models.Interval,
 	return r0, r1
 }
 
+// DeleteExpiredRuns provides a mock function with given fields: ctx, retentions, archiver
+func (_m *ORM) DeleteExpiredRuns(ctx context.Context, retentions []pipeline.RunRetention, archiver pipeline.RunArchiver) error {
+	ret := _m.Called(ctx, retentions, archiver)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []pipeline.RunRetention, pipeline.RunArchiver) error); ok {
+		r0 = rf(ctx, retentions, archiver)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteRun provides a mock function with given fields: id
 func (_m *ORM) DeleteRun(id int64) error {
 	ret := _m.Called(id)
@@ -55,6 +55,8 @@ type Run struct {
 	Pending bool
 	// FailSilently is used to signal that a task with the failEarly flag has failed, and we want to not put this in the db
 	FailSilently bool
+	// SecretInputs are available to tasks like Inputs, but are never stored.
+	SecretInputs map[string]interface{} `json:"-"`
 }
 
 func (r Run) GetID() string {
@@ -88,6 +88,9 @@ type ORM interface {
 	InsertFinishedRuns(run []*Run, saveSuccessfulTaskRuns bool, qopts ...pg.QOpt) (err error)
 
 	DeleteRunsOlderThan(context.Context, time.Duration) error
+	// DeleteExpiredRuns deletes the finished runs older than their retention,
+	// archiving them first if archiver is not nil.
+	DeleteExpiredRuns(ctx context.Context, retentions []RunRetention, archiver RunArchiver) error
 	FindRun(id int64) (Run, error)
 	GetAllRuns() ([]Run, error)
 	GetUnfinishedRuns(context.Context, time.Time, func(run Run) error) error
@@ -98,12 +101,15 @@ type orm struct {
 	utils.StartStopOnce
 	q                 pg.Q
 	lggr              logger.Logger
+	cfg               ORMConfig
 	maxSuccessfulRuns uint64
 	// jobID => count
-	pm   sync.Map
-	wg   sync.WaitGroup
-	ctx  context.Context
-	cncl context.CancelFunc
+	pm sync.Map
+	// pipelineSpecID => count of successful runs, for sampling task runs
+	sampled sync.Map
+	wg      sync.WaitGroup
+	ctx     context.Context
+	cncl    context.CancelFunc
 }
 
 var _ ORM = (*orm)(nil)
@@ -111,19 +117,19 @@ var _ ORM = (*orm)(nil)
 type ORMConfig interface {
 	pg.QConfig
 	JobPipelineMaxSuccessfulRuns() uint64
+	JobPipelineMaxSuccessfulRunsForJobType(jobType string) uint64
+	JobPipelineSuccessfulTaskRunsSampleRateForJobType(jobType string) uint32
 }
 
 func NewORM(db *sqlx.DB, lggr logger.Logger, cfg ORMConfig) *orm {
 	ctx, cancel := context.WithCancel(context.Background())
 	return &orm{
-		utils.StartStopOnce{},
-		pg.NewQ(db, lggr, cfg),
-		lggr.Named("PipelineORM"),
-		cfg.JobPipelineMaxSuccessfulRuns(),
-		sync.Map{},
-		sync.WaitGroup{},
-		ctx,
-		cancel,
+		q:                 pg.NewQ(db, lggr, cfg),
+		lggr:              lggr.Named("PipelineORM"),
+		cfg:               cfg,
+		maxSuccessfulRuns: cfg.JobPipelineMaxSuccessfulRuns(),
+		ctx:               ctx,
+		cncl:              cancel,
 	}
 }
 
@@ -199,7 +205,7 @@ func (o *orm) CreateRun(run *Run, qopts ...pg.QOpt) (err error) {
 // InsertRun inserts a run into the database
 func (o *orm) InsertRun(run *Run, qopts ...pg.QOpt) error {
 	if run.Status() == RunStatusCompleted {
-		defer o.Prune(o.q, run.PipelineSpecID)
+		defer o.Prune(o.q, run.PipelineSpecID, run.PipelineSpec.JobType)
 	}
 	q := o.q.WithOpts(qopts...)
 	sql := `INSERT INTO pipeline_runs (pipeline_spec_id, meta, all_errors, fatal_errors, inputs, outputs, created_at, finished_at, state)
@@ -254,7 +260,7 @@ func (o *orm) StoreRun(run *Run, qopts ...pg.QOpt) (restart bool, err error) {
 				return errors.Wrap(err, "StoreRun")
 			}
 		} else {
-			defer o.Prune(tx, run.PipelineSpecID)
+			defer o.Prune(tx, run.PipelineSpecID, run.PipelineSpec.JobType)
 			// Simply finish the run, no need to do any sort of locking
 			if run.Outputs.Val == nil || len(run.FatalErrors)+len(run.AllErrors) == 0 {
 				return errors.Errorf("run must have both Outputs and Errors, got Outputs: %#v, FatalErrors: %#v, AllErrors: %#v", run.Outputs.Val, run.FatalErrors, run.AllErrors)
@@ -361,17 +367,18 @@ RETURNING id
 			runIDs = append(runIDs, runID)
 		}
 
-		pipelineSpecIDm := make(map[int32]struct{})
+		// pipelineSpecID => jobType
+		pipelineSpecIDm := make(map[int32]string)
 		for i, run := range runs {
-			pipelineSpecIDm[run.PipelineSpecID] = struct{}{}
+			pipelineSpecIDm[run.PipelineSpecID] = run.PipelineSpec.JobType
 			for j := range run.PipelineTaskRuns {
 				run.PipelineTaskRuns[j].PipelineRunID = runIDs[i]
 			}
 		}
 
 		defer func() {
-			for pipelineSpecID := range pipelineSpecIDm {
-				o.Prune(tx, pipelineSpecID)
+			for pipelineSpecID, jobType := range pipelineSpecIDm {
+				o.Prune(tx, pipelineSpecID, jobType)
 			}
 		}()
 
@@ -381,11 +388,14 @@ VALUES (:pipeline_run_id, :id, :type, :index, :output, :error, :dot_id, :created
 	`
 		var pipelineTaskRuns []TaskRun
 		for _, run := range runs {
-			if !saveSuccessfulTaskRuns && !run.HasErrors() {
+			if !o.saveTaskRuns(run, saveSuccessfulTaskRuns) {
 				continue
 			}
 			pipelineTaskRuns = append(pipelineTaskRuns, run.PipelineTaskRuns...)
 		}
+		if len(pipelineTaskRuns) == 0 {
+			return nil
+		}
 
 		_, errE := tx.NamedExec(pipelineTaskRunsQuery, pipelineTaskRuns)
 		return errors.Wrap(errE, "insert pipeline task runs")
@@ -418,7 +428,7 @@ func (o *orm) InsertFinishedRun(run *Run, saveSuccessfulTaskRuns bool, qopts ...
 		return err
 	}
 
-	if o.maxSuccessfulRuns == 0 {
+	if o.cfg.JobPipelineMaxSuccessfulRunsForJobType(run.PipelineSpec.JobType) == 0 {
 		// optimisation: avoid persisting if we oughtn't to save any
 		return nil
 	}
@@ -443,11 +453,11 @@ func (o *orm) InsertFinishedRun(run *Run, saveSuccessfulTaskRuns bool, qopts ...
 			run.PipelineTaskRuns[i].PipelineRunID = run.ID
 		}
 
-		if !saveSuccessfulTaskRuns && !run.HasErrors() {
+		if !o.saveTaskRuns(run, saveSuccessfulTaskRuns) || len(run.PipelineTaskRuns) == 0 {
 			return nil
 		}
 
-		defer o.Prune(tx, run.PipelineSpecID)
+		defer o.Prune(tx, run.PipelineSpecID, run.PipelineSpec.JobType)
 		sql = `
 		INSERT INTO pipeline_task_runs (pipeline_run_id, id, type, index, output, error, dot_id, created_at, finished_at)
 		VALUES (:pipeline_run_id, :id, :type, :index, :output, :error, :dot_id, :created_at, :finished_at);`
@@ -457,46 +467,48 @@ func (o *orm) InsertFinishedRun(run *Run, saveSuccessfulTaskRuns bool, qopts ...
 	return errors.Wrap(err, "InsertFinishedRun failed")
 }
 
+// saveTaskRuns returns whether the task runs of a finished run should be saved.
+// Errored runs always are, and successful runs are either sampled according to
+// the config of the job type, or saved if saveSuccessfulTaskRuns is true.
+func (o *orm) saveTaskRuns(run *Run, saveSuccessfulTaskRuns bool) bool {
+	if run.HasErrors() {
+		return true
+	}
+	rate := o.cfg.JobPipelineSuccessfulTaskRunsSampleRateForJobType(run.PipelineSpec.JobType)
+	if rate == 0 {
+		return saveSuccessfulTaskRuns
+	}
+	return loadCount(&o.sampled, run.PipelineSpecID).Add(1)%uint64(rate) == 0
+}
+
 // DeleteRunsOlderThan deletes all pipeline_runs that have been finished for a certain threshold to free DB space
 // Caller is expected to set timeout on calling context.
 func (o *orm) DeleteRunsOlderThan(ctx context.Context, threshold time.Duration) error {
+	return o.DeleteExpiredRuns(ctx, []RunRetention{{Threshold: threshold, ErroredThreshold: threshold}}, nil)
+}
+
+// DeleteExpiredRuns deletes all pipeline_runs that have been finished for longer than their retention to free DB space
+// Caller is expected to set timeout on calling context.
+func (o *orm) DeleteExpiredRuns(ctx context.Context, retentions []RunRetention, archiver RunArchiver) error {
 	start := time.Now()
 
 	q := o.q.WithOpts(pg.WithParentCtxInheritTimeout(ctx))
 
-	queryThreshold := start.Add(-threshold)
-
-	rowsDeleted := int64(0)
-
-	err := pg.Batch(func(_, limit uint) (count uint, err error) {
-		result, cancel, err := q.ExecQIter(`
-WITH batched_pipeline_runs AS (
-	SELECT * FROM pipeline_runs
-	WHERE finished_at < ($1)
-	ORDER BY finished_at ASC
-	LIMIT $2
-)
-DELETE FROM pipeline_runs
-USING batched_pipeline_runs
-WHERE pipeline_runs.id = batched_pipeline_runs.id`,
-			queryThreshold,
-			limit,
-		)
-		defer cancel()
-		if err != nil {
-			return count, errors.Wrap(err, "DeleteRunsOlderThan failed to delete old pipeline_runs")
+	// not nil, which would be sent as NULL and match no job type
+	jobTypes := []string{}
+	for _, r := range retentions {
+		if r.JobType != "" {
+			jobTypes = append(jobTypes, r.JobType)
 		}
+	}
 
-		rowsAffected, err := result.RowsAffected()
+	rowsDeleted := int64(0)
+	for _, r := range retentions {
+		deleted, err := o.deleteExpiredRuns(q, start, r, jobTypes, archiver)
+		rowsDeleted += deleted
 		if err != nil {
-			return count, errors.Wrap(err, "DeleteRunsOlderThan failed to get rows affected")
+			return errors.Wrap(err, "DeleteExpiredRuns failed")
 		}
-		rowsDeleted += rowsAffected
-
-		return uint(rowsAffected), err
-	})
-	if err != nil {
-		return errors.Wrap(err, "DeleteRunsOlderThan failed")
 	}
 
 	deleteTS := time.Now()
@@ -506,15 +518,69 @@ WHERE pipeline_runs.id = batched_pipeline_runs.id`,
 		o.lggr.Debugw("pipeline_runs reaper VACUUM ANALYZE query completed", "duration", time.Since(start))
 	}(deleteTS)
 
-	err = q.ExecQ("VACUUM ANALYZE pipeline_runs")
+	err := q.ExecQ("VACUUM ANALYZE pipeline_runs")
 	if err != nil {
-		o.lggr.Warnw("DeleteRunsOlderThan successfully deleted old pipeline_runs rows, but failed to run VACUUM ANALYZE", "err", err)
+		o.lggr.Warnw("DeleteExpiredRuns successfully deleted old pipeline_runs rows, but failed to run VACUUM ANALYZE", "err", err)
 		return nil
 	}
 
 	return nil
 }
 
+// deleteExpiredRuns deletes the runs expired according to r, in batches. If
+// r has no job type, it applies to the runs of the jobs of any type not in
+// jobTypes.
+func (o *orm) deleteExpiredRuns(q pg.Q, now time.Time, r RunRetention, jobTypes []string, archiver RunArchiver) (rowsDeleted int64, err error) {
+	jobFilter, jobArg := `jobs.type::text = $3`, any(r.JobType)
+	if r.JobType == "" {
+		jobFilter, jobArg = `(jobs.type IS NULL OR NOT jobs.type::text = ANY($3))`, jobTypes
+	}
+	selectSQL := `
+SELECT pipeline_runs.id FROM pipeline_runs
+LEFT JOIN jobs ON jobs.pipeline_spec_id = pipeline_runs.pipeline_spec_id
+WHERE ` + jobFilter + ` AND pipeline_runs.finished_at < (CASE WHEN pipeline_runs.state = 'errored' THEN $1 ELSE $2 END)::timestamptz
+ORDER BY pipeline_runs.finished_at ASC
+LIMIT $4`
+
+	err = pg.Batch(func(_, limit uint) (count uint, err error) {
+		var ids []int64
+		if err = q.Select(&ids, selectSQL, now.Add(-r.ErroredThreshold), now.Add(-r.Threshold), jobArg, limit); err != nil {
+			return count, errors.Wrap(err, "failed to find expired pipeline_runs")
+		}
+		if len(ids) == 0 {
+			return 0, nil
+		}
+
+		if archiver != nil {
+			var runs []*Run
+			if err = q.Select(&runs, `SELECT * FROM pipeline_runs WHERE id = ANY($1) ORDER BY finished_at ASC, id ASC`, ids); err != nil {
+				return count, errors.Wrap(err, "failed to load expired pipeline_runs")
+			}
+			if err = loadAssociations(q, runs); err != nil {
+				return count, err
+			}
+			if err = archiver.Archive(runs); err != nil {
+				return count, errors.Wrap(err, "failed to archive expired pipeline_runs")
+			}
+		}
+
+		result, cancel, err := q.ExecQIter(`DELETE FROM pipeline_runs WHERE id = ANY($1)`, ids)
+		defer cancel()
+		if err != nil {
+			return count, errors.Wrap(err, "failed to delete expired pipeline_runs")
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return count, errors.Wrap(err, "failed to get rows affected")
+		}
+		rowsDeleted += rowsAffected
+
+		return uint(len(ids)), err
+	})
+	return rowsDeleted, err
+}
+
 func (o *orm) FindRun(id int64) (r Run, err error) {
 	var runs []*Run
 	err = o.q.Transaction(func(tx pg.Queryer) error {
@@ -621,14 +687,14 @@ func (o *orm) GetQ() pg.Q {
 	return o.q
 }
 
-func (o *orm) loadCount(pipelineSpecID int32) *atomic.Uint64 {
+func loadCount(m *sync.Map, pipelineSpecID int32) *atomic.Uint64 {
 	// fast path; avoids allocation
-	actual, exists := o.pm.Load(pipelineSpecID)
+	actual, exists := m.Load(pipelineSpecID)
 	if exists {
 		return actual.(*atomic.Uint64)
 	}
 	// "slow" path
-	actual, _ = o.pm.LoadOrStore(pipelineSpecID, new(atomic.Uint64))
+	actual, _ = m.LoadOrStore(pipelineSpecID, new(atomic.Uint64))
 	return actual.(*atomic.Uint64)
 }
 
@@ -637,35 +703,36 @@ func (o *orm) loadCount(pipelineSpecID int32) *atomic.Uint64 {
 const syncLimit = 1000
 
 // Prune attempts to keep the pipeline_runs table capped close to the
-// maxSuccessfulRuns length for each pipeline_spec_id.
+// maxSuccessfulRuns length of jobType for each pipeline_spec_id.
 //
 // It does this synchronously for small values and async/sampled for large
 // values.
 //
 // Note this does not guarantee the pipeline_runs table is kept to exactly the
 // max length, rather that it doesn't excessively larger than it.
-func (o *orm) Prune(tx pg.Queryer, pipelineSpecID int32) {
+func (o *orm) Prune(tx pg.Queryer, pipelineSpecID int32, jobType string) {
 	if pipelineSpecID == 0 {
 		o.lggr.Panic("expected a non-zero pipeline spec ID")
 	}
+	maxSuccessfulRuns := o.cfg.JobPipelineMaxSuccessfulRunsForJobType(jobType)
 	// For small maxSuccessfulRuns its fast enough to prune every time
-	if o.maxSuccessfulRuns < syncLimit {
-		o.execPrune(tx, pipelineSpecID)
+	if maxSuccessfulRuns < syncLimit {
+		o.execPrune(tx, pipelineSpecID, maxSuccessfulRuns)
 		return
 	}
 	// for large maxSuccessfulRuns we do it async on a sampled basis
-	every := o.maxSuccessfulRuns / 20 // it can get up to 5% larger than maxSuccessfulRuns before a prune
-	cnt := o.loadCount(pipelineSpecID)
+	every := maxSuccessfulRuns / 20 // it can get up to 5% larger than maxSuccessfulRuns before a prune
+	cnt := loadCount(&o.pm, pipelineSpecID)
 	val := cnt.Add(1)
 	if val%every == 0 {
 		ok := o.IfStarted(func() {
 			o.wg.Add(1)
 			go func() {
-				o.lggr.Debugw("Pruning runs", "pipelineSpecID", pipelineSpecID, "count", val, "every", every, "maxSuccessfulRuns", o.maxSuccessfulRuns)
+				o.lggr.Debugw("Pruning runs", "pipelineSpecID", pipelineSpecID, "count", val, "every", every, "maxSuccessfulRuns", maxSuccessfulRuns)
 				defer o.wg.Done()
 				// Must not use tx here since it's async and the transaction
 				// could be stale
-				o.execPrune(o.q.WithOpts(pg.WithLongQueryTimeout()), pipelineSpecID)
+				o.execPrune(o.q.WithOpts(pg.WithLongQueryTimeout()), pipelineSpecID, maxSuccessfulRuns)
 			}()
 		})
 		if !ok {
@@ -675,13 +742,13 @@ func (o *orm) Prune(tx pg.Queryer, pipelineSpecID int32) {
 	}
 }
 
-func (o *orm) execPrune(q pg.Queryer, pipelineSpecID int32) {
+func (o *orm) execPrune(q pg.Queryer, pipelineSpecID int32, maxSuccessfulRuns uint64) {
 	res, err := q.ExecContext(o.ctx, `DELETE FROM pipeline_runs WHERE pipeline_spec_id = $1 AND state = $2 AND id NOT IN (
 SELECT id FROM pipeline_runs
 WHERE pipeline_spec_id = $1 AND state = $2
 ORDER BY id DESC
 LIMIT $3
-)`, pipelineSpecID, RunStatusCompleted, o.maxSuccessfulRuns)
+)`, pipelineSpecID, RunStatusCompleted, maxSuccessfulRuns)
 	if err != nil {
 		o.lggr.Errorw("Failed to prune runs", "err", err, "pipelineSpecID", pipelineSpecID)
 		return
@@ -701,8 +768,9 @@ LIMIT $3
 		if !exists {
 			o.lggr.Debugw("Pipeline spec no longer exists, removing prune count", "pipelineSpecID", pipelineSpecID)
 			o.pm.Delete(pipelineSpecID)
+			o.sampled.Delete(pipelineSpecID)
 		}
-	} else if o.maxSuccessfulRuns < syncLimit {
+	} else if maxSuccessfulRuns < syncLimit {
 		o.lggr.Tracew("Pruned runs", "rowsAffected", rowsAffected, "pipelineSpecID", pipelineSpecID)
 	} else {
 		o.lggr.Debugw("Pruned runs", "rowsAffected", rowsAffected, "pipelineSpecID", pipelineSpecID)
@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/smartcontractkit/sqlx"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zapcore"
 	"gopkg.in/guregu/null.v4"
@@ -23,6 +24,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
 	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
 	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline/mocks"
 	"github.com/smartcontractkit/chainlink/v2/core/store/models"
 	"github.com/smartcontractkit/chainlink/v2/core/utils"
 )
@@ -32,6 +34,10 @@ type ormconfig struct {
 }
 
 func (ormconfig) JobPipelineMaxSuccessfulRuns() uint64 { return 123456 }
+func (c ormconfig) JobPipelineMaxSuccessfulRunsForJobType(string) uint64 {
+	return c.JobPipelineMaxSuccessfulRuns()
+}
+func (ormconfig) JobPipelineSuccessfulTaskRunsSampleRateForJobType(string) uint32 { return 0 }
 
 func setupORM(t *testing.T, name string) (db *sqlx.DB, orm pipeline.ORM) {
 	t.Helper()
@@ -507,6 +513,96 @@ func Test_PipelineORM_DeleteRunsOlderThan(t *testing.T) {
 	}
 }
 
+func Test_PipelineORM_DeleteExpiredRuns(t *testing.T) {
+	db, orm := setupORM(t, "")
+	ps := cltest.MustInsertPipelineSpec(t, db)
+
+	insertRun := func(status pipeline.RunStatus, age time.Duration) int64 {
+		run := cltest.MustInsertPipelineRunWithStatus(t, db, ps.ID, status)
+		_, err := db.Exec(`UPDATE pipeline_runs SET finished_at = $1 WHERE id = $2`, time.Now().Add(-age), run.ID)
+		require.NoError(t, err)
+		return run.ID
+	}
+	expiredCompleted := insertRun(pipeline.RunStatusCompleted, 2*time.Hour)
+	recentCompleted := insertRun(pipeline.RunStatusCompleted, time.Minute)
+	recentErrored := insertRun(pipeline.RunStatusErrored, 2*time.Hour)
+	expiredErrored := insertRun(pipeline.RunStatusErrored, 10*time.Hour)
+
+	archiver := mocks.NewRunArchiver(t)
+	var archived []int64
+	archiver.On("Archive", mock.Anything).Run(func(args mock.Arguments) {
+		for _, r := range args.Get(0).([]*pipeline.Run) {
+			archived = append(archived, r.ID)
+		}
+	}).Return(nil)
+
+	err := orm.DeleteExpiredRuns(testutils.Context(t), []pipeline.RunRetention{
+		{Threshold: time.Hour, ErroredThreshold: 5 * time.Hour},
+		// runs of jobs of other types are not affected
+		{JobType: "cron", Threshold: time.Second, ErroredThreshold: time.Second},
+	}, archiver)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []int64{expiredCompleted, expiredErrored}, archived)
+	for _, id := range []int64{expiredCompleted, expiredErrored} {
+		_, err = orm.FindRun(id)
+		require.Error(t, err, "not found")
+	}
+	for _, id := range []int64{recentCompleted, recentErrored} {
+		_, err = orm.FindRun(id)
+		require.NoError(t, err)
+	}
+}
+
+func Test_PipelineORM_DeleteExpiredRuns_JobTypes(t *testing.T) {
+	db, orm := setupORM(t, "")
+	webhookJob, _ := cltest.MustInsertWebhookSpec(t, db)
+	ocrJob := cltest.MustInsertV2JobSpec(t, db, cltest.NewEIP55Address().Address())
+	orphan := cltest.MustInsertPipelineSpec(t, db)
+
+	insertRun := func(pipelineSpecID int32, age time.Duration) int64 {
+		run := cltest.MustInsertPipelineRunWithStatus(t, db, pipelineSpecID, pipeline.RunStatusCompleted)
+		_, err := db.Exec(`UPDATE pipeline_runs SET finished_at = $1 WHERE id = $2`, time.Now().Add(-age), run.ID)
+		require.NoError(t, err)
+		return run.ID
+	}
+
+	t.Run("default retention only", func(t *testing.T) {
+		webhookRun := insertRun(webhookJob.PipelineSpecID, 2*time.Hour)
+		ocrRun := insertRun(ocrJob.PipelineSpecID, 2*time.Hour)
+		orphanRun := insertRun(orphan.ID, 2*time.Hour)
+		recentRun := insertRun(ocrJob.PipelineSpecID, time.Minute)
+
+		require.NoError(t, orm.DeleteRunsOlderThan(testutils.Context(t), time.Hour))
+
+		for _, id := range []int64{webhookRun, ocrRun, orphanRun} {
+			_, err := orm.FindRun(id)
+			require.Error(t, err, "not found")
+		}
+		_, err := orm.FindRun(recentRun)
+		require.NoError(t, err)
+	})
+
+	t.Run("per job type retention", func(t *testing.T) {
+		webhookRun := insertRun(webhookJob.PipelineSpecID, 2*time.Hour)
+		expiredWebhookRun := insertRun(webhookJob.PipelineSpecID, 20*time.Hour)
+		ocrRun := insertRun(ocrJob.PipelineSpecID, 2*time.Hour)
+		orphanRun := insertRun(orphan.ID, 2*time.Hour)
+
+		require.NoError(t, orm.DeleteExpiredRuns(testutils.Context(t), []pipeline.RunRetention{
+			{Threshold: time.Hour, ErroredThreshold: time.Hour},
+			{JobType: string(job.Webhook), Threshold: 10 * time.Hour, ErroredThreshold: 10 * time.Hour},
+		}, nil))
+
+		for _, id := range []int64{expiredWebhookRun, ocrRun, orphanRun} {
+			_, err := orm.FindRun(id)
+			require.Error(t, err, "not found")
+		}
+		_, err := orm.FindRun(webhookRun)
+		require.NoError(t, err)
+	})
+}
+
 func Test_GetUnfinishedRuns_Keepers(t *testing.T) {
 	t.Parallel()
 
@@ -716,7 +812,7 @@ func Test_Prune(t *testing.T) {
 	ps1 := cltest.MustInsertPipelineSpec(t, db)
 
 	t.Run("when there are no runs to prune, does nothing", func(t *testing.T) {
-		porm.Prune(db, ps1.ID)
+		porm.Prune(db, ps1.ID, "")
 
 		// no error logs; it did nothing
 		assert.Empty(t, observed.All())
@@ -748,7 +844,7 @@ func Test_Prune(t *testing.T) {
 		cltest.MustInsertPipelineRunWithStatus(t, db, ps2.ID, pipeline.RunStatusSuspended)
 	}
 
-	porm.Prune(db, ps2.ID)
+	porm.Prune(db, ps2.ID, "")
 
 	cnt := pgtest.MustCount(t, db, "SELECT count(*) FROM pipeline_runs WHERE pipeline_spec_id = $1 AND state = $2", ps1.ID, pipeline.RunStatusCompleted)
 	assert.Equal(t, cnt, 20)
@@ -0,0 +1,82 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+// RunRetention is how long the finished runs of the jobs of a type are kept.
+type RunRetention struct {
+	// JobType is the type of the jobs, or empty for the jobs of any type
+	// without their own RunRetention.
+	JobType string
+	// Threshold is the age above which completed runs are deleted.
+	Threshold time.Duration
+	// ErroredThreshold is the age above which errored runs are deleted.
+	ErroredThreshold time.Duration
+}
+
+//go:generate mockery --quiet --name RunArchiver --output ./mocks/ --case=underscore
+
+// RunArchiver stores the runs deleted by the reaper.
+type RunArchiver interface {
+	Archive(runs []*Run) error
+}
+
+type fileRunArchiver struct {
+	dir string
+}
+
+// NewFileRunArchiver returns a RunArchiver appending runs, with their task
+// runs, as JSON lines to a file per day in dir.
+func NewFileRunArchiver(dir string) RunArchiver {
+	return &fileRunArchiver{dir: dir}
+}
+
+// archivedRun adds the fields of a Run which are not otherwise marshaled.
+type archivedRun struct {
+	ID             int64  `json:"id"`
+	PipelineSpecID int32  `json:"pipelineSpecID"`
+	JobID          int32  `json:"jobID"`
+	JobName        string `json:"jobName"`
+	JobType        string `json:"jobType"`
+	*Run
+}
+
+func (a *fileRunArchiver) Archive(runs []*Run) (err error) {
+	if err = utils.EnsureDirAndMaxPerms(a.dir, 0700); err != nil {
+		return errors.Wrap(err, "failed to create archive directory")
+	}
+	name := filepath.Join(a.dir, fmt.Sprintf("pipeline_runs-%s.jsonl", time.Now().UTC().Format("2006-01-02")))
+	f, err := os.OpenFile(name, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrap(err, "failed to open archive file")
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	enc := json.NewEncoder(f)
+	for _, r := range runs {
+		if err = enc.Encode(archivedRun{
+			ID:             r.ID,
+			PipelineSpecID: r.PipelineSpecID,
+			JobID:          r.PipelineSpec.JobID,
+			JobName:        r.PipelineSpec.JobName,
+			JobType:        r.PipelineSpec.JobType,
+			Run:            r,
+		}); err != nil {
+			return errors.Wrapf(err, "failed to archive run %d", r.ID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package pipeline_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+)
+
+func TestFileRunArchiver(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "runs")
+	archiver := pipeline.NewFileRunArchiver(dir)
+
+	now := time.Now()
+	runs := []*pipeline.Run{
+		{
+			ID:             1,
+			PipelineSpecID: 10,
+			PipelineSpec:   pipeline.Spec{JobID: 100, JobName: "feed", JobType: "cron"},
+			State:          pipeline.RunStatusCompleted,
+			FinishedAt:     null.TimeFrom(now),
+			PipelineTaskRuns: []pipeline.TaskRun{
+				{Type: pipeline.TaskTypeHTTP, DotID: "ds"},
+			},
+		},
+		{
+			ID:             2,
+			PipelineSpecID: 10,
+			PipelineSpec:   pipeline.Spec{JobID: 100, JobName: "feed", JobType: "cron"},
+			State:          pipeline.RunStatusErrored,
+			FinishedAt:     null.TimeFrom(now),
+		},
+	}
+	require.NoError(t, archiver.Archive(runs[:1]))
+	require.NoError(t, archiver.Archive(runs[1:]))
+
+	files, err := filepath.Glob(filepath.Join(dir, "pipeline_runs-*.jsonl"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	f, err := os.Open(files[0])
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, f.Close()) })
+
+	type archived struct {
+		ID       int64              `json:"id"`
+		JobID    int32              `json:"jobID"`
+		JobType  string             `json:"jobType"`
+		State    pipeline.RunStatus `json:"state"`
+		TaskRuns []struct {
+			DotID string `json:"dotId"`
+		} `json:"taskRuns"`
+	}
+	var lines []archived
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var a archived
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &a))
+		lines = append(lines, a)
+	}
+	require.NoError(t, scanner.Err())
+	require.Len(t, lines, 2)
+
+	assert.Equal(t, int64(1), lines[0].ID)
+	assert.Equal(t, int32(100), lines[0].JobID)
+	assert.Equal(t, "cron", lines[0].JobType)
+	assert.Equal(t, pipeline.RunStatusCompleted, lines[0].State)
+	require.Len(t, lines[0].TaskRuns, 1)
+	assert.Equal(t, int64(2), lines[1].ID)
+	assert.Equal(t, pipeline.RunStatusErrored, lines[1].State)
+}
@@ -12,6 +12,10 @@ import (
 	pkgerrors "github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"gopkg.in/guregu/null.v4"
 
 	"github.com/smartcontractkit/chainlink/v2/core/bridges"
@@ -100,6 +104,9 @@ var (
 	)
 )
 
+// tracer is a no-op until tracing is enabled in the node config
+var tracer = otel.Tracer("github.com/smartcontractkit/chainlink/v2/core/services/pipeline")
+
 func NewRunner(orm ORM, btORM bridges.ORM, cfg Config, chainSet evm.ChainSet, ethks ETHKeyStore, vrfks VRFKeyStore, lggr logger.Logger, httpClient, unrestrictedHTTPClient *http.Client) *runner {
 	r := &runner{
 		orm:                    orm,
@@ -193,6 +200,26 @@ func (err ErrRunPanicked) Error() string {
 	return fmt.Sprintf("goroutine panicked when executing run: %v", err.v)
 }
 
+// runVars returns the vars of a run, including its secret inputs. These are
+// added to a copy of the inputs, so that they are not stored with the run, and
+// are redacted from logged request data.
+func runVars(run *Run) Vars {
+	inputs := run.Inputs.Val.(map[string]interface{})
+	if len(run.SecretInputs) == 0 {
+		return NewVarsFrom(inputs)
+	}
+	vars := make(map[string]interface{}, len(inputs)+len(run.SecretInputs))
+	for k, v := range inputs {
+		vars[k] = v
+	}
+	secrets := make([]interface{}, 0, len(run.SecretInputs))
+	for k, v := range run.SecretInputs {
+		vars[k] = v
+		secrets = append(secrets, v)
+	}
+	return Vars{vars: vars, secrets: secrets}
+}
+
 func NewRun(spec Spec, vars Vars) Run {
 	return Run{
 		State:          RunStatusRunning,
@@ -296,6 +323,14 @@ func (r *runner) run(ctx context.Context, pipeline *Pipeline, run *Run, vars Var
 	l = l.With("jobID", run.PipelineSpec.JobID, "jobName", run.PipelineSpec.JobName)
 	l.Debug("Initiating tasks for pipeline run of spec")
 
+	ctx, span := tracer.Start(ctx, "runner.run", trace.WithAttributes(
+		attribute.Int64("run.id", run.ID),
+		attribute.Int("job.id", int(run.PipelineSpec.JobID)),
+		attribute.String("job.name", run.PipelineSpec.JobName),
+		attribute.String("job.type", run.PipelineSpec.JobType),
+	))
+	defer span.End()
+
 	scheduler := newScheduler(pipeline, run, vars, l)
 	go scheduler.Run()
 
@@ -335,6 +370,7 @@ func (r *runner) run(ctx context.Context, pipeline *Pipeline, run *Run, vars Var
 	// scheduler.exiting = we had an error and the task was marked to failEarly
 	run.FailSilently = scheduler.exiting
 	run.State = RunStatusSuspended
+	span.SetAttributes(attribute.Bool("run.pending", scheduler.pending))
 
 	if !scheduler.pending {
 		run.FinishedAt = null.TimeFrom(time.Now())
@@ -389,6 +425,7 @@ func (r *runner) run(ctx context.Context, pipeline *Pipeline, run *Run, vars Var
 
 		if run.HasFatalErrors() {
 			run.State = RunStatusErrored
+			span.SetStatus(codes.Error, "run has fatal errors")
 			PromPipelineRunErrors.WithLabelValues(fmt.Sprintf("%d", run.PipelineSpec.JobID), run.PipelineSpec.JobName).Inc()
 		} else {
 			run.State = RunStatusCompleted
@@ -422,6 +459,13 @@ func (r *runner) executeTaskRun(ctx context.Context, spec Spec, taskRun *memoryT
 		"taskType", taskRun.task.Type(),
 		"attempt", taskRun.attempts)
 
+	ctx, span := tracer.Start(ctx, fmt.Sprintf("task.%s", taskRun.task.Type()), trace.WithAttributes(
+		attribute.String("task.name", taskRun.task.DotID()),
+		attribute.String("task.type", string(taskRun.task.Type())),
+		attribute.Int("task.attempt", int(taskRun.attempts)),
+	))
+	defer span.End()
+
 	// Task timeout will be whichever of the following timesout/cancels first:
 	// - Pipeline-level timeout
 	// - Specific task timeout (task.TaskTimeout)
@@ -456,6 +500,12 @@ func (r *runner) executeTaskRun(ctx context.Context, spec Spec, taskRun *memoryT
 	}
 	l.Debugw("Pipeline task completed", loggerFields...)
 
+	if result.Error != nil {
+		span.RecordError(result.Error)
+		span.SetStatus(codes.Error, result.Error.Error())
+	}
+	span.SetAttributes(attribute.Bool("task.pending", runInfo.IsPending))
+
 	now := time.Now()
 
 	var finishedAt null.Time
@@ -549,7 +599,7 @@ func (r *runner) Run(ctx context.Context, run *Run, l logger.Logger, saveSuccess
 	}
 
 	for {
-		r.run(ctx, pipeline, run, NewVarsFrom(run.Inputs.Val.(map[string]interface{})), l)
+		r.run(ctx, pipeline, run, runVars(run), l)
 
 		if preinsert {
 			// FailSilently = run failed and task was marked failEarly. skip StoreRun and instead delete all trace of it
@@ -627,7 +677,23 @@ func (r *runner) runReaper() {
 	ctx, cancel := r.chStop.CtxCancel(context.WithTimeout(context.Background(), r.config.JobPipelineReaperInterval()))
 	defer cancel()
 
-	err := r.orm.DeleteRunsOlderThan(ctx, r.config.JobPipelineReaperThreshold())
+	retentions := []RunRetention{{
+		Threshold:        r.config.JobPipelineReaperThresholdForJobType(""),
+		ErroredThreshold: r.config.JobPipelineErroredReaperThresholdForJobType(""),
+	}}
+	for _, jobType := range r.config.JobPipelineRetentionJobTypes() {
+		retentions = append(retentions, RunRetention{
+			JobType:          jobType,
+			Threshold:        r.config.JobPipelineReaperThresholdForJobType(jobType),
+			ErroredThreshold: r.config.JobPipelineErroredReaperThresholdForJobType(jobType),
+		})
+	}
+	var archiver RunArchiver
+	if dir := r.config.JobPipelineReaperArchiveDir(); dir != "" {
+		archiver = NewFileRunArchiver(dir)
+	}
+
+	err := r.orm.DeleteExpiredRuns(ctx, retentions, archiver)
 	if err != nil {
 		r.lggr.Errorw("Pipeline run reaper failed", "error", err)
 		r.SvcErrBuffer.Append(err)
@@ -150,7 +150,7 @@ func (t *BridgeTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inp
 		return Result{Error: err}, runInfo
 	}
 	lggr.Debugw("Bridge task: sending request",
-		"requestData", string(requestDataJSON),
+		"requestData", vars.Redact(string(requestDataJSON)),
 		"url", url.String(),
 	)
 
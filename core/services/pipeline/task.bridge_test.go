@@ -19,6 +19,7 @@ import (
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
 	"gopkg.in/guregu/null.v4"
 
 	"github.com/smartcontractkit/chainlink/v2/core/bridges"
@@ -708,6 +709,65 @@ func TestBridgeTask_Meta(t *testing.T) {
 	assert.True(t, httpCalled.Load())
 }
 
+func TestBridgeTask_RedactsSecrets(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+	cfg := configtest.NewTestGeneralConfig(t)
+
+	const secret = "0xdeadbeefcafe"
+	var empty adapterResponse
+
+	var httpCalled atomic.Bool
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req adapterRequest
+		body, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(body, &req))
+		// the adapter still receives the secrets
+		require.Equal(t, secret, req.Data["secrets"])
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(empty))
+		httpCalled.Store(true)
+	})
+
+	s1 := httptest.NewServer(handler)
+	defer s1.Close()
+	feedURL, err := url.ParseRequestURI(s1.URL)
+	require.NoError(t, err)
+
+	orm := bridges.NewORM(db, logger.TestLogger(t), cfg)
+	_, bridge := cltest.MustCreateBridge(t, db, cltest.BridgeOpts{URL: feedURL.String()}, cfg)
+
+	task := pipeline.BridgeTask{
+		BaseTask:    pipeline.NewBaseTask(0, "bridge", nil, nil, 0),
+		RequestData: `{"data": {"coin": $(coin), "secrets": $(secrets)}}`,
+		Name:        bridge.Name.String(),
+	}
+	c := clhttptest.NewTestLocalOnlyHTTPClient()
+	trORM := pipeline.NewORM(db, logger.TestLogger(t), cfg)
+	specID, err := trORM.CreateSpec(pipeline.Pipeline{}, *models.NewInterval(5 * time.Minute), pg.WithParentCtx(testutils.Context(t)))
+	require.NoError(t, err)
+	task.HelperSetDependencies(cfg, orm, specID, uuid.UUID{}, c)
+
+	lggr, observed := logger.TestLoggerObserved(t, zapcore.DebugLevel)
+	vars := pipeline.NewVarsWithSecrets(map[string]interface{}{"coin": "ETH"}, map[string]interface{}{"secrets": secret})
+	res, _ := task.Run(testutils.Context(t), lggr, vars, nil)
+	require.NoError(t, res.Error)
+	require.True(t, httpCalled.Load())
+
+	logs := observed.FilterMessage("Bridge task: sending request").All()
+	require.Len(t, logs, 1)
+	requestData := logs[0].ContextMap()["requestData"]
+	assert.Contains(t, requestData, `"coin":"ETH"`)
+	assert.Contains(t, requestData, `"secrets":"[redacted]"`)
+	assert.NotContains(t, requestData, secret)
+	for _, l := range observed.All() {
+		for _, v := range l.ContextMap() {
+			assert.NotContains(t, fmt.Sprint(v), secret)
+		}
+	}
+}
+
 func TestBridgeTask_IncludeInputAtKey(t *testing.T) {
 	t.Parallel()
 
@@ -1,6 +1,7 @@
 package pipeline
 
 import (
+	"encoding/json"
 	"regexp"
 	"strconv"
 	"strings"
@@ -18,6 +19,8 @@ var (
 
 type Vars struct {
 	vars map[string]interface{}
+	// secrets are the values of the run's secret inputs, see Redact
+	secrets []interface{}
 }
 
 // NewVarsFrom creates new Vars from the given map.
@@ -90,5 +93,21 @@ func (vars Vars) Copy() Vars {
 	for k, v := range vars.vars {
 		newVars[k] = v
 	}
-	return NewVarsFrom(newVars)
+	return Vars{vars: newVars, secrets: vars.secrets}
+}
+
+// Redact replaces the JSON encoding of every secret input in s, so that s can
+// be logged. s is expected to be the JSON encoding of data built from vars.
+func (vars Vars) Redact(s string) string {
+	for _, v := range vars.secrets {
+		if v == nil {
+			continue
+		}
+		b, err := json.Marshal(v)
+		if err != nil || len(b) == 0 {
+			continue
+		}
+		s = strings.ReplaceAll(s, string(b), `"[redacted]"`)
+	}
+	return s
 }
@@ -136,3 +136,20 @@ func TestVars_Copy(t *testing.T) {
 	varsCopy := vars.Copy()
 	require.Equal(t, vars, varsCopy)
 }
+
+func TestVars_Redact(t *testing.T) {
+	t.Parallel()
+
+	vars := pipeline.NewVarsWithSecrets(
+		map[string]interface{}{"foo": "bar"},
+		map[string]interface{}{"secrets": "0xabcdef", "nested": map[string]interface{}{"a": 1}, "empty": nil},
+	)
+	require.Equal(t,
+		`{"foo":"bar","nested":"[redacted]","secrets":"[redacted]"}`,
+		vars.Redact(`{"foo":"bar","nested":{"a":1},"secrets":"0xabcdef"}`),
+	)
+	// copies keep redacting
+	require.Equal(t, `{"secrets":"[redacted]"}`, vars.Copy().Redact(`{"secrets":"0xabcdef"}`))
+
+	require.Equal(t, `{"secrets":"0xabcdef"}`, pipeline.NewVarsFrom(nil).Redact(`{"secrets":"0xabcdef"}`))
+}
@@ -0,0 +1,41 @@
+package evm
+
+import (
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore"
+
+	relaytypes "github.com/smartcontractkit/chainlink-relay/pkg/types"
+)
+
+type attestationProvider struct {
+	*configWatcher
+	contractTransmitter ContractTransmitter
+}
+
+var (
+	_ relaytypes.Plugin = (*attestationProvider)(nil)
+)
+
+func (p *attestationProvider) ContractTransmitter() types.ContractTransmitter {
+	return p.contractTransmitter
+}
+
+// NewAttestationProvider returns the provider of attestation jobs, which
+// transmit their reports to an OCR2Base contract on the destination chain.
+func NewAttestationProvider(chainSet evm.ChainSet, rargs relaytypes.RelayArgs, pargs relaytypes.PluginArgs, lggr logger.Logger, ethKeystore keystore.Eth) (relaytypes.Plugin, error) {
+	configWatcher, err := newConfigProvider(lggr, chainSet, rargs)
+	if err != nil {
+		return nil, err
+	}
+	contractTransmitter, err := newContractTransmitter(lggr, rargs, pargs.TransmitterID, configWatcher, ethKeystore)
+	if err != nil {
+		return nil, err
+	}
+	return &attestationProvider{
+		configWatcher:       configWatcher,
+		contractTransmitter: contractTransmitter,
+	}, nil
+}
@@ -19,6 +19,7 @@ import (
 	"go.uber.org/multierr"
 	"golang.org/x/exp/maps"
 
+	relaymercury "github.com/smartcontractkit/chainlink-relay/pkg/reportingplugins/mercury"
 	relaytypes "github.com/smartcontractkit/chainlink-relay/pkg/types"
 
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm"
@@ -29,10 +30,14 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/ethkey"
 	mercuryconfig "github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/mercury/config"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/mercury/schema"
 	"github.com/smartcontractkit/chainlink/v2/core/services/ocrcommon"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
 	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/v2/core/services/relay/evm/mercury"
 	"github.com/smartcontractkit/chainlink/v2/core/services/relay/evm/mercury/reportcodec"
+	reportcodecv2 "github.com/smartcontractkit/chainlink/v2/core/services/relay/evm/mercury/v2/reportcodec"
+	reportcodecv3 "github.com/smartcontractkit/chainlink/v2/core/services/relay/evm/mercury/v3/reportcodec"
 	"github.com/smartcontractkit/chainlink/v2/core/services/relay/evm/mercury/wsrpc"
 	"github.com/smartcontractkit/chainlink/v2/core/services/relay/evm/types"
 	"github.com/smartcontractkit/chainlink/v2/core/utils"
@@ -41,6 +46,7 @@ import (
 var _ relaytypes.Relayer = &Relayer{}
 
 type RelayerConfig interface {
+	pg.QConfig
 }
 
 type Relayer struct {
@@ -108,7 +114,20 @@ func (r *Relayer) NewMercuryProvider(rargs relaytypes.RelayArgs, pargs relaytype
 		return nil, errors.WithStack(err)
 	}
 
-	reportCodec := reportcodec.NewEVMReportCodec(*relayConfig.FeedID, r.lggr.Named("ReportCodec"))
+	var reportCodec relaymercury.ReportCodec
+	var schemaReportCodec schema.ReportCodec
+	var sequencer mercury.ReportSequencer
+	switch mercuryConfig.GetSchemaVersion() {
+	case mercuryconfig.SchemaV2:
+		schemaReportCodec = reportcodecv2.NewReportCodec(*relayConfig.FeedID, r.lggr.Named("ReportCodec"))
+		sequencer = mercury.TimestampSequencer(schemaReportCodec)
+	case mercuryconfig.SchemaV3:
+		schemaReportCodec = reportcodecv3.NewReportCodec(*relayConfig.FeedID, mercuryConfig.ExpirationWindow, r.lggr.Named("ReportCodec"))
+		sequencer = mercury.TimestampSequencer(schemaReportCodec)
+	default:
+		reportCodec = reportcodec.NewEVMReportCodec(*relayConfig.FeedID, r.lggr.Named("ReportCodec"))
+		sequencer = mercury.BlockNumberSequencer(reportCodec)
+	}
 
 	if !relayConfig.EffectiveTransmitterID.Valid {
 		return nil, errors.New("EffectiveTransmitterID must be specified")
@@ -118,13 +137,20 @@ func (r *Relayer) NewMercuryProvider(rargs relaytypes.RelayArgs, pargs relaytype
 		return nil, errors.Wrap(err, "failed to get CSA key for mercury connection")
 	}
 
-	client, err := r.mercuryPool.Checkout(context.Background(), privKey, mercuryConfig.ServerPubKey, mercuryConfig.ServerURL())
-	if err != nil {
-		return nil, err
+	clients := make(map[string]wsrpc.Client)
+	for _, server := range mercuryConfig.GetServers() {
+		client, err := r.mercuryPool.Checkout(context.Background(), privKey, server.PubKey, server.URL)
+		if err != nil {
+			for _, c := range clients {
+				err = multierr.Append(err, c.Close())
+			}
+			return nil, err
+		}
+		clients[server.URL] = client
 	}
-	transmitter := mercury.NewTransmitter(r.lggr, configWatcher.ContractConfigTracker(), client, privKey.PublicKey, *relayConfig.FeedID)
+	transmitter := mercury.NewTransmitter(r.lggr, configWatcher.ContractConfigTracker(), clients, privKey.PublicKey, rargs.JobID, *relayConfig.FeedID, sequencer, mercury.NewORM(r.db, r.lggr, r.cfg))
 
-	return NewMercuryProvider(configWatcher, transmitter, reportCodec, r.lggr), nil
+	return NewMercuryProvider(configWatcher, transmitter, reportCodec, schemaReportCodec, r.lggr), nil
 }
 
 func (r *Relayer) NewConfigProvider(args relaytypes.RelayArgs) (relaytypes.ConfigProvider, error) {
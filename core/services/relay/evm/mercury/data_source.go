@@ -16,6 +16,7 @@ import (
 	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/mercury/schema"
 	"github.com/smartcontractkit/chainlink/v2/core/services/ocrcommon"
 	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/v2/core/utils"
@@ -47,23 +48,9 @@ func NewDataSource(pr pipeline.Runner, jb job.Job, spec pipeline.Spec, lggr logg
 }
 
 func (ds *datasource) Observe(ctx context.Context, repts ocrtypes.ReportTimestamp) (relaymercury.Observation, error) {
-	run, trrs, err := ds.executeRun(ctx)
+	trrs, finaltrrs, err := ds.run(ctx)
 	if err != nil {
-		return relaymercury.Observation{}, fmt.Errorf("Observe failed while executing run: %w", err)
-	}
-	select {
-	case ds.runResults <- run:
-	default:
-		ds.lggr.Warnf("unable to enqueue run save for job ID %d, buffer full", ds.spec.JobID)
-	}
-
-	// NOTE: trrs comes back as _all_ tasks, but we only want the terminal ones
-	// They are guaranteed to be sorted by index asc so should be in the correct order
-	var finaltrrs []pipeline.TaskRunResult
-	for _, trr := range trrs {
-		if trr.IsTerminal() {
-			finaltrrs = append(finaltrrs, trr)
-		}
+		return relaymercury.Observation{}, err
 	}
 
 	parsed, err := ds.parse(finaltrrs)
@@ -84,6 +71,29 @@ func (ds *datasource) Observe(ctx context.Context, repts ocrtypes.ReportTimestam
 	return parsed, nil
 }
 
+// run executes the pipeline and queues the run to be saved. It returns all the
+// task run results, and the terminal ones.
+func (ds *datasource) run(ctx context.Context) (trrs pipeline.TaskRunResults, finaltrrs pipeline.TaskRunResults, err error) {
+	run, trrs, err := ds.executeRun(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Observe failed while executing run: %w", err)
+	}
+	select {
+	case ds.runResults <- run:
+	default:
+		ds.lggr.Warnf("unable to enqueue run save for job ID %d, buffer full", ds.spec.JobID)
+	}
+
+	// NOTE: trrs comes back as _all_ tasks, but we only want the terminal ones
+	// They are guaranteed to be sorted by index asc so should be in the correct order
+	for _, trr := range trrs {
+		if trr.IsTerminal() {
+			finaltrrs = append(finaltrrs, trr)
+		}
+	}
+	return trrs, finaltrrs, nil
+}
+
 func toBigInt(val interface{}) (*big.Int, error) {
 	dec, err := utils.ToDecimal(val)
 	if err != nil {
@@ -154,6 +164,53 @@ func setAsk(obs *relaymercury.Observation, res pipeline.Result) error {
 	return nil
 }
 
+// schemaDatasource observes the fields of a timestamped report schema. It
+// does not need the head of the chain.
+type schemaDatasource struct {
+	*datasource
+	schema schema.Schema
+}
+
+var _ schema.DataSource = &schemaDatasource{}
+
+func NewSchemaDataSource(pr pipeline.Runner, jb job.Job, spec pipeline.Spec, lggr logger.Logger, rr chan pipeline.Run, s schema.Schema) *schemaDatasource {
+	return &schemaDatasource{NewDataSource(pr, jb, spec, lggr, rr, nil, nil), s}
+}
+
+func (ds *schemaDatasource) Observe(ctx context.Context, repts ocrtypes.ReportTimestamp) (schema.Observation, error) {
+	_, finaltrrs, err := ds.run(ctx)
+	if err != nil {
+		return schema.Observation{}, err
+	}
+	parsed, err := ds.parse(finaltrrs)
+	if err != nil {
+		return schema.Observation{}, fmt.Errorf("Observe failed while parsing run results: %w", err)
+	}
+	return parsed, nil
+}
+
+// parse expects the output of observe to be one value per field of the
+// schema, in order.
+//
+// returns error on parse errors: if something is the wrong type
+func (ds *schemaDatasource) parse(trrs pipeline.TaskRunResults) (obs schema.Observation, merr error) {
+	if len(trrs) != len(ds.schema.Fields) {
+		return obs, fmt.Errorf("invalid number of results, expected: %d, got: %d", len(ds.schema.Fields), len(trrs))
+	}
+	obs.Values = make([]relaymercury.ObsResult[*big.Int], len(trrs))
+	for i, field := range ds.schema.Fields {
+		res := trrs[i].Result
+		if res.Error != nil {
+			obs.Values[i].Err = res.Error
+		} else if val, err := toBigInt(res.Value); err != nil {
+			merr = errors.Join(merr, fmt.Errorf("failed to parse %s: %w", field.Name, err))
+		} else {
+			obs.Values[i].Val = val
+		}
+	}
+	return obs, merr
+}
+
 // The context passed in here has a timeout of (ObservationTimeout + ObservationGracePeriod).
 // Upon context cancellation, its expected that we return any usable values within ObservationGracePeriod.
 func (ds *datasource) executeRun(ctx context.Context) (pipeline.Run, pipeline.TaskRunResults, error) {
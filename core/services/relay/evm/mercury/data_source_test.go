@@ -9,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	relaymercury "github.com/smartcontractkit/chainlink-relay/pkg/reportingplugins/mercury"
 	"github.com/smartcontractkit/chainlink/v2/core/assets"
@@ -17,6 +18,8 @@ import (
 	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/mercury/schema"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 	mercurymocks "github.com/smartcontractkit/chainlink/v2/core/services/relay/evm/mercury/mocks"
 	"github.com/smartcontractkit/chainlink/v2/core/utils"
 )
@@ -109,3 +112,45 @@ func TestMercurySetCurrentBlock(t *testing.T) {
 		headTracker.AssertExpectations(t)
 	})
 }
+
+func TestMercurySchemaDataSourceParse(t *testing.T) {
+	ds := schemaDatasource{&datasource{lggr: logger.TestLogger(t)}, schema.V3}
+	result := func(v interface{}, err error) pipeline.TaskRunResult {
+		return pipeline.TaskRunResult{Result: pipeline.Result{Value: v, Error: err}}
+	}
+
+	t.Run("parses one value per field", func(t *testing.T) {
+		errFee := errors.New("fee unavailable")
+		obs, err := ds.parse(pipeline.TaskRunResults{
+			result("100", nil),
+			result(99, nil),
+			result(101.0, nil),
+			result(nil, errFee),
+			result("2", nil),
+		})
+		require.NoError(t, err)
+		require.Len(t, obs.Values, 5)
+		assert.Equal(t, big.NewInt(100), obs.Values[0].Val)
+		assert.Equal(t, big.NewInt(99), obs.Values[1].Val)
+		assert.Equal(t, big.NewInt(101), obs.Values[2].Val)
+		assert.Equal(t, errFee, obs.Values[3].Err)
+		assert.Equal(t, big.NewInt(2), obs.Values[4].Val)
+	})
+
+	t.Run("errors on wrong number of results", func(t *testing.T) {
+		_, err := ds.parse(pipeline.TaskRunResults{result("100", nil)})
+		require.EqualError(t, err, "invalid number of results, expected: 5, got: 1")
+	})
+
+	t.Run("errors on unparseable values", func(t *testing.T) {
+		_, err := ds.parse(pipeline.TaskRunResults{
+			result("100", nil),
+			result(99, nil),
+			result(101, nil),
+			result("foo", nil),
+			result("2", nil),
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to parse nativeFee")
+	})
+}
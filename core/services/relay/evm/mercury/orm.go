@@ -0,0 +1,107 @@
+package mercury
+
+import (
+	"crypto/sha256"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/sqlx"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+	"github.com/smartcontractkit/chainlink/v2/core/services/relay/evm/mercury/wsrpc/pb"
+)
+
+// ORM persists the transmit requests which have not been sent to the Mercury
+// servers yet, so that they survive restarts.
+type ORM interface {
+	// InsertTransmitRequest persists a transmit request of a job for each of
+	// the given servers. Inserting the same payload twice is a no-op.
+	InsertTransmitRequest(serverURLs []string, jobID int32, req *pb.TransmitRequest, reportCtx ocrtypes.ReportContext, qopts ...pg.QOpt) error
+	// DeleteTransmitRequests deletes the transmit requests of a server by
+	// payload.
+	DeleteTransmitRequests(serverURL string, reqs []*pb.TransmitRequest, qopts ...pg.QOpt) error
+	// GetTransmitRequests returns the transmit requests of a job for a
+	// server, oldest report first.
+	GetTransmitRequests(serverURL string, jobID int32, qopts ...pg.QOpt) ([]*Transmission, error)
+}
+
+// Transmission is a transmit request queued for the Mercury server.
+type Transmission struct {
+	Req       *pb.TransmitRequest
+	ReportCtx ocrtypes.ReportContext
+	CreatedAt time.Time
+}
+
+type orm struct {
+	q pg.Q
+}
+
+var _ ORM = (*orm)(nil)
+
+// NewORM returns an ORM for the Mercury transmit requests.
+func NewORM(db *sqlx.DB, lggr logger.Logger, cfg pg.QConfig) ORM {
+	return &orm{q: pg.NewQ(db, lggr.Named("MercuryORM"), cfg)}
+}
+
+func (o *orm) InsertTransmitRequest(serverURLs []string, jobID int32, req *pb.TransmitRequest, reportCtx ocrtypes.ReportContext, qopts ...pg.QOpt) error {
+	hash := hashPayload(req.Payload)
+	err := o.q.WithOpts(qopts...).ExecQ(`
+		INSERT INTO mercury_transmit_requests (server_url, payload_hash, job_id, payload, config_digest, epoch, round, extra_hash, created_at)
+		SELECT server_url, $2, $3, $4, $5, $6, $7, $8, NOW() FROM UNNEST($1::text[]) AS server_url
+		ON CONFLICT (server_url, payload_hash) DO NOTHING
+	`, pq.StringArray(serverURLs), hash[:], jobID, req.Payload, reportCtx.ConfigDigest[:], reportCtx.Epoch, reportCtx.Round, reportCtx.ExtraHash[:])
+	return errors.Wrap(err, "failed to insert mercury transmit request")
+}
+
+func (o *orm) DeleteTransmitRequests(serverURL string, reqs []*pb.TransmitRequest, qopts ...pg.QOpt) error {
+	if len(reqs) == 0 {
+		return nil
+	}
+	hashes := make(pq.ByteaArray, len(reqs))
+	for i, req := range reqs {
+		hash := hashPayload(req.Payload)
+		hashes[i] = hash[:]
+	}
+	err := o.q.WithOpts(qopts...).ExecQ(`DELETE FROM mercury_transmit_requests WHERE server_url = $1 AND payload_hash = ANY($2)`, serverURL, hashes)
+	return errors.Wrap(err, "failed to delete mercury transmit requests")
+}
+
+func (o *orm) GetTransmitRequests(serverURL string, jobID int32, qopts ...pg.QOpt) ([]*Transmission, error) {
+	var rows []struct {
+		Payload      []byte
+		ConfigDigest []byte
+		Epoch        int64
+		Round        int64
+		ExtraHash    []byte
+		CreatedAt    time.Time
+	}
+	err := o.q.WithOpts(qopts...).Select(&rows, `
+		SELECT payload, config_digest, epoch, round, extra_hash, created_at
+		FROM mercury_transmit_requests
+		WHERE job_id = $1 AND server_url = $2
+		ORDER BY epoch ASC, round ASC
+	`, jobID, serverURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get mercury transmit requests")
+	}
+
+	transmissions := make([]*Transmission, len(rows))
+	for i, row := range rows {
+		t := &Transmission{Req: &pb.TransmitRequest{Payload: row.Payload}, CreatedAt: row.CreatedAt}
+		if t.ReportCtx.ConfigDigest, err = ocrtypes.BytesToConfigDigest(row.ConfigDigest); err != nil {
+			return nil, errors.Wrap(err, "invalid config digest")
+		}
+		t.ReportCtx.Epoch = uint32(row.Epoch)
+		t.ReportCtx.Round = uint8(row.Round)
+		copy(t.ReportCtx.ExtraHash[:], row.ExtraHash)
+		transmissions[i] = t
+	}
+	return transmissions, nil
+}
+
+func hashPayload(payload []byte) [32]byte {
+	return sha256.Sum256(payload)
+}
@@ -0,0 +1,72 @@
+package mercury_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/relay/evm/mercury"
+	"github.com/smartcontractkit/chainlink/v2/core/services/relay/evm/mercury/wsrpc/pb"
+)
+
+func TestORM(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	lggr := logger.TestLogger(t)
+	orm := mercury.NewORM(db, lggr, pgtest.NewQConfig(true))
+	jb, _ := cltest.MustInsertWebhookSpec(t, db)
+	otherJob, _ := cltest.MustInsertWebhookSpec(t, db)
+
+	reportCtx := func(epoch uint32, round uint8) ocrtypes.ReportContext {
+		return ocrtypes.ReportContext{
+			ReportTimestamp: ocrtypes.ReportTimestamp{ConfigDigest: ocrtypes.ConfigDigest{1}, Epoch: epoch, Round: round},
+			ExtraHash:       [32]byte{2},
+		}
+	}
+	reqs := []*pb.TransmitRequest{{Payload: []byte("a")}, {Payload: []byte("b")}, {Payload: []byte("c")}}
+	servers := []string{"mercury.example.com", "mercury2.example.com"}
+
+	transmissions, err := orm.GetTransmitRequests(servers[0], jb.ID)
+	require.NoError(t, err)
+	assert.Empty(t, transmissions)
+
+	require.NoError(t, orm.InsertTransmitRequest(servers, jb.ID, reqs[0], reportCtx(2, 1)))
+	require.NoError(t, orm.InsertTransmitRequest(servers, jb.ID, reqs[1], reportCtx(1, 3)))
+	require.NoError(t, orm.InsertTransmitRequest(servers, otherJob.ID, reqs[2], reportCtx(1, 1)))
+	// inserting the same payload again is a no-op
+	require.NoError(t, orm.InsertTransmitRequest(servers, jb.ID, reqs[0], reportCtx(2, 1)))
+
+	for _, server := range servers {
+		transmissions, err = orm.GetTransmitRequests(server, jb.ID)
+		require.NoError(t, err)
+		require.Len(t, transmissions, 2)
+		assert.Equal(t, reqs[1].Payload, transmissions[0].Req.Payload)
+		assert.Equal(t, reportCtx(1, 3), transmissions[0].ReportCtx)
+		assert.Equal(t, reqs[0].Payload, transmissions[1].Req.Payload)
+		assert.Equal(t, reportCtx(2, 1), transmissions[1].ReportCtx)
+	}
+
+	require.NoError(t, orm.DeleteTransmitRequests(servers[0], []*pb.TransmitRequest{reqs[0], reqs[2]}))
+
+	transmissions, err = orm.GetTransmitRequests(servers[0], jb.ID)
+	require.NoError(t, err)
+	require.Len(t, transmissions, 1)
+	assert.Equal(t, reqs[1].Payload, transmissions[0].Req.Payload)
+
+	// deleting from a server keeps the requests of the other servers
+	transmissions, err = orm.GetTransmitRequests(servers[1], jb.ID)
+	require.NoError(t, err)
+	assert.Len(t, transmissions, 2)
+
+	transmissions, err = orm.GetTransmitRequests(servers[0], otherJob.ID)
+	require.NoError(t, err)
+	assert.Empty(t, transmissions)
+	transmissions, err = orm.GetTransmitRequests(servers[1], otherJob.ID)
+	require.NoError(t, err)
+	assert.Len(t, transmissions, 1)
+}
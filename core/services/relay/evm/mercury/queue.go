@@ -0,0 +1,94 @@
+package mercury
+
+import (
+	"container/heap"
+	"sync"
+)
+
+// transmitQueue is a bounded priority queue of transmissions, ordered by
+// report context so that the reports of a feed are transmitted in the order
+// they were generated. When full, pushing evicts the oldest report.
+type transmitQueue struct {
+	cond   sync.Cond
+	mu     sync.Mutex
+	pq     priorityQueue
+	maxlen int
+	closed bool
+}
+
+func newTransmitQueue(maxlen int) *transmitQueue {
+	tq := &transmitQueue{maxlen: maxlen}
+	tq.cond.L = &tq.mu
+	return tq
+}
+
+// Push adds t to the queue, and returns the transmission evicted to make
+// room for it, if any. Pushing to a closed queue is a no-op.
+func (tq *transmitQueue) Push(t *Transmission) (evicted *Transmission) {
+	tq.mu.Lock()
+	defer tq.mu.Unlock()
+	if tq.closed {
+		return nil
+	}
+	heap.Push(&tq.pq, t)
+	if tq.maxlen > 0 && tq.pq.Len() > tq.maxlen {
+		evicted = heap.Pop(&tq.pq).(*Transmission)
+	}
+	tq.cond.Signal()
+	return
+}
+
+// BlockingPop removes and returns the oldest transmission, waiting for one if
+// the queue is empty. It returns nil once the queue is closed.
+func (tq *transmitQueue) BlockingPop() *Transmission {
+	tq.mu.Lock()
+	defer tq.mu.Unlock()
+	for tq.pq.Len() == 0 && !tq.closed {
+		tq.cond.Wait()
+	}
+	if tq.closed {
+		return nil
+	}
+	return heap.Pop(&tq.pq).(*Transmission)
+}
+
+// Len returns the number of transmissions in the queue.
+func (tq *transmitQueue) Len() int {
+	tq.mu.Lock()
+	defer tq.mu.Unlock()
+	return tq.pq.Len()
+}
+
+// Close wakes up any waiting BlockingPop. The transmissions left in the queue
+// are not removed from the database, and are loaded again on restart.
+func (tq *transmitQueue) Close() {
+	tq.mu.Lock()
+	defer tq.mu.Unlock()
+	tq.closed = true
+	tq.cond.Broadcast()
+}
+
+// priorityQueue implements heap.Interface, with the oldest report first.
+type priorityQueue []*Transmission
+
+func (pq priorityQueue) Len() int { return len(pq) }
+
+func (pq priorityQueue) Less(i, j int) bool {
+	if pq[i].ReportCtx.Epoch != pq[j].ReportCtx.Epoch {
+		return pq[i].ReportCtx.Epoch < pq[j].ReportCtx.Epoch
+	}
+	return pq[i].ReportCtx.Round < pq[j].ReportCtx.Round
+}
+
+func (pq priorityQueue) Swap(i, j int) { pq[i], pq[j] = pq[j], pq[i] }
+
+func (pq *priorityQueue) Push(x any) { *pq = append(*pq, x.(*Transmission)) }
+
+func (pq *priorityQueue) Pop() any {
+	old := *pq
+	n := len(old)
+	t := old[n-1]
+	old[n-1] = nil
+	*pq = old[:n-1]
+	return t
+}
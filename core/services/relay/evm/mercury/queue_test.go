@@ -0,0 +1,84 @@
+package mercury
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/services/relay/evm/mercury/wsrpc/pb"
+)
+
+func newTestTransmission(epoch uint32, round uint8) *Transmission {
+	return &Transmission{
+		Req:       &pb.TransmitRequest{Payload: []byte{byte(epoch), round}},
+		ReportCtx: ocrtypes.ReportContext{ReportTimestamp: ocrtypes.ReportTimestamp{Epoch: epoch, Round: round}},
+		CreatedAt: time.Now(),
+	}
+}
+
+func Test_TransmitQueue(t *testing.T) {
+	t.Parallel()
+
+	t.Run("pops oldest report first", func(t *testing.T) {
+		tq := newTransmitQueue(10)
+		tq.Push(newTestTransmission(2, 1))
+		tq.Push(newTestTransmission(1, 5))
+		tq.Push(newTestTransmission(2, 0))
+		tq.Push(newTestTransmission(1, 2))
+		require.Equal(t, 4, tq.Len())
+
+		var got []ocrtypes.ReportTimestamp
+		for tq.Len() > 0 {
+			got = append(got, tq.BlockingPop().ReportCtx.ReportTimestamp)
+		}
+		assert.Equal(t, []ocrtypes.ReportTimestamp{{Epoch: 1, Round: 2}, {Epoch: 1, Round: 5}, {Epoch: 2, Round: 0}, {Epoch: 2, Round: 1}}, got)
+	})
+
+	t.Run("evicts oldest report when full", func(t *testing.T) {
+		tq := newTransmitQueue(2)
+		assert.Nil(t, tq.Push(newTestTransmission(2, 0)))
+		assert.Nil(t, tq.Push(newTestTransmission(3, 0)))
+		evicted := tq.Push(newTestTransmission(1, 0))
+		require.NotNil(t, evicted)
+		assert.Equal(t, uint32(1), evicted.ReportCtx.Epoch)
+		evicted = tq.Push(newTestTransmission(4, 0))
+		require.NotNil(t, evicted)
+		assert.Equal(t, uint32(2), evicted.ReportCtx.Epoch)
+		assert.Equal(t, 2, tq.Len())
+	})
+
+	t.Run("BlockingPop waits for a report", func(t *testing.T) {
+		tq := newTransmitQueue(10)
+		popped := make(chan *Transmission)
+		go func() { popped <- tq.BlockingPop() }()
+
+		tq.Push(newTestTransmission(1, 1))
+		select {
+		case tr := <-popped:
+			assert.Equal(t, uint32(1), tr.ReportCtx.Epoch)
+		case <-time.After(testutils.WaitTimeout(t)):
+			t.Fatal("timed out waiting for pop")
+		}
+	})
+
+	t.Run("Close unblocks BlockingPop", func(t *testing.T) {
+		tq := newTransmitQueue(10)
+		popped := make(chan *Transmission)
+		go func() { popped <- tq.BlockingPop() }()
+
+		tq.Close()
+		select {
+		case tr := <-popped:
+			assert.Nil(t, tr)
+		case <-time.After(testutils.WaitTimeout(t)):
+			t.Fatal("timed out waiting for pop")
+		}
+		assert.Nil(t, tq.Push(newTestTransmission(1, 1)))
+		assert.Equal(t, 0, tq.Len())
+	})
+}
@@ -0,0 +1,243 @@
+package mercury
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jpillora/backoff"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+	"github.com/smartcontractkit/chainlink/v2/core/services/relay/evm/mercury/wsrpc"
+	"github.com/smartcontractkit/chainlink/v2/core/services/relay/evm/mercury/wsrpc/pb"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+// server transmits the reports of a feed to one Mercury server, with its own
+// connection and queue, so that a failing server does not hold up the others.
+type server struct {
+	lggr      logger.Logger
+	url       string
+	c         wsrpc.Client
+	orm       ORM
+	sequencer ReportSequencer
+	queue     *transmitQueue
+
+	jobID     int32
+	feedID    [32]byte
+	feedIDHex string
+
+	// maxFinalized is the position of the latest report of the feed known to
+	// the server, or -1 if unknown. Queued reports up to it are stale.
+	maxFinalized atomic.Int64
+	// lastErr is the error of the last failed attempt to transmit, if the
+	// server has not accepted a report since
+	lastErr atomic.Pointer[error]
+
+	queueDepth       prometheus.Gauge
+	queueAge         prometheus.Observer
+	successCount     prometheus.Counter
+	connectionErrors prometheus.Counter
+}
+
+func newServer(lggr logger.Logger, url string, c wsrpc.Client, orm ORM, sequencer ReportSequencer, jobID int32, feedID [32]byte, feedIDHex string) *server {
+	s := &server{
+		lggr:             lggr.With("serverURL", url),
+		url:              url,
+		c:                c,
+		orm:              orm,
+		sequencer:        sequencer,
+		queue:            newTransmitQueue(maxTransmitQueueSize),
+		jobID:            jobID,
+		feedID:           feedID,
+		feedIDHex:        feedIDHex,
+		queueDepth:       transmitQueueDepth.WithLabelValues(feedIDHex, url),
+		queueAge:         transmitQueueAge.WithLabelValues(feedIDHex, url),
+		successCount:     transmitSuccessCount.WithLabelValues(feedIDHex, url),
+		connectionErrors: transmitConnectionErrorCount.WithLabelValues(feedIDHex, url),
+	}
+	s.maxFinalized.Store(-1)
+	return s
+}
+
+// load queues the reports persisted for the server.
+func (s *server) load(ctx context.Context) error {
+	transmissions, err := s.orm.GetTransmitRequests(s.url, s.jobID, pg.WithParentCtx(ctx))
+	if err != nil {
+		return err
+	}
+	if len(transmissions) > 0 {
+		s.lggr.Infow("Loaded reports pending transmission", "count", len(transmissions))
+	}
+	for _, t := range transmissions {
+		s.push(ctx, t)
+	}
+	return nil
+}
+
+// health returns the error of the last failed attempt to transmit, if any.
+func (s *server) health() error {
+	if err := s.lastErr.Load(); err != nil {
+		return *err
+	}
+	return nil
+}
+
+// push queues t, deleting the report it evicts, if any.
+func (s *server) push(ctx context.Context, t *Transmission) {
+	if evicted := s.queue.Push(t); evicted != nil {
+		s.lggr.Warnw("Transmit queue is full; dropping oldest report", "reportCtx", evicted.ReportCtx, "maxSize", maxTransmitQueueSize)
+		s.drop(ctx, evicted, dropReasonEvicted)
+	}
+	s.queueDepth.Set(float64(s.queue.Len()))
+}
+
+// drop deletes a report which will not be transmitted.
+func (s *server) drop(ctx context.Context, t *Transmission, reason dropReason) {
+	transmitDropCount.WithLabelValues(s.feedIDHex, s.url, string(reason)).Inc()
+	s.delete(ctx, t)
+}
+
+func (s *server) delete(ctx context.Context, t *Transmission) {
+	s.queueAge.Observe(time.Since(t.CreatedAt).Seconds())
+	if err := s.orm.DeleteTransmitRequests(s.url, []*pb.TransmitRequest{t.Req}, pg.WithParentCtx(ctx)); err != nil {
+		s.lggr.Errorw("Failed to delete transmit request", "reportCtx", t.ReportCtx, "err", err)
+	}
+}
+
+// runloop sends the queued reports in order, until chStop is closed.
+func (s *server) runloop(chStop utils.StopChan, wg *sync.WaitGroup) {
+	defer wg.Done()
+	ctx, cancel := chStop.NewCtx()
+	defer cancel()
+
+	b := backoff.Backoff{
+		Min:    transmitRetryMin,
+		Max:    transmitRetryMax,
+		Factor: 2,
+		Jitter: true,
+	}
+	for {
+		t := s.queue.BlockingPop()
+		if t == nil {
+			// queue closed
+			return
+		}
+		s.queueDepth.Set(float64(s.queue.Len()))
+
+		if s.isStale(t) {
+			s.lggr.Debugw("Dropping stale report", "reportCtx", t.ReportCtx, "maxFinalized", s.maxFinalized.Load())
+			s.drop(ctx, t, dropReasonStale)
+			continue
+		}
+
+		res, err := s.transmit(ctx, t.Req)
+		if ctx.Err() != nil {
+			// closed while transmitting; the report is loaded again on restart
+			return
+		}
+		if err != nil {
+			s.connectionErrors.Inc()
+			err = errors.Wrap(err, "Transmit report to Mercury server failed")
+			s.lastErr.Store(&err)
+			s.lggr.Warnw("Transmit report to Mercury server failed; retrying", "reportCtx", t.ReportCtx, "err", err)
+			s.push(ctx, t)
+			select {
+			case <-time.After(b.Duration()):
+				continue
+			case <-chStop:
+				return
+			}
+		}
+		b.Reset()
+		s.lastErr.Store(nil)
+
+		if res.Error == "" {
+			s.successCount.Inc()
+			s.lggr.Debugw("Transmit report success", "response", res, "reportCtx", t.ReportCtx)
+			if seq, err := s.sequence(t); err == nil {
+				s.setMaxFinalized(seq)
+			}
+			s.delete(ctx, t)
+		} else {
+			s.lggr.Errorw("Transmit report failed; mercury server returned error", "response", res, "reportCtx", t.ReportCtx, "err", res.Error)
+			s.drop(ctx, t, dropReasonRejected)
+		}
+	}
+}
+
+func (s *server) transmit(ctx context.Context, req *pb.TransmitRequest) (*pb.TransmitResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, transmitTimeout)
+	defer cancel()
+	return s.c.Transmit(ctx, req)
+}
+
+// latestReport returns the latest report of the feed known to the server,
+// which is nil for a new feed. op names the operation in errors.
+func (s *server) latestReport(ctx context.Context, op string) (*pb.Report, error) {
+	req := &pb.LatestReportRequest{
+		FeedId: s.feedID[:],
+	}
+	resp, err := s.c.LatestReport(ctx, req)
+	if err != nil {
+		s.lggr.Errorw(op+" failed", "err", err)
+		return nil, errors.Wrapf(err, "%s failed to fetch LatestReport", op)
+	}
+	if resp == nil {
+		return nil, errors.Errorf("%s expected LatestReport to return non-nil response", op)
+	}
+	if resp.Error != "" {
+		err = errors.New(resp.Error)
+		s.lggr.Errorw(op+" failed; mercury server returned error", "err", err)
+		return nil, err
+	}
+	if resp.Report != nil && !bytes.Equal(resp.Report.FeedId, s.feedID[:]) {
+		return nil, errors.Errorf("%s failed; mismatched feed IDs, expected: 0x%x, got: 0x%x", op, s.feedID, resp.Report.FeedId)
+	}
+	return resp.Report, nil
+}
+
+// isStale returns true if the server already has a report of the feed at or
+// after t.
+func (s *server) isStale(t *Transmission) bool {
+	max := s.maxFinalized.Load()
+	if max < 0 {
+		return false
+	}
+	seq, err := s.sequence(t)
+	if err != nil {
+		s.lggr.Errorw("Failed to decode position of queued report", "reportCtx", t.ReportCtx, "err", err)
+		return false
+	}
+	return seq <= max
+}
+
+func (s *server) sequence(t *Transmission) (int64, error) {
+	values, err := PayloadTypes.Unpack(t.Req.Payload)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to decode payload")
+	}
+	report, ok := values[1].([]byte)
+	if !ok {
+		return 0, errors.Errorf("expected report to be bytes, got: %T", values[1])
+	}
+	return s.sequencer.SequenceFromReport(report)
+}
+
+func (s *server) setMaxFinalized(seq int64) {
+	for {
+		max := s.maxFinalized.Load()
+		if seq <= max || s.maxFinalized.CompareAndSwap(max, seq) {
+			return
+		}
+	}
+}
+
+func (s *server) deleteMetrics() {
+	transmitQueueDepth.DeleteLabelValues(s.feedIDHex, s.url)
+}
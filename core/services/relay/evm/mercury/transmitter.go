@@ -1,22 +1,72 @@
 package mercury
 
 import (
-	"bytes"
 	"context"
 	"crypto/ed25519"
 	"fmt"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/smartcontractkit/libocr/offchainreporting2/chains/evmutil"
 	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"go.uber.org/multierr"
+	"golang.org/x/exp/maps"
 
 	relaymercury "github.com/smartcontractkit/chainlink-relay/pkg/reportingplugins/mercury"
 
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/mercury/schema"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
 	"github.com/smartcontractkit/chainlink/v2/core/services/relay/evm/mercury/wsrpc"
 	"github.com/smartcontractkit/chainlink/v2/core/services/relay/evm/mercury/wsrpc/pb"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+const (
+	// maxTransmitQueueSize is the number of reports of a feed kept for
+	// transmission to a server; beyond it the oldest reports are dropped
+	maxTransmitQueueSize = 10_000
+	transmitTimeout      = 5 * time.Second
+	transmitRetryMin     = 100 * time.Millisecond
+	transmitRetryMax     = 30 * time.Second
+)
+
+type dropReason string
+
+const (
+	dropReasonEvicted  dropReason = "evicted"
+	dropReasonStale    dropReason = "stale"
+	dropReasonRejected dropReason = "rejected"
+)
+
+var (
+	transmitQueueDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "mercury_transmit_queue_depth",
+		Help: "Number of reports waiting to be transmitted to the Mercury server",
+	}, []string{"feedID", "serverURL"})
+	transmitQueueAge = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "mercury_transmit_queue_report_age_seconds",
+		Help:    "Time reports spent in the transmit queue before being sent to the Mercury server or dropped",
+		Buckets: []float64{0.1, 0.5, 1, 5, 10, 30, 60, 300, 1800, 3600},
+	}, []string{"feedID", "serverURL"})
+	transmitDropCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "mercury_transmit_dropped_count",
+		Help: "Number of reports dropped without being accepted by the Mercury server",
+	}, []string{"feedID", "serverURL", "reason"})
+	transmitSuccessCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "mercury_transmit_success_count",
+		Help: "Number of reports accepted by the Mercury server",
+	}, []string{"feedID", "serverURL"})
+	transmitConnectionErrorCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "mercury_transmit_connection_error_count",
+		Help: "Number of failed attempts to send reports to the Mercury server, which are retried",
+	}, []string{"feedID", "serverURL"})
 )
 
 type Transmitter interface {
@@ -24,19 +74,76 @@ type Transmitter interface {
 	services.ServiceCtx
 }
 
+// ReportSequencer orders the reports of a feed, so that queued reports older
+// than the latest report a server has are dropped rather than transmitted.
+type ReportSequencer interface {
+	// SequenceFromReport returns the position of a report of the feed.
+	SequenceFromReport(ocrtypes.Report) (int64, error)
+	// SequenceFromLatestReport returns the position of the latest report of
+	// the feed known to a server.
+	SequenceFromLatestReport(*pb.Report) int64
+}
+
+// BlockNumberSequencer orders version 1 reports by their current block number.
+func BlockNumberSequencer(codec relaymercury.ReportCodec) ReportSequencer {
+	return blockNumberSequencer{codec}
+}
+
+type blockNumberSequencer struct {
+	codec relaymercury.ReportCodec
+}
+
+func (s blockNumberSequencer) SequenceFromReport(report ocrtypes.Report) (int64, error) {
+	return s.codec.CurrentBlockNumFromReport(report)
+}
+
+func (s blockNumberSequencer) SequenceFromLatestReport(report *pb.Report) int64 {
+	return report.CurrentBlockNumber
+}
+
+// TimestampSequencer orders timestamped reports by their observations
+// timestamp.
+func TimestampSequencer(codec schema.ReportCodec) ReportSequencer {
+	return timestampSequencer{codec}
+}
+
+type timestampSequencer struct {
+	codec schema.ReportCodec
+}
+
+func (s timestampSequencer) SequenceFromReport(report ocrtypes.Report) (int64, error) {
+	ts, err := s.codec.ObservationsTimestampFromReport(report)
+	return int64(ts), err
+}
+
+func (s timestampSequencer) SequenceFromLatestReport(report *pb.Report) int64 {
+	return report.ObservationsTimestamp
+}
+
 type ConfigTracker interface {
 	LatestConfigDetails(ctx context.Context) (changedInBlock uint64, configDigest ocrtypes.ConfigDigest, err error)
 }
 
 var _ Transmitter = &mercuryTransmitter{}
+var _ schema.Fetcher = &mercuryTransmitter{}
 
+// mercuryTransmitter persists each report to transmit, and sends the queued
+// reports of its feed in order to each of its Mercury servers, retrying with
+// backoff while a server is unreachable.
 type mercuryTransmitter struct {
+	utils.StartStopOnce
 	lggr       logger.Logger
-	rpcClient  wsrpc.Client
 	cfgTracker ConfigTracker
+	orm        ORM
+	servers    []*server
+	serverURLs []string
 
+	jobID       int32
 	feedID      [32]byte
 	fromAccount string
+
+	chStop utils.StopChan
+	wg     sync.WaitGroup
 }
 
 var PayloadTypes = getPayloadTypes()
@@ -58,22 +165,93 @@ func getPayloadTypes() abi.Arguments {
 	})
 }
 
-func NewTransmitter(lggr logger.Logger, cfgTracker ConfigTracker, rpcClient wsrpc.Client, fromAccount ed25519.PublicKey, feedID [32]byte) *mercuryTransmitter {
-	return &mercuryTransmitter{lggr.Named("MercuryTransmitter"), rpcClient, cfgTracker, feedID, fmt.Sprintf("%x", fromAccount)}
+// NewTransmitter returns a Transmitter sending the reports of a feed to each
+// server of clients, which maps server URLs to their clients. sequencer orders
+// the reports according to their schema.
+func NewTransmitter(lggr logger.Logger, cfgTracker ConfigTracker, clients map[string]wsrpc.Client, fromAccount ed25519.PublicKey, jobID int32, feedID [32]byte, sequencer ReportSequencer, orm ORM) *mercuryTransmitter {
+	feedIDHex := fmt.Sprintf("0x%x", feedID[:])
+	lggr = lggr.Named("MercuryTransmitter").With("feedID", feedIDHex)
+	serverURLs := maps.Keys(clients)
+	sort.Strings(serverURLs)
+	servers := make([]*server, len(serverURLs))
+	for i, url := range serverURLs {
+		servers[i] = newServer(lggr, url, clients[url], orm, sequencer, jobID, feedID, feedIDHex)
+	}
+	return &mercuryTransmitter{
+		lggr:        lggr,
+		cfgTracker:  cfgTracker,
+		orm:         orm,
+		servers:     servers,
+		serverURLs:  serverURLs,
+		jobID:       jobID,
+		feedID:      feedID,
+		fromAccount: fmt.Sprintf("%x", fromAccount),
+		chStop:      make(chan struct{}),
+	}
+}
+
+func (mt *mercuryTransmitter) Start(ctx context.Context) error {
+	return mt.StartOnce("MercuryTransmitter", func() error {
+		for _, s := range mt.servers {
+			if err := s.c.Start(ctx); err != nil {
+				return err
+			}
+			if err := s.load(ctx); err != nil {
+				return err
+			}
+		}
+		for _, s := range mt.servers {
+			mt.wg.Add(1)
+			go s.runloop(mt.chStop, &mt.wg)
+		}
+		return nil
+	})
+}
+
+func (mt *mercuryTransmitter) Close() error {
+	return mt.StopOnce("MercuryTransmitter", func() (merr error) {
+		for _, s := range mt.servers {
+			s.queue.Close()
+		}
+		close(mt.chStop)
+		mt.wg.Wait()
+		for _, s := range mt.servers {
+			s.deleteMetrics()
+			merr = multierr.Append(merr, s.c.Close())
+		}
+		return
+	})
+}
+
+func (mt *mercuryTransmitter) Ready() error {
+	if err := mt.StartStopOnce.Ready(); err != nil {
+		return err
+	}
+	for _, s := range mt.servers {
+		if err := s.c.Ready(); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func (mt *mercuryTransmitter) Start(ctx context.Context) error { return mt.rpcClient.Start(ctx) }
-func (mt *mercuryTransmitter) Close() error                    { return mt.rpcClient.Close() }
-func (mt *mercuryTransmitter) Ready() error                    { return mt.rpcClient.Ready() }
 func (mt *mercuryTransmitter) Name() string {
 	return mt.lggr.Name()
 }
 
+// HealthReport reports each server as unhealthy while its last attempt to
+// transmit failed.
 func (mt *mercuryTransmitter) HealthReport() map[string]error {
-	return mt.rpcClient.HealthReport()
+	report := map[string]error{mt.Name(): mt.StartStopOnce.Healthy()}
+	for _, s := range mt.servers {
+		report[fmt.Sprintf("%s.Server.%s", mt.Name(), s.url)] = s.health()
+		maps.Copy(report, s.c.HealthReport())
+	}
+	return report
 }
 
-// Transmit sends the report to the on-chain smart contract's Transmit method.
+// Transmit persists the report and queues it for each Mercury server. It does
+// not wait for the report to be sent.
 func (mt *mercuryTransmitter) Transmit(ctx context.Context, reportCtx ocrtypes.ReportContext, report ocrtypes.Report, signatures []ocrtypes.AttributedOnchainSignature) error {
 	var rs [][32]byte
 	var ss [][32]byte
@@ -98,24 +276,33 @@ func (mt *mercuryTransmitter) Transmit(ctx context.Context, reportCtx ocrtypes.R
 		Payload: payload,
 	}
 
-	mt.lggr.Debugw("Transmitting report", "transmitRequest", req, "report", report, "reportCtx", reportCtx, "signatures", signatures)
-
-	res, err := mt.rpcClient.Transmit(ctx, req)
-	if err != nil {
-		return errors.Wrap(err, "Transmit report to Mercury server failed")
-	}
+	mt.lggr.Debugw("Queueing report for transmission", "transmitRequest", req, "report", report, "reportCtx", reportCtx, "signatures", signatures)
 
-	if res.Error == "" {
-		mt.lggr.Debugw("Transmit report success", "response", res, "reportCtx", reportCtx)
-	} else {
-		err := errors.New(res.Error)
-		mt.lggr.Errorw("Transmit report failed; mercury server returned error", "response", res, "reportCtx", reportCtx, "err", err)
+	if err = mt.orm.InsertTransmitRequest(mt.serverURLs, mt.jobID, req, reportCtx, pg.WithParentCtx(ctx)); err != nil {
 		return err
 	}
-
+	now := time.Now()
+	for _, s := range mt.servers {
+		s.push(ctx, &Transmission{Req: req, ReportCtx: reportCtx, CreatedAt: now})
+	}
 	return nil
 }
 
+// latestReport returns the latest report of the feed from the first server
+// which responds successfully, with that server.
+func (mt *mercuryTransmitter) latestReport(ctx context.Context, op string) (s *server, report *pb.Report, err error) {
+	for _, s = range mt.servers {
+		report, err = s.latestReport(ctx, op)
+		if err == nil {
+			return s, report, nil
+		}
+		if len(mt.servers) > 1 {
+			s.lggr.Warnw(op+" failed; trying next server", "err", err)
+		}
+	}
+	return nil, nil, err
+}
+
 // FromAccount returns the stringified (hex) CSA public key
 func (mt *mercuryTransmitter) FromAccount() ocrtypes.Account {
 	return ocrtypes.Account(mt.fromAccount)
@@ -124,66 +311,55 @@ func (mt *mercuryTransmitter) FromAccount() ocrtypes.Account {
 // LatestConfigDigestAndEpoch retrieves the latest config digest and epoch from the OCR2 contract.
 func (mt *mercuryTransmitter) LatestConfigDigestAndEpoch(ctx context.Context) (cd ocrtypes.ConfigDigest, epoch uint32, err error) {
 	mt.lggr.Debug("LatestConfigDigestAndEpoch")
-	req := &pb.LatestReportRequest{
-		FeedId: mt.feedID[:],
-	}
-	resp, err := mt.rpcClient.LatestReport(ctx, req)
+	_, report, err := mt.latestReport(ctx, "LatestConfigDigestAndEpoch")
 	if err != nil {
-		mt.lggr.Errorw("LatestConfigDigestAndEpoch failed", "err", err)
-		return cd, epoch, errors.Wrap(err, "LatestConfigDigestAndEpoch failed to fetch LatestReport")
-	}
-	if resp == nil {
-		return cd, epoch, errors.New("LatestConfigDigestAndEpoch expected LatestReport to return non-nil response")
-	}
-	if resp.Error != "" {
-		err = errors.New(resp.Error)
-		mt.lggr.Errorw("LatestConfigDigestAndEpoch failed; mercury server returned error", "err", err)
 		return cd, epoch, err
 	}
-	if resp.Report == nil {
+	if report == nil {
 		_, cd, err = mt.cfgTracker.LatestConfigDetails(ctx)
 		mt.lggr.Info("LatestConfigDigestAndEpoch returned empty LatestReport, this is a brand new feed")
 		return cd, epoch, errors.Wrap(err, "fallback to LatestConfigDetails on empty LatestReport failed")
 	}
-	cd, err = ocrtypes.BytesToConfigDigest(resp.Report.ConfigDigest)
+	cd, err = ocrtypes.BytesToConfigDigest(report.ConfigDigest)
 	if err != nil {
-		return cd, epoch, errors.Wrapf(err, "LatestConfigDigestAndEpoch failed; response contained invalid config digest, got: 0x%x", resp.Report.ConfigDigest)
-	}
-	if !bytes.Equal(resp.Report.FeedId, mt.feedID[:]) {
-		return cd, epoch, errors.Errorf("LatestConfigDigestAndEpoch failed; mismatched feed IDs, expected: 0x%x, got: 0x%x", mt.feedID, resp.Report.FeedId)
+		return cd, epoch, errors.Wrapf(err, "LatestConfigDigestAndEpoch failed; response contained invalid config digest, got: 0x%x", report.ConfigDigest)
 	}
 
 	mt.lggr.Debugw("LatestConfigDigestAndEpoch success", "cd", cd, "epoch", epoch)
 
-	return cd, resp.Report.Epoch, nil
+	return cd, report.Epoch, nil
 }
 
 func (mt *mercuryTransmitter) FetchInitialMaxFinalizedBlockNumber(ctx context.Context) (int64, error) {
 	mt.lggr.Debug("FetchInitialMaxFinalizedBlockNumber")
-	req := &pb.LatestReportRequest{
-		FeedId: mt.feedID[:],
-	}
-	resp, err := mt.rpcClient.LatestReport(ctx, req)
+	s, report, err := mt.latestReport(ctx, "FetchInitialMaxFinalizedBlockNumber")
 	if err != nil {
-		mt.lggr.Errorw("FetchInitialMaxFinalizedBlockNumber failed", "err", err)
-		return 0, errors.Wrap(err, "FetchInitialMaxFinalizedBlockNumber failed to fetch LatestReport")
+		return 0, err
 	}
-	if resp == nil {
-		return 0, errors.New("FetchInitialMaxFinalizedBlockNumber expected LatestReport to return non-nil response")
+	if report == nil {
+		mt.lggr.Infow("FetchInitialMaxFinalizedBlockNumber returned empty LatestReport; this is a new feed so initial block number is 0", "currentBlockNum", 0)
+		return 0, nil
 	}
-	if resp.Error != "" {
-		err = errors.New(resp.Error)
-		mt.lggr.Errorw("FetchInitialMaxFinalizedBlockNumber failed; mercury server returned error", "err", err)
+
+	mt.lggr.Debugw("FetchInitialMaxFinalizedBlockNumber success", "currentBlockNum", report.CurrentBlockNumber)
+	s.setMaxFinalized(s.sequencer.SequenceFromLatestReport(report))
+
+	return report.CurrentBlockNumber, nil
+}
+
+func (mt *mercuryTransmitter) FetchInitialMaxFinalizedTimestamp(ctx context.Context) (int64, error) {
+	mt.lggr.Debug("FetchInitialMaxFinalizedTimestamp")
+	s, report, err := mt.latestReport(ctx, "FetchInitialMaxFinalizedTimestamp")
+	if err != nil {
 		return 0, err
 	}
-	if resp.Report == nil {
-		mt.lggr.Infow("FetchInitialMaxFinalizedBlockNumber returned empty LatestReport; this is a new feed so initial block number is 0", "currentBlockNum", 0)
+	if report == nil {
+		mt.lggr.Infow("FetchInitialMaxFinalizedTimestamp returned empty LatestReport; this is a new feed so initial timestamp is 0", "observationsTimestamp", 0)
 		return 0, nil
-	} else if !bytes.Equal(resp.Report.FeedId, mt.feedID[:]) {
-		return 0, errors.Errorf("FetchInitialMaxFinalizedBlockNumber failed; mismatched feed IDs, expected: 0x%x, got: 0x%x", mt.feedID, resp.Report.FeedId)
 	}
 
-	mt.lggr.Debugw("FetchInitialMaxFinalizedBlockNumber success", "currentBlockNum", resp.Report.CurrentBlockNumber)
+	mt.lggr.Debugw("FetchInitialMaxFinalizedTimestamp success", "observationsTimestamp", report.ObservationsTimestamp)
+	s.setMaxFinalized(s.sequencer.SequenceFromLatestReport(report))
 
-	return resp.Report.CurrentBlockNumber, nil
+	return report.ObservationsTimestamp, nil
 }
@@ -2,9 +2,13 @@ package mercury
 
 import (
 	"context"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -13,6 +17,8 @@ import (
 
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+	"github.com/smartcontractkit/chainlink/v2/core/services/relay/evm/mercury/reportcodec"
 	"github.com/smartcontractkit/chainlink/v2/core/services/relay/evm/mercury/wsrpc"
 	"github.com/smartcontractkit/chainlink/v2/core/services/relay/evm/mercury/wsrpc/pb"
 	"github.com/smartcontractkit/chainlink/v2/core/utils"
@@ -47,39 +53,226 @@ func (m MockTracker) LatestConfigDetails(ctx context.Context) (changedInBlock ui
 
 var _ ConfigTracker = &MockTracker{}
 
+type mockORMKey struct {
+	serverURL string
+	hash      [32]byte
+}
+
+type mockORM struct {
+	mu            sync.Mutex
+	transmissions map[mockORMKey]*Transmission
+}
+
+func newMockORM() *mockORM {
+	return &mockORM{transmissions: make(map[mockORMKey]*Transmission)}
+}
+
+func (o *mockORM) InsertTransmitRequest(serverURLs []string, jobID int32, req *pb.TransmitRequest, reportCtx ocrtypes.ReportContext, qopts ...pg.QOpt) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, u := range serverURLs {
+		o.transmissions[mockORMKey{u, hashPayload(req.Payload)}] = &Transmission{Req: req, ReportCtx: reportCtx, CreatedAt: time.Now()}
+	}
+	return nil
+}
+
+func (o *mockORM) DeleteTransmitRequests(serverURL string, reqs []*pb.TransmitRequest, qopts ...pg.QOpt) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, req := range reqs {
+		delete(o.transmissions, mockORMKey{serverURL, hashPayload(req.Payload)})
+	}
+	return nil
+}
+
+func (o *mockORM) GetTransmitRequests(serverURL string, jobID int32, qopts ...pg.QOpt) (transmissions []*Transmission, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for k, t := range o.transmissions {
+		if k.serverURL == serverURL {
+			transmissions = append(transmissions, t)
+		}
+	}
+	return
+}
+
+// len returns the number of transmit requests of a server.
+func (o *mockORM) len(serverURL string) func() int {
+	return func() int {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		n := 0
+		for k := range o.transmissions {
+			if k.serverURL == serverURL {
+				n++
+			}
+		}
+		return n
+	}
+}
+
+var _ ORM = &mockORM{}
+
+const (
+	sURL  = "wss://mercury.example.com/ws"
+	sURL2 = "wss://mercury2.example.com/ws"
+)
+
 func Test_MercuryTransmitter_Transmit(t *testing.T) {
 	t.Parallel()
 
 	lggr := logger.TestLogger(t)
+	codec := reportcodec.NewEVMReportCodec(sampleFeedID, lggr)
+
+	start := func(t *testing.T, clients map[string]wsrpc.Client, orm ORM) *mercuryTransmitter {
+		mt := NewTransmitter(lggr, nil, clients, sampleClientPubKey, 1, sampleFeedID, BlockNumberSequencer(codec), orm)
+		require.NoError(t, mt.Start(testutils.Context(t)))
+		t.Cleanup(func() { assert.NoError(t, mt.Close()) })
+		return mt
+	}
 
 	t.Run("successful transmit", func(t *testing.T) {
+		transmitted := make(chan []byte, 1)
 		c := MockWSRPCClient{
 			transmit: func(ctx context.Context, in *pb.TransmitRequest) (out *pb.TransmitResponse, err error) {
 				require.NotNil(t, in)
-				assert.Equal(t, samplePayloadHex, hexutil.Encode(in.Payload))
+				transmitted <- in.Payload
 				out = new(pb.TransmitResponse)
 				out.Code = 42
 				out.Error = ""
 				return out, nil
 			},
 		}
-		mt := NewTransmitter(lggr, nil, c, sampleClientPubKey, sampleFeedID)
+		orm := newMockORM()
+		mt := start(t, map[string]wsrpc.Client{sURL: c}, orm)
+		err := mt.Transmit(testutils.Context(t), sampleReportContext, sampleReport, sampleSigs)
+		require.NoError(t, err)
+
+		select {
+		case payload := <-transmitted:
+			assert.Equal(t, samplePayloadHex, hexutil.Encode(payload))
+		case <-time.After(testutils.WaitTimeout(t)):
+			t.Fatal("timed out waiting for transmit")
+		}
+		gomega.NewWithT(t).Eventually(orm.len(sURL)).Should(gomega.Equal(0))
+		assert.Equal(t, int64(143), mt.servers[0].maxFinalized.Load())
+	})
+
+	t.Run("failing transmit is retried", func(t *testing.T) {
+		var calls atomic.Int32
+		transmitted := make(chan []byte, 1)
+		c := MockWSRPCClient{
+			transmit: func(ctx context.Context, in *pb.TransmitRequest) (out *pb.TransmitResponse, err error) {
+				if calls.Add(1) < 3 {
+					return nil, errors.New("foo error")
+				}
+				transmitted <- in.Payload
+				return new(pb.TransmitResponse), nil
+			},
+		}
+		orm := newMockORM()
+		mt := start(t, map[string]wsrpc.Client{sURL: c}, orm)
 		err := mt.Transmit(testutils.Context(t), sampleReportContext, sampleReport, sampleSigs)
+		require.NoError(t, err)
+
+		select {
+		case payload := <-transmitted:
+			assert.Equal(t, samplePayloadHex, hexutil.Encode(payload))
+		case <-time.After(testutils.WaitTimeout(t)):
+			t.Fatal("timed out waiting for transmit")
+		}
+		assert.Equal(t, int32(3), calls.Load())
+		gomega.NewWithT(t).Eventually(orm.len(sURL)).Should(gomega.Equal(0))
+	})
 
+	t.Run("report rejected by server is dropped", func(t *testing.T) {
+		var calls atomic.Int32
+		c := MockWSRPCClient{
+			transmit: func(ctx context.Context, in *pb.TransmitRequest) (out *pb.TransmitResponse, err error) {
+				calls.Add(1)
+				return &pb.TransmitResponse{Code: 1, Error: "invalid report"}, nil
+			},
+		}
+		orm := newMockORM()
+		mt := start(t, map[string]wsrpc.Client{sURL: c}, orm)
+		err := mt.Transmit(testutils.Context(t), sampleReportContext, sampleReport, sampleSigs)
 		require.NoError(t, err)
+
+		gomega.NewWithT(t).Eventually(orm.len(sURL)).Should(gomega.Equal(0))
+		assert.Equal(t, int32(1), calls.Load())
+		assert.Equal(t, int64(-1), mt.servers[0].maxFinalized.Load())
 	})
 
-	t.Run("failing transmit", func(t *testing.T) {
+	t.Run("transmits reports persisted before start", func(t *testing.T) {
+		transmitted := make(chan []byte, 1)
 		c := MockWSRPCClient{
 			transmit: func(ctx context.Context, in *pb.TransmitRequest) (out *pb.TransmitResponse, err error) {
-				return nil, errors.New("foo error")
+				transmitted <- in.Payload
+				return new(pb.TransmitResponse), nil
 			},
 		}
-		mt := NewTransmitter(lggr, nil, c, sampleClientPubKey, sampleFeedID)
+		orm := newMockORM()
+		require.NoError(t, orm.InsertTransmitRequest([]string{sURL}, 1, &pb.TransmitRequest{Payload: samplePayload}, sampleReportContext))
+		start(t, map[string]wsrpc.Client{sURL: c}, orm)
+
+		select {
+		case payload := <-transmitted:
+			assert.Equal(t, samplePayloadHex, hexutil.Encode(payload))
+		case <-time.After(testutils.WaitTimeout(t)):
+			t.Fatal("timed out waiting for transmit")
+		}
+		gomega.NewWithT(t).Eventually(orm.len(sURL)).Should(gomega.Equal(0))
+	})
+
+	t.Run("drops reports below the max finalized block number", func(t *testing.T) {
+		c := MockWSRPCClient{
+			transmit: func(ctx context.Context, in *pb.TransmitRequest) (out *pb.TransmitResponse, err error) {
+				t.Error("unexpected transmit of stale report")
+				return new(pb.TransmitResponse), nil
+			},
+		}
+		orm := newMockORM()
+		mt := start(t, map[string]wsrpc.Client{sURL: c}, orm)
+		mt.servers[0].setMaxFinalized(143)
 		err := mt.Transmit(testutils.Context(t), sampleReportContext, sampleReport, sampleSigs)
+		require.NoError(t, err)
 
-		require.Error(t, err)
-		assert.Contains(t, err.Error(), "foo error")
+		gomega.NewWithT(t).Eventually(orm.len(sURL)).Should(gomega.Equal(0))
+	})
+
+	t.Run("failing server does not block the others", func(t *testing.T) {
+		transmitted := make(chan []byte, 1)
+		c := MockWSRPCClient{
+			transmit: func(ctx context.Context, in *pb.TransmitRequest) (out *pb.TransmitResponse, err error) {
+				transmitted <- in.Payload
+				return new(pb.TransmitResponse), nil
+			},
+		}
+		failing := MockWSRPCClient{
+			transmit: func(ctx context.Context, in *pb.TransmitRequest) (out *pb.TransmitResponse, err error) {
+				return nil, errors.New("connection refused")
+			},
+		}
+		orm := newMockORM()
+		mt := start(t, map[string]wsrpc.Client{sURL: c, sURL2: failing}, orm)
+		err := mt.Transmit(testutils.Context(t), sampleReportContext, sampleReport, sampleSigs)
+		require.NoError(t, err)
+
+		select {
+		case payload := <-transmitted:
+			assert.Equal(t, samplePayloadHex, hexutil.Encode(payload))
+		case <-time.After(testutils.WaitTimeout(t)):
+			t.Fatal("timed out waiting for transmit")
+		}
+		gomega.NewWithT(t).Eventually(orm.len(sURL)).Should(gomega.Equal(0))
+		assert.Equal(t, 1, orm.len(sURL2)())
+
+		gomega.NewWithT(t).Eventually(func() error {
+			return mt.HealthReport()[mt.Name()+".Server."+sURL2]
+		}).Should(gomega.MatchError(gomega.ContainSubstring("connection refused")))
+		report := mt.HealthReport()
+		assert.NoError(t, report[mt.Name()+".Server."+sURL])
+		assert.NoError(t, report[mt.Name()])
 	})
 }
 
@@ -104,7 +297,7 @@ func Test_MercuryTransmitter_LatestConfigDigestAndEpoch(t *testing.T) {
 				return out, nil
 			},
 		}
-		mt := NewTransmitter(lggr, nil, c, sampleClientPubKey, sampleFeedID)
+		mt := NewTransmitter(lggr, nil, map[string]wsrpc.Client{sURL: c}, sampleClientPubKey, 1, sampleFeedID, nil, nil)
 		cd, epoch, err := mt.LatestConfigDigestAndEpoch(testutils.Context(t))
 		require.NoError(t, err)
 
@@ -117,7 +310,7 @@ func Test_MercuryTransmitter_LatestConfigDigestAndEpoch(t *testing.T) {
 				return nil, errors.New("something exploded")
 			},
 		}
-		mt := NewTransmitter(lggr, nil, c, sampleClientPubKey, sampleFeedID)
+		mt := NewTransmitter(lggr, nil, map[string]wsrpc.Client{sURL: c}, sampleClientPubKey, 1, sampleFeedID, nil, nil)
 		_, _, err := mt.LatestConfigDigestAndEpoch(testutils.Context(t))
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "something exploded")
@@ -135,7 +328,7 @@ func Test_MercuryTransmitter_LatestConfigDigestAndEpoch(t *testing.T) {
 				return out, nil
 			},
 		}
-		mt := NewTransmitter(lggr, nil, c, sampleClientPubKey, sampleFeedID)
+		mt := NewTransmitter(lggr, nil, map[string]wsrpc.Client{sURL: c}, sampleClientPubKey, 1, sampleFeedID, nil, nil)
 		_, _, err := mt.LatestConfigDigestAndEpoch(testutils.Context(t))
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "LatestConfigDigestAndEpoch failed; mismatched feed IDs, expected: 0x1c916b4aa7e57ca7b68ae1bf45653f56b656fd3aa335ef7fae696b663f1b8472, got: 0x01020304")
@@ -147,7 +340,7 @@ func Test_MercuryTransmitter_LatestConfigDigestAndEpoch(t *testing.T) {
 			},
 		}
 		tracker := &MockTracker{}
-		mt := NewTransmitter(lggr, tracker, c, sampleClientPubKey, sampleFeedID)
+		mt := NewTransmitter(lggr, tracker, map[string]wsrpc.Client{sURL: c}, sampleClientPubKey, 1, sampleFeedID, nil, nil)
 		_, _, err := mt.LatestConfigDigestAndEpoch(testutils.Context(t))
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "LatestConfigDigestAndEpoch expected LatestReport to return non-nil response")
@@ -166,7 +359,7 @@ func Test_MercuryTransmitter_LatestConfigDigestAndEpoch(t *testing.T) {
 					return 123, ocrtypes.ConfigDigest(sampleConfigDigest), nil
 				},
 			}
-			mt := NewTransmitter(lggr, tracker, c, sampleClientPubKey, sampleFeedID)
+			mt := NewTransmitter(lggr, tracker, map[string]wsrpc.Client{sURL: c}, sampleClientPubKey, 1, sampleFeedID, nil, nil)
 			cd, epoch, err := mt.LatestConfigDigestAndEpoch(testutils.Context(t))
 			require.NoError(t, err)
 
@@ -179,7 +372,7 @@ func Test_MercuryTransmitter_LatestConfigDigestAndEpoch(t *testing.T) {
 					return changedInBlock, configDigest, errors.New("something exploded")
 				},
 			}
-			mt := NewTransmitter(lggr, tracker, c, sampleClientPubKey, sampleFeedID)
+			mt := NewTransmitter(lggr, tracker, map[string]wsrpc.Client{sURL: c}, sampleClientPubKey, 1, sampleFeedID, nil, nil)
 			_, _, err := mt.LatestConfigDigestAndEpoch(testutils.Context(t))
 			require.Error(t, err)
 			assert.Contains(t, err.Error(), "something exploded")
@@ -204,7 +397,7 @@ func Test_MercuryTransmitter_FetchInitialMaxFinalizedBlockNumber(t *testing.T) {
 				return out, nil
 			},
 		}
-		mt := NewTransmitter(lggr, nil, c, sampleClientPubKey, sampleFeedID)
+		mt := NewTransmitter(lggr, nil, map[string]wsrpc.Client{sURL: c}, sampleClientPubKey, 1, sampleFeedID, BlockNumberSequencer(nil), nil)
 		bn, err := mt.FetchInitialMaxFinalizedBlockNumber(testutils.Context(t))
 		require.NoError(t, err)
 
@@ -216,7 +409,7 @@ func Test_MercuryTransmitter_FetchInitialMaxFinalizedBlockNumber(t *testing.T) {
 				return nil, errors.New("something exploded")
 			},
 		}
-		mt := NewTransmitter(lggr, nil, c, sampleClientPubKey, sampleFeedID)
+		mt := NewTransmitter(lggr, nil, map[string]wsrpc.Client{sURL: c}, sampleClientPubKey, 1, sampleFeedID, nil, nil)
 		_, err := mt.FetchInitialMaxFinalizedBlockNumber(testutils.Context(t))
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "something exploded")
@@ -233,9 +426,70 @@ func Test_MercuryTransmitter_FetchInitialMaxFinalizedBlockNumber(t *testing.T) {
 				return out, nil
 			},
 		}
-		mt := NewTransmitter(lggr, nil, c, sampleClientPubKey, sampleFeedID)
+		mt := NewTransmitter(lggr, nil, map[string]wsrpc.Client{sURL: c}, sampleClientPubKey, 1, sampleFeedID, nil, nil)
 		_, err := mt.FetchInitialMaxFinalizedBlockNumber(testutils.Context(t))
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "FetchInitialMaxFinalizedBlockNumber failed; mismatched feed IDs, expected: 0x1c916b4aa7e57ca7b68ae1bf45653f56b656fd3aa335ef7fae696b663f1b8472, got: 0x")
 	})
+	t.Run("falls back to next server", func(t *testing.T) {
+		failing := MockWSRPCClient{
+			latestReport: func(ctx context.Context, in *pb.LatestReportRequest) (out *pb.LatestReportResponse, err error) {
+				return nil, errors.New("something exploded")
+			},
+		}
+		c := MockWSRPCClient{
+			latestReport: func(ctx context.Context, in *pb.LatestReportRequest) (out *pb.LatestReportResponse, err error) {
+				out = new(pb.LatestReportResponse)
+				out.Report = new(pb.Report)
+				out.Report.FeedId = sampleFeedID[:]
+				out.Report.CurrentBlockNumber = 42
+				return out, nil
+			},
+		}
+		// servers are queried in order of URL
+		mt := NewTransmitter(lggr, nil, map[string]wsrpc.Client{sURL: failing, sURL2: c}, sampleClientPubKey, 1, sampleFeedID, BlockNumberSequencer(nil), nil)
+		bn, err := mt.FetchInitialMaxFinalizedBlockNumber(testutils.Context(t))
+		require.NoError(t, err)
+
+		assert.Equal(t, 42, int(bn))
+		assert.Equal(t, int64(-1), mt.servers[0].maxFinalized.Load())
+		assert.Equal(t, int64(42), mt.servers[1].maxFinalized.Load())
+	})
+}
+
+func Test_MercuryTransmitter_FetchInitialMaxFinalizedTimestamp(t *testing.T) {
+	t.Parallel()
+
+	lggr := logger.TestLogger(t)
+
+	t.Run("successful query", func(t *testing.T) {
+		c := MockWSRPCClient{
+			latestReport: func(ctx context.Context, in *pb.LatestReportRequest) (out *pb.LatestReportResponse, err error) {
+				out = new(pb.LatestReportResponse)
+				out.Report = new(pb.Report)
+				out.Report.FeedId = sampleFeedID[:]
+				out.Report.ObservationsTimestamp = 1686000000
+				return out, nil
+			},
+		}
+		mt := NewTransmitter(lggr, nil, map[string]wsrpc.Client{sURL: c}, sampleClientPubKey, 1, sampleFeedID, TimestampSequencer(nil), nil)
+		ts, err := mt.FetchInitialMaxFinalizedTimestamp(testutils.Context(t))
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(1686000000), ts)
+		assert.Equal(t, int64(1686000000), mt.servers[0].maxFinalized.Load())
+	})
+	t.Run("new feed", func(t *testing.T) {
+		c := MockWSRPCClient{
+			latestReport: func(ctx context.Context, in *pb.LatestReportRequest) (out *pb.LatestReportResponse, err error) {
+				return new(pb.LatestReportResponse), nil
+			},
+		}
+		mt := NewTransmitter(lggr, nil, map[string]wsrpc.Client{sURL: c}, sampleClientPubKey, 1, sampleFeedID, TimestampSequencer(nil), nil)
+		ts, err := mt.FetchInitialMaxFinalizedTimestamp(testutils.Context(t))
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(0), ts)
+		assert.Equal(t, int64(-1), mt.servers[0].maxFinalized.Load())
+	})
 }
@@ -0,0 +1,82 @@
+package reportcodec
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/pkg/errors"
+	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/mercury/schema"
+)
+
+// ReportTypes is the layout of version 2 reports, which are timestamped
+// rather than tied to a block of the chain.
+var ReportTypes = getReportTypes()
+
+func getReportTypes() abi.Arguments {
+	mustNewType := func(t string) abi.Type {
+		result, err := abi.NewType(t, "", []abi.ArgumentMarshaling{})
+		if err != nil {
+			panic(fmt.Sprintf("Unexpected error during abi.NewType: %s", err))
+		}
+		return result
+	}
+	return abi.Arguments([]abi.Argument{
+		{Name: "feedId", Type: mustNewType("bytes32")},
+		{Name: "validFromTimestamp", Type: mustNewType("uint32")},
+		{Name: "observationsTimestamp", Type: mustNewType("uint32")},
+		{Name: "benchmarkPrice", Type: mustNewType("int192")},
+		{Name: "bid", Type: mustNewType("int192")},
+		{Name: "ask", Type: mustNewType("int192")},
+	})
+}
+
+var _ schema.ReportCodec = &ReportCodec{}
+
+type ReportCodec struct {
+	logger logger.Logger
+	feedID [32]byte
+}
+
+func NewReportCodec(feedID [32]byte, lggr logger.Logger) *ReportCodec {
+	return &ReportCodec{lggr, feedID}
+}
+
+func (r *ReportCodec) BuildReport(rf schema.Report) (ocrtypes.Report, error) {
+	if len(rf.Values) != len(schema.V2.Fields) {
+		return nil, errors.Errorf("expected %d values, got: %d", len(schema.V2.Fields), len(rf.Values))
+	}
+	if rf.ValidFromTimestamp > rf.ObservationsTimestamp {
+		return nil, errors.Errorf("validFromTimestamp=%d may not be greater than observationsTimestamp=%d", rf.ValidFromTimestamp, rf.ObservationsTimestamp)
+	}
+
+	reportBytes, err := ReportTypes.Pack(r.feedID, rf.ValidFromTimestamp, rf.ObservationsTimestamp, rf.Values[0], rf.Values[1], rf.Values[2])
+	return ocrtypes.Report(reportBytes), errors.Wrap(err, "failed to pack report blob")
+}
+
+// MaxReportLength returns the length of the ABI encoding of the report, which
+// has one 32 byte word per field.
+func (r *ReportCodec) MaxReportLength(n int) int {
+	return len(ReportTypes) * 32
+}
+
+func (r *ReportCodec) ObservationsTimestampFromReport(report ocrtypes.Report) (uint32, error) {
+	reportElems := map[string]interface{}{}
+	if err := ReportTypes.UnpackIntoMap(reportElems, report); err != nil {
+		return 0, errors.Errorf("error during unpack: %v", err)
+	}
+
+	timestampIface, ok := reportElems["observationsTimestamp"]
+	if !ok {
+		return 0, errors.Errorf("unpacked report has no 'observationsTimestamp' field")
+	}
+
+	timestamp, ok := timestampIface.(uint32)
+	if !ok {
+		return 0, errors.Errorf("cannot cast observationsTimestamp to uint32, type is %T", timestampIface)
+	}
+
+	return timestamp, nil
+}
@@ -0,0 +1,51 @@
+package reportcodec
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/mercury/schema"
+)
+
+func Test_ReportCodec(t *testing.T) {
+	feedID := [32]byte{'f', 'o', 'o'}
+	r := NewReportCodec(feedID, logger.TestLogger(t))
+
+	t.Run("BuildReport errors on wrong number of values", func(t *testing.T) {
+		_, err := r.BuildReport(schema.Report{ValidFromTimestamp: 1, ObservationsTimestamp: 2, Values: []*big.Int{big.NewInt(1)}})
+		require.EqualError(t, err, "expected 3 values, got: 1")
+	})
+
+	t.Run("BuildReport errors if validFromTimestamp is after observationsTimestamp", func(t *testing.T) {
+		_, err := r.BuildReport(schema.Report{ValidFromTimestamp: 3, ObservationsTimestamp: 2, Values: []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}})
+		require.EqualError(t, err, "validFromTimestamp=3 may not be greater than observationsTimestamp=2")
+	})
+
+	t.Run("BuildReport constructs a report", func(t *testing.T) {
+		report, err := r.BuildReport(schema.Report{ValidFromTimestamp: 41, ObservationsTimestamp: 42, Values: []*big.Int{big.NewInt(243), big.NewInt(244), big.NewInt(245)}})
+		require.NoError(t, err)
+		assert.Len(t, report, r.MaxReportLength(4))
+
+		reportElems := make(map[string]interface{})
+		require.NoError(t, ReportTypes.UnpackIntoMap(reportElems, report))
+		assert.Equal(t, feedID, reportElems["feedId"].([32]byte))
+		assert.Equal(t, uint32(41), reportElems["validFromTimestamp"].(uint32))
+		assert.Equal(t, uint32(42), reportElems["observationsTimestamp"].(uint32))
+		assert.Equal(t, int64(243), reportElems["benchmarkPrice"].(*big.Int).Int64())
+		assert.Equal(t, int64(244), reportElems["bid"].(*big.Int).Int64())
+		assert.Equal(t, int64(245), reportElems["ask"].(*big.Int).Int64())
+
+		ts, err := r.ObservationsTimestampFromReport(report)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(42), ts)
+	})
+
+	t.Run("ObservationsTimestampFromReport errors on invalid report", func(t *testing.T) {
+		_, err := r.ObservationsTimestampFromReport([]byte{1, 2, 3})
+		require.Error(t, err)
+	})
+}
@@ -2,6 +2,7 @@ package wsrpc
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -78,7 +79,7 @@ func (w *client) Close() error {
 }
 
 func (w *client) Name() string {
-	return "EVM.Mercury.WSRPCClient"
+	return fmt.Sprintf("EVM.Mercury.WSRPCClient.%s", w.serverURL)
 }
 
 func (w *client) HealthReport() map[string]error {
@@ -12,6 +12,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services"
+	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/plugins/mercury/schema"
 	"github.com/smartcontractkit/chainlink/v2/core/services/relay/evm/mercury"
 )
 
@@ -21,7 +22,10 @@ type mercuryProvider struct {
 	configWatcher *configWatcher
 	transmitter   mercury.Transmitter
 	reportCodec   relaymercury.ReportCodec
-	logger        logger.Logger
+	// schemaReportCodec encodes the reports of timestamped schemas, for
+	// which reportCodec is nil
+	schemaReportCodec schema.ReportCodec
+	logger            logger.Logger
 
 	ms services.MultiStart
 }
@@ -30,12 +34,14 @@ func NewMercuryProvider(
 	configWatcher *configWatcher,
 	transmitter mercury.Transmitter,
 	reportCodec relaymercury.ReportCodec,
+	schemaReportCodec schema.ReportCodec,
 	lggr logger.Logger,
 ) *mercuryProvider {
 	return &mercuryProvider{
 		configWatcher,
 		transmitter,
 		reportCodec,
+		schemaReportCodec,
 		lggr,
 		services.MultiStart{},
 	}
@@ -80,6 +86,12 @@ func (p *mercuryProvider) ReportCodec() relaymercury.ReportCodec {
 	return p.reportCodec
 }
 
+// SchemaReportCodec returns the codec of timestamped report schemas, or nil
+// for schema version 1.
+func (p *mercuryProvider) SchemaReportCodec() schema.ReportCodec {
+	return p.schemaReportCodec
+}
+
 func (p *mercuryProvider) ContractTransmitter() relaymercury.Transmitter {
 	return p.transmitter
 }
@@ -0,0 +1,281 @@
+// Package slo evaluates the service level objectives of jobs against their
+// pipeline runs.
+package slo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+const (
+	// EvaluationInterval is how often the SLOs of all jobs are evaluated
+	EvaluationInterval = time.Minute
+	webhookTimeout     = 10 * time.Second
+)
+
+var promJobSLOHealthy = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "job_slo_healthy",
+	Help: "Whether the job meets all of its service level objectives (1) or not (0)",
+}, []string{"job_id", "job_name"})
+
+// Health is the result of the evaluation of the SLO of a job
+type Health struct {
+	JobID              int32         `json:"jobID"`
+	JobName            string        `json:"jobName"`
+	Healthy            bool          `json:"healthy"`
+	Violations         []string      `json:"violations"`
+	LastSuccessAt      *time.Time    `json:"lastSuccessAt"`
+	LastTransmissionAt *time.Time    `json:"lastTransmissionAt"`
+	Runs               int64         `json:"runs"`
+	ErrorRate          float64       `json:"errorRate"`
+	P95Duration        time.Duration `json:"p95Duration"`
+	EvaluatedAt        time.Time     `json:"evaluatedAt"`
+}
+
+//go:generate mockery --quiet --name Monitor --output ./mocks/ --case=underscore
+
+// Monitor periodically evaluates the SLOs of jobs. Violations are logged,
+// reported as unhealthy in HealthReport, and sent to the webhook of the SLO,
+// if any.
+type Monitor interface {
+	services.ServiceCtx
+	// JobHealth returns the last evaluated health of a job, and false if it
+	// has no SLO or has not been evaluated yet
+	JobHealth(jobID int32) (Health, bool)
+}
+
+type monitor struct {
+	utils.StartStopOnce
+	orm        ORM
+	lggr       logger.Logger
+	httpClient *http.Client
+	interval   time.Duration
+
+	mu     sync.RWMutex
+	health map[int32]Health
+
+	chStop utils.StopChan
+	wgDone sync.WaitGroup
+}
+
+var _ Monitor = (*monitor)(nil)
+
+// NewMonitor returns a Monitor evaluating the SLOs of jobs every interval,
+// and sending webhooks with httpClient
+func NewMonitor(orm ORM, lggr logger.Logger, httpClient *http.Client, interval time.Duration) Monitor {
+	return &monitor{
+		orm:        orm,
+		lggr:       lggr.Named("JobSLOMonitor"),
+		httpClient: httpClient,
+		interval:   interval,
+		health:     make(map[int32]Health),
+		chStop:     make(chan struct{}),
+	}
+}
+
+func (m *monitor) Start(context.Context) error {
+	return m.StartOnce("JobSLOMonitor", func() error {
+		m.wgDone.Add(1)
+		go m.run()
+		return nil
+	})
+}
+
+func (m *monitor) Close() error {
+	return m.StopOnce("JobSLOMonitor", func() error {
+		close(m.chStop)
+		m.wgDone.Wait()
+		return nil
+	})
+}
+
+func (m *monitor) Name() string {
+	return m.lggr.Name()
+}
+
+// HealthReport reports each job violating its SLO as unhealthy
+func (m *monitor) HealthReport() map[string]error {
+	report := map[string]error{m.Name(): m.StartStopOnce.Healthy()}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for id, h := range m.health {
+		if !h.Healthy {
+			report[fmt.Sprintf("%s.Job.%d", m.Name(), id)] = errors.New(strings.Join(h.Violations, "; "))
+		}
+	}
+	return report
+}
+
+func (m *monitor) JobHealth(jobID int32) (Health, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	h, ok := m.health[jobID]
+	return h, ok
+}
+
+func (m *monitor) run() {
+	defer m.wgDone.Done()
+
+	ctx, cancel := m.chStop.NewCtx()
+	defer cancel()
+
+	ticker := time.NewTicker(utils.WithJitter(m.interval))
+	defer ticker.Stop()
+
+	for {
+		m.evaluate(ctx, time.Now())
+		select {
+		case <-ticker.C:
+		case <-m.chStop:
+			return
+		}
+	}
+}
+
+func (m *monitor) evaluate(ctx context.Context, now time.Time) {
+	jobs, err := m.orm.FindJobsWithSLO(pg.WithParentCtx(ctx))
+	if err != nil {
+		m.lggr.Errorw("Failed to load jobs with an SLO", "err", err)
+		return
+	}
+
+	health := make(map[int32]Health, len(jobs))
+	for _, jb := range jobs {
+		stats, err := m.orm.RunStats(jb.PipelineSpecID, now.Add(-jb.SLO.WindowDuration()), pg.WithParentCtx(ctx))
+		if err != nil {
+			m.lggr.Errorw("Failed to get job run stats", "jobID", jb.ID, "err", err)
+			continue
+		}
+		if jb.SLO.MaxTransmissionStaleness != nil {
+			stats.LastTransmissionAt, err = m.orm.LastTransmissionAt(jb.ID, now.Add(-jb.SLO.MaxTransmissionStaleness.Duration()), pg.WithParentCtx(ctx))
+			if err != nil {
+				m.lggr.Errorw("Failed to get last job transmission", "jobID", jb.ID, "err", err)
+				continue
+			}
+		}
+		h := Evaluate(jb, stats, now)
+		health[jb.ID] = h
+
+		healthy := 0.0
+		if h.Healthy {
+			healthy = 1
+		}
+		promJobSLOHealthy.WithLabelValues(strconv.Itoa(int(jb.ID)), h.JobName).Set(healthy)
+
+		m.mu.RLock()
+		prev, evaluated := m.health[jb.ID]
+		m.mu.RUnlock()
+		if evaluated && prev.Healthy == h.Healthy {
+			continue
+		}
+		if !h.Healthy {
+			m.lggr.Warnw("Job is violating its SLO", "jobID", jb.ID, "jobName", h.JobName, "violations", h.Violations)
+		} else if evaluated {
+			m.lggr.Infow("Job meets its SLO again", "jobID", jb.ID, "jobName", h.JobName)
+		}
+		// the first evaluation of a healthy job is not notified
+		if jb.SLO.WebhookURL != nil && (evaluated || !h.Healthy) {
+			m.notify(ctx, jb.SLO.WebhookURL.String(), h)
+		}
+	}
+
+	m.mu.Lock()
+	for id, h := range m.health {
+		if _, ok := health[id]; !ok {
+			promJobSLOHealthy.DeleteLabelValues(strconv.Itoa(int(id)), h.JobName)
+		}
+	}
+	m.health = health
+	m.mu.Unlock()
+}
+
+func (m *monitor) notify(ctx context.Context, url string, h Health) {
+	b, err := json.Marshal(h)
+	if err != nil {
+		m.lggr.Errorw("Failed to marshal job health", "err", err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		m.lggr.Errorw("Failed to create SLO webhook request", "jobID", h.JobID, "err", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		m.lggr.Warnw("Failed to send SLO webhook", "jobID", h.JobID, "err", err)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		m.lggr.Warnw("SLO webhook returned an error", "jobID", h.JobID, "status", resp.StatusCode)
+	}
+}
+
+// Evaluate returns the health of jb given the statistics of its runs at now
+func Evaluate(jb JobSLO, stats RunStats, now time.Time) Health {
+	h := Health{
+		JobID:              jb.ID,
+		JobName:            jb.Name.ValueOrZero(),
+		LastSuccessAt:      stats.LastSuccessAt,
+		LastTransmissionAt: stats.LastTransmissionAt,
+		Runs:               stats.Finished,
+		P95Duration:        stats.P95Duration,
+		EvaluatedAt:        now,
+		Violations:         []string{},
+	}
+	if stats.Finished > 0 {
+		h.ErrorRate = float64(stats.Errored) / float64(stats.Finished)
+	}
+
+	s := jb.SLO
+	if s.MaxStaleness != nil {
+		// a job which never succeeded is stale since its creation
+		since := jb.CreatedAt
+		if stats.LastSuccessAt != nil {
+			since = *stats.LastSuccessAt
+		}
+		if staleness := now.Sub(since); staleness > s.MaxStaleness.Duration() {
+			h.Violations = append(h.Violations, fmt.Sprintf("no successful run for %s, above maxStaleness of %s", staleness.Round(time.Second), s.MaxStaleness.Duration()))
+		}
+	}
+	if s.MaxTransmissionStaleness != nil {
+		// only transmissions within maxTransmissionStaleness are looked up, so
+		// the time of older ones is unknown
+		since := jb.CreatedAt
+		if stats.LastTransmissionAt != nil {
+			since = *stats.LastTransmissionAt
+		}
+		if now.Sub(since) > s.MaxTransmissionStaleness.Duration() {
+			h.Violations = append(h.Violations, fmt.Sprintf("no transmission confirmed on-chain within maxTransmissionStaleness of %s", s.MaxTransmissionStaleness.Duration()))
+		}
+	}
+	if stats.Finished > 0 && stats.Finished >= int64(s.MinRuns) {
+		if s.MaxErrorRate != nil && h.ErrorRate > *s.MaxErrorRate {
+			h.Violations = append(h.Violations, fmt.Sprintf("error rate of %.2f%% over %s, above maxErrorRate of %.2f%%", 100*h.ErrorRate, s.WindowDuration(), 100**s.MaxErrorRate))
+		}
+		if s.MaxP95Duration != nil && stats.P95Duration > s.MaxP95Duration.Duration() {
+			h.Violations = append(h.Violations, fmt.Sprintf("p95 run duration of %s over %s, above maxP95Duration of %s", stats.P95Duration.Round(time.Millisecond), s.WindowDuration(), s.MaxP95Duration.Duration()))
+		}
+	}
+	h.Healthy = len(h.Violations) == 0
+	return h
+}
@@ -0,0 +1,123 @@
+package slo_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/slo"
+	"github.com/smartcontractkit/chainlink/v2/core/services/slo/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/store/models"
+)
+
+func interval(d time.Duration) *models.Interval {
+	i := models.Interval(d)
+	return &i
+}
+
+func TestEvaluate(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	lastSuccess := now.Add(-30 * time.Minute)
+	maxErrorRate := 0.1
+
+	for _, tt := range []struct {
+		name       string
+		slo        job.SLO
+		stats      slo.RunStats
+		violations int
+	}{
+		{"fresh", job.SLO{MaxStaleness: interval(time.Hour)}, slo.RunStats{LastSuccessAt: &lastSuccess}, 0},
+		{"stale", job.SLO{MaxStaleness: interval(10 * time.Minute)}, slo.RunStats{LastSuccessAt: &lastSuccess}, 1},
+		{"never succeeded, recently created", job.SLO{MaxStaleness: interval(3 * time.Hour)}, slo.RunStats{}, 0},
+		{"never succeeded", job.SLO{MaxStaleness: interval(time.Hour)}, slo.RunStats{}, 1},
+		{"error rate", job.SLO{MaxErrorRate: &maxErrorRate}, slo.RunStats{Finished: 10, Errored: 2}, 1},
+		{"error rate at limit", job.SLO{MaxErrorRate: &maxErrorRate}, slo.RunStats{Finished: 10, Errored: 1}, 0},
+		{"error rate below min runs", job.SLO{MaxErrorRate: &maxErrorRate, MinRuns: 20}, slo.RunStats{Finished: 10, Errored: 5}, 0},
+		{"no runs", job.SLO{MaxErrorRate: &maxErrorRate, MaxP95Duration: interval(time.Second)}, slo.RunStats{}, 0},
+		{"p95 duration", job.SLO{MaxP95Duration: interval(time.Second)}, slo.RunStats{Finished: 10, P95Duration: 2 * time.Second}, 1},
+		{"transmitted", job.SLO{MaxTransmissionStaleness: interval(time.Hour)}, slo.RunStats{LastTransmissionAt: &lastSuccess}, 0},
+		{"transmission stale", job.SLO{MaxTransmissionStaleness: interval(10 * time.Minute)}, slo.RunStats{LastTransmissionAt: &lastSuccess}, 1},
+		{"never transmitted, recently created", job.SLO{MaxTransmissionStaleness: interval(3 * time.Hour)}, slo.RunStats{}, 0},
+		{"never transmitted", job.SLO{MaxTransmissionStaleness: interval(time.Hour)}, slo.RunStats{LastSuccessAt: &lastSuccess}, 1},
+		{"all", job.SLO{MaxStaleness: interval(time.Minute), MaxErrorRate: &maxErrorRate, MaxP95Duration: interval(time.Second)}, slo.RunStats{Finished: 10, Errored: 10, P95Duration: 2 * time.Second}, 3},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			jb := slo.JobSLO{ID: 1, Name: null.StringFrom("feed"), SLO: tt.slo, CreatedAt: now.Add(-2 * time.Hour)}
+			h := slo.Evaluate(jb, tt.stats, now)
+			assert.Len(t, h.Violations, tt.violations, h.Violations)
+			assert.Equal(t, tt.violations == 0, h.Healthy)
+			assert.Equal(t, int32(1), h.JobID)
+			assert.Equal(t, "feed", h.JobName)
+			assert.Equal(t, tt.stats.Finished, h.Runs)
+			assert.Equal(t, now, h.EvaluatedAt)
+		})
+	}
+}
+
+func TestMonitor(t *testing.T) {
+	webhooks := make(chan slo.Health, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var h slo.Health
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&h))
+		webhooks <- h
+	}))
+	t.Cleanup(srv.Close)
+	webhookURL, err := models.ParseURL(srv.URL)
+	require.NoError(t, err)
+
+	lastSuccess := time.Now().Add(-2 * time.Hour)
+	orm := mocks.NewORM(t)
+	orm.On("FindJobsWithSLO", mock.Anything).Return([]slo.JobSLO{
+		{ID: 1, Name: null.StringFrom("stale"), PipelineSpecID: 11, SLO: job.SLO{MaxStaleness: interval(time.Hour), WebhookURL: webhookURL}},
+		{ID: 2, Name: null.StringFrom("healthy"), PipelineSpecID: 12, SLO: job.SLO{MaxStaleness: interval(3 * time.Hour), MaxTransmissionStaleness: interval(3 * time.Hour), WebhookURL: webhookURL}},
+	}, nil)
+	orm.On("RunStats", int32(11), mock.Anything, mock.Anything).Return(slo.RunStats{LastSuccessAt: &lastSuccess}, nil)
+	orm.On("RunStats", int32(12), mock.Anything, mock.Anything).Return(slo.RunStats{LastSuccessAt: &lastSuccess}, nil)
+	orm.On("LastTransmissionAt", int32(2), mock.Anything, mock.Anything).Return(&lastSuccess, nil)
+
+	m := slo.NewMonitor(orm, logger.TestLogger(t), srv.Client(), time.Hour)
+	require.NoError(t, m.Start(testutils.Context(t)))
+	t.Cleanup(func() { assert.NoError(t, m.Close()) })
+
+	// only the unhealthy job is notified on the first evaluation
+	select {
+	case h := <-webhooks:
+		assert.Equal(t, int32(1), h.JobID)
+		assert.False(t, h.Healthy)
+		assert.Len(t, h.Violations, 1)
+	case <-time.After(testutils.WaitTimeout(t)):
+		t.Fatal("timed out waiting for webhook")
+	}
+
+	gomega.NewWithT(t).Eventually(func() bool {
+		_, ok := m.JobHealth(2)
+		return ok
+	}).Should(gomega.BeTrue())
+
+	h, ok := m.JobHealth(1)
+	require.True(t, ok)
+	assert.False(t, h.Healthy)
+	h, ok = m.JobHealth(2)
+	require.True(t, ok)
+	assert.True(t, h.Healthy)
+	assert.Equal(t, &lastSuccess, h.LastTransmissionAt)
+	_, ok = m.JobHealth(3)
+	assert.False(t, ok)
+
+	report := m.HealthReport()
+	assert.NoError(t, report[m.Name()])
+	assert.Error(t, report[m.Name()+".Job.1"])
+	assert.NotContains(t, report, m.Name()+".Job.2")
+	assert.Empty(t, webhooks)
+}
@@ -0,0 +1,134 @@
+package slo
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/sqlx"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+)
+
+//go:generate mockery --quiet --name ORM --output ./mocks/ --case=underscore
+
+// ORM reads the jobs with an SLO and the statistics of their runs
+type ORM interface {
+	// FindJobsWithSLO returns the jobs which have an SLO
+	FindJobsWithSLO(qopts ...pg.QOpt) ([]JobSLO, error)
+	// RunStats returns the statistics of the runs of a pipeline spec
+	// finished since the given time
+	RunStats(pipelineSpecID int32, since time.Time, qopts ...pg.QOpt) (RunStats, error)
+	// LastTransmissionAt returns the time the last on-chain transmission of a
+	// job confirmed since the given time was received, or nil if there was none
+	LastTransmissionAt(jobID int32, since time.Time, qopts ...pg.QOpt) (*time.Time, error)
+}
+
+// JobSLO is a job with an SLO
+type JobSLO struct {
+	ID             int32
+	Name           null.String
+	PipelineSpecID int32
+	SLO            job.SLO
+	CreatedAt      time.Time
+}
+
+// RunStats are the statistics of the runs of a job
+type RunStats struct {
+	// LastSuccessAt is the time the last successful run finished, at any time
+	LastSuccessAt *time.Time
+	// Finished is the number of runs finished in the window
+	Finished int64
+	// Errored is the number of runs finished in the window with errors
+	Errored int64
+	// P95Duration is the 95th percentile of the duration of the runs finished
+	// in the window
+	P95Duration time.Duration
+	// LastTransmissionAt is the time the receipt of the last on-chain
+	// transmission of the job was received, if within maxTransmissionStaleness
+	LastTransmissionAt *time.Time
+}
+
+type orm struct {
+	q pg.Q
+}
+
+var _ ORM = (*orm)(nil)
+
+// NewORM returns an ORM for the job SLO monitor
+func NewORM(db *sqlx.DB, lggr logger.Logger, cfg pg.QConfig) ORM {
+	return &orm{q: pg.NewQ(db, lggr.Named("JobSLOORM"), cfg)}
+}
+
+func (o *orm) FindJobsWithSLO(qopts ...pg.QOpt) (jobs []JobSLO, err error) {
+	err = o.q.WithOpts(qopts...).Select(&jobs, `SELECT id, name, pipeline_spec_id, slo, created_at FROM jobs WHERE slo IS NOT NULL ORDER BY id`)
+	return jobs, errors.Wrap(err, "failed to find jobs with an SLO")
+}
+
+func (o *orm) RunStats(pipelineSpecID int32, since time.Time, qopts ...pg.QOpt) (stats RunStats, err error) {
+	var row struct {
+		LastSuccessAt *time.Time
+		Finished      int64
+		Errored       int64
+		P95Seconds    sql.NullFloat64
+	}
+	err = o.q.WithOpts(qopts...).Get(&row, `
+		SELECT
+			(SELECT max(finished_at) FROM pipeline_runs WHERE pipeline_spec_id = $1 AND state = 'completed') AS last_success_at,
+			count(*) AS finished,
+			count(*) FILTER (WHERE state = 'errored') AS errored,
+			percentile_cont(0.95) WITHIN GROUP (ORDER BY extract(epoch FROM finished_at - created_at)) AS p95_seconds
+		FROM pipeline_runs
+		WHERE pipeline_spec_id = $1 AND finished_at >= $2 AND state IN ('completed', 'errored')`, pipelineSpecID, since)
+	if err != nil {
+		return stats, errors.Wrap(err, "failed to get run stats")
+	}
+	stats = RunStats{
+		LastSuccessAt: row.LastSuccessAt,
+		Finished:      row.Finished,
+		Errored:       row.Errored,
+	}
+	if row.P95Seconds.Valid {
+		stats.P95Duration = time.Duration(row.P95Seconds.Float64 * float64(time.Second))
+	}
+	return stats, nil
+}
+
+// LastTransmissionAt finds the confirmed transactions of a job among those sent
+// by its ethtx tasks, and those sent to the contract of its OCR, OCR2, flux
+// monitor or keeper spec. The receipt of a transaction is stored as soon as it
+// is mined, which is when the transmission is considered to happen.
+func (o *orm) LastTransmissionAt(jobID int32, since time.Time, qopts ...pg.QOpt) (lastTransmissionAt *time.Time, err error) {
+	err = o.q.WithOpts(qopts...).Get(&lastTransmissionAt, `
+		WITH jb AS (
+			SELECT * FROM jobs WHERE id = $1
+		), contracts AS (
+			SELECT contract_address AS address FROM ocr_oracle_specs WHERE id = (SELECT ocr_oracle_spec_id FROM jb)
+			UNION
+			SELECT decode(substring(contract_id FROM 3), 'hex') FROM ocr2_oracle_specs
+			WHERE id = (SELECT ocr2_oracle_spec_id FROM jb) AND relay = 'evm' AND contract_id ~ '^0x[0-9a-fA-F]{40}$'
+			UNION
+			SELECT contract_address FROM flux_monitor_specs WHERE id = (SELECT flux_monitor_spec_id FROM jb)
+			UNION
+			SELECT decode(substring(feed->>'contractAddress' FROM 3), 'hex') FROM flux_monitor_specs, jsonb_array_elements(feeds) feed
+			WHERE id = (SELECT flux_monitor_spec_id FROM jb)
+			UNION
+			SELECT contract_address FROM keeper_specs WHERE id = (SELECT keeper_spec_id FROM jb)
+		)
+		SELECT max(r.created_at)
+		FROM eth_receipts r
+		JOIN eth_tx_attempts a ON a.hash = r.tx_hash
+		JOIN eth_txes t ON t.id = a.eth_tx_id
+		WHERE r.created_at >= $2 AND t.state = 'confirmed' AND (
+			t.to_address IN (SELECT address FROM contracts)
+			OR t.pipeline_task_run_id IN (
+				SELECT tr.id FROM pipeline_task_runs tr
+				JOIN pipeline_runs pr ON pr.id = tr.pipeline_run_id
+				WHERE pr.pipeline_spec_id = (SELECT pipeline_spec_id FROM jb) AND pr.created_at >= $2
+			)
+		)`, jobID, since)
+	return lastTransmissionAt, errors.Wrap(err, "failed to get last transmission")
+}
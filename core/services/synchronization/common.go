@@ -8,6 +8,7 @@ const (
 	FunctionsRequests TelemetryType = "functions-requests"
 	EnhancedEAMercury TelemetryType = "enhanced-ea-mercury"
 	OCR               TelemetryType = "ocr"
+	OCR2Attestation   TelemetryType = "ocr2-attestation"
 	OCR2Automation    TelemetryType = "ocr2-automation"
 	OCR2Functions     TelemetryType = "ocr2-functions"
 	OCR2Median        TelemetryType = "ocr2-median"
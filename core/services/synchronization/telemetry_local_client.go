@@ -0,0 +1,156 @@
+package synchronization
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+const (
+	telemetryPruneInterval = time.Hour
+	telemetryFlushTimeout  = 10 * time.Second
+)
+
+type telemetryLocalClient struct {
+	utils.StartStopOnce
+	store TelemetryStore
+	lggr  logger.Logger
+
+	chTelemetry      chan TelemetryRecord
+	dropMessageCount atomic.Uint32
+
+	maxBatchSize uint
+	sendInterval time.Duration
+	maxAge       time.Duration
+
+	wgDone sync.WaitGroup
+	chStop utils.StopChan
+}
+
+// NewTelemetryLocalClient returns a TelemetryIngressBatchClient writing
+// telemetry to store instead of sending it to the ingress server. Telemetry is
+// buffered up to bufferSize messages and written in batches of maxBatchSize
+// every sendInterval. Records older than maxAge are pruned from the store, if
+// maxAge is not zero.
+func NewTelemetryLocalClient(store TelemetryStore, lggr logger.Logger, bufferSize uint, maxBatchSize uint, sendInterval time.Duration, maxAge time.Duration) TelemetryIngressBatchClient {
+	return &telemetryLocalClient{
+		store:        store,
+		lggr:         lggr.Named("TelemetryLocalClient"),
+		chTelemetry:  make(chan TelemetryRecord, bufferSize),
+		maxBatchSize: maxBatchSize,
+		sendInterval: sendInterval,
+		maxAge:       maxAge,
+		chStop:       make(chan struct{}),
+	}
+}
+
+// Start writes buffered telemetry to the store on an interval
+func (tc *telemetryLocalClient) Start(context.Context) error {
+	return tc.StartOnce("TelemetryLocalClient", func() error {
+		tc.wgDone.Add(1)
+		go tc.run()
+		return nil
+	})
+}
+
+// Close writes any buffered telemetry to the store and closes it
+func (tc *telemetryLocalClient) Close() error {
+	return tc.StopOnce("TelemetryLocalClient", func() error {
+		close(tc.chStop)
+		tc.wgDone.Wait()
+
+		ctx, cancel := context.WithTimeout(context.Background(), telemetryFlushTimeout)
+		defer cancel()
+		tc.flush(ctx)
+		return tc.store.Close()
+	})
+}
+
+func (tc *telemetryLocalClient) Name() string {
+	return tc.lggr.Name()
+}
+
+func (tc *telemetryLocalClient) HealthReport() map[string]error {
+	return map[string]error{tc.Name(): tc.StartStopOnce.Healthy()}
+}
+
+// Send buffers telemetry to be written to the store. If the buffer is full,
+// messages are dropped and a warning is logged.
+func (tc *telemetryLocalClient) Send(payload TelemPayload) {
+	record := TelemetryRecord{
+		Timestamp:  time.Now(),
+		TelemType:  payload.TelemType,
+		ContractID: payload.ContractID,
+		Telemetry:  payload.Telemetry,
+	}
+	select {
+	case tc.chTelemetry <- record:
+		tc.dropMessageCount.Store(0)
+	case <-payload.Ctx.Done():
+		return
+	default:
+		tc.logBufferFullWithExpBackoff(record)
+	}
+}
+
+func (tc *telemetryLocalClient) run() {
+	defer tc.wgDone.Done()
+
+	ctx, cancel := tc.chStop.NewCtx()
+	defer cancel()
+
+	sendTicker := time.NewTicker(tc.sendInterval)
+	defer sendTicker.Stop()
+
+	var chPrune <-chan time.Time
+	if tc.maxAge > 0 {
+		tc.prune(ctx)
+		pruneTicker := time.NewTicker(telemetryPruneInterval)
+		defer pruneTicker.Stop()
+		chPrune = pruneTicker.C
+	}
+
+	for {
+		select {
+		case <-sendTicker.C:
+			tc.flush(ctx)
+		case <-chPrune:
+			tc.prune(ctx)
+		case <-tc.chStop:
+			return
+		}
+	}
+}
+
+// flush writes all buffered telemetry to the store, in batches of maxBatchSize
+func (tc *telemetryLocalClient) flush(ctx context.Context) {
+	for len(tc.chTelemetry) > 0 {
+		var batch []TelemetryRecord
+		for len(tc.chTelemetry) > 0 && len(batch) < int(tc.maxBatchSize) {
+			batch = append(batch, <-tc.chTelemetry)
+		}
+		if err := tc.store.Write(ctx, batch); err != nil {
+			tc.lggr.Warnw("Could not write telemetry", "err", err, "count", len(batch))
+			return
+		}
+	}
+}
+
+func (tc *telemetryLocalClient) prune(ctx context.Context) {
+	if err := tc.store.Prune(ctx, time.Now().Add(-tc.maxAge)); err != nil {
+		tc.lggr.Warnw("Could not prune telemetry", "err", err)
+	}
+}
+
+// logBufferFullWithExpBackoff logs dropped messages with the same backoff as
+// telemetryIngressBatchWorker
+func (tc *telemetryLocalClient) logBufferFullWithExpBackoff(record TelemetryRecord) {
+	count := tc.dropMessageCount.Add(1)
+	if count > 0 && (count%100 == 0 || count&(count-1) == 0) {
+		tc.lggr.Warnw("telemetry local client buffer full, dropping message", "telemType", record.TelemType, "contractID", record.ContractID, "droppedCount", count)
+	}
+}
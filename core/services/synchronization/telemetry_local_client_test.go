@@ -0,0 +1,53 @@
+package synchronization_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/synchronization"
+)
+
+func TestTelemetryLocalClient(t *testing.T) {
+	store := synchronization.NewTelemetryFileStore(t.TempDir(), 1, 0, 0)
+	client := synchronization.NewTelemetryLocalClient(store, logger.TestLogger(t), 100, 2, 50*time.Millisecond, 0)
+	require.NoError(t, client.Start(testutils.Context(t)))
+
+	for _, s := range []string{"one", "two", "three"} {
+		client.Send(synchronization.TelemPayload{
+			Ctx:        testutils.Context(t),
+			Telemetry:  []byte(s),
+			TelemType:  synchronization.OCR2Mercury,
+			ContractID: "0xa",
+		})
+	}
+
+	read := func() []synchronization.TelemetryRecord {
+		records, err := store.Read(testutils.Context(t), synchronization.TelemetryFilter{})
+		require.NoError(t, err)
+		return records
+	}
+	gomega.NewWithT(t).Eventually(read).Should(gomega.HaveLen(3))
+
+	// telemetry sent before closing is flushed
+	client.Send(synchronization.TelemPayload{
+		Ctx:        testutils.Context(t),
+		Telemetry:  []byte("four"),
+		TelemType:  synchronization.OCR2Mercury,
+		ContractID: "0xa",
+	})
+	require.NoError(t, client.Close())
+
+	records := read()
+	require.Len(t, records, 4)
+	for i, s := range []string{"one", "two", "three", "four"} {
+		assert.Equal(t, []byte(s), records[i].Telemetry)
+		assert.Equal(t, synchronization.OCR2Mercury, records[i].TelemType)
+		assert.Equal(t, "0xa", records[i].ContractID)
+	}
+}
@@ -0,0 +1,76 @@
+package synchronization
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/sqlx"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+)
+
+type telemetryORM struct {
+	q pg.Q
+}
+
+var _ TelemetryStore = (*telemetryORM)(nil)
+
+// NewTelemetryORM returns a TelemetryStore keeping records in the
+// telemetry_records table
+func NewTelemetryORM(db *sqlx.DB, lggr logger.Logger, cfg pg.QConfig) TelemetryStore {
+	return &telemetryORM{q: pg.NewQ(db, lggr.Named("TelemetryORM"), cfg)}
+}
+
+func (o *telemetryORM) Write(ctx context.Context, records []TelemetryRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	err := o.q.WithOpts(pg.WithParentCtx(ctx)).ExecQNamed(`
+		INSERT INTO telemetry_records (telemetry_type, contract_id, telemetry, created_at)
+		VALUES (:telemetry_type, :contract_id, :telemetry, :created_at)`, records)
+	return errors.Wrap(err, "failed to insert telemetry records")
+}
+
+func (o *telemetryORM) Read(ctx context.Context, filter TelemetryFilter) (records []TelemetryRecord, err error) {
+	var where []string
+	var args []interface{}
+	cond := func(clause string, arg interface{}) {
+		args = append(args, arg)
+		where = append(where, fmt.Sprintf(clause, len(args)))
+	}
+	if filter.TelemType != "" {
+		cond("telemetry_type = $%d", filter.TelemType)
+	}
+	if filter.ContractID != "" {
+		cond("contract_id = $%d", filter.ContractID)
+	}
+	if !filter.Since.IsZero() {
+		cond("created_at >= $%d", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		cond("created_at <= $%d", filter.Until)
+	}
+
+	stmt := `SELECT telemetry_type, contract_id, telemetry, created_at FROM telemetry_records`
+	if len(where) > 0 {
+		stmt += " WHERE " + strings.Join(where, " AND ")
+	}
+	stmt += " ORDER BY created_at, id"
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		stmt += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	err = o.q.WithOpts(pg.WithParentCtx(ctx)).Select(&records, stmt, args...)
+	return records, errors.Wrap(err, "failed to select telemetry records")
+}
+
+func (o *telemetryORM) Prune(ctx context.Context, before time.Time) error {
+	err := o.q.WithOpts(pg.WithParentCtx(ctx)).ExecQ(`DELETE FROM telemetry_records WHERE created_at < $1`, before)
+	return errors.Wrap(err, "failed to delete telemetry records")
+}
+
+func (o *telemetryORM) Close() error { return nil }
@@ -0,0 +1,70 @@
+package synchronization
+
+import (
+	"context"
+	"crypto/ed25519"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/wsrpc"
+	"github.com/smartcontractkit/wsrpc/examples/simple/keys"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore"
+	telemPb "github.com/smartcontractkit/chainlink/v2/core/services/synchronization/telem"
+)
+
+// DialTelemetryIngress connects to the telemetry ingress server with the CSA
+// key of the node, blocking until the connection is established or ctx is done.
+func DialTelemetryIngress(ctx context.Context, url *url.URL, serverPubKeyHex string, ks keystore.CSA, lggr logger.Logger) (telemPb.TelemClient, func(), error) {
+	csaKeys, err := ks.GetAll()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(csaKeys) < 1 {
+		return nil, nil, errors.New("CSA key does not exist")
+	}
+
+	conn, err := wsrpc.DialWithContext(ctx, url.String(),
+		wsrpc.WithTransportCreds(ed25519.PrivateKey(csaKeys[0].Raw()), keys.FromHex(serverPubKeyHex)),
+		wsrpc.WithBlock(),
+		wsrpc.WithLogger(lggr),
+	)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not connect to the telemetry ingress server")
+	}
+	return telemPb.NewTelemClient(conn), conn.Close, nil
+}
+
+// ReplayTelemetry sends records to the ingress server in order, batching
+// consecutive records of the same contract and telemetry type up to
+// maxBatchSize. It returns the number of records sent before any error.
+func ReplayTelemetry(ctx context.Context, client telemPb.TelemClient, records []TelemetryRecord, maxBatchSize uint, sendTimeout time.Duration) (sent int, err error) {
+	for len(records) > 0 {
+		n := 1
+		for n < len(records) && n < int(maxBatchSize) &&
+			records[n].ContractID == records[0].ContractID && records[n].TelemType == records[0].TelemType {
+			n++
+		}
+
+		req := &telemPb.TelemBatchRequest{
+			ContractId:    records[0].ContractID,
+			TelemetryType: string(records[0].TelemType),
+			SentAt:        time.Now().UnixNano(),
+		}
+		for _, r := range records[:n] {
+			req.Telemetry = append(req.Telemetry, r.Telemetry)
+		}
+
+		sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+		_, err = client.TelemBatch(sendCtx, req)
+		cancel()
+		if err != nil {
+			return sent, errors.Wrapf(err, "could not send %s telemetry for contract %s", req.TelemetryType, req.ContractId)
+		}
+		sent += n
+		records = records[n:]
+	}
+	return sent, nil
+}
@@ -0,0 +1,47 @@
+package synchronization_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/services/synchronization"
+	"github.com/smartcontractkit/chainlink/v2/core/services/synchronization/mocks"
+	telemPb "github.com/smartcontractkit/chainlink/v2/core/services/synchronization/telem"
+)
+
+func TestReplayTelemetry(t *testing.T) {
+	records := testTelemetryRecords(t)
+	// consecutive records of the same contract and type are batched
+	records = append(records, synchronization.TelemetryRecord{TelemType: synchronization.OCR2Median, ContractID: "0xa", Telemetry: []byte("five")})
+
+	telemClient := mocks.NewTelemClient(t)
+	var batches []*telemPb.TelemBatchRequest
+	telemClient.On("TelemBatch", mock.Anything, mock.Anything).Return(nil, nil).Run(func(args mock.Arguments) {
+		batches = append(batches, args.Get(1).(*telemPb.TelemBatchRequest))
+	})
+
+	sent, err := synchronization.ReplayTelemetry(testutils.Context(t), telemClient, records, 50, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, len(records), sent)
+	require.Len(t, batches, 4)
+	assert.Equal(t, [][]byte{[]byte("one")}, batches[0].Telemetry)
+	assert.Equal(t, string(synchronization.EnhancedEA), batches[1].TelemetryType)
+	assert.Equal(t, "0xb", batches[2].ContractId)
+	assert.Equal(t, [][]byte{[]byte("four"), []byte("five")}, batches[3].Telemetry)
+
+	t.Run("stops on error", func(t *testing.T) {
+		telemClient := mocks.NewTelemClient(t)
+		telemClient.On("TelemBatch", mock.Anything, mock.Anything).Return(nil, nil).Once()
+		telemClient.On("TelemBatch", mock.Anything, mock.Anything).Return(nil, context.DeadlineExceeded).Once()
+
+		sent, err := synchronization.ReplayTelemetry(testutils.Context(t), telemClient, records, 1, time.Second)
+		assert.EqualError(t, err, "could not send enhanced-ea telemetry for contract 0xa: context deadline exceeded")
+		assert.Equal(t, 1, sent)
+	})
+}
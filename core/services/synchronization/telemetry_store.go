@@ -0,0 +1,236 @@
+package synchronization
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/sqlx"
+	"go.uber.org/multierr"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	v2 "github.com/smartcontractkit/chainlink/v2/core/config/v2"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+// TelemetryRecord is a telemetry payload kept by a local TelemetryStore
+type TelemetryRecord struct {
+	Timestamp  time.Time     `json:"timestamp" db:"created_at"`
+	TelemType  TelemetryType `json:"type" db:"telemetry_type"`
+	ContractID string        `json:"contractID" db:"contract_id"`
+	Telemetry  []byte        `json:"telemetry" db:"telemetry"`
+}
+
+// TelemetryFilter selects records from a TelemetryStore. Zero valued fields
+// match any record.
+type TelemetryFilter struct {
+	TelemType  TelemetryType
+	ContractID string
+	// Since and Until bound the record timestamps, inclusively
+	Since time.Time
+	Until time.Time
+	// Limit is the maximum number of records to return
+	Limit int
+}
+
+func (f TelemetryFilter) matches(r TelemetryRecord) bool {
+	if f.TelemType != "" && r.TelemType != f.TelemType {
+		return false
+	}
+	if f.ContractID != "" && r.ContractID != f.ContractID {
+		return false
+	}
+	if !f.Since.IsZero() && r.Timestamp.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && r.Timestamp.After(f.Until) {
+		return false
+	}
+	return true
+}
+
+// TelemetryStore keeps telemetry locally, as an alternative to sending it to
+// the ingress server
+type TelemetryStore interface {
+	// Write appends records to the store
+	Write(ctx context.Context, records []TelemetryRecord) error
+	// Read returns the records matching filter, oldest first
+	Read(ctx context.Context, filter TelemetryFilter) ([]TelemetryRecord, error)
+	// Prune deletes the records older than before, if the store does not
+	// already expire them itself
+	Prune(ctx context.Context, before time.Time) error
+	Close() error
+}
+
+// TelemetryStoreConfig is the configuration of the local telemetry sink
+type TelemetryStoreConfig interface {
+	TelemetryIngressLocalMode() string
+	TelemetryIngressLocalDir() string
+	TelemetryIngressLocalMaxSize() utils.FileSize
+	TelemetryIngressLocalMaxAge() int64
+	TelemetryIngressLocalMaxBackups() int64
+}
+
+// NewTelemetryStore returns the TelemetryStore of the configured local mode,
+// or nil if it is disabled
+func NewTelemetryStore(cfg TelemetryStoreConfig, db *sqlx.DB, lggr logger.Logger, qcfg pg.QConfig) TelemetryStore {
+	switch cfg.TelemetryIngressLocalMode() {
+	case v2.TelemetryLocalModeFile:
+		return NewTelemetryFileStore(cfg.TelemetryIngressLocalDir(), int(cfg.TelemetryIngressLocalMaxSize()/utils.MB),
+			int(cfg.TelemetryIngressLocalMaxAge()), int(cfg.TelemetryIngressLocalMaxBackups()))
+	case v2.TelemetryLocalModeDatabase:
+		return NewTelemetryORM(db, lggr, qcfg)
+	default:
+		return nil
+	}
+}
+
+const telemetryFileName = "telemetry.jsonl"
+
+type telemetryFileStore struct {
+	dir        string
+	maxSizeMB  int
+	maxAgeDays int
+	maxBackups int
+
+	mu      sync.Mutex
+	writers map[TelemetryType]*lumberjack.Logger
+}
+
+var _ TelemetryStore = (*telemetryFileStore)(nil)
+
+// NewTelemetryFileStore returns a TelemetryStore writing records as JSON lines
+// to a rotating file per telemetry type, at <dir>/<type>/telemetry.jsonl.
+// Rotated files are named after the time of their rotation and are deleted
+// once older than maxAgeDays or in excess of maxBackups, when those are set.
+func NewTelemetryFileStore(dir string, maxSizeMB, maxAgeDays, maxBackups int) TelemetryStore {
+	return &telemetryFileStore{
+		dir:        dir,
+		maxSizeMB:  maxSizeMB,
+		maxAgeDays: maxAgeDays,
+		maxBackups: maxBackups,
+		writers:    make(map[TelemetryType]*lumberjack.Logger),
+	}
+}
+
+func (s *telemetryFileStore) Write(_ context.Context, records []TelemetryRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, r := range records {
+		if r.TelemType == "" || filepath.Base(string(r.TelemType)) != string(r.TelemType) {
+			return errors.Errorf("invalid telemetry type %q", r.TelemType)
+		}
+		w, ok := s.writers[r.TelemType]
+		if !ok {
+			w = &lumberjack.Logger{
+				Filename:   filepath.Join(s.dir, string(r.TelemType), telemetryFileName),
+				MaxSize:    s.maxSizeMB,
+				MaxAge:     s.maxAgeDays,
+				MaxBackups: s.maxBackups,
+			}
+			s.writers[r.TelemType] = w
+		}
+		b, err := json.Marshal(r)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal telemetry record")
+		}
+		if _, err = w.Write(append(b, '\n')); err != nil {
+			return errors.Wrapf(err, "failed to write %s telemetry", r.TelemType)
+		}
+	}
+	return nil
+}
+
+func (s *telemetryFileStore) Read(ctx context.Context, filter TelemetryFilter) ([]TelemetryRecord, error) {
+	types := []string{string(filter.TelemType)}
+	if filter.TelemType == "" {
+		entries, err := os.ReadDir(s.dir)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Wrap(err, "failed to list telemetry directory")
+		}
+		types = types[:0]
+		for _, e := range entries {
+			if e.IsDir() {
+				types = append(types, e.Name())
+			}
+		}
+	}
+
+	var records []TelemetryRecord
+	for _, t := range types {
+		// rotated files are named <name>-<timestamp>.jsonl
+		files, err := filepath.Glob(filepath.Join(s.dir, t, "telemetry*.jsonl"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list telemetry files")
+		}
+		for _, file := range files {
+			if err = ctx.Err(); err != nil {
+				return nil, err
+			}
+			records, err = readTelemetryFile(file, filter, records)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Timestamp.Before(records[j].Timestamp)
+	})
+	if filter.Limit > 0 && len(records) > filter.Limit {
+		records = records[:filter.Limit]
+	}
+	return records, nil
+}
+
+func readTelemetryFile(file string, filter TelemetryFilter, records []TelemetryRecord) ([]TelemetryRecord, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open telemetry file")
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		if len(line) > 0 && line[len(line)-1] == '\n' {
+			var record TelemetryRecord
+			if jerr := json.Unmarshal(line, &record); jerr != nil {
+				return nil, errors.Wrapf(jerr, "failed to parse telemetry record in %s", file)
+			}
+			if filter.matches(record) {
+				records = append(records, record)
+			}
+		}
+		// a partial last line is being written, and is skipped
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		} else if err != nil {
+			return nil, errors.Wrap(err, "failed to read telemetry file")
+		}
+	}
+}
+
+// Prune is a no-op, since rotated files are expired on rotation
+func (s *telemetryFileStore) Prune(context.Context, time.Time) error { return nil }
+
+func (s *telemetryFileStore) Close() (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, w := range s.writers {
+		err = multierr.Append(err, w.Close())
+	}
+	return
+}
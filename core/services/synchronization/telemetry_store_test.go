@@ -0,0 +1,109 @@
+package synchronization_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/synchronization"
+)
+
+func testTelemetryRecords(t *testing.T) []synchronization.TelemetryRecord {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	return []synchronization.TelemetryRecord{
+		{Timestamp: now.Add(-3 * time.Minute), TelemType: synchronization.OCR2Median, ContractID: "0xa", Telemetry: []byte("one")},
+		{Timestamp: now.Add(-2 * time.Minute), TelemType: synchronization.EnhancedEA, ContractID: "0xa", Telemetry: []byte("two")},
+		{Timestamp: now.Add(-time.Minute), TelemType: synchronization.OCR2Median, ContractID: "0xb", Telemetry: []byte("three")},
+		{Timestamp: now, TelemType: synchronization.OCR2Median, ContractID: "0xa", Telemetry: []byte("four")},
+	}
+}
+
+func testTelemetryStore(t *testing.T, store synchronization.TelemetryStore) {
+	ctx := testutils.Context(t)
+	records := testTelemetryRecords(t)
+	require.NoError(t, store.Write(ctx, records[:2]))
+	require.NoError(t, store.Write(ctx, records[2:]))
+
+	for _, tt := range []struct {
+		name     string
+		filter   synchronization.TelemetryFilter
+		expected []synchronization.TelemetryRecord
+	}{
+		{"all", synchronization.TelemetryFilter{}, records},
+		{"type", synchronization.TelemetryFilter{TelemType: synchronization.OCR2Median}, []synchronization.TelemetryRecord{records[0], records[2], records[3]}},
+		{"contract", synchronization.TelemetryFilter{ContractID: "0xa"}, []synchronization.TelemetryRecord{records[0], records[1], records[3]}},
+		{"type and contract", synchronization.TelemetryFilter{TelemType: synchronization.OCR2Median, ContractID: "0xa"}, []synchronization.TelemetryRecord{records[0], records[3]}},
+		{"time range", synchronization.TelemetryFilter{Since: records[1].Timestamp, Until: records[2].Timestamp}, records[1:3]},
+		{"limit", synchronization.TelemetryFilter{Limit: 2}, records[:2]},
+		{"unknown type", synchronization.TelemetryFilter{TelemType: synchronization.OCR2VRF}, nil},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := store.Read(ctx, tt.filter)
+			require.NoError(t, err)
+			require.Len(t, actual, len(tt.expected))
+			for i := range tt.expected {
+				assert.True(t, tt.expected[i].Timestamp.Equal(actual[i].Timestamp))
+				assert.Equal(t, tt.expected[i].TelemType, actual[i].TelemType)
+				assert.Equal(t, tt.expected[i].ContractID, actual[i].ContractID)
+				assert.Equal(t, tt.expected[i].Telemetry, actual[i].Telemetry)
+			}
+		})
+	}
+}
+
+func TestTelemetryFileStore(t *testing.T) {
+	dir := t.TempDir()
+	store := synchronization.NewTelemetryFileStore(dir, 1, 0, 0)
+	t.Cleanup(func() { assert.NoError(t, store.Close()) })
+
+	testTelemetryStore(t, store)
+
+	_, err := os.Stat(filepath.Join(dir, string(synchronization.OCR2Median), "telemetry.jsonl"))
+	require.NoError(t, err)
+
+	t.Run("skips partially written records", func(t *testing.T) {
+		f, err := os.OpenFile(filepath.Join(dir, string(synchronization.EnhancedEA), "telemetry.jsonl"), os.O_APPEND|os.O_WRONLY, 0600)
+		require.NoError(t, err)
+		_, err = f.WriteString(`{"timestamp":`)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		records, err := store.Read(testutils.Context(t), synchronization.TelemetryFilter{TelemType: synchronization.EnhancedEA})
+		require.NoError(t, err)
+		assert.Len(t, records, 1)
+	})
+
+	t.Run("rejects invalid types", func(t *testing.T) {
+		err := store.Write(testutils.Context(t), []synchronization.TelemetryRecord{{TelemType: "../ocr"}})
+		assert.EqualError(t, err, `invalid telemetry type "../ocr"`)
+	})
+}
+
+func TestTelemetryFileStore_EmptyDir(t *testing.T) {
+	store := synchronization.NewTelemetryFileStore(filepath.Join(t.TempDir(), "missing"), 1, 0, 0)
+	records, err := store.Read(testutils.Context(t), synchronization.TelemetryFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestTelemetryORM(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	store := synchronization.NewTelemetryORM(db, logger.TestLogger(t), pgtest.NewQConfig(true))
+
+	testTelemetryStore(t, store)
+
+	records := testTelemetryRecords(t)
+	require.NoError(t, store.Prune(testutils.Context(t), records[2].Timestamp))
+	actual, err := store.Read(testutils.Context(t), synchronization.TelemetryFilter{})
+	require.NoError(t, err)
+	require.Len(t, actual, 2)
+	assert.Equal(t, records[2].Telemetry, actual[0].Telemetry)
+	assert.Equal(t, records[3].Telemetry, actual[1].Telemetry)
+}
@@ -0,0 +1,292 @@
+package vrf
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// ErrNoListener is returned by the Backlog when the requested job has no
+// running VRF v2 listener on this node.
+var ErrNoListener = errors.New("no running VRF v2 listener for job")
+
+// PendingRequest is a snapshot of a VRF v2 randomness request that a listener
+// has received but not yet fulfilled.
+type PendingRequest struct {
+	JobID              int32
+	RequestID          string
+	SubID              uint64
+	Sender             common.Address
+	RequestTxHash      common.Hash
+	RequestBlockNumber uint64
+	ReceivedAt         time.Time
+	// ConfirmedAtBlock is the block at which the request becomes eligible
+	// for fulfillment.
+	ConfirmedAtBlock      uint64
+	RequiredConfirmations uint64
+	Confirmations         uint64
+	CallbackGasLimit      uint32
+	Attempts              int
+	LastTry               time.Time
+	NextTry               time.Time
+	// EstimatedFeeJuels is nil until the request has been simulated at least once.
+	EstimatedFeeJuels *big.Int
+	// LastSkipReason explains why the last processing attempt left the
+	// request unfulfilled. It is empty for requests that were never tried.
+	LastSkipReason string
+}
+
+// Backlog tracks the running VRF v2 listeners so that their in-memory
+// request queues can be inspected and retried by operators.
+type Backlog struct {
+	mu        sync.RWMutex
+	listeners map[int32]*listenerV2
+}
+
+// NewBacklog returns an empty Backlog.
+func NewBacklog() *Backlog {
+	return &Backlog{listeners: make(map[int32]*listenerV2)}
+}
+
+func (b *Backlog) register(lsn *listenerV2) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.listeners[lsn.job.ID] = lsn
+}
+
+func (b *Backlog) unregister(lsn *listenerV2) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.listeners[lsn.job.ID] == lsn {
+		delete(b.listeners, lsn.job.ID)
+	}
+}
+
+// listenersFor returns the listener for jobID, or all listeners if jobID is nil.
+func (b *Backlog) listenersFor(jobID *int32) ([]*listenerV2, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if jobID != nil {
+		lsn, ok := b.listeners[*jobID]
+		if !ok {
+			return nil, errors.Wrapf(ErrNoListener, "job %d", *jobID)
+		}
+		return []*listenerV2{lsn}, nil
+	}
+	listeners := make([]*listenerV2, 0, len(b.listeners))
+	for _, lsn := range b.listeners {
+		listeners = append(listeners, lsn)
+	}
+	return listeners, nil
+}
+
+// PendingRequests returns the unfulfilled requests of the given job, or of all
+// jobs if jobID is nil, optionally restricted to a single subscription. The
+// result is ordered by job, subscription and request block.
+func (b *Backlog) PendingRequests(jobID *int32, subID *uint64) ([]PendingRequest, error) {
+	listeners, err := b.listenersFor(jobID)
+	if err != nil {
+		return nil, err
+	}
+	var reqs []PendingRequest
+	for _, lsn := range listeners {
+		reqs = append(reqs, lsn.pendingRequests(subID)...)
+	}
+	sort.SliceStable(reqs, func(i, j int) bool {
+		if reqs[i].JobID != reqs[j].JobID {
+			return reqs[i].JobID < reqs[j].JobID
+		}
+		if reqs[i].SubID != reqs[j].SubID {
+			return reqs[i].SubID < reqs[j].SubID
+		}
+		return reqs[i].RequestBlockNumber < reqs[j].RequestBlockNumber
+	})
+	return reqs, nil
+}
+
+// RetryNow clears the retry backoff of the matching pending requests and wakes
+// up the request handlers of the affected listeners. Requests that have not
+// reached their required confirmations are still held back. It returns the
+// number of requests that were reset.
+func (b *Backlog) RetryNow(jobID *int32, subID *uint64) (int, error) {
+	listeners, err := b.listenersFor(jobID)
+	if err != nil {
+		return 0, err
+	}
+	var n int
+	for _, lsn := range listeners {
+		n += lsn.retryNow(subID)
+	}
+	return n, nil
+}
+
+// roundNotes collects what the request handler learned about the requests it
+// could not fulfill during a single processing round.
+type roundNotes struct {
+	reqReasons map[string]string
+	subReasons map[uint64]string
+	fees       map[string]*big.Int
+}
+
+// apply copies the notes about req onto it. A request without a reason of its
+// own inherits the reason recorded for its subscription, e.g. when processing
+// stopped before reaching it, and otherwise keeps its previous reason.
+func (n roundNotes) apply(req *pendingRequest) {
+	reqID := req.req.RequestId.String()
+	if fee, ok := n.fees[reqID]; ok {
+		req.juelsNeeded = fee
+	}
+	if reason, ok := n.reqReasons[reqID]; ok {
+		req.lastSkipReason = reason
+	} else if reason, ok := n.subReasons[req.req.SubId]; ok {
+		req.lastSkipReason = reason
+	}
+}
+
+func (lsn *listenerV2) takeRoundNotes() roundNotes {
+	lsn.notesMu.Lock()
+	defer lsn.notesMu.Unlock()
+	notes := lsn.notes
+	lsn.notes = roundNotes{}
+	return notes
+}
+
+func (lsn *listenerV2) recordSkipID(reqID, reason string) {
+	lsn.notesMu.Lock()
+	defer lsn.notesMu.Unlock()
+	if lsn.notes.reqReasons == nil {
+		lsn.notes.reqReasons = make(map[string]string)
+	}
+	lsn.notes.reqReasons[reqID] = reason
+}
+
+func (lsn *listenerV2) recordSkip(req pendingRequest, reason string) {
+	lsn.recordSkipID(req.req.RequestId.String(), reason)
+}
+
+func (lsn *listenerV2) recordBatchSkip(batch *batchFulfillment, reason string) {
+	for _, reqID := range batch.reqIDs {
+		lsn.recordSkipID(reqID.String(), reason)
+	}
+}
+
+func (lsn *listenerV2) recordSubSkip(subID uint64, reason string) {
+	lsn.notesMu.Lock()
+	defer lsn.notesMu.Unlock()
+	if lsn.notes.subReasons == nil {
+		lsn.notes.subReasons = make(map[uint64]string)
+	}
+	lsn.notes.subReasons[subID] = reason
+}
+
+// recordInsufficientBalance records a low balance skip for the request, and for
+// the rest of its subscription since processing of the subscription stops.
+func (lsn *listenerV2) recordInsufficientBalance(p vrfPipelineResult, balance, needed *big.Int) {
+	reason := fmt.Sprintf("insufficient subscription balance: %s juels available after reserving in-flight fulfillments, request needs %s juels",
+		balance.String(), needed.String())
+	lsn.recordSkip(p.req, reason)
+	lsn.recordSubSkip(p.req.req.SubId, reason)
+}
+
+func (lsn *listenerV2) recordFeeEstimate(req pendingRequest, juelsNeeded *big.Int) {
+	// A zero estimate means the fee could not be estimated.
+	if juelsNeeded == nil || juelsNeeded.Sign() == 0 {
+		return
+	}
+	lsn.notesMu.Lock()
+	defer lsn.notesMu.Unlock()
+	if lsn.notes.fees == nil {
+		lsn.notes.fees = make(map[string]*big.Int)
+	}
+	lsn.notes.fees[req.req.RequestId.String()] = juelsNeeded
+}
+
+// pendingRequests returns a snapshot of the requests that are queued or being
+// processed, optionally restricted to a single subscription.
+func (lsn *listenerV2) pendingRequests(subID *uint64) []PendingRequest {
+	latestHead := lsn.getLatestHead()
+	lsn.reqsMu.Lock()
+	defer lsn.reqsMu.Unlock()
+	seen := make(map[string]struct{})
+	var out []PendingRequest
+	for _, reqs := range [][]pendingRequest{lsn.inFlight, lsn.reqs} {
+		for _, r := range reqs {
+			if subID != nil && r.req.SubId != *subID {
+				continue
+			}
+			reqID := r.req.RequestId.String()
+			if _, ok := seen[reqID]; ok {
+				continue
+			}
+			seen[reqID] = struct{}{}
+			out = append(out, lsn.toPendingRequest(r, latestHead))
+		}
+	}
+	return out
+}
+
+func (lsn *listenerV2) toPendingRequest(r pendingRequest, latestHead uint64) PendingRequest {
+	pr := PendingRequest{
+		JobID:              lsn.job.ID,
+		RequestID:          r.req.RequestId.String(),
+		SubID:              r.req.SubId,
+		Sender:             r.req.Sender,
+		RequestTxHash:      r.req.Raw.TxHash,
+		RequestBlockNumber: r.req.Raw.BlockNumber,
+		ReceivedAt:         r.utcTimestamp,
+		ConfirmedAtBlock:   r.confirmedAtBlock,
+		CallbackGasLimit:   r.req.CallbackGasLimit,
+		Attempts:           r.attempts,
+		LastTry:            r.lastTry,
+		LastSkipReason:     r.lastSkipReason,
+	}
+	if r.confirmedAtBlock > r.req.Raw.BlockNumber {
+		pr.RequiredConfirmations = r.confirmedAtBlock - r.req.Raw.BlockNumber
+	}
+	if latestHead > r.req.Raw.BlockNumber {
+		pr.Confirmations = latestHead - r.req.Raw.BlockNumber
+	}
+	if r.juelsNeeded != nil {
+		pr.EstimatedFeeJuels = new(big.Int).Set(r.juelsNeeded)
+	}
+	if r.attempts > 0 && lsn.job.VRFSpec.BackoffInitialDelay != 0 {
+		pr.NextTry = nextTry(r.attempts, lsn.job.VRFSpec.BackoffInitialDelay, lsn.job.VRFSpec.BackoffMaxDelay, r.lastTry)
+	}
+	return pr
+}
+
+// retryNow clears the backoff of the queued requests, optionally restricted to
+// a single subscription, and wakes up the request handler. Requests that are
+// being processed are not affected.
+func (lsn *listenerV2) retryNow(subID *uint64) int {
+	lsn.reqsMu.Lock()
+	var n int
+	for i := range lsn.reqs {
+		if subID != nil && lsn.reqs[i].req.SubId != *subID {
+			continue
+		}
+		lsn.reqs[i].attempts = 0
+		lsn.reqs[i].lastTry = time.Time{}
+		n++
+	}
+	lsn.reqsMu.Unlock()
+
+	if n > 0 {
+		select {
+		case lsn.chRetry <- struct{}{}:
+		default:
+		}
+	}
+	return n
+}
@@ -0,0 +1,160 @@
+package vrf
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/vrf_coordinator_v2"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+)
+
+func newBacklogRequest(reqID int64, subID uint64, blockNumber uint64) pendingRequest {
+	return pendingRequest{
+		confirmedAtBlock: blockNumber + 3,
+		req: &vrf_coordinator_v2.VRFCoordinatorV2RandomWordsRequested{
+			RequestId:        big.NewInt(reqID),
+			SubId:            subID,
+			CallbackGasLimit: 100_000,
+			Sender:           common.HexToAddress("0x1"),
+			Raw: types.Log{
+				BlockNumber: blockNumber,
+				TxHash:      common.BigToHash(big.NewInt(reqID)),
+			},
+		},
+		utcTimestamp: time.Now().UTC(),
+	}
+}
+
+func newBacklogListener(jobID int32, reqs ...pendingRequest) *listenerV2 {
+	return &listenerV2{
+		job: job.Job{
+			ID: jobID,
+			VRFSpec: &job.VRFSpec{
+				BackoffInitialDelay: time.Minute,
+				BackoffMaxDelay:     time.Hour,
+			},
+		},
+		reqs:             reqs,
+		latestHeadNumber: 12,
+		chRetry:          make(chan struct{}, 1),
+	}
+}
+
+func TestRoundNotes_Apply(t *testing.T) {
+	t.Parallel()
+
+	var notes roundNotes
+	lsn := newBacklogListener(1)
+	lsn.recordSubSkip(7, "sub reason")
+	lsn.recordSkip(newBacklogRequest(1, 7, 10), "request reason")
+	lsn.recordFeeEstimate(newBacklogRequest(1, 7, 10), big.NewInt(42))
+	lsn.recordFeeEstimate(newBacklogRequest(2, 7, 10), big.NewInt(0))
+	notes = lsn.takeRoundNotes()
+	assert.Empty(t, lsn.takeRoundNotes().reqReasons)
+
+	own := newBacklogRequest(1, 7, 10)
+	notes.apply(&own)
+	assert.Equal(t, "request reason", own.lastSkipReason)
+	assert.Equal(t, big.NewInt(42), own.juelsNeeded)
+
+	inherited := newBacklogRequest(2, 7, 10)
+	notes.apply(&inherited)
+	assert.Equal(t, "sub reason", inherited.lastSkipReason)
+	assert.Nil(t, inherited.juelsNeeded)
+
+	previous := newBacklogRequest(3, 8, 10)
+	previous.lastSkipReason = "previous reason"
+	notes.apply(&previous)
+	assert.Equal(t, "previous reason", previous.lastSkipReason)
+}
+
+func TestBacklog_PendingRequests(t *testing.T) {
+	t.Parallel()
+
+	tried := newBacklogRequest(2, 7, 10)
+	tried.attempts = 2
+	tried.lastTry = time.Now().UTC()
+	tried.juelsNeeded = big.NewInt(1000)
+	tried.lastSkipReason = "insufficient subscription balance"
+
+	lsn1 := newBacklogListener(1, tried, newBacklogRequest(1, 8, 9), tried)
+	lsn1.inFlight = []pendingRequest{newBacklogRequest(3, 7, 11)}
+	lsn2 := newBacklogListener(2, newBacklogRequest(4, 7, 5))
+
+	b := NewBacklog()
+	b.register(lsn1)
+	b.register(lsn2)
+
+	all, err := b.PendingRequests(nil, nil)
+	require.NoError(t, err)
+	require.Len(t, all, 4)
+	assert.Equal(t, []string{"2", "3", "1", "4"}, []string{all[0].RequestID, all[1].RequestID, all[2].RequestID, all[3].RequestID})
+
+	got := all[0]
+	assert.Equal(t, int32(1), got.JobID)
+	assert.Equal(t, uint64(7), got.SubID)
+	assert.Equal(t, uint64(3), got.RequiredConfirmations)
+	assert.Equal(t, uint64(2), got.Confirmations)
+	assert.Equal(t, 2, got.Attempts)
+	assert.Equal(t, big.NewInt(1000), got.EstimatedFeeJuels)
+	assert.Equal(t, "insufficient subscription balance", got.LastSkipReason)
+	assert.True(t, got.NextTry.After(got.LastTry))
+	assert.Nil(t, all[1].EstimatedFeeJuels)
+	assert.True(t, all[1].NextTry.IsZero())
+
+	jobID, subID := int32(1), uint64(7)
+	filtered, err := b.PendingRequests(&jobID, &subID)
+	require.NoError(t, err)
+	require.Len(t, filtered, 2)
+
+	b.unregister(lsn2)
+	jobID = 2
+	_, err = b.PendingRequests(&jobID, nil)
+	require.ErrorIs(t, err, ErrNoListener)
+	_, err = b.RetryNow(&jobID, nil)
+	require.ErrorIs(t, err, ErrNoListener)
+}
+
+func TestBacklog_RetryNow(t *testing.T) {
+	t.Parallel()
+
+	tried := newBacklogRequest(1, 7, 10)
+	tried.attempts = 5
+	tried.lastTry = time.Now().UTC()
+	other := newBacklogRequest(2, 8, 10)
+	other.attempts = 5
+	other.lastTry = time.Now().UTC()
+
+	lsn := newBacklogListener(1, tried, other)
+	require.False(t, lsn.ready(lsn.reqs[0], 20))
+
+	b := NewBacklog()
+	b.register(lsn)
+
+	subID := uint64(7)
+	n, err := b.RetryNow(nil, &subID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.True(t, lsn.ready(lsn.reqs[0], 20))
+	assert.False(t, lsn.ready(lsn.reqs[1], 20))
+	// Confirmations are still required.
+	assert.False(t, lsn.ready(lsn.reqs[0], 12))
+
+	select {
+	case <-lsn.chRetry:
+	default:
+		t.Fatal("expected the request handler to be woken up")
+	}
+
+	subID = 9
+	n, err = b.RetryNow(nil, &subID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+	assert.Len(t, lsn.chRetry, 0)
+}
@@ -36,6 +36,7 @@ type Delegate struct {
 	cc      evm.ChainSet
 	lggr    logger.Logger
 	mailMon *utils.MailboxMonitor
+	backlog *Backlog
 }
 
 type GethKeyStore interface {
@@ -59,7 +60,8 @@ func NewDelegate(
 	chainSet evm.ChainSet,
 	lggr logger.Logger,
 	cfg pg.QConfig,
-	mailMon *utils.MailboxMonitor) *Delegate {
+	mailMon *utils.MailboxMonitor,
+	backlog *Backlog) *Delegate {
 	return &Delegate{
 		q:       pg.NewQ(db, lggr, cfg),
 		ks:      ks,
@@ -68,6 +70,7 @@ func NewDelegate(
 		cc:      chainSet,
 		lggr:    lggr,
 		mailMon: mailMon,
+		backlog: backlog,
 	}
 }
 
@@ -163,7 +166,8 @@ func (d *Delegate) ServicesForSpec(jb job.Job) ([]job.ServiceCtx, error) {
 				func() {},
 				GetStartingResponseCountsV2(d.q, lV2, chainId.Uint64(), chain.Config().EvmFinalityDepth()),
 				chain.HeadBroadcaster(),
-				newLogDeduper(int(chain.Config().EvmFinalityDepth())))}, nil
+				newLogDeduper(int(chain.Config().EvmFinalityDepth())),
+				d.backlog)}, nil
 		}
 		if _, ok := task.(*pipeline.VRFTask); ok {
 			return []job.ServiceCtx{&listenerV1{
@@ -149,7 +149,8 @@ func setup(t *testing.T) (vrfUniverse, *vrf.ListenerV1, job.Job) {
 		vuni.cc,
 		logger.TestLogger(t),
 		cfg,
-		mailMon)
+		mailMon,
+		vrf.NewBacklog())
 	vs := testspecs.GenerateVRFSpec(testspecs.VRFSpecParams{PublicKey: vuni.vrfkey.PublicKey.String()})
 	jb, err := vrf.ValidatedVRFSpec(vs.Toml())
 	require.NoError(t, err)
@@ -93,6 +93,7 @@ func newListenerV2(
 	respCount map[string]uint64,
 	headBroadcaster httypes.HeadBroadcasterRegistry,
 	deduper *logDeduper,
+	backlog *Backlog,
 ) *listenerV2 {
 	return &listenerV2{
 		cfg:                cfg,
@@ -118,6 +119,8 @@ func newListenerV2(
 		wg:                 &sync.WaitGroup{},
 		aggregator:         aggregator,
 		deduper:            deduper,
+		backlog:            backlog,
+		chRetry:            make(chan struct{}, 1),
 	}
 }
 
@@ -130,6 +133,11 @@ type pendingRequest struct {
 	// used for exponential backoff when retrying
 	attempts int
 	lastTry  time.Time
+
+	// juelsNeeded is the fee estimated during the last simulation, and
+	// lastSkipReason the reason the last attempt did not fulfill the request.
+	juelsNeeded    *big.Int
+	lastSkipReason string
 }
 
 type vrfPipelineResult struct {
@@ -192,6 +200,17 @@ type listenerV2 struct {
 
 	// deduper prevents processing duplicate requests from the log broadcaster.
 	deduper *logDeduper
+
+	// backlog exposes the pending requests to operators, it may be nil.
+	backlog *Backlog
+	// inFlight holds a copy of the requests taken out of reqs by the request
+	// handler while they are being processed. Guarded by reqsMu.
+	inFlight []pendingRequest
+	// chRetry wakes up the request handler before the next poll.
+	chRetry chan struct{}
+	// notes collects skip reasons and fee estimates during a processing round.
+	notesMu sync.Mutex
+	notes   roundNotes
 }
 
 // Start starts listenerV2.
@@ -252,6 +271,7 @@ func (lsn *listenerV2) Start(ctx context.Context) error {
 		}()
 
 		lsn.mailMon.Monitor(lsn.reqLogs, "VRFListenerV2", "RequestLogs", fmt.Sprint(lsn.job.ID))
+		lsn.backlog.register(lsn)
 		return nil
 	})
 }
@@ -292,6 +312,10 @@ func (lsn *listenerV2) getAndRemoveConfirmedLogsBySub(latestHead uint64) map[uin
 		}
 	}
 	lsn.reqs = toKeep
+	lsn.inFlight = lsn.inFlight[:0]
+	for _, reqs := range toProcess {
+		lsn.inFlight = append(lsn.inFlight, reqs...)
+	}
 	return toProcess
 }
 
@@ -365,12 +389,14 @@ func (lsn *listenerV2) processPendingVRFRequests(ctx context.Context) {
 
 	// Add any unprocessed requests back to lsn.reqs after request processing is complete.
 	defer func() {
+		notes := lsn.takeRoundNotes()
 		var toKeep []pendingRequest
 		for _, subReqs := range confirmed {
 			for _, req := range subReqs {
 				if _, ok := processed[req.req.RequestId.String()]; !ok {
 					req.attempts++
 					req.lastTry = time.Now().UTC()
+					notes.apply(&req)
 					toKeep = append(toKeep, req)
 					if lsn.job.VRFSpec.BackoffInitialDelay != 0 {
 						lsn.l.Infow("Request failed, next retry will be delayed.",
@@ -393,6 +419,7 @@ func (lsn *listenerV2) processPendingVRFRequests(ctx context.Context) {
 		// so we merged the new ones with the ones that need to be requeued.
 		lsn.reqsMu.Lock()
 		lsn.reqs = append(lsn.reqs, toKeep...)
+		lsn.inFlight = lsn.inFlight[:0]
 		lsn.l.Infow("Finished processing pending requests",
 			"totalProcessed", len(processed),
 			"totalFailed", len(toKeep),
@@ -421,6 +448,7 @@ func (lsn *listenerV2) processPendingVRFRequests(ctx context.Context) {
 				}
 			} else {
 				lsn.l.Errorw("Unable to read subscription balance", "subID", subID, "err", err)
+				lsn.recordSubSkip(subID, fmt.Sprintf("unable to read subscription balance: %v", err))
 			}
 			continue
 		}
@@ -501,6 +529,7 @@ func (lsn *listenerV2) processRequestsPerSubBatch(
 		lsn.q, startBalance, lsn.chainID.Uint64(), subID)
 	if err != nil {
 		lsn.l.Errorw("Couldn't get reserved LINK for subscription", "sub", reqs[0].req.SubId, "err", err)
+		lsn.recordSubSkip(subID, fmt.Sprintf("unable to get reserved LINK for subscription: %v", err))
 		return processed
 	}
 
@@ -514,6 +543,7 @@ func (lsn *listenerV2) processRequestsPerSubBatch(
 	})
 	if err != nil {
 		lsn.l.Errorw("Couldn't get config from coordinator", "err", err)
+		lsn.recordSubSkip(subID, fmt.Sprintf("unable to get coordinator config: %v", err))
 		return processed
 	}
 
@@ -599,6 +629,7 @@ func (lsn *listenerV2) processRequestsPerSubBatch(
 			if p.err != nil {
 				if startBalanceNoReserveLink.Cmp(p.juelsNeeded) < 0 && errors.Is(p.err, errPossiblyInsufficientFunds{}) {
 					ll.Infow("Insufficient link balance to fulfill a request based on estimate, breaking", "err", p.err)
+					lsn.recordInsufficientBalance(p, startBalanceNoReserveLink, p.juelsNeeded)
 					outOfBalance = true
 
 					// break out of this inner loop to process the currently constructed batch
@@ -609,8 +640,10 @@ func (lsn *listenerV2) processRequestsPerSubBatch(
 					// Running the blockhash store feeder in backwards mode will be required to
 					// resolve this.
 					ll.Criticalw("Pipeline error", "err", p.err)
+					lsn.recordSkip(p.req, "blockhash of the request block is not in the blockhash store")
 				} else {
 					ll.Errorw("Pipeline error", "err", p.err)
+					lsn.recordSkip(p.req, fmt.Sprintf("simulation failed: %v", p.err))
 					// Ensure consumer is valid, otherwise drop the request.
 					if !lsn.isConsumerValidAfterFinalityDepthElapsed(ctx, p.req) {
 						lsn.l.Infow(
@@ -631,6 +664,7 @@ func (lsn *listenerV2) processRequestsPerSubBatch(
 				// Break out of the loop now and process what we are able to process
 				// in the constructed batches.
 				ll.Infow("Insufficient link balance to fulfill a request, breaking")
+				lsn.recordInsufficientBalance(p, startBalanceNoReserveLink, p.maxLink)
 				break
 			}
 
@@ -698,6 +732,7 @@ func (lsn *listenerV2) processRequestsPerSub(
 		lsn.q, startBalance, chainId.Uint64(), subID)
 	if err != nil {
 		lsn.l.Errorw("Couldn't get reserved LINK for subscription", "sub", reqs[0].req.SubId, "err", err)
+		lsn.recordSubSkip(subID, fmt.Sprintf("unable to get reserved LINK for subscription: %v", err))
 		return processed
 	}
 
@@ -767,6 +802,7 @@ func (lsn *listenerV2) processRequestsPerSub(
 			if p.err != nil {
 				if startBalanceNoReserveLink.Cmp(p.juelsNeeded) < 0 && errors.Is(p.err, errPossiblyInsufficientFunds{}) {
 					ll.Infow("Insufficient link balance to fulfill a request based on estimate, returning", "err", p.err)
+					lsn.recordInsufficientBalance(p, startBalanceNoReserveLink, p.juelsNeeded)
 					return processed
 				}
 
@@ -774,8 +810,10 @@ func (lsn *listenerV2) processRequestsPerSub(
 					// Running the blockhash store feeder in backwards mode will be required to
 					// resolve this.
 					ll.Criticalw("Pipeline error", "err", p.err)
+					lsn.recordSkip(p.req, "blockhash of the request block is not in the blockhash store")
 				} else {
 					ll.Errorw("Pipeline error", "err", p.err)
+					lsn.recordSkip(p.req, fmt.Sprintf("simulation failed: %v", p.err))
 
 					// Ensure consumer is valid, otherwise drop the request.
 					if !lsn.isConsumerValidAfterFinalityDepthElapsed(ctx, p.req) {
@@ -795,12 +833,14 @@ func (lsn *listenerV2) processRequestsPerSub(
 			if startBalanceNoReserveLink.Cmp(p.maxLink) < 0 {
 				// Insufficient funds, have to wait for a user top up. Leave it unprocessed for now
 				ll.Infow("Insufficient link balance to fulfill a request, returning")
+				lsn.recordInsufficientBalance(p, startBalanceNoReserveLink, p.maxLink)
 				return processed
 			}
 
 			fromAddress, err := lsn.gethks.GetRoundRobinAddress(lsn.chainID, fromAddresses...)
 			if err != nil {
 				l.Errorw("Couldn't get next from address", "err", err)
+				lsn.recordSkip(p.req, fmt.Sprintf("unable to get a from address: %v", err))
 				continue
 			}
 			ll = ll.With("fromAddress", fromAddress)
@@ -840,6 +880,7 @@ func (lsn *listenerV2) processRequestsPerSub(
 			})
 			if err != nil {
 				ll.Errorw("Error enqueuing fulfillment, requeuing request", "err", err)
+				lsn.recordSkip(p.req, fmt.Sprintf("unable to enqueue fulfillment: %v", err))
 				continue
 			}
 			ll.Infow("Enqueued fulfillment", "ethTxID", transaction.GetID())
@@ -956,6 +997,9 @@ func (lsn *listenerV2) runPipelines(
 		}(i, req)
 	}
 	wg.Wait()
+	for _, res := range results {
+		lsn.recordFeeEstimate(res.req, res.juelsNeeded)
+	}
 
 	l.Debugw("Finished running pipelines",
 		"count", len(reqs), "time", time.Since(start).String())
@@ -1082,6 +1126,9 @@ func (lsn *listenerV2) runRequestHandler(pollPeriod time.Duration, wg *sync.Wait
 			return
 		case <-tick.C:
 			lsn.processPendingVRFRequests(ctx)
+		case <-lsn.chRetry:
+			lsn.l.Infow("Retry requested, processing pending requests")
+			lsn.processPendingVRFRequests(ctx)
 		}
 	}
 }
@@ -1196,6 +1243,7 @@ func (lsn *listenerV2) markLogAsConsumed(lb log.Broadcast) {
 // Close complies with job.Service
 func (lsn *listenerV2) Close() error {
 	return lsn.StopOnce("VRFListenerV2", func() error {
+		lsn.backlog.unregister(lsn)
 		close(lsn.chStop)
 		// wait on the request handler, log listener, and head listener to stop
 		lsn.wg.Wait()
@@ -1,6 +1,7 @@
 package vrf
 
 import (
+	"fmt"
 	"math/big"
 	"time"
 
@@ -114,6 +115,7 @@ func (lsn *listenerV2) processBatch(
 		// should never happen
 		l.Errorw("Failed to pack batch fulfillRandomWords payload",
 			"err", err, "proofs", batch.proofs, "commitments", batch.commitments)
+		lsn.recordBatchSkip(batch, fmt.Sprintf("unable to pack batch fulfillment: %v", err))
 		return
 	}
 
@@ -129,6 +131,7 @@ func (lsn *listenerV2) processBatch(
 	fromAddress, err := lsn.gethks.GetRoundRobinAddress(lsn.chainID, fromAddresses...)
 	if err != nil {
 		l.Errorw("Couldn't get next from address", "err", err)
+		lsn.recordBatchSkip(batch, fmt.Sprintf("unable to get a from address: %v", err))
 		return
 	}
 
@@ -176,6 +179,7 @@ func (lsn *listenerV2) processBatch(
 	})
 	if err != nil {
 		ll.Errorw("Error enqueuing batch fulfillments, requeuing requests", "err", err)
+		lsn.recordBatchSkip(batch, fmt.Sprintf("unable to enqueue batch fulfillment: %v", err))
 		return
 	}
 	ll.Infow("Enqueued fulfillment", "ethTxID", ethTX.GetID())
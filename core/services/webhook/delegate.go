@@ -3,6 +3,7 @@ package webhook
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -23,17 +24,25 @@ type (
 	}
 
 	JobRunner interface {
-		RunJob(ctx context.Context, jobUUID uuid.UUID, requestBody string, meta pipeline.JSONSerializable) (int64, error)
+		RunJob(ctx context.Context, jobUUID uuid.UUID, requestBody string, meta pipeline.JSONSerializable, opts RunJobOpts) (int64, error)
+	}
+
+	// RunJobOpts are the signature and idempotency headers of a request
+	// triggering a webhook job.
+	RunJobOpts struct {
+		Timestamp      string
+		Signature      string
+		IdempotencyKey string
 	}
 )
 
 var _ job.Delegate = (*Delegate)(nil)
 
-func NewDelegate(runner pipeline.Runner, externalInitiatorManager ExternalInitiatorManager, lggr logger.Logger) *Delegate {
+func NewDelegate(runner pipeline.Runner, externalInitiatorManager ExternalInitiatorManager, orm ORM, lggr logger.Logger) *Delegate {
 	lggr = lggr.Named("Webhook")
 	return &Delegate{
 		externalInitiatorManager: externalInitiatorManager,
-		webhookJobRunner:         newWebhookJobRunner(runner, lggr),
+		webhookJobRunner:         newWebhookJobRunner(runner, orm, lggr),
 		lggr:                     lggr,
 	}
 }
@@ -98,13 +107,15 @@ type webhookJobRunner struct {
 	specsByUUID   map[uuid.UUID]registeredJob
 	muSpecsByUUID sync.RWMutex
 	runner        pipeline.Runner
+	orm           ORM
 	lggr          logger.Logger
 }
 
-func newWebhookJobRunner(runner pipeline.Runner, lggr logger.Logger) *webhookJobRunner {
+func newWebhookJobRunner(runner pipeline.Runner, orm ORM, lggr logger.Logger) *webhookJobRunner {
 	return &webhookJobRunner{
 		specsByUUID: make(map[uuid.UUID]registeredJob),
 		runner:      runner,
+		orm:         orm,
 		lggr:        lggr.Named("JobRunner"),
 	}
 }
@@ -144,7 +155,11 @@ func (r *webhookJobRunner) spec(externalJobID uuid.UUID) (registeredJob, bool) {
 
 var ErrJobNotExists = errors.New("job does not exist")
 
-func (r *webhookJobRunner) RunJob(ctx context.Context, jobUUID uuid.UUID, requestBody string, meta pipeline.JSONSerializable) (int64, error) {
+// RunJob runs the webhook job with the given UUID, after checking the
+// signature of the request if the job requires one. If the request carries
+// an idempotency key that started a run before, the ID of that run is
+// returned instead of starting a new one.
+func (r *webhookJobRunner) RunJob(ctx context.Context, jobUUID uuid.UUID, requestBody string, meta pipeline.JSONSerializable, opts RunJobOpts) (int64, error) {
 	spec, exists := r.spec(jobUUID)
 	if !exists {
 		return 0, ErrJobNotExists
@@ -155,9 +170,41 @@ func (r *webhookJobRunner) RunJob(ctx context.Context, jobUUID uuid.UUID, reques
 		"uuid", spec.ExternalJobID,
 	)
 
+	if err := VerifySignature(*spec.WebhookSpec, requestBody, opts.Timestamp, opts.Signature, time.Now()); err != nil {
+		return 0, err
+	}
+
 	ctx, cancel := spec.chRemove.Ctx(ctx)
 	defer cancel()
 
+	if opts.IdempotencyKey == "" {
+		return r.run(ctx, spec, requestBody, meta, jobLggr)
+	}
+
+	existingRunID, err := r.orm.ReserveIdempotencyKey(spec.ID, opts.IdempotencyKey, pg.WithParentCtx(ctx))
+	if err != nil {
+		return 0, err
+	}
+	if existingRunID.Valid {
+		jobLggr.Debugw("Webhook job was already run for idempotency key", "runID", existingRunID.Int64)
+		return existingRunID.Int64, nil
+	}
+	runID, err := r.run(ctx, spec, requestBody, meta, jobLggr)
+	if err != nil {
+		if err2 := r.orm.ReleaseIdempotencyKey(spec.ID, opts.IdempotencyKey); err2 != nil {
+			jobLggr.Errorw("Failed to release idempotency key", "error", err2)
+		}
+		return 0, err
+	}
+	// The run has started, so it is returned even if it cannot be recorded
+	// for the key. Retries then fail until the reservation becomes stale.
+	if err = r.orm.SetIdempotencyKeyRun(spec.ID, opts.IdempotencyKey, runID); err != nil {
+		jobLggr.Errorw("Failed to set run of idempotency key", "runID", runID, "error", err)
+	}
+	return runID, nil
+}
+
+func (r *webhookJobRunner) run(ctx context.Context, spec registeredJob, requestBody string, meta pipeline.JSONSerializable, jobLggr logger.Logger) (int64, error) {
 	vars := pipeline.NewVarsFrom(map[string]interface{}{
 		"jobSpec": map[string]interface{}{
 			"databaseID":    spec.ID,
@@ -1,7 +1,9 @@
 package webhook_test
 
 import (
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"gopkg.in/guregu/null.v4"
@@ -18,6 +20,7 @@ import (
 	pipelinemocks "github.com/smartcontractkit/chainlink/v2/core/services/pipeline/mocks"
 	"github.com/smartcontractkit/chainlink/v2/core/services/webhook"
 	webhookmocks "github.com/smartcontractkit/chainlink/v2/core/services/webhook/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/store/models"
 )
 
 func TestWebhookDelegate(t *testing.T) {
@@ -47,7 +50,7 @@ func TestWebhookDelegate(t *testing.T) {
 		}
 		runner    = pipelinemocks.NewRunner(t)
 		eiManager = new(webhookmocks.ExternalInitiatorManager)
-		delegate  = webhook.NewDelegate(runner, eiManager, logger.TestLogger(t))
+		delegate  = webhook.NewDelegate(runner, eiManager, webhookmocks.NewORM(t), logger.TestLogger(t))
 	)
 
 	services, err := delegate.ServicesForSpec(*spec)
@@ -56,7 +59,7 @@ func TestWebhookDelegate(t *testing.T) {
 	service := services[0]
 
 	// Should error before service is started
-	_, err = delegate.WebhookJobRunner().RunJob(testutils.Context(t), spec.ExternalJobID, requestBody, meta)
+	_, err = delegate.WebhookJobRunner().RunJob(testutils.Context(t), spec.ExternalJobID, requestBody, meta, webhook.RunJobOpts{})
 	require.Error(t, err)
 	require.Equal(t, webhook.ErrJobNotExists, errors.Cause(err))
 
@@ -73,7 +76,7 @@ func TestWebhookDelegate(t *testing.T) {
 			require.Equal(t, vars, run.Inputs.Val)
 		}).Once()
 
-	runID, err := delegate.WebhookJobRunner().RunJob(testutils.Context(t), spec.ExternalJobID, requestBody, meta)
+	runID, err := delegate.WebhookJobRunner().RunJob(testutils.Context(t), spec.ExternalJobID, requestBody, meta, webhook.RunJobOpts{})
 	require.NoError(t, err)
 	require.Equal(t, int64(123), runID)
 
@@ -83,13 +86,119 @@ func TestWebhookDelegate(t *testing.T) {
 	runner.On("Run", mock.Anything, mock.AnythingOfType("*pipeline.Run"), mock.Anything, mock.Anything, mock.Anything).
 		Return(false, expectedErr).Once()
 
-	_, err = delegate.WebhookJobRunner().RunJob(testutils.Context(t), spec.ExternalJobID, requestBody, meta)
+	_, err = delegate.WebhookJobRunner().RunJob(testutils.Context(t), spec.ExternalJobID, requestBody, meta, webhook.RunJobOpts{})
 	require.Equal(t, expectedErr, errors.Cause(err))
 
 	// Should error after service is stopped
 	err = service.Close()
 	require.NoError(t, err)
 
-	_, err = delegate.WebhookJobRunner().RunJob(testutils.Context(t), spec.ExternalJobID, requestBody, meta)
+	_, err = delegate.WebhookJobRunner().RunJob(testutils.Context(t), spec.ExternalJobID, requestBody, meta, webhook.RunJobOpts{})
 	require.Equal(t, webhook.ErrJobNotExists, errors.Cause(err))
 }
+
+func TestWebhookDelegate_IdempotencyKey(t *testing.T) {
+	var (
+		spec = job.Job{
+			ID:            123,
+			Type:          job.Webhook,
+			ExternalJobID: uuid.New(),
+			WebhookSpec:   &job.WebhookSpec{},
+			PipelineSpec:  &pipeline.Spec{},
+		}
+		opts     = webhook.RunJobOpts{IdempotencyKey: "retry-me"}
+		runner   = pipelinemocks.NewRunner(t)
+		orm      = webhookmocks.NewORM(t)
+		delegate = webhook.NewDelegate(runner, new(webhookmocks.ExternalInitiatorManager), orm, logger.TestLogger(t))
+	)
+
+	services, err := delegate.ServicesForSpec(spec)
+	require.NoError(t, err)
+	require.NoError(t, services[0].Start(testutils.Context(t)))
+	t.Cleanup(func() { require.NoError(t, services[0].Close()) })
+
+	// The first request reserves the key and records the run it started.
+	orm.On("ReserveIdempotencyKey", spec.ID, "retry-me", mock.Anything).Return(null.Int{}, nil).Once()
+	runner.On("Run", mock.Anything, mock.AnythingOfType("*pipeline.Run"), mock.Anything, mock.Anything, mock.Anything).
+		Return(false, nil).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*pipeline.Run).ID = int64(42)
+		}).Once()
+	orm.On("SetIdempotencyKeyRun", spec.ID, "retry-me", int64(42)).Return(nil).Once()
+
+	runID, err := delegate.WebhookJobRunner().RunJob(testutils.Context(t), spec.ExternalJobID, "foo", pipeline.JSONSerializable{}, opts)
+	require.NoError(t, err)
+	require.Equal(t, int64(42), runID)
+
+	// A retry returns the original run without running the pipeline.
+	orm.On("ReserveIdempotencyKey", spec.ID, "retry-me", mock.Anything).Return(null.IntFrom(42), nil).Once()
+	runID, err = delegate.WebhookJobRunner().RunJob(testutils.Context(t), spec.ExternalJobID, "foo", pipeline.JSONSerializable{}, opts)
+	require.NoError(t, err)
+	require.Equal(t, int64(42), runID)
+
+	// A retry of a run in progress fails.
+	orm.On("ReserveIdempotencyKey", spec.ID, "retry-me", mock.Anything).Return(null.Int{}, webhook.ErrIdempotencyKeyInProgress).Once()
+	_, err = delegate.WebhookJobRunner().RunJob(testutils.Context(t), spec.ExternalJobID, "foo", pipeline.JSONSerializable{}, opts)
+	require.ErrorIs(t, err, webhook.ErrIdempotencyKeyInProgress)
+
+	// The key is released if the run fails, so that it can be retried.
+	orm.On("ReserveIdempotencyKey", spec.ID, "fail-me", mock.Anything).Return(null.Int{}, nil).Once()
+	runner.On("Run", mock.Anything, mock.AnythingOfType("*pipeline.Run"), mock.Anything, mock.Anything, mock.Anything).
+		Return(false, errors.New("foo bar")).Once()
+	orm.On("ReleaseIdempotencyKey", spec.ID, "fail-me").Return(nil).Once()
+	_, err = delegate.WebhookJobRunner().RunJob(testutils.Context(t), spec.ExternalJobID, "foo", pipeline.JSONSerializable{}, webhook.RunJobOpts{IdempotencyKey: "fail-me"})
+	require.EqualError(t, err, "foo bar")
+
+	// A run that started is returned even if it cannot be recorded for the key.
+	orm.On("ReserveIdempotencyKey", spec.ID, "unrecorded", mock.Anything).Return(null.Int{}, nil).Once()
+	runner.On("Run", mock.Anything, mock.AnythingOfType("*pipeline.Run"), mock.Anything, mock.Anything, mock.Anything).
+		Return(false, nil).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*pipeline.Run).ID = int64(43)
+		}).Once()
+	orm.On("SetIdempotencyKeyRun", spec.ID, "unrecorded", int64(43)).Return(errors.New("db down")).Once()
+	runID, err = delegate.WebhookJobRunner().RunJob(testutils.Context(t), spec.ExternalJobID, "foo", pipeline.JSONSerializable{}, webhook.RunJobOpts{IdempotencyKey: "unrecorded"})
+	require.NoError(t, err)
+	require.Equal(t, int64(43), runID)
+}
+
+func TestWebhookDelegate_Signature(t *testing.T) {
+	const secret = "0123456789abcdef0123456789abcdef"
+	var (
+		spec = job.Job{
+			ID:            123,
+			Type:          job.Webhook,
+			ExternalJobID: uuid.New(),
+			WebhookSpec: &job.WebhookSpec{
+				HMACSecret:             null.StringFrom(secret),
+				HMACTimestampTolerance: models.Interval(time.Minute),
+			},
+			PipelineSpec: &pipeline.Spec{},
+		}
+		runner   = pipelinemocks.NewRunner(t)
+		delegate = webhook.NewDelegate(runner, new(webhookmocks.ExternalInitiatorManager), webhookmocks.NewORM(t), logger.TestLogger(t))
+	)
+
+	services, err := delegate.ServicesForSpec(spec)
+	require.NoError(t, err)
+	require.NoError(t, services[0].Start(testutils.Context(t)))
+	t.Cleanup(func() { require.NoError(t, services[0].Close()) })
+
+	// Unsigned requests are rejected without running the pipeline.
+	_, err = delegate.WebhookJobRunner().RunJob(testutils.Context(t), spec.ExternalJobID, "foo", pipeline.JSONSerializable{}, webhook.RunJobOpts{})
+	require.ErrorIs(t, err, webhook.ErrInvalidSignature)
+
+	runner.On("Run", mock.Anything, mock.AnythingOfType("*pipeline.Run"), mock.Anything, mock.Anything, mock.Anything).
+		Return(false, nil).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*pipeline.Run).ID = int64(42)
+		}).Once()
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	runID, err := delegate.WebhookJobRunner().RunJob(testutils.Context(t), spec.ExternalJobID, "foo", pipeline.JSONSerializable{}, webhook.RunJobOpts{
+		Timestamp: timestamp,
+		Signature: webhook.Sign(secret, timestamp, "foo"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(42), runID)
+}
@@ -0,0 +1,92 @@
+package webhook
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/sqlx"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+)
+
+//go:generate mockery --quiet --name ORM --output ./mocks/ --case=underscore
+
+var ErrIdempotencyKeyInProgress = errors.New("a run with this idempotency key is in progress")
+
+// staleReservationTimeout is the time after which the reservation of a key
+// whose run never finished, e.g. because the node crashed, can be taken over
+// by a new request.
+const staleReservationTimeout = time.Hour
+
+// ORM stores the idempotency keys of the requests triggering webhook jobs.
+type ORM interface {
+	// ReserveIdempotencyKey reserves the key for a new run of the job. If the
+	// key was reserved before, it returns the ID of the run that the key was
+	// reserved for, or ErrIdempotencyKeyInProgress if that run did not finish
+	// yet. Stale reservations are taken over.
+	ReserveIdempotencyKey(jobID int32, key string, qopts ...pg.QOpt) (existingRunID null.Int, err error)
+	// SetIdempotencyKeyRun records the run that a reserved key started.
+	SetIdempotencyKeyRun(jobID int32, key string, runID int64, qopts ...pg.QOpt) error
+	// ReleaseIdempotencyKey deletes a reservation whose run failed to start,
+	// so that the request can be retried.
+	ReleaseIdempotencyKey(jobID int32, key string, qopts ...pg.QOpt) error
+}
+
+type orm struct {
+	q pg.Q
+}
+
+var _ ORM = &orm{}
+
+// NewORM returns an ORM backed by db.
+func NewORM(db *sqlx.DB, lggr logger.Logger, cfg pg.QConfig) ORM {
+	return &orm{q: pg.NewQ(db, lggr.Named("WebhookORM"), cfg)}
+}
+
+func (o *orm) ReserveIdempotencyKey(jobID int32, key string, qopts ...pg.QOpt) (existingRunID null.Int, err error) {
+	q := o.q.WithOpts(qopts...)
+	err = q.Transaction(func(tx pg.Queryer) error {
+		res, err2 := tx.Exec(`INSERT INTO webhook_idempotency_keys (job_id, idempotency_key, created_at)
+			VALUES ($1, $2, NOW())
+			ON CONFLICT (job_id, idempotency_key) DO UPDATE SET created_at = NOW()
+			WHERE webhook_idempotency_keys.pipeline_run_id IS NULL AND webhook_idempotency_keys.created_at < $3`,
+			jobID, key, time.Now().Add(-staleReservationTimeout))
+		if err2 != nil {
+			return errors.Wrap(err2, "failed to reserve idempotency key")
+		}
+		rowsAffected, err2 := res.RowsAffected()
+		if err2 != nil {
+			return errors.Wrap(err2, "failed to get RowsAffected")
+		}
+		if rowsAffected > 0 {
+			return nil
+		}
+		err2 = tx.Get(&existingRunID, `SELECT pipeline_run_id FROM webhook_idempotency_keys WHERE job_id = $1 AND idempotency_key = $2`, jobID, key)
+		if errors.Is(err2, sql.ErrNoRows) {
+			// The reservation was released concurrently.
+			return ErrIdempotencyKeyInProgress
+		} else if err2 != nil {
+			return errors.Wrap(err2, "failed to find run of idempotency key")
+		}
+		if !existingRunID.Valid {
+			return ErrIdempotencyKeyInProgress
+		}
+		return nil
+	})
+	return existingRunID, err
+}
+
+func (o *orm) SetIdempotencyKeyRun(jobID int32, key string, runID int64, qopts ...pg.QOpt) error {
+	q := o.q.WithOpts(qopts...)
+	_, err := q.Exec(`UPDATE webhook_idempotency_keys SET pipeline_run_id = $3 WHERE job_id = $1 AND idempotency_key = $2`, jobID, key, runID)
+	return errors.Wrap(err, "failed to set run of idempotency key")
+}
+
+func (o *orm) ReleaseIdempotencyKey(jobID int32, key string, qopts ...pg.QOpt) error {
+	q := o.q.WithOpts(qopts...)
+	_, err := q.Exec(`DELETE FROM webhook_idempotency_keys WHERE job_id = $1 AND idempotency_key = $2 AND pipeline_run_id IS NULL`, jobID, key)
+	return errors.Wrap(err, "failed to release idempotency key")
+}
@@ -0,0 +1,73 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+)
+
+const (
+	// SignatureHeader is the header carrying the hex encoded HMAC-SHA256 of
+	// the timestamp and body of a request triggering a webhook job.
+	SignatureHeader = "X-Chainlink-Signature"
+	// TimestampHeader is the header carrying the unix time in seconds at
+	// which a request triggering a webhook job was signed.
+	TimestampHeader = "X-Chainlink-Timestamp"
+	// IdempotencyKeyHeader is the header carrying the idempotency key of a
+	// request triggering a webhook job.
+	IdempotencyKeyHeader = "Idempotency-Key"
+)
+
+var ErrInvalidSignature = errors.New("invalid request signature")
+
+// Sign returns the signature of a request body signed at the given unix
+// timestamp. The signature is the hex encoded HMAC-SHA256 of the timestamp
+// and the body, joined by a dot.
+func Sign(secret string, timestamp string, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks the signature of a request triggering the webhook
+// job with the given spec. Requests for jobs without an HMAC secret are not
+// signed, so they always pass.
+func VerifySignature(spec job.WebhookSpec, body, timestamp, signature string, now time.Time) error {
+	if !spec.HMACSecret.Valid {
+		return nil
+	}
+	if timestamp == "" || signature == "" {
+		return errors.Wrapf(ErrInvalidSignature, "%s and %s headers are required", SignatureHeader, TimestampHeader)
+	}
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.Wrapf(ErrInvalidSignature, "malformed %s header", TimestampHeader)
+	}
+	skew := now.Sub(time.Unix(unix, 0))
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > spec.HMACTimestampTolerance.Duration() {
+		return errors.Wrapf(ErrInvalidSignature, "timestamp is more than %s away from the current time", spec.HMACTimestampTolerance.Duration())
+	}
+	actual, err := hex.DecodeString(signature)
+	if err != nil {
+		return errors.Wrapf(ErrInvalidSignature, "malformed %s header", SignatureHeader)
+	}
+	expected, err := hex.DecodeString(Sign(spec.HMACSecret.String, timestamp, body))
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal(actual, expected) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package webhook_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/webhook"
+	"github.com/smartcontractkit/chainlink/v2/core/store/models"
+)
+
+func TestVerifySignature(t *testing.T) {
+	t.Parallel()
+
+	const (
+		secret = "0123456789abcdef0123456789abcdef"
+		body   = `{"foo": 42}`
+	)
+	now := time.Unix(1700000000, 0)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	spec := job.WebhookSpec{
+		HMACSecret:             null.StringFrom(secret),
+		HMACTimestampTolerance: models.Interval(time.Minute),
+	}
+
+	tests := []struct {
+		name      string
+		spec      job.WebhookSpec
+		body      string
+		timestamp string
+		signature string
+		now       time.Time
+		valid     bool
+	}{
+		{"valid", spec, body, timestamp, webhook.Sign(secret, timestamp, body), now, true},
+		{"within tolerance", spec, body, timestamp, webhook.Sign(secret, timestamp, body), now.Add(-time.Minute), true},
+		{"no secret", job.WebhookSpec{}, body, "", "", now, true},
+		{"missing headers", spec, body, "", "", now, false},
+		{"malformed timestamp", spec, body, "yesterday", webhook.Sign(secret, "yesterday", body), now, false},
+		{"malformed signature", spec, body, timestamp, "not hex", now, false},
+		{"expired", spec, body, timestamp, webhook.Sign(secret, timestamp, body), now.Add(time.Minute + time.Second), false},
+		{"from the future", spec, body, timestamp, webhook.Sign(secret, timestamp, body), now.Add(-time.Minute - time.Second), false},
+		{"wrong secret", spec, body, timestamp, webhook.Sign("fedcba9876543210fedcba9876543210", timestamp, body), now, false},
+		{"tampered body", spec, `{"foo": 43}`, timestamp, webhook.Sign(secret, timestamp, body), now, false},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := webhook.VerifySignature(tc.spec, tc.body, tc.timestamp, tc.signature, tc.now)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, webhook.ErrInvalidSignature)
+			}
+		})
+	}
+}
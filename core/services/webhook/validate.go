@@ -1,21 +1,35 @@
 package webhook
 
 import (
+	"time"
+
 	"github.com/pelletier/go-toml"
 	"github.com/pkg/errors"
 	"go.uber.org/multierr"
+	"gopkg.in/guregu/null.v4"
 
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
 	"github.com/smartcontractkit/chainlink/v2/core/store/models"
 )
 
+const (
+	// MinHMACSecretLength is the minimum length of the HMAC secret of a
+	// webhook job.
+	MinHMACSecretLength = 32
+	// DefaultHMACTimestampTolerance is the timestamp tolerance of signed
+	// requests, if the job does not set one.
+	DefaultHMACTimestampTolerance = 5 * time.Minute
+)
+
 type TOMLWebhookSpecExternalInitiator struct {
 	Name string      `toml:"name"`
 	Spec models.JSON `toml:"spec"`
 }
 
 type TOMLWebhookSpec struct {
-	ExternalInitiators []TOMLWebhookSpecExternalInitiator `toml:"externalInitiators"`
+	ExternalInitiators     []TOMLWebhookSpecExternalInitiator `toml:"externalInitiators"`
+	HMACSecret             string                             `toml:"hmacSecret"`
+	HMACTimestampTolerance *models.Interval                   `toml:"hmacTimestampTolerance"`
 }
 
 func ValidatedWebhookSpec(tomlString string, externalInitiatorManager ExternalInitiatorManager) (jb job.Job, err error) {
@@ -60,6 +74,32 @@ func ValidatedWebhookSpec(tomlString string, externalInitiatorManager ExternalIn
 	jb.WebhookSpec = &job.WebhookSpec{
 		ExternalInitiatorWebhookSpecs: externalInitiatorWebhookSpecs,
 	}
+	if err = validateHMAC(tomlSpec, jb.WebhookSpec); err != nil {
+		return jb, err
+	}
 
 	return jb, nil
 }
+
+// validateHMAC validates the request signing settings of the spec and copies
+// them to the webhook spec.
+func validateHMAC(tomlSpec TOMLWebhookSpec, spec *job.WebhookSpec) error {
+	if tomlSpec.HMACSecret == "" {
+		if tomlSpec.HMACTimestampTolerance != nil {
+			return errors.New(`"hmacTimestampTolerance" is set, but "hmacSecret" is not`)
+		}
+		return nil
+	}
+	if len(tomlSpec.HMACSecret) < MinHMACSecretLength {
+		return errors.Errorf(`"hmacSecret" must be at least %d characters long`, MinHMACSecretLength)
+	}
+	spec.HMACSecret = null.StringFrom(tomlSpec.HMACSecret)
+	spec.HMACTimestampTolerance = models.Interval(DefaultHMACTimestampTolerance)
+	if tomlSpec.HMACTimestampTolerance != nil {
+		if tomlSpec.HMACTimestampTolerance.Duration() <= 0 {
+			return errors.New(`"hmacTimestampTolerance" must be positive`)
+		}
+		spec.HMACTimestampTolerance = *tomlSpec.HMACTimestampTolerance
+	}
+	return nil
+}
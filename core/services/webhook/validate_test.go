@@ -2,11 +2,13 @@ package webhook_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/manyminds/api2go/jsonapi"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
 
 	"github.com/smartcontractkit/chainlink/v2/core/bridges"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
@@ -142,6 +144,66 @@ func TestValidatedWebJobSpec(t *testing.T) {
 				require.EqualError(t, err, "unable to find external initiator named bar: something exploded; unable to find external initiator named baz: something exploded")
 			},
 		},
+		{
+			name: "with HMAC secret",
+			toml: `
+            type            = "webhook"
+            schemaVersion   = 1
+            hmacSecret      = "0123456789abcdef0123456789abcdef"
+            hmacTimestampTolerance = "30s"
+            observationSource   = """
+                ds          [type=http method=GET url="https://chain.link/ETH-USD"];
+            """
+            `,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, null.StringFrom("0123456789abcdef0123456789abcdef"), s.WebhookSpec.HMACSecret)
+				assert.Equal(t, 30*time.Second, s.WebhookSpec.HMACTimestampTolerance.Duration())
+			},
+		},
+		{
+			name: "with HMAC secret and default tolerance",
+			toml: `
+            type            = "webhook"
+            schemaVersion   = 1
+            hmacSecret      = "0123456789abcdef0123456789abcdef"
+            observationSource   = """
+                ds          [type=http method=GET url="https://chain.link/ETH-USD"];
+            """
+            `,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, webhook.DefaultHMACTimestampTolerance, s.WebhookSpec.HMACTimestampTolerance.Duration())
+			},
+		},
+		{
+			name: "with short HMAC secret",
+			toml: `
+            type            = "webhook"
+            schemaVersion   = 1
+            hmacSecret      = "secret"
+            observationSource   = """
+                ds          [type=http method=GET url="https://chain.link/ETH-USD"];
+            """
+            `,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				require.EqualError(t, err, `"hmacSecret" must be at least 32 characters long`)
+			},
+		},
+		{
+			name: "with HMAC timestamp tolerance but no secret",
+			toml: `
+            type            = "webhook"
+            schemaVersion   = 1
+            hmacTimestampTolerance = "30s"
+            observationSource   = """
+                ds          [type=http method=GET url="https://chain.link/ETH-USD"];
+            """
+            `,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				require.EqualError(t, err, `"hmacTimestampTolerance" is set, but "hmacSecret" is not`)
+			},
+		},
 	}
 	for _, tc := range tt {
 		tc := tc
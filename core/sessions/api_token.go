@@ -0,0 +1,98 @@
+package sessions
+
+import (
+	"crypto/subtle"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/v2/core/auth"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+// APIToken is a named API token belonging to a user. Unlike the single token
+// stored on the users table, a user may hold many of them, each of which may
+// expire and may be scoped to a role narrower than the user's own.
+type APIToken struct {
+	ID                int64
+	UserEmail         string
+	Name              string
+	Role              UserRole
+	TokenKey          string
+	TokenSalt         string
+	TokenHashedSecret string
+	ExpiresAt         null.Time
+	LastUsed          null.Time
+	CreatedAt         time.Time
+}
+
+// APITokenRequest is sent when creating a named API token.
+type APITokenRequest struct {
+	Name      string     `json:"name"`
+	Role      string     `json:"role"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+	Password  string     `json:"password"`
+}
+
+// Expired returns true if the token has an expiry at or before now.
+func (t APIToken) Expired(now time.Time) bool {
+	return t.ExpiresAt.Valid && !now.Before(t.ExpiresAt.Time)
+}
+
+// EffectiveRole returns the role granted to requests made with the token,
+// which is never more privileged than the current role of its owner.
+func (t APIToken) EffectiveRole(owner UserRole) UserRole {
+	if owner.Includes(t.Role) {
+		return t.Role
+	}
+	return owner
+}
+
+// NewAPIToken validates the requested name, role and expiry against the owner
+// and returns the new token together with its plaintext credentials, which are
+// not stored.
+func NewAPIToken(owner User, name string, role UserRole, expiresAt null.Time) (APIToken, *auth.Token, error) {
+	if name == "" {
+		return APIToken{}, nil, errors.New("token name must be specified")
+	}
+	if _, err := GetUserRole(string(role)); err != nil {
+		return APIToken{}, nil, err
+	}
+	if !owner.Role.Includes(role) {
+		return APIToken{}, nil, errors.Errorf("token role %s exceeds the role %s of user %s", role, owner.Role, owner.Email)
+	}
+	if expiresAt.Valid && !expiresAt.Time.After(time.Now()) {
+		return APIToken{}, nil, errors.New("token expiry must be in the future")
+	}
+
+	token := auth.NewToken()
+	salt := utils.NewSecret(utils.DefaultSecretSize)
+	hashedSecret, err := auth.HashedSecret(token, salt)
+	if err != nil {
+		return APIToken{}, nil, errors.Wrap(err, "api token")
+	}
+	return APIToken{
+		UserEmail:         owner.Email,
+		Name:              name,
+		Role:              role,
+		TokenKey:          token.AccessKey,
+		TokenSalt:         salt,
+		TokenHashedSecret: hashedSecret,
+		ExpiresAt:         expiresAt,
+	}, token, nil
+}
+
+// AuthenticateAPIToken returns true on successful authentication of the named
+// API token against the given Authentication Token. Expired tokens never
+// authenticate.
+func AuthenticateAPIToken(token *auth.Token, apiToken *APIToken) (bool, error) {
+	if apiToken.Expired(time.Now()) {
+		return false, nil
+	}
+	hashedSecret, err := auth.HashedSecret(token, apiToken.TokenSalt)
+	if err != nil {
+		return false, err
+	}
+	return subtle.ConstantTimeCompare([]byte(hashedSecret), []byte(apiToken.TokenHashedSecret)) == 1, nil
+}
@@ -0,0 +1,68 @@
+package sessions_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/v2/core/auth"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+)
+
+func TestUserRole_Includes(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, sessions.UserRoleAdmin.Includes(sessions.UserRoleAdmin))
+	assert.True(t, sessions.UserRoleAdmin.Includes(sessions.UserRoleView))
+	assert.True(t, sessions.UserRoleEdit.Includes(sessions.UserRoleRun))
+	assert.False(t, sessions.UserRoleRun.Includes(sessions.UserRoleEdit))
+	assert.False(t, sessions.UserRoleView.Includes(sessions.UserRoleRun))
+	assert.False(t, sessions.UserRoleAdmin.Includes("superuser"))
+	assert.False(t, sessions.UserRole("superuser").Includes(sessions.UserRoleView))
+}
+
+func TestNewAPIToken(t *testing.T) {
+	t.Parallel()
+
+	owner := sessions.User{Email: "ci@example.com", Role: sessions.UserRoleEdit}
+
+	apiToken, token, err := sessions.NewAPIToken(owner, "ci", sessions.UserRoleRun, null.TimeFrom(time.Now().Add(time.Hour)))
+	require.NoError(t, err)
+	assert.Equal(t, owner.Email, apiToken.UserEmail)
+	assert.Equal(t, token.AccessKey, apiToken.TokenKey)
+	assert.NotEqual(t, token.Secret, apiToken.TokenHashedSecret)
+
+	ok, err := sessions.AuthenticateAPIToken(token, &apiToken)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = sessions.AuthenticateAPIToken(&auth.Token{AccessKey: token.AccessKey, Secret: "wrong"}, &apiToken)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	apiToken.ExpiresAt = null.TimeFrom(time.Now().Add(-time.Second))
+	ok, err = sessions.AuthenticateAPIToken(token, &apiToken)
+	require.NoError(t, err)
+	assert.False(t, ok, "expired tokens must not authenticate")
+
+	_, _, err = sessions.NewAPIToken(owner, "", sessions.UserRoleRun, null.Time{})
+	require.ErrorContains(t, err, "name must be specified")
+	_, _, err = sessions.NewAPIToken(owner, "ci", sessions.UserRoleAdmin, null.Time{})
+	require.ErrorContains(t, err, "exceeds the role edit")
+	_, _, err = sessions.NewAPIToken(owner, "ci", "superuser", null.Time{})
+	require.ErrorContains(t, err, "Invalid role")
+	_, _, err = sessions.NewAPIToken(owner, "ci", sessions.UserRoleRun, null.TimeFrom(time.Now().Add(-time.Hour)))
+	require.ErrorContains(t, err, "must be in the future")
+}
+
+func TestAPIToken_EffectiveRole(t *testing.T) {
+	t.Parallel()
+
+	apiToken := sessions.APIToken{Role: sessions.UserRoleEdit}
+	assert.Equal(t, sessions.UserRoleEdit, apiToken.EffectiveRole(sessions.UserRoleAdmin))
+	// the owner has since been demoted
+	assert.Equal(t, sessions.UserRoleView, apiToken.EffectiveRole(sessions.UserRoleView))
+}
@@ -54,6 +54,20 @@ func (_m *ORM) ClearNonCurrentSessions(sessionID string) error {
 	return r0
 }
 
+// CreateAPIToken provides a mock function with given fields: apiToken
+func (_m *ORM) CreateAPIToken(apiToken *sessions.APIToken) error {
+	ret := _m.Called(apiToken)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*sessions.APIToken) error); ok {
+		r0 = rf(apiToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateAndSetAuthToken provides a mock function with given fields: user
 func (_m *ORM) CreateAndSetAuthToken(user *sessions.User) (*auth.Token, error) {
 	ret := _m.Called(user)
@@ -118,6 +132,30 @@ func (_m *ORM) CreateUser(user *sessions.User) error {
 	return r0
 }
 
+// DeleteAPIToken provides a mock function with given fields: email, name
+func (_m *ORM) DeleteAPIToken(email string, name string) (sessions.APIToken, error) {
+	ret := _m.Called(email, name)
+
+	var r0 sessions.APIToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (sessions.APIToken, error)); ok {
+		return rf(email, name)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sessions.APIToken); ok {
+		r0 = rf(email, name)
+	} else {
+		r0 = ret.Get(0).(sessions.APIToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(email, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteAuthToken provides a mock function with given fields: user
 func (_m *ORM) DeleteAuthToken(user *sessions.User) error {
 	ret := _m.Called(user)
@@ -160,6 +198,30 @@ func (_m *ORM) DeleteUserSession(sessionID string) error {
 	return r0
 }
 
+// FindAPIToken provides a mock function with given fields: accessKey
+func (_m *ORM) FindAPIToken(accessKey string) (sessions.APIToken, error) {
+	ret := _m.Called(accessKey)
+
+	var r0 sessions.APIToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (sessions.APIToken, error)); ok {
+		return rf(accessKey)
+	}
+	if rf, ok := ret.Get(0).(func(string) sessions.APIToken); ok {
+		r0 = rf(accessKey)
+	} else {
+		r0 = ret.Get(0).(sessions.APIToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(accessKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindExternalInitiator provides a mock function with given fields: eia
 func (_m *ORM) FindExternalInitiator(eia *auth.Token) (*bridges.ExternalInitiator, error) {
 	ret := _m.Called(eia)
@@ -260,6 +322,32 @@ func (_m *ORM) GetUserWebAuthn(email string) ([]sessions.WebAuthn, error) {
 	return r0, r1
 }
 
+// ListAPITokens provides a mock function with given fields: email
+func (_m *ORM) ListAPITokens(email string) ([]sessions.APIToken, error) {
+	ret := _m.Called(email)
+
+	var r0 []sessions.APIToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]sessions.APIToken, error)); ok {
+		return rf(email)
+	}
+	if rf, ok := ret.Get(0).(func(string) []sessions.APIToken); ok {
+		r0 = rf(email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sessions.APIToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListUsers provides a mock function with given fields:
 func (_m *ORM) ListUsers() ([]sessions.User, error) {
 	ret := _m.Called()
@@ -286,6 +374,20 @@ func (_m *ORM) ListUsers() ([]sessions.User, error) {
 	return r0, r1
 }
 
+// MarkAPITokenUsed provides a mock function with given fields: id
+func (_m *ORM) MarkAPITokenUsed(id int64) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SaveWebAuthn provides a mock function with given fields: token
 func (_m *ORM) SaveWebAuthn(token *sessions.WebAuthn) error {
 	ret := _m.Called(token)
@@ -0,0 +1,187 @@
+package sessions
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+// OIDCConfig is the subset of the node configuration required to log in
+// through an OpenID Connect identity provider.
+type OIDCConfig interface {
+	OIDCEnabled() bool
+	OIDCIssuerURL() string
+	OIDCClientID() string
+	OIDCClientSecret() string
+	OIDCRedirectURL() string
+	OIDCScopes() []string
+	OIDCGroupsClaim() string
+	OIDCAdminGroups() []string
+	OIDCEditGroups() []string
+	OIDCRunGroups() []string
+	OIDCViewGroups() []string
+}
+
+// OIDCIdentity is the verified identity of a user returned by the identity
+// provider, mapped onto a Chainlink role.
+type OIDCIdentity struct {
+	Issuer  string
+	Subject string
+	Email   string
+	Groups  []string
+	Role    UserRole
+}
+
+// OIDCAuthRequest holds the per-login values which must be kept by the
+// caller between redirecting to the identity provider and handling the callback.
+type OIDCAuthRequest struct {
+	State        string
+	Nonce        string
+	CodeVerifier string
+}
+
+// NewOIDCAuthRequest generates a fresh state, nonce and PKCE code verifier.
+func NewOIDCAuthRequest() OIDCAuthRequest {
+	return OIDCAuthRequest{
+		State:        utils.NewBytes32ID(),
+		Nonce:        utils.NewBytes32ID(),
+		CodeVerifier: utils.NewBytes32ID(),
+	}
+}
+
+// CodeChallenge returns the S256 PKCE challenge for the request's code verifier.
+func (r OIDCAuthRequest) CodeChallenge() string {
+	sum := sha256.Sum256([]byte(r.CodeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// OIDCProvider implements the OpenID Connect authorization code flow with PKCE.
+type OIDCProvider struct {
+	oauth2   oauth2.Config
+	verifier *oidc.IDTokenVerifier
+	cfg      OIDCConfig
+}
+
+// NewOIDCProvider performs OIDC discovery against the configured issuer.
+func NewOIDCProvider(ctx context.Context, cfg OIDCConfig) (*OIDCProvider, error) {
+	if !cfg.OIDCEnabled() {
+		return nil, errors.New("OIDC is not enabled")
+	}
+	provider, err := oidc.NewProvider(ctx, cfg.OIDCIssuerURL())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to discover OIDC provider")
+	}
+	scopes := []string{oidc.ScopeOpenID}
+	for _, s := range cfg.OIDCScopes() {
+		if s != oidc.ScopeOpenID {
+			scopes = append(scopes, s)
+		}
+	}
+	return &OIDCProvider{
+		oauth2: oauth2.Config{
+			ClientID:     cfg.OIDCClientID(),
+			ClientSecret: cfg.OIDCClientSecret(),
+			RedirectURL:  cfg.OIDCRedirectURL(),
+			Endpoint:     provider.Endpoint(),
+			Scopes:       scopes,
+		},
+		verifier: provider.Verifier(&oidc.Config{ClientID: cfg.OIDCClientID()}),
+		cfg:      cfg,
+	}, nil
+}
+
+// AuthCodeURL returns the identity provider URL the user must be redirected to.
+func (p *OIDCProvider) AuthCodeURL(r OIDCAuthRequest) string {
+	return p.oauth2.AuthCodeURL(r.State,
+		oidc.Nonce(r.Nonce),
+		oauth2.SetAuthURLParam("code_challenge", r.CodeChallenge()),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+	)
+}
+
+// Exchange trades the authorization code for an ID token, verifies it against
+// the original request and maps the user's groups to a role.
+func (p *OIDCProvider) Exchange(ctx context.Context, r OIDCAuthRequest, state, code string) (OIDCIdentity, error) {
+	if state == "" || state != r.State {
+		return OIDCIdentity{}, errors.New("OIDC state mismatch")
+	}
+	token, err := p.oauth2.Exchange(ctx, code, oauth2.SetAuthURLParam("code_verifier", r.CodeVerifier))
+	if err != nil {
+		return OIDCIdentity{}, errors.Wrap(err, "failed to exchange authorization code")
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return OIDCIdentity{}, errors.New("token response did not include an id_token")
+	}
+	idToken, err := p.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return OIDCIdentity{}, errors.Wrap(err, "failed to verify id_token")
+	}
+	if idToken.Nonce != r.Nonce {
+		return OIDCIdentity{}, errors.New("OIDC nonce mismatch")
+	}
+
+	var claims map[string]interface{}
+	if err = idToken.Claims(&claims); err != nil {
+		return OIDCIdentity{}, errors.Wrap(err, "failed to parse id_token claims")
+	}
+	return p.identityFromClaims(idToken.Issuer, idToken.Subject, claims)
+}
+
+func (p *OIDCProvider) identityFromClaims(issuer, subject string, claims map[string]interface{}) (OIDCIdentity, error) {
+	email, _ := claims["email"].(string)
+	if err := ValidateEmail(email); err != nil {
+		return OIDCIdentity{}, errors.Wrap(err, "id_token has no valid email claim")
+	}
+	if verified, ok := claims["email_verified"].(bool); ok && !verified {
+		return OIDCIdentity{}, errors.Errorf("email %s is not verified by the identity provider", email)
+	}
+
+	var groups []string
+	switch v := claims[p.cfg.OIDCGroupsClaim()].(type) {
+	case []interface{}:
+		for _, g := range v {
+			if s, ok := g.(string); ok {
+				groups = append(groups, s)
+			}
+		}
+	case string:
+		groups = strings.Split(v, ",")
+	}
+
+	role, err := p.roleForGroups(groups)
+	if err != nil {
+		return OIDCIdentity{}, errors.Wrapf(err, "user %s", email)
+	}
+	return OIDCIdentity{Issuer: issuer, Subject: subject, Email: strings.ToLower(email), Groups: groups, Role: role}, nil
+}
+
+// roleForGroups returns the most privileged role mapped from any of the groups.
+func (p *OIDCProvider) roleForGroups(groups []string) (UserRole, error) {
+	for _, m := range []struct {
+		role   UserRole
+		groups []string
+	}{
+		{UserRoleAdmin, p.cfg.OIDCAdminGroups()},
+		{UserRoleEdit, p.cfg.OIDCEditGroups()},
+		{UserRoleRun, p.cfg.OIDCRunGroups()},
+		{UserRoleView, p.cfg.OIDCViewGroups()},
+	} {
+		for _, g := range groups {
+			for _, mg := range m.groups {
+				if strings.TrimSpace(g) == mg {
+					return m.role, nil
+				}
+			}
+		}
+	}
+	return "", fmt.Errorf("none of the groups %v are mapped to a role", groups)
+}
@@ -0,0 +1,156 @@
+package sessions_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/oidctest"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+)
+
+type oidcConfig struct {
+	issuer string
+}
+
+func (c oidcConfig) OIDCEnabled() bool         { return true }
+func (c oidcConfig) OIDCIssuerURL() string     { return c.issuer }
+func (c oidcConfig) OIDCClientID() string      { return "chainlink" }
+func (c oidcConfig) OIDCClientSecret() string  { return "secret" }
+func (c oidcConfig) OIDCRedirectURL() string   { return "http://localhost:6688/oidc/callback" }
+func (c oidcConfig) OIDCScopes() []string      { return []string{"openid", "email"} }
+func (c oidcConfig) OIDCGroupsClaim() string   { return "groups" }
+func (c oidcConfig) OIDCAdminGroups() []string { return []string{"cl-admins"} }
+func (c oidcConfig) OIDCEditGroups() []string  { return []string{"cl-editors"} }
+func (c oidcConfig) OIDCRunGroups() []string   { return nil }
+func (c oidcConfig) OIDCViewGroups() []string  { return []string{"cl-viewers", "engineering"} }
+
+func TestOIDCProvider_Exchange(t *testing.T) {
+	t.Parallel()
+
+	idp := oidctest.NewServer(t, "chainlink")
+	provider, err := sessions.NewOIDCProvider(testutils.Context(t), oidcConfig{issuer: idp.URL})
+	require.NoError(t, err)
+
+	login := func(t *testing.T, tamper func(r *sessions.OIDCAuthRequest)) (sessions.OIDCIdentity, error) {
+		r := sessions.NewOIDCAuthRequest()
+		callback := idp.Authorize(provider.AuthCodeURL(r))
+		if tamper != nil {
+			tamper(&r)
+		}
+		return provider.Exchange(testutils.Context(t), r, callback.Get("state"), callback.Get("code"))
+	}
+
+	tests := []struct {
+		name   string
+		groups []string
+		role   sessions.UserRole
+		err    string
+	}{
+		{"admin", []string{"engineering", "cl-admins"}, sessions.UserRoleAdmin, ""},
+		{"edit", []string{"cl-editors"}, sessions.UserRoleEdit, ""},
+		{"view", []string{"engineering"}, sessions.UserRoleView, ""},
+		{"unmapped", []string{"marketing"}, "", "none of the groups [marketing] are mapped to a role"},
+		{"no groups", nil, "", "none of the groups [] are mapped to a role"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idp.SetClaims(map[string]interface{}{"sub": "user-1", "email": "Alice@Example.com", "groups": tt.groups})
+			identity, err := login(t, nil)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, idp.URL, identity.Issuer)
+			assert.Equal(t, "user-1", identity.Subject)
+			assert.Equal(t, "alice@example.com", identity.Email)
+			assert.Equal(t, tt.role, identity.Role)
+		})
+	}
+
+	idp.SetClaims(map[string]interface{}{"sub": "user-1", "email": "alice@example.com", "groups": []string{"cl-admins"}})
+
+	t.Run("state mismatch", func(t *testing.T) {
+		_, err := login(t, func(r *sessions.OIDCAuthRequest) { r.State = "other" })
+		require.ErrorContains(t, err, "OIDC state mismatch")
+	})
+
+	t.Run("wrong code verifier", func(t *testing.T) {
+		_, err := login(t, func(r *sessions.OIDCAuthRequest) { r.CodeVerifier = sessions.NewOIDCAuthRequest().CodeVerifier })
+		require.ErrorContains(t, err, "failed to exchange authorization code")
+	})
+
+	t.Run("nonce mismatch", func(t *testing.T) {
+		_, err := login(t, func(r *sessions.OIDCAuthRequest) { r.Nonce = "other" })
+		require.ErrorContains(t, err, "OIDC nonce mismatch")
+	})
+
+	t.Run("unverified email", func(t *testing.T) {
+		idp.SetClaims(map[string]interface{}{"sub": "user-1", "email": "alice@example.com", "email_verified": false, "groups": []string{"cl-admins"}})
+		_, err := login(t, nil)
+		require.ErrorContains(t, err, "is not verified")
+	})
+}
+
+func TestORM_CreateSession_OIDC(t *testing.T) {
+	t.Parallel()
+
+	_, orm := setupORM(t)
+
+	identity := sessions.OIDCIdentity{Issuer: "https://idp.example.com", Subject: "user-1", Email: "sso@example.com", Role: sessions.UserRoleAdmin}
+	sessionID, err := orm.CreateSession(sessions.SessionRequest{Email: identity.Email, OIDCIdentity: &identity})
+	require.NoError(t, err)
+	assert.NotEmpty(t, sessionID)
+
+	user, err := orm.AuthorizedUserWithSession(sessionID)
+	require.NoError(t, err)
+	assert.Equal(t, identity.Email, user.Email)
+	assert.Equal(t, sessions.UserRoleAdmin, user.Role)
+
+	// role follows the identity provider on every login
+	identity.Role = sessions.UserRoleView
+	_, err = orm.CreateSession(sessions.SessionRequest{Email: identity.Email, OIDCIdentity: &identity})
+	require.NoError(t, err)
+	user, err = orm.FindUser(identity.Email)
+	require.NoError(t, err)
+	assert.Equal(t, sessions.UserRoleView, user.Role)
+
+	// provisioned users have no local password
+	_, err = orm.CreateSession(sessions.SessionRequest{Email: identity.Email, Password: cltest.Password})
+	require.ErrorContains(t, err, "Invalid password")
+
+	identity.Role = "superuser"
+	_, err = orm.CreateSession(sessions.SessionRequest{Email: identity.Email, OIDCIdentity: &identity})
+	require.ErrorContains(t, err, "Invalid role")
+
+	// another identity with the same email does not take over the user
+	other := sessions.OIDCIdentity{Issuer: "https://other.example.com", Subject: "user-1", Email: identity.Email, Role: sessions.UserRoleAdmin}
+	_, err = orm.CreateSession(sessions.SessionRequest{Email: other.Email, OIDCIdentity: &other})
+	require.ErrorContains(t, err, "provisioned for another OIDC identity")
+	user, err = orm.FindUser(identity.Email)
+	require.NoError(t, err)
+	assert.Equal(t, sessions.UserRoleView, user.Role)
+}
+
+func TestORM_CreateSession_OIDC_LocalUser(t *testing.T) {
+	t.Parallel()
+
+	_, orm := setupORM(t)
+
+	local := cltest.MustRandomUser(t)
+	local.Role = sessions.UserRoleView
+	require.NoError(t, orm.CreateUser(&local))
+
+	identity := sessions.OIDCIdentity{Issuer: "https://idp.example.com", Subject: "user-1", Email: local.Email, Role: sessions.UserRoleAdmin}
+	_, err := orm.CreateSession(sessions.SessionRequest{Email: identity.Email, OIDCIdentity: &identity})
+	require.ErrorContains(t, err, "a local user with email")
+
+	user, err := orm.FindUser(local.Email)
+	require.NoError(t, err)
+	assert.Equal(t, sessions.UserRoleView, user.Role)
+	assert.False(t, user.OIDCSubject.Valid)
+}
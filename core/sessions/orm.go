@@ -2,6 +2,7 @@ package sessions
 
 import (
 	"crypto/subtle"
+	"database/sql"
 	"encoding/json"
 	"strings"
 	"time"
@@ -34,6 +35,11 @@ type ORM interface {
 	SetAuthToken(user *User, token *auth.Token) error
 	CreateAndSetAuthToken(user *User) (*auth.Token, error)
 	DeleteAuthToken(user *User) error
+	CreateAPIToken(apiToken *APIToken) error
+	FindAPIToken(accessKey string) (APIToken, error)
+	ListAPITokens(email string) ([]APIToken, error)
+	MarkAPITokenUsed(id int64) error
+	DeleteAPIToken(email, name string) (APIToken, error)
 	SetPassword(user *User, newPassword string) error
 	Sessions(offset, limit int) ([]Session, error)
 	GetUserWebAuthn(email string) ([]WebAuthn, error)
@@ -165,6 +171,10 @@ func (o *orm) GetUserWebAuthn(email string) ([]WebAuthn, error) {
 // the hashed API User password in the db. Also will check WebAuthn if it's
 // enabled for that user.
 func (o *orm) CreateSession(sr SessionRequest) (string, error) {
+	if sr.OIDCIdentity != nil {
+		return o.createOIDCSession(*sr.OIDCIdentity)
+	}
+
 	user, err := o.FindUser(sr.Email)
 	if err != nil {
 		return "", err
@@ -252,6 +262,59 @@ func (o *orm) CreateSession(sr SessionRequest) (string, error) {
 	return session.ID, nil
 }
 
+// createOIDCSession provisions or updates the user identified by the OIDC
+// provider, with the role mapped from their groups, and creates a session.
+// Users provisioned this way have no local password. A login never takes over
+// a local user, or a user provisioned for another OIDC identity, with the same
+// email.
+func (o *orm) createOIDCSession(identity OIDCIdentity) (string, error) {
+	lggr := o.lggr.With("user", identity.Email, "issuer", identity.Issuer, "subject", identity.Subject)
+	if _, err := GetUserRole(string(identity.Role)); err != nil {
+		o.auditLogger.Audit(audit.AuthLoginFailedOIDC, map[string]interface{}{"email": identity.Email, "error": err})
+		return "", err
+	}
+	if identity.Issuer == "" || identity.Subject == "" {
+		err := errors.New("OIDC identity must have an issuer and a subject")
+		o.auditLogger.Audit(audit.AuthLoginFailedOIDC, map[string]interface{}{"email": identity.Email, "error": err})
+		return "", err
+	}
+
+	session := NewSession()
+	err := o.q.Transaction(func(tx pg.Queryer) error {
+		var user User
+		err := tx.Get(&user, "SELECT * FROM users WHERE lower(email) = lower($1) FOR UPDATE", identity.Email)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			sql := `INSERT INTO users (email, hashed_password, role, oidc_issuer, oidc_subject, created_at, updated_at)
+VALUES ($1, '', $2, $3, $4, now(), now()) RETURNING *`
+			if err = tx.Get(&user, sql, identity.Email, identity.Role, identity.Issuer, identity.Subject); err != nil {
+				return errors.Wrap(err, "failed to provision OIDC user")
+			}
+		case err != nil:
+			return errors.Wrap(err, "failed to find OIDC user")
+		case !user.OIDCSubject.Valid:
+			return errors.Errorf("a local user with email %s already exists", identity.Email)
+		case user.OIDCIssuer.String != identity.Issuer || user.OIDCSubject.String != identity.Subject:
+			return errors.Errorf("user %s is provisioned for another OIDC identity", identity.Email)
+		default:
+			if _, err = tx.Exec("UPDATE users SET role = $1, updated_at = now() WHERE email = $2", identity.Role, user.Email); err != nil {
+				return errors.Wrap(err, "failed to update OIDC user role")
+			}
+		}
+		_, err = tx.Exec("INSERT INTO sessions (id, email, last_used, created_at) VALUES ($1, $2, now(), now())", session.ID, user.Email)
+		return err
+	})
+	if err != nil {
+		lggr.Errorw("Failed to create OIDC session", "err", err)
+		o.auditLogger.Audit(audit.AuthLoginFailedOIDC, map[string]interface{}{"email": identity.Email, "subject": identity.Subject, "error": err})
+		return "", err
+	}
+
+	lggr.Infow("User authenticated by OIDC provider. Creating Session", "role", identity.Role)
+	o.auditLogger.Audit(audit.AuthLoginSuccessOIDC, map[string]interface{}{"email": identity.Email, "subject": identity.Subject, "role": identity.Role})
+	return session.ID, nil
+}
+
 const constantTimeEmailLength = 256
 
 func constantTimeEmailCompare(left, right string) bool {
@@ -352,6 +415,43 @@ func (o *orm) DeleteAuthToken(user *User) error {
 	return o.q.Get(user, sql, user.Email)
 }
 
+// CreateAPIToken stores a new named API token for its user.
+func (o *orm) CreateAPIToken(apiToken *APIToken) error {
+	sql := `INSERT INTO user_api_tokens (user_email, name, role, token_key, token_salt, token_hashed_secret, expires_at, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, now()) RETURNING *`
+	err := o.q.Get(apiToken, sql, apiToken.UserEmail, apiToken.Name, apiToken.Role, apiToken.TokenKey, apiToken.TokenSalt, apiToken.TokenHashedSecret, apiToken.ExpiresAt)
+	if err != nil && strings.Contains(err.Error(), "user_api_tokens_user_email_name_key") {
+		return errors.Errorf("an API token named %s already exists", apiToken.Name)
+	}
+	return err
+}
+
+// FindAPIToken will attempt to return a named API token by its access key.
+func (o *orm) FindAPIToken(accessKey string) (apiToken APIToken, err error) {
+	sql := "SELECT * FROM user_api_tokens WHERE token_key = $1"
+	err = o.q.Get(&apiToken, sql, accessKey)
+	return
+}
+
+// ListAPITokens returns the named API tokens of a user.
+func (o *orm) ListAPITokens(email string) (apiTokens []APIToken, err error) {
+	sql := "SELECT * FROM user_api_tokens WHERE lower(user_email) = lower($1) ORDER BY name"
+	err = o.q.Select(&apiTokens, sql, email)
+	return
+}
+
+// MarkAPITokenUsed records that a named API token has just been used.
+func (o *orm) MarkAPITokenUsed(id int64) error {
+	_, err := o.q.Exec("UPDATE user_api_tokens SET last_used = now() WHERE id = $1", id)
+	return err
+}
+
+// DeleteAPIToken revokes a single named API token of a user and returns it.
+func (o *orm) DeleteAPIToken(email, name string) (apiToken APIToken, err error) {
+	err = o.q.Get(&apiToken, "DELETE FROM user_api_tokens WHERE lower(user_email) = lower($1) AND name = $2 RETURNING *", email, name)
+	return
+}
+
 // SaveWebAuthn saves new WebAuthn token information.
 func (o *orm) SaveWebAuthn(token *WebAuthn) error {
 	sql := "INSERT INTO web_authns (email, public_key_data) VALUES ($1, $2)"
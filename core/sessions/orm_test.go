@@ -1,6 +1,7 @@
 package sessions_test
 
 import (
+	"database/sql"
 	"encoding/json"
 	"testing"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/go-webauthn/webauthn/webauthn"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
 
 	"github.com/smartcontractkit/sqlx"
 
@@ -294,3 +296,52 @@ func TestOrm_GenerateAuthToken(t *testing.T) {
 	assert.Empty(t, dbUser.TokenSalt.ValueOrZero())
 	assert.Empty(t, dbUser.TokenHashedSecret.ValueOrZero())
 }
+
+func TestORM_APITokens(t *testing.T) {
+	t.Parallel()
+
+	_, orm := setupORM(t)
+
+	user := cltest.MustRandomUser(t)
+	require.NoError(t, orm.CreateUser(&user))
+
+	apiToken, token, err := sessions.NewAPIToken(user, "ci", sessions.UserRoleRun, null.TimeFrom(time.Now().Add(time.Hour)))
+	require.NoError(t, err)
+	require.NoError(t, orm.CreateAPIToken(&apiToken))
+	assert.NotZero(t, apiToken.ID)
+	assert.False(t, apiToken.LastUsed.Valid)
+
+	duplicate, _, err := sessions.NewAPIToken(user, "ci", sessions.UserRoleView, null.Time{})
+	require.NoError(t, err)
+	require.ErrorContains(t, orm.CreateAPIToken(&duplicate), "an API token named ci already exists")
+
+	other, _, err := sessions.NewAPIToken(user, "deploy", sessions.UserRoleView, null.Time{})
+	require.NoError(t, err)
+	require.NoError(t, orm.CreateAPIToken(&other))
+
+	found, err := orm.FindAPIToken(token.AccessKey)
+	require.NoError(t, err)
+	assert.Equal(t, apiToken.ID, found.ID)
+
+	require.NoError(t, orm.MarkAPITokenUsed(found.ID))
+	tokens, err := orm.ListAPITokens(user.Email)
+	require.NoError(t, err)
+	require.Len(t, tokens, 2)
+	assert.Equal(t, "ci", tokens[0].Name)
+	assert.True(t, tokens[0].LastUsed.Valid)
+	assert.Equal(t, "deploy", tokens[1].Name)
+
+	deleted, err := orm.DeleteAPIToken(user.Email, "ci")
+	require.NoError(t, err)
+	assert.Equal(t, "ci", deleted.Name)
+	_, err = orm.DeleteAPIToken(user.Email, "ci")
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = orm.FindAPIToken(token.AccessKey)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// tokens are removed with their user
+	require.NoError(t, orm.DeleteUser(user.Email))
+	tokens, err = orm.ListAPITokens(user.Email)
+	require.NoError(t, err)
+	assert.Empty(t, tokens)
+}
@@ -23,6 +23,10 @@ type User struct {
 	TokenSalt         null.String
 	TokenHashedSecret null.String
 	UpdatedAt         time.Time
+	// OIDCIssuer and OIDCSubject are set only for users provisioned by an
+	// OIDC login.
+	OIDCIssuer  null.String `db:"oidc_issuer"`
+	OIDCSubject null.String `db:"oidc_subject"`
 }
 
 type UserRole string
@@ -34,6 +38,19 @@ const (
 	UserRoleView  UserRole = "view"
 )
 
+var userRoleRanks = map[UserRole]int{
+	UserRoleView:  1,
+	UserRoleRun:   2,
+	UserRoleEdit:  3,
+	UserRoleAdmin: 4,
+}
+
+// Includes returns true if r grants at least the permissions of other.
+func (r UserRole) Includes(other UserRole) bool {
+	rank, ok := userRoleRanks[r]
+	return ok && rank >= userRoleRanks[other] && userRoleRanks[other] > 0
+}
+
 // https://security.stackexchange.com/questions/39849/does-bcrypt-have-a-maximum-password-length
 const (
 	MaxBcryptPasswordLength = 50
@@ -117,6 +134,9 @@ type SessionRequest struct {
 	WebAuthnData   string `json:"webauthndata"`
 	WebAuthnConfig WebAuthnConfiguration
 	SessionStore   *WebAuthnSessionStore
+	// OIDCIdentity is set instead of a password once the user has been
+	// authenticated by the OIDC identity provider.
+	OIDCIdentity *OIDCIdentity `json:"-"`
 }
 
 // Session holds the unique id for the authenticated session.
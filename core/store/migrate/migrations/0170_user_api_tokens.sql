@@ -0,0 +1,19 @@
+-- +goose Up
+
+CREATE TABLE user_api_tokens (
+    id BIGSERIAL PRIMARY KEY,
+    user_email text NOT NULL REFERENCES users(email) ON DELETE CASCADE,
+    name text NOT NULL CHECK (name <> ''),
+    role user_roles NOT NULL,
+    token_key text NOT NULL UNIQUE,
+    token_salt text NOT NULL,
+    token_hashed_secret text NOT NULL,
+    expires_at timestamptz,
+    last_used timestamptz,
+    created_at timestamptz NOT NULL,
+    UNIQUE (user_email, name)
+);
+
+-- +goose Down
+
+DROP TABLE user_api_tokens;
@@ -0,0 +1,63 @@
+-- +goose Up
+-- Functions notifying the channels streamed to GraphQL subscribers. Their
+-- triggers are installed by the node at startup only when
+-- Feature.GraphQLSubscriptions is enabled, see pg.SetNotifyTriggers.
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_pipeline_run_created() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    PERFORM pg_notify('pipeline_run_created', json_build_object('id', NEW.id, 'pipelineSpecID', NEW.pipeline_spec_id)::text);
+    RETURN NULL;
+END
+$$;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_pipeline_task_run_updated() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    IF TG_OP = 'INSERT' OR OLD.finished_at IS DISTINCT FROM NEW.finished_at THEN
+        PERFORM pg_notify('pipeline_task_run_updated', json_build_object('id', NEW.id, 'pipelineRunID', NEW.pipeline_run_id)::text);
+    END IF;
+    RETURN NULL;
+END
+$$;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_eth_tx_state_changed() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    IF TG_OP = 'INSERT' OR OLD.state IS DISTINCT FROM NEW.state THEN
+        PERFORM pg_notify('eth_tx_state_changed', json_build_object('id', NEW.id, 'evmChainID', NEW.evm_chain_id::text, 'state', NEW.state)::text);
+    END IF;
+    RETURN NULL;
+END
+$$;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_job_proposal_updated() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    PERFORM pg_notify('job_proposal_updated', NEW.id::text);
+    RETURN NULL;
+END
+$$;
+-- +goose StatementEnd
+
+-- +goose Down
+
+DROP TRIGGER IF EXISTS notify_pipeline_run_created ON pipeline_runs;
+DROP FUNCTION notify_pipeline_run_created;
+DROP TRIGGER IF EXISTS notify_pipeline_task_run_updated ON pipeline_task_runs;
+DROP FUNCTION notify_pipeline_task_run_updated;
+DROP TRIGGER IF EXISTS notify_eth_tx_state_changed ON eth_txes;
+DROP FUNCTION notify_eth_tx_state_changed;
+DROP TRIGGER IF EXISTS notify_job_proposal_updated ON job_proposals;
+DROP FUNCTION notify_job_proposal_updated;
@@ -0,0 +1,16 @@
+-- +goose Up
+
+CREATE TABLE telemetry_records (
+    id BIGSERIAL PRIMARY KEY,
+    telemetry_type text NOT NULL,
+    contract_id text NOT NULL,
+    telemetry bytea NOT NULL,
+    created_at timestamptz NOT NULL
+);
+
+CREATE INDEX idx_telemetry_records_type_created_at ON telemetry_records (telemetry_type, created_at);
+CREATE INDEX idx_telemetry_records_created_at ON telemetry_records (created_at);
+
+-- +goose Down
+
+DROP TABLE telemetry_records;
@@ -0,0 +1,7 @@
+-- +goose Up
+
+ALTER TABLE jobs ADD COLUMN slo jsonb;
+
+-- +goose Down
+
+ALTER TABLE jobs DROP COLUMN slo;
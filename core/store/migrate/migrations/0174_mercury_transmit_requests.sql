@@ -0,0 +1,20 @@
+-- +goose Up
+
+CREATE TABLE mercury_transmit_requests (
+    server_url text NOT NULL,
+    payload_hash bytea NOT NULL,
+    job_id int NOT NULL REFERENCES jobs (id) ON DELETE CASCADE DEFERRABLE INITIALLY IMMEDIATE,
+    payload bytea NOT NULL,
+    config_digest bytea NOT NULL,
+    epoch bigint NOT NULL,
+    round bigint NOT NULL,
+    extra_hash bytea NOT NULL,
+    created_at timestamptz NOT NULL,
+    PRIMARY KEY (server_url, payload_hash)
+);
+
+CREATE INDEX idx_mercury_transmit_requests_job_id_server_url_epoch_round ON mercury_transmit_requests (job_id, server_url, epoch, round);
+
+-- +goose Down
+
+DROP TABLE mercury_transmit_requests;
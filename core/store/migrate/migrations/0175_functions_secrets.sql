@@ -0,0 +1,18 @@
+-- +goose Up
+
+CREATE TABLE functions_secrets (
+    contract_address bytea NOT NULL CHECK (octet_length(contract_address) = 20),
+    owner bytea NOT NULL CHECK (octet_length(owner) = 20),
+    slot_id bigint NOT NULL CHECK (slot_id >= 0),
+    version bigint NOT NULL CHECK (version >= 0),
+    encrypted_secrets bytea NOT NULL,
+    expires_at timestamptz NOT NULL,
+    created_at timestamptz NOT NULL,
+    PRIMARY KEY (contract_address, owner, slot_id)
+);
+
+CREATE INDEX idx_functions_secrets_expires_at ON functions_secrets (expires_at);
+
+-- +goose Down
+
+DROP TABLE functions_secrets;
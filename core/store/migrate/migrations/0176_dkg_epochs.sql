@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE dkg_epochs(
+    key_id bytea NOT NULL CHECK ( length(key_id) = 32 ),
+    config_digest bytea NOT NULL CHECK ( length(config_digest) = 32 ),
+    epoch bigint NOT NULL CHECK ( epoch > 0 ),
+    committee bytea[] NOT NULL,
+    f integer NOT NULL,
+    previous_config_digest bytea CHECK ( length(previous_config_digest) = 32 ),
+    committee_changed boolean NOT NULL DEFAULT false,
+    created_at timestamptz NOT NULL,
+    PRIMARY KEY (key_id, config_digest),
+    UNIQUE (key_id, epoch)
+);
+
+-- +goose Down
+DROP TABLE dkg_epochs;
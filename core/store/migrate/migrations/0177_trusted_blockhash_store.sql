@@ -0,0 +1,12 @@
+-- +goose Up
+ALTER TABLE blockhash_store_specs
+    ADD COLUMN trusted_blockhash_store_address bytea DEFAULT NULL
+        CONSTRAINT trusted_blockhash_store_address_len_chk CHECK (octet_length(trusted_blockhash_store_address) = 20),
+    ADD COLUMN trusted_blockhash_store_batch_size integer DEFAULT 0 NOT NULL,
+    ADD COLUMN trusted_blockhash_store_gas_limit bigint DEFAULT 0 NOT NULL;
+
+-- +goose Down
+ALTER TABLE blockhash_store_specs
+    DROP COLUMN trusted_blockhash_store_address,
+    DROP COLUMN trusted_blockhash_store_batch_size,
+    DROP COLUMN trusted_blockhash_store_gas_limit;
@@ -0,0 +1,11 @@
+-- +goose Up
+ALTER TABLE cron_specs
+    ADD COLUMN timezone text NOT NULL DEFAULT '',
+    ADD COLUMN misfire_policy text NOT NULL DEFAULT 'skip',
+    ADD COLUMN overlap_policy text NOT NULL DEFAULT 'allow';
+
+-- +goose Down
+ALTER TABLE cron_specs
+    DROP COLUMN timezone,
+    DROP COLUMN misfire_policy,
+    DROP COLUMN overlap_policy;
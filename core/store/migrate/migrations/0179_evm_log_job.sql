@@ -0,0 +1,73 @@
+-- +goose Up
+CREATE TABLE evm_log_specs
+(
+    id                BIGSERIAL PRIMARY KEY,
+    contract_address  bytea                    NOT NULL,
+    event_abi         text                     NOT NULL,
+    topic1            bytea[]                  DEFAULT '{}' NOT NULL,
+    topic2            bytea[]                  DEFAULT '{}' NOT NULL,
+    topic3            bytea[]                  DEFAULT '{}' NOT NULL,
+    min_confirmations bigint                   NOT NULL,
+    evm_chain_id      numeric(78)
+        REFERENCES evm_chains
+                DEFERRABLE,
+    created_at        timestamp with time zone NOT NULL,
+    updated_at        timestamp with time zone NOT NULL
+        CONSTRAINT contract_address_len_chk CHECK (octet_length(contract_address) = 20)
+);
+
+ALTER TABLE jobs
+    ADD COLUMN evm_log_spec_id INT REFERENCES evm_log_specs (id),
+    DROP CONSTRAINT chk_only_one_spec,
+    ADD CONSTRAINT chk_only_one_spec CHECK (
+            num_nonnulls(
+                    ocr_oracle_spec_id,
+                    ocr2_oracle_spec_id,
+                    direct_request_spec_id,
+                    flux_monitor_spec_id,
+                    keeper_spec_id,
+                    cron_spec_id,
+                    webhook_spec_id,
+                    vrf_spec_id,
+                    blockhash_store_spec_id,
+                    block_header_feeder_spec_id,
+                    bootstrap_spec_id,
+                    evm_log_spec_id) = 1);
+
+-- evm_log_processed_logs records the logs an evmlog job ran its pipeline
+-- for, so that every log is processed exactly once.
+CREATE TABLE evm_log_processed_logs
+(
+    job_id       INT                      NOT NULL REFERENCES jobs (id) ON DELETE CASCADE DEFERRABLE,
+    block_hash   bytea                    NOT NULL,
+    log_index    bigint                   NOT NULL,
+    block_number bigint                   NOT NULL,
+    tx_hash      bytea                    NOT NULL,
+    created_at   timestamp with time zone NOT NULL,
+    PRIMARY KEY (job_id, block_hash, log_index)
+);
+
+CREATE INDEX idx_evm_log_processed_logs_job_id_block_number ON evm_log_processed_logs (job_id, block_number);
+
+-- +goose Down
+DROP TABLE IF EXISTS evm_log_processed_logs;
+
+ALTER TABLE jobs
+    DROP CONSTRAINT chk_only_one_spec,
+    ADD CONSTRAINT chk_only_one_spec CHECK (
+            num_nonnulls(
+                    ocr_oracle_spec_id,
+                    ocr2_oracle_spec_id,
+                    direct_request_spec_id,
+                    flux_monitor_spec_id,
+                    keeper_spec_id,
+                    cron_spec_id,
+                    webhook_spec_id,
+                    vrf_spec_id,
+                    blockhash_store_spec_id,
+                    block_header_feeder_spec_id,
+                    bootstrap_spec_id) = 1);
+
+ALTER TABLE jobs
+    DROP COLUMN evm_log_spec_id;
+DROP TABLE IF EXISTS evm_log_specs;
@@ -0,0 +1,24 @@
+-- +goose Up
+ALTER TABLE webhook_specs
+    ADD COLUMN hmac_secret text,
+    ADD COLUMN hmac_timestamp_tolerance bigint NOT NULL DEFAULT 0;
+
+-- webhook_idempotency_keys maps the Idempotency-Key of a webhook trigger to
+-- the run it started. pipeline_run_id is NULL while the run is in progress.
+CREATE TABLE webhook_idempotency_keys
+(
+    job_id          INT                      NOT NULL REFERENCES jobs (id) ON DELETE CASCADE DEFERRABLE,
+    idempotency_key text                     NOT NULL,
+    pipeline_run_id BIGINT REFERENCES pipeline_runs (id) ON DELETE CASCADE DEFERRABLE,
+    created_at      timestamp with time zone NOT NULL,
+    PRIMARY KEY (job_id, idempotency_key)
+);
+
+CREATE INDEX idx_webhook_idempotency_keys_pipeline_run_id ON webhook_idempotency_keys (pipeline_run_id);
+
+-- +goose Down
+DROP TABLE webhook_idempotency_keys;
+
+ALTER TABLE webhook_specs
+    DROP COLUMN hmac_secret,
+    DROP COLUMN hmac_timestamp_tolerance;
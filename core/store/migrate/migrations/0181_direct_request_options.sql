@@ -0,0 +1,11 @@
+-- +goose Up
+ALTER TABLE direct_request_specs
+    ADD COLUMN max_request_gas_limit bigint,
+    ADD COLUMN max_request_min_confirmations bigint,
+    ADD COLUMN request_from_addresses text NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE direct_request_specs
+    DROP COLUMN max_request_gas_limit,
+    DROP COLUMN max_request_min_confirmations,
+    DROP COLUMN request_from_addresses;
@@ -0,0 +1,7 @@
+-- +goose Up
+-- feeds lists the aggregator contracts of a flux monitor job watching several
+-- contracts, as a JSON array of {"contractAddress", "params"} objects.
+ALTER TABLE flux_monitor_specs ADD COLUMN feeds jsonb NOT NULL DEFAULT '[]';
+
+-- +goose Down
+ALTER TABLE flux_monitor_specs DROP COLUMN feeds;
@@ -0,0 +1,14 @@
+-- +goose Up
+-- oidc_issuer and oidc_subject identify the OIDC identity a user was
+-- provisioned for. They are NULL for local users, which OIDC logins must never
+-- take over.
+ALTER TABLE users
+    ADD COLUMN oidc_issuer text,
+    ADD COLUMN oidc_subject text,
+    ADD CONSTRAINT chk_users_oidc_identity CHECK ((oidc_issuer IS NULL) = (oidc_subject IS NULL));
+CREATE UNIQUE INDEX idx_users_oidc_identity ON users (oidc_issuer, oidc_subject) WHERE oidc_subject IS NOT NULL;
+
+-- +goose Down
+ALTER TABLE users
+    DROP COLUMN oidc_issuer,
+    DROP COLUMN oidc_subject;
@@ -0,0 +1,21 @@
+-- +goose Up
+-- cron_last_ticks records the last tick each cron job ran, so that the ticks
+-- missed while the node was down can be determined on start.
+CREATE TABLE cron_last_ticks
+(
+    job_id     INT                      PRIMARY KEY REFERENCES jobs (id) ON DELETE CASCADE DEFERRABLE,
+    tick_at    timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL
+);
+
+-- Existing cron jobs resume from their latest kept run, if any, and otherwise
+-- from now rather than from their creation.
+INSERT INTO cron_last_ticks (job_id, tick_at, updated_at)
+SELECT jobs.id, COALESCE(MAX(pipeline_runs.created_at), NOW()), NOW()
+FROM jobs
+         LEFT JOIN pipeline_runs ON pipeline_runs.pipeline_spec_id = jobs.pipeline_spec_id
+WHERE jobs.cron_spec_id IS NOT NULL
+GROUP BY jobs.id;
+
+-- +goose Down
+DROP TABLE IF EXISTS cron_last_ticks;
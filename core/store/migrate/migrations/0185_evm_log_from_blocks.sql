@@ -0,0 +1,18 @@
+-- +goose Up
+-- evm_log_from_blocks records the first block each evmlog job has not read
+-- all logs of, so that a job resumes from it after a restart.
+CREATE TABLE evm_log_from_blocks
+(
+    job_id     INT                      PRIMARY KEY REFERENCES jobs (id) ON DELETE CASCADE DEFERRABLE,
+    from_block bigint                   NOT NULL,
+    updated_at timestamp with time zone NOT NULL
+);
+
+-- Existing jobs resume from the block of the last log they processed.
+INSERT INTO evm_log_from_blocks (job_id, from_block, updated_at)
+SELECT job_id, MAX(block_number), NOW()
+FROM evm_log_processed_logs
+GROUP BY job_id;
+
+-- +goose Down
+DROP TABLE IF EXISTS evm_log_from_blocks;
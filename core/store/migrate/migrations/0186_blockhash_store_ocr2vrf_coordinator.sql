@@ -0,0 +1,14 @@
+-- +goose Up
+ALTER TABLE blockhash_store_specs
+    ADD COLUMN coordinator_ocr2vrf_address bytea DEFAULT NULL
+        CONSTRAINT coordinator_ocr2vrf_address_len_chk CHECK (octet_length(coordinator_ocr2vrf_address) = 20),
+    DROP CONSTRAINT at_least_one_coordinator_chk,
+    ADD CONSTRAINT at_least_one_coordinator_chk CHECK (
+        coordinator_v1_address IS NOT NULL OR coordinator_v2_address IS NOT NULL OR coordinator_ocr2vrf_address IS NOT NULL
+    );
+
+-- +goose Down
+ALTER TABLE blockhash_store_specs
+    DROP CONSTRAINT at_least_one_coordinator_chk,
+    DROP COLUMN coordinator_ocr2vrf_address,
+    ADD CONSTRAINT at_least_one_coordinator_chk CHECK (coordinator_v1_address IS NOT NULL OR coordinator_v2_address IS NOT NULL);
@@ -556,6 +556,12 @@ type BlockhashStoreSpecParams struct {
 	RunTimeout            time.Duration
 	EVMChainID            int64
 	FromAddresses         []string
+
+	// CoordinatorOCR2VRFAddress, TrustedBlockhashStoreAddress and
+	// TrustedBlockhashStoreBatchSize are only written to the spec if set.
+	CoordinatorOCR2VRFAddress      string
+	TrustedBlockhashStoreAddress   string
+	TrustedBlockhashStoreBatchSize int32
 }
 
 // BlockhashStoreSpec defines a blockhash store job spec.
@@ -636,6 +642,15 @@ fromAddresses = %s
 		params.CoordinatorV2Address, params.WaitBlocks, params.LookbackBlocks,
 		params.BlockhashStoreAddress, params.PollPeriod.String(), params.RunTimeout.String(),
 		params.EVMChainID, formattedFromAddresses)
+	if params.CoordinatorOCR2VRFAddress != "" {
+		toml += fmt.Sprintf("coordinatorOCR2VRFAddress = %q\n", params.CoordinatorOCR2VRFAddress)
+	}
+	if params.TrustedBlockhashStoreAddress != "" {
+		toml += fmt.Sprintf("trustedBlockhashStoreAddress = %q\n", params.TrustedBlockhashStoreAddress)
+	}
+	if params.TrustedBlockhashStoreBatchSize != 0 {
+		toml += fmt.Sprintf("trustedBlockhashStoreBatchSize = %d\n", params.TrustedBlockhashStoreBatchSize)
+	}
 
 	return BlockhashStoreSpec{BlockhashStoreSpecParams: params, toml: toml}
 }
@@ -755,3 +770,69 @@ storeBlockhashesBatchSize = %d
 
 	return BlockHeaderFeederSpec{BlockHeaderFeederSpecParams: params, toml: toml}
 }
+
+// EVMLogSpecParams defines params for building an evmlog job spec.
+type EVMLogSpecParams struct {
+	JobID            string
+	Name             string
+	ContractAddress  string
+	EventABI         string
+	Topic1           []string
+	MinConfirmations uint32
+	EVMChainID       int64
+}
+
+// EVMLogSpec defines an evmlog job spec.
+type EVMLogSpec struct {
+	EVMLogSpecParams
+	toml string
+}
+
+// Toml returns the EVMLogSpec in TOML string form.
+func (e EVMLogSpec) Toml() string {
+	return e.toml
+}
+
+// GenerateEVMLogSpec creates an EVMLogSpec from the given params.
+func GenerateEVMLogSpec(params EVMLogSpecParams) EVMLogSpec {
+	if params.JobID == "" {
+		params.JobID = "123e4567-e89b-12d3-a456-426655442212"
+	}
+
+	if params.Name == "" {
+		params.Name = "evmlog"
+	}
+
+	if params.ContractAddress == "" {
+		params.ContractAddress = "0x2d7F888fE0dD469bd81A12f77e6291508f714d4B"
+	}
+
+	if params.EventABI == "" {
+		params.EventABI = "Transfer(address indexed from, address indexed to, uint256 value)"
+	}
+
+	var topics []string
+	for _, topic := range params.Topic1 {
+		topics = append(topics, fmt.Sprintf("%q", topic))
+	}
+	formattedTopic1 := fmt.Sprintf("[%s]", strings.Join(topics, ", "))
+
+	template := `
+type = "evmlog"
+schemaVersion = 1
+name = "%s"
+externalJobID = "%s"
+contractAddress = "%s"
+eventABI = "%s"
+topic1 = %s
+minConfirmations = %d
+evmChainID = "%d"
+observationSource = """
+    decode [type=ethabidecodelog abi="%s" data="$(jobRun.logData)" topics="$(jobRun.logTopics)"]
+"""
+`
+	toml := fmt.Sprintf(template, params.Name, params.JobID, params.ContractAddress, params.EventABI,
+		formattedTopic1, params.MinConfirmations, params.EVMChainID, params.EventABI)
+
+	return EVMLogSpec{EVMLogSpecParams: params, toml: toml}
+}
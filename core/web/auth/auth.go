@@ -10,6 +10,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/v2/core/auth"
 	"github.com/smartcontractkit/chainlink/v2/core/bridges"
+	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
 	clsessions "github.com/smartcontractkit/chainlink/v2/core/sessions"
 	"github.com/smartcontractkit/chainlink/v2/core/static"
 )
@@ -32,6 +33,9 @@ const (
 
 	// SessionExternalInitiatorKey is the External Initiator key in the session map
 	SessionExternalInitiatorKey = "external_initiator"
+
+	// SessionAPITokenKey is the named API token key in the session map
+	SessionAPITokenKey = "api_token"
 )
 
 // Authenticator defines the interface to authenticate requests against a
@@ -41,6 +45,8 @@ type Authenticator interface {
 	FindExternalInitiator(eia *auth.Token) (*bridges.ExternalInitiator, error)
 	FindUser(email string) (clsessions.User, error)
 	FindUserByAPIToken(apiToken string) (clsessions.User, error)
+	FindAPIToken(accessKey string) (clsessions.APIToken, error)
+	MarkAPITokenUsed(id int64) error
 }
 
 // authMethod defines a method which can be used to authenticate a request. This
@@ -83,7 +89,7 @@ func AuthenticateByToken(c *gin.Context, authr Authenticator) error {
 	user, err := authr.FindUserByAPIToken(token.AccessKey)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return auth.ErrorAuthFailed
+			return authenticateByNamedToken(c, authr, token)
 		}
 		return err
 	}
@@ -103,6 +109,44 @@ func AuthenticateByToken(c *gin.Context, authr Authenticator) error {
 
 var _ authMethod = AuthenticateByToken
 
+// authenticateByNamedToken authenticates a User by one of their named API
+// tokens. The user is granted the role of the token rather than their own.
+func authenticateByNamedToken(c *gin.Context, authr Authenticator, token *auth.Token) error {
+	apiToken, err := authr.FindAPIToken(token.AccessKey)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return auth.ErrorAuthFailed
+		}
+		return err
+	}
+
+	ok, err := clsessions.AuthenticateAPIToken(token, &apiToken)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return auth.ErrorAuthFailed
+	}
+
+	user, err := authr.FindUser(apiToken.UserEmail)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return auth.ErrorAuthFailed
+		}
+		return err
+	}
+	user.Role = apiToken.EffectiveRole(user.Role)
+
+	if err = authr.MarkAPITokenUsed(apiToken.ID); err != nil {
+		return errors.Wrap(err, "failed to record API token use")
+	}
+
+	c.Set(SessionUserKey, &user)
+	c.Set(SessionAPITokenKey, &apiToken)
+
+	return nil
+}
+
 // AuthenticateExternalInitiator authenticates an external initiator request.
 //
 // Implements authMethod
@@ -163,6 +207,29 @@ func Authenticate(store Authenticator, methods ...authMethod) gin.HandlerFunc {
 	}
 }
 
+// AuditAPITokenUse is middleware which records every state changing request
+// authenticated by a named API token in the audit log, so that actions can be
+// attributed to the token as well as to its user.
+func AuditAPITokenUse(auditLogger audit.AuditLogger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		apiToken, ok := GetAuthenticatedAPIToken(c)
+		if !ok {
+			return
+		}
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return
+		}
+		auditLogger.Audit(audit.APITokenUsed, map[string]interface{}{
+			"user":   apiToken.UserEmail,
+			"name":   apiToken.Name,
+			"role":   apiToken.Role,
+			"method": c.Request.Method,
+			"path":   c.FullPath(),
+		})
+	}
+}
+
 // GetAuthenticatedUser extracts the authentication user from the context.
 func GetAuthenticatedUser(c *gin.Context) (*clsessions.User, bool) {
 	obj, ok := c.Get(SessionUserKey)
@@ -175,6 +242,19 @@ func GetAuthenticatedUser(c *gin.Context) (*clsessions.User, bool) {
 	return user, ok
 }
 
+// GetAuthenticatedAPIToken extracts the named API token used to authenticate
+// the request from the context, if any.
+func GetAuthenticatedAPIToken(c *gin.Context) (*clsessions.APIToken, bool) {
+	obj, ok := c.Get(SessionAPITokenKey)
+	if !ok {
+		return nil, false
+	}
+
+	apiToken, ok := obj.(*clsessions.APIToken)
+
+	return apiToken, ok
+}
+
 // GetAuthenticatedExternalInitiator extracts the external initiator from the
 // context.
 func GetAuthenticatedExternalInitiator(c *gin.Context) (*bridges.ExternalInitiator, bool) {
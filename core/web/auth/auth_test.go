@@ -1,19 +1,23 @@
 package auth_test
 
 import (
+	"database/sql"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
 
 	"github.com/smartcontractkit/chainlink/v2/core/auth"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
 	"github.com/smartcontractkit/chainlink/v2/core/sessions"
 	"github.com/smartcontractkit/chainlink/v2/core/web"
 	webauth "github.com/smartcontractkit/chainlink/v2/core/web/auth"
@@ -103,6 +107,113 @@ func TestAuthenticateByToken_AuthFailed(t *testing.T) {
 	assert.Equal(t, http.StatusText(http.StatusUnauthorized), http.StatusText(w.Code))
 }
 
+type namedTokenFinder struct {
+	sessions.ORM
+	user     sessions.User
+	apiToken sessions.APIToken
+	used     []int64
+}
+
+func (u *namedTokenFinder) FindUser(email string) (sessions.User, error) {
+	return u.user, nil
+}
+
+func (u *namedTokenFinder) FindUserByAPIToken(token string) (sessions.User, error) {
+	return sessions.User{}, sql.ErrNoRows
+}
+
+func (u *namedTokenFinder) FindAPIToken(accessKey string) (sessions.APIToken, error) {
+	if accessKey != u.apiToken.TokenKey {
+		return sessions.APIToken{}, sql.ErrNoRows
+	}
+	return u.apiToken, nil
+}
+
+func (u *namedTokenFinder) MarkAPITokenUsed(id int64) error {
+	u.used = append(u.used, id)
+	return nil
+}
+
+type auditRecorder struct {
+	audit.AuditLogger
+	events []audit.EventID
+	data   []audit.Data
+}
+
+func (a *auditRecorder) Audit(eventID audit.EventID, data audit.Data) {
+	a.events = append(a.events, eventID)
+	a.data = append(a.data, data)
+}
+
+func TestAuthenticateByToken_NamedToken(t *testing.T) {
+	user := cltest.MustRandomUser(t)
+	user.Role = sessions.UserRoleAdmin
+	apiToken, token, err := sessions.NewAPIToken(user, "ci", sessions.UserRoleRun, null.Time{})
+	require.NoError(t, err)
+	apiToken.ID = 7
+
+	send := func(authr webauth.Authenticator, auditLogger audit.AuditLogger, method string, secret string) (*httptest.ResponseRecorder, *sessions.User) {
+		var sessionUser *sessions.User
+		router := gin.New()
+		router.Use(webauth.Authenticate(authr, webauth.AuthenticateByToken), webauth.AuditAPITokenUse(auditLogger))
+		router.Handle(method, "/jobs", func(c *gin.Context) {
+			sessionUser, _ = webauth.GetAuthenticatedUser(c)
+			c.String(http.StatusOK, "")
+		})
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, "/jobs", nil)
+		req.Header.Set(webauth.APIKey, token.AccessKey)
+		req.Header.Set(webauth.APISecret, secret)
+		router.ServeHTTP(w, req)
+		return w, sessionUser
+	}
+
+	t.Run("scoped role", func(t *testing.T) {
+		authr := &namedTokenFinder{user: user, apiToken: apiToken}
+		auditLogger := &auditRecorder{}
+
+		w, sessionUser := send(authr, auditLogger, http.MethodGet, token.Secret)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NotNil(t, sessionUser)
+		assert.Equal(t, user.Email, sessionUser.Email)
+		assert.Equal(t, sessions.UserRoleRun, sessionUser.Role)
+		assert.Equal(t, []int64{7}, authr.used)
+		assert.Empty(t, auditLogger.events, "reads are not audited")
+
+		w, _ = send(authr, auditLogger, http.MethodPost, token.Secret)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, []audit.EventID{audit.APITokenUsed}, auditLogger.events)
+		assert.Equal(t, "ci", auditLogger.data[0]["name"])
+		assert.Equal(t, user.Email, auditLogger.data[0]["user"])
+		assert.Equal(t, "/jobs", auditLogger.data[0]["path"])
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		authr := &namedTokenFinder{user: user, apiToken: apiToken}
+		w, _ := send(authr, &auditRecorder{}, http.MethodGet, "bad-secret")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Empty(t, authr.used)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		expired := apiToken
+		expired.ExpiresAt = null.TimeFrom(time.Now().Add(-time.Minute))
+		authr := &namedTokenFinder{user: user, apiToken: expired}
+		w, _ := send(authr, &auditRecorder{}, http.MethodGet, token.Secret)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("owner demoted", func(t *testing.T) {
+		demoted := user
+		demoted.Role = sessions.UserRoleView
+		authr := &namedTokenFinder{user: demoted, apiToken: apiToken}
+		w, sessionUser := send(authr, &auditRecorder{}, http.MethodGet, token.Secret)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, sessions.UserRoleView, sessionUser.Role)
+	})
+}
+
 func TestRequireAuth_NoneRequired(t *testing.T) {
 	called := false
 	var authr webauth.Authenticator
@@ -195,6 +306,9 @@ var routesRolesMap = [...]routeRules{
 	{"PATCH", "/v2/user/password", true, true, true},
 	{"POST", "/v2/user/token", true, true, true},
 	{"POST", "/v2/user/token/delete", true, true, true},
+	{"GET", "/v2/user/tokens", true, true, true},
+	{"POST", "/v2/user/tokens", true, true, true},
+	{"DELETE", "/v2/user/tokens/MOCK", true, true, true},
 	{"GET", "/v2/enroll_webauthn", true, true, true},
 	{"POST", "/v2/enroll_webauthn", true, true, true},
 	{"GET", "/v2/external_initiators", true, true, true},
@@ -262,6 +376,8 @@ var routesRolesMap = [...]routeRules{
 	{"DELETE", "/v2/keys/vrf/MOCK", false, false, false},
 	{"POST", "/v2/keys/vrf/import", false, false, false},
 	{"POST", "/v2/keys/vrf/export/MOCK", false, false, false},
+	{"GET", "/v2/vrf/pending_requests", true, true, true},
+	{"POST", "/v2/vrf/pending_requests/retry", false, true, true},
 	{"GET", "/v2/jobs", true, true, true},
 	{"GET", "/v2/jobs/MOCK", true, true, true},
 	{"POST", "/v2/jobs", false, false, true},
@@ -0,0 +1,342 @@
+// Package gqlws serves GraphQL operations, and in particular subscriptions,
+// over WebSocket using the graphql-transport-ws protocol.
+//
+// https://github.com/enisdenjo/graphql-ws/blob/master/PROTOCOL.md
+package gqlws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+// Protocol is the WebSocket subprotocol implemented by Handler.
+const Protocol = "graphql-transport-ws"
+
+// Message types of the graphql-transport-ws protocol.
+const (
+	MsgConnectionInit = "connection_init"
+	MsgConnectionAck  = "connection_ack"
+	MsgPing           = "ping"
+	MsgPong           = "pong"
+	MsgSubscribe      = "subscribe"
+	MsgNext           = "next"
+	MsgError          = "error"
+	MsgComplete       = "complete"
+)
+
+// Close codes of the graphql-transport-ws protocol.
+const (
+	CloseInvalidMessage      = 4400
+	CloseUnauthorized        = 4401
+	CloseInitTimeout         = 4408
+	CloseSubscriberExists    = 4409
+	CloseTooManyInitRequests = 4429
+)
+
+const (
+	initTimeout = 10 * time.Second
+	writeWait   = 10 * time.Second
+	pongWait    = 60 * time.Second
+	pingPeriod  = pongWait * 9 / 10
+)
+
+// Message is a graphql-transport-ws protocol message.
+type Message struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// SubscribePayload is the payload of a subscribe message.
+type SubscribePayload struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Subscriber executes GraphQL operations, returning a channel of
+// *graphql.Response. It is implemented by *graphql.Schema.
+type Subscriber interface {
+	Subscribe(ctx context.Context, query string, operationName string, variables map[string]interface{}) (<-chan interface{}, error)
+}
+
+// Handler upgrades HTTP requests to graphql-transport-ws connections.
+type Handler struct {
+	schema     Subscriber
+	lggr       logger.Logger
+	upgrader   websocket.Upgrader
+	readLimit  int64
+	newContext func(context.Context) context.Context
+}
+
+// NewHandler returns a Handler executing operations against schema.
+// readLimit bounds the size of client messages, checkOrigin validates the
+// Origin header of the upgrade request, and newContext, if not nil, derives
+// the context of each operation from the context of the upgrade request.
+func NewHandler(schema Subscriber, lggr logger.Logger, readLimit int64, checkOrigin func(*http.Request) bool, newContext func(context.Context) context.Context) *Handler {
+	return &Handler{
+		schema: schema,
+		lggr:   lggr.Named("GraphQLWS"),
+		upgrader: websocket.Upgrader{
+			Subprotocols:    []string{Protocol},
+			CheckOrigin:     checkOrigin,
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+		},
+		readLimit:  readLimit,
+		newContext: newContext,
+	}
+}
+
+// IsUpgradeRequest returns true if r asks to upgrade to a WebSocket connection.
+func IsUpgradeRequest(r *http.Request) bool {
+	return websocket.IsWebSocketUpgrade(r)
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader has already replied with an HTTP error
+		h.lggr.Debugw("Failed to upgrade GraphQL WebSocket connection", "err", err)
+		return
+	}
+	if conn.Subprotocol() != Protocol {
+		_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseProtocolError, "unsupported subprotocol"), time.Now().Add(writeWait))
+		_ = conn.Close()
+		return
+	}
+
+	c := &connection{
+		Handler: h,
+		conn:    conn,
+		ops:     make(map[string]context.CancelFunc),
+	}
+	c.serve(r.Context())
+}
+
+type connection struct {
+	*Handler
+	conn *websocket.Conn
+
+	writeMu sync.Mutex
+
+	mu           sync.Mutex
+	acknowledged bool
+	ops          map[string]context.CancelFunc
+	wg           sync.WaitGroup
+}
+
+func (c *connection) serve(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer func() {
+		cancel()
+		c.wg.Wait()
+		_ = c.conn.Close()
+	}()
+
+	initTimer := time.AfterFunc(initTimeout, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if !c.acknowledged {
+			c.close(CloseInitTimeout, "Connection initialisation timeout")
+		}
+	})
+	defer initTimer.Stop()
+
+	if c.readLimit > 0 {
+		c.conn.SetReadLimit(c.readLimit)
+	}
+	_ = c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	c.wg.Add(1)
+	go c.keepAlive(ctx)
+
+	for {
+		var msg Message
+		if err := c.conn.ReadJSON(&msg); err != nil {
+			if _, ok := err.(*json.SyntaxError); ok {
+				c.close(CloseInvalidMessage, "Invalid message received")
+			} else if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				c.lggr.Debugw("GraphQL WebSocket connection closed", "err", err)
+			}
+			return
+		}
+		_ = c.conn.SetReadDeadline(time.Now().Add(pongWait))
+		if !c.handle(ctx, msg) {
+			return
+		}
+	}
+}
+
+// handle processes a client message and returns false if the connection must
+// be closed.
+func (c *connection) handle(ctx context.Context, msg Message) bool {
+	switch msg.Type {
+	case MsgConnectionInit:
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.acknowledged {
+			c.close(CloseTooManyInitRequests, "Too many initialisation requests")
+			return false
+		}
+		c.acknowledged = true
+		return c.write(Message{Type: MsgConnectionAck}) == nil
+
+	case MsgPing:
+		return c.write(Message{Type: MsgPong}) == nil
+
+	case MsgPong:
+		return true
+
+	case MsgSubscribe:
+		var payload SubscribePayload
+		if msg.ID == "" || json.Unmarshal(msg.Payload, &payload) != nil {
+			c.close(CloseInvalidMessage, "Invalid subscribe message")
+			return false
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if !c.acknowledged {
+			c.close(CloseUnauthorized, "Unauthorized")
+			return false
+		}
+		if _, exists := c.ops[msg.ID]; exists {
+			c.close(CloseSubscriberExists, fmt.Sprintf("Subscriber for %s already exists", msg.ID))
+			return false
+		}
+		opCtx, cancel := context.WithCancel(ctx)
+		if c.newContext != nil {
+			opCtx = c.newContext(opCtx)
+		}
+		c.ops[msg.ID] = cancel
+		c.wg.Add(1)
+		go c.execute(opCtx, msg.ID, payload)
+		return true
+
+	case MsgComplete:
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if cancel, ok := c.ops[msg.ID]; ok {
+			cancel()
+			delete(c.ops, msg.ID)
+		}
+		return true
+
+	default:
+		c.close(CloseInvalidMessage, fmt.Sprintf("Invalid message type %q", msg.Type))
+		return false
+	}
+}
+
+func (c *connection) execute(ctx context.Context, id string, payload SubscribePayload) {
+	defer c.wg.Done()
+	defer func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if cancel, ok := c.ops[id]; ok {
+			cancel()
+			delete(c.ops, id)
+		}
+	}()
+
+	responses, err := c.schema.Subscribe(ctx, payload.Query, payload.OperationName, payload.Variables)
+	if err != nil {
+		c.writeErrors(id, []interface{}{map[string]string{"message": err.Error()}})
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			// completed by the client or the connection closed
+			return
+		case r, ok := <-responses:
+			if !ok {
+				if ctx.Err() == nil {
+					_ = c.write(Message{ID: id, Type: MsgComplete})
+				}
+				return
+			}
+			resp, ok := r.(*graphql.Response)
+			if !ok {
+				c.lggr.Errorw("Unexpected GraphQL response type", "type", fmt.Sprintf("%T", r))
+				continue
+			}
+			if resp.Data == nil && len(resp.Errors) > 0 {
+				// the operation failed before execution, e.g. it did not validate
+				errs := make([]interface{}, len(resp.Errors))
+				for i, e := range resp.Errors {
+					errs[i] = e
+				}
+				c.writeErrors(id, errs)
+				return
+			}
+			b, err := json.Marshal(resp)
+			if err != nil {
+				c.lggr.Errorw("Failed to marshal GraphQL response", "err", err)
+				continue
+			}
+			if err = c.write(Message{ID: id, Type: MsgNext, Payload: b}); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (c *connection) writeErrors(id string, errs []interface{}) {
+	b, err := json.Marshal(errs)
+	if err != nil {
+		c.lggr.Errorw("Failed to marshal GraphQL errors", "err", err)
+		return
+	}
+	_ = c.write(Message{ID: id, Type: MsgError, Payload: b})
+}
+
+func (c *connection) keepAlive(ctx context.Context) {
+	defer c.wg.Done()
+	ticker := time.NewTicker(pingPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.writeMu.Lock()
+			err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait))
+			c.writeMu.Unlock()
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (c *connection) write(msg Message) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+	err := c.conn.WriteJSON(msg)
+	if err != nil {
+		c.lggr.Debugw("Failed to write GraphQL WebSocket message", "type", msg.Type, "err", err)
+	}
+	return err
+}
+
+// close sends a close frame, which ends the read loop once the client replies.
+func (c *connection) close(code int, reason string) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	_ = c.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(writeWait))
+	_ = c.conn.Close()
+}
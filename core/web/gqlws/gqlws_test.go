@@ -0,0 +1,157 @@
+package gqlws_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/web/gqlws"
+)
+
+const testSchema = `
+	schema {
+		query: Query
+		subscription: Subscription
+	}
+	type Query {
+		hello: String!
+	}
+	type Subscription {
+		count(to: Int!): Int!
+	}`
+
+type testResolver struct{}
+
+func (*testResolver) Hello() string { return "world" }
+
+func (*testResolver) Count(ctx context.Context, args struct{ To int32 }) (<-chan int32, error) {
+	ch := make(chan int32)
+	go func() {
+		defer close(ch)
+		for i := int32(1); i <= args.To; i++ {
+			select {
+			case ch <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func newServer(t *testing.T) string {
+	schema := graphql.MustParseSchema(testSchema, &testResolver{})
+	h := gqlws.NewHandler(schema, logger.TestLogger(t), 1024, func(*http.Request) bool { return true }, nil)
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	return "ws" + strings.TrimPrefix(srv.URL, "http")
+}
+
+func dial(t *testing.T, url string) *websocket.Conn {
+	dialer := websocket.Dialer{Subprotocols: []string{gqlws.Protocol}}
+	conn, _, err := dialer.Dial(url, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	require.Equal(t, gqlws.Protocol, conn.Subprotocol())
+	return conn
+}
+
+func send(t *testing.T, conn *websocket.Conn, msg gqlws.Message) {
+	require.NoError(t, conn.WriteJSON(msg))
+}
+
+func receive(t *testing.T, conn *websocket.Conn) gqlws.Message {
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	var msg gqlws.Message
+	require.NoError(t, conn.ReadJSON(&msg))
+	return msg
+}
+
+func subscribe(t *testing.T, conn *websocket.Conn, id, query string) {
+	payload, err := json.Marshal(gqlws.SubscribePayload{Query: query})
+	require.NoError(t, err)
+	send(t, conn, gqlws.Message{ID: id, Type: gqlws.MsgSubscribe, Payload: payload})
+}
+
+func requireClosed(t *testing.T, conn *websocket.Conn, code int) {
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, _, err := conn.ReadMessage()
+	require.True(t, websocket.IsCloseError(err, code), "expected close %d, got %v", code, err)
+}
+
+func TestHandler_Subscribe(t *testing.T) {
+	t.Parallel()
+
+	conn := dial(t, newServer(t))
+	send(t, conn, gqlws.Message{Type: gqlws.MsgConnectionInit})
+	assert.Equal(t, gqlws.MsgConnectionAck, receive(t, conn).Type)
+
+	send(t, conn, gqlws.Message{Type: gqlws.MsgPing})
+	assert.Equal(t, gqlws.MsgPong, receive(t, conn).Type)
+
+	subscribe(t, conn, "1", `subscription { count(to: 2) }`)
+	for _, expected := range []string{`{"data":{"count":1}}`, `{"data":{"count":2}}`} {
+		msg := receive(t, conn)
+		assert.Equal(t, "1", msg.ID)
+		assert.Equal(t, gqlws.MsgNext, msg.Type)
+		assert.JSONEq(t, expected, string(msg.Payload))
+	}
+	msg := receive(t, conn)
+	assert.Equal(t, "1", msg.ID)
+	assert.Equal(t, gqlws.MsgComplete, msg.Type)
+
+	// queries are served as single result operations
+	subscribe(t, conn, "2", `{ hello }`)
+	msg = receive(t, conn)
+	assert.Equal(t, gqlws.MsgNext, msg.Type)
+	assert.JSONEq(t, `{"data":{"hello":"world"}}`, string(msg.Payload))
+	assert.Equal(t, gqlws.MsgComplete, receive(t, conn).Type)
+
+	subscribe(t, conn, "3", `subscription { unknown }`)
+	msg = receive(t, conn)
+	assert.Equal(t, "3", msg.ID)
+	assert.Equal(t, gqlws.MsgError, msg.Type)
+	assert.Contains(t, string(msg.Payload), "unknown")
+}
+
+func TestHandler_ProtocolErrors(t *testing.T) {
+	t.Parallel()
+
+	url := newServer(t)
+
+	t.Run("subscribe before init", func(t *testing.T) {
+		t.Parallel()
+
+		conn := dial(t, url)
+		subscribe(t, conn, "1", `subscription { count(to: 1) }`)
+		requireClosed(t, conn, gqlws.CloseUnauthorized)
+	})
+
+	t.Run("repeated init", func(t *testing.T) {
+		t.Parallel()
+
+		conn := dial(t, url)
+		send(t, conn, gqlws.Message{Type: gqlws.MsgConnectionInit})
+		assert.Equal(t, gqlws.MsgConnectionAck, receive(t, conn).Type)
+		send(t, conn, gqlws.Message{Type: gqlws.MsgConnectionInit})
+		requireClosed(t, conn, gqlws.CloseTooManyInitRequests)
+	})
+
+	t.Run("unknown message", func(t *testing.T) {
+		t.Parallel()
+
+		conn := dial(t, url)
+		send(t, conn, gqlws.Message{Type: "unknown"})
+		requireClosed(t, conn, gqlws.CloseInvalidMessage)
+	})
+}
@@ -664,7 +664,7 @@ func setupJobSpecsControllerTestsWithJobs(t *testing.T) (*cltest.TestApplication
 	err = app.AddJobV2(testutils.Context(t), &jb)
 	require.NoError(t, err)
 
-	erejb, err := directrequest.ValidatedDirectRequestSpec(string(cltest.MustReadFile(t, "../testdata/tomlspecs/direct-request-spec.toml")))
+	erejb, err := directrequest.ValidatedDirectRequestSpec(nil, nil, string(cltest.MustReadFile(t, "../testdata/tomlspecs/direct-request-spec.toml")))
 	require.NoError(t, err)
 	err = app.AddJobV2(testutils.Context(t), &erejb)
 	require.NoError(t, err)
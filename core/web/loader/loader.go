@@ -76,3 +76,28 @@ func InjectDataloader(ctx context.Context, app chainlink.Application) context.Co
 func For(ctx context.Context) *Dataloader {
 	return ctx.Value(loadersKey{}).(*Dataloader)
 }
+
+// Refresh clears the caches of the dataloader in the context, if any.
+// Long-lived GraphQL subscriptions resolve every event with the same context,
+// so values cached for earlier events must not be served for later ones.
+func Refresh(ctx context.Context) {
+	d, ok := ctx.Value(loadersKey{}).(*Dataloader)
+	if !ok {
+		return
+	}
+	for _, l := range []*dataloader.Loader{
+		d.ChainsByIDLoader,
+		d.EthTxAttemptsByEthTxIDLoader,
+		d.FeedsManagersByIDLoader,
+		d.FeedsManagerChainConfigsByManagerIDLoader,
+		d.JobProposalsByManagerIDLoader,
+		d.JobProposalSpecsByJobProposalID,
+		d.JobRunsByIDLoader,
+		d.JobsByExternalJobIDs,
+		d.JobsByPipelineSpecIDLoader,
+		d.NodesByChainIDLoader,
+		d.SpecErrorsByJobIDLoader,
+	} {
+		l.ClearAll()
+	}
+}
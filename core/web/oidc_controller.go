@@ -0,0 +1,139 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-contrib/sessions/cookie"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	clsessions "github.com/smartcontractkit/chainlink/v2/core/sessions"
+)
+
+const (
+	// oidcSessionName is the cookie holding the state of an in-progress login.
+	// It is separate from the main session cookie, which is SameSite=Strict and
+	// so is not sent when the identity provider redirects back to the node.
+	oidcSessionName     = "clsession_oidc"
+	oidcLoginTimeout    = 10 * time.Minute
+	oidcStateKey        = "oidc_state"
+	oidcNonceKey        = "oidc_nonce"
+	oidcCodeVerifierKey = "oidc_code_verifier"
+)
+
+// OIDCController manages single sign-on through an OpenID Connect identity provider.
+type OIDCController struct {
+	App        chainlink.Application
+	loginStore sessions.Store
+
+	mu       sync.Mutex
+	provider *clsessions.OIDCProvider
+}
+
+func NewOIDCController(app chainlink.Application, secret []byte) *OIDCController {
+	loginStore := cookie.NewStore(secret)
+	opts := app.GetConfig().SessionOptions()
+	opts.SameSite = http.SameSiteLaxMode
+	opts.MaxAge = int(oidcLoginTimeout.Seconds())
+	loginStore.Options(opts)
+	return &OIDCController{App: app, loginStore: loginStore}
+}
+
+// getProvider lazily runs discovery against the identity provider, so that
+// an unreachable provider does not prevent the node from starting.
+func (oc *OIDCController) getProvider(c *gin.Context) (*clsessions.OIDCProvider, error) {
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+	if oc.provider != nil {
+		return oc.provider, nil
+	}
+	p, err := clsessions.NewOIDCProvider(c.Request.Context(), oc.App.GetConfig())
+	if err != nil {
+		return nil, err
+	}
+	oc.provider = p
+	return p, nil
+}
+
+// Login redirects the user to the identity provider.
+// Example:
+// "GET <application>/oidc/login"
+func (oc *OIDCController) Login(c *gin.Context) {
+	provider, err := oc.getProvider(c)
+	if err != nil {
+		oc.App.GetLogger().Errorw("Failed to initialize OIDC provider", "err", err)
+		jsonAPIError(c, http.StatusServiceUnavailable, errors.New("OIDC provider unavailable"))
+		return
+	}
+
+	r := clsessions.NewOIDCAuthRequest()
+	// an invalid or expired cookie still returns a new, empty session
+	login, _ := oc.loginStore.Get(c.Request, oidcSessionName)
+	login.Values[oidcStateKey] = r.State
+	login.Values[oidcNonceKey] = r.Nonce
+	login.Values[oidcCodeVerifierKey] = r.CodeVerifier
+	if err = login.Save(c.Request, c.Writer); err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, multierr.Append(errors.New("unable to save OIDC login state"), err))
+		return
+	}
+
+	c.Redirect(http.StatusFound, provider.AuthCodeURL(r))
+}
+
+// Callback completes the authorization code flow and creates a session for
+// the user identified by the identity provider.
+// Example:
+// "GET <application>/oidc/callback?code=...&state=..."
+func (oc *OIDCController) Callback(c *gin.Context) {
+	defer oc.App.WakeSessionReaper()
+
+	provider, err := oc.getProvider(c)
+	if err != nil {
+		jsonAPIError(c, http.StatusServiceUnavailable, errors.New("OIDC provider unavailable"))
+		return
+	}
+
+	login, _ := oc.loginStore.Get(c.Request, oidcSessionName)
+	r := clsessions.OIDCAuthRequest{}
+	r.State, _ = login.Values[oidcStateKey].(string)
+	r.Nonce, _ = login.Values[oidcNonceKey].(string)
+	r.CodeVerifier, _ = login.Values[oidcCodeVerifierKey].(string)
+	// the login state is single use
+	login.Options.MaxAge = -1
+	if err = login.Save(c.Request, c.Writer); err != nil {
+		oc.App.GetLogger().Warnw("Failed to clear OIDC login state", "err", err)
+	}
+
+	if errParam := c.Query("error"); errParam != "" {
+		oc.App.GetAuditLogger().Audit(audit.AuthLoginFailedOIDC, map[string]interface{}{"error": errParam, "description": c.Query("error_description")})
+		jsonAPIError(c, http.StatusUnauthorized, errors.New("identity provider returned error: "+errParam))
+		return
+	}
+
+	identity, err := provider.Exchange(c.Request.Context(), r, c.Query("state"), c.Query("code"))
+	if err != nil {
+		oc.App.GetAuditLogger().Audit(audit.AuthLoginFailedOIDC, map[string]interface{}{"error": err})
+		oc.App.GetLogger().Warnw("OIDC login failed", "err", err)
+		jsonAPIError(c, http.StatusUnauthorized, errors.New("OIDC login failed"))
+		return
+	}
+
+	sid, err := oc.App.SessionORM().CreateSession(clsessions.SessionRequest{Email: identity.Email, OIDCIdentity: &identity})
+	if err != nil {
+		jsonAPIError(c, http.StatusUnauthorized, err)
+		return
+	}
+
+	if err := saveSessionID(sessions.Default(c), sid); err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, multierr.Append(errors.New("unable to save session id"), err))
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/")
+}
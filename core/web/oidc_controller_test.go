@@ -0,0 +1,104 @@
+package web_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	configtest "github.com/smartcontractkit/chainlink/v2/core/internal/testutils/configtest/v2"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/oidctest"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+	"github.com/smartcontractkit/chainlink/v2/core/store/models"
+	"github.com/smartcontractkit/chainlink/v2/core/web"
+)
+
+func setupOIDCApplication(t *testing.T, idp *oidctest.Server) *cltest.TestApplication {
+	cfg := configtest.NewGeneralConfig(t, func(c *chainlink.Config, s *chainlink.Secrets) {
+		c.WebServer.OIDC.Enabled = ptr(true)
+		c.WebServer.OIDC.IssuerURL = models.MustParseURL(idp.URL)
+		c.WebServer.OIDC.ClientID = ptr(idp.ClientID)
+		c.WebServer.OIDC.RedirectURL = models.MustParseURL("http://localhost/oidc/callback")
+		c.WebServer.OIDC.AdminGroups = &[]string{"cl-admins"}
+		c.WebServer.OIDC.RunGroups = &[]string{"cl-runners"}
+		c.WebServer.OIDC.DisableLocalPasswords = ptr(true)
+	})
+	app := cltest.NewApplicationWithConfig(t, cfg)
+	require.NoError(t, app.Start(testutils.Context(t)))
+	return app
+}
+
+func TestOIDCController_Login(t *testing.T) {
+	t.Parallel()
+
+	idp := oidctest.NewServer(t, "chainlink")
+	app := setupOIDCApplication(t, idp)
+
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+	client := &http.Client{Jar: jar, CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+
+	login := func(t *testing.T, groups []string) *http.Response {
+		idp.SetClaims(map[string]interface{}{"sub": "user-1", "email": "sso@example.com", "groups": groups})
+
+		resp, err := client.Get(app.Server.URL + "/oidc/login")
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusFound, resp.StatusCode)
+
+		callback := idp.Authorize(resp.Header.Get("Location"))
+		resp, err = client.Get(fmt.Sprintf("%s/oidc/callback?code=%s&state=%s", app.Server.URL, callback.Get("code"), callback.Get("state")))
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp
+	}
+
+	t.Run("mapped group", func(t *testing.T) {
+		resp := login(t, []string{"cl-runners"})
+		require.Equal(t, http.StatusFound, resp.StatusCode)
+		assert.Equal(t, "/", resp.Header.Get("Location"))
+
+		sessionCookie := web.FindSessionCookie(resp.Cookies())
+		require.NotNil(t, sessionCookie)
+		sessionID, err := cltest.DecodeSessionCookie(sessionCookie.Value)
+		require.NoError(t, err)
+		user, err := app.SessionORM().AuthorizedUserWithSession(sessionID)
+		require.NoError(t, err)
+		assert.Equal(t, "sso@example.com", user.Email)
+		assert.Equal(t, sessions.UserRoleRun, user.Role)
+	})
+
+	t.Run("unmapped group", func(t *testing.T) {
+		resp := login(t, []string{"marketing"})
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("callback without login", func(t *testing.T) {
+		resp, err := http.Get(app.Server.URL + "/oidc/callback?code=foo&state=bar") //nolint:noctx
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+}
+
+func TestSessionsController_Create_LocalPasswordsDisabled(t *testing.T) {
+	t.Parallel()
+
+	idp := oidctest.NewServer(t, "chainlink")
+	app := setupOIDCApplication(t, idp)
+
+	body := fmt.Sprintf(`{"email":"%s","password":"%s"}`, cltest.APIEmailAdmin, cltest.Password)
+	resp, err := http.Post(app.Server.URL+"/sessions", "application/json", bytes.NewBufferString(body)) //nolint:noctx
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
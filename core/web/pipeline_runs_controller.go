@@ -116,10 +116,21 @@ func (prc *PipelineRunsController) Create(c *gin.Context) {
 			return
 		}
 		if canRun {
-			jobRunID, err3 := prc.App.RunWebhookJobV2(c.Request.Context(), jobUUID, string(bodyBytes), pipeline.JSONSerializable{})
+			opts := webhook.RunJobOpts{
+				Timestamp:      c.GetHeader(webhook.TimestampHeader),
+				Signature:      c.GetHeader(webhook.SignatureHeader),
+				IdempotencyKey: c.GetHeader(webhook.IdempotencyKeyHeader),
+			}
+			jobRunID, err3 := prc.App.RunWebhookJobV2(c.Request.Context(), jobUUID, string(bodyBytes), pipeline.JSONSerializable{}, opts)
 			if errors.Is(err3, webhook.ErrJobNotExists) {
 				jsonAPIError(c, http.StatusNotFound, err3)
 				return
+			} else if errors.Is(err3, webhook.ErrInvalidSignature) {
+				jsonAPIError(c, http.StatusUnauthorized, err3)
+				return
+			} else if errors.Is(err3, webhook.ErrIdempotencyKeyInProgress) {
+				jsonAPIError(c, http.StatusConflict, err3)
+				return
 			} else if err3 != nil {
 				jsonAPIError(c, http.StatusInternalServerError, err3)
 				return
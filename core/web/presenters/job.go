@@ -3,6 +3,7 @@ package presenters
 import (
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 	"gopkg.in/guregu/null.v4"
@@ -36,6 +37,7 @@ const (
 	BlockhashStoreJobSpec    JobSpecType = "blockhashstore"
 	BlockHeaderFeederJobSpec JobSpecType = "blockheaderfeeder"
 	BootstrapJobSpec         JobSpecType = "bootstrap"
+	EVMLogJobSpec            JobSpecType = "evmlog"
 )
 
 // DirectRequestSpec defines the spec details of a DirectRequest Job
@@ -45,6 +47,9 @@ type DirectRequestSpec struct {
 	MinIncomingConfirmationsEnv bool                     `json:"minIncomingConfirmationsEnv,omitempty"`
 	MinContractPayment          *assets.Link             `json:"minContractPaymentLinkJuels"`
 	Requesters                  models.AddressCollection `json:"requesters"`
+	MaxRequestGasLimit          clnull.Uint32            `json:"maxRequestGasLimit"`
+	MaxRequestMinConfirmations  clnull.Uint32            `json:"maxRequestMinConfirmations"`
+	RequestFromAddresses        models.AddressCollection `json:"requestFromAddresses"`
 	Initiator                   string                   `json:"initiator"`
 	CreatedAt                   time.Time                `json:"createdAt"`
 	UpdatedAt                   time.Time                `json:"updatedAt"`
@@ -60,6 +65,9 @@ func NewDirectRequestSpec(spec *job.DirectRequestSpec) *DirectRequestSpec {
 		MinIncomingConfirmationsEnv: spec.MinIncomingConfirmationsEnv,
 		MinContractPayment:          spec.MinContractPayment,
 		Requesters:                  spec.Requesters,
+		MaxRequestGasLimit:          spec.MaxRequestGasLimit,
+		MaxRequestMinConfirmations:  spec.MaxRequestMinConfirmations,
+		RequestFromAddresses:        spec.RequestFromAddresses,
 		// This is hardcoded to runlog. When we support other initiators, we need
 		// to change this
 		Initiator:  "runlog",
@@ -71,20 +79,21 @@ func NewDirectRequestSpec(spec *job.DirectRequestSpec) *DirectRequestSpec {
 
 // FluxMonitorSpec defines the spec details of a FluxMonitor Job
 type FluxMonitorSpec struct {
-	ContractAddress     ethkey.EIP55Address `json:"contractAddress"`
-	Threshold           float32             `json:"threshold"`
-	AbsoluteThreshold   float32             `json:"absoluteThreshold"`
-	PollTimerPeriod     string              `json:"pollTimerPeriod"`
-	PollTimerDisabled   bool                `json:"pollTimerDisabled"`
-	IdleTimerPeriod     string              `json:"idleTimerPeriod"`
-	IdleTimerDisabled   bool                `json:"idleTimerDisabled"`
-	DrumbeatEnabled     bool                `json:"drumbeatEnabled"`
-	DrumbeatSchedule    *string             `json:"drumbeatSchedule"`
-	DrumbeatRandomDelay *string             `json:"drumbeatRandomDelay"`
-	MinPayment          *assets.Link        `json:"minPayment"`
-	CreatedAt           time.Time           `json:"createdAt"`
-	UpdatedAt           time.Time           `json:"updatedAt"`
-	EVMChainID          *utils.Big          `json:"evmChainID"`
+	ContractAddress     ethkey.EIP55Address  `json:"contractAddress"`
+	Threshold           float32              `json:"threshold"`
+	AbsoluteThreshold   float32              `json:"absoluteThreshold"`
+	PollTimerPeriod     string               `json:"pollTimerPeriod"`
+	PollTimerDisabled   bool                 `json:"pollTimerDisabled"`
+	IdleTimerPeriod     string               `json:"idleTimerPeriod"`
+	IdleTimerDisabled   bool                 `json:"idleTimerDisabled"`
+	DrumbeatEnabled     bool                 `json:"drumbeatEnabled"`
+	DrumbeatSchedule    *string              `json:"drumbeatSchedule"`
+	DrumbeatRandomDelay *string              `json:"drumbeatRandomDelay"`
+	MinPayment          *assets.Link         `json:"minPayment"`
+	CreatedAt           time.Time            `json:"createdAt"`
+	UpdatedAt           time.Time            `json:"updatedAt"`
+	EVMChainID          *utils.Big           `json:"evmChainID"`
+	Feeds               job.FluxMonitorFeeds `json:"feeds"`
 }
 
 // NewFluxMonitorSpec initializes a new DirectFluxMonitorSpec from a
@@ -114,6 +123,7 @@ func NewFluxMonitorSpec(spec *job.FluxMonitorSpec) *FluxMonitorSpec {
 		CreatedAt:           spec.CreatedAt,
 		UpdatedAt:           spec.UpdatedAt,
 		EVMChainID:          spec.EVMChainID,
+		Feeds:               spec.Feeds,
 	}
 }
 
@@ -254,31 +264,45 @@ func NewKeeperSpec(spec *job.KeeperSpec) *KeeperSpec {
 
 // WebhookSpec defines the spec details of a Webhook Job
 type WebhookSpec struct {
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	HMACEnabled            bool             `json:"hmacEnabled"`
+	HMACTimestampTolerance *models.Interval `json:"hmacTimestampTolerance,omitempty"`
+	CreatedAt              time.Time        `json:"createdAt"`
+	UpdatedAt              time.Time        `json:"updatedAt"`
 }
 
-// NewWebhookSpec generates a new WebhookSpec from a job.WebhookSpec
+// NewWebhookSpec generates a new WebhookSpec from a job.WebhookSpec. The HMAC
+// secret is never presented.
 func NewWebhookSpec(spec *job.WebhookSpec) *WebhookSpec {
-	return &WebhookSpec{
-		CreatedAt: spec.CreatedAt,
-		UpdatedAt: spec.UpdatedAt,
+	s := &WebhookSpec{
+		HMACEnabled: spec.HMACSecret.Valid,
+		CreatedAt:   spec.CreatedAt,
+		UpdatedAt:   spec.UpdatedAt,
 	}
+	if spec.HMACSecret.Valid {
+		s.HMACTimestampTolerance = &spec.HMACTimestampTolerance
+	}
+	return s
 }
 
 // CronSpec defines the spec details of a Cron Job
 type CronSpec struct {
-	CronSchedule string    `json:"schedule" tom:"schedule"`
-	CreatedAt    time.Time `json:"createdAt"`
-	UpdatedAt    time.Time `json:"updatedAt"`
+	CronSchedule  string                `json:"schedule" tom:"schedule"`
+	Timezone      string                `json:"timezone"`
+	MisfirePolicy job.CronMisfirePolicy `json:"misfirePolicy"`
+	OverlapPolicy job.CronOverlapPolicy `json:"overlapPolicy"`
+	CreatedAt     time.Time             `json:"createdAt"`
+	UpdatedAt     time.Time             `json:"updatedAt"`
 }
 
 // NewCronSpec generates a new CronSpec from a job.CronSpec
 func NewCronSpec(spec *job.CronSpec) *CronSpec {
 	return &CronSpec{
-		CronSchedule: spec.CronSchedule,
-		CreatedAt:    spec.CreatedAt,
-		UpdatedAt:    spec.UpdatedAt,
+		CronSchedule:  spec.CronSchedule,
+		Timezone:      spec.Timezone,
+		MisfirePolicy: spec.MisfirePolicy,
+		OverlapPolicy: spec.OverlapPolicy,
+		CreatedAt:     spec.CreatedAt,
+		UpdatedAt:     spec.UpdatedAt,
 	}
 }
 
@@ -323,31 +347,39 @@ func NewVRFSpec(spec *job.VRFSpec) *VRFSpec {
 
 // BlockhashStoreSpec defines the job parameters for a blockhash store feeder job.
 type BlockhashStoreSpec struct {
-	CoordinatorV1Address  *ethkey.EIP55Address  `json:"coordinatorV1Address"`
-	CoordinatorV2Address  *ethkey.EIP55Address  `json:"coordinatorV2Address"`
-	WaitBlocks            int32                 `json:"waitBlocks"`
-	LookbackBlocks        int32                 `json:"lookbackBlocks"`
-	BlockhashStoreAddress ethkey.EIP55Address   `json:"blockhashStoreAddress"`
-	PollPeriod            time.Duration         `json:"pollPeriod"`
-	RunTimeout            time.Duration         `json:"runTimeout"`
-	EVMChainID            *utils.Big            `json:"evmChainID"`
-	FromAddresses         []ethkey.EIP55Address `json:"fromAddresses"`
-	CreatedAt             time.Time             `json:"createdAt"`
-	UpdatedAt             time.Time             `json:"updatedAt"`
+	CoordinatorV1Address           *ethkey.EIP55Address  `json:"coordinatorV1Address"`
+	CoordinatorV2Address           *ethkey.EIP55Address  `json:"coordinatorV2Address"`
+	CoordinatorOCR2VRFAddress      *ethkey.EIP55Address  `json:"coordinatorOCR2VRFAddress"`
+	WaitBlocks                     int32                 `json:"waitBlocks"`
+	LookbackBlocks                 int32                 `json:"lookbackBlocks"`
+	BlockhashStoreAddress          ethkey.EIP55Address   `json:"blockhashStoreAddress"`
+	TrustedBlockhashStoreAddress   *ethkey.EIP55Address  `json:"trustedBlockhashStoreAddress"`
+	TrustedBlockhashStoreBatchSize int32                 `json:"trustedBlockhashStoreBatchSize"`
+	TrustedBlockhashStoreGasLimit  uint32                `json:"trustedBlockhashStoreGasLimit"`
+	PollPeriod                     time.Duration         `json:"pollPeriod"`
+	RunTimeout                     time.Duration         `json:"runTimeout"`
+	EVMChainID                     *utils.Big            `json:"evmChainID"`
+	FromAddresses                  []ethkey.EIP55Address `json:"fromAddresses"`
+	CreatedAt                      time.Time             `json:"createdAt"`
+	UpdatedAt                      time.Time             `json:"updatedAt"`
 }
 
 // NewBlockhashStoreSpec creates a new BlockhashStoreSpec for the given parameters.
 func NewBlockhashStoreSpec(spec *job.BlockhashStoreSpec) *BlockhashStoreSpec {
 	return &BlockhashStoreSpec{
-		CoordinatorV1Address:  spec.CoordinatorV1Address,
-		CoordinatorV2Address:  spec.CoordinatorV2Address,
-		WaitBlocks:            spec.WaitBlocks,
-		LookbackBlocks:        spec.LookbackBlocks,
-		BlockhashStoreAddress: spec.BlockhashStoreAddress,
-		PollPeriod:            spec.PollPeriod,
-		RunTimeout:            spec.RunTimeout,
-		EVMChainID:            spec.EVMChainID,
-		FromAddresses:         spec.FromAddresses,
+		CoordinatorV1Address:           spec.CoordinatorV1Address,
+		CoordinatorV2Address:           spec.CoordinatorV2Address,
+		CoordinatorOCR2VRFAddress:      spec.CoordinatorOCR2VRFAddress,
+		WaitBlocks:                     spec.WaitBlocks,
+		LookbackBlocks:                 spec.LookbackBlocks,
+		BlockhashStoreAddress:          spec.BlockhashStoreAddress,
+		TrustedBlockhashStoreAddress:   spec.TrustedBlockhashStoreAddress,
+		TrustedBlockhashStoreBatchSize: spec.TrustedBlockhashStoreBatchSize,
+		TrustedBlockhashStoreGasLimit:  spec.TrustedBlockhashStoreGasLimit,
+		PollPeriod:                     spec.PollPeriod,
+		RunTimeout:                     spec.RunTimeout,
+		EVMChainID:                     spec.EVMChainID,
+		FromAddresses:                  spec.FromAddresses,
 	}
 }
 
@@ -415,6 +447,34 @@ func NewBootstrapSpec(spec *job.BootstrapSpec) *BootstrapSpec {
 	}
 }
 
+// EVMLogSpec defines the spec details of an EVMLog job
+type EVMLogSpec struct {
+	ContractAddress  ethkey.EIP55Address `json:"contractAddress"`
+	EventABI         string              `json:"eventABI"`
+	Topic1           []common.Hash       `json:"topic1"`
+	Topic2           []common.Hash       `json:"topic2"`
+	Topic3           []common.Hash       `json:"topic3"`
+	MinConfirmations uint32              `json:"minConfirmations"`
+	EVMChainID       *utils.Big          `json:"evmChainID"`
+	CreatedAt        time.Time           `json:"createdAt"`
+	UpdatedAt        time.Time           `json:"updatedAt"`
+}
+
+// NewEVMLogSpec initializes a new EVMLogSpec from a job.EVMLogSpec
+func NewEVMLogSpec(spec *job.EVMLogSpec) *EVMLogSpec {
+	return &EVMLogSpec{
+		ContractAddress:  spec.ContractAddress,
+		EventABI:         spec.EventABI,
+		Topic1:           spec.Topic1,
+		Topic2:           spec.Topic2,
+		Topic3:           spec.Topic3,
+		MinConfirmations: spec.MinConfirmations,
+		EVMChainID:       spec.EVMChainID,
+		CreatedAt:        spec.CreatedAt,
+		UpdatedAt:        spec.UpdatedAt,
+	}
+}
+
 // JobError represents errors on the job
 type JobError struct {
 	ID          int64     `json:"id"`
@@ -455,6 +515,7 @@ type JobResource struct {
 	BlockhashStoreSpec     *BlockhashStoreSpec     `json:"blockhashStoreSpec"`
 	BlockHeaderFeederSpec  *BlockHeaderFeederSpec  `json:"blockHeaderFeederSpec"`
 	BootstrapSpec          *BootstrapSpec          `json:"bootstrapSpec"`
+	EVMLogSpec             *EVMLogSpec             `json:"evmLogSpec"`
 	PipelineSpec           PipelineSpec            `json:"pipelineSpec"`
 	Errors                 []JobError              `json:"errors"`
 }
@@ -496,6 +557,8 @@ func NewJobResource(j job.Job) *JobResource {
 		resource.BlockHeaderFeederSpec = NewBlockHeaderFeederSpec(j.BlockHeaderFeederSpec)
 	case job.Bootstrap:
 		resource.BootstrapSpec = NewBootstrapSpec(j.BootstrapSpec)
+	case job.EVMLog:
+		resource.EVMLogSpec = NewEVMLogSpec(j.EVMLogSpec)
 	}
 
 	jes := []JobError{}
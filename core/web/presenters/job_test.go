@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/google/uuid"
 
 	"github.com/lib/pq"
@@ -46,6 +47,12 @@ func TestJob(t *testing.T) {
 	v2CoordAddress, err := ethkey.NewEIP55Address("0x2C409DD6D4eBDdA190B5174Cc19616DD13884262")
 	require.NoError(t, err)
 
+	ocr2vrfCoordAddress, err := ethkey.NewEIP55Address("0x2be990eE17832b59E0086534c5ea2459Aa75E38F")
+	require.NoError(t, err)
+
+	trustedBlockhashStoreAddress, err := ethkey.NewEIP55Address("0x0ad9FE7a58216242a8475ca92F222b0640E26B63")
+	require.NoError(t, err)
+
 	// Used in blockheaderfeeder test
 	batchBHSAddress, err := ethkey.NewEIP55Address("0xF6bB415b033D19EFf24A872a4785c6e1C4426103")
 	require.NoError(t, err)
@@ -90,6 +97,7 @@ func TestJob(t *testing.T) {
 						"type": "directrequest",
 						"maxTaskDuration": "1m0s",
 					    "externalJobID":"0eec7e1d-d0d2-476c-a1a8-72dfb6633f46",
+						"evmLogSpec": null,
 						"pipelineSpec": {
 							"id": 1,
 							"dotDagSource": "ds1 [type=http method=GET url=\"https://pricesource1.com\"",
@@ -100,6 +108,9 @@ func TestJob(t *testing.T) {
 							"minIncomingConfirmations": null,
 							"minContractPaymentLinkJuels": null,
 							"requesters": null,
+							"maxRequestGasLimit": null,
+							"maxRequestMinConfirmations": null,
+							"requestFromAddresses": null,
 							"initiator": "runlog",
 							"createdAt":"2000-01-01T00:00:00Z",
 							"updatedAt":"2000-01-01T00:00:00Z",
@@ -159,6 +170,7 @@ func TestJob(t *testing.T) {
 						"type": "fluxmonitor",
 						"maxTaskDuration": "1m0s",
 					    "externalJobID":"0eec7e1d-d0d2-476c-a1a8-72dfb6633f46",
+						"evmLogSpec": null,
 						"pipelineSpec": {
 							"id": 1,
 							"dotDagSource": "ds1 [type=http method=GET url=\"https://pricesource1.com\"",
@@ -178,7 +190,8 @@ func TestJob(t *testing.T) {
 							"minPayment": "1",
 							"createdAt":"2000-01-01T00:00:00Z",
 							"updatedAt":"2000-01-01T00:00:00Z",
-							"evmChainID": "42"
+							"evmChainID": "42",
+							"feeds": null
 						},
 						"gasLimit": null,
 						"forwardingAllowed": false,
@@ -243,6 +256,7 @@ func TestJob(t *testing.T) {
 						"type": "offchainreporting",
 						"maxTaskDuration": "1m0s",
 					  "externalJobID":"0eec7e1d-d0d2-476c-a1a8-72dfb6633f46",
+						"evmLogSpec": null,
 						"pipelineSpec": {
 							"id": 1,
 							"dotDagSource": "ds1 [type=http method=GET url=\"https://pricesource1.com\"",
@@ -316,6 +330,7 @@ func TestJob(t *testing.T) {
 						"type": "keeper",
 						"maxTaskDuration": "1m0s",
 					    "externalJobID":"0eec7e1d-d0d2-476c-a1a8-72dfb6633f46",
+						"evmLogSpec": null,
 						"pipelineSpec": {
 							"id": 1,
 							"dotDagSource": "",
@@ -351,9 +366,12 @@ func TestJob(t *testing.T) {
 			job: job.Job{
 				ID: 1,
 				CronSpec: &job.CronSpec{
-					CronSchedule: cronSchedule,
-					CreatedAt:    timestamp,
-					UpdatedAt:    timestamp,
+					CronSchedule:  cronSchedule,
+					Timezone:      "Europe/Berlin",
+					MisfirePolicy: job.CronMisfireRunOnce,
+					OverlapPolicy: job.CronOverlapQueue,
+					CreatedAt:     timestamp,
+					UpdatedAt:     timestamp,
 				},
 				ExternalJobID: uuid.MustParse("0EEC7E1D-D0D2-476C-A1A8-72DFB6633F46"),
 				PipelineSpec: &pipeline.Spec{
@@ -376,6 +394,7 @@ func TestJob(t *testing.T) {
                         "type": "cron",
                         "maxTaskDuration": "1m0s",
 					    "externalJobID":"0eec7e1d-d0d2-476c-a1a8-72dfb6633f46",
+                        "evmLogSpec": null,
                         "pipelineSpec": {
                             "id": 1,
                             "dotDagSource": "",
@@ -383,6 +402,9 @@ func TestJob(t *testing.T) {
                         },
                         "cronSpec": {
                             "schedule": "%s",
+                            "timezone": "Europe/Berlin",
+                            "misfirePolicy": "runOnce",
+                            "overlapPolicy": "queue",
                             "createdAt":"2000-01-01T00:00:00Z",
                             "updatedAt":"2000-01-01T00:00:00Z"
                         },
@@ -408,8 +430,10 @@ func TestJob(t *testing.T) {
 			job: job.Job{
 				ID: 1,
 				WebhookSpec: &job.WebhookSpec{
-					CreatedAt: timestamp,
-					UpdatedAt: timestamp,
+					HMACSecret:             null.StringFrom("0123456789abcdef0123456789abcdef"),
+					HMACTimestampTolerance: models.Interval(5 * time.Minute),
+					CreatedAt:              timestamp,
+					UpdatedAt:              timestamp,
 				},
 				ExternalJobID: uuid.MustParse("0eec7e1d-d0d2-476c-a1a8-72dfb6633f46"),
 				PipelineSpec: &pipeline.Spec{
@@ -432,12 +456,15 @@ func TestJob(t *testing.T) {
 						"type": "webhook",
 						"maxTaskDuration": "1m0s",
 					    "externalJobID":"0eec7e1d-d0d2-476c-a1a8-72dfb6633f46",
+						"evmLogSpec": null,
 						"pipelineSpec": {
 							"id": 1,
 							"dotDagSource": "",
 							"jobID": 0
 						},
 						"webhookSpec": {
+							"hmacEnabled": true,
+							"hmacTimestampTolerance": "5m0s",
 							"createdAt":"2000-01-01T00:00:00Z",
 							"updatedAt":"2000-01-01T00:00:00Z"
 						},
@@ -463,16 +490,20 @@ func TestJob(t *testing.T) {
 			job: job.Job{
 				ID: 1,
 				BlockhashStoreSpec: &job.BlockhashStoreSpec{
-					ID:                    1,
-					CoordinatorV1Address:  &v1CoordAddress,
-					CoordinatorV2Address:  &v2CoordAddress,
-					WaitBlocks:            123,
-					LookbackBlocks:        223,
-					BlockhashStoreAddress: contractAddress,
-					PollPeriod:            25 * time.Second,
-					RunTimeout:            10 * time.Second,
-					EVMChainID:            utils.NewBigI(4),
-					FromAddresses:         []ethkey.EIP55Address{fromAddress},
+					ID:                             1,
+					CoordinatorV1Address:           &v1CoordAddress,
+					CoordinatorV2Address:           &v2CoordAddress,
+					CoordinatorOCR2VRFAddress:      &ocr2vrfCoordAddress,
+					WaitBlocks:                     123,
+					LookbackBlocks:                 223,
+					BlockhashStoreAddress:          contractAddress,
+					PollPeriod:                     25 * time.Second,
+					RunTimeout:                     10 * time.Second,
+					EVMChainID:                     utils.NewBigI(4),
+					FromAddresses:                  []ethkey.EIP55Address{fromAddress},
+					TrustedBlockhashStoreAddress:   &trustedBlockhashStoreAddress,
+					TrustedBlockhashStoreBatchSize: 20,
+					TrustedBlockhashStoreGasLimit:  2_500_000,
 				},
 				PipelineSpec: &pipeline.Spec{
 					ID:           1,
@@ -507,9 +538,13 @@ func TestJob(t *testing.T) {
 						"blockhashStoreSpec": {
 							"coordinatorV1Address": "0x16988483b46e695f6c8D58e6e1461DC703e008e1",
 							"coordinatorV2Address": "0x2C409DD6D4eBDdA190B5174Cc19616DD13884262",
+							"coordinatorOCR2VRFAddress": "0x2be990eE17832b59E0086534c5ea2459Aa75E38F",
 							"waitBlocks": 123,
 							"lookbackBlocks": 223,
 							"blockhashStoreAddress": "0x9E40733cC9df84636505f4e6Db28DCa0dC5D1bba",
+							"trustedBlockhashStoreAddress": "0x0ad9FE7a58216242a8475ca92F222b0640E26B63",
+							"trustedBlockhashStoreBatchSize": 20,
+							"trustedBlockhashStoreGasLimit": 2500000,
 							"pollPeriod": 25000000000,
 							"runTimeout": 10000000000,
 							"evmChainID": "4",
@@ -519,6 +554,7 @@ func TestJob(t *testing.T) {
 						},
 						"blockHeaderFeederSpec": null,
 						"bootstrapSpec": null,
+						"evmLogSpec": null,
 						"pipelineSpec": {
 							"id": 1,
 							"jobID": 0,
@@ -596,6 +632,7 @@ func TestJob(t *testing.T) {
 							"updatedAt": "0001-01-01T00:00:00Z"
 						},
 						"bootstrapSpec": null,
+						"evmLogSpec": null,
 						"pipelineSpec": {
 							"id": 1,
 							"jobID": 0,
@@ -659,6 +696,73 @@ func TestJob(t *testing.T) {
 							"relayConfig":{"chainID":1337}, 
 							"updatedAt":"0001-01-01T00:00:00Z"
 						},
+						"evmLogSpec": null,
+						"pipelineSpec": {
+							"id": 1,
+							"jobID": 0,
+							"dotDagSource": ""
+						},
+						"errors": []
+					}
+				}
+			}`,
+		},
+		{
+			name: "evm log spec",
+			job: job.Job{
+				ID: 1,
+				EVMLogSpec: &job.EVMLogSpec{
+					ID:               1,
+					ContractAddress:  contractAddress,
+					EventABI:         "Transfer(address indexed from, address indexed to, uint256 value)",
+					Topic2:           []common.Hash{common.HexToHash("0x2a")},
+					MinConfirmations: 3,
+					EVMChainID:       utils.NewBigI(4),
+				},
+				PipelineSpec: &pipeline.Spec{
+					ID:           1,
+					DotDagSource: "",
+				},
+				ExternalJobID: uuid.MustParse("0eec7e1d-d0d2-476c-a1a8-72dfb6633f47"),
+				Type:          job.EVMLog,
+				SchemaVersion: 1,
+				Name:          null.StringFrom("evmlog"),
+			},
+			want: `
+			{
+				"data": {
+					"type": "jobs",
+					"id": "1",
+					"attributes": {
+						"name": "evmlog",
+						"type": "evmlog",
+						"schemaVersion": 1,
+						"maxTaskDuration": "0s",
+						"externalJobID": "0eec7e1d-d0d2-476c-a1a8-72dfb6633f47",
+						"directRequestSpec": null,
+						"fluxMonitorSpec": null,
+						"gasLimit": null,
+						"forwardingAllowed": false,
+						"cronSpec": null,
+						"offChainReportingOracleSpec": null,
+						"offChainReporting2OracleSpec": null,
+						"keeperSpec": null,
+						"vrfSpec": null,
+						"webhookSpec": null,
+						"blockhashStoreSpec": null,
+						"blockHeaderFeederSpec": null,
+						"bootstrapSpec": null,
+						"evmLogSpec": {
+							"contractAddress": "0x9E40733cC9df84636505f4e6Db28DCa0dC5D1bba",
+							"eventABI": "Transfer(address indexed from, address indexed to, uint256 value)",
+							"topic1": null,
+							"topic2": ["0x000000000000000000000000000000000000000000000000000000000000002a"],
+							"topic3": null,
+							"minConfirmations": 3,
+							"evmChainID": "4",
+							"createdAt": "0001-01-01T00:00:00Z",
+							"updatedAt": "0001-01-01T00:00:00Z"
+						},
 						"pipelineSpec": {
 							"id": 1,
 							"jobID": 0,
@@ -711,6 +815,7 @@ func TestJob(t *testing.T) {
 						"type": "keeper",
 						"maxTaskDuration": "1m0s",
 					    "externalJobID":"0eec7e1d-d0d2-476c-a1a8-72dfb6633f46",
+						"evmLogSpec": null,
 						"pipelineSpec": {
 							"id": 1,
 							"dotDagSource": "",
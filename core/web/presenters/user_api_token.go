@@ -0,0 +1,63 @@
+package presenters
+
+import (
+	"time"
+
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+)
+
+// UserAPITokenResource represents a named API token JSONAPI resource. The
+// credentials are never included.
+type UserAPITokenResource struct {
+	JAID
+	Name      string            `json:"name"`
+	Role      sessions.UserRole `json:"role"`
+	ExpiresAt *time.Time        `json:"expiresAt"`
+	LastUsed  *time.Time        `json:"lastUsed"`
+	CreatedAt time.Time         `json:"createdAt"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r UserAPITokenResource) GetName() string {
+	return "user_api_tokens"
+}
+
+// NewUserAPITokenResource constructs a new UserAPITokenResource.
+//
+// Token names are unique per user, so the name is used as the ID.
+func NewUserAPITokenResource(t sessions.APIToken) *UserAPITokenResource {
+	return &UserAPITokenResource{
+		JAID:      NewJAID(t.Name),
+		Name:      t.Name,
+		Role:      t.Role,
+		ExpiresAt: t.ExpiresAt.Ptr(),
+		LastUsed:  t.LastUsed.Ptr(),
+		CreatedAt: t.CreatedAt,
+	}
+}
+
+// NewUserAPITokenResources initializes a slice of JSONAPI named API token resources
+func NewUserAPITokenResources(tokens []sessions.APIToken) []UserAPITokenResource {
+	rs := []UserAPITokenResource{}
+	for _, t := range tokens {
+		rs = append(rs, *NewUserAPITokenResource(t))
+	}
+	return rs
+}
+
+// CreatedUserAPITokenResource is returned once when a named API token is
+// created, and is the only time its secret is revealed.
+type CreatedUserAPITokenResource struct {
+	UserAPITokenResource
+	AccessKey string `json:"accessKey"`
+	Secret    string `json:"secret"`
+}
+
+// NewCreatedUserAPITokenResource constructs a new CreatedUserAPITokenResource.
+func NewCreatedUserAPITokenResource(t sessions.APIToken, secret string) *CreatedUserAPITokenResource {
+	return &CreatedUserAPITokenResource{
+		UserAPITokenResource: *NewUserAPITokenResource(t),
+		AccessKey:            t.TokenKey,
+		Secret:               secret,
+	}
+}
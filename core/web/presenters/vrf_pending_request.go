@@ -0,0 +1,76 @@
+package presenters
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+// VRFPendingRequestResource is a JSONAPI resource for a VRF v2 request that
+// has not been fulfilled yet.
+type VRFPendingRequestResource struct {
+	JAID
+	JobID                 int32          `json:"jobID"`
+	SubID                 uint64         `json:"subID"`
+	Sender                common.Address `json:"sender"`
+	RequestTxHash         common.Hash    `json:"requestTxHash"`
+	RequestBlockNumber    uint64         `json:"requestBlockNumber"`
+	ReceivedAt            time.Time      `json:"receivedAt"`
+	ConfirmedAtBlock      uint64         `json:"confirmedAtBlock"`
+	RequiredConfirmations uint64         `json:"requiredConfirmations"`
+	Confirmations         uint64         `json:"confirmations"`
+	CallbackGasLimit      uint32         `json:"callbackGasLimit"`
+	Attempts              int            `json:"attempts"`
+	LastTry               *time.Time     `json:"lastTry"`
+	NextTry               *time.Time     `json:"nextTry"`
+	EstimatedFeeJuels     *utils.Big     `json:"estimatedFeeJuels"`
+	LastSkipReason        string         `json:"lastSkipReason"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r VRFPendingRequestResource) GetName() string {
+	return "vrf_pending_requests"
+}
+
+// NewVRFPendingRequestResource constructs a new VRFPendingRequestResource.
+func NewVRFPendingRequestResource(req vrf.PendingRequest) VRFPendingRequestResource {
+	r := VRFPendingRequestResource{
+		JAID:                  NewJAID(req.RequestID),
+		JobID:                 req.JobID,
+		SubID:                 req.SubID,
+		Sender:                req.Sender,
+		RequestTxHash:         req.RequestTxHash,
+		RequestBlockNumber:    req.RequestBlockNumber,
+		ReceivedAt:            req.ReceivedAt,
+		ConfirmedAtBlock:      req.ConfirmedAtBlock,
+		RequiredConfirmations: req.RequiredConfirmations,
+		Confirmations:         req.Confirmations,
+		CallbackGasLimit:      req.CallbackGasLimit,
+		Attempts:              req.Attempts,
+		LastSkipReason:        req.LastSkipReason,
+	}
+	if !req.LastTry.IsZero() {
+		lastTry := req.LastTry
+		r.LastTry = &lastTry
+	}
+	if !req.NextTry.IsZero() {
+		nextTry := req.NextTry
+		r.NextTry = &nextTry
+	}
+	if req.EstimatedFeeJuels != nil {
+		r.EstimatedFeeJuels = utils.NewBig(req.EstimatedFeeJuels)
+	}
+	return r
+}
+
+// NewVRFPendingRequestResources constructs a slice of VRFPendingRequestResource.
+func NewVRFPendingRequestResources(reqs []vrf.PendingRequest) []VRFPendingRequestResource {
+	rs := []VRFPendingRequestResource{}
+	for _, req := range reqs {
+		rs = append(rs, NewVRFPendingRequestResource(req))
+	}
+	return rs
+}
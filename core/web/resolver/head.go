@@ -0,0 +1,42 @@
+package resolver
+
+import (
+	"github.com/graph-gophers/graphql-go"
+
+	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
+	"github.com/smartcontractkit/chainlink/v2/core/utils/stringutils"
+)
+
+// HeadResolver resolves the Head type.
+type HeadResolver struct {
+	head evmtypes.Head
+}
+
+func NewHead(head evmtypes.Head) *HeadResolver {
+	return &HeadResolver{head: head}
+}
+
+// Number resolves the head's block number.
+func (r *HeadResolver) Number() string {
+	return stringutils.FromInt64(r.head.Number)
+}
+
+// Hash resolves the head's block hash.
+func (r *HeadResolver) Hash() string {
+	return r.head.Hash.Hex()
+}
+
+// ParentHash resolves the head's parent block hash.
+func (r *HeadResolver) ParentHash() string {
+	return r.head.ParentHash.Hex()
+}
+
+// Timestamp resolves the head's block timestamp.
+func (r *HeadResolver) Timestamp() graphql.Time {
+	return graphql.Time{Time: r.head.Timestamp}
+}
+
+// ChainID resolves the head's EVM chain ID.
+func (r *HeadResolver) ChainID() graphql.ID {
+	return graphql.ID(r.head.EVMChainID.String())
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/slo"
 	"github.com/smartcontractkit/chainlink/v2/core/web/loader"
 )
 
@@ -131,6 +132,74 @@ func (r *JobResolver) Runs(ctx context.Context, args struct {
 	return NewJobRunsPayload(runs, count, r.app), nil
 }
 
+// Health resolves the last evaluated health of the job's SLO, if any.
+func (r *JobResolver) Health() *JobHealthResolver {
+	h, ok := r.app.JobSLOMonitor().JobHealth(r.j.ID)
+	if !ok {
+		return nil
+	}
+
+	return NewJobHealth(h)
+}
+
+// JobHealthResolver resolves the JobHealth type.
+type JobHealthResolver struct {
+	h slo.Health
+}
+
+func NewJobHealth(h slo.Health) *JobHealthResolver {
+	return &JobHealthResolver{h: h}
+}
+
+// Healthy resolves whether the job meets its SLO.
+func (r *JobHealthResolver) Healthy() bool {
+	return r.h.Healthy
+}
+
+// Violations resolves the violated objectives of the SLO.
+func (r *JobHealthResolver) Violations() []string {
+	return r.h.Violations
+}
+
+// LastSuccessAt resolves the time of the last successful run.
+func (r *JobHealthResolver) LastSuccessAt() *graphql.Time {
+	if r.h.LastSuccessAt == nil {
+		return nil
+	}
+
+	return &graphql.Time{Time: *r.h.LastSuccessAt}
+}
+
+// LastTransmissionAt resolves the time of the last confirmed on-chain
+// transmission, if within maxTransmissionStaleness.
+func (r *JobHealthResolver) LastTransmissionAt() *graphql.Time {
+	if r.h.LastTransmissionAt == nil {
+		return nil
+	}
+
+	return &graphql.Time{Time: *r.h.LastTransmissionAt}
+}
+
+// Runs resolves the number of runs finished in the SLO window.
+func (r *JobHealthResolver) Runs() int32 {
+	return int32(r.h.Runs)
+}
+
+// ErrorRate resolves the ratio of errored runs in the SLO window.
+func (r *JobHealthResolver) ErrorRate() float64 {
+	return r.h.ErrorRate
+}
+
+// P95Duration resolves the 95th percentile run duration in the SLO window.
+func (r *JobHealthResolver) P95Duration() string {
+	return r.h.P95Duration.String()
+}
+
+// EvaluatedAt resolves the time of the evaluation.
+func (r *JobHealthResolver) EvaluatedAt() graphql.Time {
+	return graphql.Time{Time: r.h.EvaluatedAt}
+}
+
 // JobsPayloadResolver resolves a page of jobs
 type JobsPayloadResolver struct {
 	app   chainlink.Application
@@ -14,9 +14,12 @@ import (
 	"gopkg.in/guregu/null.v4"
 
 	clnull "github.com/smartcontractkit/chainlink/v2/core/null"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/v2/core/services/directrequest"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
 	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/v2/core/services/slo"
+	slomocks "github.com/smartcontractkit/chainlink/v2/core/services/slo/mocks"
 	"github.com/smartcontractkit/chainlink/v2/core/store/models"
 	"github.com/smartcontractkit/chainlink/v2/core/testdata/testspecs"
 	"github.com/smartcontractkit/chainlink/v2/core/utils/stringutils"
@@ -254,6 +257,93 @@ func TestResolver_Job(t *testing.T) {
 	RunGQLTests(t, testCases)
 }
 
+func TestResolver_JobHealth(t *testing.T) {
+	var (
+		id = int32(1)
+
+		query = `
+			query GetJob {
+				job(id: "1") {
+					... on Job {
+						health {
+							healthy
+							violations
+							lastSuccessAt
+							lastTransmissionAt
+							runs
+							errorRate
+							p95Duration
+							evaluatedAt
+						}
+					}
+				}
+			}
+		`
+	)
+
+	testCases := []GQLTestCase{
+		{
+			name:          "success",
+			authenticated: true,
+			before: func(f *gqlTestFramework) {
+				f.App.On("JobORM").Return(f.Mocks.jobORM)
+				f.Mocks.jobORM.On("FindJobWithoutSpecErrors", id).Return(job.Job{ID: id}, nil)
+				lastSuccessAt := f.Timestamp()
+				monitor := slomocks.NewMonitor(t)
+				monitor.On("JobHealth", id).Return(slo.Health{
+					JobID:         id,
+					Healthy:       false,
+					Violations:    []string{"no successful run for 2h0m0s, above maxStaleness of 1h0m0s"},
+					LastSuccessAt: &lastSuccessAt,
+					Runs:          4,
+					ErrorRate:     0.25,
+					P95Duration:   1500 * time.Millisecond,
+					EvaluatedAt:   f.Timestamp().Add(2 * time.Hour),
+				}, true)
+				f.App.On("JobSLOMonitor").Return(monitor)
+			},
+			query: query,
+			result: `
+				{
+					"job": {
+						"health": {
+							"healthy": false,
+							"violations": ["no successful run for 2h0m0s, above maxStaleness of 1h0m0s"],
+							"lastSuccessAt": "2021-01-01T00:00:00Z",
+							"lastTransmissionAt": null,
+							"runs": 4,
+							"errorRate": 0.25,
+							"p95Duration": "1.5s",
+							"evaluatedAt": "2021-01-01T02:00:00Z"
+						}
+					}
+				}
+			`,
+		},
+		{
+			name:          "no SLO",
+			authenticated: true,
+			before: func(f *gqlTestFramework) {
+				f.App.On("JobORM").Return(f.Mocks.jobORM)
+				f.Mocks.jobORM.On("FindJobWithoutSpecErrors", id).Return(job.Job{ID: id}, nil)
+				monitor := slomocks.NewMonitor(t)
+				monitor.On("JobHealth", id).Return(slo.Health{}, false)
+				f.App.On("JobSLOMonitor").Return(monitor)
+			},
+			query: query,
+			result: `
+				{
+					"job": {
+						"health": null
+					}
+				}
+			`,
+		},
+	}
+
+	RunGQLTests(t, testCases)
+}
+
 func TestResolver_CreateJob(t *testing.T) {
 	t.Parallel()
 
@@ -289,7 +379,7 @@ func TestResolver_CreateJob(t *testing.T) {
 			"TOML": "some wrong value",
 		},
 	}
-	jb, err := directrequest.ValidatedDirectRequestSpec(testspecs.DirectRequestSpec)
+	jb, err := directrequest.ValidatedDirectRequestSpec(nil, nil, testspecs.DirectRequestSpec)
 	assert.NoError(t, err)
 
 	d, err := json.Marshal(map[string]interface{}{
@@ -316,6 +406,9 @@ func TestResolver_CreateJob(t *testing.T) {
 			authenticated: true,
 			before: func(f *gqlTestFramework) {
 				f.App.On("GetConfig").Return(f.Mocks.cfg)
+				f.App.On("GetChains").Return(chainlink.Chains{EVM: f.Mocks.chainSet})
+				f.App.On("GetKeyStore").Return(f.Mocks.keystore)
+				f.Mocks.keystore.On("Eth").Return(f.Mocks.ethKs)
 				f.App.On("AddJobV2", mock.Anything, &jb).Return(nil)
 			},
 			query:     mutation,
@@ -343,6 +436,9 @@ func TestResolver_CreateJob(t *testing.T) {
 			authenticated: true,
 			before: func(f *gqlTestFramework) {
 				f.App.On("GetConfig").Return(f.Mocks.cfg)
+				f.App.On("GetChains").Return(chainlink.Chains{EVM: f.Mocks.chainSet})
+				f.App.On("GetKeyStore").Return(f.Mocks.keystore)
+				f.Mocks.keystore.On("Eth").Return(f.Mocks.ethKs)
 				f.App.On("AddJobV2", mock.Anything, &jb).Return(gError)
 			},
 			query:     mutation,
@@ -1,6 +1,7 @@
 package resolver
 
 import (
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/graph-gophers/graphql-go"
 
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
@@ -109,6 +110,16 @@ func (r *SpecResolver) ToBootstrapSpec() (*BootstrapSpecResolver, bool) {
 	return &BootstrapSpecResolver{spec: *r.j.BootstrapSpec}, true
 }
 
+// ToEVMLogSpec returns the EVMLogSpec from the SpecResolver if the job is an
+// EVMLog job.
+func (r *SpecResolver) ToEVMLogSpec() (*EVMLogSpecResolver, bool) {
+	if r.j.Type != job.EVMLog {
+		return nil, false
+	}
+
+	return &EVMLogSpecResolver{spec: *r.j.EVMLogSpec}, true
+}
+
 type CronSpecResolver struct {
 	spec job.CronSpec
 }
@@ -117,6 +128,21 @@ func (r *CronSpecResolver) Schedule() string {
 	return r.spec.CronSchedule
 }
 
+// Timezone resolves the spec's time zone.
+func (r *CronSpecResolver) Timezone() string {
+	return r.spec.Timezone
+}
+
+// MisfirePolicy resolves the spec's misfire policy.
+func (r *CronSpecResolver) MisfirePolicy() string {
+	return string(r.spec.MisfirePolicy)
+}
+
+// OverlapPolicy resolves the spec's overlap policy.
+func (r *CronSpecResolver) OverlapPolicy() string {
+	return string(r.spec.OverlapPolicy)
+}
+
 // CreatedAt resolves the spec's created at timestamp.
 func (r *CronSpecResolver) CreatedAt() graphql.Time {
 	return graphql.Time{Time: r.spec.CreatedAt}
@@ -177,6 +203,35 @@ func (r *DirectRequestSpecResolver) Requesters() *[]string {
 	return &requesters
 }
 
+// MaxRequestGasLimit resolves the highest gas limit a request may set.
+func (r *DirectRequestSpecResolver) MaxRequestGasLimit() *int32 {
+	if !r.spec.MaxRequestGasLimit.Valid {
+		return nil
+	}
+	maxGasLimit := int32(r.spec.MaxRequestGasLimit.Uint32)
+	return &maxGasLimit
+}
+
+// MaxRequestMinConfirmations resolves the highest number of confirmations a
+// request may wait for.
+func (r *DirectRequestSpecResolver) MaxRequestMinConfirmations() *int32 {
+	if !r.spec.MaxRequestMinConfirmations.Valid {
+		return nil
+	}
+	maxConfs := int32(r.spec.MaxRequestMinConfirmations.Uint32)
+	return &maxConfs
+}
+
+// RequestFromAddresses resolves the keys a request may choose to be fulfilled
+// from.
+func (r *DirectRequestSpecResolver) RequestFromAddresses() *[]string {
+	if r.spec.RequestFromAddresses == nil {
+		return nil
+	}
+	addresses := r.spec.RequestFromAddresses.ToStrings()
+	return &addresses
+}
+
 type FluxMonitorSpecResolver struct {
 	spec job.FluxMonitorSpec
 }
@@ -267,6 +322,33 @@ func (r *FluxMonitorSpecResolver) Threshold() float64 {
 	return float64(r.spec.Threshold)
 }
 
+// Feeds resolves the spec's feeds, which are empty for jobs watching a single
+// contract.
+func (r *FluxMonitorSpecResolver) Feeds() []*FluxMonitorFeedResolver {
+	resolvers := make([]*FluxMonitorFeedResolver, 0, len(r.spec.Feeds))
+	for _, feed := range r.spec.Feeds {
+		resolvers = append(resolvers, &FluxMonitorFeedResolver{feed: feed})
+	}
+	return resolvers
+}
+
+type FluxMonitorFeedResolver struct {
+	feed job.FluxMonitorFeed
+}
+
+// ContractAddress resolves the feed's contract address.
+func (r *FluxMonitorFeedResolver) ContractAddress() string {
+	return r.feed.ContractAddress.String()
+}
+
+// Params resolves the feed's pipeline params.
+func (r *FluxMonitorFeedResolver) Params() gqlscalar.Map {
+	if r.feed.Params == nil {
+		return gqlscalar.Map{}
+	}
+	return gqlscalar.Map(r.feed.Params)
+}
+
 type KeeperSpecResolver struct {
 	spec job.KeeperSpec
 }
@@ -726,6 +808,20 @@ type WebhookSpecResolver struct {
 	spec job.WebhookSpec
 }
 
+// HMACEnabled resolves whether requests triggering the job must be signed.
+func (r *WebhookSpecResolver) HMACEnabled() bool {
+	return r.spec.HMACSecret.Valid
+}
+
+// HMACTimestampTolerance resolves the timestamp tolerance of signed requests.
+func (r *WebhookSpecResolver) HMACTimestampTolerance() *string {
+	if !r.spec.HMACSecret.Valid {
+		return nil
+	}
+	tolerance := r.spec.HMACTimestampTolerance.Duration().String()
+	return &tolerance
+}
+
 // CreatedAt resolves the spec's created at timestamp.
 func (r *WebhookSpecResolver) CreatedAt() graphql.Time {
 	return graphql.Time{Time: r.spec.CreatedAt}
@@ -754,6 +850,15 @@ func (b *BlockhashStoreSpecResolver) CoordinatorV2Address() *string {
 	return &addr
 }
 
+// CoordinatorOCR2VRFAddress returns the address of the OCR2VRF Coordinator, if any.
+func (b *BlockhashStoreSpecResolver) CoordinatorOCR2VRFAddress() *string {
+	if b.spec.CoordinatorOCR2VRFAddress == nil {
+		return nil
+	}
+	addr := b.spec.CoordinatorOCR2VRFAddress.String()
+	return &addr
+}
+
 // WaitBlocks returns the job's WaitBlocks param.
 func (b *BlockhashStoreSpecResolver) WaitBlocks() int32 {
 	return b.spec.WaitBlocks
@@ -769,6 +874,25 @@ func (b *BlockhashStoreSpecResolver) BlockhashStoreAddress() string {
 	return b.spec.BlockhashStoreAddress.String()
 }
 
+// TrustedBlockhashStoreAddress returns the job's TrustedBlockhashStoreAddress param, if any.
+func (b *BlockhashStoreSpecResolver) TrustedBlockhashStoreAddress() *string {
+	if b.spec.TrustedBlockhashStoreAddress == nil {
+		return nil
+	}
+	addr := b.spec.TrustedBlockhashStoreAddress.String()
+	return &addr
+}
+
+// TrustedBlockhashStoreBatchSize returns the job's TrustedBlockhashStoreBatchSize param.
+func (b *BlockhashStoreSpecResolver) TrustedBlockhashStoreBatchSize() int32 {
+	return b.spec.TrustedBlockhashStoreBatchSize
+}
+
+// TrustedBlockhashStoreGasLimit returns the job's TrustedBlockhashStoreGasLimit param.
+func (b *BlockhashStoreSpecResolver) TrustedBlockhashStoreGasLimit() int32 {
+	return int32(b.spec.TrustedBlockhashStoreGasLimit)
+}
+
 // PollPeriod return's the job's PollPeriod param.
 func (b *BlockhashStoreSpecResolver) PollPeriod() string {
 	return b.spec.PollPeriod.String()
@@ -960,3 +1084,62 @@ func (r *BootstrapSpecResolver) ContractConfigConfirmations() *int32 {
 func (r *BootstrapSpecResolver) CreatedAt() graphql.Time {
 	return graphql.Time{Time: r.spec.CreatedAt}
 }
+
+// EVMLogSpecResolver exposes the job parameters for an EVMLogSpec.
+type EVMLogSpecResolver struct {
+	spec job.EVMLogSpec
+}
+
+// ContractAddress resolves the spec's contract address.
+func (r *EVMLogSpecResolver) ContractAddress() string {
+	return r.spec.ContractAddress.String()
+}
+
+// EventABI resolves the spec's event ABI.
+func (r *EVMLogSpecResolver) EventABI() string {
+	return r.spec.EventABI
+}
+
+// Topic1 resolves the spec's filter of the first indexed topic.
+func (r *EVMLogSpecResolver) Topic1() []string {
+	return hashesToStrings(r.spec.Topic1)
+}
+
+// Topic2 resolves the spec's filter of the second indexed topic.
+func (r *EVMLogSpecResolver) Topic2() []string {
+	return hashesToStrings(r.spec.Topic2)
+}
+
+// Topic3 resolves the spec's filter of the third indexed topic.
+func (r *EVMLogSpecResolver) Topic3() []string {
+	return hashesToStrings(r.spec.Topic3)
+}
+
+// MinConfirmations resolves the spec's min confirmations.
+func (r *EVMLogSpecResolver) MinConfirmations() int32 {
+	return int32(r.spec.MinConfirmations)
+}
+
+// EVMChainID resolves the spec's evm chain id.
+func (r *EVMLogSpecResolver) EVMChainID() *string {
+	if r.spec.EVMChainID == nil {
+		return nil
+	}
+
+	chainID := r.spec.EVMChainID.String()
+
+	return &chainID
+}
+
+// CreatedAt resolves the spec's created at timestamp.
+func (r *EVMLogSpecResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.spec.CreatedAt}
+}
+
+func hashesToStrings(hashes []common.Hash) []string {
+	strs := []string{}
+	for _, h := range hashes {
+		strs = append(strs, h.String())
+	}
+	return strs
+}
@@ -36,8 +36,11 @@ func TestResolver_CronSpec(t *testing.T) {
 				f.Mocks.jobORM.On("FindJobWithoutSpecErrors", id).Return(job.Job{
 					Type: job.Cron,
 					CronSpec: &job.CronSpec{
-						CronSchedule: "CRON_TZ=UTC 0 0 1 1 *",
-						CreatedAt:    f.Timestamp(),
+						CronSchedule:  "0 0 0 1 1 *",
+						Timezone:      "UTC",
+						MisfirePolicy: job.CronMisfireCatchUp,
+						OverlapPolicy: job.CronOverlapSkip,
+						CreatedAt:     f.Timestamp(),
 					},
 				}, nil)
 			},
@@ -49,6 +52,9 @@ func TestResolver_CronSpec(t *testing.T) {
 								__typename
 								... on CronSpec {
 									schedule
+									timezone
+									misfirePolicy
+									overlapPolicy
 									createdAt
 								}
 							}
@@ -61,7 +67,10 @@ func TestResolver_CronSpec(t *testing.T) {
 					"job": {
 						"spec": {
 							"__typename": "CronSpec",
-							"schedule": "CRON_TZ=UTC 0 0 1 1 *",
+							"schedule": "0 0 0 1 1 *",
+							"timezone": "UTC",
+							"misfirePolicy": "catchUp",
+							"overlapPolicy": "skip",
 							"createdAt": "2021-01-01T00:00:00Z"
 						}
 					}
@@ -97,6 +106,8 @@ func TestResolver_DirectRequestSpec(t *testing.T) {
 						MinIncomingConfirmationsEnv: true,
 						MinContractPayment:          assets.NewLinkFromJuels(1000),
 						Requesters:                  models.AddressCollection{requesterAddress},
+						MaxRequestGasLimit:          clnull.NewUint32(1000000, true),
+						RequestFromAddresses:        models.AddressCollection{requesterAddress},
 					},
 				}, nil)
 			},
@@ -114,6 +125,9 @@ func TestResolver_DirectRequestSpec(t *testing.T) {
 									minIncomingConfirmationsEnv
 									minContractPaymentLinkJuels
 									requesters
+									maxRequestGasLimit
+									maxRequestMinConfirmations
+									requestFromAddresses
 								}
 							}
 						}
@@ -131,7 +145,10 @@ func TestResolver_DirectRequestSpec(t *testing.T) {
 							"minIncomingConfirmations": 1,
 							"minIncomingConfirmationsEnv": true,
 							"minContractPaymentLinkJuels": "1000",
-							"requesters": ["0x3cCad4715152693fE3BC4460591e3D3Fbd071b42"]
+							"requesters": ["0x3cCad4715152693fE3BC4460591e3D3Fbd071b42"],
+							"maxRequestGasLimit": 1000000,
+							"maxRequestMinConfirmations": null,
+							"requestFromAddresses": ["0x3cCad4715152693fE3BC4460591e3D3Fbd071b42"]
 						}
 					}
 				}
@@ -286,6 +303,61 @@ func TestResolver_FluxMonitorSpec(t *testing.T) {
 				}
 			`,
 		},
+		{
+			name:          "flux monitor spec with feeds",
+			authenticated: true,
+			before: func(f *gqlTestFramework) {
+				f.App.On("JobORM").Return(f.Mocks.jobORM)
+				f.Mocks.jobORM.On("FindJobWithoutSpecErrors", id).Return(job.Job{
+					Type: job.FluxMonitor,
+					FluxMonitorSpec: &job.FluxMonitorSpec{
+						ContractAddress: contractAddress,
+						Feeds: job.FluxMonitorFeeds{
+							{ContractAddress: contractAddress, Params: map[string]interface{}{"from": "ETH", "to": "USD"}},
+							{ContractAddress: "0x3cCad4715152693fE3BC4460591e3D3Fbd071b42"},
+						},
+					},
+				}, nil)
+			},
+			query: `
+				query GetJob {
+					job(id: "1") {
+						... on Job {
+							spec {
+								__typename
+								... on FluxMonitorSpec {
+									contractAddress
+									feeds {
+										contractAddress
+										params
+									}
+								}
+							}
+						}
+					}
+				}
+			`,
+			result: `
+				{
+					"job": {
+						"spec": {
+							"__typename": "FluxMonitorSpec",
+							"contractAddress": "0x613a38AC1659769640aaE063C651F48E0250454C",
+							"feeds": [
+								{
+									"contractAddress": "0x613a38AC1659769640aaE063C651F48E0250454C",
+									"params": {"from": "ETH", "to": "USD"}
+								},
+								{
+									"contractAddress": "0x3cCad4715152693fE3BC4460591e3D3Fbd071b42",
+									"params": {}
+								}
+							]
+						}
+					}
+				}
+			`,
+		},
 	}
 
 	RunGQLTests(t, testCases)
@@ -699,7 +771,9 @@ func TestResolver_WebhookSpec(t *testing.T) {
 				f.Mocks.jobORM.On("FindJobWithoutSpecErrors", id).Return(job.Job{
 					Type: job.Webhook,
 					WebhookSpec: &job.WebhookSpec{
-						CreatedAt: f.Timestamp(),
+						HMACSecret:             null.StringFrom("0123456789abcdef0123456789abcdef"),
+						HMACTimestampTolerance: models.Interval(5 * time.Minute),
+						CreatedAt:              f.Timestamp(),
 					},
 				}, nil)
 			},
@@ -710,6 +784,8 @@ func TestResolver_WebhookSpec(t *testing.T) {
 							spec {
 								__typename
 								... on WebhookSpec {
+									hmacEnabled
+									hmacTimestampTolerance
 									createdAt
 								}
 							}
@@ -722,6 +798,8 @@ func TestResolver_WebhookSpec(t *testing.T) {
 					"job": {
 						"spec": {
 							"__typename": "WebhookSpec",
+							"hmacEnabled": true,
+							"hmacTimestampTolerance": "5m0s",
 							"createdAt": "2021-01-01T00:00:00Z"
 						}
 					}
@@ -743,6 +821,9 @@ func TestResolver_BlockhashStoreSpec(t *testing.T) {
 	coordinatorV2Address, err := ethkey.NewEIP55Address("0x2fcA960AF066cAc46085588a66dA2D614c7Cd337")
 	require.NoError(t, err)
 
+	coordinatorOCR2VRFAddress, err := ethkey.NewEIP55Address("0x2be990eE17832b59E0086534c5ea2459Aa75E38F")
+	require.NoError(t, err)
+
 	fromAddress1, err := ethkey.NewEIP55Address("0x3cCad4715152693fE3BC4460591e3D3Fbd071b42")
 	require.NoError(t, err)
 
@@ -752,6 +833,9 @@ func TestResolver_BlockhashStoreSpec(t *testing.T) {
 	blockhashStoreAddress, err := ethkey.NewEIP55Address("0xb26A6829D454336818477B946f03Fb21c9706f3A")
 	require.NoError(t, err)
 
+	trustedBlockhashStoreAddress, err := ethkey.NewEIP55Address("0x0ad9FE7a58216242a8475ca92F222b0640E26B63")
+	require.NoError(t, err)
+
 	testCases := []GQLTestCase{
 		{
 			name:          "blockhash store spec",
@@ -761,16 +845,20 @@ func TestResolver_BlockhashStoreSpec(t *testing.T) {
 				f.Mocks.jobORM.On("FindJobWithoutSpecErrors", id).Return(job.Job{
 					Type: job.BlockhashStore,
 					BlockhashStoreSpec: &job.BlockhashStoreSpec{
-						CoordinatorV1Address:  &coordinatorV1Address,
-						CoordinatorV2Address:  &coordinatorV2Address,
-						CreatedAt:             f.Timestamp(),
-						EVMChainID:            utils.NewBigI(42),
-						FromAddresses:         []ethkey.EIP55Address{fromAddress1, fromAddress2},
-						PollPeriod:            1 * time.Minute,
-						RunTimeout:            37 * time.Second,
-						WaitBlocks:            100,
-						LookbackBlocks:        200,
-						BlockhashStoreAddress: blockhashStoreAddress,
+						CoordinatorV1Address:           &coordinatorV1Address,
+						CoordinatorV2Address:           &coordinatorV2Address,
+						CoordinatorOCR2VRFAddress:      &coordinatorOCR2VRFAddress,
+						CreatedAt:                      f.Timestamp(),
+						EVMChainID:                     utils.NewBigI(42),
+						FromAddresses:                  []ethkey.EIP55Address{fromAddress1, fromAddress2},
+						PollPeriod:                     1 * time.Minute,
+						RunTimeout:                     37 * time.Second,
+						WaitBlocks:                     100,
+						LookbackBlocks:                 200,
+						BlockhashStoreAddress:          blockhashStoreAddress,
+						TrustedBlockhashStoreAddress:   &trustedBlockhashStoreAddress,
+						TrustedBlockhashStoreBatchSize: 20,
+						TrustedBlockhashStoreGasLimit:  2_500_000,
 					},
 				}, nil)
 			},
@@ -783,6 +871,7 @@ func TestResolver_BlockhashStoreSpec(t *testing.T) {
 								... on BlockhashStoreSpec {
 									coordinatorV1Address
 									coordinatorV2Address
+									coordinatorOCR2VRFAddress
 									createdAt
 									evmChainID
 									fromAddresses
@@ -791,6 +880,9 @@ func TestResolver_BlockhashStoreSpec(t *testing.T) {
 									waitBlocks
 									lookbackBlocks
 									blockhashStoreAddress
+									trustedBlockhashStoreAddress
+									trustedBlockhashStoreBatchSize
+									trustedBlockhashStoreGasLimit
 								}
 							}
 						}
@@ -804,6 +896,7 @@ func TestResolver_BlockhashStoreSpec(t *testing.T) {
 							"__typename": "BlockhashStoreSpec",
 							"coordinatorV1Address": "0x613a38AC1659769640aaE063C651F48E0250454C",
 							"coordinatorV2Address": "0x2fcA960AF066cAc46085588a66dA2D614c7Cd337",
+							"coordinatorOCR2VRFAddress": "0x2be990eE17832b59E0086534c5ea2459Aa75E38F",
 							"createdAt": "2021-01-01T00:00:00Z",
 							"evmChainID": "42",
 							"fromAddresses": ["0x3cCad4715152693fE3BC4460591e3D3Fbd071b42", "0xD479d7c994D298cA05bF270136ED9627b7E684D3"],
@@ -811,7 +904,10 @@ func TestResolver_BlockhashStoreSpec(t *testing.T) {
 							"runTimeout": "37s",
 							"waitBlocks": 100,
 							"lookbackBlocks": 200,
-							"blockhashStoreAddress": "0xb26A6829D454336818477B946f03Fb21c9706f3A"
+							"blockhashStoreAddress": "0xb26A6829D454336818477B946f03Fb21c9706f3A",
+							"trustedBlockhashStoreAddress": "0x0ad9FE7a58216242a8475ca92F222b0640E26B63",
+							"trustedBlockhashStoreBatchSize": 20,
+							"trustedBlockhashStoreGasLimit": 2500000
 						}
 					}
 				}
@@ -920,6 +1016,75 @@ func TestResolver_BlockHeaderFeederSpec(t *testing.T) {
 	RunGQLTests(t, testCases)
 }
 
+func TestResolver_EVMLogSpec(t *testing.T) {
+	var (
+		id = int32(1)
+	)
+	contractAddress, err := ethkey.NewEIP55Address("0x613a38AC1659769640aaE063C651F48E0250454C")
+	require.NoError(t, err)
+
+	testCases := []GQLTestCase{
+		{
+			name:          "evm log spec",
+			authenticated: true,
+			before: func(f *gqlTestFramework) {
+				f.App.On("JobORM").Return(f.Mocks.jobORM)
+				f.Mocks.jobORM.On("FindJobWithoutSpecErrors", id).Return(job.Job{
+					Type: job.EVMLog,
+					EVMLogSpec: &job.EVMLogSpec{
+						ContractAddress:  contractAddress,
+						EventABI:         "Transfer(address indexed from, address indexed to, uint256 value)",
+						Topic1:           []common.Hash{common.HexToHash("0x2a")},
+						MinConfirmations: 5,
+						EVMChainID:       utils.NewBigI(42),
+						CreatedAt:        f.Timestamp(),
+					},
+				}, nil)
+			},
+			query: `
+				query GetJob {
+					job(id: "1") {
+						... on Job {
+							spec {
+								__typename
+								... on EVMLogSpec {
+									contractAddress
+									eventABI
+									topic1
+									topic2
+									topic3
+									minConfirmations
+									evmChainID
+									createdAt
+								}
+							}
+						}
+					}
+				}
+			`,
+			result: `
+				{
+					"job": {
+						"spec": {
+							"__typename": "EVMLogSpec",
+							"contractAddress": "0x613a38AC1659769640aaE063C651F48E0250454C",
+							"eventABI": "Transfer(address indexed from, address indexed to, uint256 value)",
+							"topic1": ["0x000000000000000000000000000000000000000000000000000000000000002a"],
+							"topic2": [],
+							"topic3": [],
+							"minConfirmations": 5,
+							"evmChainID": "42",
+							"createdAt": "2021-01-01T00:00:00Z"
+						}
+					}
+				}
+			`,
+		},
+	}
+
+	RunGQLTests(t, testCases)
+}
+
 func TestResolver_BootstrapSpec(t *testing.T) {
 	var (
 		id = int32(1)
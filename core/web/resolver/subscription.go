@@ -0,0 +1,232 @@
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/pkg/errors"
+
+	evmtypes "github.com/smartcontractkit/chainlink/v2/core/chains/evm/types"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+	"github.com/smartcontractkit/chainlink/v2/core/utils/stringutils"
+	"github.com/smartcontractkit/chainlink/v2/core/web/loader"
+)
+
+// headSubscriptionBuffer is the number of heads buffered for a slow
+// subscriber before further heads are dropped.
+const headSubscriptionBuffer = 16
+
+// JobRunCreated streams the runs of a job as they are created.
+func (r *Resolver) JobRunCreated(ctx context.Context, args struct{ JobID graphql.ID }) (<-chan *JobRunResolver, error) {
+	if err := authenticateUser(ctx); err != nil {
+		return nil, err
+	}
+
+	id, err := stringutils.ToInt32(string(args.JobID))
+	if err != nil {
+		return nil, err
+	}
+	j, err := r.App.JobORM().FindJobWithoutSpecErrors(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscribeEvents(ctx, r.App, pg.ChannelPipelineRunCreated, func(ev pg.Event) (*JobRunResolver, error) {
+		var payload struct {
+			ID             int64 `json:"id"`
+			PipelineSpecID int32 `json:"pipelineSpecID"`
+		}
+		if err := json.Unmarshal([]byte(ev.Payload), &payload); err != nil {
+			return nil, err
+		}
+		if payload.PipelineSpecID != j.PipelineSpecID {
+			return nil, nil
+		}
+		run, err := r.App.JobORM().FindPipelineRunByID(payload.ID)
+		if err != nil {
+			return nil, err
+		}
+		return NewJobRun(run, r.App), nil
+	})
+}
+
+// TaskRunUpdated streams the task runs of a job run as they are created and
+// as they finish.
+func (r *Resolver) TaskRunUpdated(ctx context.Context, args struct{ JobRunID graphql.ID }) (<-chan *TaskRunResolver, error) {
+	if err := authenticateUser(ctx); err != nil {
+		return nil, err
+	}
+
+	runID, err := stringutils.ToInt64(string(args.JobRunID))
+	if err != nil {
+		return nil, err
+	}
+
+	return subscribeEvents(ctx, r.App, pg.ChannelPipelineTaskRunUpdated, func(ev pg.Event) (*TaskRunResolver, error) {
+		var payload struct {
+			ID            string `json:"id"`
+			PipelineRunID int64  `json:"pipelineRunID"`
+		}
+		if err := json.Unmarshal([]byte(ev.Payload), &payload); err != nil {
+			return nil, err
+		}
+		if payload.PipelineRunID != runID {
+			return nil, nil
+		}
+		run, err := r.App.JobORM().FindPipelineRunByID(runID)
+		if err != nil {
+			return nil, err
+		}
+		for _, tr := range run.PipelineTaskRuns {
+			if tr.ID.String() == payload.ID {
+				return NewTaskRun(tr), nil
+			}
+		}
+		return nil, errors.Errorf("task run %s not found in job run %d", payload.ID, runID)
+	})
+}
+
+// EthTransactionStateChanged streams eth transactions as they are created and
+// whenever their state changes, optionally limited to a single chain.
+func (r *Resolver) EthTransactionStateChanged(ctx context.Context, args struct{ ChainID *graphql.ID }) (<-chan *EthTransactionResolver, error) {
+	if err := authenticateUser(ctx); err != nil {
+		return nil, err
+	}
+
+	return subscribeEvents(ctx, r.App, pg.ChannelEthTxStateChanged, func(ev pg.Event) (*EthTransactionResolver, error) {
+		var payload struct {
+			ID         int64  `json:"id"`
+			EVMChainID string `json:"evmChainID"`
+		}
+		if err := json.Unmarshal([]byte(ev.Payload), &payload); err != nil {
+			return nil, err
+		}
+		if args.ChainID != nil && string(*args.ChainID) != payload.EVMChainID {
+			return nil, nil
+		}
+		tx, err := r.App.TxmStorageService().FindEthTxWithAttempts(payload.ID)
+		if err != nil {
+			return nil, err
+		}
+		return NewEthTransaction(tx), nil
+	})
+}
+
+// JobProposalUpdated streams job proposals as they are received from feeds
+// managers and whenever they change.
+func (r *Resolver) JobProposalUpdated(ctx context.Context) (<-chan *JobProposalResolver, error) {
+	if err := authenticateUser(ctx); err != nil {
+		return nil, err
+	}
+
+	return subscribeEvents(ctx, r.App, pg.ChannelJobProposalUpdated, func(ev pg.Event) (*JobProposalResolver, error) {
+		id, err := stringutils.ToInt64(ev.Payload)
+		if err != nil {
+			return nil, err
+		}
+		jp, err := r.App.GetFeedsService().GetJobProposal(id)
+		if err != nil {
+			return nil, err
+		}
+		return NewJobProposal(jp), nil
+	})
+}
+
+// NewHead streams the new heads of an EVM chain. Heads are dropped rather than
+// delaying the head tracker if the subscriber falls behind.
+func (r *Resolver) NewHead(ctx context.Context, args struct{ ChainID graphql.ID }) (<-chan *HeadResolver, error) {
+	if err := authenticateUser(ctx); err != nil {
+		return nil, err
+	}
+
+	id, ok := new(big.Int).SetString(string(args.ChainID), 10)
+	if !ok {
+		return nil, errors.Errorf("invalid chain id: %s", args.ChainID)
+	}
+	chain, err := r.App.GetChains().EVM.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := &headSubscriber{
+		ctx:  ctx,
+		lggr: r.App.GetLogger(),
+		ch:   make(chan *HeadResolver, headSubscriptionBuffer),
+	}
+	_, unsubscribe := chain.HeadBroadcaster().Subscribe(sub)
+	go func() {
+		<-ctx.Done()
+		unsubscribe()
+	}()
+
+	return sub.ch, nil
+}
+
+type headSubscriber struct {
+	ctx  context.Context
+	lggr logger.SugaredLogger
+	ch   chan *HeadResolver
+}
+
+// OnNewLongestChain implements httypes.HeadTrackable. The channel is never
+// closed, since callbacks may still be running after unsubscribing.
+func (s *headSubscriber) OnNewLongestChain(_ context.Context, head *evmtypes.Head) {
+	loader.Refresh(s.ctx)
+	select {
+	case s.ch <- NewHead(*head):
+	case <-s.ctx.Done():
+	default:
+		s.lggr.Warnw("GraphQL head subscriber is too slow, dropping head", "head", head.Number)
+	}
+}
+
+// subscribeEvents streams the resolvers built from events on a Postgres
+// channel until the context is done. resolve may return a nil resolver to
+// skip an event. Failing to resolve an event is logged and does not end the
+// subscription. The channels are only notified when
+// Feature.GraphQLSubscriptions is enabled.
+func subscribeEvents[R any](ctx context.Context, app chainlink.Application, channel string, resolve func(pg.Event) (*R, error)) (<-chan *R, error) {
+	if !app.GetConfig().FeatureGraphQLSubscriptions() {
+		return nil, errors.New("GraphQL subscriptions are disabled, set Feature.GraphQLSubscriptions to enable them")
+	}
+	sub, err := app.GetEventBroadcaster().Subscribe(channel, "")
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan *R)
+	go func() {
+		defer close(ch)
+		defer sub.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev, ok := <-sub.Events():
+				if !ok {
+					return
+				}
+				loader.Refresh(ctx)
+				res, err := resolve(ev)
+				if err != nil {
+					app.GetLogger().Warnw("Failed to resolve GraphQL subscription event", "channel", channel, "payload", ev.Payload, "err", err)
+					continue
+				}
+				if res == nil {
+					continue
+				}
+				select {
+				case ch <- res:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return ch, nil
+}
@@ -0,0 +1,160 @@
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	configtest2 "github.com/smartcontractkit/chainlink/v2/core/internal/testutils/configtest/v2"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/services/feeds"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pg"
+	pgmocks "github.com/smartcontractkit/chainlink/v2/core/services/pg/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+)
+
+// subscribe starts a subscription and returns its responses.
+func (f *gqlTestFramework) subscribe(ctx context.Context, query string) <-chan interface{} {
+	f.t.Helper()
+
+	responses, err := f.RootSchema.Subscribe(ctx, query, "", nil)
+	require.NoError(f.t, err)
+	return responses
+}
+
+// enableSubscriptions sets Feature.GraphQLSubscriptions.
+func (f *gqlTestFramework) enableSubscriptions(enabled bool) {
+	f.App.On("GetConfig").Return(configtest2.NewGeneralConfig(f.t, func(c *chainlink.Config, s *chainlink.Secrets) {
+		c.Feature.GraphQLSubscriptions = &enabled
+	}))
+}
+
+// mockEvents registers a subscription to channel on the event broadcaster and
+// returns the channel on which to send its events.
+func (f *gqlTestFramework) mockEvents(channel string) chan pg.Event {
+	f.t.Helper()
+
+	f.enableSubscriptions(true)
+	events := make(chan pg.Event)
+	sub := pgmocks.NewSubscription(f.t)
+	sub.On("Events").Return((<-chan pg.Event)(events))
+	sub.On("Close").Return().Maybe()
+	eb := pgmocks.NewEventBroadcaster(f.t)
+	eb.On("Subscribe", channel, "").Return(sub, nil)
+	f.App.On("GetEventBroadcaster").Return(eb)
+	f.App.On("GetLogger").Return(logger.TestLogger(f.t)).Maybe()
+	return events
+}
+
+func nextResponse(t *testing.T, responses <-chan interface{}) *graphql.Response {
+	t.Helper()
+
+	select {
+	case r := <-responses:
+		resp, ok := r.(*graphql.Response)
+		require.True(t, ok)
+		return resp
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for subscription response")
+		return nil
+	}
+}
+
+func TestResolver_JobProposalUpdated(t *testing.T) {
+	t.Parallel()
+
+	query := `
+		subscription {
+			jobProposalUpdated {
+				id
+				status
+			}
+		}`
+
+	t.Run("not authorized", func(t *testing.T) {
+		t.Parallel()
+
+		f := setupFramework(t)
+		resp := nextResponse(t, f.subscribe(f.Ctx, query))
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "Unauthorized", resp.Errors[0].Message)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		f := setupFramework(t)
+		f.injectAuthenticatedUser()
+		f.enableSubscriptions(false)
+		resp := nextResponse(t, f.subscribe(f.Ctx, query))
+		require.Len(t, resp.Errors, 1)
+		assert.Contains(t, resp.Errors[0].Message, "GraphQL subscriptions are disabled")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		f := setupFramework(t)
+		f.injectAuthenticatedUser()
+		events := f.mockEvents(pg.ChannelJobProposalUpdated)
+		f.App.On("GetFeedsService").Return(f.Mocks.feedsSvc)
+		f.Mocks.feedsSvc.On("GetJobProposal", int64(1)).Return(&feeds.JobProposal{
+			ID:     1,
+			Name:   null.StringFrom("job_proposal_1"),
+			Status: feeds.JobProposalStatusPending,
+		}, nil)
+
+		ctx, cancel := context.WithCancel(f.Ctx)
+		defer cancel()
+		responses := f.subscribe(ctx, query)
+
+		events <- pg.Event{Channel: pg.ChannelJobProposalUpdated, Payload: "1"}
+		resp := nextResponse(t, responses)
+		require.Empty(t, resp.Errors)
+		assert.JSONEq(t, `{"jobProposalUpdated": {"id": "1", "status": "PENDING"}}`, string(resp.Data))
+	})
+}
+
+func TestResolver_JobRunCreated(t *testing.T) {
+	t.Parallel()
+
+	query := `
+		subscription {
+			jobRunCreated(jobID: "1") {
+				id
+			}
+		}`
+
+	f := setupFramework(t)
+	f.injectAuthenticatedUser()
+	events := f.mockEvents(pg.ChannelPipelineRunCreated)
+	f.App.On("JobORM").Return(f.Mocks.jobORM)
+	f.Mocks.jobORM.On("FindJobWithoutSpecErrors", int32(1)).Return(job.Job{ID: 1, PipelineSpecID: 2}, nil)
+	f.Mocks.jobORM.On("FindPipelineRunByID", int64(6)).Return(pipeline.Run{ID: 6, PipelineSpecID: 2}, nil)
+
+	ctx, cancel := context.WithCancel(f.Ctx)
+	defer cancel()
+	responses := f.subscribe(ctx, query)
+
+	// runs of other jobs are skipped
+	for _, run := range []struct {
+		ID             int64 `json:"id"`
+		PipelineSpecID int32 `json:"pipelineSpecID"`
+	}{{5, 3}, {6, 2}} {
+		b, err := json.Marshal(run)
+		require.NoError(t, err)
+		events <- pg.Event{Channel: pg.ChannelPipelineRunCreated, Payload: string(b)}
+	}
+
+	resp := nextResponse(t, responses)
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"jobRunCreated": {"id": "6"}}`, string(resp.Data))
+}
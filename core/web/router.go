@@ -2,6 +2,7 @@ package web
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -37,6 +38,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/v2/core/web/auth"
+	"github.com/smartcontractkit/chainlink/v2/core/web/gqlws"
 	"github.com/smartcontractkit/chainlink/v2/core/web/loader"
 	"github.com/smartcontractkit/chainlink/v2/core/web/resolver"
 	"github.com/smartcontractkit/chainlink/v2/core/web/schema"
@@ -81,27 +83,38 @@ func NewRouter(app chainlink.Application, prometheus *ginprom.Prometheus) (*gin.
 
 	debugRoutes(app, api)
 	healthRoutes(app, api)
-	sessionRoutes(app, api)
+	sessionRoutes(app, api, secret)
 	v2Routes(app, api)
 	loopRoutes(app, api)
 
 	guiAssetRoutes(engine, config.DisableRateLimiting(), app.GetLogger())
 
+	gqlSchema := graphqlSchema(app)
 	api.POST("/query",
 		auth.AuthenticateGQL(app.SessionORM(), app.GetLogger().Named("GQLHandler")),
 		loader.Middleware(app),
-		graphqlHandler(app),
+		graphqlHandler(gqlSchema),
+	)
+	api.GET("/query",
+		auth.AuthenticateGQL(app.SessionORM(), app.GetLogger().Named("GQLHandler")),
+		graphqlWSHandler(app, gqlSchema),
 	)
 
 	return engine, nil
 }
 
-// Defining the Graphql handler
-func graphqlHandler(app chainlink.Application) gin.HandlerFunc {
+// graphqlSubscriptionTimeout bounds the time taken to resolve each event of a
+// subscription.
+const graphqlSubscriptionTimeout = 10 * time.Second
+
+// Defining the Graphql schema
+func graphqlSchema(app chainlink.Application) *graphql.Schema {
 	rootSchema := schema.MustGetRootSchema()
 
 	// Disable introspection and set a max query depth in production.
-	var schemaOpts []graphql.SchemaOpt
+	schemaOpts := []graphql.SchemaOpt{
+		graphql.SubscribeResolverTimeout(graphqlSubscriptionTimeout),
+	}
 
 	if !app.GetConfig().InfiniteDepthQueries() {
 		schemaOpts = append(schemaOpts,
@@ -109,13 +122,16 @@ func graphqlHandler(app chainlink.Application) gin.HandlerFunc {
 		)
 	}
 
-	schema := graphql.MustParseSchema(rootSchema,
+	return graphql.MustParseSchema(rootSchema,
 		&resolver.Resolver{
 			App: app,
 		},
 		schemaOpts...,
 	)
+}
 
+// Defining the Graphql handler
+func graphqlHandler(schema *graphql.Schema) gin.HandlerFunc {
 	h := relay.Handler{Schema: schema}
 
 	return func(c *gin.Context) {
@@ -123,6 +139,25 @@ func graphqlHandler(app chainlink.Application) gin.HandlerFunc {
 	}
 }
 
+// graphqlWSHandler serves GraphQL subscriptions over WebSocket. The Origin of
+// the upgrade request has already been checked against AllowOrigins by the
+// CORS middleware. Each operation gets its own dataloaders, since their cache
+// lives as long as the context.
+func graphqlWSHandler(app chainlink.Application, schema *graphql.Schema) gin.HandlerFunc {
+	h := gqlws.NewHandler(schema, app.GetLogger(), app.GetConfig().DefaultHTTPLimit(),
+		func(*http.Request) bool { return true },
+		func(ctx context.Context) context.Context { return loader.InjectDataloader(ctx, app) },
+	)
+
+	return func(c *gin.Context) {
+		if !gqlws.IsUpgradeRequest(c.Request) {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+		h.ServeHTTP(c.Writer, c.Request)
+	}
+}
+
 func rateLimiter(period time.Duration, limit int64) gin.HandlerFunc {
 	store := memory.NewStore()
 	rate := limiter.Rate{
@@ -203,7 +238,7 @@ func ginHandlerFromHTTP(h http.HandlerFunc) gin.HandlerFunc {
 	}
 }
 
-func sessionRoutes(app chainlink.Application, r *gin.RouterGroup) {
+func sessionRoutes(app chainlink.Application, r *gin.RouterGroup, secret []byte) {
 	config := app.GetConfig()
 	unauth := r.Group("/", rateLimiter(
 		config.UnAuthenticatedRateLimitPeriod().Duration(),
@@ -211,6 +246,11 @@ func sessionRoutes(app chainlink.Application, r *gin.RouterGroup) {
 	))
 	sc := NewSessionsController(app)
 	unauth.POST("/sessions", sc.Create)
+	if config.OIDCEnabled() {
+		oc := NewOIDCController(app, secret)
+		unauth.GET("/oidc/login", oc.Login)
+		unauth.GET("/oidc/callback", oc.Callback)
+	}
 	auth := r.Group("/", auth.Authenticate(app.SessionORM(), auth.AuthenticateBySession))
 	auth.DELETE("/sessions", sc.Destroy)
 }
@@ -238,7 +278,7 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 	authv2 := r.Group("/v2", auth.Authenticate(app.SessionORM(),
 		auth.AuthenticateByToken,
 		auth.AuthenticateBySession,
-	))
+	), auth.AuditAPITokenUse(app.GetAuditLogger()))
 	{
 		uc := UserController{app}
 		authv2.GET("/users", auth.RequiresAdminRole(uc.Index))
@@ -249,6 +289,11 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		authv2.POST("/user/token", uc.NewAPIToken)
 		authv2.POST("/user/token/delete", uc.DeleteAPIToken)
 
+		uatc := UserAPITokensController{app}
+		authv2.GET("/user/tokens", uatc.Index)
+		authv2.POST("/user/tokens", uatc.Create)
+		authv2.DELETE("/user/tokens/:name", uatc.Delete)
+
 		wa := NewWebAuthnController(app)
 		authv2.GET("/enroll_webauthn", wa.BeginRegistration)
 		authv2.POST("/enroll_webauthn", wa.FinishRegistration)
@@ -356,6 +401,10 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		authv2.POST("/keys/vrf/import", auth.RequiresAdminRole(vrfkc.Import))
 		authv2.POST("/keys/vrf/export/:keyID", auth.RequiresAdminRole(vrfkc.Export))
 
+		vprc := VRFPendingRequestsController{app}
+		authv2.GET("/vrf/pending_requests", vprc.Index)
+		authv2.POST("/vrf/pending_requests/retry", auth.RequiresRunRole(vprc.Retry))
+
 		jc := JobsController{app}
 		authv2.GET("/jobs", paginatedRequest(jc.Index))
 		authv2.GET("/jobs/:ID", jc.Show)
@@ -428,7 +477,7 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		auth.AuthenticateExternalInitiator,
 		auth.AuthenticateByToken,
 		auth.AuthenticateBySession,
-	))
+	), auth.AuditAPITokenUse(app.GetAuditLogger()))
 	userOrEI.GET("/ping", ping.Show)
 	userOrEI.POST("/jobs/:ID/runs", auth.RequiresRunRole(prc.Create))
 }
@@ -38,6 +38,12 @@ func (sc *SessionsController) Create(c *gin.Context) {
 		return
 	}
 
+	if sc.App.GetConfig().OIDCDisableLocalPasswords() {
+		sc.App.GetAuditLogger().Audit(audit.AuthLoginLocalDisabled, map[string]interface{}{"email": sr.Email})
+		jsonAPIError(c, http.StatusForbidden, errors.New("password login is disabled, log in with OIDC instead"))
+		return
+	}
+
 	// Does this user have 2FA enabled?
 	userWebAuthnTokens, err := sc.App.SessionORM().GetUserWebAuthn(sr.Email)
 	if err != nil {
@@ -0,0 +1,128 @@
+package web
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	clsession "github.com/smartcontractkit/chainlink/v2/core/sessions"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+	webauth "github.com/smartcontractkit/chainlink/v2/core/web/auth"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+// UserAPITokensController manages the named API tokens of the current Session's User.
+type UserAPITokensController struct {
+	App chainlink.Application
+}
+
+// Index lists the named API tokens of the current user.
+// Example:
+// "GET <application>/user/tokens"
+func (c *UserAPITokensController) Index(ctx *gin.Context) {
+	sessionUser, ok := webauth.GetAuthenticatedUser(ctx)
+	if !ok {
+		jsonAPIError(ctx, http.StatusInternalServerError, errors.New("failed to obtain current user from context"))
+		return
+	}
+	tokens, err := c.App.SessionORM().ListAPITokens(sessionUser.Email)
+	if err != nil {
+		jsonAPIError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(ctx, presenters.NewUserAPITokenResources(tokens), "user_api_tokens")
+}
+
+// Create generates a new named API token for the current user. The role of the
+// token defaults to the user's own role and may not exceed it.
+// Example:
+// "POST <application>/user/tokens"
+func (c *UserAPITokensController) Create(ctx *gin.Context) {
+	var request clsession.APITokenRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(ctx, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	if _, ok := webauth.GetAuthenticatedAPIToken(ctx); ok {
+		jsonAPIError(ctx, http.StatusForbidden, errors.New("named API tokens cannot be used to create API tokens"))
+		return
+	}
+	sessionUser, ok := webauth.GetAuthenticatedUser(ctx)
+	if !ok {
+		jsonAPIError(ctx, http.StatusInternalServerError, errors.New("failed to obtain current user from context"))
+		return
+	}
+	user, err := c.App.SessionORM().FindUser(sessionUser.Email)
+	if err != nil {
+		c.App.GetLogger().Errorf("failed to obtain current user record: %s", err)
+		jsonAPIError(ctx, http.StatusInternalServerError, errors.New("unable to create API token"))
+		return
+	}
+	// users provisioned through single sign-on have no local password to confirm
+	if user.HashedPassword != "" && !utils.CheckPasswordHash(request.Password, user.HashedPassword) {
+		c.App.GetAuditLogger().Audit(audit.APITokenCreateAttemptPasswordMismatch, map[string]interface{}{"user": user.Email, "name": request.Name})
+		jsonAPIError(ctx, http.StatusUnauthorized, errors.New("incorrect password"))
+		return
+	}
+
+	role := user.Role
+	if request.Role != "" {
+		role = clsession.UserRole(request.Role)
+	}
+	apiToken, token, err := clsession.NewAPIToken(user, request.Name, role, null.TimeFromPtr(request.ExpiresAt))
+	if err != nil {
+		jsonAPIError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	if err = c.App.SessionORM().CreateAPIToken(&apiToken); err != nil {
+		jsonAPIError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	c.App.GetAuditLogger().Audit(audit.APITokenCreated, map[string]interface{}{
+		"user":      user.Email,
+		"name":      apiToken.Name,
+		"role":      apiToken.Role,
+		"expiresAt": apiToken.ExpiresAt,
+	})
+	jsonAPIResponseWithStatus(ctx, presenters.NewCreatedUserAPITokenResource(apiToken, token.Secret), "user_api_token", http.StatusCreated)
+}
+
+// Delete revokes one of the current user's named API tokens.
+// Example:
+// "DELETE <application>/user/tokens/:name"
+func (c *UserAPITokensController) Delete(ctx *gin.Context) {
+	if _, ok := webauth.GetAuthenticatedAPIToken(ctx); ok {
+		jsonAPIError(ctx, http.StatusForbidden, errors.New("named API tokens cannot be used to delete API tokens"))
+		return
+	}
+	sessionUser, ok := webauth.GetAuthenticatedUser(ctx)
+	if !ok {
+		jsonAPIError(ctx, http.StatusInternalServerError, errors.New("failed to obtain current user from context"))
+		return
+	}
+	name := ctx.Param("name")
+	apiToken, err := c.App.SessionORM().DeleteAPIToken(sessionUser.Email, name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			jsonAPIError(ctx, http.StatusNotFound, errors.Errorf("API token %s not found", name))
+			return
+		}
+		jsonAPIError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.App.GetAuditLogger().Audit(audit.APITokenDeleted, map[string]interface{}{
+		"user":      sessionUser.Email,
+		"name":      apiToken.Name,
+		"role":      apiToken.Role,
+		"expiresAt": apiToken.ExpiresAt,
+	})
+	jsonAPIResponseWithStatus(ctx, nil, "user_api_token", http.StatusNoContent)
+}
@@ -0,0 +1,106 @@
+package web_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+	webauth "github.com/smartcontractkit/chainlink/v2/core/web/auth"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func TestUserAPITokensController_Lifecycle(t *testing.T) {
+	t.Parallel()
+
+	app := cltest.NewApplicationEVMDisabled(t)
+	require.NoError(t, app.Start(testutils.Context(t)))
+
+	client := app.NewHTTPClient(cltest.APIEmailAdmin)
+
+	create := func(t *testing.T, request sessions.APITokenRequest) *http.Response {
+		body, err := json.Marshal(request)
+		require.NoError(t, err)
+		resp, cleanup := client.Post("/v2/user/tokens", bytes.NewBuffer(body))
+		t.Cleanup(cleanup)
+		return resp
+	}
+
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	resp := create(t, sessions.APITokenRequest{Name: "ci", Role: "run", ExpiresAt: &expiresAt, Password: cltest.Password})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var created presenters.CreatedUserAPITokenResource
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &created))
+	assert.Equal(t, "ci", created.Name)
+	assert.Equal(t, sessions.UserRoleRun, created.Role)
+	assert.NotEmpty(t, created.AccessKey)
+	assert.NotEmpty(t, created.Secret)
+	require.NotNil(t, created.ExpiresAt)
+	assert.True(t, expiresAt.Equal(*created.ExpiresAt))
+
+	t.Run("wrong password", func(t *testing.T) {
+		resp := create(t, sessions.APITokenRequest{Name: "other", Password: "wrong-password"})
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		resp := create(t, sessions.APITokenRequest{Name: "ci", Password: cltest.Password})
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("token is scoped", func(t *testing.T) {
+		headers := map[string]string{webauth.APIKey: created.AccessKey, webauth.APISecret: created.Secret}
+
+		resp, cleanup := client.Get("/v2/user/tokens", headers)
+		defer cleanup()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		// run role may not list users
+		req, err := http.NewRequestWithContext(testutils.Context(t), http.MethodGet, app.Server.URL+"/v2/users", nil)
+		require.NoError(t, err)
+		req.Header.Set(webauth.APIKey, created.AccessKey)
+		req.Header.Set(webauth.APISecret, created.Secret)
+		resp, err = http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		// tokens may not delete tokens
+		req, err = http.NewRequestWithContext(testutils.Context(t), http.MethodDelete, app.Server.URL+"/v2/user/tokens/ci", nil)
+		require.NoError(t, err)
+		req.Header.Set(webauth.APIKey, created.AccessKey)
+		req.Header.Set(webauth.APISecret, created.Secret)
+		resp, err = http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		apiToken, err := app.SessionORM().FindAPIToken(created.AccessKey)
+		require.NoError(t, err)
+		assert.True(t, apiToken.LastUsed.Valid)
+	})
+
+	resp, cleanup := client.Get("/v2/user/tokens")
+	defer cleanup()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var tokens []presenters.UserAPITokenResource
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &tokens))
+	require.Len(t, tokens, 1)
+	assert.Equal(t, "ci", tokens[0].Name)
+	assert.NotNil(t, tokens[0].LastUsed)
+
+	resp, cleanup = client.Delete("/v2/user/tokens/ci")
+	defer cleanup()
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp, cleanup = client.Delete("/v2/user/tokens/ci")
+	defer cleanup()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
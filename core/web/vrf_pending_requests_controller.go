@@ -0,0 +1,112 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+// VRFPendingRequestsController exposes the unfulfilled requests of the
+// running VRF v2 jobs.
+type VRFPendingRequestsController struct {
+	App chainlink.Application
+}
+
+// Index lists the pending VRF v2 requests, optionally filtered by job and
+// subscription.
+// Example:
+//
+//	"<application>/v2/vrf/pending_requests?jobID=1&subID=2"
+func (vprc *VRFPendingRequestsController) Index(c *gin.Context) {
+	jobID, subID, err := parseVRFBacklogFilters(c)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	reqs, err := vprc.App.VRFBacklog().PendingRequests(jobID, subID)
+	if errors.Is(err, vrf.ErrNoListener) {
+		jsonAPIError(c, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponse(c, presenters.NewVRFPendingRequestResources(reqs), "vrf_pending_requests")
+}
+
+// Retry clears the retry backoff of the matching pending requests so that they
+// are processed right away, optionally filtered by job and subscription.
+// Example:
+//
+//	"<application>/v2/vrf/pending_requests/retry?jobID=1&subID=2"
+func (vprc *VRFPendingRequestsController) Retry(c *gin.Context) {
+	jobID, subID, err := parseVRFBacklogFilters(c)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	n, err := vprc.App.VRFBacklog().RetryNow(jobID, subID)
+	if errors.Is(err, vrf.ErrNoListener) {
+		jsonAPIError(c, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := VRFRetryResponse{
+		Message: fmt.Sprintf("Retrying %d pending requests", n),
+		Count:   n,
+	}
+	jsonAPIResponse(c, &response, "response")
+}
+
+func parseVRFBacklogFilters(c *gin.Context) (jobID *int32, subID *uint64, err error) {
+	if s := c.Query("jobID"); s != "" {
+		id, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "invalid 'jobID' query string param")
+		}
+		jobID = new(int32)
+		*jobID = int32(id)
+	}
+	if s := c.Query("subID"); s != "" {
+		id, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "invalid 'subID' query string param")
+		}
+		subID = &id
+	}
+	return jobID, subID, nil
+}
+
+type VRFRetryResponse struct {
+	Message string `json:"message"`
+	Count   int    `json:"count"`
+}
+
+// GetID returns the jsonapi ID.
+func (s VRFRetryResponse) GetID() string {
+	return "vrfRetryID"
+}
+
+// GetName returns the collection name for jsonapi.
+func (VRFRetryResponse) GetName() string {
+	return "vrf_retries"
+}
+
+// SetID is used to conform to the UnmarshallIdentifier interface for
+// deserializing from jsonapi documents.
+func (*VRFRetryResponse) SetID(string) error {
+	return nil
+}